@@ -8,16 +8,25 @@ import (
 	"os"
 	"os/signal"
 	"path/filepath"
+	"sync"
 	"syscall"
 
 	"github.com/joho/godotenv"
 	_ "github.com/lib/pq"
 
+	"service-chat/internal/bot"
 	"service-chat/internal/config"
 	"service-chat/internal/db"
+	"service-chat/internal/eventbus"
+	"service-chat/internal/filter"
 	"service-chat/internal/handler"
 	"service-chat/internal/logger"
+	"service-chat/internal/preview"
+	"service-chat/internal/ratelimit"
+	"service-chat/internal/realtime"
 	"service-chat/internal/service"
+	"service-chat/internal/storage"
+	"service-chat/internal/webhook"
 	"service-chat/server"
 )
 
@@ -73,14 +82,81 @@ func main() {
 	}
 	customLog.Info("Database initialization was successful")
 
+	// Инициализируем хранилище файлов для вложений сообщений
+	blobStore, errStore := storage.NewBlobStore(cfg.Attachments)
+	if errStore != nil {
+		customLog.Error("Failed to init blob store", logger.Err(errStore))
+		os.Exit(1)
+	}
+	customLog.Info("Blob store initialization was successful", slog.String("store", cfg.Attachments.Store))
+
+	// Собираем цепочку фильтров текста сообщений из конфига
+	filters, errFilters := filter.NewChainFromConfig(cfg.Filters)
+	if errFilters != nil {
+		customLog.Error("Failed to init message filters", logger.Err(errFilters))
+		os.Exit(1)
+	}
+
+	// Хаб websocket и SSE подключений, через него клиенты получают события своих чатов.
+	// События приходят в хаб через шину, чтобы их получили подключения ко всем экземплярам сервиса
+	hub := realtime.NewHub(cfg.Realtime)
+	bus, errBus := eventbus.NewBus(cfg, database, customLog)
+	if errBus != nil {
+		customLog.Error("Failed to init event bus", logger.Err(errBus))
+		os.Exit(1)
+	}
+	bus.Subscribe(hub)
+	customLog.Info("Event bus initialization was successful", slog.String("driver", cfg.EventBus.Driver))
+
 	// Собираем наши слои проекта
 	repos := db.NewDB(database)
-	services := service.NewService(repos)
-	handlers := handler.NewHandler(services)
+	services := service.NewService(repos, blobStore, preview.NewHTTPFetcher(cfg.Previews),
+		webhook.NewHTTPSender(cfg.Webhooks), bot.NewHTTPCaller(cfg.Commands), filters, bus, hub, cfg)
+
+	// Статусы пользователей других экземпляров сервиса приходят через шину
+	bus.Subscribe(services.Presence)
+
+	// Лимиты частоты запросов, в postgres они общие для всех экземпляров сервиса
+	limiter, errLimiter := ratelimit.NewLimiterFromConfig(cfg.RateLimit, database)
+	if errLimiter != nil {
+		customLog.Error("Failed to init rate limiter", logger.Err(errLimiter))
+		os.Exit(1)
+	}
+	handlers := handler.NewHandler(services).WithRateLimiter(limiter)
+
+	// Запускаем фоновую отправку отложенных сообщений, загрузку превью ссылок, получение событий шины,
+	// отправку вебхуков и уведомлений, останавливаем их при выходе из приложения
+	ctxWorkers, stopWorkers := context.WithCancel(context.Background())
+	var workers sync.WaitGroup
+	dispatcher := service.NewDispatcher(services.Scheduled, cfg.Scheduler, customLog)
+	workers.Add(5)
+	go func() {
+		defer workers.Done()
+		dispatcher.Run(ctxWorkers)
+	}()
+	go func() {
+		defer workers.Done()
+		services.Preview.Run(ctxWorkers, customLog)
+	}()
+	go func() {
+		defer workers.Done()
+		bus.Run(ctxWorkers, customLog)
+	}()
+	go func() {
+		defer workers.Done()
+		services.Webhook.Run(ctxWorkers, customLog)
+	}()
+	go func() {
+		defer workers.Done()
+		services.Notification.Run(ctxWorkers, customLog)
+	}()
 
 	// Инициализируем экземпляр сервера
 	srv := new(server.Server)
 
+	// websocket и SSE соединения сами не завершатся, поэтому закрываем их при остановке сервера
+	srv.RegisterOnShutDown(hub.Shutdown)
+
 	// Плавный выход из приложения, перестаём принимать все входящие запросы,
 	// при этом закончим обработку всех текущих запросов и операций в базе данных.
 	// Для этого запускаем сервер в горутине
@@ -107,9 +183,15 @@ func main() {
 
 	// Информируем о завершении работы приложения и выходим из него
 	customLog.Info("Server is shutting down")
-	if err := srv.ShutDown(context.Background()); err != nil {
+	ctxShutDown, cancelShutDown := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout)
+	if err := srv.ShutDown(ctxShutDown); err != nil {
 		customLog.Error("Failed to shutdown server", logger.Err(err))
 	}
+	cancelShutDown()
+
+	// Дожидаемся завершения фоновых задач, которые работают с бд
+	stopWorkers()
+	workers.Wait()
 
 	// Закрываем все соединения с бд
 	if err := database.Close(); err != nil {
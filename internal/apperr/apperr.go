@@ -0,0 +1,81 @@
+package apperr
+
+import "errors"
+
+// Коды ошибок, клиенты сервиса могут на них полагаться
+const (
+	// CodeValidation - неверные данные запроса
+	CodeValidation = "validation"
+	// CodeUnauthorized - пользователь не авторизован
+	CodeUnauthorized = "unauthorized"
+	// CodeForbidden - у пользователя нет прав на действие
+	CodeForbidden = "forbidden"
+	// CodeNotFound - объект не найден или недоступен пользователю
+	CodeNotFound = "not_found"
+	// CodeConflict - действие противоречит текущему состоянию, например объект уже существует
+	CodeConflict = "conflict"
+	// CodeRateLimited - превышен лимит частоты запросов
+	CodeRateLimited = "rate_limited"
+	// CodeUnavailable - внешняя система, например бот команды, не ответила
+	CodeUnavailable = "unavailable"
+	// CodeInternal - внутренняя ошибка, подробности клиенту не показываются
+	CodeInternal = "internal"
+)
+
+// Error - ошибка предметной области с кодом. Текст ошибки можно показать клиенту,
+// поэтому в него нельзя подставлять ошибки бд и других внутренних систем
+type Error struct {
+	Code    string
+	Message string
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// New - ошибка с кодом code
+func New(code, message string) *Error {
+	return &Error{Code: code, Message: message}
+}
+
+// Validation - неверные данные запроса
+func Validation(message string) *Error {
+	return New(CodeValidation, message)
+}
+
+// Unauthorized - пользователь не авторизован
+func Unauthorized(message string) *Error {
+	return New(CodeUnauthorized, message)
+}
+
+// Forbidden - у пользователя нет прав на действие
+func Forbidden(message string) *Error {
+	return New(CodeForbidden, message)
+}
+
+// NotFound - объект не найден или недоступен пользователю
+func NotFound(message string) *Error {
+	return New(CodeNotFound, message)
+}
+
+// Conflict - действие противоречит текущему состоянию
+func Conflict(message string) *Error {
+	return New(CodeConflict, message)
+}
+
+// As - достаём ошибку предметной области из цепочки ошибок
+func As(err error) (*Error, bool) {
+	var appErr *Error
+	if errors.As(err, &appErr) {
+		return appErr, true
+	}
+	return nil, false
+}
+
+// Code - код ошибки, у ошибок без кода - internal
+func Code(err error) string {
+	if appErr, ok := As(err); ok {
+		return appErr.Code
+	}
+	return CodeInternal
+}
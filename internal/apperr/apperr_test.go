@@ -0,0 +1,62 @@
+package apperr
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCode(t *testing.T) {
+	// Тестовая таблица с данными
+	testTable := []struct {
+		name   string
+		err    error
+		exCode string
+		exMsg  string
+	}{
+		{
+			name:   "Typed error",
+			err:    NotFound("Invalid chat_id"),
+			exCode: CodeNotFound,
+			exMsg:  "Invalid chat_id",
+		},
+		{
+			name:   "Wrapped typed error",
+			err:    fmt.Errorf("error path: db.GetChat, error: %w", Forbidden("Only chat admins can do this")),
+			exCode: CodeForbidden,
+			exMsg:  "Only chat admins can do this",
+		},
+		{
+			name:   "Custom code",
+			err:    New(CodeRateLimited, "Rate limit exceeded, retry later"),
+			exCode: CodeRateLimited,
+			exMsg:  "Rate limit exceeded, retry later",
+		},
+		{
+			name:   "Untyped error",
+			err:    errors.New("pq: connection refused"),
+			exCode: CodeInternal,
+		},
+		{
+			name:   "Nil",
+			exCode: CodeInternal,
+		},
+	}
+
+	// Итерируемся по нашей тестовой таблице
+	for _, tt := range testTable {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.exCode, Code(tt.err))
+
+			appErr, ok := As(tt.err)
+			if tt.exMsg == "" {
+				assert.False(t, ok)
+				return
+			}
+			assert.True(t, ok)
+			assert.Equal(t, tt.exMsg, appErr.Error())
+		})
+	}
+}
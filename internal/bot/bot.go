@@ -0,0 +1,57 @@
+package bot
+
+import (
+	"context"
+
+	"service-chat/internal/apperr"
+)
+
+// Заголовки запроса к внешнему боту, подпись считается так же, как у исходящих вебхуков
+const (
+	HeaderTimestamp = "X-Bot-Timestamp"
+	HeaderSignature = "X-Bot-Signature"
+)
+
+// Куда отправляется ответ бота
+const (
+	// ResponseEphemeral - ответ видит только вызвавший команду, используется по умолчанию
+	ResponseEphemeral = "ephemeral"
+	// ResponseInChannel - ответ отправляется в чат сообщением от имени бота
+	ResponseInChannel = "in_channel"
+)
+
+// ErrNoResponse - бот не ответил или ответил с ошибкой
+var ErrNoResponse = apperr.New(apperr.CodeUnavailable, "Bot did not respond, try again later")
+
+// Call - вызов команды, который отправляется боту
+type Call struct {
+	Command string `json:"command"`
+	// Text - текст после имени команды
+	Text   string `json:"text"`
+	ChatID int64  `json:"chat_id"`
+	UserID int64  `json:"user_id"`
+	// Timestamp - время вызова в unix секундах, оно же подписывается в заголовке
+	Timestamp int64 `json:"timestamp"`
+}
+
+// Request - запрос к боту: адрес, ключ подписи и вызов команды
+type Request struct {
+	URL    string
+	Secret string
+	Call   Call
+}
+
+// Reply - ответ бота на вызов команды
+type Reply struct {
+	Text string `json:"text"`
+	// Format - формат текста: plain (по умолчанию) или markdown
+	Format string `json:"format,omitempty"`
+	// ResponseType - ephemeral (по умолчанию) или in_channel
+	ResponseType string `json:"response_type,omitempty"`
+}
+
+// Caller - интерфейс вызова команды внешнего бота
+type Caller interface {
+	// Call - отправляем вызов команды и возвращаем ответ бота, пустой ответ - nil без ошибки
+	Call(ctx context.Context, req Request) (*Reply, error)
+}
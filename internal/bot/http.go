@@ -0,0 +1,90 @@
+package bot
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"service-chat/internal/config"
+	"service-chat/internal/safehttp"
+	"service-chat/internal/webhook"
+)
+
+const (
+	userAgent = "service-chat-bot/1.0"
+	// maxReplyBody - сколько байт ответа бота читаем, текст длиннее всё равно не пройдёт проверку длины сообщения
+	maxReplyBody = 64 << 10
+)
+
+// HTTPCaller - вызов команд POST запросом с подписанным json. Соединения только с публичными адресами,
+// редиректы не выполняются, иначе бот мог бы перенаправить запрос во внутреннюю сеть
+type HTTPCaller struct {
+	client *http.Client
+}
+
+// NewHTTPCaller - конструктор вызова команд внешних ботов
+func NewHTTPCaller(cfg config.Commands) *HTTPCaller {
+	return newHTTPCaller(cfg, false)
+}
+
+// newHTTPCaller - allowPrivate разрешает адреса внутренней сети, нужен только для тестов на локальном сервере
+func newHTTPCaller(cfg config.Commands, allowPrivate bool) *HTTPCaller {
+	return &HTTPCaller{client: safehttp.NewClient(cfg.Timeout, allowPrivate, safehttp.NoRedirect)}
+}
+
+// Call - отправляем подписанный вызов команды и разбираем ответ бота
+func (c *HTTPCaller) Call(ctx context.Context, in Request) (*Reply, error) {
+	const op = "bot.HTTPCaller.Call"
+
+	if err := webhook.ValidateURL(in.URL); err != nil {
+		return nil, fmt.Errorf("error path: %s, error: %w", op, err)
+	}
+
+	timestamp := in.Call.Timestamp
+	payload, err := json.Marshal(in.Call)
+	if err != nil {
+		return nil, fmt.Errorf("error path: %s, error: %w", op, err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, in.URL, bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("error path: %s, error: %w", op, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(HeaderSignature, webhook.Sign(in.Secret, timestamp, payload))
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error path: %s, error: %w", op, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxReplyBody))
+	if err != nil {
+		return nil, fmt.Errorf("error path: %s, error: %w", op, err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, fmt.Errorf("error path: %s, error: unexpected status %d", op, resp.StatusCode)
+	}
+
+	// Бот может ничего не отвечать, например если ответит позже через входящий вебхук
+	if len(bytes.TrimSpace(body)) == 0 {
+		return nil, nil
+	}
+
+	var reply Reply
+	if err = json.Unmarshal(body, &reply); err != nil {
+		return nil, fmt.Errorf("error path: %s, error: invalid reply: %w", op, err)
+	}
+	if reply.Format != "" && reply.Format != "plain" && reply.Format != "markdown" {
+		return nil, fmt.Errorf("error path: %s, error: invalid reply format %q", op, reply.Format)
+	}
+
+	return &reply, nil
+}
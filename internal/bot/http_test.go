@@ -0,0 +1,84 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"service-chat/internal/config"
+	"service-chat/internal/safehttp"
+	"service-chat/internal/webhook"
+)
+
+func TestHTTPCaller_Call(t *testing.T) {
+	var got *http.Request
+	var body []byte
+	mux := http.NewServeMux()
+	mux.HandleFunc("/reply", func(w http.ResponseWriter, r *http.Request) {
+		got = r
+		body, _ = io.ReadAll(r.Body)
+		_, _ = w.Write([]byte(`{"text":"Deploying *main*","format":"markdown","response_type":"in_channel"}`))
+	})
+	mux.HandleFunc("/empty", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	mux.HandleFunc("/invalid", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"text":"hi","format":"html"}`))
+	})
+	mux.HandleFunc("/fail", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "boom", http.StatusInternalServerError)
+	})
+	mux.HandleFunc("/redirect", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/reply", http.StatusFound)
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	caller := newHTTPCaller(config.Commands{Timeout: 500 * time.Millisecond}, true)
+	req := Request{
+		Secret: "cmdsec_test",
+		Call:   Call{Command: "deploy", Text: "main", ChatID: 2, UserID: 1, Timestamp: 1700000000},
+	}
+
+	// Вызов подписан ключом команды, ответ бота разбирается
+	req.URL = srv.URL + "/reply"
+	reply, err := caller.Call(context.Background(), req)
+	assert.NoError(t, err)
+	assert.Equal(t, &Reply{Text: "Deploying *main*", Format: "markdown", ResponseType: ResponseInChannel}, reply)
+	assert.Equal(t, `{"command":"deploy","text":"main","chat_id":2,"user_id":1,"timestamp":1700000000}`, string(body))
+	assert.Equal(t, "1700000000", got.Header.Get(HeaderTimestamp))
+	assert.Equal(t, webhook.Sign("cmdsec_test", 1700000000, body), got.Header.Get(HeaderSignature))
+
+	// Пустой ответ - бот ничего не отвечает
+	req.URL = srv.URL + "/empty"
+	reply, err = caller.Call(context.Background(), req)
+	assert.NoError(t, err)
+	assert.Nil(t, reply)
+
+	for _, path := range []string{"/invalid", "/fail", "/redirect"} {
+		req.URL = srv.URL + path
+		_, err = caller.Call(context.Background(), req)
+		assert.Error(t, err, path)
+	}
+}
+
+func TestHTTPCaller_SSRF(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"text":"hi"}`))
+	}))
+	defer srv.Close()
+
+	// Вызов без разрешения внутренней сети не должен доходить до локального сервера
+	caller := NewHTTPCaller(config.Commands{Timeout: 500 * time.Millisecond})
+	_, err := caller.Call(context.Background(), Request{URL: srv.URL})
+	assert.True(t, errors.Is(err, safehttp.ErrForbiddenAddress), "error %v", err)
+
+	_, err = caller.Call(context.Background(), Request{URL: "ftp://example.com/"})
+	assert.ErrorIs(t, err, webhook.ErrInvalidURL)
+}
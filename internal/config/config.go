@@ -14,9 +14,23 @@ type Config struct {
 	// Тег yaml:"env" определяет какое имя будет у параметра Env в yaml файле если мы оттуда будем считывать данные
 	// env-default:"local" - окружение по умолчанию
 	// yaml:"connections" - кол-во одновременных подключений к базе данных задано в local.yaml
-	Env      string   `yaml:"env" env-default:"local" env-description:"Environment"`
-	Database Database `yaml:"database"`
-	Server   Server   `yaml:"server"`
+	Env           string        `yaml:"env" env-default:"local" env-description:"Environment"`
+	Database      Database      `yaml:"database"`
+	Server        Server        `yaml:"server"`
+	Attachments   Attachments   `yaml:"attachments"`
+	Scheduler     Scheduler     `yaml:"scheduler"`
+	Messages      Messages      `yaml:"messages"`
+	Previews      Previews      `yaml:"previews"`
+	Realtime      Realtime      `yaml:"realtime"`
+	EventBus      EventBus      `yaml:"eventBus"`
+	Presence      Presence      `yaml:"presence"`
+	Webhooks      Webhooks      `yaml:"webhooks"`
+	Incoming      Incoming      `yaml:"incomingWebhooks"`
+	Commands      Commands      `yaml:"commands"`
+	Notifications Notifications `yaml:"notifications"`
+	Moderation    Moderation    `yaml:"moderation"`
+	Filters       Filters       `yaml:"filters"`
+	RateLimit     RateLimit     `yaml:"rateLimit"`
 }
 
 // Database - структура конфига базы данных
@@ -36,6 +50,230 @@ type Server struct {
 	Port        string        `yaml:"port"`
 	Timeout     time.Duration `yaml:"timeout" env-default:"5s"`
 	IdleTimeout time.Duration `yaml:"idleTimeout" env-default:"60s"`
+	// ShutdownTimeout - сколько ждём завершения текущих запросов и соединений при остановке
+	ShutdownTimeout time.Duration `yaml:"shutdownTimeout" env-default:"10s"`
+}
+
+// Attachments - структура конфига вложений сообщений
+type Attachments struct {
+	// Store - тип хранилища файлов: local или s3
+	Store    string `yaml:"store" env-default:"local"`
+	LocalDir string `yaml:"localDir" env-default:"./data/attachments"`
+	// MaxSize - максимальный размер одного файла в байтах
+	MaxSize      int64    `yaml:"maxSize" env-default:"10485760"`
+	AllowedTypes []string `yaml:"allowedTypes" env-default:"image/png,image/jpeg,image/gif,application/pdf,text/plain"`
+	// ThumbnailSize - максимальная сторона миниатюры изображения в пикселях
+	ThumbnailSize int `yaml:"thumbnailSize" env-default:"256"`
+	S3            S3  `yaml:"s3"`
+}
+
+// S3 - структура конфига S3-совместимого хранилища, секретный ключ берётся из .env
+type S3 struct {
+	Endpoint  string        `yaml:"endpoint"`
+	Region    string        `yaml:"region" env-default:"us-east-1"`
+	Bucket    string        `yaml:"bucket"`
+	AccessKey string        `yaml:"accessKey"`
+	SecretKey string        `yaml:"-"`
+	Timeout   time.Duration `yaml:"timeout" env-default:"30s"`
+}
+
+// Scheduler - структура конфига отправки отложенных сообщений
+type Scheduler struct {
+	// Interval - как часто проверяем, не пора ли отправить отложенные сообщения
+	Interval time.Duration `yaml:"interval" env-default:"5s"`
+	// BatchSize - сколько сообщений отправляем за одну проверку
+	BatchSize int `yaml:"batchSize" env-default:"100"`
+	// MaxDelay - насколько далеко в будущее можно запланировать сообщение
+	MaxDelay time.Duration `yaml:"maxDelay" env-default:"8760h"`
+	// ClaimTimeout - через сколько забранное, но так и не отправленное сообщение снова уходит на отправку
+	ClaimTimeout time.Duration `yaml:"claimTimeout" env-default:"1m"`
+}
+
+// Messages - структура конфига сообщений
+type Messages struct {
+	// MaxTextLength - максимальная длина текста сообщения в символах
+	MaxTextLength int `yaml:"maxTextLength" env-default:"4096"`
+	// HideBlocked - не показывать пользователю сообщения тех, кого он внёс в чёрный список, в общих чатах
+	HideBlocked bool `yaml:"hideBlocked" env-default:"true"`
+}
+
+// Filters - структура конфига фильтров текста сообщений. Действие фильтра: off, reject, mask или flag,
+// администратор чата может задать для своего чата другое действие
+type Filters struct {
+	// DenyList - регулярные выражения запрещённых слов, регистр не учитывается
+	DenyList       []string `yaml:"denyList"`
+	DenyListAction string   `yaml:"denyListAction" env-default:"mask"`
+	// SecretsAction - действие при ключах доступа, токенах и паролях в тексте
+	SecretsAction string `yaml:"secretsAction" env-default:"reject"`
+	// MaxLinks - сколько ссылок можно отправить в одном сообщении
+	MaxLinks    int    `yaml:"maxLinks" env-default:"10"`
+	LinksAction string `yaml:"linksAction" env-default:"reject"`
+}
+
+// Previews - структура конфига превью ссылок в сообщениях
+type Previews struct {
+	// Disabled - отключить загрузку превью
+	Disabled bool `yaml:"disabled"`
+	// Workers - сколько ссылок загружаем одновременно
+	Workers int `yaml:"workers" env-default:"2"`
+	// QueueSize - размер очереди сообщений на загрузку превью, при переполнении превью не загружаются
+	QueueSize int `yaml:"queueSize" env-default:"100"`
+	// MaxURLs - сколько ссылок из одного сообщения получают превью
+	MaxURLs int `yaml:"maxURLs" env-default:"3"`
+	// Timeout - время на загрузку одной страницы вместе с редиректами
+	Timeout time.Duration `yaml:"timeout" env-default:"5s"`
+	// MaxBodySize - сколько байт страницы читаем в поисках метатегов
+	MaxBodySize int64 `yaml:"maxBodySize" env-default:"1048576"`
+	// CacheTTL - сколько времени превью адреса считается актуальным
+	CacheTTL time.Duration `yaml:"cacheTTL" env-default:"24h"`
+}
+
+// Realtime - структура конфига доставки событий через websocket и server-sent events
+type Realtime struct {
+	// SendBuffer - сколько событий ждут отправки в одно подключение, при переполнении клиент отключается
+	SendBuffer int `yaml:"sendBuffer" env-default:"64"`
+	// PingInterval - как часто отправляем клиенту проверку соединения
+	PingInterval time.Duration `yaml:"pingInterval" env-default:"30s"`
+	// PongTimeout - сколько ждём любого сообщения от клиента, прежде чем закрыть соединение
+	PongTimeout time.Duration `yaml:"pongTimeout" env-default:"75s"`
+	// WriteTimeout - время на отправку одного события
+	WriteTimeout time.Duration `yaml:"writeTimeout" env-default:"10s"`
+	// LogSize - сколько последних событий пользователя храним для продолжения потока по Last-Event-ID
+	LogSize int `yaml:"logSize" env-default:"256"`
+	// LogTTL - сколько храним журнал пользователя после отключения его последнего подключения
+	LogTTL time.Duration `yaml:"logTTL" env-default:"10m"`
+}
+
+// EventBus - структура конфига шины событий между экземплярами сервиса
+type EventBus struct {
+	// Driver - local: события получают только подключения этого экземпляра,
+	// postgres: события всех экземпляров доставляются через LISTEN/NOTIFY
+	Driver string `yaml:"driver" env-default:"local"`
+	// Channel - канал NOTIFY, общий для всех экземпляров
+	Channel string `yaml:"channel" env-default:"chat_events"`
+	// MinReconnect, MaxReconnect - границы паузы перед повторным подключением слушателя к бд
+	MinReconnect time.Duration `yaml:"minReconnect" env-default:"1s"`
+	MaxReconnect time.Duration `yaml:"maxReconnect" env-default:"1m"`
+}
+
+// Presence - структура конфига статусов пользователей в сети
+type Presence struct {
+	// TypingTTL - сколько показываем, что пользователь печатает, если клиент не продлил индикатор
+	TypingTTL time.Duration `yaml:"typingTTL" env-default:"5s"`
+}
+
+// Webhooks - структура конфига исходящих вебхуков чатов
+type Webhooks struct {
+	// Disabled - отключить отправку вебхуков, события копятся в очереди и будут отправлены после включения
+	Disabled bool `yaml:"disabled"`
+	// MaxPerChat - сколько вебхуков можно создать в одном чате
+	MaxPerChat int `yaml:"maxPerChat" env-default:"10"`
+	// Interval - как часто проверяем очередь событий на отправку
+	Interval time.Duration `yaml:"interval" env-default:"2s"`
+	// BatchSize - сколько событий отправляем за одну проверку
+	BatchSize int `yaml:"batchSize" env-default:"50"`
+	// Timeout - время на один запрос к адресу вебхука
+	Timeout time.Duration `yaml:"timeout" env-default:"10s"`
+	// MaxAttempts - после стольких неудачных попыток событие больше не отправляется и остаётся в статусе dead
+	MaxAttempts int `yaml:"maxAttempts" env-default:"8"`
+	// MinBackoff, MaxBackoff - пауза перед повторной отправкой удваивается после каждой неудачи в этих границах
+	MinBackoff time.Duration `yaml:"minBackoff" env-default:"10s"`
+	MaxBackoff time.Duration `yaml:"maxBackoff" env-default:"1h"`
+}
+
+// Incoming - структура конфига входящих вебхуков, через которые внешние системы пишут в чат
+type Incoming struct {
+	// MaxPerChat - сколько входящих вебхуков можно создать в одном чате
+	MaxPerChat int `yaml:"maxPerChat" env-default:"10"`
+	// RatePerMinute - сколько сообщений в минуту принимает один вебхук
+	RatePerMinute int `yaml:"ratePerMinute" env-default:"30"`
+	// Burst - сколько сообщений подряд вебхук может отправить сверх равномерного темпа
+	Burst int `yaml:"burst" env-default:"10"`
+}
+
+// Commands - структура конфига команд чатов
+type Commands struct {
+	// MaxPerChat - сколько команд внешних ботов можно создать в одном чате
+	MaxPerChat int `yaml:"maxPerChat" env-default:"20"`
+	// Timeout - сколько ждём ответа внешнего бота на вызов команды
+	Timeout time.Duration `yaml:"timeout" env-default:"3s"`
+}
+
+// Moderation - структура конфига жалоб и модерации
+type Moderation struct {
+	// MaxSuspension - на какой самый долгий срок модератор может отстранить пользователя
+	MaxSuspension time.Duration `yaml:"maxSuspension" env-default:"8760h"`
+}
+
+// RateLimit - структура конфига ограничения частоты запросов. Лимит группы маршрутов - корзина токенов:
+// burst запросов подряд, дальше requests запросов за period. Requests 0 снимает ограничение с группы
+type RateLimit struct {
+	Disabled bool `yaml:"disabled"`
+	// Store - memory: лимиты считает каждый экземпляр сервиса, postgres: лимиты общие для всех экземпляров
+	Store string `yaml:"store" env-default:"memory"`
+	// TrustProxy - брать IP клиента из X-Real-IP или последнего адреса X-Forwarded-For, включать только за своим прокси
+	TrustProxy bool `yaml:"trustProxy"`
+	// Auth - лимит регистрации и входа по IP клиента
+	Auth RateRule `yaml:"auth"`
+	// API - лимит всех маршрутов с авторизацией по пользователю
+	API RateRule `yaml:"api"`
+	// Messages - дополнительный лимит маршрутов /messages по пользователю. Запрос к /messages забирает токен
+	// и из api, и из messages: api ограничивает все запросы пользователя, messages - отдельно отправку сообщений
+	Messages RateRule `yaml:"messages"`
+}
+
+// RateRule - лимит запросов группы маршрутов
+type RateRule struct {
+	Requests int           `yaml:"requests"`
+	Period   time.Duration `yaml:"period"`
+	// Burst - сколько запросов можно сделать подряд, если не задан - равен requests
+	Burst int `yaml:"burst"`
+}
+
+// Notifications - структура конфига уведомлений о сообщениях для пользователей не в сети
+type Notifications struct {
+	// Disabled - отключить уведомления, новые уведомления не создаются
+	Disabled bool `yaml:"disabled"`
+	// QueueSize - сколько новых сообщений ждут выбора получателей уведомлений, при переполнении уведомления не создаются
+	QueueSize int `yaml:"queueSize" env-default:"100"`
+	// Interval - как часто проверяем очередь уведомлений на отправку
+	Interval time.Duration `yaml:"interval" env-default:"10s"`
+	// BatchSize - скольким пользователям отправляем уведомления за одну проверку
+	BatchSize int `yaml:"batchSize" env-default:"50"`
+	// MaxItems - сколько сообщений показываем в одной сводке, об остальных сообщаем числом
+	MaxItems int `yaml:"maxItems" env-default:"20"`
+	// MaxAttempts - после стольких неудачных попыток уведомление больше не отправляется
+	MaxAttempts int `yaml:"maxAttempts" env-default:"5"`
+	// MinBackoff, MaxBackoff - пауза перед повторной отправкой удваивается после каждой неудачи в этих границах
+	MinBackoff time.Duration `yaml:"minBackoff" env-default:"1m"`
+	MaxBackoff time.Duration `yaml:"maxBackoff" env-default:"1h"`
+	Email      Email         `yaml:"email"`
+	Push       Push          `yaml:"push"`
+}
+
+// Email - структура конфига уведомлений по почте через SMTP сервер
+type Email struct {
+	// Host - адрес SMTP сервера, если не задан - письма не отправляются
+	Host     string `yaml:"host"`
+	Port     string `yaml:"port" env-default:"587"`
+	Username string `yaml:"username"`
+	Password string `yaml:"-"`
+	// From - адрес отправителя писем
+	From    string        `yaml:"from"`
+	Timeout time.Duration `yaml:"timeout" env-default:"10s"`
+	// DigestInterval - сообщения копятся и уходят одним письмом не чаще этого интервала, упоминания отправляются сразу
+	DigestInterval time.Duration `yaml:"digestInterval" env-default:"15m"`
+}
+
+// Push - структура конфига push уведомлений через HTTP запрос к провайдеру
+type Push struct {
+	// URL - адрес провайдера push уведомлений, если не задан - push уведомления не отправляются
+	URL string `yaml:"url"`
+	// Secret - ключ подписи запросов к провайдеру
+	Secret  string        `yaml:"-"`
+	Timeout time.Duration `yaml:"timeout" env-default:"5s"`
+	// DigestInterval - сообщения копятся и уходят одним уведомлением не чаще этого интервала, упоминания отправляются сразу
+	DigestInterval time.Duration `yaml:"digestInterval" env-default:"0s"`
 }
 
 // MustSetEnv - функция, которая прочитает файл с конфигом и создаст и заполнит объект Config
@@ -61,5 +299,12 @@ func MustSetEnv(configPath string) (*Config, error) {
 	}
 	cfg.Database.Password = password
 
+	// Достаём секретный ключ S3 хранилища из .env
+	cfg.Attachments.S3.SecretKey = os.Getenv("S3_SECRET_KEY")
+
+	// Достаём пароль SMTP сервера и ключ подписи push провайдера из .env
+	cfg.Notifications.Email.Password = os.Getenv("SMTP_PASSWORD")
+	cfg.Notifications.Push.Secret = os.Getenv("PUSH_SECRET")
+
 	return &cfg, nil
 }
@@ -28,10 +28,114 @@ func TestMustSetEnv_OK(t *testing.T) {
 			SSLMode:     "disable",
 		},
 		Server: Server{
-			Host:        "localhost",
-			Port:        "9000",
+			Host:            "localhost",
+			Port:            "9000",
+			Timeout:         time.Second * 5,
+			IdleTimeout:     time.Second * 60,
+			ShutdownTimeout: time.Second * 10,
+		},
+		Attachments: Attachments{
+			Store:         "local",
+			LocalDir:      "./data/attachments",
+			MaxSize:       10485760,
+			AllowedTypes:  []string{"image/png", "image/jpeg"},
+			ThumbnailSize: 256,
+			S3: S3{
+				Endpoint:  "http://localhost:9002",
+				Region:    "us-east-1",
+				Bucket:    "service-chat",
+				AccessKey: "service-chat",
+				Timeout:   time.Second * 30,
+			},
+		},
+		Scheduler: Scheduler{
+			Interval:     time.Second * 5,
+			BatchSize:    100,
+			MaxDelay:     time.Hour * 8760,
+			ClaimTimeout: time.Minute,
+		},
+		Messages: Messages{
+			MaxTextLength: 4096,
+			HideBlocked:   true,
+		},
+		Previews: Previews{
+			Workers:     2,
+			QueueSize:   100,
+			MaxURLs:     3,
 			Timeout:     time.Second * 5,
-			IdleTimeout: time.Second * 60,
+			MaxBodySize: 1048576,
+			CacheTTL:    time.Hour * 24,
+		},
+		Realtime: Realtime{
+			SendBuffer:   64,
+			PingInterval: time.Second * 30,
+			PongTimeout:  time.Second * 75,
+			WriteTimeout: time.Second * 10,
+			LogSize:      256,
+			LogTTL:       time.Minute * 10,
+		},
+		EventBus: EventBus{
+			Driver:       "local",
+			Channel:      "chat_events",
+			MinReconnect: time.Second,
+			MaxReconnect: time.Minute,
+		},
+		Presence: Presence{
+			TypingTTL: time.Second * 5,
+		},
+		Webhooks: Webhooks{
+			MaxPerChat:  10,
+			Interval:    time.Second * 2,
+			BatchSize:   50,
+			Timeout:     time.Second * 10,
+			MaxAttempts: 8,
+			MinBackoff:  time.Second * 10,
+			MaxBackoff:  time.Hour,
+		},
+		Incoming: Incoming{
+			MaxPerChat:    10,
+			RatePerMinute: 30,
+			Burst:         10,
+		},
+		Commands: Commands{
+			MaxPerChat: 20,
+			Timeout:    3 * time.Second,
+		},
+		Notifications: Notifications{
+			QueueSize:   100,
+			Interval:    10 * time.Second,
+			BatchSize:   50,
+			MaxItems:    20,
+			MaxAttempts: 5,
+			MinBackoff:  time.Minute,
+			MaxBackoff:  time.Hour,
+			Email: Email{
+				Host:           "localhost",
+				Port:           "1025",
+				From:           "chat@example.com",
+				Timeout:        10 * time.Second,
+				DigestInterval: 15 * time.Minute,
+			},
+			Push: Push{
+				URL:     "http://localhost:9100/push",
+				Timeout: 5 * time.Second,
+			},
+		},
+		Moderation: Moderation{
+			MaxSuspension: 720 * time.Hour,
+		},
+		Filters: Filters{
+			DenyList:       []string{`\bspam\b`},
+			DenyListAction: "mask",
+			SecretsAction:  "reject",
+			MaxLinks:       5,
+			LinksAction:    "flag",
+		},
+		RateLimit: RateLimit{
+			Store:    "postgres",
+			Auth:     RateRule{Requests: 10, Period: time.Minute, Burst: 5},
+			API:      RateRule{Requests: 600, Period: time.Minute},
+			Messages: RateRule{Requests: 30, Period: 10 * time.Second, Burst: 10},
 		},
 	}
 
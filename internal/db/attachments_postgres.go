@@ -0,0 +1,135 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/lib/pq"
+
+	"service-chat/internal/apperr"
+	"service-chat/internal/db/entity"
+)
+
+const (
+	opAttachmentAdd  = "db.AddAttachment"
+	opAttachmentGet  = "db.GetAttachment"
+	opAttachmentLoad = "db.loadAttachments"
+)
+
+type AttachmentPostgres struct {
+	db *sql.DB
+}
+
+func NewAttachmentPostgres(db *sql.DB) *AttachmentPostgres {
+	return &AttachmentPostgres{db: db}
+}
+
+// AddAttachment - сохраняем информацию о вложении, прикрепить файл может только автор сообщения
+func (a *AttachmentPostgres) AddAttachment(in entity.AttachmentAdd) (*entity.Attachment, error) {
+	// Скелет sql запроса на сохранение вложения к неудалённому сообщению автора
+	stmt, err := a.db.Prepare(`INSERT INTO "attachment" (message_id, user_id, file_name, content_type, size, storage_key, thumbnail_key)
+									SELECT m.id, m.user_id, $3, $4, $5, $6, NULLIF($7, '')
+									FROM "message" AS m
+									WHERE m.id = $1 AND m.user_id = $2 AND m.is_deleted = false
+									RETURNING id, message_id, user_id, file_name, content_type, size, storage_key, thumbnail_key, created_at`)
+	if err != nil {
+		return nil, fmt.Errorf("error path: %s, error: %w", opAttachmentAdd, err)
+	}
+	defer stmt.Close()
+
+	// Сохраняем вложение в бд
+	row := stmt.QueryRow(in.MessageID, in.UserID, in.FileName, in.ContentType, in.Size, in.StorageKey, in.ThumbnailKey)
+	attachment, errSc := scanAttachment(row)
+	if errSc != nil && errSc.Error() == errNoRows {
+		return nil, fmt.Errorf("error path: %s, error: %w", opAttachmentAdd, apperr.NotFound("Invalid message_id OR user_id"))
+	} else if errSc != nil {
+		return nil, fmt.Errorf("error path: %s, error: %w", opAttachmentAdd, errSc)
+	}
+
+	return attachment, nil
+}
+
+// GetAttachment - получаем информацию о вложении, если пользователь состоит в чате с этим сообщением
+func (a *AttachmentPostgres) GetAttachment(in entity.AttachmentGet) (*entity.Attachment, error) {
+	// Скелет sql запроса на получение вложения с проверкой членства пользователя в чате
+	stmt, err := a.db.Prepare(`SELECT a.id, a.message_id, a.user_id, a.file_name, a.content_type, a.size,
+											a.storage_key, a.thumbnail_key, a.created_at
+									FROM "attachment" AS a
+									INNER JOIN "message" AS m
+									ON m.id = a.message_id
+									INNER JOIN "chats_messages" AS cm
+									ON cm.message_id = a.message_id
+									INNER JOIN "users_chat" AS author
+									ON author.id = cm.users_chat_id
+									INNER JOIN "users_chat" AS uc
+									ON uc.chat_id = author.chat_id
+									WHERE a.id = $1
+									  AND uc.user_id = $2
+									  AND m.is_deleted = false`)
+	if err != nil {
+		return nil, fmt.Errorf("error path: %s, error: %w", opAttachmentGet, err)
+	}
+	defer stmt.Close()
+
+	// Получаем вложение из бд
+	attachment, errSc := scanAttachment(stmt.QueryRow(in.AttachmentID, in.UserID))
+	if errSc != nil && errSc.Error() == errNoRows {
+		return nil, fmt.Errorf("error path: %s, error: %w", opAttachmentGet, apperr.NotFound("Attachment not found"))
+	} else if errSc != nil {
+		return nil, fmt.Errorf("error path: %s, error: %w", opAttachmentGet, errSc)
+	}
+
+	return attachment, nil
+}
+
+// loadAttachments - получаем вложения для списка сообщений
+func loadAttachments(q preparer, messageIDs []int64) (map[int64][]entity.Attachment, error) {
+	attachments := make(map[int64][]entity.Attachment, len(messageIDs))
+	if len(messageIDs) == 0 {
+		return attachments, nil
+	}
+
+	// Скелет sql запроса на получение вложений по списку сообщений
+	stmt, err := q.Prepare(`SELECT id, message_id, user_id, file_name, content_type, size, storage_key, thumbnail_key, created_at
+								FROM "attachment"
+								WHERE message_id = ANY ($1)
+								ORDER BY id`)
+	if err != nil {
+		return nil, fmt.Errorf("error path: %s, error: %w", opAttachmentLoad, err)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.Query(pq.Array(messageIDs))
+	if err != nil {
+		return nil, fmt.Errorf("error path: %s, error: %w", opAttachmentLoad, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		attachment, errSc := scanAttachment(rows)
+		if errSc != nil {
+			return nil, fmt.Errorf("error path: %s, error: %w", opAttachmentLoad, errSc)
+		}
+		attachments[attachment.MessageID] = append(attachments[attachment.MessageID], *attachment)
+	}
+
+	// В конце проверяем строки на ошибки (best practice)
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error path: %s, error: %w", opAttachmentLoad, err)
+	}
+
+	return attachments, nil
+}
+
+// scanAttachment - читаем строку вложения, миниатюра есть только у изображений
+func scanAttachment(row interface{ Scan(dest ...any) error }) (*entity.Attachment, error) {
+	var attachment entity.Attachment
+	var thumbnailKey sql.NullString
+	if err := row.Scan(&attachment.Id, &attachment.MessageID, &attachment.UserID, &attachment.FileName,
+		&attachment.ContentType, &attachment.Size, &attachment.StorageKey, &thumbnailKey, &attachment.CreatedAt); err != nil {
+		return nil, err
+	}
+	attachment.ThumbnailKey = thumbnailKey.String
+
+	return &attachment, nil
+}
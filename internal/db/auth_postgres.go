@@ -4,9 +4,11 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/lib/pq"
 
+	"service-chat/internal/apperr"
 	"service-chat/internal/db/entity"
 )
 
@@ -25,7 +27,7 @@ func (r *AuthPostgres) CreateUser(user entity.User) (int, error) {
 
 	// Если пустой запрос
 	if user.Username == "" || user.Password == "" {
-		return 0, fmt.Errorf("error path: %s, error: empty username or password", op)
+		return 0, fmt.Errorf("error path: %s, error: %w", op, apperr.Validation("empty username or password"))
 	}
 
 	var id int
@@ -42,7 +44,7 @@ func (r *AuthPostgres) CreateUser(user entity.User) (int, error) {
 	var rowErr *pq.Error
 	ok := errors.As(row.Err(), &rowErr)
 	if ok && rowErr.Code == errCodeUnique {
-		return 0, fmt.Errorf("error path: %s, error: %s", op, rowErr.Code.Name())
+		return 0, fmt.Errorf("error path: %s, error: %w", op, apperr.Conflict("User already exists"))
 	}
 
 	// Получаем id записи
@@ -58,7 +60,7 @@ func (r *AuthPostgres) GetUser(user entity.User) (*entity.User, error) {
 
 	// Если пустой username
 	if user.Username == "" {
-		return nil, fmt.Errorf("error path: %s, error: empty username", op)
+		return nil, fmt.Errorf("error path: %s, error: %w", op, apperr.Validation("empty username"))
 	}
 
 	var userDB entity.User
@@ -72,9 +74,35 @@ func (r *AuthPostgres) GetUser(user entity.User) (*entity.User, error) {
 	row := stmt.QueryRow(user.Username)
 
 	// Получаем id, username, password_hash из базы данных
-	if err = row.Scan(&userDB.Id, &userDB.Username, &userDB.Password); err != nil {
+	if err = row.Scan(&userDB.Id, &userDB.Username, &userDB.Password); err != nil && err.Error() == errNoRows {
+		return nil, fmt.Errorf("error path: %s, error: %w", op, apperr.NotFound("User not found"))
+	} else if err != nil {
 		return nil, fmt.Errorf("error path: %s, error: %w", op, err)
 	}
 
 	return &userDB, nil
 }
+
+// GetSuspension - время, до которого пользователь отстранён модератором, nil - пользователь не отстранялся
+func (r *AuthPostgres) GetSuspension(userID int) (*time.Time, error) {
+	const op = "db.GetSuspension"
+
+	// Скелет sql запроса в базу данных
+	stmt, err := r.db.Prepare(`SELECT suspended_until FROM "user" WHERE id = $1`)
+	if err != nil {
+		return nil, fmt.Errorf("error path: %s, error: %w", op, err)
+	}
+	defer stmt.Close()
+
+	var suspendedUntil sql.NullTime
+	if row := stmt.QueryRow(userID).Scan(&suspendedUntil); row != nil && row.Error() == errNoRows {
+		return nil, nil
+	} else if row != nil {
+		return nil, fmt.Errorf("error path: %s, error: %w", op, row)
+	}
+	if !suspendedUntil.Valid {
+		return nil, nil
+	}
+
+	return &suspendedUntil.Time, nil
+}
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
 	"github.com/stretchr/testify/assert"
 
 	"service-chat/internal/db/entity"
@@ -92,13 +93,12 @@ func TestAuthPostgres_CreateUser(t *testing.T) {
 			},
 			mock: func(input args, wantID int) {
 				// Мок sql запроса
-				rowMock := sqlmock.NewRows([]string{"id"}).AddRow(wantID).RowError(0, errors.New("unique_violation"))
 				mock.
 					ExpectPrepare(`INSERT INTO "user" (username, password_hash) VALUES ($1, $2) RETURNING id`).
-					ExpectQuery().WithArgs(input.user.Username, input.user.Password).WillReturnRows(rowMock)
+					ExpectQuery().WithArgs(input.user.Username, input.user.Password).WillReturnError(&pq.Error{Code: errCodeUnique})
 			},
 			wantID:  0,
-			wantErr: errors.New("error path: db.CreateUser, error: unique_violation"),
+			wantErr: errors.New("error path: db.CreateUser, error: User already exists"),
 		},
 	}
 
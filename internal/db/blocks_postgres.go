@@ -0,0 +1,137 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+
+	"service-chat/internal/apperr"
+	"service-chat/internal/db/entity"
+)
+
+const (
+	opBlockAdd = "db.AddBlock"
+	opBlockGet = "db.GetBlocks"
+	opBlockDel = "db.DeleteBlock"
+)
+
+type BlockPostgres struct {
+	db *sql.DB
+}
+
+func NewBlockPostgres(db *sql.DB) *BlockPostgres {
+	return &BlockPostgres{db: db}
+}
+
+// AddBlock - вносим пользователя в чёрный список, повторное добавление ничего не меняет
+func (b *BlockPostgres) AddBlock(in entity.BlockAdd) error {
+	// Скелет sql запроса на проверку, что блокируемый пользователь существует
+	stmtUser, err := b.db.Prepare(`SELECT EXISTS (SELECT 1 FROM "user" WHERE id = $1 AND is_deleted = false)`)
+	if err != nil {
+		return fmt.Errorf("error path: %s, error: %w", opBlockAdd, err)
+	}
+	defer stmtUser.Close()
+
+	var exists bool
+	if err = stmtUser.QueryRow(in.BlockedID).Scan(&exists); err != nil {
+		return fmt.Errorf("error path: %s, error: %w", opBlockAdd, err)
+	}
+	if !exists {
+		return fmt.Errorf("error path: %s, error: %w", opBlockAdd,
+			apperr.NotFound(fmt.Sprintf("User with userID %d does not exist", in.BlockedID)))
+	}
+
+	// Скелет sql запроса на добавление пользователя в чёрный список
+	stmt, err := b.db.Prepare(`INSERT INTO "user_block" (blocker_id, blocked_id) VALUES ($1, $2) ON CONFLICT DO NOTHING`)
+	if err != nil {
+		return fmt.Errorf("error path: %s, error: %w", opBlockAdd, err)
+	}
+	defer stmt.Close()
+
+	if _, err = stmt.Exec(in.UserID, in.BlockedID); err != nil {
+		return fmt.Errorf("error path: %s, error: %w", opBlockAdd, err)
+	}
+
+	return nil
+}
+
+// GetBlocks - получаем чёрный список пользователя, недавно добавленные идут первыми
+func (b *BlockPostgres) GetBlocks(userID int) ([]entity.Block, error) {
+	// Скелет sql запроса на получение чёрного списка
+	stmt, err := b.db.Prepare(`SELECT b.blocked_id, u.username, b.created_at
+									FROM "user_block" AS b
+									INNER JOIN "user" AS u
+									ON u.id = b.blocked_id
+									WHERE b.blocker_id = $1
+									ORDER BY b.created_at DESC, b.blocked_id DESC`)
+	if err != nil {
+		return nil, fmt.Errorf("error path: %s, error: %w", opBlockGet, err)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.Query(userID)
+	if err != nil {
+		return nil, fmt.Errorf("error path: %s, error: %w", opBlockGet, err)
+	}
+	defer rows.Close()
+
+	var blocks []entity.Block
+	for rows.Next() {
+		var block entity.Block
+		if errSc := rows.Scan(&block.UserID, &block.Username, &block.CreatedAt); errSc != nil {
+			return nil, fmt.Errorf("error path: %s, error: %w", opBlockGet, errSc)
+		}
+		blocks = append(blocks, block)
+	}
+
+	// В конце проверяем строки на ошибки (best practice)
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error path: %s, error: %w", opBlockGet, err)
+	}
+
+	return blocks, nil
+}
+
+// DeleteBlock - убираем пользователя из чёрного списка
+func (b *BlockPostgres) DeleteBlock(in entity.BlockDel) error {
+	// Скелет sql запроса на удаление пользователя из чёрного списка
+	stmt, err := b.db.Prepare(`DELETE FROM "user_block" WHERE blocker_id = $1 AND blocked_id = $2`)
+	if err != nil {
+		return fmt.Errorf("error path: %s, error: %w", opBlockDel, err)
+	}
+	defer stmt.Close()
+
+	res, err := stmt.Exec(in.UserID, in.BlockedID)
+	if err != nil {
+		return fmt.Errorf("error path: %s, error: %w", opBlockDel, err)
+	}
+	if affected, errAff := res.RowsAffected(); errAff != nil {
+		return fmt.Errorf("error path: %s, error: %w", opBlockDel, errAff)
+	} else if affected == 0 {
+		return fmt.Errorf("error path: %s, error: %w", opBlockDel,
+			apperr.NotFound(fmt.Sprintf("User with userID %d is not blocked", in.BlockedID)))
+	}
+
+	return nil
+}
+
+// checkDirectChat - личный чат можно создать, только если blockerID не вносил blockedID в чёрный список.
+// Ошибка не сообщает о чёрном списке, чтобы заблокированный пользователь не узнал о нём
+func checkDirectChat(q preparer, op string, blockerID, blockedID int64) error {
+	// Скелет sql запроса на проверку чёрного списка
+	stmt, err := q.Prepare(`SELECT EXISTS (SELECT 1 FROM "user_block" WHERE blocker_id = $1 AND blocked_id = $2)`)
+	if err != nil {
+		return fmt.Errorf("error path: %s, error: %w", op, err)
+	}
+	defer stmt.Close()
+
+	var blocked bool
+	if err = stmt.QueryRow(blockerID, blockedID).Scan(&blocked); err != nil {
+		return fmt.Errorf("error path: %s, error: %w", op, err)
+	}
+	if blocked {
+		return fmt.Errorf("error path: %s, error: %w", op,
+			apperr.Forbidden(fmt.Sprintf("Cannot create a direct chat with userID %d", blockerID)))
+	}
+
+	return nil
+}
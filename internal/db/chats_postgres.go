@@ -7,6 +7,7 @@ import (
 
 	"github.com/lib/pq"
 
+	"service-chat/internal/apperr"
 	"service-chat/internal/db/entity"
 )
 
@@ -28,6 +29,18 @@ func NewChatsPostgres(db *sql.DB) *ChatsPostgres {
 func (c *ChatsPostgres) CreateChat(in entity.ChatAdd) (int, error) {
 	var chatID int
 
+	// Личный чат нельзя создать с пользователем, который внёс создателя в чёрный список
+	if len(in.Users) == 2 {
+		for _, user := range in.Users {
+			if user == in.AdminID {
+				continue
+			}
+			if errBlock := checkDirectChat(c.db, opCreateChat, user, in.AdminID); errBlock != nil {
+				return 0, errBlock
+			}
+		}
+	}
+
 	// Запускаем транзакцию
 	tx, err := c.db.Begin()
 	if err != nil {
@@ -49,7 +62,7 @@ func (c *ChatsPostgres) CreateChat(in entity.ChatAdd) (int, error) {
 	var rowErr *pq.Error
 	ok := errors.As(rowChat.Err(), &rowErr)
 	if ok && rowErr.Code == errCodeUnique {
-		return 0, fmt.Errorf("error path: %s, error: %s", opCreateChat, rowErr.Code.Name())
+		return 0, fmt.Errorf("error path: %s, error: %w", opCreateChat, apperr.Conflict("Chat already exists"))
 	}
 
 	// Получаем id chat
@@ -63,7 +76,7 @@ func (c *ChatsPostgres) CreateChat(in entity.ChatAdd) (int, error) {
 	}
 
 	// Скелет sql запроса в базу данных для связи чата и пользователей в таблице users_chat
-	stmtUsersChat, errUsersChat := tx.Prepare(pq.CopyIn("users_chat", "user_id", "chat_id"))
+	stmtUsersChat, errUsersChat := tx.Prepare(pq.CopyIn("users_chat", "user_id", "chat_id", "role"))
 	if errUsersChat != nil {
 		return 0, fmt.Errorf("error path: %s, error: %w", opCreateChat, errUsersChat)
 	}
@@ -71,7 +84,12 @@ func (c *ChatsPostgres) CreateChat(in entity.ChatAdd) (int, error) {
 
 	// Запрос на связь чата с пользователями
 	for _, user := range in.Users {
-		_, err = stmtUsersChat.Exec(user, chatID)
+		// Создатель чата становится его администратором
+		role := roleMember
+		if user == in.AdminID {
+			role = roleAdmin
+		}
+		_, err = stmtUsersChat.Exec(user, chatID, role)
 		if err != nil {
 			// Откатываем транзакцию в случае ошибки
 			errTx := tx.Rollback()
@@ -102,6 +120,8 @@ func (c *ChatsPostgres) GetChat(in entity.ChatGet) ([]entity.Chat, error) {
 
 	// Если в одних чатах есть сообщения, а в других нет, то сначала выводим чаты с сообщениями, сортируя от [Z-A],
 	// затем выводим пустые чаты с сортировкой по дате создания чата от [Z-A]
+
+	// Если задан размер страницы, выводим только её, без него - все чаты
 	stmtChats, err := c.db.Prepare(`WITH sort_chat AS (
 												SELECT uc.chat_id, MAX(cm.message_id) AS mm, c.id, c.name, c.created_at, c.is_deleted
 												FROM users_chat AS uc
@@ -113,8 +133,14 @@ func (c *ChatsPostgres) GetChat(in entity.ChatGet) ([]entity.Chat, error) {
 												GROUP BY uc.chat_id, c.id
 												ORDER BY mm DESC NULLS LAST, uc.chat_id DESC
 											)
-											SELECT sort_chat.id AS id, sort_chat.name, sort_chat.created_at, sort_chat.is_deleted
-											FROM sort_chat`)
+											SELECT sort_chat.id AS id, sort_chat.name, sort_chat.created_at, sort_chat.is_deleted,
+											       EXISTS(
+											           SELECT 1 FROM draft AS d
+											           WHERE d.user_id = $1 AND d.chat_id = sort_chat.id AND d.text <> ''
+											       ) AS has_draft
+											FROM sort_chat
+											ORDER BY sort_chat.mm DESC NULLS LAST, sort_chat.chat_id DESC
+											LIMIT NULLIF($2, 0) OFFSET $3`)
 
 	if err != nil {
 		return nil, fmt.Errorf("error path: %s, error: %w", opGetChat, err)
@@ -122,7 +148,7 @@ func (c *ChatsPostgres) GetChat(in entity.ChatGet) ([]entity.Chat, error) {
 	defer stmtChats.Close()
 
 	// Получаем чаты из бд
-	rowsChats, err := stmtChats.Query(in.UserID)
+	rowsChats, err := stmtChats.Query(in.UserID, in.Limit, in.Offset)
 	if err != nil {
 		return nil, fmt.Errorf("error path: %s, error: %w", opGetChat, err)
 	}
@@ -132,7 +158,7 @@ func (c *ChatsPostgres) GetChat(in entity.ChatGet) ([]entity.Chat, error) {
 	var chats []entity.Chat
 	for rowsChats.Next() {
 		var chat entity.Chat
-		if errChat := rowsChats.Scan(&chat.Id, &chat.Name, &chat.CreatedAt, &chat.IsDeleted); errChat != nil {
+		if errChat := rowsChats.Scan(&chat.Id, &chat.Name, &chat.CreatedAt, &chat.IsDeleted, &chat.HasDraft); errChat != nil {
 			return nil, fmt.Errorf("error path: %s, error: %w", opGetChat, errChat)
 		}
 		chats = append(chats, chat)
@@ -0,0 +1,211 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+
+	"service-chat/internal/apperr"
+	"service-chat/internal/db/entity"
+)
+
+const (
+	opCommandAdd  = "db.AddCommand"
+	opCommandGet  = "db.GetCommands"
+	opCommandDel  = "db.DeleteCommand"
+	opCommandFind = "db.FindCommand"
+)
+
+type CommandPostgres struct {
+	db *sql.DB
+}
+
+func NewCommandPostgres(db *sql.DB) *CommandPostgres {
+	return &CommandPostgres{db: db}
+}
+
+// AddCommand - создаём команду чата вместе со служебным пользователем бота, который становится участником чата.
+// Создавать команды могут только администраторы чата
+func (c *CommandPostgres) AddCommand(in entity.CommandAdd) (*entity.ChatCommand, error) {
+	// Начинаем транзакцию, строка чата блокируется до её конца, поэтому лимит команд не превысить параллельными запросами
+	tx, err := c.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("error path: %s, error: %w", opCommandAdd, err)
+	}
+
+	command, errAdd := addCommand(tx, in)
+	if errAdd != nil {
+		if errTx := tx.Rollback(); errTx != nil {
+			return nil, fmt.Errorf("error path: %s, error: %s", opCommandAdd, errTx)
+		}
+		return nil, errAdd
+	}
+
+	return command, tx.Commit()
+}
+
+// addCommand - создаём команду внутри транзакции, откат транзакции при ошибке остаётся на вызывающей стороне
+func addCommand(tx preparer, in entity.CommandAdd) (*entity.ChatCommand, error) {
+	if _, errAdmin := checkChatAdmin(tx, opCommandAdd, in.ChatID, in.UserID); errAdmin != nil {
+		return nil, errAdmin
+	}
+
+	// Скелет sql запроса на подсчёт команд чата и поиск команды с тем же именем
+	stmtCount, err := tx.Prepare(`SELECT COUNT(*), COUNT(*) FILTER (WHERE command = $2)
+									FROM "bot_command"
+									WHERE chat_id = $1`)
+	if err != nil {
+		return nil, fmt.Errorf("error path: %s, error: %w", opCommandAdd, err)
+	}
+	defer stmtCount.Close()
+
+	// Проверяем ограничение на количество команд в чате и уникальность имени
+	var count, same int
+	if err = stmtCount.QueryRow(in.ChatID, in.Command).Scan(&count, &same); err != nil {
+		return nil, fmt.Errorf("error path: %s, error: %w", opCommandAdd, err)
+	}
+	if same > 0 {
+		return nil, fmt.Errorf("error path: %s, error: %w", opCommandAdd,
+			apperr.Conflict(fmt.Sprintf("Command /%s already exists", in.Command)))
+	}
+	if count >= in.MaxPerChat {
+		return nil, fmt.Errorf("error path: %s, error: %w", opCommandAdd,
+			apperr.Conflict(fmt.Sprintf("Commands limit of %d reached", in.MaxPerChat)))
+	}
+
+	botID, err := addBotMember(tx, opCommandAdd, in.ChatID, in.BotName)
+	if err != nil {
+		return nil, err
+	}
+
+	// Скелет sql запроса на создание команды
+	stmtAdd, err := tx.Prepare(`INSERT INTO "bot_command" (chat_id, user_id, bot_id, command, description, callback_url, secret)
+									VALUES ($1, $2, $3, $4, $5, $6, $7)
+									RETURNING id, chat_id, user_id, bot_id, command, description, callback_url, created_at`)
+	if err != nil {
+		return nil, fmt.Errorf("error path: %s, error: %w", opCommandAdd, err)
+	}
+	defer stmtAdd.Close()
+
+	var command entity.ChatCommand
+	if err = stmtAdd.QueryRow(in.ChatID, in.UserID, botID, in.Command, in.Description, in.URL, in.Secret).
+		Scan(&command.ID, &command.ChatID, &command.UserID, &command.BotID, &command.Command,
+			&command.Description, &command.URL, &command.CreatedAt); err != nil {
+		return nil, fmt.Errorf("error path: %s, error: %w", opCommandAdd, err)
+	}
+
+	return &command, nil
+}
+
+// GetCommands - получаем команды чата без ключей подписи, список доступен участникам чата.
+// Адрес бота видят только администраторы чата
+func (c *CommandPostgres) GetCommands(in entity.CommandGet) ([]entity.ChatCommand, error) {
+	if _, errMember := checkChatMember(c.db, opCommandGet, in.ChatID, in.UserID); errMember != nil {
+		return nil, errMember
+	}
+
+	// Скелет sql запроса на получение команд чата
+	stmt, err := c.db.Prepare(`SELECT bc.id, bc.chat_id, bc.user_id, bc.bot_id, bc.command, bc.description,
+										CASE WHEN uc.role = $3 THEN bc.callback_url ELSE '' END,
+										bc.created_at
+									FROM "bot_command" AS bc
+									INNER JOIN "users_chat" AS uc
+									ON uc.chat_id = bc.chat_id AND uc.user_id = $2
+									WHERE bc.chat_id = $1
+									ORDER BY bc.command`)
+	if err != nil {
+		return nil, fmt.Errorf("error path: %s, error: %w", opCommandGet, err)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.Query(in.ChatID, in.UserID, roleAdmin)
+	if err != nil {
+		return nil, fmt.Errorf("error path: %s, error: %w", opCommandGet, err)
+	}
+	defer rows.Close()
+
+	var commands []entity.ChatCommand
+	for rows.Next() {
+		var command entity.ChatCommand
+		if errSc := rows.Scan(&command.ID, &command.ChatID, &command.UserID, &command.BotID, &command.Command,
+			&command.Description, &command.URL, &command.CreatedAt); errSc != nil {
+			return nil, fmt.Errorf("error path: %s, error: %w", opCommandGet, errSc)
+		}
+		commands = append(commands, command)
+	}
+
+	// В конце проверяем строки на ошибки (best practice)
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error path: %s, error: %w", opCommandGet, err)
+	}
+
+	return commands, nil
+}
+
+// DeleteCommand - удаляем команду чата. Служебный пользователь бота помечается удалённым,
+// но остаётся в чате, чтобы сохранились его сообщения
+func (c *CommandPostgres) DeleteCommand(in entity.CommandDel) error {
+	// Начинаем транзакцию
+	tx, err := c.db.Begin()
+	if err != nil {
+		return fmt.Errorf("error path: %s, error: %w", opCommandDel, err)
+	}
+
+	if errDel := deleteCommand(tx, in); errDel != nil {
+		if errTx := tx.Rollback(); errTx != nil {
+			return fmt.Errorf("error path: %s, error: %s", opCommandDel, errTx)
+		}
+		return errDel
+	}
+
+	return tx.Commit()
+}
+
+// deleteCommand - удаляем команду внутри транзакции, откат транзакции при ошибке остаётся на вызывающей стороне
+func deleteCommand(tx preparer, in entity.CommandDel) error {
+	if _, errAdmin := checkChatAdmin(tx, opCommandDel, in.ChatID, in.UserID); errAdmin != nil {
+		return errAdmin
+	}
+
+	// Скелет sql запроса на удаление команды
+	stmtDel, err := tx.Prepare(`DELETE FROM "bot_command" WHERE id = $1 AND chat_id = $2 RETURNING bot_id`)
+	if err != nil {
+		return fmt.Errorf("error path: %s, error: %w", opCommandDel, err)
+	}
+	defer stmtDel.Close()
+
+	var botID int64
+	if row := stmtDel.QueryRow(in.ID, in.ChatID).Scan(&botID); row != nil && row.Error() == errNoRows {
+		return fmt.Errorf("error path: %s, error: %w", opCommandDel, apperr.NotFound("Invalid command_id"))
+	} else if row != nil {
+		return fmt.Errorf("error path: %s, error: %w", opCommandDel, row)
+	}
+
+	return deleteBotUser(tx, opCommandDel, botID)
+}
+
+// FindCommand - находим команду чата для её вызова участником чата вместе с адресом и ключом подписи.
+// Если такой команды в чате нет - возвращаем nil без ошибки
+func (c *CommandPostgres) FindCommand(in entity.CommandFind) (*entity.ChatCommand, error) {
+	if _, errMember := checkChatMember(c.db, opCommandFind, in.ChatID, in.UserID); errMember != nil {
+		return nil, errMember
+	}
+
+	// Скелет sql запроса на поиск команды
+	stmt, err := c.db.Prepare(`SELECT id, chat_id, user_id, bot_id, command, description, callback_url, secret, created_at
+									FROM "bot_command"
+									WHERE chat_id = $1 AND command = $2`)
+	if err != nil {
+		return nil, fmt.Errorf("error path: %s, error: %w", opCommandFind, err)
+	}
+	defer stmt.Close()
+
+	var command entity.ChatCommand
+	if row := stmt.QueryRow(in.ChatID, in.Command).Scan(&command.ID, &command.ChatID, &command.UserID, &command.BotID,
+		&command.Command, &command.Description, &command.URL, &command.Secret, &command.CreatedAt); row != nil && row.Error() == errNoRows {
+		return nil, nil
+	} else if row != nil {
+		return nil, fmt.Errorf("error path: %s, error: %w", opCommandFind, row)
+	}
+
+	return &command, nil
+}
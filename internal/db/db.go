@@ -2,6 +2,7 @@ package db
 
 import (
 	"database/sql"
+	"time"
 
 	"service-chat/internal/db/entity"
 )
@@ -15,6 +16,7 @@ import (
 type Authorization interface {
 	CreateUser(user entity.User) (int, error)
 	GetUser(user entity.User) (*entity.User, error)
+	GetSuspension(userID int) (*time.Time, error)
 }
 
 // Chat - интерфейс для чатов
@@ -30,6 +32,130 @@ type Message interface {
 	UpdateMessage(in entity.MessageUpdate) (int, error)
 	GetMessage(in entity.MessageGet) ([]entity.Message, error)
 	DeleteMessage(in entity.MessageDel) ([]entity.DelMsg, error)
+	SearchMessage(in entity.MessageSearch) ([]entity.SearchResult, error)
+	ForwardMessage(in entity.MessageForward) ([]entity.Forwarded, error)
+	MessageChats(messageIDs []int64) (map[int64]int64, error)
+}
+
+// Reaction - интерфейс для реакций на сообщения
+type Reaction interface {
+	AddReaction(in entity.ReactionAdd) ([]entity.Reaction, error)
+	DeleteReaction(in entity.ReactionDel) ([]entity.Reaction, error)
+}
+
+// Attachment - интерфейс для вложений сообщений
+type Attachment interface {
+	AddAttachment(in entity.AttachmentAdd) (*entity.Attachment, error)
+	GetAttachment(in entity.AttachmentGet) (*entity.Attachment, error)
+}
+
+// Mention - интерфейс для упоминаний пользователей
+type Mention interface {
+	GetMentions(in entity.MentionGet) ([]entity.Message, error)
+}
+
+// Pin - интерфейс для закреплённых сообщений
+type Pin interface {
+	PinMessage(in entity.PinAdd) error
+	UnpinMessage(in entity.PinDel) error
+	GetPins(in entity.PinGet) ([]entity.Message, error)
+}
+
+// Scheduled - интерфейс для отложенных сообщений
+type Scheduled interface {
+	AddScheduled(in entity.ScheduledAdd) (int64, error)
+	GetScheduled(in entity.ScheduledGet) ([]entity.ScheduledMessage, error)
+	UpdateScheduled(in entity.ScheduledUpdate) error
+	CancelScheduled(in entity.ScheduledDel) error
+	GetDueScheduled(in entity.ScheduledDue) ([]entity.ScheduledMessage, error)
+	ClaimScheduled(in entity.ScheduledClaim) (*entity.ScheduledMessage, error)
+	FinishScheduled(in entity.ScheduledFinish) error
+}
+
+// Draft - интерфейс для черновиков сообщений
+type Draft interface {
+	SaveDraft(in entity.DraftSave) (*entity.Draft, bool, error)
+	GetDraft(in entity.DraftGet) (*entity.Draft, error)
+}
+
+// Preview - интерфейс для превью ссылок в сообщениях
+type Preview interface {
+	AddMessageLinks(in entity.MessageLinks) error
+	GetPreview(url string) (*entity.LinkPreview, error)
+	SavePreview(in entity.LinkPreview) error
+}
+
+// Presence - интерфейс для статусов пользователей в сети
+type Presence interface {
+	SaveLastSeen(in entity.PresenceSeen) error
+	GetChatMembers(in entity.PresenceGet) ([]entity.Presence, error)
+	GetUserChats(userID int64) ([]int64, error)
+	CheckMember(in entity.PresenceGet) error
+}
+
+// Webhook - интерфейс для исходящих вебхуков чатов и очереди их событий
+type Webhook interface {
+	AddWebhook(in entity.WebhookAdd) (*entity.Webhook, error)
+	GetWebhooks(in entity.WebhookGet) ([]entity.Webhook, error)
+	DeleteWebhook(in entity.WebhookDel) error
+	GetWebhookAttempts(in entity.WebhookAttemptsGet) ([]entity.WebhookAttempt, error)
+	ClaimDeliveries(in entity.WebhookClaim) ([]entity.WebhookJob, error)
+	SaveAttempt(in entity.WebhookResult) error
+}
+
+// Incoming - интерфейс для входящих вебхуков чатов
+type Incoming interface {
+	AddIncoming(in entity.IncomingAdd) (*entity.IncomingWebhook, error)
+	GetIncoming(in entity.IncomingGet) ([]entity.IncomingWebhook, error)
+	DeleteIncoming(in entity.IncomingDel) error
+	GetIncomingByToken(tokenHash string) (*entity.IncomingWebhook, error)
+}
+
+// Command - интерфейс для команд чатов, которые обрабатывают внешние боты
+type Command interface {
+	AddCommand(in entity.CommandAdd) (*entity.ChatCommand, error)
+	GetCommands(in entity.CommandGet) ([]entity.ChatCommand, error)
+	DeleteCommand(in entity.CommandDel) error
+	FindCommand(in entity.CommandFind) (*entity.ChatCommand, error)
+}
+
+// Poll - интерфейс для опросов в чатах
+type Poll interface {
+	AddPoll(in entity.PollAdd) (int, error)
+	Vote(in entity.PollVote) (*entity.Poll, error)
+}
+
+// Block - интерфейс для чёрного списка пользователя
+type Block interface {
+	AddBlock(in entity.BlockAdd) error
+	GetBlocks(userID int) ([]entity.Block, error)
+	DeleteBlock(in entity.BlockDel) error
+}
+
+// Moderation - интерфейс для жалоб на сообщения и действий модераторов
+type Moderation interface {
+	AddReport(in entity.ReportAdd) (int64, error)
+	GetReports(in entity.ReportsGet) ([]entity.Report, error)
+	ModerateReport(in entity.ModerationAdd) (*entity.ModerationAction, error)
+	GetActions(in entity.ActionsGet) ([]entity.ModerationAction, error)
+}
+
+// Filter - интерфейс для настроек фильтров текста сообщений в чатах и отправки сообщений в очередь модерации
+type Filter interface {
+	ChatFilterActions(chatID int64) (map[string]string, error)
+	GetChatFilters(in entity.ChatFiltersGet) (map[string]string, error)
+	SetChatFilter(in entity.ChatFilterSet) error
+}
+
+// Notification - интерфейс для уведомлений пользователей о сообщениях
+type Notification interface {
+	GetNotifySettings(userID int) (*entity.NotifySettings, error)
+	SaveNotifySettings(in entity.NotifySettingsSave) error
+	SetChatNotify(in entity.ChatNotifySet) error
+	GetRecipients(in entity.RecipientsGet) ([]entity.NotifyRecipient, error)
+	AddNotifications(in []entity.NotificationAdd) error
+	ClaimNotifications(in entity.NotificationClaim) ([]entity.NotificationJob, error)
+	CompleteNotifications(in entity.NotificationResult) error
 }
 
 // DB - собирает все наши интерфейсы в одном месте
@@ -37,6 +163,22 @@ type DB struct {
 	Authorization
 	Chat
 	Message
+	Reaction
+	Attachment
+	Mention
+	Pin
+	Scheduled
+	Draft
+	Preview
+	Presence
+	Webhook
+	Incoming
+	Command
+	Poll
+	Notification
+	Block
+	Moderation
+	Filter
 }
 
 // NewDB - конструктор базы данных
@@ -45,5 +187,21 @@ func NewDB(db *sql.DB) *DB {
 		Authorization: NewAuthPostgres(db),
 		Chat:          NewChatsPostgres(db),
 		Message:       NewMessagePostgres(db),
+		Reaction:      NewReactionPostgres(db),
+		Attachment:    NewAttachmentPostgres(db),
+		Mention:       NewMentionPostgres(db),
+		Pin:           NewPinPostgres(db),
+		Scheduled:     NewScheduledPostgres(db),
+		Draft:         NewDraftPostgres(db),
+		Preview:       NewPreviewPostgres(db),
+		Presence:      NewPresencePostgres(db),
+		Webhook:       NewWebhookPostgres(db),
+		Incoming:      NewIncomingPostgres(db),
+		Command:       NewCommandPostgres(db),
+		Poll:          NewPollPostgres(db),
+		Notification:  NewNotificationPostgres(db),
+		Block:         NewBlockPostgres(db),
+		Moderation:    NewModerationPostgres(db),
+		Filter:        NewFilterPostgres(db),
 	}
 }
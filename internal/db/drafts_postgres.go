@@ -0,0 +1,103 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+
+	"service-chat/internal/db/entity"
+)
+
+const (
+	opDraftSave = "db.SaveDraft"
+	opDraftGet  = "db.GetDraft"
+)
+
+type DraftPostgres struct {
+	db *sql.DB
+}
+
+func NewDraftPostgres(db *sql.DB) *DraftPostgres {
+	return &DraftPostgres{db: db}
+}
+
+// SaveDraft - сохраняем черновик, если его версия больше сохранённой (last-writer-wins).
+// Возвращаем актуальный черновик и признак того, что запись применена.
+// Если версия устарела, возвращаем черновик с другого устройства, который новее
+func (d *DraftPostgres) SaveDraft(in entity.DraftSave) (*entity.Draft, bool, error) {
+	// Начинаем транзакцию
+	tx, err := d.db.Begin()
+	if err != nil {
+		return nil, false, fmt.Errorf("error path: %s, error: %w", opDraftSave, err)
+	}
+
+	// Черновики хранятся только для чатов, в которых состоит пользователь
+	if _, errMember := checkChatMember(tx, opDraftSave, in.ChatID, in.UserID); errMember != nil {
+		if errTx := tx.Rollback(); errTx != nil {
+			return nil, false, fmt.Errorf("error path: %s, error: %s", opDraftSave, errTx)
+		}
+		return nil, false, errMember
+	}
+
+	// Скелет sql запроса на сохранение черновика, запись с меньшей или равной версией не применяется
+	stmtSave, err := tx.Prepare(`INSERT INTO "draft" (user_id, chat_id, text, version) VALUES ($1, $2, $3, $4)
+									ON CONFLICT (user_id, chat_id) DO UPDATE
+									SET text = EXCLUDED.text, version = EXCLUDED.version, updated_at = now()
+									WHERE "draft".version < EXCLUDED.version
+									RETURNING chat_id, text, version, updated_at`)
+	if err != nil {
+		return nil, false, fmt.Errorf("error path: %s, error: %w", opDraftSave, err)
+	}
+	defer stmtSave.Close()
+
+	var draft entity.Draft
+	row := stmtSave.QueryRow(in.UserID, in.ChatID, in.Text, in.Version).
+		Scan(&draft.ChatID, &draft.Text, &draft.Version, &draft.UpdatedAt)
+	if row == nil {
+		return &draft, true, tx.Commit()
+	} else if row.Error() != errNoRows {
+		if errTx := tx.Rollback(); errTx != nil {
+			return nil, false, fmt.Errorf("error path: %s, error: %s", opDraftSave, errTx)
+		}
+		return nil, false, fmt.Errorf("error path: %s, error: %w", opDraftSave, row)
+	}
+
+	// Версия устарела - возвращаем сохранённый черновик
+	current, errGet := getDraft(tx, opDraftSave, entity.DraftGet{ChatID: in.ChatID, UserID: in.UserID})
+	if errGet != nil {
+		if errTx := tx.Rollback(); errTx != nil {
+			return nil, false, fmt.Errorf("error path: %s, error: %s", opDraftSave, errTx)
+		}
+		return nil, false, errGet
+	}
+
+	return current, false, tx.Commit()
+}
+
+// GetDraft - получаем черновик пользователя в чате, удалённый черновик возвращается с пустым текстом
+func (d *DraftPostgres) GetDraft(in entity.DraftGet) (*entity.Draft, error) {
+	// Черновики доступны только участникам чата
+	if _, errMember := checkChatMember(d.db, opDraftGet, in.ChatID, in.UserID); errMember != nil {
+		return nil, errMember
+	}
+
+	return getDraft(d.db, opDraftGet, in)
+}
+
+// getDraft - читаем черновик из бд, если черновика нет - возвращаем nil
+func getDraft(q preparer, op string, in entity.DraftGet) (*entity.Draft, error) {
+	// Скелет sql запроса на получение черновика
+	stmt, err := q.Prepare(`SELECT chat_id, text, version, updated_at FROM "draft" WHERE user_id = $1 AND chat_id = $2`)
+	if err != nil {
+		return nil, fmt.Errorf("error path: %s, error: %w", op, err)
+	}
+	defer stmt.Close()
+
+	var draft entity.Draft
+	if row := stmt.QueryRow(in.UserID, in.ChatID).Scan(&draft.ChatID, &draft.Text, &draft.Version, &draft.UpdatedAt); row != nil && row.Error() == errNoRows {
+		return nil, nil
+	} else if row != nil {
+		return nil, fmt.Errorf("error path: %s, error: %w", op, row)
+	}
+
+	return &draft, nil
+}
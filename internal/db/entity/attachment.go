@@ -0,0 +1,34 @@
+package entity
+
+// Attachment - сущность для работы с вложениями сообщений
+type Attachment struct {
+	Id           int64  `json:"id" db:"id"`
+	MessageID    int64  `json:"message_id" db:"message_id"`
+	UserID       int64  `json:"user_id" db:"user_id"`
+	FileName     string `json:"file_name" db:"file_name"`
+	ContentType  string `json:"content_type" db:"content_type"`
+	Size         int64  `json:"size" db:"size"`
+	StorageKey   string `json:"-" db:"storage_key"`
+	ThumbnailKey string `json:"-" db:"thumbnail_key"`
+	CreatedAt    string `json:"created_at" db:"created_at"`
+	// URL и ThumbnailURL - ссылки для скачивания, доступны только участникам чата
+	URL          string `json:"url,omitempty"`
+	ThumbnailURL string `json:"thumbnail_url,omitempty"`
+}
+
+// AttachmentAdd - сущность для сохранения вложения к сообщению автора
+type AttachmentAdd struct {
+	MessageID    int64  `json:"messageID"`
+	UserID       int    `json:"userID"`
+	FileName     string `json:"fileName"`
+	ContentType  string `json:"contentType"`
+	Size         int64  `json:"size"`
+	StorageKey   string `json:"storageKey"`
+	ThumbnailKey string `json:"thumbnailKey"`
+}
+
+// AttachmentGet - сущность для получения вложения участником чата
+type AttachmentGet struct {
+	AttachmentID int64 `json:"attachmentID"`
+	UserID       int   `json:"userID"`
+}
@@ -0,0 +1,20 @@
+package entity
+
+// Block - пользователь из чёрного списка
+type Block struct {
+	UserID    int64  `json:"user_id" db:"blocked_id"`
+	Username  string `json:"username" db:"username"`
+	CreatedAt string `json:"created_at" db:"created_at"`
+}
+
+// BlockAdd - сущность для добавления пользователя в чёрный список
+type BlockAdd struct {
+	UserID    int   `json:"userID"`
+	BlockedID int64 `json:"blockedID"`
+}
+
+// BlockDel - сущность для удаления пользователя из чёрного списка
+type BlockDel struct {
+	UserID    int   `json:"userID"`
+	BlockedID int64 `json:"blockedID"`
+}
@@ -6,17 +6,24 @@ type Chat struct {
 	Name      string `json:"name" db:"name"`
 	CreatedAt string `json:"created_at" db:"created_at"`
 	IsDeleted bool   `json:"is_deleted" db:"is_deleted"`
+	// HasDraft - у пользователя есть несохранённый черновик в этом чате
+	HasDraft bool `json:"has_draft" db:"has_draft"`
 }
 
 // ChatAdd - сущность для создания чата между пользователями в бд
 type ChatAdd struct {
 	ChatName string  `json:"chatName"`
 	Users    []int64 `json:"users"`
+	// AdminID - создатель чата, становится его администратором
+	AdminID int64 `json:"adminID"`
 }
 
 // ChatGet - сущность для получения чата пользователя из бд
 type ChatGet struct {
 	UserID int64 `json:"user_id"`
+	// Limit - размер страницы, 0 - все чаты
+	Limit  int64 `json:"limit"`
+	Offset int64 `json:"offset"`
 }
 
 // ChatDelete - сущность для soft удаления чатов в бд
@@ -0,0 +1,57 @@
+package entity
+
+// ChatCommand - сущность команды чата, которую обрабатывает внешний бот по адресу URL.
+// Ответы бота в чат пишет служебный пользователь BotID. Secret возвращается только при создании
+type ChatCommand struct {
+	ID          int64  `json:"id" db:"id"`
+	ChatID      int64  `json:"chat_id" db:"chat_id"`
+	UserID      int64  `json:"user_id" db:"user_id"`
+	BotID       int64  `json:"bot_id" db:"bot_id"`
+	Command     string `json:"command" db:"command"`
+	Description string `json:"description,omitempty" db:"description"`
+	URL         string `json:"url,omitempty" db:"callback_url"`
+	Secret      string `json:"secret,omitempty" db:"secret"`
+	CreatedAt   string `json:"created_at" db:"created_at"`
+}
+
+// CommandAdd - сущность для создания команды чата администратором чата
+type CommandAdd struct {
+	ChatID      int64  `json:"chatID"`
+	UserID      int    `json:"userID"`
+	Command     string `json:"command"`
+	Description string `json:"description"`
+	URL         string `json:"url"`
+	Secret      string `json:"secret"`
+	BotName     string `json:"botName"`
+	MaxPerChat  int    `json:"maxPerChat"`
+}
+
+// CommandGet - сущность для получения команд чата
+type CommandGet struct {
+	ChatID int64 `json:"chatID"`
+	UserID int   `json:"userID"`
+}
+
+// CommandDel - сущность для удаления команды чата
+type CommandDel struct {
+	ID     int64 `json:"id"`
+	ChatID int64 `json:"chatID"`
+	UserID int   `json:"userID"`
+}
+
+// CommandFind - сущность для поиска команды чата при её вызове участником чата
+type CommandFind struct {
+	ChatID  int64  `json:"chatID"`
+	UserID  int    `json:"userID"`
+	Command string `json:"command"`
+}
+
+// CommandReply - результат команды. Text видит только вызвавший команду,
+// MessageID - сообщение, которое команда отправила в чат
+type CommandReply struct {
+	Command   string `json:"command"`
+	Text      string `json:"text,omitempty"`
+	Format    string `json:"format,omitempty"`
+	HTML      string `json:"html,omitempty"`
+	MessageID int64  `json:"message_id,omitempty"`
+}
@@ -0,0 +1,24 @@
+package entity
+
+// Draft - черновик сообщения пользователя в чате
+type Draft struct {
+	ChatID int64  `json:"chat_id" db:"chat_id"`
+	Text   string `json:"text" db:"text"`
+	// Version - версия черновика от клиента, побеждает запись с большей версией
+	Version   int64  `json:"version" db:"version"`
+	UpdatedAt string `json:"updated_at" db:"updated_at"`
+}
+
+// DraftSave - сущность для сохранения черновика, пустой текст означает удаление черновика
+type DraftSave struct {
+	ChatID  int64  `json:"chatID"`
+	UserID  int    `json:"userID"`
+	Text    string `json:"text"`
+	Version int64  `json:"version"`
+}
+
+// DraftGet - сущность для получения черновика пользователя в чате
+type DraftGet struct {
+	ChatID int64 `json:"chatID"`
+	UserID int   `json:"userID"`
+}
@@ -0,0 +1,30 @@
+package entity
+
+// ChatFilter - действие фильтра текста сообщений в чате
+type ChatFilter struct {
+	Filter string `json:"filter" db:"filter"`
+	Action string `json:"action" db:"action"`
+	// Overridden - действие задал администратор чата, иначе действует значение из конфига
+	Overridden bool `json:"overridden"`
+}
+
+// ChatFiltersGet - сущность для получения действий фильтров, заданных в чате
+type ChatFiltersGet struct {
+	ChatID int64 `json:"chatID"`
+	UserID int   `json:"userID"`
+}
+
+// ChatFilterSet - сущность для изменения действия фильтра в чате администратором, пустое действие
+// возвращает значение из конфига
+type ChatFilterSet struct {
+	ChatID int64  `json:"chatID"`
+	UserID int    `json:"userID"`
+	Filter string `json:"filter"`
+	Action string `json:"action"`
+}
+
+// MessageFlag - сущность для отправки сообщения в очередь модерации фильтрами текста
+type MessageFlag struct {
+	MessageID int64    `json:"messageID"`
+	Filters   []string `json:"filters"`
+}
@@ -0,0 +1,23 @@
+package entity
+
+// Forward - источник пересланного сообщения, по нему клиент показывает "Переслано от"
+type Forward struct {
+	// MessageID - исходное сообщение, пусто если оно удалено из бд
+	MessageID int64  `json:"message_id,omitempty"`
+	UserID    int64  `json:"user_id"`
+	ChatID    int64  `json:"chat_id,omitempty"`
+	CreatedAt string `json:"created_at"`
+}
+
+// MessageForward - сущность для пересылки сообщения в другие чаты от лица пользователя
+type MessageForward struct {
+	MessageID int64   `json:"messageID"`
+	UserID    int     `json:"userID"`
+	ChatIDs   []int64 `json:"chatIDs"`
+}
+
+// Forwarded - сущность для результата пересылки: новое сообщение в целевом чате
+type Forwarded struct {
+	ChatID    int64 `json:"chat_id"`
+	MessageID int64 `json:"message_id"`
+}
@@ -0,0 +1,37 @@
+package entity
+
+// IncomingWebhook - сущность входящего вебхука чата. Сообщения вебхука пишет служебный пользователь BotID.
+// Token и URL возвращаются только при создании
+type IncomingWebhook struct {
+	ID        int64  `json:"id" db:"id"`
+	ChatID    int64  `json:"chat_id" db:"chat_id"`
+	UserID    int64  `json:"user_id" db:"user_id"`
+	BotID     int64  `json:"bot_id" db:"bot_id"`
+	Name      string `json:"name" db:"name"`
+	Token     string `json:"token,omitempty"`
+	URL       string `json:"url,omitempty"`
+	CreatedAt string `json:"created_at" db:"created_at"`
+}
+
+// IncomingAdd - сущность для создания входящего вебхука администратором чата
+type IncomingAdd struct {
+	ChatID     int64  `json:"chatID"`
+	UserID     int    `json:"userID"`
+	Name       string `json:"name"`
+	BotName    string `json:"botName"`
+	TokenHash  string `json:"tokenHash"`
+	MaxPerChat int    `json:"maxPerChat"`
+}
+
+// IncomingGet - сущность для получения входящих вебхуков чата
+type IncomingGet struct {
+	ChatID int64 `json:"chatID"`
+	UserID int   `json:"userID"`
+}
+
+// IncomingDel - сущность для удаления входящего вебхука, его служебный пользователь помечается удалённым
+type IncomingDel struct {
+	ID     int64 `json:"id"`
+	ChatID int64 `json:"chatID"`
+	UserID int   `json:"userID"`
+}
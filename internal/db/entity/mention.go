@@ -0,0 +1,24 @@
+package entity
+
+// Mention - сущность упоминания пользователя в тексте сообщения
+type Mention struct {
+	UserID   int64  `json:"user_id" db:"user_id"`
+	Username string `json:"username" db:"username"`
+	// Offset и Length - позиция упоминания "@username" в тексте сообщения в символах
+	Offset int `json:"offset" db:"offset"`
+	Length int `json:"length" db:"length"`
+}
+
+// MentionAdd - сущность упоминания, найденного в тексте, до сопоставления с участниками чата
+type MentionAdd struct {
+	Username string `json:"username"`
+	Offset   int    `json:"offset"`
+	Length   int    `json:"length"`
+}
+
+// MentionGet - сущность для получения ленты сообщений, в которых упомянут пользователь
+type MentionGet struct {
+	UserID int   `json:"userID"`
+	Limit  int64 `json:"limit"`
+	Offset int64 `json:"offset"`
+}
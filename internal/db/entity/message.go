@@ -1,5 +1,13 @@
 package entity
 
+// Форматы текста сообщения
+const (
+	// FormatPlain - обычный текст
+	FormatPlain = "plain"
+	// FormatMarkdown - текст с разметкой markdown
+	FormatMarkdown = "markdown"
+)
+
 // Message - сущность для работы с сообщениями
 type Message struct {
 	Id        int64  `json:"id" db:"id"`
@@ -7,13 +15,41 @@ type Message struct {
 	UserID    int64  `json:"user_id" db:"user_id"`
 	CreatedAt string `json:"created_at" db:"created_at"`
 	IsDeleted bool   `json:"is_deleted" db:"is_deleted"`
+	// IsHidden - сообщение скрыто модератором, текст и связанные данные не отдаются
+	IsHidden bool `json:"is_hidden,omitempty" db:"is_hidden"`
+	// Format - формат текста: plain или markdown
+	Format string `json:"format,omitempty" db:"format"`
+	// HTML - безопасный HTML, отрендеренный из markdown, текст в Text остаётся исходным
+	HTML string `json:"html,omitempty"`
+	// Kind - тип сообщения: user - сообщение пользователя, system - служебное сообщение о событии в чате, poll - опрос
+	Kind string `json:"kind,omitempty" db:"kind"`
+	// Pinned - сообщение закреплено в чате
+	Pinned bool `json:"pinned,omitempty"`
+	// Reactions - реакции на сообщение, сгруппированные по emoji
+	Reactions []Reaction `json:"reactions,omitempty"`
+	// Attachments - файлы, прикреплённые к сообщению
+	Attachments []Attachment `json:"attachments,omitempty"`
+	// Mentions - упомянутые в тексте участники чата
+	Mentions []Mention `json:"mentions,omitempty"`
+	// Previews - превью ссылок из текста, появляются после фоновой загрузки
+	Previews []LinkPreview `json:"previews,omitempty"`
+	// Poll - опрос с текущими голосами, заполняется у сообщений вида poll
+	Poll *Poll `json:"poll,omitempty"`
+	// ForwardedFrom - источник, если сообщение переслано из другого чата
+	ForwardedFrom *Forward `json:"forwarded_from,omitempty"`
+	// ChatID - чат сообщения, заполняется в выборках по нескольким чатам
+	ChatID int64 `json:"chat_id,omitempty"`
 }
 
 // MessageAdd - сущность для отправки сообщения в чат от лица пользователя
 type MessageAdd struct {
-	ChatID int64  `json:"chatID"`
-	UserID int64  `json:"userID"`
-	Text   string `json:"text"`
+	ChatID   int64        `json:"chatID"`
+	UserID   int64        `json:"userID"`
+	Text     string       `json:"text"`
+	Format   string       `json:"format"`
+	Mentions []MentionAdd `json:"mentions"`
+	// Flagged - сработавшие фильтры с действием flag, жалоба создаётся в той же транзакции
+	Flagged []string `json:"flagged"`
 }
 
 // MessageUpdate - сущность для редактирования сообщения от лица пользователя
@@ -21,6 +57,11 @@ type MessageUpdate struct {
 	MessageID int64  `json:"messageID"`
 	UserID    int64  `json:"userID"`
 	NewText   string `json:"newText"`
+	// Format - новый формат текста, если пустой - формат не меняется
+	Format   string       `json:"format"`
+	Mentions []MentionAdd `json:"mentions"`
+	// Flagged - сработавшие фильтры с действием flag, жалоба создаётся в той же транзакции
+	Flagged []string `json:"flagged"`
 }
 
 // MessageGet - сущность для получения списка сообщений в конкретном чате
@@ -29,6 +70,8 @@ type MessageGet struct {
 	Limit  int64 `json:"limit"`
 	Offset int64 `json:"offset"`
 	UserID int   `json:"userID"`
+	// HideBlocked - не отдаём сообщения пользователей из чёрного списка UserID
+	HideBlocked bool `json:"hideBlocked"`
 }
 
 // MessageDel - сущность для удаления сообщений
@@ -37,6 +80,9 @@ type MessageDel struct {
 	UserID int     `json:"user_id"`
 }
 
+// ResultMessageDeleted - результат функции delete_message в бд для удалённого сообщения
+const ResultMessageDeleted = "Message successfully deleted"
+
 // DelMsg - сущность для получения результата удаления сообщений из бд
 type DelMsg struct {
 	MessageID int64  `json:"message_id" db:"identifier"`
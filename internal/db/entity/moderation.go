@@ -0,0 +1,105 @@
+package entity
+
+import "time"
+
+// Роли пользователей сервиса
+const (
+	RoleUser      = "user"
+	RoleModerator = "moderator"
+)
+
+// Причины жалоб на сообщения
+const (
+	ReportSpam       = "spam"
+	ReportHarassment = "harassment"
+	ReportHate       = "hate"
+	ReportViolence   = "violence"
+	ReportSexual     = "sexual"
+	ReportOther      = "other"
+	// ReportFilter - жалобу оставил фильтр текста сообщений с действием flag, автора жалобы нет
+	ReportFilter = "filter"
+)
+
+// Статусы жалоб
+const (
+	ReportOpen      = "open"
+	ReportResolved  = "resolved"
+	ReportDismissed = "dismissed"
+)
+
+// Действия модератора по жалобе
+const (
+	// ActionHide - сообщение скрывается так же, как удалённое автором
+	ActionHide = "hide"
+	// ActionWarn - автору сообщения выносится предупреждение
+	ActionWarn = "warn"
+	// ActionSuspend - автор сообщения отстраняется на время
+	ActionSuspend = "suspend"
+	// ActionDismiss - жалоба отклонена без мер
+	ActionDismiss = "dismiss"
+)
+
+// Report - жалоба на сообщение в очереди модерации
+type Report struct {
+	ID         int64  `json:"id" db:"id"`
+	MessageID  int64  `json:"message_id" db:"message_id"`
+	ChatID     int64  `json:"chat_id"`
+	AuthorID   int64  `json:"author_id"`
+	Text       string `json:"text"`
+	ReporterID int64  `json:"reporter_id" db:"reporter_id"`
+	Reason     string `json:"reason" db:"reason"`
+	Comment    string `json:"comment,omitempty" db:"comment"`
+	Status     string `json:"status" db:"status"`
+	// Reports - сколько всего жалоб на это сообщение ждут модератора
+	Reports   int64  `json:"reports"`
+	CreatedAt string `json:"created_at" db:"created_at"`
+}
+
+// ReportAdd - сущность жалобы участника чата на сообщение
+type ReportAdd struct {
+	MessageID int64  `json:"messageID"`
+	UserID    int    `json:"userID"`
+	Reason    string `json:"reason"`
+	Comment   string `json:"comment"`
+}
+
+// ReportsGet - сущность для получения очереди жалоб модератором
+type ReportsGet struct {
+	UserID int    `json:"userID"`
+	Status string `json:"status"`
+	Limit  int64  `json:"limit"`
+	Offset int64  `json:"offset"`
+}
+
+// ModerationAction - запись журнала действий модераторов
+type ModerationAction struct {
+	ID          int64  `json:"id" db:"id"`
+	ModeratorID int64  `json:"moderator_id" db:"moderator_id"`
+	Action      string `json:"action" db:"action"`
+	ReportID    int64  `json:"report_id" db:"report_id"`
+	MessageID   int64  `json:"message_id" db:"message_id"`
+	// ChatID - чат сообщения, заполняется только в ответе на действие
+	ChatID int64  `json:"chat_id,omitempty"`
+	UserID int64  `json:"user_id" db:"user_id"`
+	Note   string `json:"note,omitempty" db:"note"`
+	// SuspendedUntil - до какого времени отстранён пользователь, только для suspend
+	SuspendedUntil string `json:"suspended_until,omitempty" db:"suspended_until"`
+	CreatedAt      string `json:"created_at" db:"created_at"`
+}
+
+// ModerationAdd - сущность действия модератора по жалобе. SuspendedUntil задаётся только для suspend
+type ModerationAdd struct {
+	ReportID       int64      `json:"reportID"`
+	UserID         int        `json:"userID"`
+	Action         string     `json:"action"`
+	Note           string     `json:"note"`
+	SuspendedUntil *time.Time `json:"suspendedUntil"`
+}
+
+// ActionsGet - сущность для получения журнала действий модераторов, TargetID - фильтр по пользователю
+type ActionsGet struct {
+	UserID   int   `json:"userID"`
+	TargetID int64 `json:"targetID"`
+	Limit    int64 `json:"limit"`
+	Offset   int64 `json:"offset"`
+}
@@ -0,0 +1,105 @@
+package entity
+
+import "time"
+
+// Уровни уведомлений участника чата
+const (
+	// NotifyAll - уведомления о всех сообщениях чата
+	NotifyAll = "all"
+	// NotifyMentions - уведомления только о сообщениях, в которых пользователь упомянут
+	NotifyMentions = "mentions"
+	// NotifyNone - без уведомлений
+	NotifyNone = "none"
+)
+
+// Статусы уведомления в очереди
+const (
+	NotificationPending = "pending"
+	NotificationSent    = "sent"
+	// NotificationSkipped - сообщение удалено или адрес получателя очищен до отправки
+	NotificationSkipped = "skipped"
+	NotificationFailed  = "failed"
+)
+
+// NotifySettings - сущность адресов, на которые пользователь получает уведомления
+type NotifySettings struct {
+	Email     string `json:"email" db:"email"`
+	PushToken string `json:"push_token" db:"push_token"`
+}
+
+// NotifySettingsSave - сущность для сохранения адресов уведомлений пользователя
+type NotifySettingsSave struct {
+	UserID    int    `json:"userID"`
+	Email     string `json:"email"`
+	PushToken string `json:"pushToken"`
+}
+
+// ChatNotifySet - сущность для настройки уведомлений участника чата. Пустой MutedUntil снимает заглушение
+type ChatNotifySet struct {
+	ChatID     int64      `json:"chatID"`
+	UserID     int        `json:"userID"`
+	Level      string     `json:"level"`
+	MutedUntil *time.Time `json:"mutedUntil"`
+}
+
+// RecipientsGet - сущность для выбора получателей уведомлений о новом сообщении
+type RecipientsGet struct {
+	ChatID    int64     `json:"chatID"`
+	MessageID int64     `json:"messageID"`
+	AuthorID  int64     `json:"authorID"`
+	Now       time.Time `json:"now"`
+}
+
+// NotifyRecipient - участник чата, которого нужно уведомить о сообщении, с его адресами
+type NotifyRecipient struct {
+	UserID    int64  `json:"userID"`
+	Email     string `json:"email"`
+	PushToken string `json:"pushToken"`
+	// Mention - пользователь упомянут в сообщении
+	Mention bool `json:"mention"`
+}
+
+// NotificationAdd - сущность уведомления для очереди
+type NotificationAdd struct {
+	UserID    int64     `json:"userID"`
+	Channel   string    `json:"channel"`
+	ChatID    int64     `json:"chatID"`
+	MessageID int64     `json:"messageID"`
+	Mention   bool      `json:"mention"`
+	SendAfter time.Time `json:"sendAfter"`
+}
+
+// NotificationClaim - сущность для выборки уведомлений канала, которые пора отправить. Берутся все ожидающие
+// уведомления пользователей, у которых наступило время хотя бы одного уведомления. До LeaseUntil
+// уведомления не выдаются другим экземплярам сервиса
+type NotificationClaim struct {
+	Channel    string    `json:"channel"`
+	Now        time.Time `json:"now"`
+	LeaseUntil time.Time `json:"leaseUntil"`
+	Limit      int       `json:"limit"`
+}
+
+// NotificationJob - уведомление из очереди, взятое на отправку, вместе с сообщением и адресом получателя
+type NotificationJob struct {
+	ID        int64  `json:"id"`
+	UserID    int64  `json:"userID"`
+	Address   string `json:"address"`
+	ChatID    int64  `json:"chatID"`
+	ChatName  string `json:"chatName"`
+	MessageID int64  `json:"messageID"`
+	Author    string `json:"author"`
+	Text      string `json:"text"`
+	Mention   bool   `json:"mention"`
+	IsDeleted bool   `json:"isDeleted"`
+	CreatedAt string `json:"createdAt"`
+	// Attempts - номер текущей попытки отправки
+	Attempts int `json:"attempts"`
+}
+
+// NotificationResult - сущность результата отправки уведомлений. SendAfter - время следующей попытки для pending
+type NotificationResult struct {
+	IDs       []int64   `json:"ids"`
+	Status    string    `json:"status"`
+	Error     string    `json:"error"`
+	SendAfter time.Time `json:"sendAfter"`
+}
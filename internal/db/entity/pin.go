@@ -0,0 +1,22 @@
+package entity
+
+// PinAdd - сущность для закрепления сообщения администратором чата
+type PinAdd struct {
+	MessageID int64 `json:"messageID"`
+	UserID    int   `json:"userID"`
+	// MaxPins - максимальное количество закреплённых сообщений в чате
+	MaxPins int `json:"maxPins"`
+}
+
+// PinDel - сущность для открепления сообщения администратором чата
+type PinDel struct {
+	MessageID int64 `json:"messageID"`
+	UserID    int   `json:"userID"`
+}
+
+// PinGet - сущность для получения закреплённых сообщений чата
+type PinGet struct {
+	ChatID int64 `json:"chatID"`
+	UserID int   `json:"userID"`
+	Limit  int   `json:"limit"`
+}
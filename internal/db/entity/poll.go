@@ -0,0 +1,47 @@
+package entity
+
+import "time"
+
+// Poll - сущность опроса. Вопрос опроса - текст сообщения, голоса считаются при каждом получении сообщения
+type Poll struct {
+	ID int64 `json:"id" db:"id"`
+	// Multiple - можно выбрать несколько вариантов ответа
+	Multiple bool `json:"multiple" db:"multiple"`
+	// Anonymous - участники не видят, кто за что проголосовал
+	Anonymous bool `json:"anonymous" db:"anonymous"`
+	// CloseAt - время закрытия опроса, после него голоса не принимаются
+	CloseAt string `json:"close_at,omitempty" db:"close_at"`
+	Closed  bool   `json:"closed"`
+	// TotalVoters - сколько участников проголосовало хотя бы за один вариант
+	TotalVoters int64        `json:"total_voters"`
+	Options     []PollOption `json:"options"`
+}
+
+// PollOption - вариант ответа опроса с количеством голосов
+type PollOption struct {
+	ID        int64  `json:"id" db:"id"`
+	Text      string `json:"text" db:"text"`
+	Votes     int64  `json:"votes"`
+	VotedByMe bool   `json:"voted_by_me"`
+	// Voters - проголосовавшие пользователи, в анонимном опросе не заполняется
+	Voters []int64 `json:"voters,omitempty"`
+}
+
+// PollAdd - сущность для создания опроса в чате от лица пользователя
+type PollAdd struct {
+	ChatID    int64      `json:"chatID"`
+	UserID    int64      `json:"userID"`
+	Question  string     `json:"question"`
+	Options   []string   `json:"options"`
+	Multiple  bool       `json:"multiple"`
+	Anonymous bool       `json:"anonymous"`
+	CloseAt   *time.Time `json:"closeAt"`
+}
+
+// PollVote - сущность для голоса пользователя в опросе. Пустой OptionIDs отзывает голос
+type PollVote struct {
+	MessageID int64     `json:"messageID"`
+	UserID    int       `json:"userID"`
+	OptionIDs []int64   `json:"optionIDs"`
+	Now       time.Time `json:"now"`
+}
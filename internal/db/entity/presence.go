@@ -0,0 +1,35 @@
+package entity
+
+import "time"
+
+// Статусы пользователя в сети
+const (
+	// PresenceOnline - у пользователя есть подключение к /ws или /events
+	PresenceOnline = "online"
+	// PresenceAway - пользователь подключён, но отметил себя отошедшим
+	PresenceAway = "away"
+	// PresenceOffline - у пользователя нет подключений
+	PresenceOffline = "offline"
+)
+
+// Presence - статус участника чата
+type Presence struct {
+	UserID int64  `json:"user_id" db:"user_id"`
+	Status string `json:"status"`
+	// LastSeen - когда пользователь последний раз был в сети, пустое, если он ещё не подключался
+	LastSeen string `json:"last_seen,omitempty" db:"last_seen_at"`
+	// Typing - пользователь сейчас печатает сообщение в этом чате
+	Typing bool `json:"typing"`
+}
+
+// PresenceGet - сущность для получения участников чата
+type PresenceGet struct {
+	ChatID int64 `json:"chatID"`
+	UserID int   `json:"userID"`
+}
+
+// PresenceSeen - сущность для сохранения времени последнего появления пользователя в сети
+type PresenceSeen struct {
+	UserID int64     `json:"userID"`
+	SeenAt time.Time `json:"seenAt"`
+}
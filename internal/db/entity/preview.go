@@ -0,0 +1,29 @@
+package entity
+
+import "time"
+
+// Статусы превью ссылки
+const (
+	// PreviewOK - превью получено
+	PreviewOK = "ok"
+	// PreviewFailed - адрес недоступен или не является HTML страницей
+	PreviewFailed = "failed"
+)
+
+// LinkPreview - превью ссылки из сообщения по данным Open Graph
+type LinkPreview struct {
+	URL         string `json:"url" db:"url"`
+	Title       string `json:"title,omitempty" db:"title"`
+	Description string `json:"description,omitempty" db:"description"`
+	ImageURL    string `json:"image_url,omitempty" db:"image_url"`
+	SiteName    string `json:"site_name,omitempty" db:"site_name"`
+	// Status - ok или failed, в ответ не попадает
+	Status    string    `json:"-" db:"status"`
+	FetchedAt time.Time `json:"-" db:"fetched_at"`
+}
+
+// MessageLinks - сущность для сохранения ссылок, найденных в тексте сообщения
+type MessageLinks struct {
+	MessageID int64    `json:"messageID"`
+	URLs      []string `json:"urls"`
+}
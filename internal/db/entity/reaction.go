@@ -0,0 +1,22 @@
+package entity
+
+// Reaction - сущность для агрегированной реакции на сообщение
+type Reaction struct {
+	Emoji       string `json:"emoji" db:"emoji"`
+	Count       int64  `json:"count" db:"count"`
+	ReactedByMe bool   `json:"reacted_by_me" db:"reacted_by_me"`
+}
+
+// ReactionAdd - сущность для добавления реакции на сообщение от лица пользователя
+type ReactionAdd struct {
+	MessageID int64  `json:"messageID"`
+	UserID    int    `json:"userID"`
+	Emoji     string `json:"emoji"`
+}
+
+// ReactionDel - сущность для удаления реакции на сообщение от лица пользователя
+type ReactionDel struct {
+	MessageID int64  `json:"messageID"`
+	UserID    int    `json:"userID"`
+	Emoji     string `json:"emoji"`
+}
@@ -0,0 +1,74 @@
+package entity
+
+import "time"
+
+// ScheduledMessage - сущность отложенного сообщения, которое будет отправлено в чат в send_at
+type ScheduledMessage struct {
+	Id     int64  `json:"id" db:"id"`
+	ChatID int64  `json:"chat_id" db:"chat_id"`
+	UserID int64  `json:"user_id" db:"user_id"`
+	Text   string `json:"text" db:"text"`
+	Format string `json:"format" db:"format"`
+	SendAt string `json:"send_at" db:"send_at"`
+	// Status - pending, sending, sent, cancelled или failed
+	Status string `json:"status" db:"status"`
+	// Error - причина, по которой сообщение не удалось отправить
+	Error     string `json:"error,omitempty" db:"error"`
+	CreatedAt string `json:"created_at" db:"created_at"`
+}
+
+// ScheduledAdd - сущность для создания отложенного сообщения
+type ScheduledAdd struct {
+	ChatID int64     `json:"chatID"`
+	UserID int64     `json:"userID"`
+	Text   string    `json:"text"`
+	Format string    `json:"format"`
+	SendAt time.Time `json:"sendAt"`
+}
+
+// ScheduledGet - сущность для получения отложенных сообщений автора
+type ScheduledGet struct {
+	UserID int   `json:"userID"`
+	ChatID int64 `json:"chatID"`
+}
+
+// ScheduledUpdate - сущность для редактирования отложенного сообщения автором, пустые поля не меняются
+type ScheduledUpdate struct {
+	ID     int64      `json:"id"`
+	UserID int        `json:"userID"`
+	Text   string     `json:"text"`
+	SendAt *time.Time `json:"sendAt"`
+}
+
+// ScheduledDel - сущность для отмены отложенного сообщения автором
+type ScheduledDel struct {
+	ID     int64 `json:"id"`
+	UserID int   `json:"userID"`
+}
+
+// ScheduledDue - сущность для выборки отложенных сообщений, которые пора отправить.
+// Сообщения в статусе sending, забранные до LeaseBefore, считаются брошенными и выбираются снова
+type ScheduledDue struct {
+	Now         time.Time `json:"now"`
+	LeaseBefore time.Time `json:"leaseBefore"`
+	Limit       int       `json:"limit"`
+}
+
+// ScheduledClaim - сущность для захвата отложенного сообщения на отправку.
+// Сообщение в статусе sending, забранное до LeaseBefore, можно забрать повторно
+type ScheduledClaim struct {
+	ID          int64     `json:"id"`
+	Now         time.Time `json:"now"`
+	LeaseBefore time.Time `json:"leaseBefore"`
+}
+
+// ScheduledFinish - сущность для записи результата отправки отложенного сообщения
+type ScheduledFinish struct {
+	ID int64 `json:"id"`
+	// MessageID - созданное сообщение, у выполненной команды может отсутствовать
+	MessageID int64 `json:"messageID"`
+	// Error - причина, по которой сообщение не удалось отправить
+	Error string `json:"error"`
+	// Retry - ошибка временная, сообщение вернётся в очередь
+	Retry bool `json:"retry"`
+}
@@ -0,0 +1,26 @@
+package entity
+
+import "time"
+
+// MessageSearch - сущность для полнотекстового поиска по сообщениям в чатах пользователя
+type MessageSearch struct {
+	UserID   int        `json:"userID"`
+	Query    string     `json:"query"`
+	ChatID   int64      `json:"chatID"`
+	AuthorID int64      `json:"authorID"`
+	From     *time.Time `json:"from"`
+	To       *time.Time `json:"to"`
+	Limit    int64      `json:"limit"`
+	// AfterRank и AfterID - позиция последнего результата предыдущей страницы (keyset пагинация)
+	AfterRank *float32 `json:"afterRank"`
+	AfterID   int64    `json:"afterID"`
+}
+
+// SearchResult - сущность найденного сообщения с подсветкой совпадений
+type SearchResult struct {
+	Message Message `json:"message"`
+	ChatID  int64   `json:"chat_id"`
+	// Snippet - фрагмент текста, совпадения обёрнуты в <mark></mark>, остальной текст экранирован для HTML
+	Snippet string  `json:"snippet"`
+	Rank    float32 `json:"rank"`
+}
@@ -0,0 +1,115 @@
+package entity
+
+import "time"
+
+// События, которые отправляются в вебхуки
+const (
+	WebhookMessageCreated = "message.created"
+)
+
+// Статусы события в очереди вебхука
+const (
+	DeliveryPending   = "pending"
+	DeliveryDelivered = "delivered"
+	DeliveryDead      = "dead"
+)
+
+// Webhook - сущность исходящего вебхука чата. Secret возвращается только при создании
+type Webhook struct {
+	ID        int64  `json:"id" db:"id"`
+	ChatID    int64  `json:"chat_id" db:"chat_id"`
+	UserID    int64  `json:"user_id" db:"user_id"`
+	URL       string `json:"url" db:"url"`
+	Secret    string `json:"secret,omitempty" db:"secret"`
+	CreatedAt string `json:"created_at" db:"created_at"`
+}
+
+// WebhookAdd - сущность для создания вебхука администратором чата
+type WebhookAdd struct {
+	ChatID     int64  `json:"chatID"`
+	UserID     int    `json:"userID"`
+	URL        string `json:"url"`
+	Secret     string `json:"secret"`
+	MaxPerChat int    `json:"maxPerChat"`
+}
+
+// WebhookGet - сущность для получения вебхуков чата
+type WebhookGet struct {
+	ChatID int64 `json:"chatID"`
+	UserID int   `json:"userID"`
+}
+
+// WebhookDel - сущность для удаления вебхука, вместе с ним удаляется очередь его событий
+type WebhookDel struct {
+	ID     int64 `json:"id"`
+	ChatID int64 `json:"chatID"`
+	UserID int   `json:"userID"`
+}
+
+// WebhookAttempt - сущность попытки отправки события в вебхук
+type WebhookAttempt struct {
+	DeliveryID int64  `json:"delivery_id" db:"delivery_id"`
+	Event      string `json:"event" db:"event"`
+	// DeliveryStatus - текущий статус события: pending, delivered или dead
+	DeliveryStatus string `json:"delivery_status" db:"delivery_status"`
+	Attempt        int    `json:"attempt" db:"attempt"`
+	// StatusCode - код ответа адреса вебхука, 0 - ответ не получен
+	StatusCode int    `json:"status_code" db:"status_code"`
+	Error      string `json:"error,omitempty" db:"error"`
+	DurationMs int64  `json:"duration_ms" db:"duration_ms"`
+	CreatedAt  string `json:"created_at" db:"created_at"`
+}
+
+// WebhookAttemptsGet - сущность для получения последних попыток отправки в вебхук
+type WebhookAttemptsGet struct {
+	WebhookID int64 `json:"webhookID"`
+	ChatID    int64 `json:"chatID"`
+	UserID    int   `json:"userID"`
+	Limit     int64 `json:"limit"`
+}
+
+// WebhookEvent - тело запроса в вебхук о новом сообщении чата
+type WebhookEvent struct {
+	Event   string              `json:"event"`
+	ChatID  int64               `json:"chat_id"`
+	Message WebhookEventMessage `json:"message"`
+}
+
+// WebhookEventMessage - сообщение в теле запроса вебхука
+type WebhookEventMessage struct {
+	ID     int64  `json:"id"`
+	UserID int64  `json:"user_id"`
+	Text   string `json:"text"`
+	Format string `json:"format"`
+}
+
+// WebhookClaim - сущность для выборки событий, которые пора отправить.
+// До LeaseUntil событие не выдаётся другим экземплярам сервиса
+type WebhookClaim struct {
+	Now        time.Time `json:"now"`
+	LeaseUntil time.Time `json:"leaseUntil"`
+	Limit      int       `json:"limit"`
+}
+
+// WebhookJob - событие из очереди, взятое на отправку. Attempt - номер текущей попытки
+type WebhookJob struct {
+	DeliveryID int64  `json:"deliveryID"`
+	WebhookID  int64  `json:"webhookID"`
+	URL        string `json:"url"`
+	Secret     string `json:"secret"`
+	Event      string `json:"event"`
+	Payload    string `json:"payload"`
+	Attempt    int    `json:"attempt"`
+}
+
+// WebhookResult - сущность результата попытки отправки события
+type WebhookResult struct {
+	DeliveryID int64         `json:"deliveryID"`
+	Attempt    int           `json:"attempt"`
+	StatusCode int           `json:"statusCode"`
+	Error      string        `json:"error"`
+	Duration   time.Duration `json:"duration"`
+	// Status - новый статус события, NextAttemptAt - время следующей попытки для pending
+	Status        string    `json:"status"`
+	NextAttemptAt time.Time `json:"nextAttemptAt"`
+}
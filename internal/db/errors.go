@@ -3,4 +3,6 @@ package db
 const (
 	errCodeUnique = "23505"
 	errNoRows     = "sql: no rows in result set"
+	// errNoMessages - исключение функции delete_message, если у пользователя нет ни одного из сообщений
+	errNoMessages = "Not found messages"
 )
@@ -0,0 +1,143 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"service-chat/internal/db/entity"
+)
+
+const (
+	opFilterActions = "db.ChatFilterActions"
+	opFiltersGet    = "db.GetChatFilters"
+	opFilterSet     = "db.SetChatFilter"
+)
+
+type FilterPostgres struct {
+	db *sql.DB
+}
+
+func NewFilterPostgres(db *sql.DB) *FilterPostgres {
+	return &FilterPostgres{db: db}
+}
+
+// ChatFilterActions - действия фильтров, заданные в чате, по имени фильтра
+func (f *FilterPostgres) ChatFilterActions(chatID int64) (map[string]string, error) {
+	return chatFilterActions(f.db, opFilterActions, chatID)
+}
+
+// GetChatFilters - действия фильтров, заданные в чате, доступно участникам чата
+func (f *FilterPostgres) GetChatFilters(in entity.ChatFiltersGet) (map[string]string, error) {
+	if _, errMember := checkChatMember(f.db, opFiltersGet, in.ChatID, in.UserID); errMember != nil {
+		return nil, errMember
+	}
+
+	return chatFilterActions(f.db, opFiltersGet, in.ChatID)
+}
+
+// SetChatFilter - задаём действие фильтра в чате, пустое действие удаляет настройку чата. Доступно администраторам чата
+func (f *FilterPostgres) SetChatFilter(in entity.ChatFilterSet) error {
+	// Начинаем транзакцию
+	tx, err := f.db.Begin()
+	if err != nil {
+		return fmt.Errorf("error path: %s, error: %w", opFilterSet, err)
+	}
+
+	if errSet := setChatFilter(tx, in); errSet != nil {
+		if errTx := tx.Rollback(); errTx != nil {
+			return fmt.Errorf("error path: %s, error: %s", opFilterSet, errTx)
+		}
+		return errSet
+	}
+
+	return tx.Commit()
+}
+
+// setChatFilter - меняем действие фильтра внутри транзакции, откат транзакции при ошибке остаётся на вызывающей стороне
+func setChatFilter(tx preparer, in entity.ChatFilterSet) error {
+	if _, errAdmin := checkChatAdmin(tx, opFilterSet, in.ChatID, in.UserID); errAdmin != nil {
+		return errAdmin
+	}
+
+	// Скелет sql запроса на сохранение действия фильтра
+	query := `INSERT INTO "chat_filter" (chat_id, filter, action, updated_by, updated_at)
+				VALUES ($1, $2, $3, $4, now() AT TIME ZONE 'UTC')
+				ON CONFLICT (chat_id, filter) DO UPDATE
+				SET action = EXCLUDED.action, updated_by = EXCLUDED.updated_by, updated_at = EXCLUDED.updated_at`
+	args := []any{in.ChatID, in.Filter, in.Action, in.UserID}
+	if in.Action == "" {
+		// Скелет sql запроса на удаление настройки чата
+		query = `DELETE FROM "chat_filter" WHERE chat_id = $1 AND filter = $2`
+		args = args[:2]
+	}
+
+	stmt, err := tx.Prepare(query)
+	if err != nil {
+		return fmt.Errorf("error path: %s, error: %w", opFilterSet, err)
+	}
+	defer stmt.Close()
+
+	if _, err = stmt.Exec(args...); err != nil {
+		return fmt.Errorf("error path: %s, error: %w", opFilterSet, err)
+	}
+
+	return nil
+}
+
+// flagMessage - отправляем сообщение в очередь модерации жалобой без автора, в комментарии - сработавшие фильтры.
+// Пока на сообщение есть открытая жалоба фильтра, новая не создаётся
+func flagMessage(q preparer, op string, in entity.MessageFlag) error {
+	if len(in.Filters) == 0 {
+		return nil
+	}
+
+	// Скелет sql запроса на создание жалобы
+	stmt, err := q.Prepare(`INSERT INTO "report" (message_id, reporter_id, reason, comment)
+								SELECT $1, NULL, $2, $3
+								WHERE NOT EXISTS (SELECT 1 FROM "report"
+												  WHERE message_id = $1 AND reporter_id IS NULL AND status = $4)`)
+	if err != nil {
+		return fmt.Errorf("error path: %s, error: %w", op, err)
+	}
+	defer stmt.Close()
+
+	comment := "Flagged by filters: " + strings.Join(in.Filters, ", ")
+	if _, err = stmt.Exec(in.MessageID, entity.ReportFilter, comment, entity.ReportOpen); err != nil {
+		return fmt.Errorf("error path: %s, error: %w", op, err)
+	}
+
+	return nil
+}
+
+// chatFilterActions - получаем действия фильтров, заданные в чате
+func chatFilterActions(q preparer, op string, chatID int64) (map[string]string, error) {
+	// Скелет sql запроса на получение настроек фильтров чата
+	stmt, err := q.Prepare(`SELECT filter, action FROM "chat_filter" WHERE chat_id = $1`)
+	if err != nil {
+		return nil, fmt.Errorf("error path: %s, error: %w", op, err)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.Query(chatID)
+	if err != nil {
+		return nil, fmt.Errorf("error path: %s, error: %w", op, err)
+	}
+	defer rows.Close()
+
+	actions := make(map[string]string)
+	for rows.Next() {
+		var name, action string
+		if errSc := rows.Scan(&name, &action); errSc != nil {
+			return nil, fmt.Errorf("error path: %s, error: %w", op, errSc)
+		}
+		actions[name] = action
+	}
+
+	// В конце проверяем строки на ошибки (best practice)
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error path: %s, error: %w", op, err)
+	}
+
+	return actions, nil
+}
@@ -0,0 +1,163 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/lib/pq"
+
+	"service-chat/internal/apperr"
+	"service-chat/internal/db/entity"
+)
+
+const (
+	opMessageForward = "db.ForwardMessage"
+	opForwardLoad    = "db.loadForwards"
+)
+
+// ForwardMessage - пересылаем сообщение в другие чаты от лица пользователя.
+// Пользователь должен состоять и в исходном чате, и во всех целевых, иначе ничего не пересылается
+func (m *MessagePostgres) ForwardMessage(in entity.MessageForward) ([]entity.Forwarded, error) {
+	// Начинаем транзакцию
+	tx, err := m.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("error path: %s, error: %w", opMessageForward, err)
+	}
+
+	// Переслать можно только неудалённое сообщение из чата, в котором состоит пользователь
+	chatID, errMember := checkMessageMember(tx, opMessageForward, in.MessageID, in.UserID)
+	if errMember != nil {
+		if errTx := tx.Rollback(); errTx != nil {
+			return nil, fmt.Errorf("error path: %s, error: %s", opMessageForward, errTx)
+		}
+		return nil, errMember
+	}
+
+	// Скелет sql запроса на получение исходного сообщения
+	stmtSrc, err := tx.Prepare(`SELECT text, format, user_id, created_at, kind,
+       									forward_message_id, forward_user_id, forward_chat_id, forward_created_at
+									FROM "message"
+									WHERE id = $1`)
+	if err != nil {
+		return nil, fmt.Errorf("error path: %s, error: %w", opMessageForward, err)
+	}
+	defer stmtSrc.Close()
+
+	var text, format, kind string
+	var src entity.Forward
+	var fwdMessageID, fwdUserID, fwdChatID sql.NullInt64
+	var fwdCreatedAt sql.NullString
+	if err = stmtSrc.QueryRow(in.MessageID).Scan(&text, &format, &src.UserID, &src.CreatedAt, &kind,
+		&fwdMessageID, &fwdUserID, &fwdChatID, &fwdCreatedAt); err != nil {
+		if errTx := tx.Rollback(); errTx != nil {
+			return nil, fmt.Errorf("error path: %s, error: %s", opMessageForward, errTx)
+		}
+		return nil, fmt.Errorf("error path: %s, error: %w", opMessageForward, err)
+	}
+
+	// Служебные сообщения относятся к событиям конкретного чата и не пересылаются
+	if kind != kindUser {
+		if errTx := tx.Rollback(); errTx != nil {
+			return nil, fmt.Errorf("error path: %s, error: %s", opMessageForward, errTx)
+		}
+		return nil, fmt.Errorf("error path: %s, error: %w", opMessageForward, apperr.Validation("System messages cannot be forwarded"))
+	}
+
+	// Если сообщение уже было переслано, сохраняем самый первый источник
+	src.MessageID, src.ChatID = in.MessageID, chatID
+	if fwdUserID.Valid {
+		src = entity.Forward{
+			MessageID: fwdMessageID.Int64,
+			UserID:    fwdUserID.Int64,
+			ChatID:    fwdChatID.Int64,
+			CreatedAt: fwdCreatedAt.String,
+		}
+	}
+
+	// Скелет sql запроса на создание пересланного сообщения
+	stmtMsg, err := tx.Prepare(`INSERT INTO "message" (text, format, user_id, forward_message_id, forward_user_id, forward_chat_id, forward_created_at)
+									VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id`)
+	if err != nil {
+		return nil, fmt.Errorf("error path: %s, error: %w", opMessageForward, err)
+	}
+	defer stmtMsg.Close()
+
+	// Скелет sql запроса на связь пересланного сообщения с целевым чатом
+	stmtCm, err := tx.Prepare(`INSERT INTO "chats_messages" (users_chat_id, message_id) VALUES ($1, $2)`)
+	if err != nil {
+		return nil, fmt.Errorf("error path: %s, error: %w", opMessageForward, err)
+	}
+	defer stmtCm.Close()
+
+	forwarded := make([]entity.Forwarded, 0, len(in.ChatIDs))
+	for _, targetID := range in.ChatIDs {
+		// Пересылать можно только в чаты, в которых состоит пользователь
+		usersChatID, errTarget := checkChatMember(tx, opMessageForward, targetID, in.UserID)
+		if errTarget != nil {
+			if errTx := tx.Rollback(); errTx != nil {
+				return nil, fmt.Errorf("error path: %s, error: %s", opMessageForward, errTx)
+			}
+			return nil, errTarget
+		}
+
+		// Создаём копию сообщения с источником
+		var messageID int64
+		errAdd := stmtMsg.QueryRow(text, format, in.UserID, nullID(src.MessageID), src.UserID, nullID(src.ChatID), src.CreatedAt).
+			Scan(&messageID)
+		if errAdd == nil {
+			_, errAdd = stmtCm.Exec(usersChatID, messageID)
+		}
+		if errAdd != nil {
+			if errTx := tx.Rollback(); errTx != nil {
+				return nil, fmt.Errorf("error path: %s, error: %s", opMessageForward, errTx)
+			}
+			return nil, fmt.Errorf("error path: %s, error: %w", opMessageForward, errAdd)
+		}
+
+		forwarded = append(forwarded, entity.Forwarded{ChatID: targetID, MessageID: messageID})
+	}
+
+	return forwarded, tx.Commit()
+}
+
+// loadForwards - получаем источники пересланных сообщений из списка сообщений
+func loadForwards(q preparer, messageIDs []int64) (map[int64]*entity.Forward, error) {
+	forwards := make(map[int64]*entity.Forward, len(messageIDs))
+	if len(messageIDs) == 0 {
+		return forwards, nil
+	}
+
+	// Скелет sql запроса на получение источников пересланных сообщений
+	stmt, err := q.Prepare(`SELECT id, forward_message_id, forward_user_id, forward_chat_id, forward_created_at
+								FROM "message"
+								WHERE id = ANY ($1)
+								  AND forward_user_id IS NOT NULL`)
+	if err != nil {
+		return nil, fmt.Errorf("error path: %s, error: %w", opForwardLoad, err)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.Query(pq.Array(messageIDs))
+	if err != nil {
+		return nil, fmt.Errorf("error path: %s, error: %w", opForwardLoad, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var messageID int64
+		var fwdMessageID, fwdChatID sql.NullInt64
+		var fwd entity.Forward
+		if errSc := rows.Scan(&messageID, &fwdMessageID, &fwd.UserID, &fwdChatID, &fwd.CreatedAt); errSc != nil {
+			return nil, fmt.Errorf("error path: %s, error: %w", opForwardLoad, errSc)
+		}
+		fwd.MessageID, fwd.ChatID = fwdMessageID.Int64, fwdChatID.Int64
+		forwards[messageID] = &fwd
+	}
+
+	// В конце проверяем строки на ошибки (best practice)
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error path: %s, error: %w", opForwardLoad, err)
+	}
+
+	return forwards, nil
+}
@@ -0,0 +1,196 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+
+	"service-chat/internal/apperr"
+	"service-chat/internal/db/entity"
+)
+
+const (
+	opIncomingAdd   = "db.AddIncoming"
+	opIncomingGet   = "db.GetIncoming"
+	opIncomingDel   = "db.DeleteIncoming"
+	opIncomingToken = "db.GetIncomingByToken"
+)
+
+type IncomingPostgres struct {
+	db *sql.DB
+}
+
+func NewIncomingPostgres(db *sql.DB) *IncomingPostgres {
+	return &IncomingPostgres{db: db}
+}
+
+// AddIncoming - создаём входящий вебхук чата вместе со служебным пользователем, который становится участником чата.
+// Создавать вебхуки могут только администраторы чата
+func (i *IncomingPostgres) AddIncoming(in entity.IncomingAdd) (*entity.IncomingWebhook, error) {
+	// Начинаем транзакцию, строка чата блокируется до её конца, поэтому лимит вебхуков не превысить параллельными запросами
+	tx, err := i.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("error path: %s, error: %w", opIncomingAdd, err)
+	}
+
+	hook, errAdd := addIncoming(tx, in)
+	if errAdd != nil {
+		if errTx := tx.Rollback(); errTx != nil {
+			return nil, fmt.Errorf("error path: %s, error: %s", opIncomingAdd, errTx)
+		}
+		return nil, errAdd
+	}
+
+	return hook, tx.Commit()
+}
+
+// addIncoming - создаём вебхук внутри транзакции, откат транзакции при ошибке остаётся на вызывающей стороне
+func addIncoming(tx preparer, in entity.IncomingAdd) (*entity.IncomingWebhook, error) {
+	if _, errAdmin := checkChatAdmin(tx, opIncomingAdd, in.ChatID, in.UserID); errAdmin != nil {
+		return nil, errAdmin
+	}
+
+	// Скелет sql запроса на подсчёт входящих вебхуков чата
+	stmtCount, err := tx.Prepare(`SELECT COUNT(*) FROM "incoming_webhook" WHERE chat_id = $1`)
+	if err != nil {
+		return nil, fmt.Errorf("error path: %s, error: %w", opIncomingAdd, err)
+	}
+	defer stmtCount.Close()
+
+	// Проверяем ограничение на количество вебхуков в чате
+	var count int
+	if err = stmtCount.QueryRow(in.ChatID).Scan(&count); err != nil {
+		return nil, fmt.Errorf("error path: %s, error: %w", opIncomingAdd, err)
+	}
+	if count >= in.MaxPerChat {
+		return nil, fmt.Errorf("error path: %s, error: %w", opIncomingAdd,
+			apperr.Conflict(fmt.Sprintf("Incoming webhooks limit of %d reached", in.MaxPerChat)))
+	}
+
+	botID, err := addBotMember(tx, opIncomingAdd, in.ChatID, in.BotName)
+	if err != nil {
+		return nil, err
+	}
+
+	// Скелет sql запроса на создание вебхука
+	stmtAdd, err := tx.Prepare(`INSERT INTO "incoming_webhook" (chat_id, user_id, bot_id, name, token_hash)
+									VALUES ($1, $2, $3, $4, $5)
+									RETURNING id, chat_id, user_id, bot_id, name, created_at`)
+	if err != nil {
+		return nil, fmt.Errorf("error path: %s, error: %w", opIncomingAdd, err)
+	}
+	defer stmtAdd.Close()
+
+	var hook entity.IncomingWebhook
+	if err = stmtAdd.QueryRow(in.ChatID, in.UserID, botID, in.Name, in.TokenHash).
+		Scan(&hook.ID, &hook.ChatID, &hook.UserID, &hook.BotID, &hook.Name, &hook.CreatedAt); err != nil {
+		return nil, fmt.Errorf("error path: %s, error: %w", opIncomingAdd, err)
+	}
+
+	return &hook, nil
+}
+
+// GetIncoming - получаем входящие вебхуки чата без токенов, список доступен только администраторам чата
+func (i *IncomingPostgres) GetIncoming(in entity.IncomingGet) ([]entity.IncomingWebhook, error) {
+	if _, errAdmin := checkChatAdmin(i.db, opIncomingGet, in.ChatID, in.UserID); errAdmin != nil {
+		return nil, errAdmin
+	}
+
+	// Скелет sql запроса на получение входящих вебхуков чата
+	stmt, err := i.db.Prepare(`SELECT id, chat_id, user_id, bot_id, name, created_at
+									FROM "incoming_webhook"
+									WHERE chat_id = $1
+									ORDER BY id`)
+	if err != nil {
+		return nil, fmt.Errorf("error path: %s, error: %w", opIncomingGet, err)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.Query(in.ChatID)
+	if err != nil {
+		return nil, fmt.Errorf("error path: %s, error: %w", opIncomingGet, err)
+	}
+	defer rows.Close()
+
+	var hooks []entity.IncomingWebhook
+	for rows.Next() {
+		var hook entity.IncomingWebhook
+		if errSc := rows.Scan(&hook.ID, &hook.ChatID, &hook.UserID, &hook.BotID, &hook.Name, &hook.CreatedAt); errSc != nil {
+			return nil, fmt.Errorf("error path: %s, error: %w", opIncomingGet, errSc)
+		}
+		hooks = append(hooks, hook)
+	}
+
+	// В конце проверяем строки на ошибки (best practice)
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error path: %s, error: %w", opIncomingGet, err)
+	}
+
+	return hooks, nil
+}
+
+// DeleteIncoming - удаляем входящий вебхук. Служебный пользователь помечается удалённым,
+// но остаётся в чате, чтобы сохранились его сообщения
+func (i *IncomingPostgres) DeleteIncoming(in entity.IncomingDel) error {
+	// Начинаем транзакцию
+	tx, err := i.db.Begin()
+	if err != nil {
+		return fmt.Errorf("error path: %s, error: %w", opIncomingDel, err)
+	}
+
+	if errDel := deleteIncoming(tx, in); errDel != nil {
+		if errTx := tx.Rollback(); errTx != nil {
+			return fmt.Errorf("error path: %s, error: %s", opIncomingDel, errTx)
+		}
+		return errDel
+	}
+
+	return tx.Commit()
+}
+
+// deleteIncoming - удаляем вебхук внутри транзакции, откат транзакции при ошибке остаётся на вызывающей стороне
+func deleteIncoming(tx preparer, in entity.IncomingDel) error {
+	if _, errAdmin := checkChatAdmin(tx, opIncomingDel, in.ChatID, in.UserID); errAdmin != nil {
+		return errAdmin
+	}
+
+	// Скелет sql запроса на удаление вебхука
+	stmtDel, err := tx.Prepare(`DELETE FROM "incoming_webhook" WHERE id = $1 AND chat_id = $2 RETURNING bot_id`)
+	if err != nil {
+		return fmt.Errorf("error path: %s, error: %w", opIncomingDel, err)
+	}
+	defer stmtDel.Close()
+
+	var botID int64
+	if row := stmtDel.QueryRow(in.ID, in.ChatID).Scan(&botID); row != nil && row.Error() == errNoRows {
+		return fmt.Errorf("error path: %s, error: %w", opIncomingDel, apperr.NotFound("Invalid webhook_id"))
+	} else if row != nil {
+		return fmt.Errorf("error path: %s, error: %w", opIncomingDel, row)
+	}
+
+	return deleteBotUser(tx, opIncomingDel, botID)
+}
+
+// GetIncomingByToken - находим вебхук по хэшу токена, вебхук удалённого чата не находится
+func (i *IncomingPostgres) GetIncomingByToken(tokenHash string) (*entity.IncomingWebhook, error) {
+	// Скелет sql запроса на поиск вебхука
+	stmt, err := i.db.Prepare(`SELECT iw.id, iw.chat_id, iw.user_id, iw.bot_id, iw.name, iw.created_at
+									FROM "incoming_webhook" AS iw
+									INNER JOIN "chat" AS c
+									ON c.id = iw.chat_id
+									WHERE iw.token_hash = $1
+									  AND c.is_deleted = false`)
+	if err != nil {
+		return nil, fmt.Errorf("error path: %s, error: %w", opIncomingToken, err)
+	}
+	defer stmt.Close()
+
+	var hook entity.IncomingWebhook
+	if row := stmt.QueryRow(tokenHash).Scan(&hook.ID, &hook.ChatID, &hook.UserID, &hook.BotID, &hook.Name,
+		&hook.CreatedAt); row != nil && row.Error() == errNoRows {
+		return nil, fmt.Errorf("error path: %s, error: %w", opIncomingToken, apperr.NotFound("Invalid webhook token"))
+	} else if row != nil {
+		return nil, fmt.Errorf("error path: %s, error: %w", opIncomingToken, row)
+	}
+
+	return &hook, nil
+}
@@ -0,0 +1,199 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"service-chat/internal/apperr"
+)
+
+// Роли участников чата
+const (
+	roleMember = "member"
+	roleAdmin  = "admin"
+)
+
+// botPasswordHash - пароль служебного пользователя, расшифровать его нельзя, поэтому войти под ботом невозможно
+const botPasswordHash = "!"
+
+// preparer - общий интерфейс для *sql.DB и *sql.Tx, чтобы вспомогательные запросы работали как внутри транзакции, так и без неё
+type preparer interface {
+	Prepare(query string) (*sql.Stmt, error)
+}
+
+// checkMessageMember - проверяем, что сообщение существует и пользователь состоит в чате,
+// в котором находится сообщение, по аналогии с проверкой в AddMessage. Возвращаем chat_id сообщения
+func checkMessageMember(tx preparer, op string, messageID int64, userID int) (int64, error) {
+	var chatID int64
+
+	// Скелет sql запроса на поиск чата сообщения, в котором состоит пользователь
+	stmt, err := tx.Prepare(`SELECT uc.chat_id
+									FROM "chats_messages" AS cm
+									INNER JOIN "users_chat" AS author
+									ON author.id = cm.users_chat_id
+									INNER JOIN "users_chat" AS uc
+									ON uc.chat_id = author.chat_id
+									INNER JOIN "message" AS m
+									ON m.id = cm.message_id
+									WHERE cm.message_id = $1
+									  AND uc.user_id = $2
+									  AND m.is_deleted = false`)
+	if err != nil {
+		return 0, fmt.Errorf("error path: %s, error: %w", op, err)
+	}
+	defer stmt.Close()
+
+	// Если сообщения нет или пользователь не состоит в чате
+	if row := stmt.QueryRow(messageID, userID).Scan(&chatID); row != nil && row.Error() == errNoRows {
+		return 0, fmt.Errorf("error path: %s, error: %w", op, apperr.NotFound("Invalid message_id"))
+	} else if row != nil {
+		return 0, fmt.Errorf("error path: %s, error: %w", op, row)
+	}
+
+	return chatID, nil
+}
+
+// checkChatMember - проверяем, что пользователь состоит в неудалённом чате. Возвращаем users_chat_id пользователя
+func checkChatMember(q preparer, op string, chatID int64, userID int) (int64, error) {
+	var usersChatID int64
+
+	// Скелет sql запроса на поиск участника чата
+	stmt, err := q.Prepare(`SELECT uc.id
+								FROM "users_chat" AS uc
+								INNER JOIN "chat" AS c
+								ON c.id = uc.chat_id
+								WHERE uc.chat_id = $1
+								  AND uc.user_id = $2
+								  AND c.is_deleted = false`)
+	if err != nil {
+		return 0, fmt.Errorf("error path: %s, error: %w", op, err)
+	}
+	defer stmt.Close()
+
+	// Если чата нет или пользователь в нём не состоит
+	if row := stmt.QueryRow(chatID, userID).Scan(&usersChatID); row != nil && row.Error() == errNoRows {
+		return 0, fmt.Errorf("error path: %s, error: %w", op,
+			apperr.NotFound(fmt.Sprintf("User with userID %d does not exist in chatID %d", userID, chatID)))
+	} else if row != nil {
+		return 0, fmt.Errorf("error path: %s, error: %w", op, row)
+	}
+
+	return usersChatID, nil
+}
+
+// checkChatAdmin - проверяем, что пользователь является администратором неудалённого чата.
+// Строка чата блокируется до конца транзакции, чтобы изменения чата администраторами шли последовательно.
+// Возвращаем users_chat_id пользователя
+func checkChatAdmin(tx preparer, op string, chatID int64, userID int) (int64, error) {
+	var usersChatID int64
+	var role string
+
+	// Скелет sql запроса на получение роли участника чата
+	stmt, err := tx.Prepare(`SELECT uc.id, uc.role
+								FROM "users_chat" AS uc
+								INNER JOIN "chat" AS c
+								ON c.id = uc.chat_id
+								WHERE uc.chat_id = $1
+								  AND uc.user_id = $2
+								  AND c.is_deleted = false
+								FOR UPDATE OF c`)
+	if err != nil {
+		return 0, fmt.Errorf("error path: %s, error: %w", op, err)
+	}
+	defer stmt.Close()
+
+	// Если чата нет или пользователь в нём не состоит
+	if row := stmt.QueryRow(chatID, userID).Scan(&usersChatID, &role); row != nil && row.Error() == errNoRows {
+		return 0, fmt.Errorf("error path: %s, error: %w", op,
+			apperr.NotFound(fmt.Sprintf("User with userID %d does not exist in chatID %d", userID, chatID)))
+	} else if row != nil {
+		return 0, fmt.Errorf("error path: %s, error: %w", op, row)
+	}
+
+	// Если пользователь не администратор чата
+	if role != roleAdmin {
+		return 0, fmt.Errorf("error path: %s, error: %w", op, apperr.Forbidden("Only chat admins can do this"))
+	}
+
+	return usersChatID, nil
+}
+
+// addBotMember - создаём служебного пользователя и добавляем его участником в чат. Возвращаем id пользователя
+func addBotMember(tx preparer, op string, chatID int64, botName string) (int64, error) {
+	// Скелет sql запроса на создание служебного пользователя и добавление его в чат
+	stmt, err := tx.Prepare(`WITH bot AS (
+									INSERT INTO "user" (username, password_hash, is_bot) VALUES ($1, $2, true)
+									RETURNING id
+								)
+								INSERT INTO "users_chat" (user_id, chat_id, role)
+								SELECT id, $3, $4 FROM bot
+								RETURNING user_id`)
+	if err != nil {
+		return 0, fmt.Errorf("error path: %s, error: %w", op, err)
+	}
+	defer stmt.Close()
+
+	var botID int64
+	if err = stmt.QueryRow(botName, botPasswordHash, chatID, roleMember).Scan(&botID); err != nil {
+		return 0, fmt.Errorf("error path: %s, error: %w", op, err)
+	}
+
+	return botID, nil
+}
+
+// deleteBotUser - помечаем служебного пользователя удалённым. Из чата он не удаляется, чтобы сохранились его сообщения
+func deleteBotUser(tx preparer, op string, botID int64) error {
+	// Скелет sql запроса на удаление служебного пользователя
+	stmt, err := tx.Prepare(`UPDATE "user" SET is_deleted = true WHERE id = $1 AND is_bot = true`)
+	if err != nil {
+		return fmt.Errorf("error path: %s, error: %w", op, err)
+	}
+	defer stmt.Close()
+
+	if _, err = stmt.Exec(botID); err != nil {
+		return fmt.Errorf("error path: %s, error: %w", op, err)
+	}
+
+	return nil
+}
+
+// messageChatID - получаем чат, в котором находится сообщение
+func messageChatID(tx preparer, op string, messageID int64) (int64, error) {
+	var chatID int64
+
+	// Скелет sql запроса на получение чата сообщения
+	stmt, err := tx.Prepare(`SELECT uc.chat_id
+									FROM "chats_messages" AS cm
+									INNER JOIN "users_chat" AS uc
+									ON uc.id = cm.users_chat_id
+									WHERE cm.message_id = $1`)
+	if err != nil {
+		return 0, fmt.Errorf("error path: %s, error: %w", op, err)
+	}
+	defer stmt.Close()
+
+	if row := stmt.QueryRow(messageID).Scan(&chatID); row != nil && row.Error() == errNoRows {
+		return 0, fmt.Errorf("error path: %s, error: %w", op, apperr.NotFound("Invalid message_id"))
+	} else if row != nil {
+		return 0, fmt.Errorf("error path: %s, error: %w", op, row)
+	}
+
+	return chatID, nil
+}
+
+// nullID - нулевой идентификатор передаём в запрос как NULL (фильтр не задан)
+func nullID(id int64) any {
+	if id == 0 {
+		return nil
+	}
+	return id
+}
+
+// nullTime - пустое время передаём в запрос как NULL, колонки timestamp хранят время в UTC
+func nullTime(t *time.Time) any {
+	if t == nil {
+		return nil
+	}
+	return t.UTC().Format("2006-01-02 15:04:05.999999")
+}
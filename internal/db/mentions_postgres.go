@@ -0,0 +1,171 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/lib/pq"
+
+	"service-chat/internal/db/entity"
+)
+
+const (
+	opMentionGet  = "db.GetMentions"
+	opMentionLoad = "db.loadMentions"
+)
+
+type MentionPostgres struct {
+	db *sql.DB
+}
+
+func NewMentionPostgres(db *sql.DB) *MentionPostgres {
+	return &MentionPostgres{db: db}
+}
+
+// GetMentions - получаем ленту сообщений, в которых упомянут пользователь, от новых к старым.
+// В ленту попадают только сообщения из неудалённых чатов, в которых пользователь состоит
+func (r *MentionPostgres) GetMentions(in entity.MentionGet) ([]entity.Message, error) {
+	// Скелет sql запроса на получение сообщений с упоминанием пользователя
+	stmt, err := r.db.Prepare(`SELECT m.id, m.text, m.user_id, m.created_at, m.is_deleted, m.format, author.chat_id
+									FROM "message" AS m
+									INNER JOIN "chats_messages" AS cm
+									ON cm.message_id = m.id
+									INNER JOIN "users_chat" AS author
+									ON author.id = cm.users_chat_id
+									INNER JOIN "chat" AS c
+									ON c.id = author.chat_id
+									WHERE m.id IN (SELECT message_id FROM "mention" WHERE user_id = $1)
+									  AND m.is_deleted = false
+									  AND c.is_deleted = false
+									  AND author.chat_id IN (SELECT chat_id FROM "users_chat" WHERE user_id = $1)
+									ORDER BY m.id DESC
+									LIMIT $2 OFFSET $3`)
+	if err != nil {
+		return nil, fmt.Errorf("error path: %s, error: %w", opMentionGet, err)
+	}
+	defer stmt.Close()
+
+	// Получаем сообщения из бд
+	rows, err := stmt.Query(in.UserID, in.Limit, in.Offset)
+	if err != nil {
+		return nil, fmt.Errorf("error path: %s, error: %w", opMentionGet, err)
+	}
+	defer rows.Close()
+
+	// Структура для записи всех полученных сообщений из бд
+	var messages []entity.Message
+	for rows.Next() {
+		var msg entity.Message
+		if errSc := rows.Scan(&msg.Id, &msg.Text, &msg.UserID, &msg.CreatedAt, &msg.IsDeleted, &msg.Format, &msg.ChatID); errSc != nil {
+			return nil, fmt.Errorf("error path: %s, error: %w", opMentionGet, errSc)
+		}
+		messages = append(messages, msg)
+	}
+
+	// В конце проверяем строки на ошибки (best practice)
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error path: %s, error: %w", opMentionGet, err)
+	}
+
+	// Добавляем к сообщениям реакции, вложения, упоминания, закрепления и источники пересылки
+	if err = enrichMessages(r.db, messages, in.UserID); err != nil {
+		return nil, err
+	}
+
+	return messages, nil
+}
+
+// saveMentions - заменяем упоминания сообщения, сохраняются только упоминания участников чата сообщения
+func saveMentions(tx preparer, op string, messageID int64, chatID int64, mentions []entity.MentionAdd) error {
+	// Скелет sql запроса на удаление прежних упоминаний (при редактировании сообщения)
+	stmtDel, err := tx.Prepare(`DELETE FROM "mention" WHERE message_id = $1`)
+	if err != nil {
+		return fmt.Errorf("error path: %s, error: %w", op, err)
+	}
+	defer stmtDel.Close()
+
+	if _, err = stmtDel.Exec(messageID); err != nil {
+		return fmt.Errorf("error path: %s, error: %w", op, err)
+	}
+
+	if len(mentions) == 0 {
+		return nil
+	}
+
+	usernames := make([]string, 0, len(mentions))
+	offsets := make([]int64, 0, len(mentions))
+	lengths := make([]int64, 0, len(mentions))
+	for _, mention := range mentions {
+		usernames = append(usernames, mention.Username)
+		offsets = append(offsets, int64(mention.Offset))
+		lengths = append(lengths, int64(mention.Length))
+	}
+
+	// Скелет sql запроса на сохранение упоминаний, имена пользователей сопоставляем с участниками чата.
+	// Пользователь, который внёс автора сообщения в чёрный список, упомянут не будет
+	stmtAdd, err := tx.Prepare(`INSERT INTO "mention" (message_id, user_id, "offset", "length")
+									SELECT $1, u.id, mn.off, mn.len
+									FROM unnest($3::text[], $4::integer[], $5::integer[]) AS mn(username, off, len)
+									INNER JOIN "user" AS u
+									ON u.username = mn.username
+									INNER JOIN "users_chat" AS uc
+									ON uc.user_id = u.id AND uc.chat_id = $2
+									WHERE NOT EXISTS (
+										SELECT 1 FROM "user_block" AS b
+										WHERE b.blocker_id = u.id
+										  AND b.blocked_id = (SELECT user_id FROM "message" WHERE id = $1)
+									)
+									ON CONFLICT DO NOTHING`)
+	if err != nil {
+		return fmt.Errorf("error path: %s, error: %w", op, err)
+	}
+	defer stmtAdd.Close()
+
+	if _, err = stmtAdd.Exec(messageID, chatID, pq.Array(usernames), pq.Array(offsets), pq.Array(lengths)); err != nil {
+		return fmt.Errorf("error path: %s, error: %w", op, err)
+	}
+
+	return nil
+}
+
+// loadMentions - получаем упоминания для списка сообщений
+func loadMentions(q preparer, messageIDs []int64) (map[int64][]entity.Mention, error) {
+	mentions := make(map[int64][]entity.Mention, len(messageIDs))
+	if len(messageIDs) == 0 {
+		return mentions, nil
+	}
+
+	// Скелет sql запроса на получение упоминаний по списку сообщений
+	stmt, err := q.Prepare(`SELECT mn.message_id, mn.user_id, u.username, mn."offset", mn."length"
+								FROM "mention" AS mn
+								INNER JOIN "user" AS u
+								ON u.id = mn.user_id
+								WHERE mn.message_id = ANY ($1)
+								ORDER BY mn.message_id, mn."offset"`)
+	if err != nil {
+		return nil, fmt.Errorf("error path: %s, error: %w", opMentionLoad, err)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.Query(pq.Array(messageIDs))
+	if err != nil {
+		return nil, fmt.Errorf("error path: %s, error: %w", opMentionLoad, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var messageID int64
+		var mention entity.Mention
+		if errSc := rows.Scan(&messageID, &mention.UserID, &mention.Username, &mention.Offset, &mention.Length); errSc != nil {
+			return nil, fmt.Errorf("error path: %s, error: %w", opMentionLoad, errSc)
+		}
+		mentions[messageID] = append(mentions[messageID], mention)
+	}
+
+	// В конце проверяем строки на ошибки (best practice)
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error path: %s, error: %w", opMentionLoad, err)
+	}
+
+	return mentions, nil
+}
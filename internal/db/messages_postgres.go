@@ -7,6 +7,7 @@ import (
 
 	"github.com/lib/pq"
 
+	"service-chat/internal/apperr"
 	"service-chat/internal/db/entity"
 )
 
@@ -15,6 +16,14 @@ const (
 	opMessageUpdate = "db.UpdateMessage"
 	opMessageGet    = "db.GetMessage"
 	opDelMsg        = "db.DeleteMessage"
+	opMessageChats  = "db.MessageChats"
+)
+
+// Типы сообщений
+const (
+	kindUser   = "user"
+	kindSystem = "system"
+	kindPoll   = "poll"
 )
 
 type MessagePostgres struct {
@@ -27,30 +36,42 @@ func NewMessagePostgres(db *sql.DB) *MessagePostgres {
 
 // AddMessage - сохраняем сообщение в чат от пользователя в бд и возвращаем message id
 func (m *MessagePostgres) AddMessage(in entity.MessageAdd) (int, error) {
-	var messageID int
-	var cmID int
-
 	// Начинаем транзакцию
 	tx, err := m.db.Begin()
 	if err != nil {
-		return 0, fmt.Errorf("error path: %s, error: %w", opCreateChat, err)
+		return 0, fmt.Errorf("error path: %s, error: %w", opMessageAdd, err)
+	}
+
+	// Сохраняем сообщение, связь с чатом и упоминания
+	messageID, errAdd := insertMessage(tx, opMessageAdd, kindUser, in)
+	if errAdd != nil {
+		// Откатываем транзакцию в случае ошибки
+		errTx := tx.Rollback()
+		if errTx != nil {
+			return 0, fmt.Errorf("error path: %s, error: %s", opMessageAdd, errTx)
+		}
+		return 0, errAdd
 	}
 
+	return messageID, tx.Commit()
+}
+
+// insertMessage - сохраняем сообщение пользователя вида kind внутри транзакции: само сообщение, его связь с чатом,
+// упоминания и события для вебхуков чата. Откат транзакции при ошибке остаётся на вызывающей стороне
+func insertMessage(tx preparer, op, kind string, in entity.MessageAdd) (int, error) {
+	var messageID int
+	var cmID int
+
 	// Скелет sql запроса на сохранение сообщения в бд
-	stmtAdd, errAdd := tx.Prepare(`INSERT INTO "message" (text, user_id) VALUES ($1, $2) RETURNING id`)
+	stmtAdd, errAdd := tx.Prepare(`INSERT INTO "message" (text, user_id, format, kind) VALUES ($1, $2, $3, $4) RETURNING id`)
 	if errAdd != nil {
-		return 0, fmt.Errorf("error path: %s, error: %w", opMessageAdd, errAdd)
+		return 0, fmt.Errorf("error path: %s, error: %w", op, errAdd)
 	}
 	defer stmtAdd.Close()
 
 	// Сохраняем сообщение от пользователя в бд
-	if rowAdd := tx.Stmt(stmtAdd).QueryRow(in.Text, in.UserID).Scan(&messageID); rowAdd != nil {
-		// Откатываем транзакцию в случае ошибки
-		errTx := tx.Rollback()
-		if errTx != nil {
-			return 0, fmt.Errorf("error path: %s, error: %s", opCreateChat, errTx)
-		}
-		return 0, fmt.Errorf("error path: %s, error: %w", opMessageAdd, rowAdd)
+	if rowAdd := stmtAdd.QueryRow(in.Text, in.UserID, messageFormat(in.Format), kind).Scan(&messageID); rowAdd != nil {
+		return 0, fmt.Errorf("error path: %s, error: %w", op, rowAdd)
 	}
 
 	// Скелет sql на связь users_chat_id и message_id в таблице chats_messages
@@ -62,47 +83,97 @@ func (m *MessagePostgres) AddMessage(in entity.MessageAdd) (int, error) {
 										SELECT id, $3 FROM uci 
 										RETURNING id`)
 	if errCm != nil {
-		return 0, fmt.Errorf("error path: %s, error: %w", opCreateChat, errCm)
+		return 0, fmt.Errorf("error path: %s, error: %w", op, errCm)
 	}
 	defer stmtCm.Close()
 
 	// Создаём связь users_chat_id и message_id в таблице chats_messages
-	if rowCm := tx.Stmt(stmtCm).QueryRow(in.UserID, in.ChatID, messageID).Scan(&cmID); rowCm != nil && rowCm.Error() == errNoRows {
-		errTx := tx.Rollback()
-		if errTx != nil {
-			return 0, fmt.Errorf("error path: %s, error: %s", opCreateChat, errTx)
-		}
-		return 0, fmt.Errorf("error path: %s, error: %s", opMessageAdd, "Invalid chat_id")
+	if rowCm := stmtCm.QueryRow(in.UserID, in.ChatID, messageID).Scan(&cmID); rowCm != nil && rowCm.Error() == errNoRows {
+		return 0, fmt.Errorf("error path: %s, error: %w", op, apperr.NotFound("Invalid chat_id"))
 	} else if rowCm != nil {
-		errTx := tx.Rollback()
-		if errTx != nil {
-			return 0, fmt.Errorf("error path: %s, error: %s", opCreateChat, errTx)
-		}
-		return 0, fmt.Errorf("error path: %s, error: %w", opMessageAdd, rowCm)
+		return 0, fmt.Errorf("error path: %s, error: %w", op, rowCm)
 	}
 
-	return messageID, tx.Commit()
+	// Сохраняем упоминания участников чата
+	if errMention := saveMentions(tx, op, int64(messageID), in.ChatID, in.Mentions); errMention != nil {
+		return 0, errMention
+	}
+
+	// Ставим событие о сообщении в очередь вебхуков чата
+	if errHook := enqueueWebhooks(tx, op, messageID, in); errHook != nil {
+		return 0, errHook
+	}
+
+	// Сообщение, на которое сработали фильтры, отправляем модераторам
+	if errFlag := flagMessage(tx, op, entity.MessageFlag{MessageID: int64(messageID), Filters: in.Flagged}); errFlag != nil {
+		return 0, errFlag
+	}
+
+	return messageID, nil
+}
+
+// messageFormat - формат текста сообщения, по умолчанию обычный текст
+func messageFormat(format string) string {
+	if format == "" {
+		return entity.FormatPlain
+	}
+	return format
 }
 
 // UpdateMessage - редактируем сообщение от пользователя в бд и возвращаем message id
 func (m *MessagePostgres) UpdateMessage(in entity.MessageUpdate) (int, error) {
 	var messageID int
 
+	// Начинаем транзакцию, вместе с текстом обновляются упоминания
+	tx, err := m.db.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("error path: %s, error: %w", opMessageUpdate, err)
+	}
+
 	// Скелет sql запроса на редактирование сообщения в бд
-	stmt, err := m.db.Prepare(`UPDATE "message" SET text = $1 WHERE id = $2 AND user_id = $3 RETURNING id`)
+	stmt, err := tx.Prepare(`UPDATE "message" SET text = $1, format = COALESCE(NULLIF($5, ''), format)
+									WHERE id = $2 AND user_id = $3 AND kind = $4 RETURNING id`)
 	if err != nil {
+		errTx := tx.Rollback()
+		if errTx != nil {
+			return 0, fmt.Errorf("error path: %s, error: %s", opMessageUpdate, errTx)
+		}
 		return 0, fmt.Errorf("error path: %s, error: %w", opMessageUpdate, err)
 	}
 	defer stmt.Close()
 
 	// Редактируем сообщение от пользователя в бд
-	if row := stmt.QueryRow(in.NewText, in.MessageID, in.UserID).Scan(&messageID); row != nil && row.Error() == errNoRows {
-		return 0, fmt.Errorf("error path: %s, error: %s", opMessageUpdate, "Invalid message_id OR user_id")
+	if row := stmt.QueryRow(in.NewText, in.MessageID, in.UserID, kindUser, in.Format).Scan(&messageID); row != nil && row.Error() == errNoRows {
+		errTx := tx.Rollback()
+		if errTx != nil {
+			return 0, fmt.Errorf("error path: %s, error: %s", opMessageUpdate, errTx)
+		}
+		return 0, fmt.Errorf("error path: %s, error: %w", opMessageUpdate, apperr.NotFound("Invalid message_id OR user_id"))
 	} else if row != nil {
+		errTx := tx.Rollback()
+		if errTx != nil {
+			return 0, fmt.Errorf("error path: %s, error: %s", opMessageUpdate, errTx)
+		}
 		return 0, fmt.Errorf("error path: %s, error: %w", opMessageUpdate, row)
 	}
 
-	return messageID, nil
+	// Получаем чат сообщения и заменяем упоминания
+	chatID, errChat := messageChatID(tx, opMessageUpdate, in.MessageID)
+	if errChat == nil {
+		errChat = saveMentions(tx, opMessageUpdate, in.MessageID, chatID, in.Mentions)
+	}
+	if errChat == nil {
+		errChat = flagMessage(tx, opMessageUpdate, entity.MessageFlag{MessageID: in.MessageID, Filters: in.Flagged})
+	}
+	if errChat != nil {
+		errTx := tx.Rollback()
+		if errTx != nil {
+			return 0, fmt.Errorf("error path: %s, error: %s", opMessageUpdate, errTx)
+		}
+		return 0, errChat
+	}
+
+	return messageID, tx.Commit()
 }
 
 // GetMessage - получаем список сообщений в конкретном чате из бд
@@ -156,7 +227,7 @@ func (m *MessagePostgres) GetMessage(in entity.MessageGet) ([]entity.Message, er
 	}
 	if !checkUser {
 		return nil, fmt.Errorf("error path: %s, error: %w", opMessageGet,
-			errors.New(fmt.Sprintf("User with userID %d does not exist in chatID %d", in.UserID, in.ChatID)))
+			apperr.NotFound(fmt.Sprintf("User with userID %d does not exist in chatID %d", in.UserID, in.ChatID)))
 	}
 
 	// Далее по users_chat_id (несколько) берём все messageID из таблицы chats_messages,
@@ -168,9 +239,11 @@ func (m *MessagePostgres) GetMessage(in entity.MessageGet) ([]entity.Message, er
 											FROM chats_messages
 											WHERE users_chat_id = ANY ($1)
 											)
-										SELECT *
+										SELECT id, CASE WHEN is_hidden THEN '' ELSE text END, user_id, created_at, is_deleted,
+										       is_hidden, format, kind
 										FROM message
 										WHERE id IN (SELECT message_id FROM cm)
+										  AND NOT ($4 AND user_id IN (SELECT blocked_id FROM "user_block" WHERE blocker_id = $5))
 										ORDER BY created_at
 										LIMIT $2 OFFSET $3`)
 	if err != nil {
@@ -179,7 +252,7 @@ func (m *MessagePostgres) GetMessage(in entity.MessageGet) ([]entity.Message, er
 	defer stmtMsg.Close()
 
 	// Получаем сообщения из бд
-	rowsMsg, err := stmtMsg.Query(pq.Array(uc.usersChatID), in.Limit, in.Offset)
+	rowsMsg, err := stmtMsg.Query(pq.Array(uc.usersChatID), in.Limit, in.Offset, in.HideBlocked, in.UserID)
 	if err != nil {
 		return nil, fmt.Errorf("error path: %s, error: %w", opMessageGet, err)
 	}
@@ -189,7 +262,8 @@ func (m *MessagePostgres) GetMessage(in entity.MessageGet) ([]entity.Message, er
 	var messages []entity.Message
 	for rowsMsg.Next() {
 		var msg entity.Message
-		if errSc := rowsMsg.Scan(&msg.Id, &msg.Text, &msg.UserID, &msg.CreatedAt, &msg.IsDeleted); errSc != nil {
+		if errSc := rowsMsg.Scan(&msg.Id, &msg.Text, &msg.UserID, &msg.CreatedAt, &msg.IsDeleted, &msg.IsHidden,
+			&msg.Format, &msg.Kind); errSc != nil {
 			return nil, fmt.Errorf("error path: %s, error: %w", opMessageGet, errSc)
 		}
 		messages = append(messages, msg)
@@ -200,9 +274,116 @@ func (m *MessagePostgres) GetMessage(in entity.MessageGet) ([]entity.Message, er
 		return nil, fmt.Errorf("error path: %s, error: %w", opMessageGet, err)
 	}
 
+	// Добавляем к сообщениям реакции, вложения, упоминания, закрепления, источники пересылки и опросы
+	if err = enrichMessages(m.db, messages, in.UserID); err != nil {
+		return nil, err
+	}
+
 	return messages, nil
 }
 
+// enrichMessages - добавляем к списку сообщений данные из связанных таблиц,
+// реакции считаются от лица пользователя, который запрашивает сообщения
+func enrichMessages(q preparer, messages []entity.Message, userID int) error {
+	ids := messageIDs(messages)
+
+	// Реакции пользователей
+	reactions, err := loadReactions(q, ids, userID)
+	if err != nil {
+		return err
+	}
+
+	// Вложения
+	attachments, err := loadAttachments(q, ids)
+	if err != nil {
+		return err
+	}
+
+	// Упоминания
+	mentions, err := loadMentions(q, ids)
+	if err != nil {
+		return err
+	}
+
+	// Закреплённые сообщения
+	pinned, err := loadPinned(q, ids)
+	if err != nil {
+		return err
+	}
+
+	// Источники пересланных сообщений
+	forwards, err := loadForwards(q, ids)
+	if err != nil {
+		return err
+	}
+
+	// Превью ссылок
+	previews, err := loadPreviews(q, ids)
+	if err != nil {
+		return err
+	}
+
+	// Опросы с текущими голосами
+	polls, err := loadPolls(q, ids, userID)
+	if err != nil {
+		return err
+	}
+
+	for i := range messages {
+		messages[i].Reactions = reactions[messages[i].Id]
+		messages[i].Attachments = attachments[messages[i].Id]
+		messages[i].Mentions = mentions[messages[i].Id]
+		messages[i].Pinned = pinned[messages[i].Id]
+		messages[i].ForwardedFrom = forwards[messages[i].Id]
+		messages[i].Previews = previews[messages[i].Id]
+		messages[i].Poll = polls[messages[i].Id]
+	}
+
+	return nil
+}
+
+// addSystemMessage - сохраняем служебное сообщение о событии в чате от лица пользователя, который его вызвал
+func addSystemMessage(tx preparer, op string, usersChatID int64, userID int, text string) error {
+	var messageID int64
+
+	// Скелет sql запроса на создание служебного сообщения
+	stmtMsg, err := tx.Prepare(`INSERT INTO "message" (text, user_id, kind) VALUES ($1, $2, $3) RETURNING id`)
+	if err != nil {
+		return fmt.Errorf("error path: %s, error: %w", op, err)
+	}
+	defer stmtMsg.Close()
+
+	if err = stmtMsg.QueryRow(text, userID, kindSystem).Scan(&messageID); err != nil {
+		return fmt.Errorf("error path: %s, error: %w", op, err)
+	}
+
+	// Скелет sql запроса на связь служебного сообщения с чатом
+	stmtCm, err := tx.Prepare(`INSERT INTO "chats_messages" (users_chat_id, message_id) VALUES ($1, $2)`)
+	if err != nil {
+		return fmt.Errorf("error path: %s, error: %w", op, err)
+	}
+	defer stmtCm.Close()
+
+	if _, err = stmtCm.Exec(usersChatID, messageID); err != nil {
+		return fmt.Errorf("error path: %s, error: %w", op, err)
+	}
+
+	return nil
+}
+
+// messageIDs - собираем id сообщений для дополнительных запросов по списку сообщений.
+// Скрытые модератором сообщения пропускаем: их вложения, упоминания и превью участникам не отдаются
+func messageIDs(messages []entity.Message) []int64 {
+	ids := make([]int64, 0, len(messages))
+	for _, msg := range messages {
+		if msg.IsHidden {
+			continue
+		}
+		ids = append(ids, msg.Id)
+	}
+	return ids
+}
+
 // DeleteMessage - soft удаление сообщений
 func (m *MessagePostgres) DeleteMessage(in entity.MessageDel) ([]entity.DelMsg, error) {
 	// Скелет sql запроса на удаление сообщений
@@ -214,7 +395,10 @@ func (m *MessagePostgres) DeleteMessage(in entity.MessageDel) ([]entity.DelMsg,
 
 	// Получаем информацию по результатам soft удаления сообщений из бд
 	rowsDel, err := stmtDelMsg.Query(in.UserID, pq.Array(in.MsgIds))
-	if err != nil {
+	var errPq *pq.Error
+	if errors.As(err, &errPq) && errPq.Message == errNoMessages {
+		return nil, fmt.Errorf("error path: %s, error: %w", opDelMsg, apperr.NotFound("Message not found"))
+	} else if err != nil {
 		return nil, fmt.Errorf("error path: %s, error: %w", opDelMsg, err)
 	}
 	defer rowsDel.Close()
@@ -236,3 +420,43 @@ func (m *MessagePostgres) DeleteMessage(in entity.MessageDel) ([]entity.DelMsg,
 
 	return delMsg, nil
 }
+
+// MessageChats - получаем чаты сообщений: ключ - id сообщения, значение - id чата
+func (m *MessagePostgres) MessageChats(messageIDs []int64) (map[int64]int64, error) {
+	chats := make(map[int64]int64, len(messageIDs))
+	if len(messageIDs) == 0 {
+		return chats, nil
+	}
+
+	// Скелет sql запроса на получение чатов сообщений
+	stmt, err := m.db.Prepare(`SELECT cm.message_id, uc.chat_id
+									FROM "chats_messages" AS cm
+									INNER JOIN "users_chat" AS uc
+									ON uc.id = cm.users_chat_id
+									WHERE cm.message_id = ANY ($1)`)
+	if err != nil {
+		return nil, fmt.Errorf("error path: %s, error: %w", opMessageChats, err)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.Query(pq.Array(messageIDs))
+	if err != nil {
+		return nil, fmt.Errorf("error path: %s, error: %w", opMessageChats, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var messageID, chatID int64
+		if errSc := rows.Scan(&messageID, &chatID); errSc != nil {
+			return nil, fmt.Errorf("error path: %s, error: %w", opMessageChats, errSc)
+		}
+		chats[messageID] = chatID
+	}
+
+	// В конце проверяем строки на ошибки (best practice)
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error path: %s, error: %w", opMessageChats, err)
+	}
+
+	return chats, nil
+}
@@ -12,6 +12,7 @@ package mock_db
 import (
 	reflect "reflect"
 	entity "service-chat/internal/db/entity"
+	time "time"
 
 	gomock "go.uber.org/mock/gomock"
 )
@@ -54,6 +55,21 @@ func (mr *MockAuthorizationMockRecorder) CreateUser(user any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockAuthorization)(nil).CreateUser), user)
 }
 
+// GetSuspension mocks base method.
+func (m *MockAuthorization) GetSuspension(userID int) (*time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSuspension", userID)
+	ret0, _ := ret[0].(*time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSuspension indicates an expected call of GetSuspension.
+func (mr *MockAuthorizationMockRecorder) GetSuspension(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSuspension", reflect.TypeOf((*MockAuthorization)(nil).GetSuspension), userID)
+}
+
 // GetUser mocks base method.
 func (m *MockAuthorization) GetUser(user entity.User) (*entity.User, error) {
 	m.ctrl.T.Helper()
@@ -190,6 +206,21 @@ func (mr *MockMessageMockRecorder) DeleteMessage(in any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMessage", reflect.TypeOf((*MockMessage)(nil).DeleteMessage), in)
 }
 
+// ForwardMessage mocks base method.
+func (m *MockMessage) ForwardMessage(in entity.MessageForward) ([]entity.Forwarded, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ForwardMessage", in)
+	ret0, _ := ret[0].([]entity.Forwarded)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ForwardMessage indicates an expected call of ForwardMessage.
+func (mr *MockMessageMockRecorder) ForwardMessage(in any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForwardMessage", reflect.TypeOf((*MockMessage)(nil).ForwardMessage), in)
+}
+
 // GetMessage mocks base method.
 func (m *MockMessage) GetMessage(in entity.MessageGet) ([]entity.Message, error) {
 	m.ctrl.T.Helper()
@@ -205,6 +236,36 @@ func (mr *MockMessageMockRecorder) GetMessage(in any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMessage", reflect.TypeOf((*MockMessage)(nil).GetMessage), in)
 }
 
+// MessageChats mocks base method.
+func (m *MockMessage) MessageChats(messageIDs []int64) (map[int64]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MessageChats", messageIDs)
+	ret0, _ := ret[0].(map[int64]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MessageChats indicates an expected call of MessageChats.
+func (mr *MockMessageMockRecorder) MessageChats(messageIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MessageChats", reflect.TypeOf((*MockMessage)(nil).MessageChats), messageIDs)
+}
+
+// SearchMessage mocks base method.
+func (m *MockMessage) SearchMessage(in entity.MessageSearch) ([]entity.SearchResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchMessage", in)
+	ret0, _ := ret[0].([]entity.SearchResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchMessage indicates an expected call of SearchMessage.
+func (mr *MockMessageMockRecorder) SearchMessage(in any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchMessage", reflect.TypeOf((*MockMessage)(nil).SearchMessage), in)
+}
+
 // UpdateMessage mocks base method.
 func (m *MockMessage) UpdateMessage(in entity.MessageUpdate) (int, error) {
 	m.ctrl.T.Helper()
@@ -219,3 +280,1207 @@ func (mr *MockMessageMockRecorder) UpdateMessage(in any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMessage", reflect.TypeOf((*MockMessage)(nil).UpdateMessage), in)
 }
+
+// MockReaction is a mock of Reaction interface.
+type MockReaction struct {
+	ctrl     *gomock.Controller
+	recorder *MockReactionMockRecorder
+}
+
+// MockReactionMockRecorder is the mock recorder for MockReaction.
+type MockReactionMockRecorder struct {
+	mock *MockReaction
+}
+
+// NewMockReaction creates a new mock instance.
+func NewMockReaction(ctrl *gomock.Controller) *MockReaction {
+	mock := &MockReaction{ctrl: ctrl}
+	mock.recorder = &MockReactionMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReaction) EXPECT() *MockReactionMockRecorder {
+	return m.recorder
+}
+
+// AddReaction mocks base method.
+func (m *MockReaction) AddReaction(in entity.ReactionAdd) ([]entity.Reaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddReaction", in)
+	ret0, _ := ret[0].([]entity.Reaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddReaction indicates an expected call of AddReaction.
+func (mr *MockReactionMockRecorder) AddReaction(in any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddReaction", reflect.TypeOf((*MockReaction)(nil).AddReaction), in)
+}
+
+// DeleteReaction mocks base method.
+func (m *MockReaction) DeleteReaction(in entity.ReactionDel) ([]entity.Reaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteReaction", in)
+	ret0, _ := ret[0].([]entity.Reaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteReaction indicates an expected call of DeleteReaction.
+func (mr *MockReactionMockRecorder) DeleteReaction(in any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteReaction", reflect.TypeOf((*MockReaction)(nil).DeleteReaction), in)
+}
+
+// MockAttachment is a mock of Attachment interface.
+type MockAttachment struct {
+	ctrl     *gomock.Controller
+	recorder *MockAttachmentMockRecorder
+}
+
+// MockAttachmentMockRecorder is the mock recorder for MockAttachment.
+type MockAttachmentMockRecorder struct {
+	mock *MockAttachment
+}
+
+// NewMockAttachment creates a new mock instance.
+func NewMockAttachment(ctrl *gomock.Controller) *MockAttachment {
+	mock := &MockAttachment{ctrl: ctrl}
+	mock.recorder = &MockAttachmentMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAttachment) EXPECT() *MockAttachmentMockRecorder {
+	return m.recorder
+}
+
+// AddAttachment mocks base method.
+func (m *MockAttachment) AddAttachment(in entity.AttachmentAdd) (*entity.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddAttachment", in)
+	ret0, _ := ret[0].(*entity.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddAttachment indicates an expected call of AddAttachment.
+func (mr *MockAttachmentMockRecorder) AddAttachment(in any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddAttachment", reflect.TypeOf((*MockAttachment)(nil).AddAttachment), in)
+}
+
+// GetAttachment mocks base method.
+func (m *MockAttachment) GetAttachment(in entity.AttachmentGet) (*entity.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAttachment", in)
+	ret0, _ := ret[0].(*entity.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAttachment indicates an expected call of GetAttachment.
+func (mr *MockAttachmentMockRecorder) GetAttachment(in any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAttachment", reflect.TypeOf((*MockAttachment)(nil).GetAttachment), in)
+}
+
+// MockMention is a mock of Mention interface.
+type MockMention struct {
+	ctrl     *gomock.Controller
+	recorder *MockMentionMockRecorder
+}
+
+// MockMentionMockRecorder is the mock recorder for MockMention.
+type MockMentionMockRecorder struct {
+	mock *MockMention
+}
+
+// NewMockMention creates a new mock instance.
+func NewMockMention(ctrl *gomock.Controller) *MockMention {
+	mock := &MockMention{ctrl: ctrl}
+	mock.recorder = &MockMentionMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMention) EXPECT() *MockMentionMockRecorder {
+	return m.recorder
+}
+
+// GetMentions mocks base method.
+func (m *MockMention) GetMentions(in entity.MentionGet) ([]entity.Message, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMentions", in)
+	ret0, _ := ret[0].([]entity.Message)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMentions indicates an expected call of GetMentions.
+func (mr *MockMentionMockRecorder) GetMentions(in any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMentions", reflect.TypeOf((*MockMention)(nil).GetMentions), in)
+}
+
+// MockPin is a mock of Pin interface.
+type MockPin struct {
+	ctrl     *gomock.Controller
+	recorder *MockPinMockRecorder
+}
+
+// MockPinMockRecorder is the mock recorder for MockPin.
+type MockPinMockRecorder struct {
+	mock *MockPin
+}
+
+// NewMockPin creates a new mock instance.
+func NewMockPin(ctrl *gomock.Controller) *MockPin {
+	mock := &MockPin{ctrl: ctrl}
+	mock.recorder = &MockPinMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPin) EXPECT() *MockPinMockRecorder {
+	return m.recorder
+}
+
+// GetPins mocks base method.
+func (m *MockPin) GetPins(in entity.PinGet) ([]entity.Message, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPins", in)
+	ret0, _ := ret[0].([]entity.Message)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPins indicates an expected call of GetPins.
+func (mr *MockPinMockRecorder) GetPins(in any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPins", reflect.TypeOf((*MockPin)(nil).GetPins), in)
+}
+
+// PinMessage mocks base method.
+func (m *MockPin) PinMessage(in entity.PinAdd) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PinMessage", in)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PinMessage indicates an expected call of PinMessage.
+func (mr *MockPinMockRecorder) PinMessage(in any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PinMessage", reflect.TypeOf((*MockPin)(nil).PinMessage), in)
+}
+
+// UnpinMessage mocks base method.
+func (m *MockPin) UnpinMessage(in entity.PinDel) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnpinMessage", in)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnpinMessage indicates an expected call of UnpinMessage.
+func (mr *MockPinMockRecorder) UnpinMessage(in any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnpinMessage", reflect.TypeOf((*MockPin)(nil).UnpinMessage), in)
+}
+
+// MockScheduled is a mock of Scheduled interface.
+type MockScheduled struct {
+	ctrl     *gomock.Controller
+	recorder *MockScheduledMockRecorder
+}
+
+// MockScheduledMockRecorder is the mock recorder for MockScheduled.
+type MockScheduledMockRecorder struct {
+	mock *MockScheduled
+}
+
+// NewMockScheduled creates a new mock instance.
+func NewMockScheduled(ctrl *gomock.Controller) *MockScheduled {
+	mock := &MockScheduled{ctrl: ctrl}
+	mock.recorder = &MockScheduledMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockScheduled) EXPECT() *MockScheduledMockRecorder {
+	return m.recorder
+}
+
+// AddScheduled mocks base method.
+func (m *MockScheduled) AddScheduled(in entity.ScheduledAdd) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddScheduled", in)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddScheduled indicates an expected call of AddScheduled.
+func (mr *MockScheduledMockRecorder) AddScheduled(in any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddScheduled", reflect.TypeOf((*MockScheduled)(nil).AddScheduled), in)
+}
+
+// CancelScheduled mocks base method.
+func (m *MockScheduled) CancelScheduled(in entity.ScheduledDel) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelScheduled", in)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CancelScheduled indicates an expected call of CancelScheduled.
+func (mr *MockScheduledMockRecorder) CancelScheduled(in any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelScheduled", reflect.TypeOf((*MockScheduled)(nil).CancelScheduled), in)
+}
+
+// ClaimScheduled mocks base method.
+func (m *MockScheduled) ClaimScheduled(in entity.ScheduledClaim) (*entity.ScheduledMessage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimScheduled", in)
+	ret0, _ := ret[0].(*entity.ScheduledMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimScheduled indicates an expected call of ClaimScheduled.
+func (mr *MockScheduledMockRecorder) ClaimScheduled(in any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimScheduled", reflect.TypeOf((*MockScheduled)(nil).ClaimScheduled), in)
+}
+
+// FinishScheduled mocks base method.
+func (m *MockScheduled) FinishScheduled(in entity.ScheduledFinish) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FinishScheduled", in)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FinishScheduled indicates an expected call of FinishScheduled.
+func (mr *MockScheduledMockRecorder) FinishScheduled(in any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FinishScheduled", reflect.TypeOf((*MockScheduled)(nil).FinishScheduled), in)
+}
+
+// GetDueScheduled mocks base method.
+func (m *MockScheduled) GetDueScheduled(in entity.ScheduledDue) ([]entity.ScheduledMessage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDueScheduled", in)
+	ret0, _ := ret[0].([]entity.ScheduledMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDueScheduled indicates an expected call of GetDueScheduled.
+func (mr *MockScheduledMockRecorder) GetDueScheduled(in any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDueScheduled", reflect.TypeOf((*MockScheduled)(nil).GetDueScheduled), in)
+}
+
+// GetScheduled mocks base method.
+func (m *MockScheduled) GetScheduled(in entity.ScheduledGet) ([]entity.ScheduledMessage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetScheduled", in)
+	ret0, _ := ret[0].([]entity.ScheduledMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetScheduled indicates an expected call of GetScheduled.
+func (mr *MockScheduledMockRecorder) GetScheduled(in any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetScheduled", reflect.TypeOf((*MockScheduled)(nil).GetScheduled), in)
+}
+
+// UpdateScheduled mocks base method.
+func (m *MockScheduled) UpdateScheduled(in entity.ScheduledUpdate) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateScheduled", in)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateScheduled indicates an expected call of UpdateScheduled.
+func (mr *MockScheduledMockRecorder) UpdateScheduled(in any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateScheduled", reflect.TypeOf((*MockScheduled)(nil).UpdateScheduled), in)
+}
+
+// MockDraft is a mock of Draft interface.
+type MockDraft struct {
+	ctrl     *gomock.Controller
+	recorder *MockDraftMockRecorder
+}
+
+// MockDraftMockRecorder is the mock recorder for MockDraft.
+type MockDraftMockRecorder struct {
+	mock *MockDraft
+}
+
+// NewMockDraft creates a new mock instance.
+func NewMockDraft(ctrl *gomock.Controller) *MockDraft {
+	mock := &MockDraft{ctrl: ctrl}
+	mock.recorder = &MockDraftMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDraft) EXPECT() *MockDraftMockRecorder {
+	return m.recorder
+}
+
+// GetDraft mocks base method.
+func (m *MockDraft) GetDraft(in entity.DraftGet) (*entity.Draft, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDraft", in)
+	ret0, _ := ret[0].(*entity.Draft)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDraft indicates an expected call of GetDraft.
+func (mr *MockDraftMockRecorder) GetDraft(in any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDraft", reflect.TypeOf((*MockDraft)(nil).GetDraft), in)
+}
+
+// SaveDraft mocks base method.
+func (m *MockDraft) SaveDraft(in entity.DraftSave) (*entity.Draft, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveDraft", in)
+	ret0, _ := ret[0].(*entity.Draft)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// SaveDraft indicates an expected call of SaveDraft.
+func (mr *MockDraftMockRecorder) SaveDraft(in any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveDraft", reflect.TypeOf((*MockDraft)(nil).SaveDraft), in)
+}
+
+// MockPreview is a mock of Preview interface.
+type MockPreview struct {
+	ctrl     *gomock.Controller
+	recorder *MockPreviewMockRecorder
+}
+
+// MockPreviewMockRecorder is the mock recorder for MockPreview.
+type MockPreviewMockRecorder struct {
+	mock *MockPreview
+}
+
+// NewMockPreview creates a new mock instance.
+func NewMockPreview(ctrl *gomock.Controller) *MockPreview {
+	mock := &MockPreview{ctrl: ctrl}
+	mock.recorder = &MockPreviewMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPreview) EXPECT() *MockPreviewMockRecorder {
+	return m.recorder
+}
+
+// AddMessageLinks mocks base method.
+func (m *MockPreview) AddMessageLinks(in entity.MessageLinks) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddMessageLinks", in)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddMessageLinks indicates an expected call of AddMessageLinks.
+func (mr *MockPreviewMockRecorder) AddMessageLinks(in any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddMessageLinks", reflect.TypeOf((*MockPreview)(nil).AddMessageLinks), in)
+}
+
+// GetPreview mocks base method.
+func (m *MockPreview) GetPreview(url string) (*entity.LinkPreview, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPreview", url)
+	ret0, _ := ret[0].(*entity.LinkPreview)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPreview indicates an expected call of GetPreview.
+func (mr *MockPreviewMockRecorder) GetPreview(url any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPreview", reflect.TypeOf((*MockPreview)(nil).GetPreview), url)
+}
+
+// SavePreview mocks base method.
+func (m *MockPreview) SavePreview(in entity.LinkPreview) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SavePreview", in)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SavePreview indicates an expected call of SavePreview.
+func (mr *MockPreviewMockRecorder) SavePreview(in any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SavePreview", reflect.TypeOf((*MockPreview)(nil).SavePreview), in)
+}
+
+// MockPresence is a mock of Presence interface.
+type MockPresence struct {
+	ctrl     *gomock.Controller
+	recorder *MockPresenceMockRecorder
+}
+
+// MockPresenceMockRecorder is the mock recorder for MockPresence.
+type MockPresenceMockRecorder struct {
+	mock *MockPresence
+}
+
+// NewMockPresence creates a new mock instance.
+func NewMockPresence(ctrl *gomock.Controller) *MockPresence {
+	mock := &MockPresence{ctrl: ctrl}
+	mock.recorder = &MockPresenceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPresence) EXPECT() *MockPresenceMockRecorder {
+	return m.recorder
+}
+
+// CheckMember mocks base method.
+func (m *MockPresence) CheckMember(in entity.PresenceGet) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckMember", in)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CheckMember indicates an expected call of CheckMember.
+func (mr *MockPresenceMockRecorder) CheckMember(in any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckMember", reflect.TypeOf((*MockPresence)(nil).CheckMember), in)
+}
+
+// GetChatMembers mocks base method.
+func (m *MockPresence) GetChatMembers(in entity.PresenceGet) ([]entity.Presence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChatMembers", in)
+	ret0, _ := ret[0].([]entity.Presence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChatMembers indicates an expected call of GetChatMembers.
+func (mr *MockPresenceMockRecorder) GetChatMembers(in any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChatMembers", reflect.TypeOf((*MockPresence)(nil).GetChatMembers), in)
+}
+
+// GetUserChats mocks base method.
+func (m *MockPresence) GetUserChats(userID int64) ([]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserChats", userID)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserChats indicates an expected call of GetUserChats.
+func (mr *MockPresenceMockRecorder) GetUserChats(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserChats", reflect.TypeOf((*MockPresence)(nil).GetUserChats), userID)
+}
+
+// SaveLastSeen mocks base method.
+func (m *MockPresence) SaveLastSeen(in entity.PresenceSeen) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveLastSeen", in)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveLastSeen indicates an expected call of SaveLastSeen.
+func (mr *MockPresenceMockRecorder) SaveLastSeen(in any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveLastSeen", reflect.TypeOf((*MockPresence)(nil).SaveLastSeen), in)
+}
+
+// MockWebhook is a mock of Webhook interface.
+type MockWebhook struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookMockRecorder
+}
+
+// MockWebhookMockRecorder is the mock recorder for MockWebhook.
+type MockWebhookMockRecorder struct {
+	mock *MockWebhook
+}
+
+// NewMockWebhook creates a new mock instance.
+func NewMockWebhook(ctrl *gomock.Controller) *MockWebhook {
+	mock := &MockWebhook{ctrl: ctrl}
+	mock.recorder = &MockWebhookMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhook) EXPECT() *MockWebhookMockRecorder {
+	return m.recorder
+}
+
+// AddWebhook mocks base method.
+func (m *MockWebhook) AddWebhook(in entity.WebhookAdd) (*entity.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddWebhook", in)
+	ret0, _ := ret[0].(*entity.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddWebhook indicates an expected call of AddWebhook.
+func (mr *MockWebhookMockRecorder) AddWebhook(in any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddWebhook", reflect.TypeOf((*MockWebhook)(nil).AddWebhook), in)
+}
+
+// ClaimDeliveries mocks base method.
+func (m *MockWebhook) ClaimDeliveries(in entity.WebhookClaim) ([]entity.WebhookJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimDeliveries", in)
+	ret0, _ := ret[0].([]entity.WebhookJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimDeliveries indicates an expected call of ClaimDeliveries.
+func (mr *MockWebhookMockRecorder) ClaimDeliveries(in any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimDeliveries", reflect.TypeOf((*MockWebhook)(nil).ClaimDeliveries), in)
+}
+
+// DeleteWebhook mocks base method.
+func (m *MockWebhook) DeleteWebhook(in entity.WebhookDel) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWebhook", in)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteWebhook indicates an expected call of DeleteWebhook.
+func (mr *MockWebhookMockRecorder) DeleteWebhook(in any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWebhook", reflect.TypeOf((*MockWebhook)(nil).DeleteWebhook), in)
+}
+
+// GetWebhookAttempts mocks base method.
+func (m *MockWebhook) GetWebhookAttempts(in entity.WebhookAttemptsGet) ([]entity.WebhookAttempt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhookAttempts", in)
+	ret0, _ := ret[0].([]entity.WebhookAttempt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWebhookAttempts indicates an expected call of GetWebhookAttempts.
+func (mr *MockWebhookMockRecorder) GetWebhookAttempts(in any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhookAttempts", reflect.TypeOf((*MockWebhook)(nil).GetWebhookAttempts), in)
+}
+
+// GetWebhooks mocks base method.
+func (m *MockWebhook) GetWebhooks(in entity.WebhookGet) ([]entity.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhooks", in)
+	ret0, _ := ret[0].([]entity.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWebhooks indicates an expected call of GetWebhooks.
+func (mr *MockWebhookMockRecorder) GetWebhooks(in any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhooks", reflect.TypeOf((*MockWebhook)(nil).GetWebhooks), in)
+}
+
+// SaveAttempt mocks base method.
+func (m *MockWebhook) SaveAttempt(in entity.WebhookResult) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveAttempt", in)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveAttempt indicates an expected call of SaveAttempt.
+func (mr *MockWebhookMockRecorder) SaveAttempt(in any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveAttempt", reflect.TypeOf((*MockWebhook)(nil).SaveAttempt), in)
+}
+
+// MockIncoming is a mock of Incoming interface.
+type MockIncoming struct {
+	ctrl     *gomock.Controller
+	recorder *MockIncomingMockRecorder
+}
+
+// MockIncomingMockRecorder is the mock recorder for MockIncoming.
+type MockIncomingMockRecorder struct {
+	mock *MockIncoming
+}
+
+// NewMockIncoming creates a new mock instance.
+func NewMockIncoming(ctrl *gomock.Controller) *MockIncoming {
+	mock := &MockIncoming{ctrl: ctrl}
+	mock.recorder = &MockIncomingMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIncoming) EXPECT() *MockIncomingMockRecorder {
+	return m.recorder
+}
+
+// AddIncoming mocks base method.
+func (m *MockIncoming) AddIncoming(in entity.IncomingAdd) (*entity.IncomingWebhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddIncoming", in)
+	ret0, _ := ret[0].(*entity.IncomingWebhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddIncoming indicates an expected call of AddIncoming.
+func (mr *MockIncomingMockRecorder) AddIncoming(in any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddIncoming", reflect.TypeOf((*MockIncoming)(nil).AddIncoming), in)
+}
+
+// DeleteIncoming mocks base method.
+func (m *MockIncoming) DeleteIncoming(in entity.IncomingDel) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteIncoming", in)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteIncoming indicates an expected call of DeleteIncoming.
+func (mr *MockIncomingMockRecorder) DeleteIncoming(in any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteIncoming", reflect.TypeOf((*MockIncoming)(nil).DeleteIncoming), in)
+}
+
+// GetIncoming mocks base method.
+func (m *MockIncoming) GetIncoming(in entity.IncomingGet) ([]entity.IncomingWebhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIncoming", in)
+	ret0, _ := ret[0].([]entity.IncomingWebhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIncoming indicates an expected call of GetIncoming.
+func (mr *MockIncomingMockRecorder) GetIncoming(in any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIncoming", reflect.TypeOf((*MockIncoming)(nil).GetIncoming), in)
+}
+
+// GetIncomingByToken mocks base method.
+func (m *MockIncoming) GetIncomingByToken(tokenHash string) (*entity.IncomingWebhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIncomingByToken", tokenHash)
+	ret0, _ := ret[0].(*entity.IncomingWebhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIncomingByToken indicates an expected call of GetIncomingByToken.
+func (mr *MockIncomingMockRecorder) GetIncomingByToken(tokenHash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIncomingByToken", reflect.TypeOf((*MockIncoming)(nil).GetIncomingByToken), tokenHash)
+}
+
+// MockCommand is a mock of Command interface.
+type MockCommand struct {
+	ctrl     *gomock.Controller
+	recorder *MockCommandMockRecorder
+}
+
+// MockCommandMockRecorder is the mock recorder for MockCommand.
+type MockCommandMockRecorder struct {
+	mock *MockCommand
+}
+
+// NewMockCommand creates a new mock instance.
+func NewMockCommand(ctrl *gomock.Controller) *MockCommand {
+	mock := &MockCommand{ctrl: ctrl}
+	mock.recorder = &MockCommandMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCommand) EXPECT() *MockCommandMockRecorder {
+	return m.recorder
+}
+
+// AddCommand mocks base method.
+func (m *MockCommand) AddCommand(in entity.CommandAdd) (*entity.ChatCommand, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddCommand", in)
+	ret0, _ := ret[0].(*entity.ChatCommand)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddCommand indicates an expected call of AddCommand.
+func (mr *MockCommandMockRecorder) AddCommand(in any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddCommand", reflect.TypeOf((*MockCommand)(nil).AddCommand), in)
+}
+
+// DeleteCommand mocks base method.
+func (m *MockCommand) DeleteCommand(in entity.CommandDel) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCommand", in)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCommand indicates an expected call of DeleteCommand.
+func (mr *MockCommandMockRecorder) DeleteCommand(in any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCommand", reflect.TypeOf((*MockCommand)(nil).DeleteCommand), in)
+}
+
+// FindCommand mocks base method.
+func (m *MockCommand) FindCommand(in entity.CommandFind) (*entity.ChatCommand, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindCommand", in)
+	ret0, _ := ret[0].(*entity.ChatCommand)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindCommand indicates an expected call of FindCommand.
+func (mr *MockCommandMockRecorder) FindCommand(in any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindCommand", reflect.TypeOf((*MockCommand)(nil).FindCommand), in)
+}
+
+// GetCommands mocks base method.
+func (m *MockCommand) GetCommands(in entity.CommandGet) ([]entity.ChatCommand, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCommands", in)
+	ret0, _ := ret[0].([]entity.ChatCommand)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCommands indicates an expected call of GetCommands.
+func (mr *MockCommandMockRecorder) GetCommands(in any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCommands", reflect.TypeOf((*MockCommand)(nil).GetCommands), in)
+}
+
+// MockPoll is a mock of Poll interface.
+type MockPoll struct {
+	ctrl     *gomock.Controller
+	recorder *MockPollMockRecorder
+}
+
+// MockPollMockRecorder is the mock recorder for MockPoll.
+type MockPollMockRecorder struct {
+	mock *MockPoll
+}
+
+// NewMockPoll creates a new mock instance.
+func NewMockPoll(ctrl *gomock.Controller) *MockPoll {
+	mock := &MockPoll{ctrl: ctrl}
+	mock.recorder = &MockPollMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPoll) EXPECT() *MockPollMockRecorder {
+	return m.recorder
+}
+
+// AddPoll mocks base method.
+func (m *MockPoll) AddPoll(in entity.PollAdd) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddPoll", in)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddPoll indicates an expected call of AddPoll.
+func (mr *MockPollMockRecorder) AddPoll(in any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddPoll", reflect.TypeOf((*MockPoll)(nil).AddPoll), in)
+}
+
+// Vote mocks base method.
+func (m *MockPoll) Vote(in entity.PollVote) (*entity.Poll, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Vote", in)
+	ret0, _ := ret[0].(*entity.Poll)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Vote indicates an expected call of Vote.
+func (mr *MockPollMockRecorder) Vote(in any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Vote", reflect.TypeOf((*MockPoll)(nil).Vote), in)
+}
+
+// MockBlock is a mock of Block interface.
+type MockBlock struct {
+	ctrl     *gomock.Controller
+	recorder *MockBlockMockRecorder
+}
+
+// MockBlockMockRecorder is the mock recorder for MockBlock.
+type MockBlockMockRecorder struct {
+	mock *MockBlock
+}
+
+// NewMockBlock creates a new mock instance.
+func NewMockBlock(ctrl *gomock.Controller) *MockBlock {
+	mock := &MockBlock{ctrl: ctrl}
+	mock.recorder = &MockBlockMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBlock) EXPECT() *MockBlockMockRecorder {
+	return m.recorder
+}
+
+// AddBlock mocks base method.
+func (m *MockBlock) AddBlock(in entity.BlockAdd) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddBlock", in)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddBlock indicates an expected call of AddBlock.
+func (mr *MockBlockMockRecorder) AddBlock(in any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddBlock", reflect.TypeOf((*MockBlock)(nil).AddBlock), in)
+}
+
+// DeleteBlock mocks base method.
+func (m *MockBlock) DeleteBlock(in entity.BlockDel) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBlock", in)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteBlock indicates an expected call of DeleteBlock.
+func (mr *MockBlockMockRecorder) DeleteBlock(in any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBlock", reflect.TypeOf((*MockBlock)(nil).DeleteBlock), in)
+}
+
+// GetBlocks mocks base method.
+func (m *MockBlock) GetBlocks(userID int) ([]entity.Block, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBlocks", userID)
+	ret0, _ := ret[0].([]entity.Block)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBlocks indicates an expected call of GetBlocks.
+func (mr *MockBlockMockRecorder) GetBlocks(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlocks", reflect.TypeOf((*MockBlock)(nil).GetBlocks), userID)
+}
+
+// MockModeration is a mock of Moderation interface.
+type MockModeration struct {
+	ctrl     *gomock.Controller
+	recorder *MockModerationMockRecorder
+}
+
+// MockModerationMockRecorder is the mock recorder for MockModeration.
+type MockModerationMockRecorder struct {
+	mock *MockModeration
+}
+
+// NewMockModeration creates a new mock instance.
+func NewMockModeration(ctrl *gomock.Controller) *MockModeration {
+	mock := &MockModeration{ctrl: ctrl}
+	mock.recorder = &MockModerationMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockModeration) EXPECT() *MockModerationMockRecorder {
+	return m.recorder
+}
+
+// AddReport mocks base method.
+func (m *MockModeration) AddReport(in entity.ReportAdd) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddReport", in)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddReport indicates an expected call of AddReport.
+func (mr *MockModerationMockRecorder) AddReport(in any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddReport", reflect.TypeOf((*MockModeration)(nil).AddReport), in)
+}
+
+// GetActions mocks base method.
+func (m *MockModeration) GetActions(in entity.ActionsGet) ([]entity.ModerationAction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActions", in)
+	ret0, _ := ret[0].([]entity.ModerationAction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActions indicates an expected call of GetActions.
+func (mr *MockModerationMockRecorder) GetActions(in any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActions", reflect.TypeOf((*MockModeration)(nil).GetActions), in)
+}
+
+// GetReports mocks base method.
+func (m *MockModeration) GetReports(in entity.ReportsGet) ([]entity.Report, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReports", in)
+	ret0, _ := ret[0].([]entity.Report)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReports indicates an expected call of GetReports.
+func (mr *MockModerationMockRecorder) GetReports(in any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReports", reflect.TypeOf((*MockModeration)(nil).GetReports), in)
+}
+
+// ModerateReport mocks base method.
+func (m *MockModeration) ModerateReport(in entity.ModerationAdd) (*entity.ModerationAction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ModerateReport", in)
+	ret0, _ := ret[0].(*entity.ModerationAction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ModerateReport indicates an expected call of ModerateReport.
+func (mr *MockModerationMockRecorder) ModerateReport(in any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ModerateReport", reflect.TypeOf((*MockModeration)(nil).ModerateReport), in)
+}
+
+// MockFilter is a mock of Filter interface.
+type MockFilter struct {
+	ctrl     *gomock.Controller
+	recorder *MockFilterMockRecorder
+}
+
+// MockFilterMockRecorder is the mock recorder for MockFilter.
+type MockFilterMockRecorder struct {
+	mock *MockFilter
+}
+
+// NewMockFilter creates a new mock instance.
+func NewMockFilter(ctrl *gomock.Controller) *MockFilter {
+	mock := &MockFilter{ctrl: ctrl}
+	mock.recorder = &MockFilterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFilter) EXPECT() *MockFilterMockRecorder {
+	return m.recorder
+}
+
+// ChatFilterActions mocks base method.
+func (m *MockFilter) ChatFilterActions(chatID int64) (map[string]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChatFilterActions", chatID)
+	ret0, _ := ret[0].(map[string]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChatFilterActions indicates an expected call of ChatFilterActions.
+func (mr *MockFilterMockRecorder) ChatFilterActions(chatID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChatFilterActions", reflect.TypeOf((*MockFilter)(nil).ChatFilterActions), chatID)
+}
+
+// GetChatFilters mocks base method.
+func (m *MockFilter) GetChatFilters(in entity.ChatFiltersGet) (map[string]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChatFilters", in)
+	ret0, _ := ret[0].(map[string]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChatFilters indicates an expected call of GetChatFilters.
+func (mr *MockFilterMockRecorder) GetChatFilters(in any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChatFilters", reflect.TypeOf((*MockFilter)(nil).GetChatFilters), in)
+}
+
+// SetChatFilter mocks base method.
+func (m *MockFilter) SetChatFilter(in entity.ChatFilterSet) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetChatFilter", in)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetChatFilter indicates an expected call of SetChatFilter.
+func (mr *MockFilterMockRecorder) SetChatFilter(in any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetChatFilter", reflect.TypeOf((*MockFilter)(nil).SetChatFilter), in)
+}
+
+// MockNotification is a mock of Notification interface.
+type MockNotification struct {
+	ctrl     *gomock.Controller
+	recorder *MockNotificationMockRecorder
+}
+
+// MockNotificationMockRecorder is the mock recorder for MockNotification.
+type MockNotificationMockRecorder struct {
+	mock *MockNotification
+}
+
+// NewMockNotification creates a new mock instance.
+func NewMockNotification(ctrl *gomock.Controller) *MockNotification {
+	mock := &MockNotification{ctrl: ctrl}
+	mock.recorder = &MockNotificationMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNotification) EXPECT() *MockNotificationMockRecorder {
+	return m.recorder
+}
+
+// AddNotifications mocks base method.
+func (m *MockNotification) AddNotifications(in []entity.NotificationAdd) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddNotifications", in)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddNotifications indicates an expected call of AddNotifications.
+func (mr *MockNotificationMockRecorder) AddNotifications(in any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddNotifications", reflect.TypeOf((*MockNotification)(nil).AddNotifications), in)
+}
+
+// ClaimNotifications mocks base method.
+func (m *MockNotification) ClaimNotifications(in entity.NotificationClaim) ([]entity.NotificationJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimNotifications", in)
+	ret0, _ := ret[0].([]entity.NotificationJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimNotifications indicates an expected call of ClaimNotifications.
+func (mr *MockNotificationMockRecorder) ClaimNotifications(in any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimNotifications", reflect.TypeOf((*MockNotification)(nil).ClaimNotifications), in)
+}
+
+// CompleteNotifications mocks base method.
+func (m *MockNotification) CompleteNotifications(in entity.NotificationResult) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteNotifications", in)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CompleteNotifications indicates an expected call of CompleteNotifications.
+func (mr *MockNotificationMockRecorder) CompleteNotifications(in any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteNotifications", reflect.TypeOf((*MockNotification)(nil).CompleteNotifications), in)
+}
+
+// GetNotifySettings mocks base method.
+func (m *MockNotification) GetNotifySettings(userID int) (*entity.NotifySettings, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNotifySettings", userID)
+	ret0, _ := ret[0].(*entity.NotifySettings)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNotifySettings indicates an expected call of GetNotifySettings.
+func (mr *MockNotificationMockRecorder) GetNotifySettings(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNotifySettings", reflect.TypeOf((*MockNotification)(nil).GetNotifySettings), userID)
+}
+
+// GetRecipients mocks base method.
+func (m *MockNotification) GetRecipients(in entity.RecipientsGet) ([]entity.NotifyRecipient, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecipients", in)
+	ret0, _ := ret[0].([]entity.NotifyRecipient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRecipients indicates an expected call of GetRecipients.
+func (mr *MockNotificationMockRecorder) GetRecipients(in any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecipients", reflect.TypeOf((*MockNotification)(nil).GetRecipients), in)
+}
+
+// SaveNotifySettings mocks base method.
+func (m *MockNotification) SaveNotifySettings(in entity.NotifySettingsSave) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveNotifySettings", in)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveNotifySettings indicates an expected call of SaveNotifySettings.
+func (mr *MockNotificationMockRecorder) SaveNotifySettings(in any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveNotifySettings", reflect.TypeOf((*MockNotification)(nil).SaveNotifySettings), in)
+}
+
+// SetChatNotify mocks base method.
+func (m *MockNotification) SetChatNotify(in entity.ChatNotifySet) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetChatNotify", in)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetChatNotify indicates an expected call of SetChatNotify.
+func (mr *MockNotificationMockRecorder) SetChatNotify(in any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetChatNotify", reflect.TypeOf((*MockNotification)(nil).SetChatNotify), in)
+}
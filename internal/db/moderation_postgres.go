@@ -0,0 +1,311 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+
+	"service-chat/internal/apperr"
+	"service-chat/internal/db/entity"
+)
+
+const (
+	opReportAdd  = "db.AddReport"
+	opReportsGet = "db.GetReports"
+	opModerate   = "db.ModerateReport"
+	opActionsGet = "db.GetActions"
+)
+
+type ModerationPostgres struct {
+	db *sql.DB
+}
+
+func NewModerationPostgres(db *sql.DB) *ModerationPostgres {
+	return &ModerationPostgres{db: db}
+}
+
+// AddReport - жалоба участника чата на сообщение, на одно сообщение пользователь жалуется один раз. Возвращаем id жалобы
+func (m *ModerationPostgres) AddReport(in entity.ReportAdd) (int64, error) {
+	if _, errMember := checkMessageMember(m.db, opReportAdd, in.MessageID, in.UserID); errMember != nil {
+		return 0, errMember
+	}
+
+	// Скелет sql запроса на получение автора сообщения
+	stmtAuthor, err := m.db.Prepare(`SELECT user_id FROM "message" WHERE id = $1`)
+	if err != nil {
+		return 0, fmt.Errorf("error path: %s, error: %w", opReportAdd, err)
+	}
+	defer stmtAuthor.Close()
+
+	var authorID int64
+	if err = stmtAuthor.QueryRow(in.MessageID).Scan(&authorID); err != nil {
+		return 0, fmt.Errorf("error path: %s, error: %w", opReportAdd, err)
+	}
+	if authorID == int64(in.UserID) {
+		return 0, fmt.Errorf("error path: %s, error: %w", opReportAdd, apperr.Validation("Cannot report your own message"))
+	}
+
+	// Скелет sql запроса на добавление жалобы
+	stmt, err := m.db.Prepare(`INSERT INTO "report" (message_id, reporter_id, reason, comment) VALUES ($1, $2, $3, $4)
+									ON CONFLICT (message_id, reporter_id) DO NOTHING
+									RETURNING id`)
+	if err != nil {
+		return 0, fmt.Errorf("error path: %s, error: %w", opReportAdd, err)
+	}
+	defer stmt.Close()
+
+	var reportID int64
+	if row := stmt.QueryRow(in.MessageID, in.UserID, in.Reason, in.Comment).Scan(&reportID); row != nil && row.Error() == errNoRows {
+		return 0, fmt.Errorf("error path: %s, error: %w", opReportAdd, apperr.Conflict("Message already reported"))
+	} else if row != nil {
+		return 0, fmt.Errorf("error path: %s, error: %w", opReportAdd, row)
+	}
+
+	return reportID, nil
+}
+
+// GetReports - очередь жалоб для модератора, старые жалобы идут первыми
+func (m *ModerationPostgres) GetReports(in entity.ReportsGet) ([]entity.Report, error) {
+	if errModerator := checkModerator(m.db, opReportsGet, in.UserID); errModerator != nil {
+		return nil, errModerator
+	}
+
+	// Скелет sql запроса на получение жалоб вместе с сообщением и его чатом
+	stmt, err := m.db.Prepare(`SELECT r.id, r.message_id,
+										COALESCE((SELECT uc.chat_id FROM "chats_messages" AS cm
+												  INNER JOIN "users_chat" AS uc
+												  ON uc.id = cm.users_chat_id
+												  WHERE cm.message_id = r.message_id), 0),
+										msg.user_id, msg.text, COALESCE(r.reporter_id, 0), r.reason, r.comment, r.status,
+										(SELECT COUNT(*) FROM "report" AS o WHERE o.message_id = r.message_id AND o.status = $2),
+										r.created_at
+									FROM "report" AS r
+									INNER JOIN "message" AS msg
+									ON msg.id = r.message_id
+									WHERE r.status = $1
+									ORDER BY r.created_at, r.id
+									LIMIT $3 OFFSET $4`)
+	if err != nil {
+		return nil, fmt.Errorf("error path: %s, error: %w", opReportsGet, err)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.Query(in.Status, entity.ReportOpen, in.Limit, in.Offset)
+	if err != nil {
+		return nil, fmt.Errorf("error path: %s, error: %w", opReportsGet, err)
+	}
+	defer rows.Close()
+
+	var reports []entity.Report
+	for rows.Next() {
+		var report entity.Report
+		if errSc := rows.Scan(&report.ID, &report.MessageID, &report.ChatID, &report.AuthorID, &report.Text,
+			&report.ReporterID, &report.Reason, &report.Comment, &report.Status, &report.Reports, &report.CreatedAt); errSc != nil {
+			return nil, fmt.Errorf("error path: %s, error: %w", opReportsGet, errSc)
+		}
+		reports = append(reports, report)
+	}
+
+	// В конце проверяем строки на ошибки (best practice)
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error path: %s, error: %w", opReportsGet, err)
+	}
+
+	return reports, nil
+}
+
+// ModerateReport - действие модератора по жалобе: скрыть сообщение, предупредить или отстранить автора,
+// отклонить жалобу. Действие записывается в журнал и закрывает все открытые жалобы на сообщение
+func (m *ModerationPostgres) ModerateReport(in entity.ModerationAdd) (*entity.ModerationAction, error) {
+	// Запускаем транзакцию
+	tx, err := m.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("error path: %s, error: %w", opModerate, err)
+	}
+
+	action, errModerate := moderate(tx, in)
+	if errModerate != nil {
+		// Откатываем транзакцию в случае ошибки
+		if errTx := tx.Rollback(); errTx != nil {
+			return nil, fmt.Errorf("error path: %s, error: %s", opModerate, errTx)
+		}
+		return nil, errModerate
+	}
+
+	return action, tx.Commit()
+}
+
+// moderate - применяем действие модератора внутри транзакции, откат транзакции при ошибке остаётся на вызывающей стороне
+func moderate(tx preparer, in entity.ModerationAdd) (*entity.ModerationAction, error) {
+	if errModerator := checkModerator(tx, opModerate, in.UserID); errModerator != nil {
+		return nil, errModerator
+	}
+
+	// Скелет sql запроса на получение жалобы с автором сообщения, жалоба блокируется до конца транзакции
+	stmtReport, err := tx.Prepare(`SELECT r.message_id, r.status, msg.user_id, u.role
+									FROM "report" AS r
+									INNER JOIN "message" AS msg
+									ON msg.id = r.message_id
+									INNER JOIN "user" AS u
+									ON u.id = msg.user_id
+									WHERE r.id = $1
+									FOR UPDATE OF r`)
+	if err != nil {
+		return nil, fmt.Errorf("error path: %s, error: %w", opModerate, err)
+	}
+	defer stmtReport.Close()
+
+	action := entity.ModerationAction{
+		ModeratorID: int64(in.UserID),
+		Action:      in.Action,
+		ReportID:    in.ReportID,
+		Note:        in.Note,
+	}
+	var status, authorRole string
+	if row := stmtReport.QueryRow(in.ReportID).Scan(&action.MessageID, &status, &action.UserID, &authorRole); row != nil && row.Error() == errNoRows {
+		return nil, fmt.Errorf("error path: %s, error: %w", opModerate, apperr.NotFound("Invalid report_id"))
+	} else if row != nil {
+		return nil, fmt.Errorf("error path: %s, error: %w", opModerate, row)
+	}
+
+	if status != entity.ReportOpen {
+		return nil, fmt.Errorf("error path: %s, error: %w", opModerate, apperr.Conflict("Report is already closed"))
+	}
+	if in.Action == entity.ActionWarn || in.Action == entity.ActionSuspend {
+		if action.UserID == int64(in.UserID) {
+			return nil, fmt.Errorf("error path: %s, error: %w", opModerate, apperr.Validation("Cannot warn or suspend yourself"))
+		}
+		if in.Action == entity.ActionSuspend && authorRole == entity.RoleModerator {
+			return nil, fmt.Errorf("error path: %s, error: %w", opModerate, apperr.Forbidden("Cannot suspend a moderator"))
+		}
+	}
+
+	switch in.Action {
+	case entity.ActionHide:
+		// Скрытое сообщение выглядит для участников чата так же, как удалённое автором, но его текст
+		// и связанные данные участникам больше не отдаются
+		if errHide := execModeration(tx, `UPDATE "message" SET is_deleted = true, is_hidden = true WHERE id = $1`,
+			action.MessageID); errHide != nil {
+			return nil, errHide
+		}
+		chatID, errChat := messageChatID(tx, opModerate, action.MessageID)
+		if errChat != nil {
+			return nil, errChat
+		}
+		action.ChatID = chatID
+	case entity.ActionSuspend:
+		// Более долгое отстранение не сокращается новым
+		if errSuspend := execModeration(tx, `UPDATE "user" SET suspended_until = GREATEST(suspended_until, $2) WHERE id = $1`,
+			action.UserID, nullTime(in.SuspendedUntil)); errSuspend != nil {
+			return nil, errSuspend
+		}
+	}
+
+	// Скелет sql запроса на запись действия в журнал
+	stmtAction, err := tx.Prepare(`INSERT INTO "moderation_action" (moderator_id, action, report_id, message_id, user_id, note, suspended_until)
+									VALUES ($1, $2, $3, $4, $5, $6, $7)
+									RETURNING id, created_at, suspended_until`)
+	if err != nil {
+		return nil, fmt.Errorf("error path: %s, error: %w", opModerate, err)
+	}
+	defer stmtAction.Close()
+
+	var suspendedUntil sql.NullString
+	if err = stmtAction.QueryRow(action.ModeratorID, action.Action, action.ReportID, action.MessageID, action.UserID,
+		action.Note, nullTime(in.SuspendedUntil)).Scan(&action.ID, &action.CreatedAt, &suspendedUntil); err != nil {
+		return nil, fmt.Errorf("error path: %s, error: %w", opModerate, err)
+	}
+	action.SuspendedUntil = suspendedUntil.String
+
+	// Закрываем все открытые жалобы на сообщение
+	reportStatus := entity.ReportResolved
+	if in.Action == entity.ActionDismiss {
+		reportStatus = entity.ReportDismissed
+	}
+	if errClose := execModeration(tx, `UPDATE "report" SET status = $2, resolved_at = now(), action_id = $3
+										WHERE message_id = $1 AND status = $4`,
+		action.MessageID, reportStatus, action.ID, entity.ReportOpen); errClose != nil {
+		return nil, errClose
+	}
+
+	return &action, nil
+}
+
+// GetActions - журнал действий модераторов, новые действия идут первыми
+func (m *ModerationPostgres) GetActions(in entity.ActionsGet) ([]entity.ModerationAction, error) {
+	if errModerator := checkModerator(m.db, opActionsGet, in.UserID); errModerator != nil {
+		return nil, errModerator
+	}
+
+	// Скелет sql запроса на получение журнала, фильтр по пользователю необязательный
+	stmt, err := m.db.Prepare(`SELECT id, moderator_id, action, report_id, message_id, user_id, note,
+										suspended_until, created_at
+									FROM "moderation_action"
+									WHERE ($1::integer IS NULL OR user_id = $1)
+									ORDER BY created_at DESC, id DESC
+									LIMIT $2 OFFSET $3`)
+	if err != nil {
+		return nil, fmt.Errorf("error path: %s, error: %w", opActionsGet, err)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.Query(nullID(in.TargetID), in.Limit, in.Offset)
+	if err != nil {
+		return nil, fmt.Errorf("error path: %s, error: %w", opActionsGet, err)
+	}
+	defer rows.Close()
+
+	var actions []entity.ModerationAction
+	for rows.Next() {
+		var action entity.ModerationAction
+		var suspendedUntil sql.NullString
+		if errSc := rows.Scan(&action.ID, &action.ModeratorID, &action.Action, &action.ReportID, &action.MessageID,
+			&action.UserID, &action.Note, &suspendedUntil, &action.CreatedAt); errSc != nil {
+			return nil, fmt.Errorf("error path: %s, error: %w", opActionsGet, errSc)
+		}
+		action.SuspendedUntil = suspendedUntil.String
+		actions = append(actions, action)
+	}
+
+	// В конце проверяем строки на ошибки (best practice)
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error path: %s, error: %w", opActionsGet, err)
+	}
+
+	return actions, nil
+}
+
+// checkModerator - проверяем, что пользователь является модератором
+func checkModerator(q preparer, op string, userID int) error {
+	// Скелет sql запроса на получение роли пользователя
+	stmt, err := q.Prepare(`SELECT role FROM "user" WHERE id = $1 AND is_deleted = false`)
+	if err != nil {
+		return fmt.Errorf("error path: %s, error: %w", op, err)
+	}
+	defer stmt.Close()
+
+	var role string
+	if row := stmt.QueryRow(userID).Scan(&role); row != nil && row.Error() != errNoRows {
+		return fmt.Errorf("error path: %s, error: %w", op, row)
+	}
+	if role != entity.RoleModerator {
+		return fmt.Errorf("error path: %s, error: %w", op,
+			apperr.Forbidden(fmt.Sprintf("User with userID %d is not a moderator", userID)))
+	}
+
+	return nil
+}
+
+// execModeration - выполняем изменяющий запрос действия модератора
+func execModeration(tx preparer, query string, args ...any) error {
+	stmt, err := tx.Prepare(query)
+	if err != nil {
+		return fmt.Errorf("error path: %s, error: %w", opModerate, err)
+	}
+	defer stmt.Close()
+
+	if _, err = stmt.Exec(args...); err != nil {
+		return fmt.Errorf("error path: %s, error: %w", opModerate, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,94 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+
+	"service-chat/internal/db/entity"
+)
+
+func TestModerationPostgres_HideMessage(t *testing.T) {
+	// Создаём мок объекта базы данных
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	// Модератор 1 скрывает сообщение 5 пользователя 2 из чата 3 по жалобе 4
+	mock.ExpectBegin()
+	mock.ExpectPrepare(`SELECT role FROM "user" WHERE id = $1 AND is_deleted = false`).ExpectQuery().WithArgs(1).
+		WillReturnRows(sqlmock.NewRows([]string{"role"}).AddRow(entity.RoleModerator))
+	mock.ExpectPrepare(`SELECT r.message_id, r.status, msg.user_id, u.role
+						FROM "report" AS r
+						INNER JOIN "message" AS msg
+						ON msg.id = r.message_id
+						INNER JOIN "user" AS u
+						ON u.id = msg.user_id
+						WHERE r.id = $1
+						FOR UPDATE OF r`).ExpectQuery().WithArgs(int64(4)).
+		WillReturnRows(sqlmock.NewRows([]string{"message_id", "status", "user_id", "role"}).
+			AddRow(5, entity.ReportOpen, 2, entity.RoleUser))
+	mock.ExpectPrepare(`UPDATE "message" SET is_deleted = true, is_hidden = true WHERE id = $1`).ExpectExec().
+		WithArgs(int64(5)).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectPrepare(`SELECT uc.chat_id
+						FROM "chats_messages" AS cm
+						INNER JOIN "users_chat" AS uc
+						ON uc.id = cm.users_chat_id
+						WHERE cm.message_id = $1`).ExpectQuery().WithArgs(int64(5)).
+		WillReturnRows(sqlmock.NewRows([]string{"chat_id"}).AddRow(3))
+	mock.ExpectPrepare(`INSERT INTO "moderation_action" (moderator_id, action, report_id, message_id, user_id, note, suspended_until)
+						VALUES ($1, $2, $3, $4, $5, $6, $7)
+						RETURNING id, created_at, suspended_until`).ExpectQuery().
+		WithArgs(int64(1), entity.ActionHide, int64(4), int64(5), int64(2), "spam", nil).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "suspended_until"}).AddRow(6, "2026-10-19T12:00:00Z", nil))
+	mock.ExpectPrepare(`UPDATE "report" SET status = $2, resolved_at = now(), action_id = $3
+						WHERE message_id = $1 AND status = $4`).ExpectExec().
+		WithArgs(int64(5), entity.ReportResolved, int64(6), entity.ReportOpen).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	action, err := NewModerationPostgres(db).ModerateReport(entity.ModerationAdd{
+		ReportID: 4,
+		UserID:   1,
+		Action:   entity.ActionHide,
+		Note:     "spam",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), action.ChatID)
+
+	// Участник чата 7 получает сообщения: текст скрытого сообщения не отдаётся, а вложения,
+	// упоминания и превью для него даже не запрашиваются
+	mock.ExpectPrepare(`SELECT id, user_id FROM "users_chat" WHERE chat_id = $1`).ExpectQuery().WithArgs(int64(3)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id"}).AddRow(10, 2).AddRow(11, 7))
+	mock.ExpectPrepare(`WITH cm AS (
+							SELECT message_id
+							FROM chats_messages
+							WHERE users_chat_id = ANY ($1)
+							)
+						SELECT id, CASE WHEN is_hidden THEN '' ELSE text END, user_id, created_at, is_deleted,
+						       is_hidden, format, kind
+						FROM message
+						WHERE id IN (SELECT message_id FROM cm)
+						  AND NOT ($4 AND user_id IN (SELECT blocked_id FROM "user_block" WHERE blocker_id = $5))
+						ORDER BY created_at
+						LIMIT $2 OFFSET $3`).ExpectQuery().
+		WithArgs(pq.Array([]int{10, 11}), int64(20), int64(0), false, 7).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "text", "user_id", "created_at", "is_deleted", "is_hidden", "format", "kind"}).
+			AddRow(5, "", 2, "2026-10-19T11:00:00Z", true, true, entity.FormatPlain, "user"))
+
+	messages, err := NewMessagePostgres(db).GetMessage(entity.MessageGet{ChatID: 3, Limit: 20, UserID: 7})
+	assert.NoError(t, err)
+	assert.Equal(t, []entity.Message{{
+		Id:        5,
+		UserID:    2,
+		CreatedAt: "2026-10-19T11:00:00Z",
+		IsDeleted: true,
+		IsHidden:  true,
+		Format:    entity.FormatPlain,
+		Kind:      "user",
+	}}, messages)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
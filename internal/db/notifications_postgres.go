@@ -0,0 +1,269 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/lib/pq"
+
+	"service-chat/internal/db/entity"
+)
+
+const (
+	opNotifySettingsGet  = "db.GetNotifySettings"
+	opNotifySettingsSave = "db.SaveNotifySettings"
+	opChatNotifySet      = "db.SetChatNotify"
+	opNotifyRecipients   = "db.GetRecipients"
+	opNotificationAdd    = "db.AddNotifications"
+	opNotificationClaim  = "db.ClaimNotifications"
+	opNotificationSave   = "db.CompleteNotifications"
+)
+
+type NotificationPostgres struct {
+	db *sql.DB
+}
+
+func NewNotificationPostgres(db *sql.DB) *NotificationPostgres {
+	return &NotificationPostgres{db: db}
+}
+
+// GetNotifySettings - получаем адреса уведомлений пользователя, если он их не задавал - адреса пустые
+func (n *NotificationPostgres) GetNotifySettings(userID int) (*entity.NotifySettings, error) {
+	// Скелет sql запроса на получение адресов уведомлений
+	stmt, err := n.db.Prepare(`SELECT email, push_token FROM "notification_settings" WHERE user_id = $1`)
+	if err != nil {
+		return nil, fmt.Errorf("error path: %s, error: %w", opNotifySettingsGet, err)
+	}
+	defer stmt.Close()
+
+	var settings entity.NotifySettings
+	if row := stmt.QueryRow(userID).Scan(&settings.Email, &settings.PushToken); row != nil && row.Error() == errNoRows {
+		return &settings, nil
+	} else if row != nil {
+		return nil, fmt.Errorf("error path: %s, error: %w", opNotifySettingsGet, row)
+	}
+
+	return &settings, nil
+}
+
+// SaveNotifySettings - сохраняем адреса уведомлений пользователя, пустой адрес отключает канал
+func (n *NotificationPostgres) SaveNotifySettings(in entity.NotifySettingsSave) error {
+	// Скелет sql запроса на сохранение адресов уведомлений
+	stmt, err := n.db.Prepare(`INSERT INTO "notification_settings" (user_id, email, push_token) VALUES ($1, $2, $3)
+									ON CONFLICT (user_id) DO UPDATE
+									SET email = EXCLUDED.email, push_token = EXCLUDED.push_token, updated_at = now()`)
+	if err != nil {
+		return fmt.Errorf("error path: %s, error: %w", opNotifySettingsSave, err)
+	}
+	defer stmt.Close()
+
+	if _, err = stmt.Exec(in.UserID, in.Email, in.PushToken); err != nil {
+		return fmt.Errorf("error path: %s, error: %w", opNotifySettingsSave, err)
+	}
+
+	return nil
+}
+
+// SetChatNotify - меняем уровень уведомлений участника чата и время, до которого чат заглушён
+func (n *NotificationPostgres) SetChatNotify(in entity.ChatNotifySet) error {
+	usersChatID, errMember := checkChatMember(n.db, opChatNotifySet, in.ChatID, in.UserID)
+	if errMember != nil {
+		return errMember
+	}
+
+	// Скелет sql запроса на изменение настроек уведомлений участника чата
+	stmt, err := n.db.Prepare(`UPDATE "users_chat" SET notify = $2, muted_until = $3 WHERE id = $1`)
+	if err != nil {
+		return fmt.Errorf("error path: %s, error: %w", opChatNotifySet, err)
+	}
+	defer stmt.Close()
+
+	if _, err = stmt.Exec(usersChatID, in.Level, nullTime(in.MutedUntil)); err != nil {
+		return fmt.Errorf("error path: %s, error: %w", opChatNotifySet, err)
+	}
+
+	return nil
+}
+
+// GetRecipients - выбираем участников чата, которых нужно уведомить о сообщении: кроме автора и ботов,
+// с заданным адресом уведомлений, не заглушивших чат и не внёсших автора в чёрный список,
+// с уровнем all или упомянутых в сообщении при уровне mentions
+func (n *NotificationPostgres) GetRecipients(in entity.RecipientsGet) ([]entity.NotifyRecipient, error) {
+	// Скелет sql запроса на выбор получателей уведомлений
+	stmt, err := n.db.Prepare(`SELECT r.user_id, r.email, r.push_token, r.mention
+									FROM (
+										SELECT uc.user_id, s.email, s.push_token, uc.notify,
+											EXISTS (SELECT 1 FROM "mention" AS mn
+													WHERE mn.message_id = $2 AND mn.user_id = uc.user_id) AS mention
+										FROM "users_chat" AS uc
+										INNER JOIN "user" AS u
+										ON u.id = uc.user_id
+										INNER JOIN "notification_settings" AS s
+										ON s.user_id = uc.user_id
+										WHERE uc.chat_id = $1
+										  AND uc.user_id <> $3
+										  AND u.is_bot = false
+										  AND u.is_deleted = false
+										  AND (s.email <> '' OR s.push_token <> '')
+										  AND (uc.muted_until IS NULL OR uc.muted_until <= $4)
+										  AND NOT EXISTS (SELECT 1 FROM "user_block" AS b
+														  WHERE b.blocker_id = uc.user_id AND b.blocked_id = $3)
+									) AS r
+									WHERE r.notify = $5 OR (r.notify = $6 AND r.mention)
+									ORDER BY r.user_id`)
+	if err != nil {
+		return nil, fmt.Errorf("error path: %s, error: %w", opNotifyRecipients, err)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.Query(in.ChatID, in.MessageID, in.AuthorID, nullTime(&in.Now), entity.NotifyAll, entity.NotifyMentions)
+	if err != nil {
+		return nil, fmt.Errorf("error path: %s, error: %w", opNotifyRecipients, err)
+	}
+	defer rows.Close()
+
+	var recipients []entity.NotifyRecipient
+	for rows.Next() {
+		var recipient entity.NotifyRecipient
+		if errSc := rows.Scan(&recipient.UserID, &recipient.Email, &recipient.PushToken, &recipient.Mention); errSc != nil {
+			return nil, fmt.Errorf("error path: %s, error: %w", opNotifyRecipients, errSc)
+		}
+		recipients = append(recipients, recipient)
+	}
+
+	// В конце проверяем строки на ошибки (best practice)
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error path: %s, error: %w", opNotifyRecipients, err)
+	}
+
+	return recipients, nil
+}
+
+// AddNotifications - ставим уведомления в очередь одним запросом
+func (n *NotificationPostgres) AddNotifications(in []entity.NotificationAdd) error {
+	if len(in) == 0 {
+		return nil
+	}
+
+	userIDs := make([]int64, len(in))
+	channels := make([]string, len(in))
+	chatIDs := make([]int64, len(in))
+	messageIDs := make([]int64, len(in))
+	mentions := make([]bool, len(in))
+	sendAfter := make([]string, len(in))
+	for i, notification := range in {
+		userIDs[i] = notification.UserID
+		channels[i] = notification.Channel
+		chatIDs[i] = notification.ChatID
+		messageIDs[i] = notification.MessageID
+		mentions[i] = notification.Mention
+		sendAfter[i] = nullTime(&notification.SendAfter).(string)
+	}
+
+	// Скелет sql запроса на добавление уведомлений в очередь
+	stmt, err := n.db.Prepare(`INSERT INTO "notification" (user_id, channel, chat_id, message_id, mention, send_after)
+									SELECT * FROM unnest($1::integer[], $2::varchar[], $3::integer[], $4::integer[],
+										$5::boolean[], $6::timestamp[])`)
+	if err != nil {
+		return fmt.Errorf("error path: %s, error: %w", opNotificationAdd, err)
+	}
+	defer stmt.Close()
+
+	if _, err = stmt.Exec(pq.Array(userIDs), pq.Array(channels), pq.Array(chatIDs), pq.Array(messageIDs),
+		pq.Array(mentions), pq.Array(sendAfter)); err != nil {
+		return fmt.Errorf("error path: %s, error: %w", opNotificationAdd, err)
+	}
+
+	return nil
+}
+
+// ClaimNotifications - берём на отправку все ожидающие уведомления канала у пользователей, время отправки
+// хотя бы одного уведомления которых наступило. Счётчик попыток увеличивается сразу, а время отправки
+// откладывается до LeaseUntil: другие экземпляры сервиса не получат эти уведомления, а если экземпляр
+// упадёт во время отправки, уведомления будут отправлены повторно
+func (n *NotificationPostgres) ClaimNotifications(in entity.NotificationClaim) ([]entity.NotificationJob, error) {
+	// Скелет sql запроса на выборку и блокировку уведомлений. Адрес получателя берём из текущих настроек:
+	// email для писем и push_token для push уведомлений
+	stmt, err := n.db.Prepare(`WITH due AS (
+										SELECT user_id FROM "notification"
+										WHERE channel = $1 AND status = $2 AND send_after <= $3
+										GROUP BY user_id
+										ORDER BY MIN(send_after)
+										LIMIT $5
+									), claimed AS (
+										UPDATE "notification" AS n
+										SET attempts = n.attempts + 1, send_after = $4
+										WHERE n.id IN (
+											SELECT id FROM "notification"
+											WHERE channel = $1 AND status = $2 AND user_id IN (SELECT user_id FROM due)
+											FOR UPDATE SKIP LOCKED
+										)
+										RETURNING n.id, n.user_id, n.chat_id, n.message_id, n.mention, n.attempts, n.created_at
+									)
+									SELECT cl.id, cl.user_id,
+										CASE $1 WHEN 'email' THEN COALESCE(s.email, '')
+										        WHEN 'push' THEN COALESCE(s.push_token, '')
+										        ELSE '' END,
+										cl.chat_id, c.name, cl.message_id, a.username, m.text, cl.mention,
+										m.is_deleted OR c.is_deleted, cl.created_at, cl.attempts
+									FROM claimed AS cl
+									INNER JOIN "chat" AS c
+									ON c.id = cl.chat_id
+									INNER JOIN "message" AS m
+									ON m.id = cl.message_id
+									INNER JOIN "user" AS a
+									ON a.id = m.user_id
+									LEFT JOIN "notification_settings" AS s
+									ON s.user_id = cl.user_id
+									ORDER BY cl.user_id, cl.id`)
+	if err != nil {
+		return nil, fmt.Errorf("error path: %s, error: %w", opNotificationClaim, err)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.Query(in.Channel, entity.NotificationPending, nullTime(&in.Now), nullTime(&in.LeaseUntil), in.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("error path: %s, error: %w", opNotificationClaim, err)
+	}
+	defer rows.Close()
+
+	var jobs []entity.NotificationJob
+	for rows.Next() {
+		var job entity.NotificationJob
+		if errSc := rows.Scan(&job.ID, &job.UserID, &job.Address, &job.ChatID, &job.ChatName, &job.MessageID,
+			&job.Author, &job.Text, &job.Mention, &job.IsDeleted, &job.CreatedAt, &job.Attempts); errSc != nil {
+			return nil, fmt.Errorf("error path: %s, error: %w", opNotificationClaim, errSc)
+		}
+		jobs = append(jobs, job)
+	}
+
+	// В конце проверяем строки на ошибки (best practice)
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error path: %s, error: %w", opNotificationClaim, err)
+	}
+
+	return jobs, nil
+}
+
+// CompleteNotifications - переводим уведомления в новый статус после попытки отправки
+func (n *NotificationPostgres) CompleteNotifications(in entity.NotificationResult) error {
+	if len(in.IDs) == 0 {
+		return nil
+	}
+
+	// Скелет sql запроса на обновление статуса уведомлений
+	stmt, err := n.db.Prepare(`UPDATE "notification"
+									SET status = $2, error = NULLIF($3, ''), send_after = $4,
+									    sent_at = CASE WHEN $2 = $5 THEN now() END
+									WHERE id = ANY ($1)`)
+	if err != nil {
+		return fmt.Errorf("error path: %s, error: %w", opNotificationSave, err)
+	}
+	defer stmt.Close()
+
+	if _, err = stmt.Exec(pq.Array(in.IDs), in.Status, in.Error, nullTime(&in.SendAfter), entity.NotificationSent); err != nil {
+		return fmt.Errorf("error path: %s, error: %w", opNotificationSave, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,257 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/lib/pq"
+
+	"service-chat/internal/apperr"
+	"service-chat/internal/db/entity"
+)
+
+const (
+	opPinAdd  = "db.PinMessage"
+	opPinDel  = "db.UnpinMessage"
+	opPinGet  = "db.GetPins"
+	opPinLoad = "db.loadPinned"
+)
+
+type PinPostgres struct {
+	db *sql.DB
+}
+
+func NewPinPostgres(db *sql.DB) *PinPostgres {
+	return &PinPostgres{db: db}
+}
+
+// PinMessage - закрепляем сообщение в чате и записываем об этом служебное сообщение
+func (p *PinPostgres) PinMessage(in entity.PinAdd) error {
+	// Начинаем транзакцию
+	tx, err := p.db.Begin()
+	if err != nil {
+		return fmt.Errorf("error path: %s, error: %w", opPinAdd, err)
+	}
+
+	// Закрепить можно только неудалённое сообщение из чата, в котором состоит пользователь
+	chatID, errMember := checkMessageMember(tx, opPinAdd, in.MessageID, in.UserID)
+	if errMember != nil {
+		if errTx := tx.Rollback(); errTx != nil {
+			return fmt.Errorf("error path: %s, error: %s", opPinAdd, errTx)
+		}
+		return errMember
+	}
+
+	// Закреплять сообщения могут только администраторы чата
+	usersChatID, errAdmin := checkChatAdmin(tx, opPinAdd, chatID, in.UserID)
+	if errAdmin != nil {
+		if errTx := tx.Rollback(); errTx != nil {
+			return fmt.Errorf("error path: %s, error: %s", opPinAdd, errTx)
+		}
+		return errAdmin
+	}
+
+	// Скелет sql запроса на подсчёт закреплённых сообщений в чате
+	stmtCount, err := tx.Prepare(`SELECT COUNT(*) FROM "pin" WHERE chat_id = $1`)
+	if err != nil {
+		return fmt.Errorf("error path: %s, error: %w", opPinAdd, err)
+	}
+	defer stmtCount.Close()
+
+	// Проверяем ограничение на количество закреплённых сообщений
+	var count int
+	if err = stmtCount.QueryRow(chatID).Scan(&count); err != nil {
+		if errTx := tx.Rollback(); errTx != nil {
+			return fmt.Errorf("error path: %s, error: %s", opPinAdd, errTx)
+		}
+		return fmt.Errorf("error path: %s, error: %w", opPinAdd, err)
+	}
+	if count >= in.MaxPins {
+		if errTx := tx.Rollback(); errTx != nil {
+			return fmt.Errorf("error path: %s, error: %s", opPinAdd, errTx)
+		}
+		return fmt.Errorf("error path: %s, error: %w", opPinAdd,
+			apperr.Conflict(fmt.Sprintf("Pinned messages limit of %d reached", in.MaxPins)))
+	}
+
+	// Скелет sql запроса на закрепление сообщения
+	stmtAdd, err := tx.Prepare(`INSERT INTO "pin" (chat_id, message_id, user_id) VALUES ($1, $2, $3)
+									ON CONFLICT DO NOTHING`)
+	if err != nil {
+		return fmt.Errorf("error path: %s, error: %w", opPinAdd, err)
+	}
+	defer stmtAdd.Close()
+
+	// Закрепляем сообщение, если оно уже закреплено - сообщаем об ошибке
+	res, errExec := stmtAdd.Exec(chatID, in.MessageID, in.UserID)
+	if errExec != nil {
+		if errTx := tx.Rollback(); errTx != nil {
+			return fmt.Errorf("error path: %s, error: %s", opPinAdd, errTx)
+		}
+		return fmt.Errorf("error path: %s, error: %w", opPinAdd, errExec)
+	}
+	if affected, errCount := res.RowsAffected(); errCount == nil && affected == 0 {
+		if errTx := tx.Rollback(); errTx != nil {
+			return fmt.Errorf("error path: %s, error: %s", opPinAdd, errTx)
+		}
+		return fmt.Errorf("error path: %s, error: %w", opPinAdd, apperr.Conflict("Message is already pinned"))
+	}
+
+	// Записываем в чат служебное сообщение о закреплении
+	text := fmt.Sprintf("Message %d was pinned", in.MessageID)
+	if errSys := addSystemMessage(tx, opPinAdd, usersChatID, in.UserID, text); errSys != nil {
+		if errTx := tx.Rollback(); errTx != nil {
+			return fmt.Errorf("error path: %s, error: %s", opPinAdd, errTx)
+		}
+		return errSys
+	}
+
+	return tx.Commit()
+}
+
+// UnpinMessage - открепляем сообщение в чате и записываем об этом служебное сообщение
+func (p *PinPostgres) UnpinMessage(in entity.PinDel) error {
+	// Начинаем транзакцию
+	tx, err := p.db.Begin()
+	if err != nil {
+		return fmt.Errorf("error path: %s, error: %w", opPinDel, err)
+	}
+
+	// Открепить можно и удалённое после закрепления сообщение, поэтому берём чат без проверки удаления
+	chatID, errChat := messageChatID(tx, opPinDel, in.MessageID)
+	if errChat != nil {
+		if errTx := tx.Rollback(); errTx != nil {
+			return fmt.Errorf("error path: %s, error: %s", opPinDel, errTx)
+		}
+		return errChat
+	}
+
+	// Откреплять сообщения могут только администраторы чата
+	usersChatID, errAdmin := checkChatAdmin(tx, opPinDel, chatID, in.UserID)
+	if errAdmin != nil {
+		if errTx := tx.Rollback(); errTx != nil {
+			return fmt.Errorf("error path: %s, error: %s", opPinDel, errTx)
+		}
+		return errAdmin
+	}
+
+	// Скелет sql запроса на открепление сообщения
+	stmtDel, err := tx.Prepare(`DELETE FROM "pin" WHERE chat_id = $1 AND message_id = $2`)
+	if err != nil {
+		return fmt.Errorf("error path: %s, error: %w", opPinDel, err)
+	}
+	defer stmtDel.Close()
+
+	// Открепляем сообщение, если оно не было закреплено - сообщаем об ошибке
+	res, errExec := stmtDel.Exec(chatID, in.MessageID)
+	if errExec != nil {
+		if errTx := tx.Rollback(); errTx != nil {
+			return fmt.Errorf("error path: %s, error: %s", opPinDel, errTx)
+		}
+		return fmt.Errorf("error path: %s, error: %w", opPinDel, errExec)
+	}
+	if affected, errCount := res.RowsAffected(); errCount == nil && affected == 0 {
+		if errTx := tx.Rollback(); errTx != nil {
+			return fmt.Errorf("error path: %s, error: %s", opPinDel, errTx)
+		}
+		return fmt.Errorf("error path: %s, error: %w", opPinDel, apperr.NotFound("Pin not found"))
+	}
+
+	// Записываем в чат служебное сообщение об откреплении
+	text := fmt.Sprintf("Message %d was unpinned", in.MessageID)
+	if errSys := addSystemMessage(tx, opPinDel, usersChatID, in.UserID, text); errSys != nil {
+		if errTx := tx.Rollback(); errTx != nil {
+			return fmt.Errorf("error path: %s, error: %s", opPinDel, errTx)
+		}
+		return errSys
+	}
+
+	return tx.Commit()
+}
+
+// GetPins - получаем закреплённые сообщения чата, последние закреплённые идут первыми
+func (p *PinPostgres) GetPins(in entity.PinGet) ([]entity.Message, error) {
+	// Закреплённые сообщения видят только участники чата
+	if _, errMember := checkChatMember(p.db, opPinGet, in.ChatID, in.UserID); errMember != nil {
+		return nil, errMember
+	}
+
+	// Скелет sql запроса на получение закреплённых сообщений
+	stmt, err := p.db.Prepare(`SELECT m.id, m.text, m.user_id, m.created_at, m.is_deleted, m.format, m.kind
+									FROM "pin" AS p
+									INNER JOIN "message" AS m
+									ON m.id = p.message_id
+									WHERE p.chat_id = $1
+									  AND m.is_deleted = false
+									ORDER BY p.created_at DESC, p.message_id DESC
+									LIMIT $2`)
+	if err != nil {
+		return nil, fmt.Errorf("error path: %s, error: %w", opPinGet, err)
+	}
+	defer stmt.Close()
+
+	// Получаем сообщения из бд
+	rows, err := stmt.Query(in.ChatID, in.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("error path: %s, error: %w", opPinGet, err)
+	}
+	defer rows.Close()
+
+	// Структура для записи всех полученных сообщений из бд
+	var messages []entity.Message
+	for rows.Next() {
+		var msg entity.Message
+		if errSc := rows.Scan(&msg.Id, &msg.Text, &msg.UserID, &msg.CreatedAt, &msg.IsDeleted, &msg.Format, &msg.Kind); errSc != nil {
+			return nil, fmt.Errorf("error path: %s, error: %w", opPinGet, errSc)
+		}
+		messages = append(messages, msg)
+	}
+
+	// В конце проверяем строки на ошибки (best practice)
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error path: %s, error: %w", opPinGet, err)
+	}
+
+	// Добавляем к сообщениям реакции, вложения, упоминания, закрепления и источники пересылки
+	if err = enrichMessages(p.db, messages, in.UserID); err != nil {
+		return nil, err
+	}
+
+	return messages, nil
+}
+
+// loadPinned - получаем, какие из сообщений списка закреплены
+func loadPinned(q preparer, messageIDs []int64) (map[int64]bool, error) {
+	pinned := make(map[int64]bool, len(messageIDs))
+	if len(messageIDs) == 0 {
+		return pinned, nil
+	}
+
+	// Скелет sql запроса на получение закреплённых сообщений по списку сообщений
+	stmt, err := q.Prepare(`SELECT message_id FROM "pin" WHERE message_id = ANY ($1)`)
+	if err != nil {
+		return nil, fmt.Errorf("error path: %s, error: %w", opPinLoad, err)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.Query(pq.Array(messageIDs))
+	if err != nil {
+		return nil, fmt.Errorf("error path: %s, error: %w", opPinLoad, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var messageID int64
+		if errSc := rows.Scan(&messageID); errSc != nil {
+			return nil, fmt.Errorf("error path: %s, error: %w", opPinLoad, errSc)
+		}
+		pinned[messageID] = true
+	}
+
+	// В конце проверяем строки на ошибки (best practice)
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error path: %s, error: %w", opPinLoad, err)
+	}
+
+	return pinned, nil
+}
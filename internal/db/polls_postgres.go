@@ -0,0 +1,250 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/lib/pq"
+
+	"service-chat/internal/apperr"
+	"service-chat/internal/db/entity"
+)
+
+const (
+	opPollAdd  = "db.AddPoll"
+	opPollVote = "db.Vote"
+	opPollLoad = "db.loadPolls"
+)
+
+type PollPostgres struct {
+	db *sql.DB
+}
+
+func NewPollPostgres(db *sql.DB) *PollPostgres {
+	return &PollPostgres{db: db}
+}
+
+// AddPoll - сохраняем опрос в чат от пользователя: сообщение с вопросом и варианты ответа. Возвращаем message id
+func (p *PollPostgres) AddPoll(in entity.PollAdd) (int, error) {
+	// Начинаем транзакцию
+	tx, err := p.db.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("error path: %s, error: %w", opPollAdd, err)
+	}
+
+	messageID, errAdd := addPoll(tx, in)
+	if errAdd != nil {
+		if errTx := tx.Rollback(); errTx != nil {
+			return 0, fmt.Errorf("error path: %s, error: %s", opPollAdd, errTx)
+		}
+		return 0, errAdd
+	}
+
+	return messageID, tx.Commit()
+}
+
+// addPoll - создаём опрос внутри транзакции, откат транзакции при ошибке остаётся на вызывающей стороне
+func addPoll(tx preparer, in entity.PollAdd) (int, error) {
+	// Сообщение с вопросом проходит тот же путь, что и обычное: проверка участника, вебхуки чата
+	messageID, err := insertMessage(tx, opPollAdd, kindPoll, entity.MessageAdd{
+		ChatID: in.ChatID,
+		UserID: in.UserID,
+		Text:   in.Question,
+		Format: entity.FormatPlain,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	// Скелет sql запроса на создание опроса
+	stmtPoll, err := tx.Prepare(`INSERT INTO "poll" (message_id, multiple, anonymous, close_at)
+									VALUES ($1, $2, $3, $4)
+									RETURNING id`)
+	if err != nil {
+		return 0, fmt.Errorf("error path: %s, error: %w", opPollAdd, err)
+	}
+	defer stmtPoll.Close()
+
+	var pollID int64
+	if err = stmtPoll.QueryRow(messageID, in.Multiple, in.Anonymous, nullTime(in.CloseAt)).Scan(&pollID); err != nil {
+		return 0, fmt.Errorf("error path: %s, error: %w", opPollAdd, err)
+	}
+
+	// Скелет sql запроса на создание вариантов ответа, порядок вариантов сохраняется в position
+	stmtOption, err := tx.Prepare(`INSERT INTO "poll_option" (poll_id, position, text)
+									SELECT $1, o.position, o.text
+									FROM unnest($2::varchar[]) WITH ORDINALITY AS o(text, position)`)
+	if err != nil {
+		return 0, fmt.Errorf("error path: %s, error: %w", opPollAdd, err)
+	}
+	defer stmtOption.Close()
+
+	if _, err = stmtOption.Exec(pollID, pq.Array(in.Options)); err != nil {
+		return 0, fmt.Errorf("error path: %s, error: %w", opPollAdd, err)
+	}
+
+	return messageID, nil
+}
+
+// Vote - заменяем голос пользователя в опросе на выбранные варианты, пустой список вариантов отзывает голос.
+// Голосовать могут только участники чата, пока опрос не закрыт. Возвращаем опрос с текущими голосами
+func (p *PollPostgres) Vote(in entity.PollVote) (*entity.Poll, error) {
+	// Начинаем транзакцию, строка опроса блокируется до её конца, поэтому голоса пользователя не задвоятся
+	tx, err := p.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("error path: %s, error: %w", opPollVote, err)
+	}
+
+	poll, errVote := vote(tx, in)
+	if errVote != nil {
+		if errTx := tx.Rollback(); errTx != nil {
+			return nil, fmt.Errorf("error path: %s, error: %s", opPollVote, errTx)
+		}
+		return nil, errVote
+	}
+
+	return poll, tx.Commit()
+}
+
+// vote - меняем голос внутри транзакции, откат транзакции при ошибке остаётся на вызывающей стороне
+func vote(tx preparer, in entity.PollVote) (*entity.Poll, error) {
+	if _, errMember := checkMessageMember(tx, opPollVote, in.MessageID, in.UserID); errMember != nil {
+		return nil, errMember
+	}
+
+	// Скелет sql запроса на получение опроса сообщения
+	stmtPoll, err := tx.Prepare(`SELECT id, multiple, close_at IS NOT NULL AND close_at <= $2
+									FROM "poll"
+									WHERE message_id = $1
+									FOR UPDATE`)
+	if err != nil {
+		return nil, fmt.Errorf("error path: %s, error: %w", opPollVote, err)
+	}
+	defer stmtPoll.Close()
+
+	var pollID int64
+	var multiple, closed bool
+	if row := stmtPoll.QueryRow(in.MessageID, nullTime(&in.Now)).Scan(&pollID, &multiple, &closed); row != nil && row.Error() == errNoRows {
+		return nil, fmt.Errorf("error path: %s, error: %w", opPollVote, apperr.Validation("Message is not a poll"))
+	} else if row != nil {
+		return nil, fmt.Errorf("error path: %s, error: %w", opPollVote, row)
+	}
+
+	if closed {
+		return nil, fmt.Errorf("error path: %s, error: %w", opPollVote, apperr.Conflict("Poll is closed"))
+	}
+	if !multiple && len(in.OptionIDs) > 1 {
+		return nil, fmt.Errorf("error path: %s, error: %w", opPollVote, apperr.Validation("Poll allows only one option"))
+	}
+
+	if len(in.OptionIDs) > 0 {
+		// Скелет sql запроса на проверку, что все варианты относятся к опросу
+		stmtCheck, errCheck := tx.Prepare(`SELECT COUNT(*) FROM "poll_option" WHERE poll_id = $1 AND id = ANY ($2)`)
+		if errCheck != nil {
+			return nil, fmt.Errorf("error path: %s, error: %w", opPollVote, errCheck)
+		}
+		defer stmtCheck.Close()
+
+		var count int
+		if err = stmtCheck.QueryRow(pollID, pq.Array(in.OptionIDs)).Scan(&count); err != nil {
+			return nil, fmt.Errorf("error path: %s, error: %w", opPollVote, err)
+		}
+		if count != len(in.OptionIDs) {
+			return nil, fmt.Errorf("error path: %s, error: %w", opPollVote, apperr.Validation("Invalid option_id"))
+		}
+	}
+
+	// Скелет sql запроса на удаление прежнего голоса пользователя
+	stmtDel, err := tx.Prepare(`DELETE FROM "poll_vote" WHERE poll_id = $1 AND user_id = $2`)
+	if err != nil {
+		return nil, fmt.Errorf("error path: %s, error: %w", opPollVote, err)
+	}
+	defer stmtDel.Close()
+
+	if _, err = stmtDel.Exec(pollID, in.UserID); err != nil {
+		return nil, fmt.Errorf("error path: %s, error: %w", opPollVote, err)
+	}
+
+	if len(in.OptionIDs) > 0 {
+		// Скелет sql запроса на сохранение голоса
+		stmtAdd, errAdd := tx.Prepare(`INSERT INTO "poll_vote" (poll_id, option_id, user_id)
+											SELECT $1, unnest($2::integer[]), $3`)
+		if errAdd != nil {
+			return nil, fmt.Errorf("error path: %s, error: %w", opPollVote, errAdd)
+		}
+		defer stmtAdd.Close()
+
+		if _, err = stmtAdd.Exec(pollID, pq.Array(in.OptionIDs), in.UserID); err != nil {
+			return nil, fmt.Errorf("error path: %s, error: %w", opPollVote, err)
+		}
+	}
+
+	// Получаем опрос с текущими голосами
+	polls, err := loadPolls(tx, []int64{in.MessageID}, in.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	return polls[in.MessageID], nil
+}
+
+// loadPolls - получаем опросы сообщений с голосами, отметка о голосе считается от лица пользователя userID.
+// Кто голосовал, показываем только в неанонимных опросах
+func loadPolls(q preparer, messageIDs []int64, userID int) (map[int64]*entity.Poll, error) {
+	polls := make(map[int64]*entity.Poll, len(messageIDs))
+	if len(messageIDs) == 0 {
+		return polls, nil
+	}
+
+	// Скелет sql запроса на получение опросов, варианты ответа идут в порядке создания
+	stmt, err := q.Prepare(`SELECT p.message_id, p.id, p.multiple, p.anonymous, p.close_at,
+									p.close_at IS NOT NULL AND p.close_at <= (now() AT TIME ZONE 'UTC'),
+									(SELECT COUNT(DISTINCT pv.user_id) FROM "poll_vote" AS pv WHERE pv.poll_id = p.id),
+									o.id, o.text, COUNT(v.user_id), COALESCE(BOOL_OR(v.user_id = $2), false),
+									CASE WHEN p.anonymous THEN '{}'::integer[]
+									     ELSE ARRAY_REMOVE(ARRAY_AGG(v.user_id ORDER BY v.created_at), NULL) END
+								FROM "poll" AS p
+								INNER JOIN "poll_option" AS o
+								ON o.poll_id = p.id
+								LEFT JOIN "poll_vote" AS v
+								ON v.option_id = o.id
+								WHERE p.message_id = ANY ($1)
+								GROUP BY p.id, o.id
+								ORDER BY p.message_id, o.position`)
+	if err != nil {
+		return nil, fmt.Errorf("error path: %s, error: %w", opPollLoad, err)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.Query(pq.Array(messageIDs), userID)
+	if err != nil {
+		return nil, fmt.Errorf("error path: %s, error: %w", opPollLoad, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var messageID int64
+		var poll entity.Poll
+		var closeAt sql.NullString
+		var option entity.PollOption
+		var voters pq.Int64Array
+		if errSc := rows.Scan(&messageID, &poll.ID, &poll.Multiple, &poll.Anonymous, &closeAt, &poll.Closed,
+			&poll.TotalVoters, &option.ID, &option.Text, &option.Votes, &option.VotedByMe, &voters); errSc != nil {
+			return nil, fmt.Errorf("error path: %s, error: %w", opPollLoad, errSc)
+		}
+		option.Voters = voters
+
+		if _, ok := polls[messageID]; !ok {
+			poll.CloseAt = closeAt.String
+			polls[messageID] = &poll
+		}
+		polls[messageID].Options = append(polls[messageID].Options, option)
+	}
+
+	// В конце проверяем строки на ошибки (best practice)
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error path: %s, error: %w", opPollLoad, err)
+	}
+
+	return polls, nil
+}
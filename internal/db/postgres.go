@@ -16,16 +16,7 @@ const (
 // NewPostgresDB - соединение с базой данных postgres
 func NewPostgresDB(cfg *config.Config) (*sql.DB, error) {
 	const op = "db.NewPostgresDB"
-	// Параметры подключения к базе данных
-	url := fmt.Sprintf("postgres://%v:%v@%v:%v/%v?sslmode=%v",
-		cfg.Database.Username,
-		cfg.Database.Password,
-		cfg.Database.Host,
-		cfg.Database.Port,
-		cfg.Database.Name,
-		cfg.Database.SSLMode)
-
-	db, err := sql.Open(driver, url)
+	db, err := sql.Open(driver, ConnString(cfg))
 	if err != nil {
 		return nil, fmt.Errorf("error path: %s, error: %w", op, err)
 	}
@@ -41,3 +32,14 @@ func NewPostgresDB(cfg *config.Config) (*sql.DB, error) {
 
 	return db, nil
 }
+
+// ConnString - параметры подключения к базе данных
+func ConnString(cfg *config.Config) string {
+	return fmt.Sprintf("postgres://%v:%v@%v:%v/%v?sslmode=%v",
+		cfg.Database.Username,
+		cfg.Database.Password,
+		cfg.Database.Host,
+		cfg.Database.Port,
+		cfg.Database.Name,
+		cfg.Database.SSLMode)
+}
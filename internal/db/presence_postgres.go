@@ -0,0 +1,128 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+
+	"service-chat/internal/db/entity"
+)
+
+const (
+	opPresenceSeen    = "db.SaveLastSeen"
+	opPresenceMembers = "db.GetChatMembers"
+	opPresenceChats   = "db.GetUserChats"
+	opPresenceCheck   = "db.CheckMember"
+)
+
+type PresencePostgres struct {
+	db *sql.DB
+}
+
+func NewPresencePostgres(db *sql.DB) *PresencePostgres {
+	return &PresencePostgres{db: db}
+}
+
+// SaveLastSeen - сохраняем время, когда пользователь последний раз был в сети
+func (p *PresencePostgres) SaveLastSeen(in entity.PresenceSeen) error {
+	// Скелет sql запроса на сохранение времени последнего появления в сети
+	stmt, err := p.db.Prepare(`UPDATE "user" SET last_seen_at = $2 WHERE id = $1`)
+	if err != nil {
+		return fmt.Errorf("error path: %s, error: %w", opPresenceSeen, err)
+	}
+	defer stmt.Close()
+
+	if _, err = stmt.Exec(in.UserID, nullTime(&in.SeenAt)); err != nil {
+		return fmt.Errorf("error path: %s, error: %w", opPresenceSeen, err)
+	}
+
+	return nil
+}
+
+// GetChatMembers - получаем участников чата и время их последнего появления в сети,
+// список доступен только участникам чата
+func (p *PresencePostgres) GetChatMembers(in entity.PresenceGet) ([]entity.Presence, error) {
+	if _, errMember := checkChatMember(p.db, opPresenceMembers, in.ChatID, in.UserID); errMember != nil {
+		return nil, errMember
+	}
+
+	// Скелет sql запроса на получение участников чата
+	stmt, err := p.db.Prepare(`SELECT u.id, u.last_seen_at
+									FROM "users_chat" AS uc
+									INNER JOIN "user" AS u
+									ON u.id = uc.user_id
+									WHERE uc.chat_id = $1
+									  AND u.is_deleted = false
+									ORDER BY u.id`)
+	if err != nil {
+		return nil, fmt.Errorf("error path: %s, error: %w", opPresenceMembers, err)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.Query(in.ChatID)
+	if err != nil {
+		return nil, fmt.Errorf("error path: %s, error: %w", opPresenceMembers, err)
+	}
+	defer rows.Close()
+
+	var members []entity.Presence
+	for rows.Next() {
+		var member entity.Presence
+		var lastSeen sql.NullString
+		if errSc := rows.Scan(&member.UserID, &lastSeen); errSc != nil {
+			return nil, fmt.Errorf("error path: %s, error: %w", opPresenceMembers, errSc)
+		}
+		member.LastSeen = lastSeen.String
+		members = append(members, member)
+	}
+
+	// В конце проверяем строки на ошибки (best practice)
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error path: %s, error: %w", opPresenceMembers, err)
+	}
+
+	return members, nil
+}
+
+// GetUserChats - получаем id неудалённых чатов пользователя, в них отправляются события о его статусе
+func (p *PresencePostgres) GetUserChats(userID int64) ([]int64, error) {
+	// Скелет sql запроса на получение чатов пользователя
+	stmt, err := p.db.Prepare(`SELECT uc.chat_id
+									FROM "users_chat" AS uc
+									INNER JOIN "chat" AS c
+									ON c.id = uc.chat_id
+									WHERE uc.user_id = $1
+									  AND c.is_deleted = false
+									ORDER BY uc.chat_id`)
+	if err != nil {
+		return nil, fmt.Errorf("error path: %s, error: %w", opPresenceChats, err)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.Query(userID)
+	if err != nil {
+		return nil, fmt.Errorf("error path: %s, error: %w", opPresenceChats, err)
+	}
+	defer rows.Close()
+
+	var chatIDs []int64
+	for rows.Next() {
+		var chatID int64
+		if errSc := rows.Scan(&chatID); errSc != nil {
+			return nil, fmt.Errorf("error path: %s, error: %w", opPresenceChats, errSc)
+		}
+		chatIDs = append(chatIDs, chatID)
+	}
+
+	// В конце проверяем строки на ошибки (best practice)
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error path: %s, error: %w", opPresenceChats, err)
+	}
+
+	return chatIDs, nil
+}
+
+// CheckMember - проверяем, что пользователь состоит в неудалённом чате
+func (p *PresencePostgres) CheckMember(in entity.PresenceGet) error {
+	_, err := checkChatMember(p.db, opPresenceCheck, in.ChatID, in.UserID)
+	return err
+}
@@ -0,0 +1,130 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/lib/pq"
+
+	"service-chat/internal/db/entity"
+)
+
+const (
+	opPreviewLinks = "db.AddMessageLinks"
+	opPreviewGet   = "db.GetPreview"
+	opPreviewSave  = "db.SavePreview"
+	opPreviewLoad  = "db.loadPreviews"
+)
+
+type PreviewPostgres struct {
+	db *sql.DB
+}
+
+func NewPreviewPostgres(db *sql.DB) *PreviewPostgres {
+	return &PreviewPostgres{db: db}
+}
+
+// AddMessageLinks - сохраняем ссылки, найденные в тексте сообщения, в порядке их появления
+func (p *PreviewPostgres) AddMessageLinks(in entity.MessageLinks) error {
+	// Скелет sql запроса на сохранение ссылок сообщения, сообщение могло быть уже удалено
+	stmt, err := p.db.Prepare(`INSERT INTO "message_link" (message_id, url, position)
+									SELECT m.id, u.url, u.position - 1
+									FROM "message" m, unnest($2::text[]) WITH ORDINALITY AS u(url, position)
+									WHERE m.id = $1
+									ON CONFLICT DO NOTHING`)
+	if err != nil {
+		return fmt.Errorf("error path: %s, error: %w", opPreviewLinks, err)
+	}
+	defer stmt.Close()
+
+	if _, err = stmt.Exec(in.MessageID, pq.Array(in.URLs)); err != nil {
+		return fmt.Errorf("error path: %s, error: %w", opPreviewLinks, err)
+	}
+
+	return nil
+}
+
+// GetPreview - получаем превью адреса из кэша, если превью ещё не загружалось - возвращаем nil
+func (p *PreviewPostgres) GetPreview(url string) (*entity.LinkPreview, error) {
+	// Скелет sql запроса на получение превью адреса
+	stmt, err := p.db.Prepare(`SELECT url, title, description, image_url, site_name, status, fetched_at
+									FROM "link_preview" WHERE url = $1`)
+	if err != nil {
+		return nil, fmt.Errorf("error path: %s, error: %w", opPreviewGet, err)
+	}
+	defer stmt.Close()
+
+	var preview entity.LinkPreview
+	row := stmt.QueryRow(url).Scan(&preview.URL, &preview.Title, &preview.Description, &preview.ImageURL,
+		&preview.SiteName, &preview.Status, &preview.FetchedAt)
+	if row != nil && row.Error() == errNoRows {
+		return nil, nil
+	} else if row != nil {
+		return nil, fmt.Errorf("error path: %s, error: %w", opPreviewGet, row)
+	}
+
+	return &preview, nil
+}
+
+// SavePreview - сохраняем или обновляем превью адреса в кэше
+func (p *PreviewPostgres) SavePreview(in entity.LinkPreview) error {
+	// Скелет sql запроса на сохранение превью адреса
+	stmt, err := p.db.Prepare(`INSERT INTO "link_preview" (url, title, description, image_url, site_name, status, fetched_at)
+									VALUES ($1, $2, $3, $4, $5, $6, now())
+									ON CONFLICT (url) DO UPDATE
+									SET title = EXCLUDED.title, description = EXCLUDED.description,
+										image_url = EXCLUDED.image_url, site_name = EXCLUDED.site_name,
+										status = EXCLUDED.status, fetched_at = EXCLUDED.fetched_at`)
+	if err != nil {
+		return fmt.Errorf("error path: %s, error: %w", opPreviewSave, err)
+	}
+	defer stmt.Close()
+
+	if _, err = stmt.Exec(in.URL, in.Title, in.Description, in.ImageURL, in.SiteName, in.Status); err != nil {
+		return fmt.Errorf("error path: %s, error: %w", opPreviewSave, err)
+	}
+
+	return nil
+}
+
+// loadPreviews - получаем загруженные превью ссылок для списка сообщений, сгруппированные по message_id
+func loadPreviews(q preparer, messageIDs []int64) (map[int64][]entity.LinkPreview, error) {
+	previews := make(map[int64][]entity.LinkPreview, len(messageIDs))
+	if len(messageIDs) == 0 {
+		return previews, nil
+	}
+
+	// Скелет sql запроса на получение превью по списку сообщений, незагруженные адреса пропускаем
+	stmt, err := q.Prepare(`SELECT ml.message_id, lp.url, lp.title, lp.description, lp.image_url, lp.site_name
+								FROM "message_link" ml
+								JOIN "link_preview" lp ON lp.url = ml.url
+								WHERE ml.message_id = ANY ($1) AND lp.status = $2
+								ORDER BY ml.message_id, ml.position`)
+	if err != nil {
+		return nil, fmt.Errorf("error path: %s, error: %w", opPreviewLoad, err)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.Query(pq.Array(messageIDs), entity.PreviewOK)
+	if err != nil {
+		return nil, fmt.Errorf("error path: %s, error: %w", opPreviewLoad, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var messageID int64
+		var preview entity.LinkPreview
+		if errSc := rows.Scan(&messageID, &preview.URL, &preview.Title, &preview.Description,
+			&preview.ImageURL, &preview.SiteName); errSc != nil {
+			return nil, fmt.Errorf("error path: %s, error: %w", opPreviewLoad, errSc)
+		}
+		previews[messageID] = append(previews[messageID], preview)
+	}
+
+	// В конце проверяем строки на ошибки (best practice)
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error path: %s, error: %w", opPreviewLoad, err)
+	}
+
+	return previews, nil
+}
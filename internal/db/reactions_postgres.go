@@ -0,0 +1,161 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/lib/pq"
+
+	"service-chat/internal/apperr"
+	"service-chat/internal/db/entity"
+)
+
+const (
+	opReactionAdd  = "db.AddReaction"
+	opReactionDel  = "db.DeleteReaction"
+	opReactionLoad = "db.loadReactions"
+)
+
+type ReactionPostgres struct {
+	db *sql.DB
+}
+
+func NewReactionPostgres(db *sql.DB) *ReactionPostgres {
+	return &ReactionPostgres{db: db}
+}
+
+// AddReaction - сохраняем реакцию пользователя на сообщение и возвращаем актуальный список реакций на сообщение
+func (r *ReactionPostgres) AddReaction(in entity.ReactionAdd) ([]entity.Reaction, error) {
+	// Начинаем транзакцию
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("error path: %s, error: %w", opReactionAdd, err)
+	}
+
+	// Пользователь может реагировать только на сообщения из чатов, в которых он состоит
+	if _, errMember := checkMessageMember(tx, opReactionAdd, in.MessageID, in.UserID); errMember != nil {
+		if errTx := tx.Rollback(); errTx != nil {
+			return nil, fmt.Errorf("error path: %s, error: %s", opReactionAdd, errTx)
+		}
+		return nil, errMember
+	}
+
+	// Скелет sql запроса на сохранение реакции, повторная реакция тем же emoji игнорируется
+	stmtAdd, errAdd := tx.Prepare(`INSERT INTO "reaction" (message_id, user_id, emoji) VALUES ($1, $2, $3)
+										ON CONFLICT DO NOTHING`)
+	if errAdd != nil {
+		return nil, fmt.Errorf("error path: %s, error: %w", opReactionAdd, errAdd)
+	}
+	defer stmtAdd.Close()
+
+	// Сохраняем реакцию в бд
+	if _, errExec := stmtAdd.Exec(in.MessageID, in.UserID, in.Emoji); errExec != nil {
+		if errTx := tx.Rollback(); errTx != nil {
+			return nil, fmt.Errorf("error path: %s, error: %s", opReactionAdd, errTx)
+		}
+		return nil, fmt.Errorf("error path: %s, error: %w", opReactionAdd, errExec)
+	}
+
+	// Получаем актуальный список реакций на сообщение
+	reactions, errLoad := loadReactions(tx, []int64{in.MessageID}, in.UserID)
+	if errLoad != nil {
+		if errTx := tx.Rollback(); errTx != nil {
+			return nil, fmt.Errorf("error path: %s, error: %s", opReactionAdd, errTx)
+		}
+		return nil, errLoad
+	}
+
+	return reactions[in.MessageID], tx.Commit()
+}
+
+// DeleteReaction - удаляем реакцию пользователя на сообщение и возвращаем актуальный список реакций на сообщение
+func (r *ReactionPostgres) DeleteReaction(in entity.ReactionDel) ([]entity.Reaction, error) {
+	// Начинаем транзакцию
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("error path: %s, error: %w", opReactionDel, err)
+	}
+
+	// Пользователь может убирать реакции только в чатах, в которых он состоит
+	if _, errMember := checkMessageMember(tx, opReactionDel, in.MessageID, in.UserID); errMember != nil {
+		if errTx := tx.Rollback(); errTx != nil {
+			return nil, fmt.Errorf("error path: %s, error: %s", opReactionDel, errTx)
+		}
+		return nil, errMember
+	}
+
+	// Скелет sql запроса на удаление реакции
+	stmtDel, errDel := tx.Prepare(`DELETE FROM "reaction" WHERE message_id = $1 AND user_id = $2 AND emoji = $3`)
+	if errDel != nil {
+		return nil, fmt.Errorf("error path: %s, error: %w", opReactionDel, errDel)
+	}
+	defer stmtDel.Close()
+
+	// Удаляем реакцию, если её не было - сообщаем об ошибке
+	res, errExec := stmtDel.Exec(in.MessageID, in.UserID, in.Emoji)
+	if errExec != nil {
+		if errTx := tx.Rollback(); errTx != nil {
+			return nil, fmt.Errorf("error path: %s, error: %s", opReactionDel, errTx)
+		}
+		return nil, fmt.Errorf("error path: %s, error: %w", opReactionDel, errExec)
+	}
+	if count, errCount := res.RowsAffected(); errCount == nil && count == 0 {
+		if errTx := tx.Rollback(); errTx != nil {
+			return nil, fmt.Errorf("error path: %s, error: %s", opReactionDel, errTx)
+		}
+		return nil, fmt.Errorf("error path: %s, error: %w", opReactionDel, apperr.NotFound("Reaction not found"))
+	}
+
+	// Получаем актуальный список реакций на сообщение
+	reactions, errLoad := loadReactions(tx, []int64{in.MessageID}, in.UserID)
+	if errLoad != nil {
+		if errTx := tx.Rollback(); errTx != nil {
+			return nil, fmt.Errorf("error path: %s, error: %s", opReactionDel, errTx)
+		}
+		return nil, errLoad
+	}
+
+	return reactions[in.MessageID], tx.Commit()
+}
+
+// loadReactions - получаем реакции, сгруппированные по emoji, для списка сообщений,
+// с отметкой о том, реагировал ли пользователь сам
+func loadReactions(q preparer, messageIDs []int64, userID int) (map[int64][]entity.Reaction, error) {
+	reactions := make(map[int64][]entity.Reaction, len(messageIDs))
+	if len(messageIDs) == 0 {
+		return reactions, nil
+	}
+
+	// Скелет sql запроса на получение реакций, порядок emoji - по времени первой реакции
+	stmt, err := q.Prepare(`SELECT message_id, emoji, COUNT(*), BOOL_OR(user_id = $2)
+								FROM "reaction"
+								WHERE message_id = ANY ($1)
+								GROUP BY message_id, emoji
+								ORDER BY message_id, MIN(created_at), emoji`)
+	if err != nil {
+		return nil, fmt.Errorf("error path: %s, error: %w", opReactionLoad, err)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.Query(pq.Array(messageIDs), userID)
+	if err != nil {
+		return nil, fmt.Errorf("error path: %s, error: %w", opReactionLoad, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var messageID int64
+		var reaction entity.Reaction
+		if errSc := rows.Scan(&messageID, &reaction.Emoji, &reaction.Count, &reaction.ReactedByMe); errSc != nil {
+			return nil, fmt.Errorf("error path: %s, error: %w", opReactionLoad, errSc)
+		}
+		reactions[messageID] = append(reactions[messageID], reaction)
+	}
+
+	// В конце проверяем строки на ошибки (best practice)
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error path: %s, error: %w", opReactionLoad, err)
+	}
+
+	return reactions, nil
+}
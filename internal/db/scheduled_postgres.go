@@ -0,0 +1,268 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+
+	"service-chat/internal/apperr"
+	"service-chat/internal/db/entity"
+)
+
+const (
+	opScheduledAdd    = "db.AddScheduled"
+	opScheduledGet    = "db.GetScheduled"
+	opScheduledUpdate = "db.UpdateScheduled"
+	opScheduledCancel = "db.CancelScheduled"
+	opScheduledDue    = "db.GetDueScheduled"
+	opScheduledClaim  = "db.ClaimScheduled"
+	opScheduledFinish = "db.FinishScheduled"
+)
+
+// Статусы отложенных сообщений, sending - сообщение забрал на отправку один из экземпляров сервиса
+const (
+	scheduledPending   = "pending"
+	scheduledSending   = "sending"
+	scheduledSent      = "sent"
+	scheduledCancelled = "cancelled"
+	scheduledFailed    = "failed"
+)
+
+type ScheduledPostgres struct {
+	db *sql.DB
+}
+
+func NewScheduledPostgres(db *sql.DB) *ScheduledPostgres {
+	return &ScheduledPostgres{db: db}
+}
+
+// AddScheduled - сохраняем отложенное сообщение, запланировать его можно только в чат, в котором состоит автор
+func (s *ScheduledPostgres) AddScheduled(in entity.ScheduledAdd) (int64, error) {
+	var id int64
+
+	// Проверяем, что автор состоит в чате
+	if _, errMember := checkChatMember(s.db, opScheduledAdd, in.ChatID, int(in.UserID)); errMember != nil {
+		return 0, errMember
+	}
+
+	// Скелет sql запроса на сохранение отложенного сообщения
+	stmt, err := s.db.Prepare(`INSERT INTO "scheduled_message" (chat_id, user_id, text, format, send_at)
+									VALUES ($1, $2, $3, $4, $5) RETURNING id`)
+	if err != nil {
+		return 0, fmt.Errorf("error path: %s, error: %w", opScheduledAdd, err)
+	}
+	defer stmt.Close()
+
+	if err = stmt.QueryRow(in.ChatID, in.UserID, in.Text, messageFormat(in.Format), nullTime(&in.SendAt)).Scan(&id); err != nil {
+		return 0, fmt.Errorf("error path: %s, error: %w", opScheduledAdd, err)
+	}
+
+	return id, nil
+}
+
+// GetScheduled - получаем ожидающие и неотправленные отложенные сообщения автора, ближайшие идут первыми
+func (s *ScheduledPostgres) GetScheduled(in entity.ScheduledGet) ([]entity.ScheduledMessage, error) {
+	// Скелет sql запроса на получение отложенных сообщений автора, фильтр по чату необязательный
+	stmt, err := s.db.Prepare(`SELECT id, chat_id, user_id, text, format, send_at, status, COALESCE(error, ''), created_at
+									FROM "scheduled_message"
+									WHERE user_id = $1
+									  AND ($2::integer IS NULL OR chat_id = $2)
+									  AND status IN ($3, $4)
+									ORDER BY send_at, id`)
+	if err != nil {
+		return nil, fmt.Errorf("error path: %s, error: %w", opScheduledGet, err)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.Query(in.UserID, nullID(in.ChatID), scheduledPending, scheduledFailed)
+	if err != nil {
+		return nil, fmt.Errorf("error path: %s, error: %w", opScheduledGet, err)
+	}
+	defer rows.Close()
+
+	return scanScheduled(rows, opScheduledGet)
+}
+
+// UpdateScheduled - редактируем текст и/или время отправки ещё не отправленного сообщения
+func (s *ScheduledPostgres) UpdateScheduled(in entity.ScheduledUpdate) error {
+	// Скелет sql запроса на редактирование отложенного сообщения, пустые значения не меняют поле
+	stmt, err := s.db.Prepare(`UPDATE "scheduled_message"
+									SET text = COALESCE(NULLIF($3, ''), text),
+									    send_at = COALESCE($4::timestamp, send_at),
+									    updated_at = now()
+									WHERE id = $1
+									  AND user_id = $2
+									  AND status = $5`)
+	if err != nil {
+		return fmt.Errorf("error path: %s, error: %w", opScheduledUpdate, err)
+	}
+	defer stmt.Close()
+
+	res, err := stmt.Exec(in.ID, in.UserID, in.Text, nullTime(in.SendAt), scheduledPending)
+	if err != nil {
+		return fmt.Errorf("error path: %s, error: %w", opScheduledUpdate, err)
+	}
+	if count, errCount := res.RowsAffected(); errCount == nil && count == 0 {
+		return fmt.Errorf("error path: %s, error: %w", opScheduledUpdate, apperr.NotFound("Scheduled message not found"))
+	}
+
+	return nil
+}
+
+// CancelScheduled - отменяем ещё не отправленное сообщение
+func (s *ScheduledPostgres) CancelScheduled(in entity.ScheduledDel) error {
+	// Скелет sql запроса на отмену отложенного сообщения
+	stmt, err := s.db.Prepare(`UPDATE "scheduled_message" SET status = $3, updated_at = now()
+									WHERE id = $1 AND user_id = $2 AND status = $4`)
+	if err != nil {
+		return fmt.Errorf("error path: %s, error: %w", opScheduledCancel, err)
+	}
+	defer stmt.Close()
+
+	res, err := stmt.Exec(in.ID, in.UserID, scheduledCancelled, scheduledPending)
+	if err != nil {
+		return fmt.Errorf("error path: %s, error: %w", opScheduledCancel, err)
+	}
+	if count, errCount := res.RowsAffected(); errCount == nil && count == 0 {
+		return fmt.Errorf("error path: %s, error: %w", opScheduledCancel, apperr.NotFound("Scheduled message not found"))
+	}
+
+	return nil
+}
+
+// GetDueScheduled - получаем отложенные сообщения, время отправки которых наступило, и сообщения,
+// которые забрал на отправку экземпляр сервиса, но так и не отправил до истечения аренды
+func (s *ScheduledPostgres) GetDueScheduled(in entity.ScheduledDue) ([]entity.ScheduledMessage, error) {
+	// Скелет sql запроса на получение сообщений, которые пора отправить
+	stmt, err := s.db.Prepare(`SELECT id, chat_id, user_id, text, format, send_at, status, COALESCE(error, ''), created_at
+									FROM "scheduled_message"
+									WHERE send_at <= $2
+									  AND (status = $1 OR (status = $4 AND claimed_at <= $5))
+									ORDER BY send_at, id
+									LIMIT $3`)
+	if err != nil {
+		return nil, fmt.Errorf("error path: %s, error: %w", opScheduledDue, err)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.Query(scheduledPending, nullTime(&in.Now), in.Limit, scheduledSending, nullTime(&in.LeaseBefore))
+	if err != nil {
+		return nil, fmt.Errorf("error path: %s, error: %w", opScheduledDue, err)
+	}
+	defer rows.Close()
+
+	return scanScheduled(rows, opScheduledDue)
+}
+
+// ClaimScheduled - забираем отложенное сообщение на отправку: статус меняется на sending в одном запросе,
+// поэтому несколько экземпляров сервиса не отправят сообщение дважды. Сообщение, аренда которого истекла,
+// забирается повторно - экземпляр, забравший его раньше, упал во время отправки. Если сообщение уже отправлено,
+// отменено или его забрал другой экземпляр, возвращаем nil без ошибки
+func (s *ScheduledPostgres) ClaimScheduled(in entity.ScheduledClaim) (*entity.ScheduledMessage, error) {
+	// Начинаем транзакцию
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("error path: %s, error: %w", opScheduledClaim, err)
+	}
+
+	// Скелет sql запроса на захват отложенного сообщения
+	stmtClaim, err := tx.Prepare(`UPDATE "scheduled_message" SET status = $3, claimed_at = $4, updated_at = now()
+									WHERE id = $1
+									  AND (status = $2 OR (status = $3 AND claimed_at <= $5))
+									RETURNING chat_id, user_id, text, format`)
+	if err != nil {
+		if errTx := tx.Rollback(); errTx != nil {
+			return nil, fmt.Errorf("error path: %s, error: %s", opScheduledClaim, errTx)
+		}
+		return nil, fmt.Errorf("error path: %s, error: %w", opScheduledClaim, err)
+	}
+	defer stmtClaim.Close()
+
+	msg := entity.ScheduledMessage{Id: in.ID, Status: scheduledSending}
+	if row := stmtClaim.QueryRow(in.ID, scheduledPending, scheduledSending, nullTime(&in.Now),
+		nullTime(&in.LeaseBefore)).Scan(&msg.ChatID, &msg.UserID, &msg.Text, &msg.Format); row != nil {
+		if errTx := tx.Rollback(); errTx != nil {
+			return nil, fmt.Errorf("error path: %s, error: %s", opScheduledClaim, errTx)
+		}
+		if row.Error() == errNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error path: %s, error: %w", opScheduledClaim, row)
+	}
+
+	// Если автор вышел из чата или чат удалён, сообщение уже не отправить - помечаем его как failed
+	if _, errMember := checkChatMember(tx, opScheduledClaim, msg.ChatID, int(msg.UserID)); errMember != nil {
+		reason := "Author is not a member of the chat"
+		stmtFail, errFail := tx.Prepare(`UPDATE "scheduled_message" SET status = $2, error = $3, updated_at = now()
+											WHERE id = $1`)
+		if errFail != nil {
+			if errTx := tx.Rollback(); errTx != nil {
+				return nil, fmt.Errorf("error path: %s, error: %s", opScheduledClaim, errTx)
+			}
+			return nil, fmt.Errorf("error path: %s, error: %w", opScheduledClaim, errFail)
+		}
+		defer stmtFail.Close()
+
+		if _, errExec := stmtFail.Exec(in.ID, scheduledFailed, reason); errExec != nil {
+			if errTx := tx.Rollback(); errTx != nil {
+				return nil, fmt.Errorf("error path: %s, error: %s", opScheduledClaim, errTx)
+			}
+			return nil, fmt.Errorf("error path: %s, error: %w", opScheduledClaim, errExec)
+		}
+		if errTx := tx.Commit(); errTx != nil {
+			return nil, fmt.Errorf("error path: %s, error: %w", opScheduledClaim, errTx)
+		}
+		return nil, fmt.Errorf("error path: %s, error: %s", opScheduledClaim, reason)
+	}
+
+	if errTx := tx.Commit(); errTx != nil {
+		return nil, fmt.Errorf("error path: %s, error: %w", opScheduledClaim, errTx)
+	}
+
+	return &msg, nil
+}
+
+// FinishScheduled - записываем результат отправки забранного сообщения: sent с id созданного сообщения,
+// failed с причиной или снова pending, чтобы повторить отправку при следующей проверке
+func (s *ScheduledPostgres) FinishScheduled(in entity.ScheduledFinish) error {
+	status := scheduledSent
+	if in.Retry {
+		status = scheduledPending
+	} else if in.Error != "" {
+		status = scheduledFailed
+	}
+
+	// Скелет sql запроса на обновление статуса отложенного сообщения
+	stmt, err := s.db.Prepare(`UPDATE "scheduled_message"
+									SET status = $2, message_id = $3, error = NULLIF($4, ''), updated_at = now()
+									WHERE id = $1 AND status = $5`)
+	if err != nil {
+		return fmt.Errorf("error path: %s, error: %w", opScheduledFinish, err)
+	}
+	defer stmt.Close()
+
+	if _, err = stmt.Exec(in.ID, status, nullID(in.MessageID), in.Error, scheduledSending); err != nil {
+		return fmt.Errorf("error path: %s, error: %w", opScheduledFinish, err)
+	}
+
+	return nil
+}
+
+// scanScheduled - читаем отложенные сообщения из результата запроса
+func scanScheduled(rows *sql.Rows, op string) ([]entity.ScheduledMessage, error) {
+	var messages []entity.ScheduledMessage
+	for rows.Next() {
+		var msg entity.ScheduledMessage
+		if errSc := rows.Scan(&msg.Id, &msg.ChatID, &msg.UserID, &msg.Text, &msg.Format, &msg.SendAt, &msg.Status,
+			&msg.Error, &msg.CreatedAt); errSc != nil {
+			return nil, fmt.Errorf("error path: %s, error: %w", op, errSc)
+		}
+		messages = append(messages, msg)
+	}
+
+	// В конце проверяем строки на ошибки (best practice)
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error path: %s, error: %w", op, err)
+	}
+
+	return messages, nil
+}
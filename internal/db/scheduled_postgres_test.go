@@ -0,0 +1,134 @@
+package db
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+
+	"service-chat/internal/db/entity"
+)
+
+const (
+	sqlScheduledDue = `SELECT id, chat_id, user_id, text, format, send_at, status, COALESCE(error, ''), created_at
+						FROM "scheduled_message"
+						WHERE send_at <= $2
+						  AND (status = $1 OR (status = $4 AND claimed_at <= $5))
+						ORDER BY send_at, id
+						LIMIT $3`
+	sqlScheduledClaim = `UPDATE "scheduled_message" SET status = $3, claimed_at = $4, updated_at = now()
+						WHERE id = $1
+						  AND (status = $2 OR (status = $3 AND claimed_at <= $5))
+						RETURNING chat_id, user_id, text, format`
+	sqlScheduledMember = `SELECT uc.id
+						FROM "users_chat" AS uc
+						INNER JOIN "chat" AS c
+						ON c.id = uc.chat_id
+						WHERE uc.chat_id = $1
+						  AND uc.user_id = $2
+						  AND c.is_deleted = false`
+)
+
+func TestScheduledPostgres_GetDueScheduled(t *testing.T) {
+	// Создаём мок объекта базы данных
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	in := entity.ScheduledDue{Now: now, LeaseBefore: now.Add(-time.Minute), Limit: 10}
+
+	// Вместе с ожидающими выбираются сообщения, аренда которых истекла
+	rows := sqlmock.NewRows([]string{"id", "chat_id", "user_id", "text", "format", "send_at", "status", "error", "created_at"}).
+		AddRow(1, 2, 3, "hello", "plain", "2026-10-19T11:00:00Z", scheduledPending, "", "2026-10-18T11:00:00Z").
+		AddRow(4, 2, 3, "stale", "plain", "2026-10-19T10:00:00Z", scheduledSending, "", "2026-10-18T10:00:00Z")
+	mock.ExpectPrepare(sqlScheduledDue).ExpectQuery().
+		WithArgs(scheduledPending, "2026-10-19 12:00:00", 10, scheduledSending, "2026-10-19 11:59:00").
+		WillReturnRows(rows)
+
+	got, err := NewScheduledPostgres(db).GetDueScheduled(in)
+	assert.NoError(t, err)
+	assert.Equal(t, []entity.ScheduledMessage{
+		{Id: 1, ChatID: 2, UserID: 3, Text: "hello", Format: "plain", SendAt: "2026-10-19T11:00:00Z",
+			Status: scheduledPending, CreatedAt: "2026-10-18T11:00:00Z"},
+		{Id: 4, ChatID: 2, UserID: 3, Text: "stale", Format: "plain", SendAt: "2026-10-19T10:00:00Z",
+			Status: scheduledSending, CreatedAt: "2026-10-18T10:00:00Z"},
+	}, got)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestScheduledPostgres_ClaimScheduled(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	in := entity.ScheduledClaim{ID: 4, Now: now, LeaseBefore: now.Add(-time.Minute)}
+
+	// Функция для определения поведения мока базы данных
+	type mockBehavior func(mock sqlmock.Sqlmock)
+
+	tests := []struct {
+		name    string
+		mock    mockBehavior
+		want    *entity.ScheduledMessage
+		wantErr string
+	}{
+		{
+			// Ожидающее сообщение или сообщение, брошенное упавшим экземпляром, забирается одним запросом
+			name: "Claimed",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectPrepare(sqlScheduledClaim).ExpectQuery().
+					WithArgs(int64(4), scheduledPending, scheduledSending, "2026-10-19 12:00:00", "2026-10-19 11:59:00").
+					WillReturnRows(sqlmock.NewRows([]string{"chat_id", "user_id", "text", "format"}).AddRow(2, 3, "stale", "plain"))
+				mock.ExpectPrepare(sqlScheduledMember).ExpectQuery().WithArgs(int64(2), 3).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(7))
+				mock.ExpectCommit()
+			},
+			want: &entity.ScheduledMessage{Id: 4, ChatID: 2, UserID: 3, Text: "stale", Format: "plain", Status: scheduledSending},
+		},
+		{
+			// Уже отправлено или аренда другого экземпляра ещё не истекла
+			name: "Not claimable",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectPrepare(sqlScheduledClaim).ExpectQuery().
+					WithArgs(int64(4), scheduledPending, scheduledSending, "2026-10-19 12:00:00", "2026-10-19 11:59:00").
+					WillReturnRows(sqlmock.NewRows([]string{"chat_id", "user_id", "text", "format"}))
+				mock.ExpectRollback()
+			},
+		},
+		{
+			name: "Prepare error",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectPrepare(sqlScheduledClaim).WillReturnError(errors.New("connection reset"))
+				mock.ExpectRollback()
+			},
+			wantErr: "error path: db.ClaimScheduled, error: connection reset",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Создаём мок объекта базы данных
+			db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer func() { _ = db.Close() }()
+
+			tt.mock(mock)
+
+			got, err := NewScheduledPostgres(db).ClaimScheduled(in)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
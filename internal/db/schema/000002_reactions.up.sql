@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS "reaction" (
+    "message_id" integer NOT NULL,
+    "user_id" integer NOT NULL,
+    "emoji" varchar(32) NOT NULL,
+    "created_at" timestamp NOT NULL DEFAULT (now()),
+    PRIMARY KEY ("message_id", "user_id", "emoji")
+);
+
+ALTER TABLE "reaction" ADD FOREIGN KEY ("message_id") REFERENCES "message" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "reaction" ADD FOREIGN KEY ("user_id") REFERENCES "user" ("id") ON DELETE NO ACTION;
@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS "attachment" (
+    "id" INTEGER GENERATED BY DEFAULT AS IDENTITY UNIQUE PRIMARY KEY NOT NULL,
+    "message_id" integer NOT NULL,
+    "user_id" integer NOT NULL,
+    "file_name" varchar(255) NOT NULL,
+    "content_type" varchar(255) NOT NULL,
+    "size" bigint NOT NULL,
+    "storage_key" varchar(255) UNIQUE NOT NULL,
+    "thumbnail_key" varchar(255),
+    "created_at" timestamp NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "attachment" ADD FOREIGN KEY ("message_id") REFERENCES "message" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "attachment" ADD FOREIGN KEY ("user_id") REFERENCES "user" ("id") ON DELETE NO ACTION;
+
+CREATE INDEX IF NOT EXISTS "attachment_message_id_idx" ON "attachment" ("message_id");
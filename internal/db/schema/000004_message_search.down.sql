@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS "message_text_tsv_idx";
+
+ALTER TABLE "message" DROP COLUMN IF EXISTS "text_tsv";
\ No newline at end of file
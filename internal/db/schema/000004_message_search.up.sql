@@ -0,0 +1,5 @@
+-- полнотекстовый поиск по сообщениям, конфигурация simple не зависит от языка сообщения
+ALTER TABLE "message" ADD COLUMN IF NOT EXISTS "text_tsv" tsvector
+    GENERATED ALWAYS AS (to_tsvector('simple', "text")) STORED;
+
+CREATE INDEX IF NOT EXISTS "message_text_tsv_idx" ON "message" USING GIN ("text_tsv");
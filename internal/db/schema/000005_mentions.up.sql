@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS "mention" (
+    "message_id" integer NOT NULL,
+    "user_id" integer NOT NULL,
+    -- позиция упоминания в тексте сообщения в символах
+    "offset" integer NOT NULL,
+    "length" integer NOT NULL,
+    "created_at" timestamp NOT NULL DEFAULT (now()),
+    PRIMARY KEY ("message_id", "offset")
+);
+
+ALTER TABLE "mention" ADD FOREIGN KEY ("message_id") REFERENCES "message" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "mention" ADD FOREIGN KEY ("user_id") REFERENCES "user" ("id") ON DELETE NO ACTION;
+
+CREATE INDEX IF NOT EXISTS "mention_user_id_idx" ON "mention" ("user_id", "message_id" DESC);
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS "pin";
+
+ALTER TABLE "message" DROP COLUMN IF EXISTS "kind";
+
+ALTER TABLE "users_chat" DROP COLUMN IF EXISTS "role";
\ No newline at end of file
@@ -0,0 +1,25 @@
+-- роль участника в чате, администратором становится создатель чата
+ALTER TABLE "users_chat" ADD COLUMN IF NOT EXISTS "role" varchar(16) NOT NULL DEFAULT 'member';
+
+-- в уже существующих чатах администратором назначаем первого добавленного участника
+UPDATE "users_chat" SET "role" = 'admin'
+WHERE id IN (SELECT MIN(id) FROM "users_chat" GROUP BY chat_id);
+
+-- тип сообщения: user - сообщение пользователя, system - служебное сообщение о событии в чате
+ALTER TABLE "message" ADD COLUMN IF NOT EXISTS "kind" varchar(16) NOT NULL DEFAULT 'user';
+
+CREATE TABLE IF NOT EXISTS "pin" (
+    "chat_id" integer NOT NULL,
+    "message_id" integer NOT NULL,
+    "user_id" integer NOT NULL,
+    "created_at" timestamp NOT NULL DEFAULT (now()),
+    PRIMARY KEY ("chat_id", "message_id")
+);
+
+ALTER TABLE "pin" ADD FOREIGN KEY ("chat_id") REFERENCES "chat" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "pin" ADD FOREIGN KEY ("message_id") REFERENCES "message" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "pin" ADD FOREIGN KEY ("user_id") REFERENCES "user" ("id") ON DELETE NO ACTION;
+
+CREATE UNIQUE INDEX IF NOT EXISTS "pin_message_id_idx" ON "pin" ("message_id");
@@ -0,0 +1,4 @@
+ALTER TABLE "message" DROP COLUMN IF EXISTS "forward_created_at";
+ALTER TABLE "message" DROP COLUMN IF EXISTS "forward_chat_id";
+ALTER TABLE "message" DROP COLUMN IF EXISTS "forward_user_id";
+ALTER TABLE "message" DROP COLUMN IF EXISTS "forward_message_id";
\ No newline at end of file
@@ -0,0 +1,12 @@
+-- источник пересланного сообщения: исходное сообщение, его автор, чат и время отправки.
+-- При повторной пересылке сохраняется самый первый источник
+ALTER TABLE "message" ADD COLUMN IF NOT EXISTS "forward_message_id" integer NULL;
+ALTER TABLE "message" ADD COLUMN IF NOT EXISTS "forward_user_id" integer NULL;
+ALTER TABLE "message" ADD COLUMN IF NOT EXISTS "forward_chat_id" integer NULL;
+ALTER TABLE "message" ADD COLUMN IF NOT EXISTS "forward_created_at" timestamp NULL;
+
+ALTER TABLE "message" ADD FOREIGN KEY ("forward_message_id") REFERENCES "message" ("id") ON DELETE SET NULL;
+
+ALTER TABLE "message" ADD FOREIGN KEY ("forward_user_id") REFERENCES "user" ("id") ON DELETE NO ACTION;
+
+ALTER TABLE "message" ADD FOREIGN KEY ("forward_chat_id") REFERENCES "chat" ("id") ON DELETE SET NULL;
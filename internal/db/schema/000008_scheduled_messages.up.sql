@@ -0,0 +1,26 @@
+-- отложенные сообщения хранятся отдельно от message и не видны в чате до отправки
+CREATE TABLE IF NOT EXISTS "scheduled_message" (
+    "id" INTEGER GENERATED BY DEFAULT AS IDENTITY UNIQUE PRIMARY KEY NOT NULL,
+    "chat_id" integer NOT NULL,
+    "user_id" integer NOT NULL,
+    "text" varchar(255) NOT NULL,
+    "send_at" timestamp NOT NULL,
+    -- pending - ждёт отправки, sent - отправлено, cancelled - отменено автором, failed - не удалось отправить
+    "status" varchar(16) NOT NULL DEFAULT 'pending',
+    -- сообщение, созданное при отправке
+    "message_id" integer NULL,
+    "error" varchar(255) NULL,
+    "created_at" timestamp NOT NULL DEFAULT (now()),
+    "updated_at" timestamp NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "scheduled_message" ADD FOREIGN KEY ("chat_id") REFERENCES "chat" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "scheduled_message" ADD FOREIGN KEY ("user_id") REFERENCES "user" ("id") ON DELETE NO ACTION;
+
+ALTER TABLE "scheduled_message" ADD FOREIGN KEY ("message_id") REFERENCES "message" ("id") ON DELETE SET NULL;
+
+-- поиск сообщений, которые пора отправить
+CREATE INDEX IF NOT EXISTS "scheduled_message_due_idx" ON "scheduled_message" ("send_at") WHERE "status" = 'pending';
+
+CREATE INDEX IF NOT EXISTS "scheduled_message_user_id_idx" ON "scheduled_message" ("user_id", "send_at");
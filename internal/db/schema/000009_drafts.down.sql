@@ -0,0 +1 @@
+DROP TABLE IF EXISTS "draft";
\ No newline at end of file
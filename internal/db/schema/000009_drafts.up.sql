@@ -0,0 +1,15 @@
+-- черновик сообщения пользователя в чате, общий для всех устройств пользователя.
+-- version задаёт клиент, сохраняется только запись с большей версией (last-writer-wins),
+-- удаление черновика сохраняется как пустой текст со своей версией
+CREATE TABLE IF NOT EXISTS "draft" (
+    "user_id" integer NOT NULL,
+    "chat_id" integer NOT NULL,
+    "text" text NOT NULL DEFAULT '',
+    "version" bigint NOT NULL,
+    "updated_at" timestamp NOT NULL DEFAULT (now()),
+    PRIMARY KEY ("user_id", "chat_id")
+);
+
+ALTER TABLE "draft" ADD FOREIGN KEY ("user_id") REFERENCES "user" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "draft" ADD FOREIGN KEY ("chat_id") REFERENCES "chat" ("id") ON DELETE CASCADE;
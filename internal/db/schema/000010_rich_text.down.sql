@@ -0,0 +1,15 @@
+ALTER TABLE "scheduled_message" DROP COLUMN IF EXISTS "format";
+
+ALTER TABLE "scheduled_message" ALTER COLUMN "text" TYPE varchar(255) USING left("text", 255);
+
+ALTER TABLE "message" DROP COLUMN IF EXISTS "format";
+
+DROP INDEX IF EXISTS "message_text_tsv_idx";
+ALTER TABLE "message" DROP COLUMN IF EXISTS "text_tsv";
+
+ALTER TABLE "message" ALTER COLUMN "text" TYPE varchar(255) USING left("text", 255);
+
+ALTER TABLE "message" ADD COLUMN IF NOT EXISTS "text_tsv" tsvector
+    GENERATED ALWAYS AS (to_tsvector('simple', "text")) STORED;
+
+CREATE INDEX IF NOT EXISTS "message_text_tsv_idx" ON "message" USING GIN ("text_tsv");
\ No newline at end of file
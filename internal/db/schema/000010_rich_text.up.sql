@@ -0,0 +1,18 @@
+-- текст сообщения больше не ограничен 255 символами, лимит задаётся в конфиге сервиса.
+-- Сгенерированную колонку поиска пересоздаём, так как тип колонки text под ней менять нельзя
+DROP INDEX IF EXISTS "message_text_tsv_idx";
+ALTER TABLE "message" DROP COLUMN IF EXISTS "text_tsv";
+
+ALTER TABLE "message" ALTER COLUMN "text" TYPE text;
+
+ALTER TABLE "message" ADD COLUMN IF NOT EXISTS "text_tsv" tsvector
+    GENERATED ALWAYS AS (to_tsvector('simple', "text")) STORED;
+
+CREATE INDEX IF NOT EXISTS "message_text_tsv_idx" ON "message" USING GIN ("text_tsv");
+
+-- формат текста сообщения: plain - обычный текст, markdown - разметка markdown
+ALTER TABLE "message" ADD COLUMN IF NOT EXISTS "format" varchar(16) NOT NULL DEFAULT 'plain';
+
+ALTER TABLE "scheduled_message" ALTER COLUMN "text" TYPE text;
+
+ALTER TABLE "scheduled_message" ADD COLUMN IF NOT EXISTS "format" varchar(16) NOT NULL DEFAULT 'plain';
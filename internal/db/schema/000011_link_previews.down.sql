@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS "message_link";
+
+DROP TABLE IF EXISTS "link_preview";
\ No newline at end of file
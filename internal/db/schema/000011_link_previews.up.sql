@@ -0,0 +1,21 @@
+-- кэш превью ссылок (Open Graph), одно превью на адрес для всех сообщений.
+-- status = failed сохраняется, чтобы не запрашивать недоступный адрес повторно до истечения кэша
+CREATE TABLE IF NOT EXISTS "link_preview" (
+    "url" text PRIMARY KEY,
+    "title" text NOT NULL DEFAULT '',
+    "description" text NOT NULL DEFAULT '',
+    "image_url" text NOT NULL DEFAULT '',
+    "site_name" text NOT NULL DEFAULT '',
+    "status" varchar(16) NOT NULL DEFAULT 'ok',
+    "fetched_at" timestamp NOT NULL DEFAULT (now())
+);
+
+-- ссылки, найденные в тексте сообщения, position - порядок ссылки в тексте
+CREATE TABLE IF NOT EXISTS "message_link" (
+    "message_id" integer NOT NULL,
+    "url" text NOT NULL,
+    "position" integer NOT NULL DEFAULT 0,
+    PRIMARY KEY ("message_id", "url")
+);
+
+ALTER TABLE "message_link" ADD FOREIGN KEY ("message_id") REFERENCES "message" ("id") ON DELETE CASCADE;
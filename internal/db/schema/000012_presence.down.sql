@@ -0,0 +1 @@
+ALTER TABLE "user" DROP COLUMN IF EXISTS "last_seen_at";
\ No newline at end of file
@@ -0,0 +1,3 @@
+-- время, когда пользователь последний раз был в сети: сохраняется при закрытии его последнего подключения,
+-- текущий статус (online, away, offline) хранится только в памяти сервиса
+ALTER TABLE "user" ADD COLUMN IF NOT EXISTS "last_seen_at" timestamp;
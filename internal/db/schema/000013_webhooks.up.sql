@@ -0,0 +1,49 @@
+-- исходящие вебхуки чата, secret - ключ подписи запросов HMAC-SHA256
+CREATE TABLE IF NOT EXISTS "webhook" (
+    "id" INTEGER GENERATED BY DEFAULT AS IDENTITY UNIQUE PRIMARY KEY NOT NULL,
+    "chat_id" integer NOT NULL,
+    "user_id" integer NOT NULL,
+    "url" varchar(2048) NOT NULL,
+    "secret" varchar(128) NOT NULL,
+    "created_at" timestamp NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "webhook" ADD FOREIGN KEY ("chat_id") REFERENCES "chat" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "webhook" ADD FOREIGN KEY ("user_id") REFERENCES "user" ("id") ON DELETE NO ACTION;
+
+CREATE INDEX IF NOT EXISTS "webhook_chat_id_idx" ON "webhook" ("chat_id");
+
+-- очередь событий на отправку (outbox), пишется в одной транзакции с сообщением.
+-- pending - ждёт отправки, delivered - доставлено, dead - попытки исчерпаны
+CREATE TABLE IF NOT EXISTS "webhook_delivery" (
+    "id" BIGINT GENERATED BY DEFAULT AS IDENTITY UNIQUE PRIMARY KEY NOT NULL,
+    "webhook_id" integer NOT NULL,
+    "event" varchar(64) NOT NULL,
+    "payload" text NOT NULL,
+    "status" varchar(16) NOT NULL DEFAULT 'pending',
+    "attempts" integer NOT NULL DEFAULT 0,
+    "next_attempt_at" timestamp NOT NULL DEFAULT (now()),
+    "created_at" timestamp NOT NULL DEFAULT (now()),
+    "updated_at" timestamp NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "webhook_delivery" ADD FOREIGN KEY ("webhook_id") REFERENCES "webhook" ("id") ON DELETE CASCADE;
+
+-- поиск событий, которые пора отправить
+CREATE INDEX IF NOT EXISTS "webhook_delivery_due_idx" ON "webhook_delivery" ("next_attempt_at") WHERE "status" = 'pending';
+
+-- история попыток отправки, status_code = 0 - ответ не получен
+CREATE TABLE IF NOT EXISTS "webhook_attempt" (
+    "id" BIGINT GENERATED BY DEFAULT AS IDENTITY UNIQUE PRIMARY KEY NOT NULL,
+    "delivery_id" bigint NOT NULL,
+    "attempt" integer NOT NULL,
+    "status_code" integer NOT NULL DEFAULT 0,
+    "error" varchar(255) NULL,
+    "duration_ms" integer NOT NULL DEFAULT 0,
+    "created_at" timestamp NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "webhook_attempt" ADD FOREIGN KEY ("delivery_id") REFERENCES "webhook_delivery" ("id") ON DELETE CASCADE;
+
+CREATE INDEX IF NOT EXISTS "webhook_attempt_delivery_id_idx" ON "webhook_attempt" ("delivery_id");
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS "incoming_webhook";
+
+ALTER TABLE "user" DROP COLUMN IF EXISTS "is_bot";
\ No newline at end of file
@@ -0,0 +1,21 @@
+-- служебные пользователи, от имени которых пишут входящие вебхуки, войти под ними нельзя
+ALTER TABLE "user" ADD COLUMN IF NOT EXISTS "is_bot" boolean NOT NULL DEFAULT false;
+
+-- входящие вебхуки чата, token_hash - sha256 от токена из адреса вебхука, сам токен не хранится
+CREATE TABLE IF NOT EXISTS "incoming_webhook" (
+    "id" INTEGER GENERATED BY DEFAULT AS IDENTITY UNIQUE PRIMARY KEY NOT NULL,
+    "chat_id" integer NOT NULL,
+    "user_id" integer NOT NULL,
+    "bot_id" integer NOT NULL,
+    "name" varchar(64) NOT NULL,
+    "token_hash" varchar(64) UNIQUE NOT NULL,
+    "created_at" timestamp NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "incoming_webhook" ADD FOREIGN KEY ("chat_id") REFERENCES "chat" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "incoming_webhook" ADD FOREIGN KEY ("user_id") REFERENCES "user" ("id") ON DELETE NO ACTION;
+
+ALTER TABLE "incoming_webhook" ADD FOREIGN KEY ("bot_id") REFERENCES "user" ("id") ON DELETE NO ACTION;
+
+CREATE INDEX IF NOT EXISTS "incoming_webhook_chat_id_idx" ON "incoming_webhook" ("chat_id");
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS "bot_command";
\ No newline at end of file
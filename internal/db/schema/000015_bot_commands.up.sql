@@ -0,0 +1,20 @@
+-- команды чата, которые обрабатывает внешний бот: сообщение /command отправляется на callback_url,
+-- ответ бота пишет служебный пользователь bot_id или получает только вызвавший команду
+CREATE TABLE IF NOT EXISTS "bot_command" (
+    "id" INTEGER GENERATED BY DEFAULT AS IDENTITY UNIQUE PRIMARY KEY NOT NULL,
+    "chat_id" integer NOT NULL,
+    "user_id" integer NOT NULL,
+    "bot_id" integer NOT NULL,
+    "command" varchar(32) NOT NULL,
+    "description" varchar(256) NOT NULL DEFAULT '',
+    "callback_url" varchar(2048) NOT NULL,
+    "secret" varchar(128) NOT NULL,
+    "created_at" timestamp NOT NULL DEFAULT (now()),
+    UNIQUE ("chat_id", "command")
+);
+
+ALTER TABLE "bot_command" ADD FOREIGN KEY ("chat_id") REFERENCES "chat" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "bot_command" ADD FOREIGN KEY ("user_id") REFERENCES "user" ("id") ON DELETE NO ACTION;
+
+ALTER TABLE "bot_command" ADD FOREIGN KEY ("bot_id") REFERENCES "user" ("id") ON DELETE NO ACTION;
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS "poll_vote";
+
+DROP TABLE IF EXISTS "poll_option";
+
+DROP TABLE IF EXISTS "poll";
\ No newline at end of file
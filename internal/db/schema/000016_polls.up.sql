@@ -0,0 +1,39 @@
+-- опрос в чате: вопрос хранится в тексте сообщения вида poll, варианты ответа - в poll_option.
+-- close_at - время закрытия опроса в UTC, после него голоса не принимаются
+CREATE TABLE IF NOT EXISTS "poll" (
+    "id" INTEGER GENERATED BY DEFAULT AS IDENTITY UNIQUE PRIMARY KEY NOT NULL,
+    "message_id" integer NOT NULL UNIQUE,
+    "multiple" boolean NOT NULL DEFAULT false,
+    "anonymous" boolean NOT NULL DEFAULT false,
+    "close_at" timestamp,
+    "created_at" timestamp NOT NULL DEFAULT (now())
+);
+
+CREATE TABLE IF NOT EXISTS "poll_option" (
+    "id" INTEGER GENERATED BY DEFAULT AS IDENTITY UNIQUE PRIMARY KEY NOT NULL,
+    "poll_id" integer NOT NULL,
+    "position" smallint NOT NULL,
+    "text" varchar(100) NOT NULL,
+    UNIQUE ("poll_id", "position")
+);
+
+-- голос участника чата за вариант ответа, в опросе с одним ответом у пользователя не больше одного голоса
+CREATE TABLE IF NOT EXISTS "poll_vote" (
+    "poll_id" integer NOT NULL,
+    "option_id" integer NOT NULL,
+    "user_id" integer NOT NULL,
+    "created_at" timestamp NOT NULL DEFAULT (now()),
+    PRIMARY KEY ("option_id", "user_id")
+);
+
+ALTER TABLE "poll" ADD FOREIGN KEY ("message_id") REFERENCES "message" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "poll_option" ADD FOREIGN KEY ("poll_id") REFERENCES "poll" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "poll_vote" ADD FOREIGN KEY ("poll_id") REFERENCES "poll" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "poll_vote" ADD FOREIGN KEY ("option_id") REFERENCES "poll_option" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "poll_vote" ADD FOREIGN KEY ("user_id") REFERENCES "user" ("id") ON DELETE NO ACTION;
+
+CREATE INDEX IF NOT EXISTS "poll_vote_poll_id_user_id_idx" ON "poll_vote" ("poll_id", "user_id");
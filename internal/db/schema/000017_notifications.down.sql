@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS "notification";
+
+DROP TABLE IF EXISTS "notification_settings";
+
+ALTER TABLE "users_chat" DROP COLUMN IF EXISTS "muted_until";
+
+ALTER TABLE "users_chat" DROP COLUMN IF EXISTS "notify";
\ No newline at end of file
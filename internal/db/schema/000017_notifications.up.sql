@@ -0,0 +1,43 @@
+-- уровень уведомлений участника чата: all - о всех сообщениях, mentions - только об упоминаниях, none - без уведомлений.
+-- До muted_until (UTC) чат заглушён и уведомления не создаются совсем
+ALTER TABLE "users_chat" ADD COLUMN IF NOT EXISTS "notify" varchar(16) NOT NULL DEFAULT 'all';
+
+ALTER TABLE "users_chat" ADD COLUMN IF NOT EXISTS "muted_until" timestamp;
+
+-- адреса, на которые пользователь получает уведомления, пока он не в сети
+CREATE TABLE IF NOT EXISTS "notification_settings" (
+    "user_id" integer UNIQUE PRIMARY KEY NOT NULL,
+    "email" varchar(320) NOT NULL DEFAULT '',
+    "push_token" varchar(512) NOT NULL DEFAULT '',
+    "updated_at" timestamp NOT NULL DEFAULT (now())
+);
+
+-- очередь уведомлений: уведомления пользователя в одном канале, время отправки которых наступило,
+-- отправляются одной сводкой вместе с остальными ожидающими уведомлениями канала
+CREATE TABLE IF NOT EXISTS "notification" (
+    "id" INTEGER GENERATED BY DEFAULT AS IDENTITY UNIQUE PRIMARY KEY NOT NULL,
+    "user_id" integer NOT NULL,
+    "channel" varchar(16) NOT NULL,
+    "chat_id" integer NOT NULL,
+    "message_id" integer NOT NULL,
+    "mention" boolean NOT NULL DEFAULT false,
+    -- status: pending - ждёт отправки, sent - отправлено, skipped - сообщение удалено до отправки, failed - попытки закончились
+    "status" varchar(16) NOT NULL DEFAULT 'pending',
+    "attempts" integer NOT NULL DEFAULT 0,
+    "send_after" timestamp NOT NULL,
+    "error" varchar(255),
+    "created_at" timestamp NOT NULL DEFAULT (now()),
+    "sent_at" timestamp
+);
+
+ALTER TABLE "notification_settings" ADD FOREIGN KEY ("user_id") REFERENCES "user" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "notification" ADD FOREIGN KEY ("user_id") REFERENCES "user" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "notification" ADD FOREIGN KEY ("chat_id") REFERENCES "chat" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "notification" ADD FOREIGN KEY ("message_id") REFERENCES "message" ("id") ON DELETE CASCADE;
+
+CREATE INDEX IF NOT EXISTS "notification_pending_idx" ON "notification" ("channel", "send_after") WHERE "status" = 'pending';
+
+CREATE INDEX IF NOT EXISTS "notification_user_idx" ON "notification" ("user_id", "channel") WHERE "status" = 'pending';
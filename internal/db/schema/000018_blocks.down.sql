@@ -0,0 +1 @@
+DROP TABLE IF EXISTS "user_block";
\ No newline at end of file
@@ -0,0 +1,15 @@
+-- чёрный список пользователя: blocked_id не может создать личный чат с blocker_id и упомянуть его,
+-- а сообщения blocked_id в общих чатах скрыты от blocker_id, если это включено в конфиге
+CREATE TABLE IF NOT EXISTS "user_block" (
+    "blocker_id" integer NOT NULL,
+    "blocked_id" integer NOT NULL,
+    "created_at" timestamp NOT NULL DEFAULT (now()),
+    PRIMARY KEY ("blocker_id", "blocked_id"),
+    CHECK ("blocker_id" <> "blocked_id")
+);
+
+ALTER TABLE "user_block" ADD FOREIGN KEY ("blocker_id") REFERENCES "user" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "user_block" ADD FOREIGN KEY ("blocked_id") REFERENCES "user" ("id") ON DELETE CASCADE;
+
+CREATE INDEX IF NOT EXISTS "user_block_blocked_idx" ON "user_block" ("blocked_id");
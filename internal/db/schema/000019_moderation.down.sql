@@ -0,0 +1,11 @@
+DROP TRIGGER IF EXISTS "moderation_action_immutable" ON "moderation_action";
+
+DROP FUNCTION IF EXISTS moderation_action_immutable();
+
+DROP TABLE IF EXISTS "report";
+
+DROP TABLE IF EXISTS "moderation_action";
+
+ALTER TABLE "user" DROP COLUMN IF EXISTS "suspended_until";
+
+ALTER TABLE "user" DROP COLUMN IF EXISTS "role";
\ No newline at end of file
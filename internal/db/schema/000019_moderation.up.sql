@@ -0,0 +1,60 @@
+-- роль пользователя: user - обычный пользователь, moderator - разбирает жалобы. Роль назначается в бд.
+-- До suspended_until (UTC) пользователь отстранён и не может пользоваться сервисом
+ALTER TABLE "user" ADD COLUMN IF NOT EXISTS "role" varchar(16) NOT NULL DEFAULT 'user';
+
+ALTER TABLE "user" ADD COLUMN IF NOT EXISTS "suspended_until" timestamp;
+
+-- жалоба участника чата на сообщение, на одно сообщение пользователь жалуется один раз.
+-- status: open - ждёт модератора, resolved - модератор принял меры, dismissed - жалоба отклонена
+CREATE TABLE IF NOT EXISTS "report" (
+    "id" INTEGER GENERATED BY DEFAULT AS IDENTITY UNIQUE PRIMARY KEY NOT NULL,
+    "message_id" integer NOT NULL,
+    "reporter_id" integer NOT NULL,
+    "reason" varchar(32) NOT NULL,
+    "comment" varchar(500) NOT NULL DEFAULT '',
+    "status" varchar(16) NOT NULL DEFAULT 'open',
+    "created_at" timestamp NOT NULL DEFAULT (now()),
+    "resolved_at" timestamp,
+    "action_id" integer,
+    UNIQUE ("message_id", "reporter_id")
+);
+
+-- журнал действий модераторов: записи только добавляются, изменить или удалить их нельзя
+CREATE TABLE IF NOT EXISTS "moderation_action" (
+    "id" INTEGER GENERATED BY DEFAULT AS IDENTITY UNIQUE PRIMARY KEY NOT NULL,
+    "moderator_id" integer NOT NULL,
+    "action" varchar(16) NOT NULL,
+    "report_id" integer NOT NULL,
+    "message_id" integer NOT NULL,
+    "user_id" integer NOT NULL,
+    "note" varchar(500) NOT NULL DEFAULT '',
+    "suspended_until" timestamp,
+    "created_at" timestamp NOT NULL DEFAULT (now())
+);
+
+ALTER TABLE "report" ADD FOREIGN KEY ("message_id") REFERENCES "message" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "report" ADD FOREIGN KEY ("reporter_id") REFERENCES "user" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "report" ADD FOREIGN KEY ("action_id") REFERENCES "moderation_action" ("id");
+
+ALTER TABLE "moderation_action" ADD FOREIGN KEY ("moderator_id") REFERENCES "user" ("id");
+
+ALTER TABLE "moderation_action" ADD FOREIGN KEY ("user_id") REFERENCES "user" ("id");
+
+CREATE INDEX IF NOT EXISTS "report_open_idx" ON "report" ("created_at") WHERE "status" = 'open';
+
+CREATE INDEX IF NOT EXISTS "moderation_action_user_idx" ON "moderation_action" ("user_id", "created_at");
+
+-- функция запрещает изменять и удалять записи журнала действий модераторов
+CREATE OR REPLACE FUNCTION moderation_action_immutable() RETURNS trigger AS
+$$
+BEGIN
+    RAISE EXCEPTION 'moderation_action is append-only';
+END;
+$$
+LANGUAGE plpgsql;
+
+CREATE TRIGGER "moderation_action_immutable"
+    BEFORE UPDATE OR DELETE ON "moderation_action"
+    FOR EACH ROW EXECUTE FUNCTION moderation_action_immutable();
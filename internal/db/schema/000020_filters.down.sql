@@ -0,0 +1,5 @@
+DELETE FROM "report" WHERE "reporter_id" IS NULL;
+
+ALTER TABLE "report" ALTER COLUMN "reporter_id" SET NOT NULL;
+
+DROP TABLE IF EXISTS "chat_filter";
\ No newline at end of file
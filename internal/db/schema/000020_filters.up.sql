@@ -0,0 +1,16 @@
+-- действие фильтра текста сообщений, заданное администратором для своего чата вместо действия из конфига
+CREATE TABLE IF NOT EXISTS "chat_filter" (
+    "chat_id" integer NOT NULL,
+    "filter" varchar(16) NOT NULL,
+    "action" varchar(8) NOT NULL,
+    "updated_by" integer,
+    "updated_at" timestamp NOT NULL DEFAULT (now()),
+    PRIMARY KEY ("chat_id", "filter")
+);
+
+ALTER TABLE "chat_filter" ADD FOREIGN KEY ("chat_id") REFERENCES "chat" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "chat_filter" ADD FOREIGN KEY ("updated_by") REFERENCES "user" ("id") ON DELETE SET NULL;
+
+-- жалобы без автора оставляет фильтр текста сообщений с действием flag
+ALTER TABLE "report" ALTER COLUMN "reporter_id" DROP NOT NULL;
@@ -0,0 +1,10 @@
+-- корзины токенов ограничения частоты запросов, общие для всех экземпляров сервиса.
+-- После full_at корзина полная, и строку можно удалить без потери состояния
+CREATE UNLOGGED TABLE IF NOT EXISTS "rate_limit" (
+    "key" varchar(255) PRIMARY KEY NOT NULL,
+    "tokens" double precision NOT NULL,
+    "updated_at" timestamp NOT NULL,
+    "full_at" timestamp NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS "rate_limit_full_idx" ON "rate_limit" ("full_at");
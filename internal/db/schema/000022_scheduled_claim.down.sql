@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS "scheduled_message_claimed_idx";
+
+ALTER TABLE "scheduled_message" DROP COLUMN IF EXISTS "claimed_at";
\ No newline at end of file
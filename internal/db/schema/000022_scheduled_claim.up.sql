@@ -0,0 +1,6 @@
+-- время, когда экземпляр сервиса забрал сообщение на отправку. Если экземпляр упал во время отправки,
+-- по истечении аренды сообщение в статусе sending снова забирается на отправку
+ALTER TABLE "scheduled_message" ADD COLUMN IF NOT EXISTS "claimed_at" timestamp NULL;
+
+-- поиск сообщений, аренда которых истекла
+CREATE INDEX IF NOT EXISTS "scheduled_message_claimed_idx" ON "scheduled_message" ("claimed_at") WHERE "status" = 'sending';
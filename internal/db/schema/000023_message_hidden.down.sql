@@ -0,0 +1 @@
+ALTER TABLE "message" DROP COLUMN IF EXISTS "is_hidden";
\ No newline at end of file
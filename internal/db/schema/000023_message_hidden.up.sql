@@ -0,0 +1,7 @@
+-- сообщение скрыто модератором: для участников чата оно выглядит как удалённое, а текст, вложения,
+-- упоминания и превью не отдаются. Исходный текст остаётся у модераторов в жалобах
+ALTER TABLE "message" ADD COLUMN IF NOT EXISTS "is_hidden" boolean NOT NULL DEFAULT false;
+
+-- сообщения, скрытые до появления колонки, определяем по журналу модераторов
+UPDATE "message" SET "is_hidden" = true
+WHERE "id" IN (SELECT "message_id" FROM "moderation_action" WHERE "action" = 'hide');
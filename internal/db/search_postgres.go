@@ -0,0 +1,88 @@
+package db
+
+import (
+	"fmt"
+
+	"service-chat/internal/db/entity"
+)
+
+const (
+	opMessageSearch = "db.SearchMessage"
+)
+
+// SearchMessage - полнотекстовый поиск по сообщениям в чатах, в которых состоит пользователь.
+// Результаты отсортированы по релевантности, пагинация по паре (rank, id) последнего результата
+func (m *MessagePostgres) SearchMessage(in entity.MessageSearch) ([]entity.SearchResult, error) {
+	// Скелет sql запроса на поиск, подсветка совпадений считается только для страницы результатов,
+	// текст экранируется до подсветки, чтобы фрагмент был безопасен для HTML
+	stmt, err := m.db.Prepare(`WITH q AS (
+										SELECT websearch_to_tsquery('simple', $2) AS query
+									),
+									found AS (
+										SELECT m.id, m.text, m.user_id, m.created_at, m.is_deleted, author.chat_id,
+											   ts_rank(m.text_tsv, q.query) AS rank
+										FROM q, "message" AS m
+										INNER JOIN "chats_messages" AS cm
+										ON cm.message_id = m.id
+										INNER JOIN "users_chat" AS author
+										ON author.id = cm.users_chat_id
+										INNER JOIN "chat" AS c
+										ON c.id = author.chat_id
+										WHERE m.text_tsv @@ q.query
+										  AND m.is_deleted = false
+										  AND c.is_deleted = false
+										  AND author.chat_id IN (SELECT chat_id FROM "users_chat" WHERE user_id = $1)
+										  AND ($3::integer IS NULL OR author.chat_id = $3)
+										  AND ($4::integer IS NULL OR m.user_id = $4)
+										  AND ($5::timestamp IS NULL OR m.created_at >= $5)
+										  AND ($6::timestamp IS NULL OR m.created_at < $6)
+									),
+									page AS (
+										SELECT *
+										FROM found
+										WHERE $7::real IS NULL OR (rank, id) < ($7::real, $8)
+										ORDER BY rank DESC, id DESC
+										LIMIT $9
+									)
+									SELECT page.id, page.text, page.user_id, page.created_at, page.is_deleted, page.chat_id, page.rank,
+										   ts_headline('simple',
+													   replace(replace(replace(page.text, '&', '&amp;'), '<', '&lt;'), '>', '&gt;'),
+													   q.query,
+													   'StartSel=<mark>, StopSel=</mark>, MaxFragments=2, MinWords=5, MaxWords=20')
+									FROM page, q
+									ORDER BY page.rank DESC, page.id DESC`)
+	if err != nil {
+		return nil, fmt.Errorf("error path: %s, error: %w", opMessageSearch, err)
+	}
+	defer stmt.Close()
+
+	// Необязательные фильтры передаём как NULL
+	var afterRank any
+	if in.AfterRank != nil {
+		afterRank = *in.AfterRank
+	}
+	rows, err := stmt.Query(in.UserID, in.Query, nullID(in.ChatID), nullID(in.AuthorID),
+		nullTime(in.From), nullTime(in.To), afterRank, in.AfterID, in.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("error path: %s, error: %w", opMessageSearch, err)
+	}
+	defer rows.Close()
+
+	// Структура для записи всех найденных сообщений из бд
+	var results []entity.SearchResult
+	for rows.Next() {
+		var res entity.SearchResult
+		if errSc := rows.Scan(&res.Message.Id, &res.Message.Text, &res.Message.UserID, &res.Message.CreatedAt,
+			&res.Message.IsDeleted, &res.ChatID, &res.Rank, &res.Snippet); errSc != nil {
+			return nil, fmt.Errorf("error path: %s, error: %w", opMessageSearch, errSc)
+		}
+		results = append(results, res)
+	}
+
+	// В конце проверяем строки на ошибки (best practice)
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error path: %s, error: %w", opMessageSearch, err)
+	}
+
+	return results, nil
+}
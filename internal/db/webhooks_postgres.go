@@ -0,0 +1,316 @@
+package db
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"service-chat/internal/apperr"
+	"service-chat/internal/db/entity"
+)
+
+const (
+	opWebhookAdd      = "db.AddWebhook"
+	opWebhookGet      = "db.GetWebhooks"
+	opWebhookDel      = "db.DeleteWebhook"
+	opWebhookAttempts = "db.GetWebhookAttempts"
+	opWebhookClaim    = "db.ClaimDeliveries"
+	opWebhookSave     = "db.SaveAttempt"
+)
+
+type WebhookPostgres struct {
+	db *sql.DB
+}
+
+func NewWebhookPostgres(db *sql.DB) *WebhookPostgres {
+	return &WebhookPostgres{db: db}
+}
+
+// AddWebhook - создаём вебхук чата, создавать вебхуки могут только администраторы чата
+func (w *WebhookPostgres) AddWebhook(in entity.WebhookAdd) (*entity.Webhook, error) {
+	// Начинаем транзакцию, строка чата блокируется до её конца, поэтому лимит вебхуков не превысить параллельными запросами
+	tx, err := w.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("error path: %s, error: %w", opWebhookAdd, err)
+	}
+
+	if _, errAdmin := checkChatAdmin(tx, opWebhookAdd, in.ChatID, in.UserID); errAdmin != nil {
+		if errTx := tx.Rollback(); errTx != nil {
+			return nil, fmt.Errorf("error path: %s, error: %s", opWebhookAdd, errTx)
+		}
+		return nil, errAdmin
+	}
+
+	// Скелет sql запроса на подсчёт вебхуков чата
+	stmtCount, err := tx.Prepare(`SELECT COUNT(*) FROM "webhook" WHERE chat_id = $1`)
+	if err != nil {
+		return nil, fmt.Errorf("error path: %s, error: %w", opWebhookAdd, err)
+	}
+	defer stmtCount.Close()
+
+	// Проверяем ограничение на количество вебхуков в чате
+	var count int
+	if err = stmtCount.QueryRow(in.ChatID).Scan(&count); err != nil {
+		if errTx := tx.Rollback(); errTx != nil {
+			return nil, fmt.Errorf("error path: %s, error: %s", opWebhookAdd, errTx)
+		}
+		return nil, fmt.Errorf("error path: %s, error: %w", opWebhookAdd, err)
+	}
+	if count >= in.MaxPerChat {
+		if errTx := tx.Rollback(); errTx != nil {
+			return nil, fmt.Errorf("error path: %s, error: %s", opWebhookAdd, errTx)
+		}
+		return nil, fmt.Errorf("error path: %s, error: %w", opWebhookAdd,
+			apperr.Conflict(fmt.Sprintf("Webhooks limit of %d reached", in.MaxPerChat)))
+	}
+
+	// Скелет sql запроса на создание вебхука
+	stmtAdd, err := tx.Prepare(`INSERT INTO "webhook" (chat_id, user_id, url, secret) VALUES ($1, $2, $3, $4)
+									RETURNING id, chat_id, user_id, url, secret, created_at`)
+	if err != nil {
+		return nil, fmt.Errorf("error path: %s, error: %w", opWebhookAdd, err)
+	}
+	defer stmtAdd.Close()
+
+	var hook entity.Webhook
+	if err = stmtAdd.QueryRow(in.ChatID, in.UserID, in.URL, in.Secret).
+		Scan(&hook.ID, &hook.ChatID, &hook.UserID, &hook.URL, &hook.Secret, &hook.CreatedAt); err != nil {
+		if errTx := tx.Rollback(); errTx != nil {
+			return nil, fmt.Errorf("error path: %s, error: %s", opWebhookAdd, errTx)
+		}
+		return nil, fmt.Errorf("error path: %s, error: %w", opWebhookAdd, err)
+	}
+
+	return &hook, tx.Commit()
+}
+
+// GetWebhooks - получаем вебхуки чата без ключей подписи, список доступен только администраторам чата
+func (w *WebhookPostgres) GetWebhooks(in entity.WebhookGet) ([]entity.Webhook, error) {
+	if _, errAdmin := checkChatAdmin(w.db, opWebhookGet, in.ChatID, in.UserID); errAdmin != nil {
+		return nil, errAdmin
+	}
+
+	// Скелет sql запроса на получение вебхуков чата
+	stmt, err := w.db.Prepare(`SELECT id, chat_id, user_id, url, created_at
+									FROM "webhook"
+									WHERE chat_id = $1
+									ORDER BY id`)
+	if err != nil {
+		return nil, fmt.Errorf("error path: %s, error: %w", opWebhookGet, err)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.Query(in.ChatID)
+	if err != nil {
+		return nil, fmt.Errorf("error path: %s, error: %w", opWebhookGet, err)
+	}
+	defer rows.Close()
+
+	var hooks []entity.Webhook
+	for rows.Next() {
+		var hook entity.Webhook
+		if errSc := rows.Scan(&hook.ID, &hook.ChatID, &hook.UserID, &hook.URL, &hook.CreatedAt); errSc != nil {
+			return nil, fmt.Errorf("error path: %s, error: %w", opWebhookGet, errSc)
+		}
+		hooks = append(hooks, hook)
+	}
+
+	// В конце проверяем строки на ошибки (best practice)
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error path: %s, error: %w", opWebhookGet, err)
+	}
+
+	return hooks, nil
+}
+
+// DeleteWebhook - удаляем вебхук чата вместе с очередью его событий и историей отправки
+func (w *WebhookPostgres) DeleteWebhook(in entity.WebhookDel) error {
+	if _, errAdmin := checkChatAdmin(w.db, opWebhookDel, in.ChatID, in.UserID); errAdmin != nil {
+		return errAdmin
+	}
+
+	// Скелет sql запроса на удаление вебхука
+	stmt, err := w.db.Prepare(`DELETE FROM "webhook" WHERE id = $1 AND chat_id = $2`)
+	if err != nil {
+		return fmt.Errorf("error path: %s, error: %w", opWebhookDel, err)
+	}
+	defer stmt.Close()
+
+	res, err := stmt.Exec(in.ID, in.ChatID)
+	if err != nil {
+		return fmt.Errorf("error path: %s, error: %w", opWebhookDel, err)
+	}
+	if affected, errCount := res.RowsAffected(); errCount == nil && affected == 0 {
+		return fmt.Errorf("error path: %s, error: %w", opWebhookDel, apperr.NotFound("Invalid webhook_id"))
+	}
+
+	return nil
+}
+
+// GetWebhookAttempts - получаем последние попытки отправки событий в вебхук, новые идут первыми
+func (w *WebhookPostgres) GetWebhookAttempts(in entity.WebhookAttemptsGet) ([]entity.WebhookAttempt, error) {
+	if _, errAdmin := checkChatAdmin(w.db, opWebhookAttempts, in.ChatID, in.UserID); errAdmin != nil {
+		return nil, errAdmin
+	}
+
+	// Скелет sql запроса на получение попыток отправки
+	stmt, err := w.db.Prepare(`SELECT a.delivery_id, d.event, d.status, a.attempt, a.status_code,
+										COALESCE(a.error, ''), a.duration_ms, a.created_at
+									FROM "webhook_attempt" AS a
+									INNER JOIN "webhook_delivery" AS d
+									ON d.id = a.delivery_id
+									INNER JOIN "webhook" AS w
+									ON w.id = d.webhook_id
+									WHERE w.id = $1
+									  AND w.chat_id = $2
+									ORDER BY a.id DESC
+									LIMIT $3`)
+	if err != nil {
+		return nil, fmt.Errorf("error path: %s, error: %w", opWebhookAttempts, err)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.Query(in.WebhookID, in.ChatID, in.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("error path: %s, error: %w", opWebhookAttempts, err)
+	}
+	defer rows.Close()
+
+	var attempts []entity.WebhookAttempt
+	for rows.Next() {
+		var attempt entity.WebhookAttempt
+		if errSc := rows.Scan(&attempt.DeliveryID, &attempt.Event, &attempt.DeliveryStatus, &attempt.Attempt,
+			&attempt.StatusCode, &attempt.Error, &attempt.DurationMs, &attempt.CreatedAt); errSc != nil {
+			return nil, fmt.Errorf("error path: %s, error: %w", opWebhookAttempts, errSc)
+		}
+		attempts = append(attempts, attempt)
+	}
+
+	// В конце проверяем строки на ошибки (best practice)
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error path: %s, error: %w", opWebhookAttempts, err)
+	}
+
+	return attempts, nil
+}
+
+// ClaimDeliveries - берём на отправку события, время отправки которых наступило. Счётчик попыток
+// увеличивается сразу, а следующая попытка откладывается до LeaseUntil: другие экземпляры сервиса
+// не получат эти события, а если экземпляр упадёт во время отправки, событие будет отправлено повторно
+func (w *WebhookPostgres) ClaimDeliveries(in entity.WebhookClaim) ([]entity.WebhookJob, error) {
+	// Скелет sql запроса на выборку и блокировку событий
+	stmt, err := w.db.Prepare(`UPDATE "webhook_delivery" AS d
+									SET attempts = d.attempts + 1, next_attempt_at = $2, updated_at = now()
+									FROM "webhook" AS w
+									WHERE w.id = d.webhook_id
+									  AND d.id IN (
+										SELECT id FROM "webhook_delivery"
+										WHERE status = $3 AND next_attempt_at <= $1
+										ORDER BY next_attempt_at, id
+										LIMIT $4
+										FOR UPDATE SKIP LOCKED
+									  )
+									RETURNING d.id, d.webhook_id, w.url, w.secret, d.event, d.payload, d.attempts`)
+	if err != nil {
+		return nil, fmt.Errorf("error path: %s, error: %w", opWebhookClaim, err)
+	}
+	defer stmt.Close()
+
+	rows, err := stmt.Query(nullTime(&in.Now), nullTime(&in.LeaseUntil), entity.DeliveryPending, in.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("error path: %s, error: %w", opWebhookClaim, err)
+	}
+	defer rows.Close()
+
+	var jobs []entity.WebhookJob
+	for rows.Next() {
+		var job entity.WebhookJob
+		if errSc := rows.Scan(&job.DeliveryID, &job.WebhookID, &job.URL, &job.Secret, &job.Event, &job.Payload,
+			&job.Attempt); errSc != nil {
+			return nil, fmt.Errorf("error path: %s, error: %w", opWebhookClaim, errSc)
+		}
+		jobs = append(jobs, job)
+	}
+
+	// В конце проверяем строки на ошибки (best practice)
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error path: %s, error: %w", opWebhookClaim, err)
+	}
+
+	return jobs, nil
+}
+
+// SaveAttempt - записываем попытку отправки в историю и переводим событие в новый статус
+func (w *WebhookPostgres) SaveAttempt(in entity.WebhookResult) error {
+	// Начинаем транзакцию
+	tx, err := w.db.Begin()
+	if err != nil {
+		return fmt.Errorf("error path: %s, error: %w", opWebhookSave, err)
+	}
+
+	// Скелет sql запроса на сохранение попытки
+	stmtAttempt, err := tx.Prepare(`INSERT INTO "webhook_attempt" (delivery_id, attempt, status_code, error, duration_ms)
+										VALUES ($1, $2, $3, NULLIF($4, ''), $5)`)
+	if err != nil {
+		return fmt.Errorf("error path: %s, error: %w", opWebhookSave, err)
+	}
+	defer stmtAttempt.Close()
+
+	if _, err = stmtAttempt.Exec(in.DeliveryID, in.Attempt, in.StatusCode, in.Error, in.Duration.Milliseconds()); err != nil {
+		if errTx := tx.Rollback(); errTx != nil {
+			return fmt.Errorf("error path: %s, error: %s", opWebhookSave, errTx)
+		}
+		return fmt.Errorf("error path: %s, error: %w", opWebhookSave, err)
+	}
+
+	// Скелет sql запроса на обновление статуса события
+	stmtStatus, err := tx.Prepare(`UPDATE "webhook_delivery"
+										SET status = $2, next_attempt_at = $3, updated_at = now()
+										WHERE id = $1`)
+	if err != nil {
+		return fmt.Errorf("error path: %s, error: %w", opWebhookSave, err)
+	}
+	defer stmtStatus.Close()
+
+	if _, err = stmtStatus.Exec(in.DeliveryID, in.Status, nullTime(&in.NextAttemptAt)); err != nil {
+		if errTx := tx.Rollback(); errTx != nil {
+			return fmt.Errorf("error path: %s, error: %s", opWebhookSave, errTx)
+		}
+		return fmt.Errorf("error path: %s, error: %w", opWebhookSave, err)
+	}
+
+	return tx.Commit()
+}
+
+// enqueueWebhooks - ставим событие о новом сообщении в очередь всех вебхуков чата. Вызывается
+// в транзакции сохранения сообщения, поэтому событие появится в очереди тогда и только тогда,
+// когда сообщение сохранено
+func enqueueWebhooks(tx preparer, op string, messageID int, in entity.MessageAdd) error {
+	payload, err := json.Marshal(entity.WebhookEvent{
+		Event:  entity.WebhookMessageCreated,
+		ChatID: in.ChatID,
+		Message: entity.WebhookEventMessage{
+			ID:     int64(messageID),
+			UserID: in.UserID,
+			Text:   in.Text,
+			Format: messageFormat(in.Format),
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("error path: %s, error: %w", op, err)
+	}
+
+	// Скелет sql запроса на добавление события в очередь вебхуков чата
+	stmt, err := tx.Prepare(`INSERT INTO "webhook_delivery" (webhook_id, event, payload)
+								SELECT id, $2, $3 FROM "webhook" WHERE chat_id = $1`)
+	if err != nil {
+		return fmt.Errorf("error path: %s, error: %w", op, err)
+	}
+	defer stmt.Close()
+
+	if _, err = stmt.Exec(in.ChatID, entity.WebhookMessageCreated, string(payload)); err != nil {
+		return fmt.Errorf("error path: %s, error: %w", op, err)
+	}
+
+	return nil
+}
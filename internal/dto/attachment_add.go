@@ -0,0 +1,10 @@
+package dto
+
+import "io"
+
+// AttachmentAdd - структура для файла из multipart запроса ручки загрузки вложения
+type AttachmentAdd struct {
+	FileName string    `json:"file_name"`
+	Size     int64     `json:"size"`
+	File     io.Reader `json:"-"`
+}
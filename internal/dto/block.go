@@ -0,0 +1,6 @@
+package dto
+
+// BlockAdd - структура запроса для ручки добавления пользователя в чёрный список
+type BlockAdd struct {
+	UserID int64 `json:"user_id" validate:"required"`
+}
@@ -3,4 +3,7 @@ package dto
 // ChatGet - структура запроса для ручки получения списка чатов конкретного пользователя
 type ChatGet struct {
 	UserID *int64 `json:"user_id" validate:"required"`
+	// Limit, Offset - страница списка чатов, если Limit не задан - возвращаются все чаты
+	Limit  int64 `json:"limit,omitempty" validate:"omitempty,min=1,max=100"`
+	Offset int64 `json:"offset,omitempty" validate:"omitempty,min=0"`
 }
@@ -0,0 +1,10 @@
+package dto
+
+// CommandAdd - структура запроса для ручки создания команды чата, которую обрабатывает внешний бот
+type CommandAdd struct {
+	// Command - имя команды без косой черты: латинские буквы в нижнем регистре, цифры, _ и -
+	Command     string `json:"command" validate:"required,max=32"`
+	Description string `json:"description,omitempty" validate:"max=256"`
+	// URL - адрес бота, на который отправляется вызов команды
+	URL string `json:"url" validate:"required,max=2048"`
+}
@@ -0,0 +1,12 @@
+package dto
+
+// DraftSave - структура запроса для ручки сохранения черновика
+type DraftSave struct {
+	Text    string `json:"text" validate:"required,max=4096"`
+	Version int64  `json:"version" validate:"required,min=1"`
+}
+
+// DraftDelete - структура запроса для ручки удаления черновика
+type DraftDelete struct {
+	Version int64 `json:"version" validate:"required,min=1"`
+}
@@ -0,0 +1,9 @@
+package dto
+
+// ChatFilterSet - структура запроса для ручки изменения действия фильтра в чате
+type ChatFilterSet struct {
+	// Filter - denylist, secrets или links
+	Filter string `json:"filter" validate:"required"`
+	// Action - default возвращает действие из конфига
+	Action string `json:"action" validate:"required,oneof=off reject mask flag default"`
+}
@@ -0,0 +1,45 @@
+package dto
+
+// IncomingAdd - структура запроса для ручки создания входящего вебхука чата
+type IncomingAdd struct {
+	Name string `json:"name" validate:"required,max=64,excludesall=!@#$&*()?"`
+}
+
+// IncomingMessage - тело запроса входящего вебхука. Простой формат: {"text": "...", "format": "markdown"},
+// формат Slack: text, blocks и attachments, текст в разметке Slack mrkdwn
+type IncomingMessage struct {
+	Text string `json:"text"`
+	// Format - формат текста простого запроса: plain (по умолчанию) или markdown
+	Format      string               `json:"format,omitempty" validate:"omitempty,oneof=plain markdown"`
+	Blocks      []IncomingBlock      `json:"blocks,omitempty" validate:"max=50"`
+	Attachments []IncomingAttachment `json:"attachments,omitempty" validate:"max=20"`
+}
+
+// IncomingBlock - блок сообщения Slack, учитывается текст блоков section, header и context
+type IncomingBlock struct {
+	Type     string              `json:"type"`
+	Text     *IncomingBlockText  `json:"text,omitempty"`
+	Elements []IncomingBlockText `json:"elements,omitempty"`
+}
+
+// IncomingBlockText - текстовый объект блока Slack
+type IncomingBlockText struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+// IncomingAttachment - вложение сообщения Slack, в таком виде пишет, например, Alertmanager
+type IncomingAttachment struct {
+	Fallback  string          `json:"fallback"`
+	Pretext   string          `json:"pretext"`
+	Title     string          `json:"title"`
+	TitleLink string          `json:"title_link"`
+	Text      string          `json:"text"`
+	Fields    []IncomingField `json:"fields"`
+}
+
+// IncomingField - поле вложения Slack
+type IncomingField struct {
+	Title string `json:"title"`
+	Value string `json:"value"`
+}
@@ -0,0 +1,7 @@
+package dto
+
+// MentionGet - структура запроса для ручки ленты упоминаний пользователя, заполняется из query параметров
+type MentionGet struct {
+	Limit  int64 `json:"limit" validate:"omitempty,min=1,max=100"`
+	Offset int64 `json:"offset" validate:"omitempty,min=0"`
+}
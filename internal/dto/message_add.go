@@ -1,8 +1,16 @@
 package dto
 
+import "time"
+
 // MessageAdd - структура запроса для ручки отправить сообщение от лица пользователя в чат
 type MessageAdd struct {
 	ChatID int64  `json:"chat_id" validate:"required"`
 	UserID int64  `json:"user_id" validate:"required"`
 	Text   string `json:"text" validate:"required"`
+	// Format - формат текста: plain (по умолчанию) или markdown
+	Format string `json:"format,omitempty" validate:"omitempty,oneof=plain markdown"`
+	// SendAt - если задано, сообщение будет отправлено в чат в указанное время (RFC3339)
+	SendAt *time.Time `json:"send_at,omitempty"`
+	// SkipCommands - текст не разбирается как команда, задаётся сервисом для сообщений ботов и самих команд
+	SkipCommands bool `json:"-"`
 }
@@ -0,0 +1,9 @@
+package dto
+
+// MessageEdit - структура запроса для ручки редактирования сообщения в API v2, сообщение и автор
+// берутся из пути запроса и токена
+type MessageEdit struct {
+	Text string `json:"text" validate:"required"`
+	// Format - новый формат текста, если не задан - формат не меняется
+	Format string `json:"format,omitempty" validate:"omitempty,oneof=plain markdown"`
+}
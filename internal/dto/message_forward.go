@@ -0,0 +1,6 @@
+package dto
+
+// MessageForward - структура запроса для ручки пересылки сообщения в другие чаты
+type MessageForward struct {
+	ChatIDs []int64 `json:"chat_ids" validate:"required,min=1,max=10"`
+}
@@ -0,0 +1,14 @@
+package dto
+
+import "time"
+
+// MessageSearch - структура запроса для ручки полнотекстового поиска по сообщениям, заполняется из query параметров
+type MessageSearch struct {
+	Query    string     `json:"q" validate:"required,max=255"`
+	ChatID   int64      `json:"chat_id" validate:"omitempty,min=1"`
+	AuthorID int64      `json:"author_id" validate:"omitempty,min=1"`
+	From     *time.Time `json:"from"`
+	To       *time.Time `json:"to"`
+	Limit    int64      `json:"limit" validate:"omitempty,min=1,max=100"`
+	Cursor   string     `json:"cursor"`
+}
@@ -5,4 +5,6 @@ type MessageUpdate struct {
 	MessageID int64  `json:"message_id" validate:"required"`
 	UserID    int64  `json:"user_id" validate:"required"`
 	NewText   string `json:"new_text" validate:"required"`
+	// Format - новый формат текста, если не задан - формат не меняется
+	Format string `json:"format,omitempty" validate:"omitempty,oneof=plain markdown"`
 }
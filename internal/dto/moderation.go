@@ -0,0 +1,31 @@
+package dto
+
+// ReportAdd - структура запроса для ручки жалобы на сообщение
+type ReportAdd struct {
+	Reason  string `json:"reason" validate:"required,oneof=spam harassment hate violence sexual other"`
+	Comment string `json:"comment,omitempty" validate:"max=500"`
+}
+
+// ReportsGet - структура запроса для ручки очереди жалоб, заполняется из query параметров
+type ReportsGet struct {
+	// Status - open по умолчанию, resolved или dismissed
+	Status string `json:"status" validate:"omitempty,oneof=open resolved dismissed"`
+	Limit  int64  `json:"limit" validate:"omitempty,min=1,max=100"`
+	Offset int64  `json:"offset" validate:"omitempty,min=0"`
+}
+
+// ModerationAdd - структура запроса для ручки действия модератора по жалобе
+type ModerationAdd struct {
+	Action string `json:"action" validate:"required,oneof=hide warn suspend dismiss"`
+	// Duration - срок отстранения для suspend, например 24h или 30m
+	Duration string `json:"duration,omitempty"`
+	Note     string `json:"note,omitempty" validate:"max=500"`
+}
+
+// ActionsGet - структура запроса для ручки журнала действий модераторов, заполняется из query параметров
+type ActionsGet struct {
+	// UserID - только действия в отношении этого пользователя
+	UserID int64 `json:"user_id" validate:"omitempty,min=1"`
+	Limit  int64 `json:"limit" validate:"omitempty,min=1,max=100"`
+	Offset int64 `json:"offset" validate:"omitempty,min=0"`
+}
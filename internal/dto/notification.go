@@ -0,0 +1,17 @@
+package dto
+
+import "time"
+
+// NotifySettings - структура запроса для ручки сохранения адресов уведомлений, пустой адрес отключает канал
+type NotifySettings struct {
+	Email     string `json:"email,omitempty" validate:"max=320"`
+	PushToken string `json:"push_token,omitempty" validate:"max=512"`
+}
+
+// ChatNotify - структура запроса для ручки настройки уведомлений чата
+type ChatNotify struct {
+	// Level - all - о всех сообщениях, mentions - только об упоминаниях, none - без уведомлений
+	Level string `json:"level" validate:"required,oneof=all mentions none"`
+	// MutedUntil - до этого времени (RFC3339) уведомлений из чата нет, если не задано - чат не заглушён
+	MutedUntil *time.Time `json:"muted_until,omitempty"`
+}
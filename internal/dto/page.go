@@ -0,0 +1,7 @@
+package dto
+
+// Page - структура запроса страницы списка в API v2, заполняется из query параметров
+type Page struct {
+	Limit  int64 `json:"limit" validate:"omitempty,min=1,max=100"`
+	Offset int64 `json:"offset" validate:"omitempty,min=0"`
+}
@@ -0,0 +1,19 @@
+package dto
+
+import "time"
+
+// PollAdd - структура запроса для ручки создания опроса в чате
+type PollAdd struct {
+	Question string `json:"question" validate:"required,max=300"`
+	// Options - варианты ответа, от 2 до 10
+	Options   []string `json:"options" validate:"required"`
+	Multiple  bool     `json:"multiple,omitempty"`
+	Anonymous bool     `json:"anonymous,omitempty"`
+	// CloseAt - время закрытия опроса (RFC3339), если не задано - опрос не закрывается
+	CloseAt *time.Time `json:"close_at,omitempty"`
+}
+
+// PollVote - структура запроса для ручки голосования в опросе, новый голос заменяет прежний
+type PollVote struct {
+	OptionIDs []int64 `json:"option_ids" validate:"required"`
+}
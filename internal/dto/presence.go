@@ -0,0 +1,6 @@
+package dto
+
+// PresenceSet - структура запроса для ручки смены статуса пользователя
+type PresenceSet struct {
+	Status string `json:"status" validate:"required,oneof=online away"`
+}
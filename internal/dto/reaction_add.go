@@ -0,0 +1,6 @@
+package dto
+
+// ReactionAdd - структура запроса для ручки добавления реакции на сообщение
+type ReactionAdd struct {
+	Emoji string `json:"emoji" validate:"required,max=32"`
+}
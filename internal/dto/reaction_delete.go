@@ -0,0 +1,6 @@
+package dto
+
+// ReactionDelete - структура запроса для ручки удаления реакции на сообщение
+type ReactionDelete struct {
+	Emoji string `json:"emoji" validate:"required,max=32"`
+}
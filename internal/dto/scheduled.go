@@ -0,0 +1,14 @@
+package dto
+
+import "time"
+
+// ScheduledGet - структура запроса для ручки получения отложенных сообщений, заполняется из query параметров
+type ScheduledGet struct {
+	ChatID int64 `json:"chat_id" validate:"omitempty,min=1"`
+}
+
+// ScheduledUpdate - структура запроса для ручки редактирования отложенного сообщения, пустые поля не меняются
+type ScheduledUpdate struct {
+	Text   string     `json:"text"`
+	SendAt *time.Time `json:"send_at,omitempty"`
+}
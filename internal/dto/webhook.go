@@ -0,0 +1,11 @@
+package dto
+
+// WebhookAdd - структура запроса для ручки создания вебхука чата
+type WebhookAdd struct {
+	URL string `json:"url" validate:"required,max=2048"`
+}
+
+// WebhookAttemptsGet - структура запроса для ручки истории отправки событий в вебхук
+type WebhookAttemptsGet struct {
+	Limit int64 `json:"limit" validate:"omitempty,min=1,max=100"`
+}
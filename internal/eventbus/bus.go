@@ -0,0 +1,82 @@
+package eventbus
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"sync"
+
+	"service-chat/internal/config"
+	"service-chat/internal/db"
+	"service-chat/internal/realtime"
+)
+
+const (
+	driverLocal    = "local"
+	driverPostgres = "postgres"
+)
+
+// Bus - шина событий. Слой сервиса публикует события после записи в бд, а получатели (хаб подключений)
+// получают события всех экземпляров сервиса
+type Bus interface {
+	realtime.Publisher
+	// Subscribe - добавляем получателя событий
+	Subscribe(p realtime.Publisher)
+	// Run - доставляем события получателям, пока не будет отменён контекст
+	Run(ctx context.Context, log *slog.Logger)
+}
+
+// NewBus - создаём шину событий согласно конфигу
+func NewBus(cfg *config.Config, database *sql.DB, log *slog.Logger) (Bus, error) {
+	switch cfg.EventBus.Driver {
+	case driverLocal:
+		return NewLocalBus(), nil
+	case driverPostgres:
+		return NewPostgresBus(cfg.EventBus, database, db.ConnString(cfg), log), nil
+	default:
+		return nil, errors.New("unknown event bus driver: " + cfg.EventBus.Driver)
+	}
+}
+
+// subscribers - получатели событий шины
+type subscribers struct {
+	mu   sync.RWMutex
+	list []realtime.Publisher
+}
+
+// Subscribe - добавляем получателя событий
+func (s *subscribers) Subscribe(p realtime.Publisher) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.list = append(s.list, p)
+}
+
+// deliver - передаём событие всем получателям
+func (s *subscribers) deliver(ev realtime.Event) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, p := range s.list {
+		p.Publish(ev)
+	}
+}
+
+// LocalBus - шина внутри одного экземпляра сервиса, событие сразу передаётся получателям
+type LocalBus struct {
+	subscribers
+}
+
+// NewLocalBus - конструктор шины внутри экземпляра
+func NewLocalBus() *LocalBus {
+	return &LocalBus{}
+}
+
+// Publish - передаём событие получателям
+func (b *LocalBus) Publish(ev realtime.Event) {
+	b.deliver(ev)
+}
+
+// Run - локальной шине фоновая работа не нужна, ждём отмены контекста
+func (b *LocalBus) Run(ctx context.Context, _ *slog.Logger) {
+	<-ctx.Done()
+}
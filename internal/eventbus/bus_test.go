@@ -0,0 +1,154 @@
+package eventbus
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+
+	"service-chat/internal/config"
+	"service-chat/internal/realtime"
+)
+
+// recorder - получатель, запоминающий события
+type recorder struct {
+	mu     sync.Mutex
+	events []realtime.Event
+}
+
+func (r *recorder) Publish(ev realtime.Event) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, ev)
+}
+
+func TestNewBus(t *testing.T) {
+	log := slog.New(slog.NewJSONHandler(io.Discard, nil))
+
+	bus, err := NewBus(&config.Config{EventBus: config.EventBus{Driver: "local"}}, nil, log)
+	assert.NoError(t, err)
+	assert.IsType(t, &LocalBus{}, bus)
+
+	bus, err = NewBus(&config.Config{EventBus: config.EventBus{Driver: "postgres"}}, nil, log)
+	assert.NoError(t, err)
+	assert.IsType(t, &PostgresBus{}, bus)
+
+	_, err = NewBus(&config.Config{EventBus: config.EventBus{Driver: "kafka"}}, nil, log)
+	assert.Equal(t, errors.New("unknown event bus driver: kafka"), err)
+}
+
+func TestLocalBus_Publish(t *testing.T) {
+	bus := NewLocalBus()
+	first, second := &recorder{}, &recorder{}
+	bus.Subscribe(first)
+	bus.Subscribe(second)
+
+	ev := realtime.Event{Type: realtime.EventMessageDeleted, ChatID: 2, Data: realtime.MessagePayload{MessageID: 7}}
+	bus.Publish(ev)
+
+	assert.Equal(t, []realtime.Event{ev}, first.events)
+	assert.Equal(t, []realtime.Event{ev}, second.events)
+}
+
+func TestPostgresBus_Publish(t *testing.T) {
+	// Создаём мок объекта базы данных
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	bus := NewPostgresBus(config.EventBus{Channel: "chat_events"}, db, "", slog.New(slog.NewJSONHandler(io.Discard, nil)))
+
+	mock.ExpectExec(`SELECT pg_notify($1, $2)`).
+		WithArgs("chat_events", `{"type":"message.deleted","chat_id":2,"data":{"message_id":7}}`).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	bus.Publish(realtime.Event{Type: realtime.EventMessageDeleted, ChatID: 2, Data: realtime.MessagePayload{MessageID: 7}})
+
+	// Ошибка бд только логируется
+	mock.ExpectExec(`SELECT pg_notify($1, $2)`).WillReturnError(errors.New("connection refused"))
+	bus.Publish(realtime.Event{Type: realtime.EventChatDeleted, ChatID: 2})
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestEncodeEvent(t *testing.T) {
+	t.Run("Round trip keeps client json", func(t *testing.T) {
+		ev := realtime.Event{
+			Type:   realtime.EventMessageCreated,
+			ChatID: 2,
+			Data:   realtime.MessagePayload{MessageID: 7, UserID: 1, Text: "**hi**", Format: "markdown", HTML: "<p><strong>hi</strong></p>"},
+		}
+		payload, err := encodeEvent(ev)
+		assert.NoError(t, err)
+
+		decoded, err := decodeEvent(payload)
+		assert.NoError(t, err)
+
+		want, _ := json.Marshal(ev)
+		got, _ := json.Marshal(decoded)
+		assert.Equal(t, string(want), string(got))
+	})
+
+	t.Run("Event without data", func(t *testing.T) {
+		decoded, err := decodeEvent([]byte(`{"type":"chat.created","chat_id":5,"user_ids":[1,2]}`))
+		assert.NoError(t, err)
+		assert.Equal(t, realtime.Event{Type: realtime.EventChatCreated, ChatID: 5, UserIDs: []int64{1, 2}}, decoded)
+	})
+
+	t.Run("Presence keeps chat ids", func(t *testing.T) {
+		payload, err := encodeEvent(realtime.Event{
+			Type:    realtime.EventPresenceChanged,
+			ChatIDs: []int64{2, 3},
+			Data:    realtime.PresencePayload{UserID: 1, Status: "online"},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, `{"type":"presence.changed","chat_ids":[2,3],"data":{"user_id":1,"status":"online"}}`, string(payload))
+
+		decoded, err := decodeEvent(payload)
+		assert.NoError(t, err)
+		assert.Equal(t, []int64{2, 3}, decoded.ChatIDs)
+	})
+
+	t.Run("Command reply keeps recipient", func(t *testing.T) {
+		payload, err := encodeEvent(realtime.Event{
+			Type:     realtime.EventCommandReply,
+			ChatID:   2,
+			ToUserID: 1,
+			Data:     realtime.CommandPayload{Command: "help", Text: "Commands"},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, `{"type":"command.reply","chat_id":2,"to_user_id":1,"data":{"command":"help","text":"Commands"}}`, string(payload))
+
+		decoded, err := decodeEvent(payload)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), decoded.ToUserID)
+	})
+
+	t.Run("Long text is dropped", func(t *testing.T) {
+		text := strings.Repeat("я", 4096)
+		payload, err := encodeEvent(realtime.Event{
+			Type:   realtime.EventMessageCreated,
+			ChatID: 2,
+			Data:   realtime.MessagePayload{MessageID: 7, UserID: 1, Text: text, Format: "markdown", HTML: "<p>" + text + "</p>"},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, `{"type":"message.created","chat_id":2,"data":{"message_id":7,"user_id":1,"format":"markdown"}}`, string(payload))
+	})
+
+	t.Run("Too large", func(t *testing.T) {
+		_, err := encodeEvent(realtime.Event{Type: realtime.EventChatCreated, ChatID: 2, Data: strings.Repeat("a", maxNotifyPayload)})
+		assert.ErrorIs(t, err, errPayloadTooLarge)
+	})
+
+	t.Run("Invalid payload", func(t *testing.T) {
+		_, err := decodeEvent([]byte(`{`))
+		assert.Error(t, err)
+	})
+}
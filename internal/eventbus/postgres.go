@@ -0,0 +1,170 @@
+package eventbus
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/lib/pq"
+
+	"service-chat/internal/config"
+	"service-chat/internal/logger"
+	"service-chat/internal/realtime"
+)
+
+const (
+	// maxNotifyPayload - postgres ограничивает payload NOTIFY 8000 байтами
+	maxNotifyPayload = 7999
+	// listenerPing - как часто проверяем соединение слушателя, чтобы быстрее заметить разрыв
+	listenerPing = 90 * time.Second
+)
+
+// errPayloadTooLarge - событие не помещается в NOTIFY даже без текста сообщения
+var errPayloadTooLarge = errors.New("event payload is too large for NOTIFY")
+
+// PostgresBus - шина между экземплярами сервиса через postgres LISTEN/NOTIFY. Событие публикуется в канал,
+// каждый экземпляр, включая отправителя, получает его своим слушателем и передаёт получателям
+type PostgresBus struct {
+	subscribers
+	cfg config.EventBus
+	db  *sql.DB
+	// dsn - параметры подключения слушателя, ему нужно отдельное соединение вне пула
+	dsn string
+	log *slog.Logger
+}
+
+// NewPostgresBus - конструктор шины через postgres
+func NewPostgresBus(cfg config.EventBus, db *sql.DB, dsn string, log *slog.Logger) *PostgresBus {
+	return &PostgresBus{cfg: cfg, db: db, dsn: dsn, log: log}
+}
+
+// Publish - отправляем событие в канал NOTIFY. Доставка в реальном времени не гарантируется,
+// поэтому ошибка только логируется и не влияет на уже выполненную запись в бд
+func (b *PostgresBus) Publish(ev realtime.Event) {
+	const op = "eventbus.PostgresBus.Publish"
+
+	payload, err := encodeEvent(ev)
+	if err != nil {
+		b.log.Error("failed to encode event", slog.String("op", op), slog.String("type", ev.Type), logger.Err(err))
+		return
+	}
+
+	if _, err = b.db.Exec(`SELECT pg_notify($1, $2)`, b.cfg.Channel, string(payload)); err != nil {
+		b.log.Error("failed to publish event", slog.String("op", op), slog.String("type", ev.Type), logger.Err(err))
+	}
+}
+
+// Run - слушаем канал и передаём события получателям, пока не будет отменён контекст.
+// Слушатель сам переподключается к бд, события, отправленные во время разрыва, теряются
+func (b *PostgresBus) Run(ctx context.Context, log *slog.Logger) {
+	const op = "eventbus.PostgresBus.Run"
+	log = log.With(slog.String("op", op))
+
+	listener := pq.NewListener(b.dsn, b.cfg.MinReconnect, b.cfg.MaxReconnect, func(_ pq.ListenerEventType, err error) {
+		if err != nil {
+			log.Error("event bus listener error", logger.Err(err))
+		}
+	})
+	defer listener.Close()
+
+	if err := listener.Listen(b.cfg.Channel); err != nil {
+		log.Error("failed to listen event bus channel", slog.String("channel", b.cfg.Channel), logger.Err(err))
+		return
+	}
+
+	ping := time.NewTicker(listenerPing)
+	defer ping.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case n := <-listener.Notify:
+			// nil приходит после переподключения
+			if n == nil {
+				log.Warn("event bus listener reconnected, events may have been lost")
+				continue
+			}
+			ev, err := decodeEvent([]byte(n.Extra))
+			if err != nil {
+				log.Error("failed to decode event", logger.Err(err))
+				continue
+			}
+			b.deliver(ev)
+		case <-ping.C:
+			go func() { _ = listener.Ping() }()
+		}
+	}
+}
+
+// wireEvent - событие в канале NOTIFY, данные остаются исходным json и передаются клиентам без изменений
+type wireEvent struct {
+	Type     string          `json:"type"`
+	ChatID   int64           `json:"chat_id,omitempty"`
+	ChatIDs  []int64         `json:"chat_ids,omitempty"`
+	UserIDs  []int64         `json:"user_ids,omitempty"`
+	ToUserID int64           `json:"to_user_id,omitempty"`
+	Data     json.RawMessage `json:"data,omitempty"`
+}
+
+// encodeEvent - кодируем событие для NOTIFY. Если оно не помещается, убираем текст сообщения:
+// клиент получит его вместе с историей чата. У ответа команды убираем только html, текст больше нигде не хранится
+func encodeEvent(ev realtime.Event) ([]byte, error) {
+	payload, err := marshalWire(ev)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(payload) > maxNotifyPayload {
+		if msg, ok := ev.Data.(realtime.MessagePayload); ok {
+			msg.Text, msg.HTML = "", ""
+			ev.Data = msg
+			if payload, err = marshalWire(ev); err != nil {
+				return nil, err
+			}
+		}
+		if reply, ok := ev.Data.(realtime.CommandPayload); ok {
+			reply.HTML = ""
+			ev.Data = reply
+			if payload, err = marshalWire(ev); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if len(payload) > maxNotifyPayload {
+		return nil, errPayloadTooLarge
+	}
+
+	return payload, nil
+}
+
+// decodeEvent - разбираем событие из NOTIFY
+func decodeEvent(payload []byte) (realtime.Event, error) {
+	var wire wireEvent
+	if err := json.Unmarshal(payload, &wire); err != nil {
+		return realtime.Event{}, err
+	}
+
+	ev := realtime.Event{Type: wire.Type, ChatID: wire.ChatID, ChatIDs: wire.ChatIDs, UserIDs: wire.UserIDs, ToUserID: wire.ToUserID}
+	if len(wire.Data) > 0 {
+		ev.Data = wire.Data
+	}
+	return ev, nil
+}
+
+// marshalWire - кодируем событие вместе с полями, которые не отправляются клиентам
+func marshalWire(ev realtime.Event) ([]byte, error) {
+	data, err := json.Marshal(ev.Data)
+	if err != nil {
+		return nil, err
+	}
+	if ev.Data == nil {
+		data = nil
+	}
+
+	return json.Marshal(wireEvent{Type: ev.Type, ChatID: ev.ChatID, ChatIDs: ev.ChatIDs, UserIDs: ev.UserIDs,
+		ToUserID: ev.ToUserID, Data: data})
+}
@@ -0,0 +1,246 @@
+package filter
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"service-chat/internal/apperr"
+	"service-chat/internal/config"
+)
+
+// Действия фильтра при срабатывании
+const (
+	// ActionOff - фильтр выключен
+	ActionOff = "off"
+	// ActionReject - сообщение не сохраняется
+	ActionReject = "reject"
+	// ActionMask - найденные фрагменты заменяются на *
+	ActionMask = "mask"
+	// ActionFlag - сообщение сохраняется без изменений и попадает в очередь модерации
+	ActionFlag = "flag"
+)
+
+// Встроенные фильтры
+const (
+	NameDenyList = "denylist"
+	NameSecrets  = "secrets"
+	NameLinks    = "links"
+)
+
+// Actions - допустимые действия фильтра
+var Actions = []string{ActionOff, ActionReject, ActionMask, ActionFlag}
+
+// secretPatterns - признаки ключей доступа и токенов, которые нельзя оставлять в переписке
+var secretPatterns = []*regexp.Regexp{
+	// Ключ доступа AWS
+	regexp.MustCompile(`\b(?:AKIA|ASIA)[0-9A-Z]{16}\b`),
+	// Токены GitHub
+	regexp.MustCompile(`\bgh[pousr]_[A-Za-z0-9]{36,}\b`),
+	regexp.MustCompile(`\bgithub_pat_[A-Za-z0-9_]{22,}\b`),
+	// Токены Slack
+	regexp.MustCompile(`\bxox[abposr]-[A-Za-z0-9-]{10,}`),
+	// Ключи Stripe и похожие sk_live_...
+	regexp.MustCompile(`\b[sr]k_live_[A-Za-z0-9]{16,}\b`),
+	// JWT
+	regexp.MustCompile(`\beyJ[A-Za-z0-9_-]{8,}\.eyJ[A-Za-z0-9_-]{8,}\.[A-Za-z0-9_-]{8,}`),
+	// Закрытый ключ в формате PEM
+	regexp.MustCompile(`-----BEGIN (?:[A-Z]+ )*PRIVATE KEY-----`),
+	// Пароль или токен в виде password=..., api_key: ...
+	regexp.MustCompile(`(?i)\b(?:password|passwd|pwd|secret|token|api[_-]?key|access[_-]?token|auth[_-]?token)\s*[:=]\s*\S+`),
+}
+
+// linkRe - ссылка в тексте сообщения
+var linkRe = regexp.MustCompile(`https?://[^\s<>"'()\[\]{}]+`)
+
+// Filter - фильтр текста сообщения, возвращает байтовые диапазоны [начало, конец) найденных фрагментов
+type Filter interface {
+	Name() string
+	Match(text string) [][]int
+}
+
+// RegexFilter - находит фрагменты по списку регулярных выражений
+type RegexFilter struct {
+	name     string
+	patterns []*regexp.Regexp
+}
+
+// NewRegexFilter - компилируем список регулярных выражений, поиск без учёта регистра
+func NewRegexFilter(name string, patterns []string) (*RegexFilter, error) {
+	f := &RegexFilter{name: name}
+	for _, pattern := range patterns {
+		re, err := regexp.Compile("(?i)" + pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s pattern %q: %w", name, pattern, err)
+		}
+		f.patterns = append(f.patterns, re)
+	}
+	return f, nil
+}
+
+func (f *RegexFilter) Name() string {
+	return f.name
+}
+
+func (f *RegexFilter) Match(text string) [][]int {
+	var matches [][]int
+	for _, re := range f.patterns {
+		matches = append(matches, re.FindAllStringIndex(text, -1)...)
+	}
+	return matches
+}
+
+// NewSecretsFilter - фильтр ключей доступа, токенов и паролей
+func NewSecretsFilter() *RegexFilter {
+	return &RegexFilter{name: NameSecrets, patterns: secretPatterns}
+}
+
+// LinksFilter - находит ссылки сверх допустимого количества
+type LinksFilter struct {
+	max int
+}
+
+// NewLinksFilter - фильтр сообщений, в которых больше max ссылок
+func NewLinksFilter(max int) *LinksFilter {
+	return &LinksFilter{max: max}
+}
+
+func (f *LinksFilter) Name() string {
+	return NameLinks
+}
+
+func (f *LinksFilter) Match(text string) [][]int {
+	links := linkRe.FindAllStringIndex(text, -1)
+	if len(links) <= f.max {
+		return nil
+	}
+	return links[f.max:]
+}
+
+// Rule - фильтр и действие по умолчанию при его срабатывании
+type Rule struct {
+	Filter Filter
+	Action string
+}
+
+// Result - текст сообщения после фильтров
+type Result struct {
+	// Text - текст с замаскированными фрагментами
+	Text string
+	// Flagged - фильтры с действием flag, которые сработали
+	Flagged []string
+}
+
+// RejectError - сообщение отклонено фильтром
+type RejectError struct {
+	Filter string
+}
+
+func (e *RejectError) Error() string {
+	return fmt.Sprintf("Message rejected by %s filter", e.Filter)
+}
+
+// Unwrap - для клиента отклонённое сообщение - ошибка валидации
+func (e *RejectError) Unwrap() error {
+	return apperr.Validation(e.Error())
+}
+
+// Chain - цепочка фильтров, фильтры применяются по порядку к тексту после предыдущих фильтров
+type Chain struct {
+	rules []Rule
+}
+
+func NewChain(rules ...Rule) *Chain {
+	return &Chain{rules: rules}
+}
+
+// NewChainFromConfig - собираем цепочку встроенных фильтров: запрещённые выражения, секреты, количество ссылок
+func NewChainFromConfig(cfg config.Filters) (*Chain, error) {
+	denyList, err := NewRegexFilter(NameDenyList, cfg.DenyList)
+	if err != nil {
+		return nil, err
+	}
+
+	rules := []Rule{
+		{Filter: denyList, Action: cfg.DenyListAction},
+		{Filter: NewSecretsFilter(), Action: cfg.SecretsAction},
+		{Filter: NewLinksFilter(cfg.MaxLinks), Action: cfg.LinksAction},
+	}
+	for _, rule := range rules {
+		if !ValidAction(rule.Action) {
+			return nil, fmt.Errorf("invalid %s filter action %q", rule.Filter.Name(), rule.Action)
+		}
+	}
+
+	return NewChain(rules...), nil
+}
+
+// Rules - фильтры цепочки с действиями по умолчанию
+func (c *Chain) Rules() []Rule {
+	return c.rules
+}
+
+// Apply - применяем фильтры к тексту. overrides - действия фильтров, заданные для чата, вместо действий по умолчанию.
+// При срабатывании фильтра с действием reject возвращаем *RejectError
+func (c *Chain) Apply(text string, overrides map[string]string) (Result, error) {
+	result := Result{Text: text}
+
+	for _, rule := range c.rules {
+		action := rule.Action
+		if override, ok := overrides[rule.Filter.Name()]; ok {
+			action = override
+		}
+		if action == ActionOff || action == "" {
+			continue
+		}
+
+		matches := rule.Filter.Match(result.Text)
+		if len(matches) == 0 {
+			continue
+		}
+
+		switch action {
+		case ActionReject:
+			return Result{}, &RejectError{Filter: rule.Filter.Name()}
+		case ActionMask:
+			result.Text = mask(result.Text, matches)
+		case ActionFlag:
+			result.Flagged = append(result.Flagged, rule.Filter.Name())
+		}
+	}
+
+	return result, nil
+}
+
+// ValidAction - проверяем, что действие фильтра известно
+func ValidAction(action string) bool {
+	for _, a := range Actions {
+		if a == action {
+			return true
+		}
+	}
+	return false
+}
+
+// mask - заменяем каждый символ найденных фрагментов на *, пересекающиеся фрагменты объединяем
+func mask(text string, matches [][]int) string {
+	sort.Slice(matches, func(i, j int) bool { return matches[i][0] < matches[j][0] })
+
+	var b strings.Builder
+	b.Grow(len(text))
+	pos := 0
+	for _, m := range matches {
+		start, end := max(m[0], pos), m[1]
+		if start >= end {
+			continue
+		}
+		b.WriteString(text[pos:start])
+		b.WriteString(strings.Repeat("*", utf8.RuneCountInString(text[start:end])))
+		pos = end
+	}
+	b.WriteString(text[pos:])
+
+	return b.String()
+}
@@ -0,0 +1,202 @@
+package handler
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+
+	"service-chat/internal/apperr"
+	"service-chat/internal/db/entity"
+	"service-chat/internal/dto"
+	"service-chat/internal/logger"
+)
+
+const (
+	// Имя поля multipart формы с файлами
+	attachmentField = "file"
+	// Запас на заголовки multipart формы сверх размера файла
+	multipartOverhead = 1 << 20
+	// Максимальный объём формы, который держим в памяти, остальное пишется во временные файлы
+	multipartMemory = 8 << 20
+)
+
+// AttachmentAdd - прикрепить файлы к сообщению от лица автора сообщения
+// @Summary AttachmentAdd
+// @Security ApiKeyAuth
+// @Tags Attachment
+// @Description Upload attachments to message
+// @ID Upload attachments
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path int true "message id"
+// @Param file formData file true "attachment file, can be repeated"
+// @Success 200 {object} Response{Status, Message, AttachmentsList}
+// @Failure 400,404,405 {object} Response
+// @Failure 500 {object} Response
+// @Failure default {object} Response
+// @Router /messages/{id}/attachments [post]
+func (h *Handler) AttachmentAdd(log *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Логируем наш запрос
+		const op = "handler.AttachmentAdd"
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		// Получаем id пользователя из контекста
+		idCtx, errCtx := GetUserID(r.Context())
+		if errCtx != nil {
+			log.Error("failed to get userID from context")
+			renderError(w, r, "", errCtx)
+			return
+		}
+
+		// Получаем id сообщения из пути запроса
+		messageID, errID := GetPathID(r)
+		if errID != nil {
+			log.Error("invalid message ID")
+			renderError(w, r, "", errID)
+			return
+		}
+
+		// Ограничиваем размер тела запроса, чтобы не читать заведомо слишком большие файлы
+		r.Body = http.MaxBytesReader(w, r.Body, h.services.Attachment.MaxUploadSize()+multipartOverhead)
+		if errForm := r.ParseMultipartForm(multipartMemory); errForm != nil {
+			log.Error("failed to parse multipart form", logger.Err(errForm))
+			renderError(w, r, "", apperr.Validation("Invalid multipart form"))
+			return
+		}
+		defer r.MultipartForm.RemoveAll()
+
+		files := r.MultipartForm.File[attachmentField]
+		if len(files) == 0 {
+			log.Error("no files in request")
+			renderError(w, r, "", apperr.Validation(fmt.Sprintf("Field %s is a required field", attachmentField)))
+			return
+		}
+
+		// Сохраняем каждый файл через слой сервиса
+		var attachments []entity.Attachment
+		for _, header := range files {
+			file, errOpen := header.Open()
+			if errOpen != nil {
+				log.Error("failed to open uploaded file", logger.Err(errOpen))
+				renderError(w, r, "", apperr.Validation("Failed to read uploaded file"))
+				return
+			}
+
+			attachment, errAdd := h.services.Attachment.AddAttachment(dto.AttachmentAdd{
+				FileName: header.Filename,
+				Size:     header.Size,
+				File:     file,
+			}, messageID, idCtx)
+			_ = file.Close()
+			if errAdd != nil {
+				log.Error("failed to add attachment", logger.Err(errAdd))
+				renderError(w, r, fmt.Sprintf("Failed to add attachment %s", header.Filename), errAdd)
+				return
+			}
+			attachments = append(attachments, *attachment)
+		}
+
+		// Если ошибок нет отправляем успешный ответ
+		log.Info("Attachments added successfully", slog.Int64("messageID", messageID))
+		render.JSON(w, r, Response{
+			Status:          StatusOK,
+			Message:         "Attachments added successfully",
+			AttachmentsList: attachments,
+		})
+		return
+	}
+}
+
+// AttachmentGet - скачать вложение, доступно только участникам чата
+// @Summary AttachmentGet
+// @Security ApiKeyAuth
+// @Tags Attachment
+// @Description Download attachment
+// @ID Download attachment
+// @Produce octet-stream
+// @Param id path int true "attachment id"
+// @Success 200 {file} file
+// @Failure 400,404,405 {object} Response
+// @Failure 500 {object} Response
+// @Failure default {object} Response
+// @Router /attachments/{id} [get]
+func (h *Handler) AttachmentGet(log *slog.Logger) http.HandlerFunc {
+	return h.attachmentDownload(log, "handler.AttachmentGet", false)
+}
+
+// AttachmentThumbnail - скачать миниатюру изображения, доступно только участникам чата
+// @Summary AttachmentThumbnail
+// @Security ApiKeyAuth
+// @Tags Attachment
+// @Description Download attachment thumbnail
+// @ID Download attachment thumbnail
+// @Produce png
+// @Param id path int true "attachment id"
+// @Success 200 {file} file
+// @Failure 400,404,405 {object} Response
+// @Failure 500 {object} Response
+// @Failure default {object} Response
+// @Router /attachments/{id}/thumbnail [get]
+func (h *Handler) AttachmentThumbnail(log *slog.Logger) http.HandlerFunc {
+	return h.attachmentDownload(log, "handler.AttachmentThumbnail", true)
+}
+
+// attachmentDownload - общая логика отдачи файла вложения или его миниатюры
+func (h *Handler) attachmentDownload(log *slog.Logger, op string, thumbnail bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Логируем наш запрос
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		// Получаем id пользователя из контекста
+		idCtx, errCtx := GetUserID(r.Context())
+		if errCtx != nil {
+			log.Error("failed to get userID from context")
+			renderError(w, r, "", errCtx)
+			return
+		}
+
+		// Получаем id вложения из пути запроса
+		attachmentID, errID := GetPathID(r)
+		if errID != nil {
+			log.Error("invalid attachment ID")
+			renderError(w, r, "", errID)
+			return
+		}
+
+		// Получаем вложение со слоя сервиса, там же проверяется членство в чате
+		attachment, body, errGet := h.services.Attachment.GetAttachment(attachmentID, idCtx, thumbnail)
+		if errGet != nil {
+			log.Error("failed to get attachment", logger.Err(errGet))
+			renderError(w, r, "Failed to get attachment", errGet)
+			return
+		}
+		defer body.Close()
+
+		// Отдаём файл, браузер не должен пытаться исполнять содержимое вложения
+		w.Header().Set("Content-Type", attachment.ContentType)
+		w.Header().Set("X-Content-Type-Options", "nosniff")
+		w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": attachment.FileName}))
+		if !thumbnail {
+			w.Header().Set("Content-Length", strconv.FormatInt(attachment.Size, 10))
+		}
+		if _, errCopy := io.Copy(w, body); errCopy != nil {
+			log.Error("failed to send attachment", logger.Err(errCopy))
+			return
+		}
+
+		log.Info("Attachment sent successfully", slog.Int64("attachmentID", attachmentID))
+	}
+}
@@ -0,0 +1,165 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"service-chat/internal/apperr"
+	"service-chat/internal/db/entity"
+	"service-chat/internal/dto"
+	"service-chat/internal/service"
+	mockService "service-chat/internal/service/mocks"
+)
+
+func TestHandler_AttachmentAdd(t *testing.T) {
+	// Структура для последующей реализации поведения мока
+	type mockBehaviour func(s *mockService.MockAttachment)
+
+	//Инициализируем контролер для мока сервиса
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Создаём моки сервиса вложений
+	mockAttachment := mockService.NewMockAttachment(ctrl)
+
+	// Создаём объект сервиса в который передадим наш мок вложений
+	services := &service.Service{Attachment: mockAttachment}
+
+	// Создаём экземпляр обработчика
+	handler := NewHandler(services)
+
+	// Мокируем логгер
+	mockLog := slog.New(slog.NewJSONHandler(io.Discard, nil))
+
+	// Инициализируем сервер
+	r := chi.NewRouter()
+	r.Post("/messages/{id}/attachments", handler.AttachmentAdd(mockLog))
+
+	// Готовим multipart форму
+	form := func(field string) (*bytes.Buffer, string) {
+		var body bytes.Buffer
+		mw := multipart.NewWriter(&body)
+		fw, _ := mw.CreateFormFile(field, "notes.txt")
+		_, _ = fw.Write([]byte("hello"))
+		_ = mw.Close()
+		return &body, mw.FormDataContentType()
+	}
+
+	// Тестовая таблица с данными
+	testTable := []struct {
+		name                 string
+		field                string
+		mockBehaviour        mockBehaviour
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:  "OK",
+			field: "file",
+			mockBehaviour: func(s *mockService.MockAttachment) {
+				s.EXPECT().MaxUploadSize().Return(int64(1024))
+				s.EXPECT().AddAttachment(gomock.Any(), int64(1), 1).
+					DoAndReturn(func(in dto.AttachmentAdd, messageID int64, userID int) (*entity.Attachment, error) {
+						data, _ := io.ReadAll(in.File)
+						assert.Equal(t, "hello", string(data))
+						assert.Equal(t, "notes.txt", in.FileName)
+						return &entity.Attachment{Id: 1, MessageID: 1, UserID: 1, FileName: "notes.txt",
+							ContentType: "text/plain", Size: 5, URL: "/attachments/1"}, nil
+					})
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"status":"OK","message":"Attachments added successfully","attachments_list":[{"id":1,"message_id":1,"user_id":1,"file_name":"notes.txt","content_type":"text/plain","size":5,"created_at":"","url":"/attachments/1"}]}`,
+		},
+		{
+			name:  "No file field",
+			field: "other",
+			mockBehaviour: func(s *mockService.MockAttachment) {
+				s.EXPECT().MaxUploadSize().Return(int64(1024))
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":"Error","code":"validation","error":"Field file is a required field"}`,
+		},
+		{
+			name:  "Other error",
+			field: "file",
+			mockBehaviour: func(s *mockService.MockAttachment) {
+				s.EXPECT().MaxUploadSize().Return(int64(1024))
+				s.EXPECT().AddAttachment(gomock.Any(), int64(1), 1).Return(nil, errors.New("some error"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: `{"status":"Error","code":"internal","error":"Failed to add attachment notes.txt"}`,
+		},
+	}
+
+	// Итерируемся по нашей тестовой таблице
+	for _, tt := range testTable {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockBehaviour(mockAttachment)
+
+			// Готовим тестовый запрос
+			body, contentType := form(tt.field)
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/messages/1/attachments", body)
+			req.Header.Set("Content-Type", contentType)
+
+			// Выполняем запрос
+			r.ServeHTTP(w, req.WithContext(context.WithValue(req.Context(), userCtx, 1)))
+
+			// Сравниваем ожидаемый и актуальный результат
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			assert.Equal(t, tt.expectedResponseBody, strings.TrimSpace(w.Body.String()))
+		})
+	}
+}
+
+func TestHandler_AttachmentGet(t *testing.T) {
+	//Инициализируем контролер для мока сервиса
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Создаём моки сервиса вложений
+	mockAttachment := mockService.NewMockAttachment(ctrl)
+	handler := NewHandler(&service.Service{Attachment: mockAttachment})
+	mockLog := slog.New(slog.NewJSONHandler(io.Discard, nil))
+
+	// Инициализируем сервер
+	r := chi.NewRouter()
+	r.Get("/attachments/{id}", handler.AttachmentGet(mockLog))
+
+	// Участник чата получает файл
+	mockAttachment.EXPECT().GetAttachment(int64(1), 1, false).Return(&entity.Attachment{
+		Id: 1, FileName: "отчёт.txt", ContentType: "text/plain", Size: 5,
+	}, io.NopCloser(strings.NewReader("hello")), nil)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/attachments/1", nil)
+	r.ServeHTTP(w, req.WithContext(context.WithValue(req.Context(), userCtx, 1)))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "hello", w.Body.String())
+	assert.Equal(t, "text/plain", w.Header().Get("Content-Type"))
+	assert.Equal(t, "nosniff", w.Header().Get("X-Content-Type-Options"))
+	assert.Equal(t, "attachment; filename*=utf-8''%D0%BE%D1%82%D1%87%D1%91%D1%82.txt", w.Header().Get("Content-Disposition"))
+
+	// Пользователь не состоит в чате
+	mockAttachment.EXPECT().GetAttachment(int64(1), 2, false).Return(nil, nil, apperr.NotFound("Attachment not found"))
+
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest(http.MethodGet, "/attachments/1", nil)
+	r.ServeHTTP(w, req.WithContext(context.WithValue(req.Context(), userCtx, 2)))
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Equal(t, `{"status":"Error","code":"not_found","error":"Failed to get attachment: Attachment not found"}`, strings.TrimSpace(w.Body.String()))
+}
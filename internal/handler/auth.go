@@ -4,11 +4,11 @@ import (
 	"fmt"
 	"log/slog"
 	"net/http"
-	"strings"
 
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/render"
 
+	"service-chat/internal/apperr"
 	"service-chat/internal/dto"
 	"service-chat/internal/logger"
 	"service-chat/internal/validate"
@@ -31,7 +31,7 @@ func (h *Handler) SignUp(log *slog.Logger) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		// Логируем наш запрос
 		const op = "handler.SignUp"
-		log = log.With(
+		log := log.With(
 			slog.String("op", op),
 			slog.String("request_id", middleware.GetReqID(r.Context())),
 		)
@@ -42,25 +42,20 @@ func (h *Handler) SignUp(log *slog.Logger) http.HandlerFunc {
 		// Анализируем запрос от пользователя
 		fail := validate.BaseValidate(log, r.Body, &req)
 		if fail != nil && fail.ValidateErr != nil {
-			render.JSON(w, r, ValidationError(fail.ValidateErr))
+			renderError(w, r, "", fail.ValidateErr)
 
 			return
 		} else if fail != nil && fail.ErrMsg != "" {
-			render.JSON(w, r, Error(fail.ErrMsg))
+			renderError(w, r, "", apperr.Validation(fail.ErrMsg))
 
 			return
 		}
 
 		// Отправляем валидную структуру на слой сервиса
 		id, errCreate := h.services.Authorization.CreateUser(req)
-		if errCreate != nil && strings.Contains(errCreate.Error(), "unique_violation") {
-			log.Error("user already exists", logger.Err(errCreate))
-			render.JSON(w, r, Error("User already exists"))
-
-			return
-		} else if errCreate != nil {
+		if errCreate != nil {
 			log.Error("failed to create user", logger.Err(errCreate))
-			render.JSON(w, r, Error("Failed to create user"))
+			renderError(w, r, "Failed to create user", errCreate)
 
 			return
 		}
@@ -90,7 +85,7 @@ func (h *Handler) SignIn(log *slog.Logger) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		// Логируем наш запрос
 		const op = "handler.SignIn"
-		log = log.With(
+		log := log.With(
 			slog.String("op", op),
 			slog.String("request_id", middleware.GetReqID(r.Context())),
 		)
@@ -101,25 +96,20 @@ func (h *Handler) SignIn(log *slog.Logger) http.HandlerFunc {
 		// Анализируем запрос от пользователя
 		fail := validate.BaseValidate(log, r.Body, &req)
 		if fail != nil && fail.ValidateErr != nil {
-			render.JSON(w, r, ValidationError(fail.ValidateErr))
+			renderError(w, r, "", fail.ValidateErr)
 
 			return
 		} else if fail != nil && fail.ErrMsg != "" {
-			render.JSON(w, r, Error(fail.ErrMsg))
+			renderError(w, r, "", apperr.Validation(fail.ErrMsg))
 
 			return
 		}
 
 		// Отправляем валидную структуру на слой сервиса
 		token, errToken := h.services.Authorization.GenerateToken(req)
-		if errToken != nil && strings.Contains(errToken.Error(), "sql: no rows in result set") {
-			log.Error("user not found", logger.Err(errToken))
-			render.JSON(w, r, Error("User not found"))
-
-			return
-		} else if errToken != nil {
+		if errToken != nil {
 			log.Error("failed to generation jwt token", logger.Err(errToken))
-			render.JSON(w, r, Error(fmt.Sprintf("Failed to generation jwt token: %s", errToken)))
+			renderError(w, r, "Failed to generation jwt token", errToken)
 
 			return
 		}
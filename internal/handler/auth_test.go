@@ -14,6 +14,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
 
+	"service-chat/internal/apperr"
 	"service-chat/internal/dto"
 	"service-chat/internal/service"
 	mockService "service-chat/internal/service/mocks"
@@ -76,35 +77,35 @@ func TestHandler_SignUp(t *testing.T) {
 			name:                 "Required field username is missing",
 			inputBody:            `{"password": "adgui*"}`,
 			mockBehavior:         func(s *mockService.MockAuthorization, user dto.SignUpRequest) {},
-			expectedStatusCode:   http.StatusOK,
-			expectedResponseBody: `{"status":"Error","error":"Field Username is a required field"}`,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":"Error","code":"validation","error":"Field Username is a required field"}`,
 		},
 		{
 			name:                 "Required field password is missing",
 			inputBody:            `{"username": "Andrey"}`,
 			mockBehavior:         func(s *mockService.MockAuthorization, user dto.SignUpRequest) {},
-			expectedStatusCode:   http.StatusOK,
-			expectedResponseBody: `{"status":"Error","error":"Field Password is a required field"}`,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":"Error","code":"validation","error":"Field Password is a required field"}`,
 		},
 		{
 			name:                 "All required field is missing",
 			inputBody:            `{}`,
 			mockBehavior:         func(s *mockService.MockAuthorization, user dto.SignUpRequest) {},
-			expectedStatusCode:   http.StatusOK,
-			expectedResponseBody: `{"status":"Error","error":"Field Username is a required field, Field Password is a required field"}`,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":"Error","code":"validation","error":"Field Username is a required field, Field Password is a required field"}`,
 		},
 		{
 			name:                 "Request body is nil",
 			mockBehavior:         func(s *mockService.MockAuthorization, user dto.SignUpRequest) {},
-			expectedStatusCode:   http.StatusOK,
-			expectedResponseBody: `{"status":"Error","error":"Empty request"}`,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":"Error","code":"validation","error":"Empty request"}`,
 		},
 		{
 			name:                 "Error decode",
 			inputBody:            `{"username": , "password": "adgui*"}`,
 			mockBehavior:         func(s *mockService.MockAuthorization, user dto.SignUpRequest) {},
-			expectedStatusCode:   http.StatusOK,
-			expectedResponseBody: `{"status":"Error","error":"Invalid request"}`,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":"Error","code":"validation","error":"Invalid request"}`,
 		},
 		{
 			name:      "Username max 20 chars",
@@ -123,36 +124,36 @@ func TestHandler_SignUp(t *testing.T) {
 			name:                 "Username 21 chars",
 			inputBody:            `{"username": "Andreytuoplkjhgsdtywk", "password": "adgui*"}`,
 			mockBehavior:         func(s *mockService.MockAuthorization, user dto.SignUpRequest) {},
-			expectedStatusCode:   http.StatusOK,
-			expectedResponseBody: `{"status":"Error","error":"Field Username cannot exceed 20 characters"}`,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":"Error","code":"validation","error":"Field Username cannot exceed 20 characters"}`,
 		},
 		{
 			name:                 "Username forbidden characters",
 			inputBody:            `{"username": "Andrey@", "password": "adgui*"}`,
 			mockBehavior:         func(s *mockService.MockAuthorization, user dto.SignUpRequest) {},
-			expectedStatusCode:   http.StatusOK,
-			expectedResponseBody: `{"status":"Error","error":"Field Username must not contain symbols !@#$\u0026*()?"}`,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":"Error","code":"validation","error":"Field Username must not contain symbols !@#$\u0026*()?"}`,
 		},
 		{
 			name:                 "Password min 6 chars",
 			inputBody:            `{"username": "Andrey", "password": "adgui"}`,
 			mockBehavior:         func(s *mockService.MockAuthorization, user dto.SignUpRequest) {},
-			expectedStatusCode:   http.StatusOK,
-			expectedResponseBody: `{"status":"Error","error":"Field Password must contain at least 6 characters"}`,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":"Error","code":"validation","error":"Field Password must contain at least 6 characters"}`,
 		},
 		{
 			name:                 "Password max 12 chars",
 			inputBody:            `{"username": "Andrey", "password": "qwertyuiopasd"}`,
 			mockBehavior:         func(s *mockService.MockAuthorization, user dto.SignUpRequest) {},
-			expectedStatusCode:   http.StatusOK,
-			expectedResponseBody: `{"status":"Error","error":"Field Password cannot exceed 12 characters"}`,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":"Error","code":"validation","error":"Field Password cannot exceed 12 characters"}`,
 		},
 		{
 			name:                 "Password contains special character",
 			inputBody:            `{"username": "Andrey", "password": "qwerty"}`,
 			mockBehavior:         func(s *mockService.MockAuthorization, user dto.SignUpRequest) {},
-			expectedStatusCode:   http.StatusOK,
-			expectedResponseBody: `{"status":"Error","error":"Field Password must contain Latin letters and Arabic numerals, as well as the symbols @#$\u0026*()"}`,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":"Error","code":"validation","error":"Field Password must contain Latin letters and Arabic numerals, as well as the symbols @#$\u0026*()"}`,
 		},
 		{
 			name:      "Unique violation username",
@@ -162,10 +163,10 @@ func TestHandler_SignUp(t *testing.T) {
 				Password: "adgui*",
 			},
 			mockBehavior: func(s *mockService.MockAuthorization, user dto.SignUpRequest) {
-				s.EXPECT().CreateUser(user).Return(0, errors.New("unique_violation"))
+				s.EXPECT().CreateUser(user).Return(0, apperr.Conflict("User already exists"))
 			},
-			expectedStatusCode:   http.StatusOK,
-			expectedResponseBody: `{"status":"Error","error":"User already exists"}`,
+			expectedStatusCode:   http.StatusConflict,
+			expectedResponseBody: `{"status":"Error","code":"conflict","error":"Failed to create user: User already exists"}`,
 		},
 		{
 			name:      "Other error",
@@ -177,8 +178,8 @@ func TestHandler_SignUp(t *testing.T) {
 			mockBehavior: func(s *mockService.MockAuthorization, user dto.SignUpRequest) {
 				s.EXPECT().CreateUser(user).Return(0, errors.New("fail"))
 			},
-			expectedStatusCode:   http.StatusOK,
-			expectedResponseBody: `{"status":"Error","error":"Failed to create user"}`,
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: `{"status":"Error","code":"internal","error":"Failed to create user"}`,
 		},
 	}
 
@@ -258,35 +259,35 @@ func TestHandler_SignIn(t *testing.T) {
 			name:                 "Required field username is missing",
 			inputBody:            `{"password": "adgui*"}`,
 			mockBehavior:         func(s *mockService.MockAuthorization, user dto.SignInRequest) {},
-			expectedStatusCode:   http.StatusOK,
-			expectedResponseBody: `{"status":"Error","error":"Field Username is a required field"}`,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":"Error","code":"validation","error":"Field Username is a required field"}`,
 		},
 		{
 			name:                 "Required field password is missing",
 			inputBody:            `{"username": "Andrey"}`,
 			mockBehavior:         func(s *mockService.MockAuthorization, user dto.SignInRequest) {},
-			expectedStatusCode:   http.StatusOK,
-			expectedResponseBody: `{"status":"Error","error":"Field Password is a required field"}`,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":"Error","code":"validation","error":"Field Password is a required field"}`,
 		},
 		{
 			name:                 "All required field is missing",
 			inputBody:            `{}`,
 			mockBehavior:         func(s *mockService.MockAuthorization, user dto.SignInRequest) {},
-			expectedStatusCode:   http.StatusOK,
-			expectedResponseBody: `{"status":"Error","error":"Field Username is a required field, Field Password is a required field"}`,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":"Error","code":"validation","error":"Field Username is a required field, Field Password is a required field"}`,
 		},
 		{
 			name:                 "Request body is nil",
 			mockBehavior:         func(s *mockService.MockAuthorization, user dto.SignInRequest) {},
-			expectedStatusCode:   http.StatusOK,
-			expectedResponseBody: `{"status":"Error","error":"Empty request"}`,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":"Error","code":"validation","error":"Empty request"}`,
 		},
 		{
 			name:                 "Error decode",
 			inputBody:            `{"username": , "password": "adgui*"}`,
 			mockBehavior:         func(s *mockService.MockAuthorization, user dto.SignInRequest) {},
-			expectedStatusCode:   http.StatusOK,
-			expectedResponseBody: `{"status":"Error","error":"Invalid request"}`,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":"Error","code":"validation","error":"Invalid request"}`,
 		},
 		{
 			name:      "Username max 20 chars",
@@ -305,49 +306,49 @@ func TestHandler_SignIn(t *testing.T) {
 			name:                 "Username 21 chars",
 			inputBody:            `{"username": "Andreytuoplkjhgsdtywk", "password": "adgui*"}`,
 			mockBehavior:         func(s *mockService.MockAuthorization, user dto.SignInRequest) {},
-			expectedStatusCode:   http.StatusOK,
-			expectedResponseBody: `{"status":"Error","error":"Field Username cannot exceed 20 characters"}`,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":"Error","code":"validation","error":"Field Username cannot exceed 20 characters"}`,
 		},
 		{
 			name:                 "Username forbidden characters",
 			inputBody:            `{"username": "Andrey@", "password": "adgui*"}`,
 			mockBehavior:         func(s *mockService.MockAuthorization, user dto.SignInRequest) {},
-			expectedStatusCode:   http.StatusOK,
-			expectedResponseBody: `{"status":"Error","error":"Field Username must not contain symbols !@#$\u0026*()?"}`,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":"Error","code":"validation","error":"Field Username must not contain symbols !@#$\u0026*()?"}`,
 		},
 		{
 			name:                 "Password min 6 chars",
 			inputBody:            `{"username": "Andrey", "password": "adgui"}`,
 			mockBehavior:         func(s *mockService.MockAuthorization, user dto.SignInRequest) {},
-			expectedStatusCode:   http.StatusOK,
-			expectedResponseBody: `{"status":"Error","error":"Field Password must contain at least 6 characters"}`,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":"Error","code":"validation","error":"Field Password must contain at least 6 characters"}`,
 		},
 		{
 			name:                 "Password max 12 chars",
 			inputBody:            `{"username": "Andrey", "password": "qwertyuiopasd"}`,
 			mockBehavior:         func(s *mockService.MockAuthorization, user dto.SignInRequest) {},
-			expectedStatusCode:   http.StatusOK,
-			expectedResponseBody: `{"status":"Error","error":"Field Password cannot exceed 12 characters"}`,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":"Error","code":"validation","error":"Field Password cannot exceed 12 characters"}`,
 		},
 		{
 			name:                 "Password contains special character",
 			inputBody:            `{"username": "Andrey", "password": "qwerty"}`,
 			mockBehavior:         func(s *mockService.MockAuthorization, user dto.SignInRequest) {},
-			expectedStatusCode:   http.StatusOK,
-			expectedResponseBody: `{"status":"Error","error":"Field Password must contain Latin letters and Arabic numerals, as well as the symbols @#$\u0026*()"}`,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":"Error","code":"validation","error":"Field Password must contain Latin letters and Arabic numerals, as well as the symbols @#$\u0026*()"}`,
 		},
 		{
-			name:      "Unique violation username",
+			name:      "User not found",
 			inputBody: `{"username": "Andrey", "password": "adgui*"}`,
 			inputUser: dto.SignInRequest{
 				Username: "Andrey",
 				Password: "adgui*",
 			},
 			mockBehavior: func(s *mockService.MockAuthorization, user dto.SignInRequest) {
-				s.EXPECT().GenerateToken(user).Return("", errors.New("sql: no rows in result set"))
+				s.EXPECT().GenerateToken(user).Return("", apperr.NotFound("User not found"))
 			},
-			expectedStatusCode:   http.StatusOK,
-			expectedResponseBody: `{"status":"Error","error":"User not found"}`,
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"status":"Error","code":"not_found","error":"Failed to generation jwt token: User not found"}`,
 		},
 		{
 			name:      "Other error",
@@ -359,8 +360,8 @@ func TestHandler_SignIn(t *testing.T) {
 			mockBehavior: func(s *mockService.MockAuthorization, user dto.SignInRequest) {
 				s.EXPECT().GenerateToken(user).Return("", errors.New("fail"))
 			},
-			expectedStatusCode:   http.StatusOK,
-			expectedResponseBody: `{"status":"Error","error":"Failed to generation jwt token: fail"}`,
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: `{"status":"Error","code":"internal","error":"Failed to generation jwt token"}`,
 		},
 	}
 
@@ -0,0 +1,173 @@
+package handler
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+
+	"service-chat/internal/apperr"
+	"service-chat/internal/dto"
+	"service-chat/internal/logger"
+	"service-chat/internal/validate"
+)
+
+// BlockAdd - внести пользователя в чёрный список
+// @Summary BlockAdd
+// @Security ApiKeyAuth
+// @Tags Block
+// @Description Block a user: they cannot create a direct chat with the current user or mention them, and their messages in shared chats are hidden from the current user if the server is configured to do so
+// @ID Add block
+// @Accept json
+// @Produce json
+// @Param input body dto.BlockAdd true "id of the user to block"
+// @Success 200 {object} Response{Status, Message}
+// @Failure 400,404,405 {object} Response
+// @Failure 500 {object} Response
+// @Failure default {object} Response
+// @Router /users/me/blocks [post]
+func (h *Handler) BlockAdd(log *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Логируем наш запрос
+		const op = "handler.BlockAdd"
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		// Получаем id пользователя из контекста
+		idCtx, errCtx := GetUserID(r.Context())
+		if errCtx != nil {
+			log.Error("failed to get userID from context")
+			renderError(w, r, "", errCtx)
+			return
+		}
+
+		// Структура для записи входных данных из JSON от пользователя
+		var req dto.BlockAdd
+
+		// Анализируем запрос от пользователя
+		fail := validate.BaseValidate(log, r.Body, &req)
+		if fail != nil && fail.ValidateErr != nil {
+			log.Error("invalid request data")
+			renderError(w, r, "", fail.ValidateErr)
+			return
+		} else if fail != nil && fail.ErrMsg != "" {
+			log.Error("invalid request data")
+			renderError(w, r, "", apperr.Validation(fail.ErrMsg))
+			return
+		}
+
+		// Отправляем валидную структуру на слой сервиса
+		if errAdd := h.services.Block.AddBlock(req, idCtx); errAdd != nil {
+			log.Error("failed to block user", logger.Err(errAdd))
+			renderError(w, r, "Failed to block user", errAdd)
+			return
+		}
+
+		// Если ошибок нет отправляем успешный ответ
+		log.Info("User blocked successfully", slog.Int64("blockedID", req.UserID))
+		render.JSON(w, r, OK("User blocked successfully"))
+		return
+	}
+}
+
+// BlockGet - чёрный список текущего пользователя
+// @Summary BlockGet
+// @Security ApiKeyAuth
+// @Tags Block
+// @Description Get the users blocked by the current user, recently blocked first
+// @ID Get blocks
+// @Produce json
+// @Success 200 {object} Response{Status, Message, BlocksList}
+// @Failure 400,404,405 {object} Response
+// @Failure 500 {object} Response
+// @Failure default {object} Response
+// @Router /users/me/blocks [get]
+func (h *Handler) BlockGet(log *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Логируем наш запрос
+		const op = "handler.BlockGet"
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		// Получаем id пользователя из контекста
+		idCtx, errCtx := GetUserID(r.Context())
+		if errCtx != nil {
+			log.Error("failed to get userID from context")
+			renderError(w, r, "", errCtx)
+			return
+		}
+
+		// Отправляем запрос на слой сервиса
+		blocks, errGet := h.services.Block.GetBlocks(idCtx)
+		if errGet != nil {
+			log.Error("failed to get blocks", logger.Err(errGet))
+			renderError(w, r, "Failed to get blocks", errGet)
+			return
+		}
+
+		// Если ошибок нет отправляем успешный ответ
+		log.Info("Blocks found successfully", slog.Int("count", len(blocks)))
+		render.JSON(w, r, Response{
+			Status:     StatusOK,
+			Message:    "Blocks found successfully",
+			BlocksList: blocks,
+		})
+		return
+	}
+}
+
+// BlockDelete - убрать пользователя из чёрного списка
+// @Summary BlockDelete
+// @Security ApiKeyAuth
+// @Tags Block
+// @Description Unblock a user previously blocked by the current user
+// @ID Delete block
+// @Produce json
+// @Param id path int true "id of the blocked user"
+// @Success 200 {object} Response{Status, Message}
+// @Failure 400,404,405 {object} Response
+// @Failure 500 {object} Response
+// @Failure default {object} Response
+// @Router /users/me/blocks/{id} [delete]
+func (h *Handler) BlockDelete(log *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Логируем наш запрос
+		const op = "handler.BlockDelete"
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		// Получаем id пользователя из контекста
+		idCtx, errCtx := GetUserID(r.Context())
+		if errCtx != nil {
+			log.Error("failed to get userID from context")
+			renderError(w, r, "", errCtx)
+			return
+		}
+
+		// Получаем id заблокированного пользователя из пути запроса
+		blockedID, errID := GetPathID(r)
+		if errID != nil {
+			log.Error("invalid user ID")
+			renderError(w, r, "", errID)
+			return
+		}
+
+		if errDel := h.services.Block.DeleteBlock(blockedID, idCtx); errDel != nil {
+			log.Error("failed to unblock user", logger.Err(errDel))
+			renderError(w, r, "Failed to unblock user", errDel)
+			return
+		}
+
+		// Если ошибок нет отправляем успешный ответ
+		log.Info("User unblocked successfully", slog.Int64("blockedID", blockedID))
+		render.JSON(w, r, OK("User unblocked successfully"))
+		return
+	}
+}
@@ -0,0 +1,143 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"service-chat/internal/apperr"
+	"service-chat/internal/db/entity"
+	"service-chat/internal/dto"
+	"service-chat/internal/service"
+	mockService "service-chat/internal/service/mocks"
+)
+
+func TestHandler_Block(t *testing.T) {
+	// Структура для последующей реализации поведения мока
+	type mockBehaviour func(s *mockService.MockBlock)
+
+	//Инициализируем контролер для мока сервиса
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Создаём мок сервиса чёрного списка
+	mockBlock := mockService.NewMockBlock(ctrl)
+
+	// Создаём экземпляр обработчика
+	handler := NewHandler(&service.Service{Block: mockBlock})
+
+	// Мокируем логгер
+	mockLog := slog.New(slog.NewJSONHandler(io.Discard, nil))
+
+	// Инициализируем сервер
+	r := chi.NewRouter()
+	r.Post("/users/me/blocks", handler.BlockAdd(mockLog))
+	r.Get("/users/me/blocks", handler.BlockGet(mockLog))
+	r.Delete("/users/me/blocks/{id}", handler.BlockDelete(mockLog))
+
+	// Тестовая таблица с данными
+	testTable := []struct {
+		name                 string
+		method               string
+		path                 string
+		inputBody            string
+		mockBehaviour        mockBehaviour
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:      "Add OK",
+			method:    http.MethodPost,
+			path:      "/users/me/blocks",
+			inputBody: `{"user_id":2}`,
+			mockBehaviour: func(s *mockService.MockBlock) {
+				s.EXPECT().AddBlock(dto.BlockAdd{UserID: 2}, 1).Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"status":"OK","message":"User blocked successfully"}`,
+		},
+		{
+			name:                 "Add without user",
+			method:               http.MethodPost,
+			path:                 "/users/me/blocks",
+			inputBody:            `{}`,
+			mockBehaviour:        func(s *mockService.MockBlock) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":"Error","code":"validation","error":"Field UserID is a required field"}`,
+		},
+		{
+			name:      "Add yourself",
+			method:    http.MethodPost,
+			path:      "/users/me/blocks",
+			inputBody: `{"user_id":1}`,
+			mockBehaviour: func(s *mockService.MockBlock) {
+				s.EXPECT().AddBlock(dto.BlockAdd{UserID: 1}, 1).Return(apperr.Validation("cannot block yourself"))
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":"Error","code":"validation","error":"Failed to block user: cannot block yourself"}`,
+		},
+		{
+			name:   "Get OK",
+			method: http.MethodGet,
+			path:   "/users/me/blocks",
+			mockBehaviour: func(s *mockService.MockBlock) {
+				s.EXPECT().GetBlocks(1).Return([]entity.Block{{UserID: 2, Username: "eve", CreatedAt: "2030-01-01T09:00:00Z"}}, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"status":"OK","message":"Blocks found successfully","blocks_list":[{"user_id":2,"username":"eve","created_at":"2030-01-01T09:00:00Z"}]}`,
+		},
+		{
+			name:   "Delete OK",
+			method: http.MethodDelete,
+			path:   "/users/me/blocks/2",
+			mockBehaviour: func(s *mockService.MockBlock) {
+				s.EXPECT().DeleteBlock(int64(2), 1).Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"status":"OK","message":"User unblocked successfully"}`,
+		},
+		{
+			name:   "Delete not blocked",
+			method: http.MethodDelete,
+			path:   "/users/me/blocks/3",
+			mockBehaviour: func(s *mockService.MockBlock) {
+				s.EXPECT().DeleteBlock(int64(3), 1).Return(apperr.NotFound("User with userID 3 is not blocked"))
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"status":"Error","code":"not_found","error":"Failed to unblock user: User with userID 3 is not blocked"}`,
+		},
+		{
+			name:                 "Delete invalid id",
+			method:               http.MethodDelete,
+			path:                 "/users/me/blocks/abc",
+			mockBehaviour:        func(s *mockService.MockBlock) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":"Error","code":"validation","error":"Invalid id in path"}`,
+		},
+	}
+
+	// Итерируемся по нашей тестовой таблице
+	for _, tt := range testTable {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockBehaviour(mockBlock)
+
+			// Готовим и выполняем тестовый запрос
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(tt.method, tt.path, bytes.NewBufferString(tt.inputBody))
+			r.ServeHTTP(w, req.WithContext(context.WithValue(req.Context(), userCtx, 1)))
+
+			// Сравниваем ожидаемый и актуальный результат
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			assert.Equal(t, tt.expectedResponseBody, strings.TrimSpace(w.Body.String()))
+		})
+	}
+}
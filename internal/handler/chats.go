@@ -4,11 +4,11 @@ import (
 	"fmt"
 	"log/slog"
 	"net/http"
-	"strings"
 
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/render"
 
+	"service-chat/internal/apperr"
 	"service-chat/internal/dto"
 	"service-chat/internal/logger"
 	"service-chat/internal/validate"
@@ -18,7 +18,7 @@ import (
 // @Summary ChatAdd
 // @Security ApiKeyAuth
 // @Tags Chat
-// @Description Create chat
+// @Description Create chat, the creator becomes its admin
 // @ID Create chat
 // @Accept json
 // @Produce json
@@ -32,11 +32,19 @@ func (h *Handler) ChatAdd(log *slog.Logger) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		// Логируем наш запрос
 		const op = "handler.ChatAdd"
-		log = log.With(
+		log := log.With(
 			slog.String("op", op),
 			slog.String("request_id", middleware.GetReqID(r.Context())),
 		)
 
+		// Получаем id пользователя из контекста, создатель становится администратором чата
+		idCtx, errCtx := GetUserID(r.Context())
+		if errCtx != nil {
+			log.Error("failed to get userID from context")
+			renderError(w, r, "", errCtx)
+			return
+		}
+
 		// Структура для записи входных данных из JSON от пользователя
 		var req dto.ChatAdd
 
@@ -44,23 +52,19 @@ func (h *Handler) ChatAdd(log *slog.Logger) http.HandlerFunc {
 		fail := validate.BaseValidate(log, r.Body, &req)
 		if fail != nil && fail.ValidateErr != nil {
 			log.Error("invalid request data")
-			render.JSON(w, r, ValidationError(fail.ValidateErr))
+			renderError(w, r, "", fail.ValidateErr)
 			return
 		} else if fail != nil && fail.ErrMsg != "" {
 			log.Error("invalid request data")
-			render.JSON(w, r, Error(fail.ErrMsg))
+			renderError(w, r, "", apperr.Validation(fail.ErrMsg))
 			return
 		}
 
 		// Отправляем валидную структуру на слой сервиса
-		chatID, err := h.services.Chat.CreateChat(req)
-		if err != nil && strings.Contains(err.Error(), "unique_violation") {
-			log.Error("chat already exists", logger.Err(err))
-			render.JSON(w, r, Error("Chat already exists"))
-			return
-		} else if err != nil {
+		chatID, err := h.services.Chat.CreateChat(req, idCtx)
+		if err != nil {
 			log.Error("failed to create chat", logger.Err(err))
-			render.JSON(w, r, Error(fmt.Sprintf("Failed to create chat: %s", err)))
+			renderError(w, r, "Failed to create chat", err)
 			return
 		}
 
@@ -89,7 +93,7 @@ func (h *Handler) ChatDelete(log *slog.Logger) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		// Логируем наш запрос
 		const op = "handler.ChatDelete"
-		log = log.With(
+		log := log.With(
 			slog.String("op", op),
 			slog.String("request_id", middleware.GetReqID(r.Context())),
 		)
@@ -98,7 +102,7 @@ func (h *Handler) ChatDelete(log *slog.Logger) http.HandlerFunc {
 		idCtx, errCtx := GetUserID(r.Context())
 		if errCtx != nil {
 			log.Error("failed to get userID from context")
-			render.JSON(w, r, Error(errCtx.Error()))
+			renderError(w, r, "", errCtx)
 			return
 		}
 
@@ -109,11 +113,11 @@ func (h *Handler) ChatDelete(log *slog.Logger) http.HandlerFunc {
 		fail := validate.BaseValidate(log, r.Body, &req)
 		if fail != nil && fail.ValidateErr != nil {
 			log.Error("invalid request data")
-			render.JSON(w, r, ValidationError(fail.ValidateErr))
+			renderError(w, r, "", fail.ValidateErr)
 			return
 		} else if fail != nil && fail.ErrMsg != "" {
 			log.Error("invalid request data")
-			render.JSON(w, r, Error(fail.ErrMsg))
+			renderError(w, r, "", apperr.Validation(fail.ErrMsg))
 			return
 		}
 
@@ -121,7 +125,7 @@ func (h *Handler) ChatDelete(log *slog.Logger) http.HandlerFunc {
 		chatsDel, errMsg := h.services.Chat.DeleteChat(req, idCtx)
 		if errMsg != nil {
 			log.Error("failed to delete chats", logger.Err(errMsg))
-			render.JSON(w, r, Error(fmt.Sprintf("Failed to delete chats: %s", errMsg)))
+			renderError(w, r, "Failed to delete chats", errMsg)
 			return
 		}
 
@@ -154,7 +158,7 @@ func (h *Handler) ChatGet(log *slog.Logger) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		// Логируем наш запрос
 		const op = "handler.ChatGet"
-		log = log.With(
+		log := log.With(
 			slog.String("op", op),
 			slog.String("request_id", middleware.GetReqID(r.Context())),
 		)
@@ -163,7 +167,7 @@ func (h *Handler) ChatGet(log *slog.Logger) http.HandlerFunc {
 		idCtx, errCtx := GetUserID(r.Context())
 		if errCtx != nil {
 			log.Error("failed to get userID from context")
-			render.JSON(w, r, Error(errCtx.Error()))
+			renderError(w, r, "", errCtx)
 			return
 		}
 
@@ -174,18 +178,18 @@ func (h *Handler) ChatGet(log *slog.Logger) http.HandlerFunc {
 		fail := validate.BaseValidate(log, r.Body, &req)
 		if fail != nil && fail.ValidateErr != nil {
 			log.Error("invalid request data")
-			render.JSON(w, r, ValidationError(fail.ValidateErr))
+			renderError(w, r, "", fail.ValidateErr)
 			return
 		} else if fail != nil && fail.ErrMsg != "" {
 			log.Error("invalid request data")
-			render.JSON(w, r, Error(fail.ErrMsg))
+			renderError(w, r, "", apperr.Validation(fail.ErrMsg))
 			return
 		}
 
 		// Проверяем, что id из контекста совпадает с id из запроса
 		if int64(idCtx) != *req.UserID {
 			log.Error("invalid user ID")
-			render.JSON(w, r, Error("Invalid user ID"))
+			renderError(w, r, "", apperr.Forbidden("Invalid user ID"))
 			return
 		}
 
@@ -193,7 +197,7 @@ func (h *Handler) ChatGet(log *slog.Logger) http.HandlerFunc {
 		chats, errMsg := h.services.Chat.GetChat(req)
 		if errMsg != nil {
 			log.Error("failed to get chats", logger.Err(errMsg))
-			render.JSON(w, r, Error(fmt.Sprintf("Failed to get chats: %s", errMsg)))
+			renderError(w, r, "Failed to get chats", errMsg)
 			return
 		}
 
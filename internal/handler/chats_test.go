@@ -14,6 +14,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
 
+	"service-chat/internal/apperr"
 	"service-chat/internal/db/entity"
 	"service-chat/internal/dto"
 	"service-chat/internal/service"
@@ -65,7 +66,7 @@ func TestHandler_ChatAdd(t *testing.T) {
 				Users:    []int64{1, 2},
 			},
 			mockBehavior: func(s *mockService.MockChat, chat dto.ChatAdd) {
-				s.EXPECT().CreateChat(chat).Return(1, nil)
+				s.EXPECT().CreateChat(chat, 1).Return(1, nil)
 			},
 			expectedStatusCode:   http.StatusOK,
 			expectedResponseBody: `{"status":"OK","message":"Chat created successfully, id: 1"}`,
@@ -74,35 +75,35 @@ func TestHandler_ChatAdd(t *testing.T) {
 			name:                 "Required field chat_name is missing",
 			inputBody:            `{"users": [1,2]}`,
 			mockBehavior:         func(s *mockService.MockChat, chat dto.ChatAdd) {},
-			expectedStatusCode:   http.StatusOK,
-			expectedResponseBody: `{"status":"Error","error":"Field ChatName is a required field"}`,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":"Error","code":"validation","error":"Field ChatName is a required field"}`,
 		},
 		{
 			name:                 "Required field chat_name is missing",
 			inputBody:            `{"chat_name": "chat_1"}`,
 			mockBehavior:         func(s *mockService.MockChat, chat dto.ChatAdd) {},
-			expectedStatusCode:   http.StatusOK,
-			expectedResponseBody: `{"status":"Error","error":"Field Users is a required field"}`,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":"Error","code":"validation","error":"Field Users is a required field"}`,
 		},
 		{
 			name:                 "All required field is missing",
 			inputBody:            `{}`,
 			mockBehavior:         func(s *mockService.MockChat, chat dto.ChatAdd) {},
-			expectedStatusCode:   http.StatusOK,
-			expectedResponseBody: `{"status":"Error","error":"Field ChatName is a required field, Field Users is a required field"}`,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":"Error","code":"validation","error":"Field ChatName is a required field, Field Users is a required field"}`,
 		},
 		{
 			name:                 "Request body is nil",
 			mockBehavior:         func(s *mockService.MockChat, chat dto.ChatAdd) {},
-			expectedStatusCode:   http.StatusOK,
-			expectedResponseBody: `{"status":"Error","error":"Empty request"}`,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":"Error","code":"validation","error":"Empty request"}`,
 		},
 		{
 			name:                 "Error decode",
 			inputBody:            `{"chat_name": ,"users": [1,2]}`,
 			mockBehavior:         func(s *mockService.MockChat, chat dto.ChatAdd) {},
-			expectedStatusCode:   http.StatusOK,
-			expectedResponseBody: `{"status":"Error","error":"Invalid request"}`,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":"Error","code":"validation","error":"Invalid request"}`,
 		},
 		{
 			name:      "Chat_name max 20 chars",
@@ -112,7 +113,7 @@ func TestHandler_ChatAdd(t *testing.T) {
 				Users:    []int64{1, 2},
 			},
 			mockBehavior: func(s *mockService.MockChat, chat dto.ChatAdd) {
-				s.EXPECT().CreateChat(chat).Return(1, nil)
+				s.EXPECT().CreateChat(chat, 1).Return(1, nil)
 			},
 			expectedStatusCode:   http.StatusOK,
 			expectedResponseBody: `{"status":"OK","message":"Chat created successfully, id: 1"}`,
@@ -121,43 +122,43 @@ func TestHandler_ChatAdd(t *testing.T) {
 			name:                 "Chat_name max 21 chars",
 			inputBody:            `{"chat_name": "qwertyuiopasdfghjklzx","users": [1,2]}`,
 			mockBehavior:         func(s *mockService.MockChat, chat dto.ChatAdd) {},
-			expectedStatusCode:   http.StatusOK,
-			expectedResponseBody: `{"status":"Error","error":"Field ChatName cannot exceed 20 characters"}`,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":"Error","code":"validation","error":"Field ChatName cannot exceed 20 characters"}`,
 		},
 		{
 			name:                 "Chat_name forbidden characters",
 			inputBody:            `{"chat_name": "chat_1#","users": [1,2]}`,
 			mockBehavior:         func(s *mockService.MockChat, chat dto.ChatAdd) {},
-			expectedStatusCode:   http.StatusOK,
-			expectedResponseBody: `{"status":"Error","error":"Field ChatName must not contain symbols !@#$\u0026*()?"}`,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":"Error","code":"validation","error":"Field ChatName must not contain symbols !@#$\u0026*()?"}`,
 		},
 		{
 			name:                 "Chat_name min 6 chars",
 			inputBody:            `{"chat_name": "chat","users": [1,2]}`,
 			mockBehavior:         func(s *mockService.MockChat, chat dto.ChatAdd) {},
-			expectedStatusCode:   http.StatusOK,
-			expectedResponseBody: `{"status":"Error","error":"Field ChatName must contain at least 6 characters"}`,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":"Error","code":"validation","error":"Field ChatName must contain at least 6 characters"}`,
 		},
 		{
 			name:                 "Empty users",
 			inputBody:            `{"chat_name": "chat_1","users": []}`,
 			mockBehavior:         func(s *mockService.MockChat, chat dto.ChatAdd) {},
-			expectedStatusCode:   http.StatusOK,
-			expectedResponseBody: `{"status":"Error","error":"Field Users must contain at least 2 characters"}`,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":"Error","code":"validation","error":"Field Users must contain at least 2 characters"}`,
 		},
 		{
 			name:                 "One user",
 			inputBody:            `{"chat_name": "chat_1","users": [1]}`,
 			mockBehavior:         func(s *mockService.MockChat, chat dto.ChatAdd) {},
-			expectedStatusCode:   http.StatusOK,
-			expectedResponseBody: `{"status":"Error","error":"Field Users must contain at least 2 characters"}`,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":"Error","code":"validation","error":"Field Users must contain at least 2 characters"}`,
 		},
 		{
 			name:                 "Chat_name is nil",
 			inputBody:            `{"chat_name": "","users": [1,2]}`,
 			mockBehavior:         func(s *mockService.MockChat, chat dto.ChatAdd) {},
-			expectedStatusCode:   http.StatusOK,
-			expectedResponseBody: `{"status":"Error","error":"Field ChatName is a required field"}`,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":"Error","code":"validation","error":"Field ChatName is a required field"}`,
 		},
 		{
 			name:      "Unique violation chat_name",
@@ -167,10 +168,10 @@ func TestHandler_ChatAdd(t *testing.T) {
 				Users:    []int64{1, 2},
 			},
 			mockBehavior: func(s *mockService.MockChat, chat dto.ChatAdd) {
-				s.EXPECT().CreateChat(chat).Return(1, errors.New("unique_violation"))
+				s.EXPECT().CreateChat(chat, 1).Return(0, apperr.Conflict("Chat already exists"))
 			},
-			expectedStatusCode:   http.StatusOK,
-			expectedResponseBody: `{"status":"Error","error":"Chat already exists"}`,
+			expectedStatusCode:   http.StatusConflict,
+			expectedResponseBody: `{"status":"Error","code":"conflict","error":"Failed to create chat: Chat already exists"}`,
 		},
 		{
 			name:      "Other error",
@@ -180,10 +181,10 @@ func TestHandler_ChatAdd(t *testing.T) {
 				Users:    []int64{1, 2},
 			},
 			mockBehavior: func(s *mockService.MockChat, chat dto.ChatAdd) {
-				s.EXPECT().CreateChat(chat).Return(1, errors.New("example error"))
+				s.EXPECT().CreateChat(chat, 1).Return(1, errors.New("example error"))
 			},
-			expectedStatusCode:   http.StatusOK,
-			expectedResponseBody: `{"status":"Error","error":"Failed to create chat: example error"}`,
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: `{"status":"Error","code":"internal","error":"Failed to create chat"}`,
 		},
 	}
 
@@ -197,8 +198,8 @@ func TestHandler_ChatAdd(t *testing.T) {
 			w := httptest.NewRecorder()
 			req := httptest.NewRequest(http.MethodPost, "/chats/add", strings.NewReader(tt.inputBody))
 
-			// Выполняем запрос
-			r.ServeHTTP(w, req)
+			// Выполняем запрос от лица пользователя
+			r.ServeHTTP(w, req.WithContext(context.WithValue(req.Context(), userCtx, 1)))
 
 			// Сравниваем ожидаемый и актуальный результат
 			assert.Equal(t, tt.expectedStatusCode, w.Code)
@@ -288,28 +289,28 @@ func TestHandler_ChatDelete(t *testing.T) {
 			name:                 "Required field chat_ids is missing",
 			inputBody:            `{}`,
 			mockBehavior:         func(s *mockService.MockChat, chat dto.ChatDelete) {},
-			expectedStatusCode:   http.StatusOK,
-			expectedResponseBody: `{"status":"Error","error":"Field ChatIds is a required field"}`,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":"Error","code":"validation","error":"Field ChatIds is a required field"}`,
 		},
 		{
 			name:                 "Empty chat_ids",
 			inputBody:            `{"chat_ids": []}`,
 			mockBehavior:         func(s *mockService.MockChat, chat dto.ChatDelete) {},
-			expectedStatusCode:   http.StatusOK,
-			expectedResponseBody: `{"status":"Error","error":"Field ChatIds must contain at least 1 characters"}`,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":"Error","code":"validation","error":"Field ChatIds must contain at least 1 characters"}`,
 		},
 		{
 			name:                 "Request body is nil",
 			mockBehavior:         func(s *mockService.MockChat, chat dto.ChatDelete) {},
-			expectedStatusCode:   http.StatusOK,
-			expectedResponseBody: `{"status":"Error","error":"Empty request"}`,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":"Error","code":"validation","error":"Empty request"}`,
 		},
 		{
 			name:                 "Error decode",
 			inputBody:            `{"chat_ids":}`,
 			mockBehavior:         func(s *mockService.MockChat, chat dto.ChatDelete) {},
-			expectedStatusCode:   http.StatusOK,
-			expectedResponseBody: `{"status":"Error","error":"Invalid request"}`,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":"Error","code":"validation","error":"Invalid request"}`,
 		},
 		{
 			name:      "Other error",
@@ -320,8 +321,8 @@ func TestHandler_ChatDelete(t *testing.T) {
 			mockBehavior: func(s *mockService.MockChat, chat dto.ChatDelete) {
 				s.EXPECT().DeleteChat(chat, 1).Return(nil, errors.New("some error"))
 			},
-			expectedStatusCode:   http.StatusOK,
-			expectedResponseBody: `{"status":"Error","error":"Failed to delete chats: some error"}`,
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: `{"status":"Error","code":"internal","error":"Failed to delete chats"}`,
 		},
 	}
 
@@ -398,7 +399,7 @@ func TestHandler_ChatGet(t *testing.T) {
 				}, nil)
 			},
 			expectedStatusCode:   http.StatusOK,
-			expectedResponseBody: `{"status":"OK","message":"Chats get successfully","chats_list":[{"id":1,"name":"chat_1","created_at":"2024-09-20T18:26:13.239627Z","is_deleted":false}]}`,
+			expectedResponseBody: `{"status":"OK","message":"Chats get successfully","chats_list":[{"id":1,"name":"chat_1","created_at":"2024-09-20T18:26:13.239627Z","is_deleted":false,"has_draft":false}]}`,
 		},
 		{
 			name:      "OK many chats",
@@ -429,7 +430,7 @@ func TestHandler_ChatGet(t *testing.T) {
 				}, nil)
 			},
 			expectedStatusCode:   http.StatusOK,
-			expectedResponseBody: `{"status":"OK","message":"Chats get successfully","chats_list":[{"id":1,"name":"chat_1","created_at":"2024-09-20T18:26:13.239627Z","is_deleted":false},{"id":2,"name":"chat_2","created_at":"2024-09-19T18:26:13.239627Z","is_deleted":false},{"id":3,"name":"chat_1","created_at":"2024-09-18T18:26:13.239627Z","is_deleted":false}]}`,
+			expectedResponseBody: `{"status":"OK","message":"Chats get successfully","chats_list":[{"id":1,"name":"chat_1","created_at":"2024-09-20T18:26:13.239627Z","is_deleted":false,"has_draft":false},{"id":2,"name":"chat_2","created_at":"2024-09-19T18:26:13.239627Z","is_deleted":false,"has_draft":false},{"id":3,"name":"chat_1","created_at":"2024-09-18T18:26:13.239627Z","is_deleted":false,"has_draft":false}]}`,
 		},
 		{
 			name:      "User has no chats",
@@ -447,35 +448,35 @@ func TestHandler_ChatGet(t *testing.T) {
 			name:                 "Required field user_id is missing",
 			inputBody:            `{}`,
 			mockBehavior:         func(s *mockService.MockChat, user dto.ChatGet) {},
-			expectedStatusCode:   http.StatusOK,
-			expectedResponseBody: `{"status":"Error","error":"Field UserID is a required field"}`,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":"Error","code":"validation","error":"Field UserID is a required field"}`,
 		},
 		{
 			name:                 "Empty user_id",
 			inputBody:            `{"user_id":}`,
 			mockBehavior:         func(s *mockService.MockChat, user dto.ChatGet) {},
-			expectedStatusCode:   http.StatusOK,
-			expectedResponseBody: `{"status":"Error","error":"Invalid request"}`,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":"Error","code":"validation","error":"Invalid request"}`,
 		},
 		{
 			name:                 "Invalid user ID",
 			inputBody:            `{"user_id": 0}`,
 			mockBehavior:         func(s *mockService.MockChat, user dto.ChatGet) {},
-			expectedStatusCode:   http.StatusOK,
-			expectedResponseBody: `{"status":"Error","error":"Invalid user ID"}`,
+			expectedStatusCode:   http.StatusForbidden,
+			expectedResponseBody: `{"status":"Error","code":"forbidden","error":"Invalid user ID"}`,
 		},
 		{
 			name:                 "Error decode",
 			inputBody:            `{"user_id"}`,
 			mockBehavior:         func(s *mockService.MockChat, user dto.ChatGet) {},
-			expectedStatusCode:   http.StatusOK,
-			expectedResponseBody: `{"status":"Error","error":"Invalid request"}`,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":"Error","code":"validation","error":"Invalid request"}`,
 		},
 		{
 			name:                 "Request body is nil",
 			mockBehavior:         func(s *mockService.MockChat, user dto.ChatGet) {},
-			expectedStatusCode:   http.StatusOK,
-			expectedResponseBody: `{"status":"Error","error":"Empty request"}`,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":"Error","code":"validation","error":"Empty request"}`,
 		},
 		{
 			name:      "Other error",
@@ -486,8 +487,8 @@ func TestHandler_ChatGet(t *testing.T) {
 			mockBehavior: func(s *mockService.MockChat, user dto.ChatGet) {
 				s.EXPECT().GetChat(user).Return(nil, errors.New("some error"))
 			},
-			expectedStatusCode:   http.StatusOK,
-			expectedResponseBody: `{"status":"Error","error":"Failed to get chats: some error"}`,
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: `{"status":"Error","code":"internal","error":"Failed to get chats"}`,
 		},
 	}
 
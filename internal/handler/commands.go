@@ -0,0 +1,211 @@
+package handler
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+
+	"service-chat/internal/apperr"
+	"service-chat/internal/dto"
+	"service-chat/internal/logger"
+	"service-chat/internal/validate"
+)
+
+// CommandAdd - создать команду чата, которую обрабатывает внешний бот
+// @Summary CommandAdd
+// @Security ApiKeyAuth
+// @Tags Command
+// @Description Create a chat command handled by an external bot, only chat admins can do this. When a member sends /command, the bot url receives a signed POST {"command","text","chat_id","user_id","timestamp"} with X-Bot-Timestamp and X-Bot-Signature: sha256=HMAC-SHA256(secret, "timestamp.body"). The bot replies {"text","format","response_type"}: ephemeral (default) is shown only to the caller, in_channel is posted to the chat by the bot. The secret is returned only in this response
+// @ID Add command
+// @Accept json
+// @Produce json
+// @Param id path int true "chat id"
+// @Param input body dto.CommandAdd true "command name, description and bot url"
+// @Success 200 {object} Response{Status, Message, Command}
+// @Failure 400,404,405 {object} Response
+// @Failure 500 {object} Response
+// @Failure default {object} Response
+// @Router /chats/{id}/commands [post]
+func (h *Handler) CommandAdd(log *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Логируем наш запрос
+		const op = "handler.CommandAdd"
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		// Получаем id пользователя из контекста
+		idCtx, errCtx := GetUserID(r.Context())
+		if errCtx != nil {
+			log.Error("failed to get userID from context")
+			renderError(w, r, "", errCtx)
+			return
+		}
+
+		// Получаем id чата из пути запроса
+		chatID, errID := GetPathID(r)
+		if errID != nil {
+			log.Error("invalid chat ID")
+			renderError(w, r, "", errID)
+			return
+		}
+
+		// Структура для записи входных данных из JSON от пользователя
+		var req dto.CommandAdd
+
+		// Анализируем запрос от пользователя
+		fail := validate.BaseValidate(log, r.Body, &req)
+		if fail != nil && fail.ValidateErr != nil {
+			log.Error("invalid request data")
+			renderError(w, r, "", fail.ValidateErr)
+			return
+		} else if fail != nil && fail.ErrMsg != "" {
+			log.Error("invalid request data")
+			renderError(w, r, "", apperr.Validation(fail.ErrMsg))
+			return
+		}
+
+		// Отправляем валидную структуру на слой сервиса
+		command, errAdd := h.services.Command.AddCommand(req, chatID, idCtx)
+		if errAdd != nil {
+			log.Error("failed to add command", logger.Err(errAdd))
+			renderError(w, r, "Failed to add command", errAdd)
+			return
+		}
+
+		// Если ошибок нет отправляем успешный ответ
+		log.Info("Command created successfully", slog.Int64("commandID", command.ID))
+		render.JSON(w, r, Response{
+			Status:  StatusOK,
+			Message: "Command created successfully",
+			Command: command,
+		})
+		return
+	}
+}
+
+// CommandGet - команды внешних ботов чата
+// @Summary CommandGet
+// @Security ApiKeyAuth
+// @Tags Command
+// @Description Get chat commands handled by external bots, available to chat members. Bot urls are shown only to chat admins, built-in commands are listed by /help
+// @ID Get commands
+// @Produce json
+// @Param id path int true "chat id"
+// @Success 200 {object} Response{Status, Message, CommandsList}
+// @Failure 400,404,405 {object} Response
+// @Failure 500 {object} Response
+// @Failure default {object} Response
+// @Router /chats/{id}/commands [get]
+func (h *Handler) CommandGet(log *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Логируем наш запрос
+		const op = "handler.CommandGet"
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		// Получаем id пользователя из контекста
+		idCtx, errCtx := GetUserID(r.Context())
+		if errCtx != nil {
+			log.Error("failed to get userID from context")
+			renderError(w, r, "", errCtx)
+			return
+		}
+
+		// Получаем id чата из пути запроса
+		chatID, errID := GetPathID(r)
+		if errID != nil {
+			log.Error("invalid chat ID")
+			renderError(w, r, "", errID)
+			return
+		}
+
+		// Отправляем запрос на слой сервиса
+		commands, errGet := h.services.Command.GetCommands(chatID, idCtx)
+		if errGet != nil {
+			log.Error("failed to get commands", logger.Err(errGet))
+			renderError(w, r, "Failed to get commands", errGet)
+			return
+		}
+
+		// Если команд нет
+		if len(commands) == 0 {
+			log.Info("commands not found")
+			render.JSON(w, r, OK("No commands found"))
+			return
+		}
+
+		// Если ошибок нет отправляем успешный ответ
+		log.Info("Commands found successfully", slog.Int("count", len(commands)))
+		render.JSON(w, r, Response{
+			Status:       StatusOK,
+			Message:      "Commands found successfully",
+			CommandsList: commands,
+		})
+		return
+	}
+}
+
+// CommandDelete - удалить команду чата
+// @Summary CommandDelete
+// @Security ApiKeyAuth
+// @Tags Command
+// @Description Delete a chat command, only chat admins can do this. Messages of the bot stay in the chat
+// @ID Delete command
+// @Produce json
+// @Param id path int true "chat id"
+// @Param commandID path int true "command id"
+// @Success 200 {object} Response{Status, Message}
+// @Failure 400,404,405 {object} Response
+// @Failure 500 {object} Response
+// @Failure default {object} Response
+// @Router /chats/{id}/commands/{commandID} [delete]
+func (h *Handler) CommandDelete(log *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Логируем наш запрос
+		const op = "handler.CommandDelete"
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		// Получаем id пользователя из контекста
+		idCtx, errCtx := GetUserID(r.Context())
+		if errCtx != nil {
+			log.Error("failed to get userID from context")
+			renderError(w, r, "", errCtx)
+			return
+		}
+
+		// Получаем id чата и команды из пути запроса
+		chatID, errID := GetPathID(r)
+		if errID != nil {
+			log.Error("invalid chat ID")
+			renderError(w, r, "", errID)
+			return
+		}
+		commandID, errID := getPathParamID(r, commandIDParam)
+		if errID != nil {
+			log.Error("invalid command ID")
+			renderError(w, r, "", errID)
+			return
+		}
+
+		// Отправляем запрос на слой сервиса
+		if errDel := h.services.Command.DeleteCommand(commandID, chatID, idCtx); errDel != nil {
+			log.Error("failed to delete command", logger.Err(errDel))
+			renderError(w, r, "Failed to delete command", errDel)
+			return
+		}
+
+		// Если ошибок нет отправляем успешный ответ
+		log.Info("Command deleted successfully", slog.Int64("commandID", commandID))
+		render.JSON(w, r, OK("Command deleted successfully"))
+		return
+	}
+}
@@ -0,0 +1,147 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"service-chat/internal/apperr"
+	"service-chat/internal/db/entity"
+	"service-chat/internal/dto"
+	"service-chat/internal/service"
+	mockService "service-chat/internal/service/mocks"
+)
+
+func TestHandler_Command(t *testing.T) {
+	// Структура для последующей реализации поведения мока
+	type mockBehaviour func(s *mockService.MockCommand)
+
+	//Инициализируем контролер для мока сервиса
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Создаём моки сервиса команд
+	mockCommand := mockService.NewMockCommand(ctrl)
+
+	// Создаём экземпляр обработчика
+	handler := NewHandler(&service.Service{Command: mockCommand})
+
+	// Мокируем логгер
+	mockLog := slog.New(slog.NewJSONHandler(io.Discard, nil))
+
+	// Инициализируем сервер
+	r := chi.NewRouter()
+	r.Post("/chats/{id}/commands", handler.CommandAdd(mockLog))
+	r.Get("/chats/{id}/commands", handler.CommandGet(mockLog))
+	r.Delete("/chats/{id}/commands/{commandID}", handler.CommandDelete(mockLog))
+
+	// Тестовая таблица с данными
+	testTable := []struct {
+		name                 string
+		method               string
+		path                 string
+		inputBody            string
+		mockBehaviour        mockBehaviour
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:      "Add OK",
+			method:    http.MethodPost,
+			path:      "/chats/2/commands",
+			inputBody: `{"command":"deploy","description":"Deploy a branch","url":"https://bot.example.com/deploy"}`,
+			mockBehaviour: func(s *mockService.MockCommand) {
+				s.EXPECT().AddCommand(dto.CommandAdd{Command: "deploy", Description: "Deploy a branch", URL: "https://bot.example.com/deploy"}, int64(2), 1).
+					Return(&entity.ChatCommand{ID: 3, ChatID: 2, UserID: 1, BotID: 9, Command: "deploy", Description: "Deploy a branch",
+						URL: "https://bot.example.com/deploy", Secret: "cmdsec_1", CreatedAt: "2024-01-01T00:00:00Z"}, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"status":"OK","message":"Command created successfully","command":{"id":3,"chat_id":2,"user_id":1,"bot_id":9,"command":"deploy","description":"Deploy a branch","url":"https://bot.example.com/deploy","secret":"cmdsec_1","created_at":"2024-01-01T00:00:00Z"}}`,
+		},
+		{
+			name:                 "Add without url",
+			method:               http.MethodPost,
+			path:                 "/chats/2/commands",
+			inputBody:            `{"command":"deploy"}`,
+			mockBehaviour:        func(s *mockService.MockCommand) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":"Error","code":"validation","error":"Field URL is a required field"}`,
+		},
+		{
+			name:      "Add built in",
+			method:    http.MethodPost,
+			path:      "/chats/2/commands",
+			inputBody: `{"command":"help","url":"https://bot.example.com/help"}`,
+			mockBehaviour: func(s *mockService.MockCommand) {
+				s.EXPECT().AddCommand(dto.CommandAdd{Command: "help", URL: "https://bot.example.com/help"}, int64(2), 1).
+					Return(nil, apperr.Conflict("command /help is built in"))
+			},
+			expectedStatusCode:   http.StatusConflict,
+			expectedResponseBody: `{"status":"Error","code":"conflict","error":"Failed to add command: command /help is built in"}`,
+		},
+		{
+			name:   "Get OK",
+			method: http.MethodGet,
+			path:   "/chats/2/commands",
+			mockBehaviour: func(s *mockService.MockCommand) {
+				s.EXPECT().GetCommands(int64(2), 1).
+					Return([]entity.ChatCommand{{ID: 3, ChatID: 2, UserID: 1, BotID: 9, Command: "deploy", CreatedAt: "2024-01-01T00:00:00Z"}}, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"status":"OK","message":"Commands found successfully","commands_list":[{"id":3,"chat_id":2,"user_id":1,"bot_id":9,"command":"deploy","created_at":"2024-01-01T00:00:00Z"}]}`,
+		},
+		{
+			name:   "Get empty",
+			method: http.MethodGet,
+			path:   "/chats/2/commands",
+			mockBehaviour: func(s *mockService.MockCommand) {
+				s.EXPECT().GetCommands(int64(2), 1).Return(nil, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"status":"OK","message":"No commands found"}`,
+		},
+		{
+			name:   "Delete OK",
+			method: http.MethodDelete,
+			path:   "/chats/2/commands/3",
+			mockBehaviour: func(s *mockService.MockCommand) {
+				s.EXPECT().DeleteCommand(int64(3), int64(2), 1).Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"status":"OK","message":"Command deleted successfully"}`,
+		},
+		{
+			name:                 "Delete invalid command id",
+			method:               http.MethodDelete,
+			path:                 "/chats/2/commands/abc",
+			mockBehaviour:        func(s *mockService.MockCommand) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":"Error","code":"validation","error":"Invalid commandID in path"}`,
+		},
+	}
+
+	// Итерируемся по нашей тестовой таблице
+	for _, tt := range testTable {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockBehaviour(mockCommand)
+
+			// Готовим и выполняем тестовый запрос
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(tt.method, tt.path, bytes.NewBufferString(tt.inputBody))
+			r.ServeHTTP(w, req.WithContext(context.WithValue(req.Context(), userCtx, 1)))
+
+			// Сравниваем ожидаемый и актуальный результат
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			assert.Equal(t, tt.expectedResponseBody, strings.TrimSpace(w.Body.String()))
+		})
+	}
+}
@@ -0,0 +1,240 @@
+package handler
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+
+	"service-chat/internal/apperr"
+	"service-chat/internal/db/entity"
+	"service-chat/internal/dto"
+	"service-chat/internal/logger"
+	"service-chat/internal/validate"
+)
+
+// errDraftOutdated - запись черновика не применена, на другом устройстве сохранена более новая версия
+const errDraftOutdated = "Draft version is outdated"
+
+// DraftSave - сохранить черновик сообщения в чате
+// @Summary DraftSave
+// @Security ApiKeyAuth
+// @Tags Draft
+// @Description Save message draft in chat, the write with the greater version wins. If the version is outdated, the newer draft is returned
+// @ID Save draft
+// @Accept json
+// @Produce json
+// @Param id path int true "chat id"
+// @Param input body dto.DraftSave true "draft text and client version"
+// @Success 200 {object} Response{Status, Message, Draft}
+// @Failure 400,404,405 {object} Response
+// @Failure 500 {object} Response
+// @Failure default {object} Response
+// @Router /chats/{id}/draft [put]
+func (h *Handler) DraftSave(log *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Логируем наш запрос
+		const op = "handler.DraftSave"
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		// Получаем id пользователя из контекста
+		idCtx, errCtx := GetUserID(r.Context())
+		if errCtx != nil {
+			log.Error("failed to get userID from context")
+			renderError(w, r, "", errCtx)
+			return
+		}
+
+		// Получаем id чата из пути запроса
+		chatID, errID := GetPathID(r)
+		if errID != nil {
+			log.Error("invalid chat ID")
+			renderError(w, r, "", errID)
+			return
+		}
+
+		// Структура для записи входных данных из JSON от пользователя
+		var req dto.DraftSave
+
+		// Анализируем запрос от пользователя
+		fail := validate.BaseValidate(log, r.Body, &req)
+		if fail != nil && fail.ValidateErr != nil {
+			log.Error("invalid request data")
+			renderError(w, r, "", fail.ValidateErr)
+			return
+		} else if fail != nil && fail.ErrMsg != "" {
+			log.Error("invalid request data")
+			renderError(w, r, "", apperr.Validation(fail.ErrMsg))
+			return
+		}
+
+		// Отправляем валидную структуру на слой сервиса
+		draft, saved, errSave := h.services.Draft.SaveDraft(req, chatID, idCtx)
+		if errSave != nil {
+			log.Error("failed to save draft", logger.Err(errSave))
+			renderError(w, r, "Failed to save draft", errSave)
+			return
+		}
+
+		renderDraftWrite(w, r, log, draft, saved, "Draft saved successfully")
+		return
+	}
+}
+
+// DraftGet - получить черновик сообщения в чате
+// @Summary DraftGet
+// @Security ApiKeyAuth
+// @Tags Draft
+// @Description Get message draft of the current user in chat
+// @ID Get draft
+// @Produce json
+// @Param id path int true "chat id"
+// @Success 200 {object} Response{Status, Message, Draft}
+// @Failure 400,404,405 {object} Response
+// @Failure 500 {object} Response
+// @Failure default {object} Response
+// @Router /chats/{id}/draft [get]
+func (h *Handler) DraftGet(log *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Логируем наш запрос
+		const op = "handler.DraftGet"
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		// Получаем id пользователя из контекста
+		idCtx, errCtx := GetUserID(r.Context())
+		if errCtx != nil {
+			log.Error("failed to get userID from context")
+			renderError(w, r, "", errCtx)
+			return
+		}
+
+		// Получаем id чата из пути запроса
+		chatID, errID := GetPathID(r)
+		if errID != nil {
+			log.Error("invalid chat ID")
+			renderError(w, r, "", errID)
+			return
+		}
+
+		// Отправляем запрос на слой сервиса
+		draft, errGet := h.services.Draft.GetDraft(chatID, idCtx)
+		if errGet != nil {
+			log.Error("failed to get draft", logger.Err(errGet))
+			renderError(w, r, "Failed to get draft", errGet)
+			return
+		}
+
+		// Если черновика нет
+		if draft == nil {
+			log.Info("draft not found")
+			render.JSON(w, r, OK("No draft found"))
+			return
+		}
+
+		// Если ошибок нет отправляем успешный ответ
+		log.Info("Draft found successfully", slog.Int64("chatID", chatID))
+		render.JSON(w, r, Response{
+			Status:  StatusOK,
+			Message: "Draft found successfully",
+			Draft:   draft,
+		})
+		return
+	}
+}
+
+// DraftDelete - удалить черновик сообщения в чате
+// @Summary DraftDelete
+// @Security ApiKeyAuth
+// @Tags Draft
+// @Description Delete message draft in chat, the write with the greater version wins. If the version is outdated, the newer draft is returned
+// @ID Delete draft
+// @Accept json
+// @Produce json
+// @Param id path int true "chat id"
+// @Param input body dto.DraftDelete true "client version"
+// @Success 200 {object} Response{Status, Message, Draft}
+// @Failure 400,404,405 {object} Response
+// @Failure 500 {object} Response
+// @Failure default {object} Response
+// @Router /chats/{id}/draft [delete]
+func (h *Handler) DraftDelete(log *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Логируем наш запрос
+		const op = "handler.DraftDelete"
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		// Получаем id пользователя из контекста
+		idCtx, errCtx := GetUserID(r.Context())
+		if errCtx != nil {
+			log.Error("failed to get userID from context")
+			renderError(w, r, "", errCtx)
+			return
+		}
+
+		// Получаем id чата из пути запроса
+		chatID, errID := GetPathID(r)
+		if errID != nil {
+			log.Error("invalid chat ID")
+			renderError(w, r, "", errID)
+			return
+		}
+
+		// Структура для записи входных данных из JSON от пользователя
+		var req dto.DraftDelete
+
+		// Анализируем запрос от пользователя
+		fail := validate.BaseValidate(log, r.Body, &req)
+		if fail != nil && fail.ValidateErr != nil {
+			log.Error("invalid request data")
+			renderError(w, r, "", fail.ValidateErr)
+			return
+		} else if fail != nil && fail.ErrMsg != "" {
+			log.Error("invalid request data")
+			renderError(w, r, "", apperr.Validation(fail.ErrMsg))
+			return
+		}
+
+		// Отправляем валидную структуру на слой сервиса
+		draft, deleted, errDel := h.services.Draft.DeleteDraft(req, chatID, idCtx)
+		if errDel != nil {
+			log.Error("failed to delete draft", logger.Err(errDel))
+			renderError(w, r, "Failed to delete draft", errDel)
+			return
+		}
+
+		renderDraftWrite(w, r, log, draft, deleted, "Draft deleted successfully")
+		return
+	}
+}
+
+// renderDraftWrite - ответ на запись черновика: если запись не применена, возвращаем ошибку вместе с более новым черновиком
+func renderDraftWrite(w http.ResponseWriter, r *http.Request, log *slog.Logger, draft *entity.Draft, applied bool, msg string) {
+	if !applied {
+		log.Info("draft version is outdated")
+		render.Status(r, http.StatusConflict)
+		render.JSON(w, r, Response{
+			Status: StatusError,
+			Code:   apperr.CodeConflict,
+			Error:  errDraftOutdated,
+			Draft:  draft,
+		})
+		return
+	}
+
+	log.Info(msg)
+	render.JSON(w, r, Response{
+		Status:  StatusOK,
+		Message: msg,
+		Draft:   draft,
+	})
+}
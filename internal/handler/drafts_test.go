@@ -0,0 +1,160 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"service-chat/internal/apperr"
+	"service-chat/internal/db/entity"
+	"service-chat/internal/dto"
+	"service-chat/internal/service"
+	mockService "service-chat/internal/service/mocks"
+)
+
+func TestHandler_Draft(t *testing.T) {
+	// Структура для последующей реализации поведения мока
+	type mockBehaviour func(s *mockService.MockDraft)
+
+	//Инициализируем контролер для мока сервиса
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Создаём моки сервиса черновиков
+	mockDraft := mockService.NewMockDraft(ctrl)
+
+	// Создаём экземпляр обработчика
+	handler := NewHandler(&service.Service{Draft: mockDraft})
+
+	// Мокируем логгер
+	mockLog := slog.New(slog.NewJSONHandler(io.Discard, nil))
+
+	// Инициализируем сервер
+	r := chi.NewRouter()
+	r.Put("/chats/{id}/draft", handler.DraftSave(mockLog))
+	r.Get("/chats/{id}/draft", handler.DraftGet(mockLog))
+	r.Delete("/chats/{id}/draft", handler.DraftDelete(mockLog))
+
+	// Тестовая таблица с данными
+	testTable := []struct {
+		name                 string
+		method               string
+		path                 string
+		inputBody            string
+		mockBehaviour        mockBehaviour
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:      "Save OK",
+			method:    http.MethodPut,
+			path:      "/chats/2/draft",
+			inputBody: `{"text":"hello","version":3}`,
+			mockBehaviour: func(s *mockService.MockDraft) {
+				s.EXPECT().SaveDraft(dto.DraftSave{Text: "hello", Version: 3}, int64(2), 1).
+					Return(&entity.Draft{ChatID: 2, Text: "hello", Version: 3, UpdatedAt: "2024-01-01T00:00:00Z"}, true, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"status":"OK","message":"Draft saved successfully","draft":{"chat_id":2,"text":"hello","version":3,"updated_at":"2024-01-01T00:00:00Z"}}`,
+		},
+		{
+			name:      "Save outdated version",
+			method:    http.MethodPut,
+			path:      "/chats/2/draft",
+			inputBody: `{"text":"old","version":1}`,
+			mockBehaviour: func(s *mockService.MockDraft) {
+				s.EXPECT().SaveDraft(dto.DraftSave{Text: "old", Version: 1}, int64(2), 1).
+					Return(&entity.Draft{ChatID: 2, Text: "new", Version: 3, UpdatedAt: "2024-01-01T00:00:00Z"}, false, nil)
+			},
+			expectedStatusCode:   http.StatusConflict,
+			expectedResponseBody: `{"status":"Error","code":"conflict","error":"Draft version is outdated","draft":{"chat_id":2,"text":"new","version":3,"updated_at":"2024-01-01T00:00:00Z"}}`,
+		},
+		{
+			name:                 "Save without version",
+			method:               http.MethodPut,
+			path:                 "/chats/2/draft",
+			inputBody:            `{"text":"hello"}`,
+			mockBehaviour:        func(s *mockService.MockDraft) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":"Error","code":"validation","error":"Field Version is a required field"}`,
+		},
+		{
+			name:      "Save not a member",
+			method:    http.MethodPut,
+			path:      "/chats/2/draft",
+			inputBody: `{"text":"hello","version":3}`,
+			mockBehaviour: func(s *mockService.MockDraft) {
+				s.EXPECT().SaveDraft(dto.DraftSave{Text: "hello", Version: 3}, int64(2), 1).
+					Return(nil, false, apperr.NotFound("User with userID 1 does not exist in chatID 2"))
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"status":"Error","code":"not_found","error":"Failed to save draft: User with userID 1 does not exist in chatID 2"}`,
+		},
+		{
+			name:   "Get OK",
+			method: http.MethodGet,
+			path:   "/chats/2/draft",
+			mockBehaviour: func(s *mockService.MockDraft) {
+				s.EXPECT().GetDraft(int64(2), 1).
+					Return(&entity.Draft{ChatID: 2, Text: "hello", Version: 3, UpdatedAt: "2024-01-01T00:00:00Z"}, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"status":"OK","message":"Draft found successfully","draft":{"chat_id":2,"text":"hello","version":3,"updated_at":"2024-01-01T00:00:00Z"}}`,
+		},
+		{
+			name:   "Get no draft",
+			method: http.MethodGet,
+			path:   "/chats/2/draft",
+			mockBehaviour: func(s *mockService.MockDraft) {
+				s.EXPECT().GetDraft(int64(2), 1).Return(nil, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"status":"OK","message":"No draft found"}`,
+		},
+		{
+			name:                 "Get invalid id",
+			method:               http.MethodGet,
+			path:                 "/chats/abc/draft",
+			mockBehaviour:        func(s *mockService.MockDraft) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":"Error","code":"validation","error":"Invalid id in path"}`,
+		},
+		{
+			name:      "Delete OK",
+			method:    http.MethodDelete,
+			path:      "/chats/2/draft",
+			inputBody: `{"version":4}`,
+			mockBehaviour: func(s *mockService.MockDraft) {
+				s.EXPECT().DeleteDraft(dto.DraftDelete{Version: 4}, int64(2), 1).
+					Return(&entity.Draft{ChatID: 2, Version: 4, UpdatedAt: "2024-01-01T00:00:00Z"}, true, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"status":"OK","message":"Draft deleted successfully","draft":{"chat_id":2,"text":"","version":4,"updated_at":"2024-01-01T00:00:00Z"}}`,
+		},
+	}
+
+	// Итерируемся по нашей тестовой таблице
+	for _, tt := range testTable {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockBehaviour(mockDraft)
+
+			// Готовим и выполняем тестовый запрос
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(tt.method, tt.path, bytes.NewBufferString(tt.inputBody))
+			r.ServeHTTP(w, req.WithContext(context.WithValue(req.Context(), userCtx, 1)))
+
+			// Сравниваем ожидаемый и актуальный результат
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			assert.Equal(t, tt.expectedResponseBody, strings.TrimSpace(w.Body.String()))
+		})
+	}
+}
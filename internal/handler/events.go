@@ -0,0 +1,78 @@
+package handler
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5/middleware"
+
+	"service-chat/internal/apperr"
+	"service-chat/internal/logger"
+)
+
+const (
+	lastEventIDHeader   = "Last-Event-ID"
+	errInvalidLastEvent = "Invalid Last-Event-ID header"
+)
+
+// Events - поток server-sent events с событиями всех чатов пользователя, альтернатива WebSocket
+// @Summary Events
+// @Security ApiKeyAuth
+// @Tags Realtime
+// @Description Stream the same events as /ws in text/event-stream format. Every event has an id; reconnect with
+// @Description the Last-Event-ID header to receive missed events. If they are no longer available, a resync event
+// @Description is sent and the client should reload its chats
+// @ID Events
+// @Produce text/event-stream
+// @Param Last-Event-ID header int false "id of the last received event"
+// @Success 200 {string} string "text/event-stream"
+// @Failure default {object} Response
+// @Router /events [get]
+func (h *Handler) Events(log *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Логируем наш запрос
+		const op = "handler.Events"
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		// Получаем id пользователя из контекста
+		idCtx, errCtx := GetUserID(r.Context())
+		if errCtx != nil {
+			log.Error("failed to get userID from context")
+			renderError(w, r, "", errCtx)
+			return
+		}
+
+		// Номер последнего полученного события, если клиент переподключается
+		var lastEventID uint64
+		if header := r.Header.Get(lastEventIDHeader); header != "" {
+			id, errParse := strconv.ParseUint(header, 10, 64)
+			if errParse != nil {
+				log.Error("invalid Last-Event-ID", logger.Err(errParse))
+				renderError(w, r, "", apperr.Validation(errInvalidLastEvent))
+				return
+			}
+			lastEventID = id
+		}
+
+		// Подписываемся на чаты пользователя и получаем пропущенные события
+		client, missed, errSub := h.services.Realtime.Resume(idCtx, lastEventID)
+		if errSub != nil {
+			log.Error("failed to subscribe", logger.Err(errSub))
+			renderError(w, r, "Failed to subscribe", errSub)
+			return
+		}
+		defer func() {
+			if errUnsub := h.services.Realtime.Unsubscribe(client); errUnsub != nil {
+				log.Error("failed to unsubscribe", logger.Err(errUnsub))
+			}
+		}()
+
+		log.Info("Event stream connected", slog.Int("user_id", idCtx), slog.Int("missed", len(missed)))
+		client.ServeSSE(w, r, missed)
+		log.Info("Event stream disconnected", slog.Int("user_id", idCtx))
+	}
+}
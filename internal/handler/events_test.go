@@ -0,0 +1,121 @@
+package handler
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"service-chat/internal/config"
+	"service-chat/internal/realtime"
+	"service-chat/internal/service"
+	mockService "service-chat/internal/service/mocks"
+)
+
+func TestHandler_Events(t *testing.T) {
+	//Инициализируем контролер для мока сервиса
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Хаб настоящий, мокируем только подписку пользователя на чаты
+	hub := realtime.NewHub(config.Realtime{
+		SendBuffer:   4,
+		PingInterval: time.Minute,
+		WriteTimeout: time.Second,
+		LogSize:      4,
+		LogTTL:       time.Minute,
+	})
+	mockRealtime := mockService.NewMockRealtime(ctrl)
+	handler := NewHandler(&service.Service{Realtime: mockRealtime})
+
+	// Мокируем логгер
+	mockLog := slog.New(slog.NewJSONHandler(io.Discard, nil))
+
+	// Инициализируем сервер, пользователь приходит из контекста так же, как после AuthMiddleware
+	r := chi.NewRouter()
+	r.Get("/events", func(w http.ResponseWriter, req *http.Request) {
+		handler.Events(mockLog)(w, req.WithContext(context.WithValue(req.Context(), userCtx, 1)))
+	})
+	srv := httptest.NewServer(r)
+	defer srv.Close()
+
+	// get - запрос потока с заголовком Last-Event-ID
+	get := func(lastEventID string) *http.Response {
+		req, err := http.NewRequest(http.MethodGet, srv.URL+"/events", nil)
+		require.NoError(t, err)
+		if lastEventID != "" {
+			req.Header.Set(lastEventIDHeader, lastEventID)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		return resp
+	}
+
+	t.Run("Invalid Last-Event-ID", func(t *testing.T) {
+		resp := get("abc")
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		assert.Equal(t, `{"status":"Error","code":"validation","error":"Invalid Last-Event-ID header"}`, strings.TrimSpace(string(body)))
+	})
+
+	t.Run("Subscribe error", func(t *testing.T) {
+		mockRealtime.EXPECT().Resume(1, uint64(0)).Return(nil, nil, errors.New("db error"))
+
+		resp := get("")
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+		assert.Equal(t, `{"status":"Error","code":"internal","error":"Failed to subscribe"}`, strings.TrimSpace(string(body)))
+	})
+
+	t.Run("Missed events after Last-Event-ID", func(t *testing.T) {
+		unsubscribed := make(chan struct{})
+		mockRealtime.EXPECT().Resume(1, uint64(41)).
+			Return(hubClient(t, hub), []realtime.Delivery{{ID: 42, Payload: []byte(`{"type":"message.deleted","chat_id":2}`)}}, nil)
+		mockRealtime.EXPECT().Unsubscribe(gomock.Any()).Do(func(c *realtime.Client) {
+			hub.Unregister(c)
+			close(unsubscribed)
+		})
+
+		resp := get("41")
+		assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+		reader := bufio.NewReader(resp.Body)
+		var lines []string
+		for len(lines) < 5 {
+			line, err := reader.ReadString('\n')
+			require.NoError(t, err)
+			lines = append(lines, line)
+		}
+		assert.Equal(t, []string{": ping\n", "\n", "id: 42\n", `data: {"type":"message.deleted","chat_id":2}` + "\n", "\n"}, lines)
+
+		// После отключения клиента подписка снимается
+		require.NoError(t, resp.Body.Close())
+		select {
+		case <-unsubscribed:
+		case <-time.After(5 * time.Second):
+			t.Fatal("client was not unsubscribed")
+		}
+	})
+}
+
+// hubClient - подключение пользователя к хабу, подписанное на чат 2
+func hubClient(t *testing.T, hub *realtime.Hub) *realtime.Client {
+	client, err := hub.Register(1, []int64{2})
+	require.NoError(t, err)
+	return client
+}
@@ -0,0 +1,140 @@
+package handler
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+
+	"service-chat/internal/apperr"
+	"service-chat/internal/dto"
+	"service-chat/internal/logger"
+	"service-chat/internal/validate"
+)
+
+// ChatFilterGet - действия фильтров текста сообщений в чате
+// @Summary ChatFilterGet
+// @Security ApiKeyAuth
+// @Tags Filter
+// @Description Get the message text filters of the chat (denylist, secrets, links) with their actions: off, reject, mask or flag. Overridden marks actions set by chat admins instead of the server defaults
+// @ID Get chat filters
+// @Produce json
+// @Param id path int true "chat id"
+// @Success 200 {object} Response{Status, Message, FiltersList}
+// @Failure 400,404,405 {object} Response
+// @Failure 500 {object} Response
+// @Failure default {object} Response
+// @Router /chats/{id}/filters [get]
+func (h *Handler) ChatFilterGet(log *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Логируем наш запрос
+		const op = "handler.ChatFilterGet"
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		// Получаем id пользователя из контекста
+		idCtx, errCtx := GetUserID(r.Context())
+		if errCtx != nil {
+			log.Error("failed to get userID from context")
+			renderError(w, r, "", errCtx)
+			return
+		}
+
+		// Получаем id чата из пути запроса
+		chatID, errID := GetPathID(r)
+		if errID != nil {
+			log.Error("invalid chat ID")
+			renderError(w, r, "", errID)
+			return
+		}
+
+		// Отправляем запрос на слой сервиса
+		filters, errGet := h.services.Filter.GetChatFilters(chatID, idCtx)
+		if errGet != nil {
+			log.Error("failed to get chat filters", logger.Err(errGet))
+			renderError(w, r, "Failed to get chat filters", errGet)
+			return
+		}
+
+		// Если ошибок нет отправляем успешный ответ
+		log.Info("Chat filters found successfully", slog.Int64("chatID", chatID))
+		render.JSON(w, r, Response{
+			Status:      StatusOK,
+			Message:     "Chat filters found successfully",
+			FiltersList: filters,
+		})
+		return
+	}
+}
+
+// ChatFilterSet - изменить действие фильтра текста сообщений в чате
+// @Summary ChatFilterSet
+// @Security ApiKeyAuth
+// @Tags Filter
+// @Description Override the action of a message text filter in the chat: off, reject, mask or flag. Default restores the server setting. Only for chat admins
+// @ID Set chat filter
+// @Accept json
+// @Produce json
+// @Param id path int true "chat id"
+// @Param input body dto.ChatFilterSet true "filter name and action"
+// @Success 200 {object} Response{Status, Message}
+// @Failure 400,404,405 {object} Response
+// @Failure 500 {object} Response
+// @Failure default {object} Response
+// @Router /chats/{id}/filters [put]
+func (h *Handler) ChatFilterSet(log *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Логируем наш запрос
+		const op = "handler.ChatFilterSet"
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		// Получаем id пользователя из контекста
+		idCtx, errCtx := GetUserID(r.Context())
+		if errCtx != nil {
+			log.Error("failed to get userID from context")
+			renderError(w, r, "", errCtx)
+			return
+		}
+
+		// Получаем id чата из пути запроса
+		chatID, errID := GetPathID(r)
+		if errID != nil {
+			log.Error("invalid chat ID")
+			renderError(w, r, "", errID)
+			return
+		}
+
+		// Структура для записи входных данных из JSON от пользователя
+		var req dto.ChatFilterSet
+
+		// Анализируем запрос от пользователя
+		fail := validate.BaseValidate(log, r.Body, &req)
+		if fail != nil && fail.ValidateErr != nil {
+			log.Error("invalid request data")
+			renderError(w, r, "", fail.ValidateErr)
+			return
+		} else if fail != nil && fail.ErrMsg != "" {
+			log.Error("invalid request data")
+			renderError(w, r, "", apperr.Validation(fail.ErrMsg))
+			return
+		}
+
+		// Отправляем валидную структуру на слой сервиса
+		if errSet := h.services.Filter.SetChatFilter(req, chatID, idCtx); errSet != nil {
+			log.Error("failed to set chat filter", logger.Err(errSet))
+			renderError(w, r, "Failed to set chat filter", errSet)
+			return
+		}
+
+		// Если ошибок нет отправляем успешный ответ
+		log.Info("Chat filter saved successfully", slog.Int64("chatID", chatID), slog.String("filter", req.Filter))
+		render.JSON(w, r, OK("Chat filter saved successfully"))
+		return
+	}
+}
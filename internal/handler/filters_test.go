@@ -0,0 +1,137 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"service-chat/internal/apperr"
+	"service-chat/internal/db/entity"
+	"service-chat/internal/dto"
+	"service-chat/internal/service"
+	mockService "service-chat/internal/service/mocks"
+)
+
+func TestHandler_ChatFilter(t *testing.T) {
+	// Структура для последующей реализации поведения мока
+	type mockBehaviour func(s *mockService.MockFilter)
+
+	//Инициализируем контролер для мока сервиса
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Создаём моки сервиса фильтров
+	mockFilter := mockService.NewMockFilter(ctrl)
+
+	// Создаём экземпляр обработчика
+	handler := NewHandler(&service.Service{Filter: mockFilter})
+
+	// Мокируем логгер
+	mockLog := slog.New(slog.NewJSONHandler(io.Discard, nil))
+
+	// Инициализируем сервер
+	r := chi.NewRouter()
+	r.Get("/chats/{id}/filters", handler.ChatFilterGet(mockLog))
+	r.Put("/chats/{id}/filters", handler.ChatFilterSet(mockLog))
+
+	// Тестовая таблица с данными
+	testTable := []struct {
+		name                 string
+		method               string
+		path                 string
+		inputBody            string
+		mockBehaviour        mockBehaviour
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:   "Get OK",
+			method: http.MethodGet,
+			path:   "/chats/2/filters",
+			mockBehaviour: func(s *mockService.MockFilter) {
+				s.EXPECT().GetChatFilters(int64(2), 1).Return([]entity.ChatFilter{
+					{Filter: "denylist", Action: "mask"},
+					{Filter: "secrets", Action: "flag", Overridden: true},
+				}, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"status":"OK","message":"Chat filters found successfully","filters_list":[{"filter":"denylist","action":"mask","overridden":false},{"filter":"secrets","action":"flag","overridden":true}]}`,
+		},
+		{
+			name:   "Get not member",
+			method: http.MethodGet,
+			path:   "/chats/2/filters",
+			mockBehaviour: func(s *mockService.MockFilter) {
+				s.EXPECT().GetChatFilters(int64(2), 1).Return(nil, apperr.NotFound("User with userID 1 does not exist in chatID 2"))
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"status":"Error","code":"not_found","error":"Failed to get chat filters: User with userID 1 does not exist in chatID 2"}`,
+		},
+		{
+			name:      "Set OK",
+			method:    http.MethodPut,
+			path:      "/chats/2/filters",
+			inputBody: `{"filter":"secrets","action":"flag"}`,
+			mockBehaviour: func(s *mockService.MockFilter) {
+				s.EXPECT().SetChatFilter(dto.ChatFilterSet{Filter: "secrets", Action: "flag"}, int64(2), 1).Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"status":"OK","message":"Chat filter saved successfully"}`,
+		},
+		{
+			name:                 "Set invalid action",
+			method:               http.MethodPut,
+			path:                 "/chats/2/filters",
+			inputBody:            `{"filter":"secrets","action":"drop"}`,
+			mockBehaviour:        func(s *mockService.MockFilter) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":"Error","code":"validation","error":"Field Action must be one of: off reject mask flag default"}`,
+		},
+		{
+			name:      "Set not admin",
+			method:    http.MethodPut,
+			path:      "/chats/2/filters",
+			inputBody: `{"filter":"links","action":"default"}`,
+			mockBehaviour: func(s *mockService.MockFilter) {
+				s.EXPECT().SetChatFilter(dto.ChatFilterSet{Filter: "links", Action: "default"}, int64(2), 1).
+					Return(apperr.Forbidden("Only chat admins can do this"))
+			},
+			expectedStatusCode:   http.StatusForbidden,
+			expectedResponseBody: `{"status":"Error","code":"forbidden","error":"Failed to set chat filter: Only chat admins can do this"}`,
+		},
+		{
+			name:                 "Set invalid chat id",
+			method:               http.MethodPut,
+			path:                 "/chats/abc/filters",
+			inputBody:            `{"filter":"links","action":"off"}`,
+			mockBehaviour:        func(s *mockService.MockFilter) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":"Error","code":"validation","error":"Invalid id in path"}`,
+		},
+	}
+
+	// Итерируемся по нашей тестовой таблице
+	for _, tt := range testTable {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockBehaviour(mockFilter)
+
+			// Готовим и выполняем тестовый запрос
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(tt.method, tt.path, bytes.NewBufferString(tt.inputBody))
+			r.ServeHTTP(w, req.WithContext(context.WithValue(req.Context(), userCtx, 1)))
+
+			// Сравниваем ожидаемый и актуальный результат
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			assert.Equal(t, tt.expectedResponseBody, strings.TrimSpace(w.Body.String()))
+		})
+	}
+}
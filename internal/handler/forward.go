@@ -0,0 +1,88 @@
+package handler
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+
+	"service-chat/internal/apperr"
+	"service-chat/internal/dto"
+	"service-chat/internal/logger"
+	"service-chat/internal/validate"
+)
+
+// MessageForward - переслать сообщение в другие чаты от лица пользователя
+// @Summary MessageForward
+// @Security ApiKeyAuth
+// @Tags Message
+// @Description Forward message to other chats, the user must be a member of the source and all target chats
+// @ID Forward message
+// @Accept json
+// @Produce json
+// @Param id path int true "message id"
+// @Param input body dto.MessageForward true "target chats"
+// @Success 200 {object} Response{Status, Message, ForwardedList}
+// @Failure 400,404,405 {object} Response
+// @Failure 500 {object} Response
+// @Failure default {object} Response
+// @Router /messages/{id}/forward [post]
+func (h *Handler) MessageForward(log *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Логируем наш запрос
+		const op = "handler.MessageForward"
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		// Получаем id пользователя из контекста
+		idCtx, errCtx := GetUserID(r.Context())
+		if errCtx != nil {
+			log.Error("failed to get userID from context")
+			renderError(w, r, "", errCtx)
+			return
+		}
+
+		// Получаем id сообщения из пути запроса
+		messageID, errID := GetPathID(r)
+		if errID != nil {
+			log.Error("invalid message ID")
+			renderError(w, r, "", errID)
+			return
+		}
+
+		// Структура для записи входных данных из JSON от пользователя
+		var req dto.MessageForward
+
+		// Анализируем запрос от пользователя
+		fail := validate.BaseValidate(log, r.Body, &req)
+		if fail != nil && fail.ValidateErr != nil {
+			log.Error("invalid request data")
+			renderError(w, r, "", fail.ValidateErr)
+			return
+		} else if fail != nil && fail.ErrMsg != "" {
+			log.Error("invalid request data")
+			renderError(w, r, "", apperr.Validation(fail.ErrMsg))
+			return
+		}
+
+		// Отправляем валидную структуру на слой сервиса
+		forwarded, errFwd := h.services.Message.ForwardMessage(req, messageID, idCtx)
+		if errFwd != nil {
+			log.Error("failed to forward message", logger.Err(errFwd))
+			renderError(w, r, "Failed to forward message", errFwd)
+			return
+		}
+
+		// Если ошибок нет отправляем успешный ответ
+		log.Info("Message forwarded successfully", slog.Int64("messageID", messageID))
+		render.JSON(w, r, Response{
+			Status:        StatusOK,
+			Message:       "Message forwarded successfully",
+			ForwardedList: forwarded,
+		})
+		return
+	}
+}
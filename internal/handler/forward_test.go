@@ -0,0 +1,108 @@
+package handler
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"service-chat/internal/apperr"
+	"service-chat/internal/db/entity"
+	"service-chat/internal/dto"
+	"service-chat/internal/service"
+	mockService "service-chat/internal/service/mocks"
+)
+
+func TestHandler_MessageForward(t *testing.T) {
+	// Структура для последующей реализации поведения мока
+	type mockBehaviour func(s *mockService.MockMessage)
+
+	//Инициализируем контролер для мока сервиса
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Создаём моки сервиса сообщений
+	mockMessage := mockService.NewMockMessage(ctrl)
+
+	// Создаём экземпляр обработчика
+	handler := NewHandler(&service.Service{Message: mockMessage})
+
+	// Мокируем логгер
+	mockLog := slog.New(slog.NewJSONHandler(io.Discard, nil))
+
+	// Инициализируем сервер
+	r := chi.NewRouter()
+	r.Post("/messages/{id}/forward", handler.MessageForward(mockLog))
+
+	// Тестовая таблица с данными
+	testTable := []struct {
+		name                 string
+		path                 string
+		inputBody            string
+		mockBehaviour        mockBehaviour
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:      "OK",
+			path:      "/messages/5/forward",
+			inputBody: `{"chat_ids": [2, 3]}`,
+			mockBehaviour: func(s *mockService.MockMessage) {
+				s.EXPECT().ForwardMessage(dto.MessageForward{ChatIDs: []int64{2, 3}}, int64(5), 1).
+					Return([]entity.Forwarded{{ChatID: 2, MessageID: 10}, {ChatID: 3, MessageID: 11}}, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"status":"OK","message":"Message forwarded successfully","forwarded_list":[{"chat_id":2,"message_id":10},{"chat_id":3,"message_id":11}]}`,
+		},
+		{
+			name:                 "Required chat_ids is missing",
+			path:                 "/messages/5/forward",
+			inputBody:            `{}`,
+			mockBehaviour:        func(s *mockService.MockMessage) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":"Error","code":"validation","error":"Field ChatIDs is a required field"}`,
+		},
+		{
+			name:                 "Invalid id",
+			path:                 "/messages/abc/forward",
+			inputBody:            `{"chat_ids": [2]}`,
+			mockBehaviour:        func(s *mockService.MockMessage) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":"Error","code":"validation","error":"Invalid id in path"}`,
+		},
+		{
+			name:      "Service error",
+			path:      "/messages/5/forward",
+			inputBody: `{"chat_ids": [7]}`,
+			mockBehaviour: func(s *mockService.MockMessage) {
+				s.EXPECT().ForwardMessage(dto.MessageForward{ChatIDs: []int64{7}}, int64(5), 1).
+					Return(nil, apperr.NotFound("User with userID 1 does not exist in chatID 7"))
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"status":"Error","code":"not_found","error":"Failed to forward message: User with userID 1 does not exist in chatID 7"}`,
+		},
+	}
+
+	// Итерируемся по нашей тестовой таблице
+	for _, tt := range testTable {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockBehaviour(mockMessage)
+
+			// Готовим и выполняем тестовый запрос
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, tt.path, strings.NewReader(tt.inputBody))
+			r.ServeHTTP(w, req.WithContext(context.WithValue(req.Context(), userCtx, 1)))
+
+			// Сравниваем ожидаемый и актуальный результат
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			assert.Equal(t, tt.expectedResponseBody, strings.TrimSpace(w.Body.String()))
+		})
+	}
+}
@@ -0,0 +1,266 @@
+package handler
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+
+	"service-chat/internal/apperr"
+	"service-chat/internal/dto"
+	"service-chat/internal/logger"
+	"service-chat/internal/validate"
+)
+
+const (
+	tokenParam = "token"
+	// maxIncomingBody - ограничение тела запроса входящего вебхука, ручка доступна без авторизации
+	maxIncomingBody = 1 << 20
+)
+
+// IncomingAdd - создать входящий вебхук чата
+// @Summary IncomingAdd
+// @Security ApiKeyAuth
+// @Tags Incoming webhook
+// @Description Create an incoming webhook of the chat, only chat admins can do this. External systems post messages to the returned url without a user account, messages are written by a bot member of the chat. The token is returned only in this response
+// @ID Add incoming webhook
+// @Accept json
+// @Produce json
+// @Param id path int true "chat id"
+// @Param input body dto.IncomingAdd true "webhook name, shown as the bot name"
+// @Success 200 {object} Response{Status, Message, IncomingWebhook}
+// @Failure 400,404,405 {object} Response
+// @Failure 500 {object} Response
+// @Failure default {object} Response
+// @Router /chats/{id}/incoming-webhooks [post]
+func (h *Handler) IncomingAdd(log *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Логируем наш запрос
+		const op = "handler.IncomingAdd"
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		// Получаем id пользователя из контекста
+		idCtx, errCtx := GetUserID(r.Context())
+		if errCtx != nil {
+			log.Error("failed to get userID from context")
+			renderError(w, r, "", errCtx)
+			return
+		}
+
+		// Получаем id чата из пути запроса
+		chatID, errID := GetPathID(r)
+		if errID != nil {
+			log.Error("invalid chat ID")
+			renderError(w, r, "", errID)
+			return
+		}
+
+		// Структура для записи входных данных из JSON от пользователя
+		var req dto.IncomingAdd
+
+		// Анализируем запрос от пользователя
+		fail := validate.BaseValidate(log, r.Body, &req)
+		if fail != nil && fail.ValidateErr != nil {
+			log.Error("invalid request data")
+			renderError(w, r, "", fail.ValidateErr)
+			return
+		} else if fail != nil && fail.ErrMsg != "" {
+			log.Error("invalid request data")
+			renderError(w, r, "", apperr.Validation(fail.ErrMsg))
+			return
+		}
+
+		// Отправляем валидную структуру на слой сервиса
+		hook, errAdd := h.services.Incoming.AddIncoming(req, chatID, idCtx)
+		if errAdd != nil {
+			log.Error("failed to add incoming webhook", logger.Err(errAdd))
+			renderError(w, r, "Failed to add incoming webhook", errAdd)
+			return
+		}
+
+		// Если ошибок нет отправляем успешный ответ
+		log.Info("Incoming webhook created successfully", slog.Int64("webhookID", hook.ID))
+		render.JSON(w, r, Response{
+			Status:          StatusOK,
+			Message:         "Incoming webhook created successfully",
+			IncomingWebhook: hook,
+		})
+		return
+	}
+}
+
+// IncomingGet - входящие вебхуки чата
+// @Summary IncomingGet
+// @Security ApiKeyAuth
+// @Tags Incoming webhook
+// @Description Get incoming webhooks of the chat without tokens, only chat admins can do this
+// @ID Get incoming webhooks
+// @Produce json
+// @Param id path int true "chat id"
+// @Success 200 {object} Response{Status, Message, IncomingList}
+// @Failure 400,404,405 {object} Response
+// @Failure 500 {object} Response
+// @Failure default {object} Response
+// @Router /chats/{id}/incoming-webhooks [get]
+func (h *Handler) IncomingGet(log *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Логируем наш запрос
+		const op = "handler.IncomingGet"
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		// Получаем id пользователя из контекста
+		idCtx, errCtx := GetUserID(r.Context())
+		if errCtx != nil {
+			log.Error("failed to get userID from context")
+			renderError(w, r, "", errCtx)
+			return
+		}
+
+		// Получаем id чата из пути запроса
+		chatID, errID := GetPathID(r)
+		if errID != nil {
+			log.Error("invalid chat ID")
+			renderError(w, r, "", errID)
+			return
+		}
+
+		// Отправляем запрос на слой сервиса
+		hooks, errGet := h.services.Incoming.GetIncoming(chatID, idCtx)
+		if errGet != nil {
+			log.Error("failed to get incoming webhooks", logger.Err(errGet))
+			renderError(w, r, "Failed to get incoming webhooks", errGet)
+			return
+		}
+
+		// Если вебхуков нет
+		if len(hooks) == 0 {
+			log.Info("incoming webhooks not found")
+			render.JSON(w, r, OK("No incoming webhooks found"))
+			return
+		}
+
+		// Если ошибок нет отправляем успешный ответ
+		log.Info("Incoming webhooks found successfully", slog.Int("count", len(hooks)))
+		render.JSON(w, r, Response{
+			Status:       StatusOK,
+			Message:      "Incoming webhooks found successfully",
+			IncomingList: hooks,
+		})
+		return
+	}
+}
+
+// IncomingDelete - удалить входящий вебхук чата
+// @Summary IncomingDelete
+// @Security ApiKeyAuth
+// @Tags Incoming webhook
+// @Description Delete an incoming webhook of the chat, only chat admins can do this. Messages of the webhook stay in the chat
+// @ID Delete incoming webhook
+// @Produce json
+// @Param id path int true "chat id"
+// @Param webhookID path int true "incoming webhook id"
+// @Success 200 {object} Response{Status, Message}
+// @Failure 400,404,405 {object} Response
+// @Failure 500 {object} Response
+// @Failure default {object} Response
+// @Router /chats/{id}/incoming-webhooks/{webhookID} [delete]
+func (h *Handler) IncomingDelete(log *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Логируем наш запрос
+		const op = "handler.IncomingDelete"
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		// Получаем id пользователя из контекста
+		idCtx, errCtx := GetUserID(r.Context())
+		if errCtx != nil {
+			log.Error("failed to get userID from context")
+			renderError(w, r, "", errCtx)
+			return
+		}
+
+		// Получаем id чата и вебхука из пути запроса
+		chatID, webhookID, errID := webhookPath(r)
+		if errID != nil {
+			log.Error("invalid chat or webhook ID")
+			renderError(w, r, "", errID)
+			return
+		}
+
+		// Отправляем запрос на слой сервиса
+		if errDel := h.services.Incoming.DeleteIncoming(webhookID, chatID, idCtx); errDel != nil {
+			log.Error("failed to delete incoming webhook", logger.Err(errDel))
+			renderError(w, r, "Failed to delete incoming webhook", errDel)
+			return
+		}
+
+		// Если ошибок нет отправляем успешный ответ
+		log.Info("Incoming webhook deleted successfully", slog.Int64("webhookID", webhookID))
+		render.JSON(w, r, OK("Incoming webhook deleted successfully"))
+		return
+	}
+}
+
+// IncomingPost - сообщение внешней системы через входящий вебхук
+// @Summary IncomingPost
+// @Tags Incoming webhook
+// @Description Post a message to the chat of the incoming webhook, the token in the url authorizes the request. Accepts a simple payload {"text": "...", "format": "markdown"} or a Slack-compatible payload with text, blocks and attachments (e.g. Alertmanager slack_configs). Messages are rate limited per webhook
+// @ID Post incoming webhook
+// @Accept json
+// @Produce json
+// @Param token path string true "incoming webhook token"
+// @Param input body dto.IncomingMessage true "message payload"
+// @Success 200 {object} Response{Status, Message}
+// @Failure 400,404,405 {object} Response
+// @Failure 500 {object} Response
+// @Failure default {object} Response
+// @Router /hooks/{token} [post]
+func (h *Handler) IncomingPost(log *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Логируем наш запрос, токен в лог не пишем
+		const op = "handler.IncomingPost"
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		// Структура для записи входных данных из JSON от внешней системы
+		var req dto.IncomingMessage
+
+		// Анализируем запрос, размер тела ограничен
+		fail := validate.BaseValidate(log, http.MaxBytesReader(w, r.Body, maxIncomingBody), &req)
+		if fail != nil && fail.ValidateErr != nil {
+			log.Error("invalid request data")
+			renderError(w, r, "", fail.ValidateErr)
+			return
+		} else if fail != nil && fail.ErrMsg != "" {
+			log.Error("invalid request data")
+			renderError(w, r, "", apperr.Validation(fail.ErrMsg))
+			return
+		}
+
+		// Отправляем валидную структуру на слой сервиса
+		messageID, errPost := h.services.Incoming.PostIncoming(chi.URLParam(r, tokenParam), req)
+		if errPost != nil {
+			log.Error("failed to post incoming webhook message", logger.Err(errPost))
+			renderError(w, r, "Failed to post message", errPost)
+			return
+		}
+
+		// Если ошибок нет отправляем успешный ответ
+		log.Info("Incoming webhook message created successfully", slog.Int("messageID", messageID))
+		render.JSON(w, r, OK(fmt.Sprintf("Message created successfully, id: %d", messageID)))
+		return
+	}
+}
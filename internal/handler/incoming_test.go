@@ -0,0 +1,191 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"service-chat/internal/apperr"
+	"service-chat/internal/db/entity"
+	"service-chat/internal/dto"
+	"service-chat/internal/service"
+	mockService "service-chat/internal/service/mocks"
+)
+
+func TestHandler_Incoming(t *testing.T) {
+	// Структура для последующей реализации поведения мока
+	type mockBehaviour func(s *mockService.MockIncoming)
+
+	//Инициализируем контролер для мока сервиса
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Создаём моки сервиса входящих вебхуков
+	mockIncoming := mockService.NewMockIncoming(ctrl)
+
+	// Создаём экземпляр обработчика
+	handler := NewHandler(&service.Service{Incoming: mockIncoming})
+
+	// Мокируем логгер
+	mockLog := slog.New(slog.NewJSONHandler(io.Discard, nil))
+
+	// Инициализируем сервер
+	r := chi.NewRouter()
+	r.Post("/chats/{id}/incoming-webhooks", handler.IncomingAdd(mockLog))
+	r.Get("/chats/{id}/incoming-webhooks", handler.IncomingGet(mockLog))
+	r.Delete("/chats/{id}/incoming-webhooks/{webhookID}", handler.IncomingDelete(mockLog))
+	r.Post("/hooks/{token}", handler.IncomingPost(mockLog))
+
+	// Тестовая таблица с данными
+	testTable := []struct {
+		name                 string
+		method               string
+		path                 string
+		inputBody            string
+		mockBehaviour        mockBehaviour
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:      "Add OK",
+			method:    http.MethodPost,
+			path:      "/chats/2/incoming-webhooks",
+			inputBody: `{"name":"alertmanager"}`,
+			mockBehaviour: func(s *mockService.MockIncoming) {
+				s.EXPECT().AddIncoming(dto.IncomingAdd{Name: "alertmanager"}, int64(2), 1).
+					Return(&entity.IncomingWebhook{ID: 3, ChatID: 2, UserID: 1, BotID: 9, Name: "alertmanager",
+						Token: "ihk_1", URL: "/hooks/ihk_1", CreatedAt: "2024-01-01T00:00:00Z"}, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"status":"OK","message":"Incoming webhook created successfully","incoming_webhook":{"id":3,"chat_id":2,"user_id":1,"bot_id":9,"name":"alertmanager","token":"ihk_1","url":"/hooks/ihk_1","created_at":"2024-01-01T00:00:00Z"}}`,
+		},
+		{
+			name:                 "Add without name",
+			method:               http.MethodPost,
+			path:                 "/chats/2/incoming-webhooks",
+			inputBody:            `{}`,
+			mockBehaviour:        func(s *mockService.MockIncoming) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":"Error","code":"validation","error":"Field Name is a required field"}`,
+		},
+		{
+			name:      "Add not an admin",
+			method:    http.MethodPost,
+			path:      "/chats/2/incoming-webhooks",
+			inputBody: `{"name":"alertmanager"}`,
+			mockBehaviour: func(s *mockService.MockIncoming) {
+				s.EXPECT().AddIncoming(dto.IncomingAdd{Name: "alertmanager"}, int64(2), 1).
+					Return(nil, apperr.Forbidden("Only chat admins can do this"))
+			},
+			expectedStatusCode:   http.StatusForbidden,
+			expectedResponseBody: `{"status":"Error","code":"forbidden","error":"Failed to add incoming webhook: Only chat admins can do this"}`,
+		},
+		{
+			name:   "Get OK",
+			method: http.MethodGet,
+			path:   "/chats/2/incoming-webhooks",
+			mockBehaviour: func(s *mockService.MockIncoming) {
+				s.EXPECT().GetIncoming(int64(2), 1).
+					Return([]entity.IncomingWebhook{{ID: 3, ChatID: 2, UserID: 1, BotID: 9, Name: "alertmanager", CreatedAt: "2024-01-01T00:00:00Z"}}, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"status":"OK","message":"Incoming webhooks found successfully","incoming_list":[{"id":3,"chat_id":2,"user_id":1,"bot_id":9,"name":"alertmanager","created_at":"2024-01-01T00:00:00Z"}]}`,
+		},
+		{
+			name:   "Get empty",
+			method: http.MethodGet,
+			path:   "/chats/2/incoming-webhooks",
+			mockBehaviour: func(s *mockService.MockIncoming) {
+				s.EXPECT().GetIncoming(int64(2), 1).Return(nil, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"status":"OK","message":"No incoming webhooks found"}`,
+		},
+		{
+			name:   "Delete OK",
+			method: http.MethodDelete,
+			path:   "/chats/2/incoming-webhooks/3",
+			mockBehaviour: func(s *mockService.MockIncoming) {
+				s.EXPECT().DeleteIncoming(int64(3), int64(2), 1).Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"status":"OK","message":"Incoming webhook deleted successfully"}`,
+		},
+		{
+			name:                 "Delete invalid webhook id",
+			method:               http.MethodDelete,
+			path:                 "/chats/2/incoming-webhooks/abc",
+			mockBehaviour:        func(s *mockService.MockIncoming) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":"Error","code":"validation","error":"Invalid webhookID in path"}`,
+		},
+		{
+			name:      "Post OK",
+			method:    http.MethodPost,
+			path:      "/hooks/ihk_1",
+			inputBody: `{"text":"Deploy finished","format":"markdown"}`,
+			mockBehaviour: func(s *mockService.MockIncoming) {
+				s.EXPECT().PostIncoming("ihk_1", dto.IncomingMessage{Text: "Deploy finished", Format: "markdown"}).Return(11, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"status":"OK","message":"Message created successfully, id: 11"}`,
+		},
+		{
+			name:      "Post Slack payload",
+			method:    http.MethodPost,
+			path:      "/hooks/ihk_1",
+			inputBody: `{"attachments":[{"title":"[FIRING:1] HighLoad","text":"cpu 95%"}]}`,
+			mockBehaviour: func(s *mockService.MockIncoming) {
+				s.EXPECT().PostIncoming("ihk_1", dto.IncomingMessage{Attachments: []dto.IncomingAttachment{{Title: "[FIRING:1] HighLoad", Text: "cpu 95%"}}}).
+					Return(12, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"status":"OK","message":"Message created successfully, id: 12"}`,
+		},
+		{
+			name:                 "Post invalid format",
+			method:               http.MethodPost,
+			path:                 "/hooks/ihk_1",
+			inputBody:            `{"text":"hi","format":"html"}`,
+			mockBehaviour:        func(s *mockService.MockIncoming) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":"Error","code":"validation","error":"Field Format must be one of: plain markdown"}`,
+		},
+		{
+			name:      "Post rate limited",
+			method:    http.MethodPost,
+			path:      "/hooks/ihk_1",
+			inputBody: `{"text":"hi"}`,
+			mockBehaviour: func(s *mockService.MockIncoming) {
+				s.EXPECT().PostIncoming("ihk_1", dto.IncomingMessage{Text: "hi"}).Return(0, service.ErrRateLimited)
+			},
+			expectedStatusCode:   http.StatusTooManyRequests,
+			expectedResponseBody: `{"status":"Error","code":"rate_limited","error":"Failed to post message: Rate limit exceeded, retry later"}`,
+		},
+	}
+
+	// Итерируемся по нашей тестовой таблице
+	for _, tt := range testTable {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockBehaviour(mockIncoming)
+
+			// Готовим и выполняем тестовый запрос
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(tt.method, tt.path, bytes.NewBufferString(tt.inputBody))
+			r.ServeHTTP(w, req.WithContext(context.WithValue(req.Context(), userCtx, 1)))
+
+			// Сравниваем ожидаемый и актуальный результат
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			assert.Equal(t, tt.expectedResponseBody, strings.TrimSpace(w.Body.String()))
+		})
+	}
+}
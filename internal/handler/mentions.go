@@ -0,0 +1,107 @@
+package handler
+
+import (
+	"log/slog"
+	"net/http"
+	"net/url"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+
+	"service-chat/internal/apperr"
+	"service-chat/internal/dto"
+	"service-chat/internal/logger"
+	"service-chat/internal/validate"
+)
+
+// MentionGet - лента сообщений, в которых упомянут пользователь
+// @Summary MentionGet
+// @Security ApiKeyAuth
+// @Tags Mention
+// @Description Get messages where the current user is mentioned, newest first
+// @ID Get mentions
+// @Produce json
+// @Param limit query int false "page size, 1-100, default 20"
+// @Param offset query int false "number of messages to skip"
+// @Success 200 {object} Response{Status, Message, MessagesList}
+// @Failure 400,404,405 {object} Response
+// @Failure 500 {object} Response
+// @Failure default {object} Response
+// @Router /users/me/mentions [get]
+func (h *Handler) MentionGet(log *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Логируем наш запрос
+		const op = "handler.MentionGet"
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		// Получаем id пользователя из контекста
+		idCtx, errCtx := GetUserID(r.Context())
+		if errCtx != nil {
+			log.Error("failed to get userID from context")
+			renderError(w, r, "", errCtx)
+			return
+		}
+
+		// Заполняем структуру запроса из query параметров
+		req, errParse := parseMentionGet(r.URL.Query())
+		if errParse != nil {
+			log.Error("invalid query parameters", logger.Err(errParse))
+			renderError(w, r, "", errParse)
+			return
+		}
+
+		// Анализируем запрос от пользователя
+		fail := validate.StructValidate(log, &req)
+		if fail != nil && fail.ValidateErr != nil {
+			log.Error("invalid request data")
+			renderError(w, r, "", fail.ValidateErr)
+			return
+		} else if fail != nil && fail.ErrMsg != "" {
+			log.Error("invalid request data")
+			renderError(w, r, "", apperr.Validation(fail.ErrMsg))
+			return
+		}
+
+		// Отправляем валидную структуру на слой сервиса
+		messages, errGet := h.services.Mention.GetMentions(req, idCtx)
+		if errGet != nil {
+			log.Error("failed to get mentions", logger.Err(errGet))
+			renderError(w, r, "Failed to get mentions", errGet)
+			return
+		}
+
+		// Если упоминаний нет
+		if len(messages) == 0 {
+			log.Info("mentions not found")
+			render.JSON(w, r, OK("No mentions found"))
+			return
+		}
+
+		// Если ошибок нет отправляем успешный ответ
+		log.Info("Mentions found successfully", slog.Int("count", len(messages)))
+		render.JSON(w, r, Response{
+			Status:       StatusOK,
+			Message:      "Mentions found successfully",
+			MessagesList: messages,
+		})
+		return
+	}
+}
+
+// parseMentionGet - разбираем query параметры ленты упоминаний
+func parseMentionGet(values url.Values) (dto.MentionGet, error) {
+	var req dto.MentionGet
+	var err error
+
+	if req.Limit, err = queryInt64(values, "limit"); err != nil {
+		return req, err
+	}
+	if req.Offset, err = queryInt64(values, "offset"); err != nil {
+		return req, err
+	}
+
+	return req, nil
+}
@@ -0,0 +1,110 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"service-chat/internal/db/entity"
+	"service-chat/internal/dto"
+	"service-chat/internal/service"
+	mockService "service-chat/internal/service/mocks"
+)
+
+func TestHandler_MentionGet(t *testing.T) {
+	// Структура для последующей реализации поведения мока
+	type mockBehaviour func(s *mockService.MockMention)
+
+	//Инициализируем контролер для мока сервиса
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Создаём моки сервиса упоминаний
+	mockMention := mockService.NewMockMention(ctrl)
+
+	// Создаём экземпляр обработчика
+	handler := NewHandler(&service.Service{Mention: mockMention})
+
+	// Мокируем логгер
+	mockLog := slog.New(slog.NewJSONHandler(io.Discard, nil))
+
+	// Инициализируем сервер
+	r := chi.NewRouter()
+	r.Get("/users/me/mentions", handler.MentionGet(mockLog))
+
+	// Тестовая таблица с данными
+	testTable := []struct {
+		name                 string
+		query                string
+		mockBehaviour        mockBehaviour
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:  "OK",
+			query: "?limit=1",
+			mockBehaviour: func(s *mockService.MockMention) {
+				s.EXPECT().GetMentions(dto.MentionGet{Limit: 1}, 1).
+					Return([]entity.Message{{
+						Id:       5,
+						Text:     "@alex hi",
+						UserID:   2,
+						ChatID:   3,
+						Mentions: []entity.Mention{{UserID: 1, Username: "alex", Offset: 0, Length: 5}},
+					}}, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"status":"OK","message":"Mentions found successfully","messages_list":[{"id":5,"text":"@alex hi","user_id":2,"created_at":"","is_deleted":false,"mentions":[{"user_id":1,"username":"alex","offset":0,"length":5}],"chat_id":3}]}`,
+		},
+		{
+			name:  "No mentions",
+			query: "",
+			mockBehaviour: func(s *mockService.MockMention) {
+				s.EXPECT().GetMentions(dto.MentionGet{}, 1).Return(nil, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"status":"OK","message":"No mentions found"}`,
+		},
+		{
+			name:                 "Invalid offset",
+			query:                "?offset=abc",
+			mockBehaviour:        func(s *mockService.MockMention) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":"Error","code":"validation","error":"Invalid query parameter offset"}`,
+		},
+		{
+			name:  "Service error",
+			query: "?offset=20",
+			mockBehaviour: func(s *mockService.MockMention) {
+				s.EXPECT().GetMentions(dto.MentionGet{Offset: 20}, 1).Return(nil, errors.New("some error"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: `{"status":"Error","code":"internal","error":"Failed to get mentions"}`,
+		},
+	}
+
+	// Итерируемся по нашей тестовой таблице
+	for _, tt := range testTable {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockBehaviour(mockMention)
+
+			// Готовим и выполняем тестовый запрос
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/users/me/mentions"+tt.query, nil)
+			r.ServeHTTP(w, req.WithContext(context.WithValue(req.Context(), userCtx, 1)))
+
+			// Сравниваем ожидаемый и актуальный результат
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			assert.Equal(t, tt.expectedResponseBody, strings.TrimSpace(w.Body.String()))
+		})
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/render"
 
+	"service-chat/internal/apperr"
 	"service-chat/internal/dto"
 	"service-chat/internal/logger"
 	"service-chat/internal/validate"
@@ -17,12 +18,12 @@ import (
 // @Summary MessageAdd
 // @Security ApiKeyAuth
 // @Tags Message
-// @Description Send message
+// @Description Send message, with send_at the message is scheduled and delivered at that time. A message starting with /name is executed as a chat command (/help lists them) and is not posted, the command reply is visible only to the sender. Start the text with // to post it with a leading /
 // @ID Send message
 // @Accept json
 // @Produce json
 // @Param input body dto.MessageAdd true "message info"
-// @Success 200 {object} Response
+// @Success 200 {object} Response{Status, Message, CommandReply}
 // @Failure 400,404,405 {object} Response
 // @Failure 500 {object} Response
 // @Failure default {object} Response
@@ -31,7 +32,7 @@ func (h *Handler) MessageAdd(log *slog.Logger) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		// Логируем наш запрос
 		const op = "handler.MessageAdd"
-		log = log.With(
+		log := log.With(
 			slog.String("op", op),
 			slog.String("request_id", middleware.GetReqID(r.Context())),
 		)
@@ -40,7 +41,7 @@ func (h *Handler) MessageAdd(log *slog.Logger) http.HandlerFunc {
 		idCtx, errCtx := GetUserID(r.Context())
 		if errCtx != nil {
 			log.Error("failed to get userID from context")
-			render.JSON(w, r, Error(errCtx.Error()))
+			renderError(w, r, "", errCtx)
 			return
 		}
 
@@ -51,26 +52,51 @@ func (h *Handler) MessageAdd(log *slog.Logger) http.HandlerFunc {
 		fail := validate.BaseValidate(log, r.Body, &req)
 		if fail != nil && fail.ValidateErr != nil {
 			log.Error("invalid request data")
-			render.JSON(w, r, ValidationError(fail.ValidateErr))
+			renderError(w, r, "", fail.ValidateErr)
 			return
 		} else if fail != nil && fail.ErrMsg != "" {
 			log.Error("invalid request data")
-			render.JSON(w, r, Error(fail.ErrMsg))
+			renderError(w, r, "", apperr.Validation(fail.ErrMsg))
 			return
 		}
 
 		// Проверяем, что id из контекста совпадает с id из запроса
 		if int64(idCtx) != req.UserID {
 			log.Error("invalid user ID")
-			render.JSON(w, r, Error("Invalid user ID"))
+			renderError(w, r, "", apperr.Forbidden("Invalid user ID"))
+			return
+		}
+
+		// Если задано время отправки, сообщение откладывается и не появится в чате до этого времени
+		if req.SendAt != nil {
+			scheduledID, errSch := h.services.Scheduled.ScheduleMessage(req)
+			if errSch != nil {
+				log.Error("failed to schedule message", logger.Err(errSch))
+				renderError(w, r, "Failed to schedule message", errSch)
+				return
+			}
+
+			log.Info("Message scheduled successfully", slog.Int64("scheduledID", scheduledID))
+			render.JSON(w, r, OK(fmt.Sprintf("Message scheduled successfully, id: %d", scheduledID)))
 			return
 		}
 
 		// Отправляем валидную структуру на слой сервиса
-		messageID, errMsg := h.services.Message.AddMessage(req)
+		messageID, reply, errMsg := h.services.Message.AddMessage(req)
 		if errMsg != nil {
 			log.Error("failed to add message", logger.Err(errMsg))
-			render.JSON(w, r, Error(fmt.Sprintf("Failed to create message: %s", errMsg)))
+			renderError(w, r, "Failed to create message", errMsg)
+			return
+		}
+
+		// Сообщение оказалось командой, её ответ видит только автор
+		if reply != nil {
+			log.Info("Command executed successfully", slog.String("command", reply.Command))
+			render.JSON(w, r, Response{
+				Status:       StatusOK,
+				Message:      "Command executed successfully",
+				CommandReply: reply,
+			})
 			return
 		}
 
@@ -99,7 +125,7 @@ func (h *Handler) MessageGet(log *slog.Logger) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		// Логируем наш запрос
 		const op = "handler.MessageGet"
-		log = log.With(
+		log := log.With(
 			slog.String("op", op),
 			slog.String("request_id", middleware.GetReqID(r.Context())),
 		)
@@ -108,7 +134,7 @@ func (h *Handler) MessageGet(log *slog.Logger) http.HandlerFunc {
 		idCtx, errCtx := GetUserID(r.Context())
 		if errCtx != nil {
 			log.Error("failed to get userID from context")
-			render.JSON(w, r, Error(errCtx.Error()))
+			renderError(w, r, "", errCtx)
 			return
 		}
 
@@ -119,11 +145,11 @@ func (h *Handler) MessageGet(log *slog.Logger) http.HandlerFunc {
 		fail := validate.BaseValidate(log, r.Body, &req)
 		if fail != nil && fail.ValidateErr != nil {
 			log.Error("invalid request data")
-			render.JSON(w, r, ValidationError(fail.ValidateErr))
+			renderError(w, r, "", fail.ValidateErr)
 			return
 		} else if fail != nil && fail.ErrMsg != "" {
 			log.Error("invalid request data")
-			render.JSON(w, r, Error(fail.ErrMsg))
+			renderError(w, r, "", apperr.Validation(fail.ErrMsg))
 			return
 		}
 
@@ -131,7 +157,7 @@ func (h *Handler) MessageGet(log *slog.Logger) http.HandlerFunc {
 		messages, errMsg := h.services.Message.GetMessage(req, idCtx)
 		if errMsg != nil {
 			log.Error("failed to get messages", logger.Err(errMsg))
-			render.JSON(w, r, Error(fmt.Sprintf("Failed to get messages: %s", errMsg)))
+			renderError(w, r, "Failed to get messages", errMsg)
 			return
 		}
 
@@ -171,7 +197,7 @@ func (h *Handler) MessageUpdate(log *slog.Logger) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		// Логируем наш запрос
 		const op = "handler.MessageUpdate"
-		log = log.With(
+		log := log.With(
 			slog.String("op", op),
 			slog.String("request_id", middleware.GetReqID(r.Context())),
 		)
@@ -180,7 +206,7 @@ func (h *Handler) MessageUpdate(log *slog.Logger) http.HandlerFunc {
 		idCtx, errCtx := GetUserID(r.Context())
 		if errCtx != nil {
 			log.Error("failed to get userID from context")
-			render.JSON(w, r, Error(errCtx.Error()))
+			renderError(w, r, "", errCtx)
 			return
 		}
 
@@ -191,18 +217,18 @@ func (h *Handler) MessageUpdate(log *slog.Logger) http.HandlerFunc {
 		fail := validate.BaseValidate(log, r.Body, &req)
 		if fail != nil && fail.ValidateErr != nil {
 			log.Error("invalid request data")
-			render.JSON(w, r, ValidationError(fail.ValidateErr))
+			renderError(w, r, "", fail.ValidateErr)
 			return
 		} else if fail != nil && fail.ErrMsg != "" {
 			log.Error("invalid request data")
-			render.JSON(w, r, Error(fail.ErrMsg))
+			renderError(w, r, "", apperr.Validation(fail.ErrMsg))
 			return
 		}
 
 		// Проверяем, что id из контекста совпадает с id из запроса
 		if int64(idCtx) != req.UserID {
 			log.Error("invalid user ID")
-			render.JSON(w, r, Error("Invalid user ID"))
+			renderError(w, r, "", apperr.Forbidden("Invalid user ID"))
 			return
 		}
 
@@ -210,7 +236,7 @@ func (h *Handler) MessageUpdate(log *slog.Logger) http.HandlerFunc {
 		messageID, errMsg := h.services.Message.UpdateMessage(req)
 		if errMsg != nil {
 			log.Error("failed to update message", logger.Err(errMsg))
-			render.JSON(w, r, Error(fmt.Sprintf("Failed to update message: %s", errMsg)))
+			renderError(w, r, "Failed to update message", errMsg)
 			return
 		}
 
@@ -239,7 +265,7 @@ func (h *Handler) MessageDelete(log *slog.Logger) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		// Логируем наш запрос
 		const op = "handler.MessageDelete"
-		log = log.With(
+		log := log.With(
 			slog.String("op", op),
 			slog.String("request_id", middleware.GetReqID(r.Context())),
 		)
@@ -248,7 +274,7 @@ func (h *Handler) MessageDelete(log *slog.Logger) http.HandlerFunc {
 		idCtx, errCtx := GetUserID(r.Context())
 		if errCtx != nil {
 			log.Error("failed to get userID from context")
-			render.JSON(w, r, Error(errCtx.Error()))
+			renderError(w, r, "", errCtx)
 			return
 		}
 
@@ -259,11 +285,11 @@ func (h *Handler) MessageDelete(log *slog.Logger) http.HandlerFunc {
 		fail := validate.BaseValidate(log, r.Body, &req)
 		if fail != nil && fail.ValidateErr != nil {
 			log.Error("invalid request data")
-			render.JSON(w, r, ValidationError(fail.ValidateErr))
+			renderError(w, r, "", fail.ValidateErr)
 			return
 		} else if fail != nil && fail.ErrMsg != "" {
 			log.Error("invalid request data")
-			render.JSON(w, r, Error(fail.ErrMsg))
+			renderError(w, r, "", apperr.Validation(fail.ErrMsg))
 			return
 		}
 
@@ -271,7 +297,7 @@ func (h *Handler) MessageDelete(log *slog.Logger) http.HandlerFunc {
 		msgDel, errMsg := h.services.Message.DeleteMessage(req, idCtx)
 		if errMsg != nil {
 			log.Error("failed to delete message", logger.Err(errMsg))
-			render.JSON(w, r, Error(fmt.Sprintf("Failed to delete message: %s", errMsg)))
+			renderError(w, r, "Failed to delete message", errMsg)
 			return
 		}
 
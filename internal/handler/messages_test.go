@@ -66,86 +66,123 @@ func TestHandler_MessageAdd(t *testing.T) {
 				Text:   "msg1",
 			},
 			mockBehaviour: func(s *mockService.MockMessage, message dto.MessageAdd) {
-				s.EXPECT().AddMessage(message).Return(1, nil)
+				s.EXPECT().AddMessage(message).Return(1, nil, nil)
 			},
 			expectedStatusCode:   http.StatusOK,
 			expectedResponseBody: `{"status":"OK","message":"Message created successfully, id: 1"}`,
 		},
+		{
+			name:      "OK markdown",
+			inputBody: `{"chat_id": 1,"user_id": 1,"text": "**msg1**","format": "markdown"}`,
+			inputMessage: dto.MessageAdd{
+				ChatID: 1,
+				UserID: 1,
+				Text:   "**msg1**",
+				Format: "markdown",
+			},
+			mockBehaviour: func(s *mockService.MockMessage, message dto.MessageAdd) {
+				s.EXPECT().AddMessage(message).Return(1, nil, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"status":"OK","message":"Message created successfully, id: 1"}`,
+		},
+		{
+			name:      "Command",
+			inputBody: `{"chat_id": 1,"user_id": 1,"text": "/remind 30m stand-up"}`,
+			inputMessage: dto.MessageAdd{
+				ChatID: 1,
+				UserID: 1,
+				Text:   "/remind 30m stand-up",
+			},
+			mockBehaviour: func(s *mockService.MockMessage, message dto.MessageAdd) {
+				s.EXPECT().AddMessage(message).
+					Return(0, &entity.CommandReply{Command: "remind", Text: "Reminder scheduled for 2024-01-01T00:30:00Z, id: 5"}, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"status":"OK","message":"Command executed successfully","command_reply":{"command":"remind","text":"Reminder scheduled for 2024-01-01T00:30:00Z, id: 5"}}`,
+		},
+		{
+			name:                 "Invalid format",
+			inputBody:            `{"chat_id": 1,"user_id": 1,"text": "msg1","format": "html"}`,
+			mockBehaviour:        func(s *mockService.MockMessage, message dto.MessageAdd) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":"Error","code":"validation","error":"Field Format must be one of: plain markdown"}`,
+		},
 		{
 			name:                 "Required field chat_id is missing",
 			inputBody:            `{"user_id": 1,"text": "msg1"}`,
 			mockBehaviour:        func(s *mockService.MockMessage, message dto.MessageAdd) {},
-			expectedStatusCode:   http.StatusOK,
-			expectedResponseBody: `{"status":"Error","error":"Field ChatID is a required field"}`,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":"Error","code":"validation","error":"Field ChatID is a required field"}`,
 		},
 		{
 			name:                 "Required field user_id is missing",
 			inputBody:            `{"chat_id": 1,"text": "msg1"}`,
 			mockBehaviour:        func(s *mockService.MockMessage, message dto.MessageAdd) {},
-			expectedStatusCode:   http.StatusOK,
-			expectedResponseBody: `{"status":"Error","error":"Field UserID is a required field"}`,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":"Error","code":"validation","error":"Field UserID is a required field"}`,
 		},
 		{
 			name:                 "Required field text is missing",
 			inputBody:            `{"chat_id": 1,"user_id": 1}`,
 			mockBehaviour:        func(s *mockService.MockMessage, message dto.MessageAdd) {},
-			expectedStatusCode:   http.StatusOK,
-			expectedResponseBody: `{"status":"Error","error":"Field Text is a required field"}`,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":"Error","code":"validation","error":"Field Text is a required field"}`,
 		},
 		{
 			name:                 "Empty chat_id",
 			inputBody:            `{"chat_id": ,"user_id": 1,"text": "msg1"}`,
 			mockBehaviour:        func(s *mockService.MockMessage, message dto.MessageAdd) {},
-			expectedStatusCode:   http.StatusOK,
-			expectedResponseBody: `{"status":"Error","error":"Invalid request"}`,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":"Error","code":"validation","error":"Invalid request"}`,
 		},
 		{
 			name:                 "Empty user_id",
 			inputBody:            `{"chat_id": 1,"user_id": ,"text": "msg1"}`,
 			mockBehaviour:        func(s *mockService.MockMessage, message dto.MessageAdd) {},
-			expectedStatusCode:   http.StatusOK,
-			expectedResponseBody: `{"status":"Error","error":"Invalid request"}`,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":"Error","code":"validation","error":"Invalid request"}`,
 		},
 		{
 			name:                 "Empty text",
 			inputBody:            `{"chat_id": 1,"user_id": 1,"text": }`,
 			mockBehaviour:        func(s *mockService.MockMessage, message dto.MessageAdd) {},
-			expectedStatusCode:   http.StatusOK,
-			expectedResponseBody: `{"status":"Error","error":"Invalid request"}`,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":"Error","code":"validation","error":"Invalid request"}`,
 		},
 		{
 			name:                 "Zero chat_id",
 			inputBody:            `{"chat_id": 0,"user_id": 1,"text": "msg1"}`,
 			mockBehaviour:        func(s *mockService.MockMessage, message dto.MessageAdd) {},
-			expectedStatusCode:   http.StatusOK,
-			expectedResponseBody: `{"status":"Error","error":"Field ChatID is a required field"}`,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":"Error","code":"validation","error":"Field ChatID is a required field"}`,
 		},
 		{
 			name:                 "Zero user_id",
 			inputBody:            `{"chat_id": 1,"user_id": 0,"text": "msg1"}`,
 			mockBehaviour:        func(s *mockService.MockMessage, message dto.MessageAdd) {},
-			expectedStatusCode:   http.StatusOK,
-			expectedResponseBody: `{"status":"Error","error":"Field UserID is a required field"}`,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":"Error","code":"validation","error":"Field UserID is a required field"}`,
 		},
 		{
 			name:                 "Nil text",
 			inputBody:            `{"chat_id": 1,"user_id": 1,"text": ""}`,
 			mockBehaviour:        func(s *mockService.MockMessage, message dto.MessageAdd) {},
-			expectedStatusCode:   http.StatusOK,
-			expectedResponseBody: `{"status":"Error","error":"Field Text is a required field"}`,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":"Error","code":"validation","error":"Field Text is a required field"}`,
 		},
 		{
 			name:                 "Request body is nil",
 			mockBehaviour:        func(s *mockService.MockMessage, message dto.MessageAdd) {},
-			expectedStatusCode:   http.StatusOK,
-			expectedResponseBody: `{"status":"Error","error":"Empty request"}`,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":"Error","code":"validation","error":"Empty request"}`,
 		},
 		{
 			name:                 "Error decode",
 			inputBody:            `{"chat_id": 1,"user_id": 1,"text":}`,
 			mockBehaviour:        func(s *mockService.MockMessage, message dto.MessageAdd) {},
-			expectedStatusCode:   http.StatusOK,
-			expectedResponseBody: `{"status":"Error","error":"Invalid request"}`,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":"Error","code":"validation","error":"Invalid request"}`,
 		},
 		{
 			name:      "Other error",
@@ -156,25 +193,25 @@ func TestHandler_MessageAdd(t *testing.T) {
 				Text:   "msg1",
 			},
 			mockBehaviour: func(s *mockService.MockMessage, message dto.MessageAdd) {
-				s.EXPECT().AddMessage(message).Return(0, errors.New("some error"))
+				s.EXPECT().AddMessage(message).Return(0, nil, errors.New("some error"))
 			},
-			expectedStatusCode:   http.StatusOK,
-			expectedResponseBody: `{"status":"Error","error":"Failed to create message: some error"}`,
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: `{"status":"Error","code":"internal","error":"Failed to create message"}`,
 		},
 		{
 			name:                 "Invalid user ID",
 			inputBody:            `{"chat_id": 1,"user_id": 2,"text": "msg1"}`,
 			mockBehaviour:        func(s *mockService.MockMessage, message dto.MessageAdd) {},
-			expectedStatusCode:   http.StatusOK,
-			expectedResponseBody: `{"status":"Error","error":"Invalid user ID"}`,
+			expectedStatusCode:   http.StatusForbidden,
+			expectedResponseBody: `{"status":"Error","code":"forbidden","error":"Invalid user ID"}`,
 		},
 		{
 			name:                 "User id not found",
 			inputBody:            `{"chat_id": 1,"user_id": 2,"text": "msg1"}`,
 			mockBehaviour:        func(s *mockService.MockMessage, message dto.MessageAdd) {},
 			unauthorized:         true,
-			expectedStatusCode:   http.StatusOK,
-			expectedResponseBody: `{"status":"Error","error":"user id not found"}`,
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedResponseBody: `{"status":"Error","code":"unauthorized","error":"user id not found"}`,
 		},
 	}
 
@@ -346,63 +383,63 @@ func TestHandler_MessageGet(t *testing.T) {
 			inputBody:            `{"limit": 10,"offset": 0}`,
 			userID:               1,
 			mockBehaviour:        func(s *mockService.MockMessage, message dto.MessageGet) {},
-			expectedStatusCode:   http.StatusOK,
-			expectedResponseBody: `{"status":"Error","error":"Field ChatID is a required field"}`,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":"Error","code":"validation","error":"Field ChatID is a required field"}`,
 		},
 		{
 			name:                 "Required field limit is missing",
 			inputBody:            `{"chat_id": 1,"offset": 0}`,
 			userID:               1,
 			mockBehaviour:        func(s *mockService.MockMessage, message dto.MessageGet) {},
-			expectedStatusCode:   http.StatusOK,
-			expectedResponseBody: `{"status":"Error","error":"Field Limit is a required field"}`,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":"Error","code":"validation","error":"Field Limit is a required field"}`,
 		},
 		{
 			name:                 "Required field offset is missing",
 			inputBody:            `{"chat_id": 1,"limit": 10}`,
 			userID:               1,
 			mockBehaviour:        func(s *mockService.MockMessage, message dto.MessageGet) {},
-			expectedStatusCode:   http.StatusOK,
-			expectedResponseBody: `{"status":"Error","error":"Field Offset is a required field"}`,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":"Error","code":"validation","error":"Field Offset is a required field"}`,
 		},
 		{
 			name:                 "Empty chat_id",
 			inputBody:            `{"chat_id": ,"limit": 10,"offset": 0}`,
 			userID:               1,
 			mockBehaviour:        func(s *mockService.MockMessage, message dto.MessageGet) {},
-			expectedStatusCode:   http.StatusOK,
-			expectedResponseBody: `{"status":"Error","error":"Invalid request"}`,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":"Error","code":"validation","error":"Invalid request"}`,
 		},
 		{
 			name:                 "Empty limit",
 			inputBody:            `{"chat_id": 1,"limit": ,"offset": 0}`,
 			userID:               1,
 			mockBehaviour:        func(s *mockService.MockMessage, message dto.MessageGet) {},
-			expectedStatusCode:   http.StatusOK,
-			expectedResponseBody: `{"status":"Error","error":"Invalid request"}`,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":"Error","code":"validation","error":"Invalid request"}`,
 		},
 		{
 			name:                 "Empty offset",
 			inputBody:            `{"chat_id": 1,"limit": 10,"offset": }`,
 			userID:               1,
 			mockBehaviour:        func(s *mockService.MockMessage, message dto.MessageGet) {},
-			expectedStatusCode:   http.StatusOK,
-			expectedResponseBody: `{"status":"Error","error":"Invalid request"}`,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":"Error","code":"validation","error":"Invalid request"}`,
 		},
 		{
 			name:                 "Zero chat_id",
 			inputBody:            `{"chat_id": 0,"limit": 10,"offset": 0}`,
 			userID:               1,
 			mockBehaviour:        func(s *mockService.MockMessage, message dto.MessageGet) {},
-			expectedStatusCode:   http.StatusOK,
-			expectedResponseBody: `{"status":"Error","error":"Field ChatID is a required field"}`,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":"Error","code":"validation","error":"Field ChatID is a required field"}`,
 		},
 		{
 			name:                 "Request body is nil",
 			userID:               1,
 			mockBehaviour:        func(s *mockService.MockMessage, message dto.MessageGet) {},
-			expectedStatusCode:   http.StatusOK,
-			expectedResponseBody: `{"status":"Error","error":"Empty request"}`,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":"Error","code":"validation","error":"Empty request"}`,
 		},
 		{
 			name:      "Other error",
@@ -416,8 +453,8 @@ func TestHandler_MessageGet(t *testing.T) {
 			mockBehaviour: func(s *mockService.MockMessage, message dto.MessageGet) {
 				s.EXPECT().GetMessage(message, 1).Return(nil, errors.New("some error"))
 			},
-			expectedStatusCode:   http.StatusOK,
-			expectedResponseBody: `{"status":"Error","error":"Failed to get messages: some error"}`,
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: `{"status":"Error","code":"internal","error":"Failed to get messages"}`,
 		},
 		{
 			name:      "User not found",
@@ -430,8 +467,8 @@ func TestHandler_MessageGet(t *testing.T) {
 			userID:               1,
 			mockBehaviour:        func(s *mockService.MockMessage, message dto.MessageGet) {},
 			unauthorized:         true,
-			expectedStatusCode:   http.StatusOK,
-			expectedResponseBody: `{"status":"Error","error":"user id not found"}`,
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedResponseBody: `{"status":"Error","code":"unauthorized","error":"user id not found"}`,
 		},
 	}
 
@@ -513,70 +550,70 @@ func TestHandler_MessageUpdate(t *testing.T) {
 			name:                 "Required field message_id is missing",
 			inputBody:            `{"user_id": 1,"new_text": "new_text"}`,
 			mockBehaviour:        func(s *mockService.MockMessage, message dto.MessageUpdate) {},
-			expectedStatusCode:   http.StatusOK,
-			expectedResponseBody: `{"status":"Error","error":"Field MessageID is a required field"}`,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":"Error","code":"validation","error":"Field MessageID is a required field"}`,
 		},
 		{
 			name:                 "Required field user_id is missing",
 			inputBody:            `{"message_id": 1,"new_text": "new_text"}`,
 			mockBehaviour:        func(s *mockService.MockMessage, message dto.MessageUpdate) {},
-			expectedStatusCode:   http.StatusOK,
-			expectedResponseBody: `{"status":"Error","error":"Field UserID is a required field"}`,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":"Error","code":"validation","error":"Field UserID is a required field"}`,
 		},
 		{
 			name:                 "Required field new_text is missing",
 			inputBody:            `{"message_id": 1,"user_id": 1}`,
 			mockBehaviour:        func(s *mockService.MockMessage, message dto.MessageUpdate) {},
-			expectedStatusCode:   http.StatusOK,
-			expectedResponseBody: `{"status":"Error","error":"Field NewText is a required field"}`,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":"Error","code":"validation","error":"Field NewText is a required field"}`,
 		},
 		{
 			name:                 "Zero message_id",
 			inputBody:            `{"message_id": 0,"user_id": 1,"new_text": "new_text"}`,
 			mockBehaviour:        func(s *mockService.MockMessage, message dto.MessageUpdate) {},
-			expectedStatusCode:   http.StatusOK,
-			expectedResponseBody: `{"status":"Error","error":"Field MessageID is a required field"}`,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":"Error","code":"validation","error":"Field MessageID is a required field"}`,
 		},
 		{
 			name:                 "Zero user_id",
 			inputBody:            `{"message_id": 1,"user_id": 0,"new_text": "new_text"}`,
 			mockBehaviour:        func(s *mockService.MockMessage, message dto.MessageUpdate) {},
-			expectedStatusCode:   http.StatusOK,
-			expectedResponseBody: `{"status":"Error","error":"Field UserID is a required field"}`,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":"Error","code":"validation","error":"Field UserID is a required field"}`,
 		},
 		{
 			name:                 "Nil new_text",
 			inputBody:            `{"message_id": 1,"user_id": 1,"new_text": ""}`,
 			mockBehaviour:        func(s *mockService.MockMessage, message dto.MessageUpdate) {},
-			expectedStatusCode:   http.StatusOK,
-			expectedResponseBody: `{"status":"Error","error":"Field NewText is a required field"}`,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":"Error","code":"validation","error":"Field NewText is a required field"}`,
 		},
 		{
 			name:                 "Empty message_id",
 			inputBody:            `{"message_id": ,"user_id": 1,"new_text": "new_text"}`,
 			mockBehaviour:        func(s *mockService.MockMessage, message dto.MessageUpdate) {},
-			expectedStatusCode:   http.StatusOK,
-			expectedResponseBody: `{"status":"Error","error":"Invalid request"}`,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":"Error","code":"validation","error":"Invalid request"}`,
 		},
 		{
 			name:                 "Empty user_id",
 			inputBody:            `{"message_id": 1,"user_id": ,"new_text": "new_text"}`,
 			mockBehaviour:        func(s *mockService.MockMessage, message dto.MessageUpdate) {},
-			expectedStatusCode:   http.StatusOK,
-			expectedResponseBody: `{"status":"Error","error":"Invalid request"}`,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":"Error","code":"validation","error":"Invalid request"}`,
 		},
 		{
 			name:                 "Nil new_text",
 			inputBody:            `{"message_id": 1,"user_id": 1,"new_text": }`,
 			mockBehaviour:        func(s *mockService.MockMessage, message dto.MessageUpdate) {},
-			expectedStatusCode:   http.StatusOK,
-			expectedResponseBody: `{"status":"Error","error":"Invalid request"}`,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":"Error","code":"validation","error":"Invalid request"}`,
 		},
 		{
 			name:                 "Request body is nil",
 			mockBehaviour:        func(s *mockService.MockMessage, message dto.MessageUpdate) {},
-			expectedStatusCode:   http.StatusOK,
-			expectedResponseBody: `{"status":"Error","error":"Empty request"}`,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":"Error","code":"validation","error":"Empty request"}`,
 		},
 		{
 			name:      "Other error",
@@ -589,23 +626,23 @@ func TestHandler_MessageUpdate(t *testing.T) {
 			mockBehaviour: func(s *mockService.MockMessage, message dto.MessageUpdate) {
 				s.EXPECT().UpdateMessage(message).Return(0, errors.New("some error"))
 			},
-			expectedStatusCode:   http.StatusOK,
-			expectedResponseBody: `{"status":"Error","error":"Failed to update message: some error"}`,
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: `{"status":"Error","code":"internal","error":"Failed to update message"}`,
 		},
 		{
 			name:                 "User id not found",
 			inputBody:            `{"message_id": 1,"user_id": 1,"new_text": "new_text"}`,
 			mockBehaviour:        func(s *mockService.MockMessage, message dto.MessageUpdate) {},
 			unauthorized:         true,
-			expectedStatusCode:   http.StatusOK,
-			expectedResponseBody: `{"status":"Error","error":"user id not found"}`,
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedResponseBody: `{"status":"Error","code":"unauthorized","error":"user id not found"}`,
 		},
 		{
 			name:                 "Invalid user ID",
 			inputBody:            `{"message_id": 1,"user_id": 2,"new_text": "new_text"}`,
 			mockBehaviour:        func(s *mockService.MockMessage, message dto.MessageUpdate) {},
-			expectedStatusCode:   http.StatusOK,
-			expectedResponseBody: `{"status":"Error","error":"Invalid user ID"}`,
+			expectedStatusCode:   http.StatusForbidden,
+			expectedResponseBody: `{"status":"Error","code":"forbidden","error":"Invalid user ID"}`,
 		},
 	}
 
@@ -715,15 +752,15 @@ func TestHandler_MessageDelete(t *testing.T) {
 			name:                 "Required field message_ids is missing",
 			inputBody:            `{}`,
 			mockBehaviour:        func(s *mockService.MockMessage, message dto.MessageDelete) {},
-			expectedStatusCode:   http.StatusOK,
-			expectedResponseBody: `{"status":"Error","error":"Field MessageIds is a required field"}`,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":"Error","code":"validation","error":"Field MessageIds is a required field"}`,
 		},
 		{
 			name:                 "Empty value message_ids",
 			inputBody:            `{"message_ids": []}`,
 			mockBehaviour:        func(s *mockService.MockMessage, message dto.MessageDelete) {},
-			expectedStatusCode:   http.StatusOK,
-			expectedResponseBody: `{"status":"Error","error":"Field MessageIds must contain at least 1 characters"}`,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":"Error","code":"validation","error":"Field MessageIds must contain at least 1 characters"}`,
 		},
 		{
 			name:      "Zero value message_ids",
@@ -735,21 +772,21 @@ func TestHandler_MessageDelete(t *testing.T) {
 				s.EXPECT().DeleteMessage(message, 1).Return(nil, errors.New(
 					fmt.Sprintf("Failed to delete message: error path: db.DeleteMessage, error: pq: Not found messages")))
 			},
-			expectedStatusCode:   http.StatusOK,
-			expectedResponseBody: `{"status":"Error","error":"Failed to delete message: Failed to delete message: error path: db.DeleteMessage, error: pq: Not found messages"}`,
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: `{"status":"Error","code":"internal","error":"Failed to delete message"}`,
 		},
 		{
 			name:                 "Error decode",
 			inputBody:            `{"message_ids": }`,
 			mockBehaviour:        func(s *mockService.MockMessage, message dto.MessageDelete) {},
-			expectedStatusCode:   http.StatusOK,
-			expectedResponseBody: `{"status":"Error","error":"Invalid request"}`,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":"Error","code":"validation","error":"Invalid request"}`,
 		},
 		{
 			name:                 "Request nil body",
 			mockBehaviour:        func(s *mockService.MockMessage, message dto.MessageDelete) {},
-			expectedStatusCode:   http.StatusOK,
-			expectedResponseBody: `{"status":"Error","error":"Empty request"}`,
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":"Error","code":"validation","error":"Empty request"}`,
 		},
 		{
 			name:      "Other error",
@@ -760,8 +797,8 @@ func TestHandler_MessageDelete(t *testing.T) {
 			mockBehaviour: func(s *mockService.MockMessage, message dto.MessageDelete) {
 				s.EXPECT().DeleteMessage(message, 1).Return(nil, errors.New("some error"))
 			},
-			expectedStatusCode:   http.StatusOK,
-			expectedResponseBody: `{"status":"Error","error":"Failed to delete message: some error"}`,
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: `{"status":"Error","code":"internal","error":"Failed to delete message"}`,
 		},
 		{
 			name:      "User id not found",
@@ -771,8 +808,8 @@ func TestHandler_MessageDelete(t *testing.T) {
 			},
 			mockBehaviour:        func(s *mockService.MockMessage, message dto.MessageDelete) {},
 			unauthorized:         true,
-			expectedStatusCode:   http.StatusOK,
-			expectedResponseBody: `{"status":"Error","error":"user id not found"}`,
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedResponseBody: `{"status":"Error","code":"unauthorized","error":"user id not found"}`,
 		},
 	}
 
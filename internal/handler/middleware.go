@@ -2,11 +2,16 @@ package handler
 
 import (
 	"context"
-	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"net"
 	"net/http"
+	"strconv"
 	"strings"
 
-	"github.com/go-chi/render"
+	"service-chat/internal/apperr"
+	"service-chat/internal/logger"
 )
 
 const (
@@ -16,6 +21,7 @@ const (
 	errEmptyToken  = "Token is empty"
 	userCtx        = "userID"
 	bearerToken    = "Bearer"
+	retryAfter     = "Retry-After"
 )
 
 func (h *Handler) AuthMiddleware(next http.Handler) http.Handler {
@@ -24,27 +30,33 @@ func (h *Handler) AuthMiddleware(next http.Handler) http.Handler {
 		header := r.Header.Get(authHeader)
 		// Если header пустой
 		if header == "" {
-			render.JSON(w, r, Error(errEmptyHeader))
+			renderError(w, r, "", apperr.Unauthorized(errEmptyHeader))
 			return
 		}
 
 		// Проверяем, что значение header валидно
 		headerParts := strings.Split(header, " ")
 		if len(headerParts) != 2 || headerParts[0] != bearerToken {
-			render.JSON(w, r, Error(errInvalidAuth))
+			renderError(w, r, "", apperr.Unauthorized(errInvalidAuth))
 			return
 		}
 
 		// Если длина значения token == 0
 		if len(headerParts[1]) == 0 {
-			render.JSON(w, r, Error(errEmptyToken))
+			renderError(w, r, "", apperr.Unauthorized(errEmptyToken))
 			return
 		}
 
 		// Получаем id пользователя из jwt token
 		userID, err := h.services.Authorization.ParseToken(headerParts[1])
 		if err != nil {
-			render.JSON(w, r, Error(err.Error()))
+			renderError(w, r, "", apperr.Unauthorized(err.Error()))
+			return
+		}
+
+		// Отстранённый модератором пользователь не может пользоваться сервисом до окончания срока
+		if errSuspended := h.services.Authorization.CheckSuspension(userID); errSuspended != nil {
+			renderError(w, r, "", errSuspended)
 			return
 		}
 
@@ -53,11 +65,68 @@ func (h *Handler) AuthMiddleware(next http.Handler) http.Handler {
 	})
 }
 
+// RateLimit - ограничиваем частоту запросов группы маршрутов, корзину токенов выбирает key.
+// Если лимит исчерпан, отвечаем 429 с заголовком Retry-After. Без лимитов запросы не ограничиваются
+func (h *Handler) RateLimit(log *slog.Logger, group string, key func(r *http.Request) string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if h.limiter == nil {
+			return next
+		}
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			res, err := h.limiter.Allow(r.Context(), group, key(r))
+			if err != nil {
+				// Если хранилище лимитов недоступно, запрос пропускаем, чтобы сбой лимитов не остановил сервис
+				log.Error("failed to check rate limit", slog.String("group", group), logger.Err(err))
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if !res.Allowed {
+				seconds := max(1, int(math.Ceil(res.RetryAfter.Seconds())))
+				w.Header().Set(retryAfter, strconv.Itoa(seconds))
+				renderError(w, r, "", apperr.New(apperr.CodeRateLimited, fmt.Sprintf("Too many requests, retry in %d seconds", seconds)))
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// userKey - ключ лимита по id пользователя, маршрут должен быть за AuthMiddleware
+func userKey(r *http.Request) string {
+	id, _ := GetUserID(r.Context())
+	return "user:" + strconv.Itoa(id)
+}
+
+// ipKey - ключ лимита по IP клиента. За своим прокси IP берётся из X-Real-IP или последним из X-Forwarded-For:
+// его дописал прокси, а адреса левее присылает сам клиент и может подменить
+func (h *Handler) ipKey(r *http.Request) string {
+	if h.limiter != nil && h.limiter.TrustProxy {
+		if ip := strings.TrimSpace(r.Header.Get("X-Real-IP")); ip != "" {
+			return "ip:" + ip
+		}
+		if forwarded := r.Header.Get("X-Forwarded-For"); forwarded != "" {
+			hops := strings.Split(forwarded, ",")
+			if ip := strings.TrimSpace(hops[len(hops)-1]); ip != "" {
+				return "ip:" + ip
+			}
+		}
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	return "ip:" + host
+}
+
 func GetUserID(ctx context.Context) (int, error) {
 	// Достаём из контекста userID
 	id, ok := ctx.Value(userCtx).(int)
 	if !ok {
-		return 0, errors.New("user id not found")
+		return 0, apperr.Unauthorized("user id not found")
 	}
 
 	return id, nil
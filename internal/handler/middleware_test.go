@@ -3,6 +3,8 @@ package handler
 import (
 	"context"
 	"errors"
+	"io"
+	"log/slog"
 	"net/http"
 	"net/http/httptest"
 	"strconv"
@@ -14,6 +16,8 @@ import (
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
 
+	"service-chat/internal/apperr"
+	"service-chat/internal/ratelimit"
 	"service-chat/internal/service"
 	mockService "service-chat/internal/service/mocks"
 )
@@ -67,18 +71,32 @@ func TestHandler_AuthMiddleware(t *testing.T) {
 			// Реализуем поведение мока, даём на вход token и возвращаем userID и nil ошибку
 			mockBehavior: func(s *mockService.MockAuthorization, token string) {
 				s.EXPECT().ParseToken(token).Return(1, nil)
+				s.EXPECT().CheckSuspension(1).Return(nil)
 			},
-			expectedStatusCode:   200,
+			expectedStatusCode:   http.StatusOK,
 			expectedResponseBody: `{"status":"OK","message":"1"}`,
 		},
+		{
+			name:        "Suspended",
+			headerName:  "Authorization",
+			headerValue: "Bearer token",
+			token:       "token",
+			// Пользователь отстранён модератором, запрос не доходит до обработчика
+			mockBehavior: func(s *mockService.MockAuthorization, token string) {
+				s.EXPECT().ParseToken(token).Return(1, nil)
+				s.EXPECT().CheckSuspension(1).Return(apperr.Forbidden("User is suspended until 2026-10-20T12:00:00Z"))
+			},
+			expectedStatusCode:   http.StatusForbidden,
+			expectedResponseBody: `{"status":"Error","code":"forbidden","error":"User is suspended until 2026-10-20T12:00:00Z"}`,
+		},
 		{
 			name:                 "Invalid Header Name",
 			headerName:           "",
 			headerValue:          "Bearer token",
 			token:                "token",
 			mockBehavior:         func(s *mockService.MockAuthorization, token string) {},
-			expectedStatusCode:   200,
-			expectedResponseBody: `{"status":"Error","error":"Authorization header is empty"}`,
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedResponseBody: `{"status":"Error","code":"unauthorized","error":"Authorization header is empty"}`,
 		},
 		{
 			name:                 "Invalid Header Value",
@@ -86,8 +104,8 @@ func TestHandler_AuthMiddleware(t *testing.T) {
 			headerValue:          "Bear token",
 			token:                "token",
 			mockBehavior:         func(s *mockService.MockAuthorization, token string) {},
-			expectedStatusCode:   200,
-			expectedResponseBody: `{"status":"Error","error":"Invalid authorization header"}`,
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedResponseBody: `{"status":"Error","code":"unauthorized","error":"Invalid authorization header"}`,
 		},
 		{
 			name:                 "Empty Token",
@@ -95,8 +113,8 @@ func TestHandler_AuthMiddleware(t *testing.T) {
 			headerValue:          "Bearer ",
 			token:                "token",
 			mockBehavior:         func(s *mockService.MockAuthorization, token string) {},
-			expectedStatusCode:   200,
-			expectedResponseBody: `{"status":"Error","error":"Token is empty"}`,
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedResponseBody: `{"status":"Error","code":"unauthorized","error":"Token is empty"}`,
 		},
 		{
 			name:        "Parse Error",
@@ -106,8 +124,8 @@ func TestHandler_AuthMiddleware(t *testing.T) {
 			mockBehavior: func(s *mockService.MockAuthorization, token string) {
 				s.EXPECT().ParseToken(token).Return(0, errors.New("parse error"))
 			},
-			expectedStatusCode:   200,
-			expectedResponseBody: `{"status":"Error","error":"parse error"}`,
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedResponseBody: `{"status":"Error","code":"unauthorized","error":"parse error"}`,
 		},
 	}
 
@@ -156,7 +174,7 @@ func Test_GetUserID(t *testing.T) {
 			name:          "Not found",
 			ctx:           context.Background(),
 			isFail:        true,
-			expectedError: errors.New("user id not found"),
+			expectedError: apperr.Unauthorized("user id not found"),
 		},
 	}
 
@@ -174,3 +192,145 @@ func Test_GetUserID(t *testing.T) {
 		})
 	}
 }
+
+func TestHandler_RateLimit(t *testing.T) {
+	// Один запрос подряд, следующий токен через час
+	limiter := ratelimit.NewLimiter(ratelimit.NewMemoryStore(), map[string]ratelimit.Limit{
+		ratelimit.GroupAuth: {Rate: 1.0 / 3600, Burst: 1},
+		ratelimit.GroupAPI:  {Rate: 1.0 / 3600, Burst: 1},
+	})
+	handler := NewHandler(&service.Service{}).WithRateLimiter(limiter)
+	mockLog := slog.New(slog.NewJSONHandler(io.Discard, nil))
+
+	// Инициализируем тестовые endPoints: auth ограничивается по IP, api - по пользователю из контекста
+	r := chi.NewRouter()
+	r.With(handler.RateLimit(mockLog, ratelimit.GroupAuth, handler.ipKey)).Post("/auth", func(w http.ResponseWriter, r *http.Request) {
+		render.JSON(w, r, OK("auth"))
+	})
+	r.With(handler.RateLimit(mockLog, ratelimit.GroupAPI, userKey)).Post("/api", func(w http.ResponseWriter, r *http.Request) {
+		render.JSON(w, r, OK("api"))
+	})
+
+	testTable := []struct {
+		name                 string
+		path                 string
+		remoteAddr           string
+		userID               int
+		expectedStatusCode   int
+		expectedRetryAfter   string
+		expectedResponseBody string
+	}{
+		{
+			name:                 "Auth OK",
+			path:                 "/auth",
+			remoteAddr:           "10.0.0.1:5000",
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"status":"OK","message":"auth"}`,
+		},
+		{
+			name:                 "Auth same IP other port",
+			path:                 "/auth",
+			remoteAddr:           "10.0.0.1:5001",
+			expectedStatusCode:   http.StatusTooManyRequests,
+			expectedRetryAfter:   "3600",
+			expectedResponseBody: `{"status":"Error","code":"rate_limited","error":"Too many requests, retry in 3600 seconds"}`,
+		},
+		{
+			name:                 "Auth other IP",
+			path:                 "/auth",
+			remoteAddr:           "10.0.0.2:5000",
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"status":"OK","message":"auth"}`,
+		},
+		{
+			name:                 "API OK",
+			path:                 "/api",
+			remoteAddr:           "10.0.0.1:5000",
+			userID:               1,
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"status":"OK","message":"api"}`,
+		},
+		{
+			name:                 "API same user",
+			path:                 "/api",
+			remoteAddr:           "10.0.0.3:5000",
+			userID:               1,
+			expectedStatusCode:   http.StatusTooManyRequests,
+			expectedRetryAfter:   "3600",
+			expectedResponseBody: `{"status":"Error","code":"rate_limited","error":"Too many requests, retry in 3600 seconds"}`,
+		},
+		{
+			name:                 "API other user same IP",
+			path:                 "/api",
+			remoteAddr:           "10.0.0.1:5000",
+			userID:               2,
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"status":"OK","message":"api"}`,
+		},
+	}
+
+	// Итерируемся по нашей тестовой таблице
+	for _, tt := range testTable {
+		t.Run(tt.name, func(t *testing.T) {
+			// Готовим и выполняем тестовый запрос
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, tt.path, nil)
+			req.RemoteAddr = tt.remoteAddr
+			r.ServeHTTP(w, req.WithContext(context.WithValue(req.Context(), userCtx, tt.userID)))
+
+			// Сравниваем ожидаемый и актуальный результат
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			assert.Equal(t, tt.expectedRetryAfter, w.Header().Get("Retry-After"))
+			assert.Equal(t, tt.expectedResponseBody, strings.TrimSpace(w.Body.String()))
+		})
+	}
+}
+
+func TestHandler_ipKey(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/auth", nil)
+	req.RemoteAddr = "10.0.0.1:5000"
+	req.Header.Set("X-Forwarded-For", "203.0.113.7, 10.0.0.9")
+
+	// Без доверия к прокси заголовки не учитываются
+	handler := NewHandler(&service.Service{})
+	assert.Equal(t, "ip:10.0.0.1", handler.ipKey(req))
+
+	// Последний адрес дописал наш прокси
+	limiter := ratelimit.NewLimiter(ratelimit.NewMemoryStore(), nil)
+	limiter.TrustProxy = true
+	handler.WithRateLimiter(limiter)
+	assert.Equal(t, "ip:10.0.0.9", handler.ipKey(req))
+
+	req.Header.Set("X-Real-IP", "198.51.100.4")
+	assert.Equal(t, "ip:198.51.100.4", handler.ipKey(req))
+}
+
+func TestHandler_RateLimit_SpoofedForwardedFor(t *testing.T) {
+	// Один запрос подряд, следующий токен через час
+	limiter := ratelimit.NewLimiter(ratelimit.NewMemoryStore(), map[string]ratelimit.Limit{
+		ratelimit.GroupAuth: {Rate: 1.0 / 3600, Burst: 1},
+	})
+	limiter.TrustProxy = true
+	handler := NewHandler(&service.Service{}).WithRateLimiter(limiter)
+	mockLog := slog.New(slog.NewJSONHandler(io.Discard, nil))
+
+	r := chi.NewRouter()
+	r.With(handler.RateLimit(mockLog, ratelimit.GroupAuth, handler.ipKey)).Post("/auth", func(w http.ResponseWriter, r *http.Request) {
+		render.JSON(w, r, OK("auth"))
+	})
+
+	// Клиент каждый раз присылает новый X-Forwarded-For, прокси дописывает его настоящий адрес
+	for i, spoofed := range []string{"1.1.1.1", "2.2.2.2"} {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/auth", nil)
+		req.RemoteAddr = "10.0.0.9:5000"
+		req.Header.Set("X-Forwarded-For", spoofed+", 203.0.113.7")
+		r.ServeHTTP(w, req)
+
+		if i == 0 {
+			assert.Equal(t, http.StatusOK, w.Code)
+		} else {
+			assert.Equal(t, http.StatusTooManyRequests, w.Code)
+		}
+	}
+}
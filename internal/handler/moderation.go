@@ -0,0 +1,349 @@
+package handler
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+
+	"service-chat/internal/apperr"
+	"service-chat/internal/dto"
+	"service-chat/internal/logger"
+	"service-chat/internal/validate"
+)
+
+// ReportAdd - пожаловаться на сообщение
+// @Summary ReportAdd
+// @Security ApiKeyAuth
+// @Tags Moderation
+// @Description Report a message of another chat member to the moderators. A user can report a message only once
+// @ID Add report
+// @Accept json
+// @Produce json
+// @Param id path int true "message id"
+// @Param input body dto.ReportAdd true "reason: spam, harassment, hate, violence, sexual or other"
+// @Success 200 {object} Response{Status, Message}
+// @Failure 400,404,405 {object} Response
+// @Failure 500 {object} Response
+// @Failure default {object} Response
+// @Router /messages/{id}/report [post]
+func (h *Handler) ReportAdd(log *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Логируем наш запрос
+		const op = "handler.ReportAdd"
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		// Получаем id пользователя из контекста
+		idCtx, errCtx := GetUserID(r.Context())
+		if errCtx != nil {
+			log.Error("failed to get userID from context")
+			renderError(w, r, "", errCtx)
+			return
+		}
+
+		// Получаем id сообщения из пути запроса
+		messageID, errID := GetPathID(r)
+		if errID != nil {
+			log.Error("invalid message ID")
+			renderError(w, r, "", errID)
+			return
+		}
+
+		// Структура для записи входных данных из JSON от пользователя
+		var req dto.ReportAdd
+
+		// Анализируем запрос от пользователя
+		fail := validate.BaseValidate(log, r.Body, &req)
+		if fail != nil && fail.ValidateErr != nil {
+			log.Error("invalid request data")
+			renderError(w, r, "", fail.ValidateErr)
+			return
+		} else if fail != nil && fail.ErrMsg != "" {
+			log.Error("invalid request data")
+			renderError(w, r, "", apperr.Validation(fail.ErrMsg))
+			return
+		}
+
+		// Отправляем валидную структуру на слой сервиса
+		reportID, errAdd := h.services.Moderation.ReportMessage(req, messageID, idCtx)
+		if errAdd != nil {
+			log.Error("failed to report message", logger.Err(errAdd))
+			renderError(w, r, "Failed to report message", errAdd)
+			return
+		}
+
+		// Если ошибок нет отправляем успешный ответ
+		log.Info("Message reported successfully", slog.Int64("reportID", reportID))
+		render.JSON(w, r, OK(fmt.Sprintf("Message reported successfully, id: %d", reportID)))
+		return
+	}
+}
+
+// ReportGet - очередь жалоб для модератора
+// @Summary ReportGet
+// @Security ApiKeyAuth
+// @Tags Moderation
+// @Description Get the moderation queue: reports with the reported message and the number of open reports on it, oldest first. Only for moderators
+// @ID Get reports
+// @Produce json
+// @Param status query string false "open (default), resolved or dismissed"
+// @Param limit query int false "page size, 1-100, default 20"
+// @Param offset query int false "number of reports to skip"
+// @Success 200 {object} Response{Status, Message, ReportsList}
+// @Failure 400,404,405 {object} Response
+// @Failure 500 {object} Response
+// @Failure default {object} Response
+// @Router /moderation/reports [get]
+func (h *Handler) ReportGet(log *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Логируем наш запрос
+		const op = "handler.ReportGet"
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		// Получаем id пользователя из контекста
+		idCtx, errCtx := GetUserID(r.Context())
+		if errCtx != nil {
+			log.Error("failed to get userID from context")
+			renderError(w, r, "", errCtx)
+			return
+		}
+
+		// Заполняем структуру запроса из query параметров
+		req, errParse := parseReportsGet(r.URL.Query())
+		if errParse != nil {
+			log.Error("invalid query parameters", logger.Err(errParse))
+			renderError(w, r, "", errParse)
+			return
+		}
+
+		// Анализируем запрос от пользователя
+		fail := validate.StructValidate(log, &req)
+		if fail != nil && fail.ValidateErr != nil {
+			log.Error("invalid request data")
+			renderError(w, r, "", fail.ValidateErr)
+			return
+		} else if fail != nil && fail.ErrMsg != "" {
+			log.Error("invalid request data")
+			renderError(w, r, "", apperr.Validation(fail.ErrMsg))
+			return
+		}
+
+		// Отправляем валидную структуру на слой сервиса
+		reports, errGet := h.services.Moderation.GetReports(req, idCtx)
+		if errGet != nil {
+			log.Error("failed to get reports", logger.Err(errGet))
+			renderError(w, r, "Failed to get reports", errGet)
+			return
+		}
+
+		// Если жалоб нет
+		if len(reports) == 0 {
+			log.Info("reports not found")
+			render.JSON(w, r, OK("No reports found"))
+			return
+		}
+
+		// Если ошибок нет отправляем успешный ответ
+		log.Info("Reports found successfully", slog.Int("count", len(reports)))
+		render.JSON(w, r, Response{
+			Status:      StatusOK,
+			Message:     "Reports found successfully",
+			ReportsList: reports,
+		})
+		return
+	}
+}
+
+// ModerationAdd - действие модератора по жалобе
+// @Summary ModerationAdd
+// @Security ApiKeyAuth
+// @Tags Moderation
+// @Description Act on an open report: hide the message, warn or suspend its author for a duration, or dismiss the report. All open reports on the message are closed and the action is recorded in the moderation log. Only for moderators
+// @ID Add moderation action
+// @Accept json
+// @Produce json
+// @Param id path int true "report id"
+// @Param input body dto.ModerationAdd true "action: hide, warn, suspend or dismiss; duration only for suspend"
+// @Success 200 {object} Response{Status, Message, ModerationAction}
+// @Failure 400,404,405 {object} Response
+// @Failure 500 {object} Response
+// @Failure default {object} Response
+// @Router /moderation/reports/{id}/actions [post]
+func (h *Handler) ModerationAdd(log *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Логируем наш запрос
+		const op = "handler.ModerationAdd"
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		// Получаем id пользователя из контекста
+		idCtx, errCtx := GetUserID(r.Context())
+		if errCtx != nil {
+			log.Error("failed to get userID from context")
+			renderError(w, r, "", errCtx)
+			return
+		}
+
+		// Получаем id жалобы из пути запроса
+		reportID, errID := GetPathID(r)
+		if errID != nil {
+			log.Error("invalid report ID")
+			renderError(w, r, "", errID)
+			return
+		}
+
+		// Структура для записи входных данных из JSON от пользователя
+		var req dto.ModerationAdd
+
+		// Анализируем запрос от пользователя
+		fail := validate.BaseValidate(log, r.Body, &req)
+		if fail != nil && fail.ValidateErr != nil {
+			log.Error("invalid request data")
+			renderError(w, r, "", fail.ValidateErr)
+			return
+		} else if fail != nil && fail.ErrMsg != "" {
+			log.Error("invalid request data")
+			renderError(w, r, "", apperr.Validation(fail.ErrMsg))
+			return
+		}
+
+		// Отправляем валидную структуру на слой сервиса
+		action, errMod := h.services.Moderation.Moderate(req, reportID, idCtx)
+		if errMod != nil {
+			log.Error("failed to moderate report", logger.Err(errMod))
+			renderError(w, r, "Failed to moderate report", errMod)
+			return
+		}
+
+		// Если ошибок нет отправляем успешный ответ
+		log.Info("Report moderated successfully", slog.Int64("reportID", reportID), slog.String("action", action.Action))
+		render.JSON(w, r, Response{
+			Status:           StatusOK,
+			Message:          "Report moderated successfully",
+			ModerationAction: action,
+		})
+		return
+	}
+}
+
+// ModerationGet - журнал действий модераторов
+// @Summary ModerationGet
+// @Security ApiKeyAuth
+// @Tags Moderation
+// @Description Get the append-only log of moderator actions, newest first. Only for moderators
+// @ID Get moderation actions
+// @Produce json
+// @Param user_id query int false "only actions against this user"
+// @Param limit query int false "page size, 1-100, default 20"
+// @Param offset query int false "number of actions to skip"
+// @Success 200 {object} Response{Status, Message, ActionsList}
+// @Failure 400,404,405 {object} Response
+// @Failure 500 {object} Response
+// @Failure default {object} Response
+// @Router /moderation/actions [get]
+func (h *Handler) ModerationGet(log *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Логируем наш запрос
+		const op = "handler.ModerationGet"
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		// Получаем id пользователя из контекста
+		idCtx, errCtx := GetUserID(r.Context())
+		if errCtx != nil {
+			log.Error("failed to get userID from context")
+			renderError(w, r, "", errCtx)
+			return
+		}
+
+		// Заполняем структуру запроса из query параметров
+		req, errParse := parseActionsGet(r.URL.Query())
+		if errParse != nil {
+			log.Error("invalid query parameters", logger.Err(errParse))
+			renderError(w, r, "", errParse)
+			return
+		}
+
+		// Анализируем запрос от пользователя
+		fail := validate.StructValidate(log, &req)
+		if fail != nil && fail.ValidateErr != nil {
+			log.Error("invalid request data")
+			renderError(w, r, "", fail.ValidateErr)
+			return
+		} else if fail != nil && fail.ErrMsg != "" {
+			log.Error("invalid request data")
+			renderError(w, r, "", apperr.Validation(fail.ErrMsg))
+			return
+		}
+
+		// Отправляем валидную структуру на слой сервиса
+		actions, errGet := h.services.Moderation.GetActions(req, idCtx)
+		if errGet != nil {
+			log.Error("failed to get moderation actions", logger.Err(errGet))
+			renderError(w, r, "Failed to get moderation actions", errGet)
+			return
+		}
+
+		// Если действий нет
+		if len(actions) == 0 {
+			log.Info("moderation actions not found")
+			render.JSON(w, r, OK("No moderation actions found"))
+			return
+		}
+
+		// Если ошибок нет отправляем успешный ответ
+		log.Info("Moderation actions found successfully", slog.Int("count", len(actions)))
+		render.JSON(w, r, Response{
+			Status:      StatusOK,
+			Message:     "Moderation actions found successfully",
+			ActionsList: actions,
+		})
+		return
+	}
+}
+
+// parseReportsGet - разбираем query параметры очереди жалоб
+func parseReportsGet(values url.Values) (dto.ReportsGet, error) {
+	req := dto.ReportsGet{Status: values.Get("status")}
+	var err error
+
+	if req.Limit, err = queryInt64(values, "limit"); err != nil {
+		return req, err
+	}
+	if req.Offset, err = queryInt64(values, "offset"); err != nil {
+		return req, err
+	}
+
+	return req, nil
+}
+
+// parseActionsGet - разбираем query параметры журнала действий модераторов
+func parseActionsGet(values url.Values) (dto.ActionsGet, error) {
+	var req dto.ActionsGet
+	var err error
+
+	if req.UserID, err = queryInt64(values, "user_id"); err != nil {
+		return req, err
+	}
+	if req.Limit, err = queryInt64(values, "limit"); err != nil {
+		return req, err
+	}
+	if req.Offset, err = queryInt64(values, "offset"); err != nil {
+		return req, err
+	}
+
+	return req, nil
+}
@@ -0,0 +1,186 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"service-chat/internal/apperr"
+	"service-chat/internal/db/entity"
+	"service-chat/internal/dto"
+	"service-chat/internal/service"
+	mockService "service-chat/internal/service/mocks"
+)
+
+func TestHandler_Moderation(t *testing.T) {
+	// Структура для последующей реализации поведения мока
+	type mockBehaviour func(s *mockService.MockModeration)
+
+	//Инициализируем контролер для мока сервиса
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Создаём моки сервиса модерации
+	mockModeration := mockService.NewMockModeration(ctrl)
+
+	// Создаём экземпляр обработчика
+	handler := NewHandler(&service.Service{Moderation: mockModeration})
+
+	// Мокируем логгер
+	mockLog := slog.New(slog.NewJSONHandler(io.Discard, nil))
+
+	// Инициализируем сервер
+	r := chi.NewRouter()
+	r.Post("/messages/{id}/report", handler.ReportAdd(mockLog))
+	r.Get("/moderation/reports", handler.ReportGet(mockLog))
+	r.Post("/moderation/reports/{id}/actions", handler.ModerationAdd(mockLog))
+	r.Get("/moderation/actions", handler.ModerationGet(mockLog))
+
+	// Тестовая таблица с данными
+	testTable := []struct {
+		name                 string
+		method               string
+		path                 string
+		inputBody            string
+		mockBehaviour        mockBehaviour
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:      "Report OK",
+			method:    http.MethodPost,
+			path:      "/messages/5/report",
+			inputBody: `{"reason":"spam","comment":"ads"}`,
+			mockBehaviour: func(s *mockService.MockModeration) {
+				s.EXPECT().ReportMessage(dto.ReportAdd{Reason: "spam", Comment: "ads"}, int64(5), 1).Return(int64(3), nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"status":"OK","message":"Message reported successfully, id: 3"}`,
+		},
+		{
+			name:                 "Report invalid reason",
+			method:               http.MethodPost,
+			path:                 "/messages/5/report",
+			inputBody:            `{"reason":"boring"}`,
+			mockBehaviour:        func(s *mockService.MockModeration) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":"Error","code":"validation","error":"Field Reason must be one of: spam harassment hate violence sexual other"}`,
+		},
+		{
+			name:      "Report own message",
+			method:    http.MethodPost,
+			path:      "/messages/5/report",
+			inputBody: `{"reason":"other"}`,
+			mockBehaviour: func(s *mockService.MockModeration) {
+				s.EXPECT().ReportMessage(dto.ReportAdd{Reason: "other"}, int64(5), 1).
+					Return(int64(0), apperr.Validation("Cannot report your own message"))
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":"Error","code":"validation","error":"Failed to report message: Cannot report your own message"}`,
+		},
+		{
+			name:   "Reports OK",
+			method: http.MethodGet,
+			path:   "/moderation/reports?limit=10",
+			mockBehaviour: func(s *mockService.MockModeration) {
+				s.EXPECT().GetReports(dto.ReportsGet{Limit: 10}, 1).Return([]entity.Report{{ID: 3, MessageID: 5, ChatID: 4,
+					AuthorID: 7, Text: "buy now", ReporterID: 1, Reason: "spam", Status: "open", Reports: 2,
+					CreatedAt: "2026-10-19 12:00:00"}}, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"status":"OK","message":"Reports found successfully","reports_list":[{"id":3,"message_id":5,"chat_id":4,"author_id":7,"text":"buy now","reporter_id":1,"reason":"spam","status":"open","reports":2,"created_at":"2026-10-19 12:00:00"}]}`,
+		},
+		{
+			name:                 "Reports invalid status",
+			method:               http.MethodGet,
+			path:                 "/moderation/reports?status=closed",
+			mockBehaviour:        func(s *mockService.MockModeration) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":"Error","code":"validation","error":"Field Status must be one of: open resolved dismissed"}`,
+		},
+		{
+			name:   "Reports not moderator",
+			method: http.MethodGet,
+			path:   "/moderation/reports",
+			mockBehaviour: func(s *mockService.MockModeration) {
+				s.EXPECT().GetReports(dto.ReportsGet{}, 1).Return(nil, apperr.Forbidden("User with userID 1 is not a moderator"))
+			},
+			expectedStatusCode:   http.StatusForbidden,
+			expectedResponseBody: `{"status":"Error","code":"forbidden","error":"Failed to get reports: User with userID 1 is not a moderator"}`,
+		},
+		{
+			name:      "Action OK",
+			method:    http.MethodPost,
+			path:      "/moderation/reports/3/actions",
+			inputBody: `{"action":"suspend","duration":"24h"}`,
+			mockBehaviour: func(s *mockService.MockModeration) {
+				s.EXPECT().Moderate(dto.ModerationAdd{Action: "suspend", Duration: "24h"}, int64(3), 1).
+					Return(&entity.ModerationAction{ID: 1, ModeratorID: 1, Action: "suspend", ReportID: 3, MessageID: 5,
+						UserID: 7, SuspendedUntil: "2026-10-20 12:00:00", CreatedAt: "2026-10-19 12:00:00"}, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"status":"OK","message":"Report moderated successfully","moderation_action":{"id":1,"moderator_id":1,"action":"suspend","report_id":3,"message_id":5,"user_id":7,"suspended_until":"2026-10-20 12:00:00","created_at":"2026-10-19 12:00:00"}}`,
+		},
+		{
+			name:                 "Action without action",
+			method:               http.MethodPost,
+			path:                 "/moderation/reports/3/actions",
+			inputBody:            `{}`,
+			mockBehaviour:        func(s *mockService.MockModeration) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":"Error","code":"validation","error":"Field Action is a required field"}`,
+		},
+		{
+			name:                 "Action invalid report id",
+			method:               http.MethodPost,
+			path:                 "/moderation/reports/abc/actions",
+			inputBody:            `{"action":"warn"}`,
+			mockBehaviour:        func(s *mockService.MockModeration) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":"Error","code":"validation","error":"Invalid id in path"}`,
+		},
+		{
+			name:   "Actions empty",
+			method: http.MethodGet,
+			path:   "/moderation/actions?user_id=7",
+			mockBehaviour: func(s *mockService.MockModeration) {
+				s.EXPECT().GetActions(dto.ActionsGet{UserID: 7}, 1).Return(nil, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"status":"OK","message":"No moderation actions found"}`,
+		},
+		{
+			name:                 "Actions invalid user id",
+			method:               http.MethodGet,
+			path:                 "/moderation/actions?user_id=abc",
+			mockBehaviour:        func(s *mockService.MockModeration) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":"Error","code":"validation","error":"Invalid query parameter user_id"}`,
+		},
+	}
+
+	// Итерируемся по нашей тестовой таблице
+	for _, tt := range testTable {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockBehaviour(mockModeration)
+
+			// Готовим и выполняем тестовый запрос
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(tt.method, tt.path, bytes.NewBufferString(tt.inputBody))
+			r.ServeHTTP(w, req.WithContext(context.WithValue(req.Context(), userCtx, 1)))
+
+			// Сравниваем ожидаемый и актуальный результат
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			assert.Equal(t, tt.expectedResponseBody, strings.TrimSpace(w.Body.String()))
+		})
+	}
+}
@@ -0,0 +1,189 @@
+package handler
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+
+	"service-chat/internal/apperr"
+	"service-chat/internal/dto"
+	"service-chat/internal/logger"
+	"service-chat/internal/validate"
+)
+
+// NotifySettingsGet - получить адреса уведомлений текущего пользователя
+// @Summary NotifySettingsGet
+// @Security ApiKeyAuth
+// @Tags Notification
+// @Description Get the email and the push token the current user receives notifications about new messages on while offline
+// @ID Get notification settings
+// @Produce json
+// @Success 200 {object} Response{Status, NotifySettings}
+// @Failure 400,404,405 {object} Response
+// @Failure 500 {object} Response
+// @Failure default {object} Response
+// @Router /users/me/notifications [get]
+func (h *Handler) NotifySettingsGet(log *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Логируем наш запрос
+		const op = "handler.NotifySettingsGet"
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		// Получаем id пользователя из контекста
+		idCtx, errCtx := GetUserID(r.Context())
+		if errCtx != nil {
+			log.Error("failed to get userID from context")
+			renderError(w, r, "", errCtx)
+			return
+		}
+
+		settings, errGet := h.services.Notification.GetSettings(idCtx)
+		if errGet != nil {
+			log.Error("failed to get notification settings", logger.Err(errGet))
+			renderError(w, r, "Failed to get notification settings", errGet)
+			return
+		}
+
+		// Если ошибок нет отправляем успешный ответ
+		log.Info("Notification settings received successfully")
+		render.JSON(w, r, Response{
+			Status:         StatusOK,
+			NotifySettings: settings,
+		})
+		return
+	}
+}
+
+// NotifySettingsSave - сохранить адреса уведомлений текущего пользователя
+// @Summary NotifySettingsSave
+// @Security ApiKeyAuth
+// @Tags Notification
+// @Description Set the email and the push token for notifications about new messages while offline. An empty value turns the channel off. Mentions are sent right away, other messages are collected into periodic digests
+// @ID Save notification settings
+// @Accept json
+// @Produce json
+// @Param input body dto.NotifySettings true "email and push token"
+// @Success 200 {object} Response{Status, Message}
+// @Failure 400,404,405 {object} Response
+// @Failure 500 {object} Response
+// @Failure default {object} Response
+// @Router /users/me/notifications [put]
+func (h *Handler) NotifySettingsSave(log *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Логируем наш запрос
+		const op = "handler.NotifySettingsSave"
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		// Получаем id пользователя из контекста
+		idCtx, errCtx := GetUserID(r.Context())
+		if errCtx != nil {
+			log.Error("failed to get userID from context")
+			renderError(w, r, "", errCtx)
+			return
+		}
+
+		// Структура для записи входных данных из JSON от пользователя
+		var req dto.NotifySettings
+
+		// Анализируем запрос от пользователя
+		fail := validate.BaseValidate(log, r.Body, &req)
+		if fail != nil && fail.ValidateErr != nil {
+			log.Error("invalid request data")
+			renderError(w, r, "", fail.ValidateErr)
+			return
+		} else if fail != nil && fail.ErrMsg != "" {
+			log.Error("invalid request data")
+			renderError(w, r, "", apperr.Validation(fail.ErrMsg))
+			return
+		}
+
+		// Отправляем валидную структуру на слой сервиса
+		if errSave := h.services.Notification.SaveSettings(req, idCtx); errSave != nil {
+			log.Error("failed to save notification settings", logger.Err(errSave))
+			renderError(w, r, "Failed to save notification settings", errSave)
+			return
+		}
+
+		// Если ошибок нет отправляем успешный ответ
+		log.Info("Notification settings saved successfully")
+		render.JSON(w, r, OK("Notification settings saved successfully"))
+		return
+	}
+}
+
+// ChatNotifySet - настроить уведомления чата для текущего пользователя
+// @Summary ChatNotifySet
+// @Security ApiKeyAuth
+// @Tags Notification
+// @Description Choose which messages of the chat notify the current user: all, only mentions or none. Muted_until silences the chat completely until the given time, an empty value unmutes it
+// @ID Set chat notifications
+// @Accept json
+// @Produce json
+// @Param id path int true "chat id"
+// @Param input body dto.ChatNotify true "notification level and mute time"
+// @Success 200 {object} Response{Status, Message}
+// @Failure 400,404,405 {object} Response
+// @Failure 500 {object} Response
+// @Failure default {object} Response
+// @Router /chats/{id}/notifications [put]
+func (h *Handler) ChatNotifySet(log *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Логируем наш запрос
+		const op = "handler.ChatNotifySet"
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		// Получаем id пользователя из контекста
+		idCtx, errCtx := GetUserID(r.Context())
+		if errCtx != nil {
+			log.Error("failed to get userID from context")
+			renderError(w, r, "", errCtx)
+			return
+		}
+
+		// Получаем id чата из пути запроса
+		chatID, errID := GetPathID(r)
+		if errID != nil {
+			log.Error("invalid chat ID")
+			renderError(w, r, "", errID)
+			return
+		}
+
+		// Структура для записи входных данных из JSON от пользователя
+		var req dto.ChatNotify
+
+		// Анализируем запрос от пользователя
+		fail := validate.BaseValidate(log, r.Body, &req)
+		if fail != nil && fail.ValidateErr != nil {
+			log.Error("invalid request data")
+			renderError(w, r, "", fail.ValidateErr)
+			return
+		} else if fail != nil && fail.ErrMsg != "" {
+			log.Error("invalid request data")
+			renderError(w, r, "", apperr.Validation(fail.ErrMsg))
+			return
+		}
+
+		// Отправляем валидную структуру на слой сервиса
+		if errSet := h.services.Notification.SetChatNotify(req, chatID, idCtx); errSet != nil {
+			log.Error("failed to set chat notifications", logger.Err(errSet))
+			renderError(w, r, "Failed to set chat notifications", errSet)
+			return
+		}
+
+		// Если ошибок нет отправляем успешный ответ
+		log.Info("Chat notifications updated successfully", slog.Int64("chatID", chatID))
+		render.JSON(w, r, OK("Chat notifications updated successfully"))
+		return
+	}
+}
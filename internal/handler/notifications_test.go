@@ -0,0 +1,150 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"service-chat/internal/apperr"
+	"service-chat/internal/db/entity"
+	"service-chat/internal/dto"
+	"service-chat/internal/service"
+	mockService "service-chat/internal/service/mocks"
+)
+
+func TestHandler_Notification(t *testing.T) {
+	// Структура для последующей реализации поведения мока
+	type mockBehaviour func(s *mockService.MockNotification)
+
+	//Инициализируем контролер для мока сервиса
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Создаём мок сервиса уведомлений
+	mockNotification := mockService.NewMockNotification(ctrl)
+
+	// Создаём экземпляр обработчика
+	handler := NewHandler(&service.Service{Notification: mockNotification})
+
+	// Мокируем логгер
+	mockLog := slog.New(slog.NewJSONHandler(io.Discard, nil))
+
+	// Инициализируем сервер
+	r := chi.NewRouter()
+	r.Get("/users/me/notifications", handler.NotifySettingsGet(mockLog))
+	r.Put("/users/me/notifications", handler.NotifySettingsSave(mockLog))
+	r.Put("/chats/{id}/notifications", handler.ChatNotifySet(mockLog))
+
+	mutedUntil := time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	// Тестовая таблица с данными
+	testTable := []struct {
+		name                 string
+		method               string
+		path                 string
+		inputBody            string
+		mockBehaviour        mockBehaviour
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:   "Get settings OK",
+			method: http.MethodGet,
+			path:   "/users/me/notifications",
+			mockBehaviour: func(s *mockService.MockNotification) {
+				s.EXPECT().GetSettings(1).Return(&entity.NotifySettings{Email: "bob@example.com"}, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"status":"OK","notify_settings":{"email":"bob@example.com","push_token":""}}`,
+		},
+		{
+			name:      "Save settings OK",
+			method:    http.MethodPut,
+			path:      "/users/me/notifications",
+			inputBody: `{"email":"bob@example.com","push_token":"device-1"}`,
+			mockBehaviour: func(s *mockService.MockNotification) {
+				s.EXPECT().SaveSettings(dto.NotifySettings{Email: "bob@example.com", PushToken: "device-1"}, 1).Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"status":"OK","message":"Notification settings saved successfully"}`,
+		},
+		{
+			name:      "Save settings invalid email",
+			method:    http.MethodPut,
+			path:      "/users/me/notifications",
+			inputBody: `{"email":"bob"}`,
+			mockBehaviour: func(s *mockService.MockNotification) {
+				s.EXPECT().SaveSettings(dto.NotifySettings{Email: "bob"}, 1).Return(apperr.Validation("invalid email"))
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":"Error","code":"validation","error":"Failed to save notification settings: invalid email"}`,
+		},
+		{
+			name:      "Set chat notifications OK",
+			method:    http.MethodPut,
+			path:      "/chats/2/notifications",
+			inputBody: `{"level":"mentions","muted_until":"2030-01-02T03:04:05Z"}`,
+			mockBehaviour: func(s *mockService.MockNotification) {
+				s.EXPECT().SetChatNotify(dto.ChatNotify{Level: entity.NotifyMentions, MutedUntil: &mutedUntil}, int64(2), 1).Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"status":"OK","message":"Chat notifications updated successfully"}`,
+		},
+		{
+			name:                 "Set chat notifications invalid level",
+			method:               http.MethodPut,
+			path:                 "/chats/2/notifications",
+			inputBody:            `{"level":"loud"}`,
+			mockBehaviour:        func(s *mockService.MockNotification) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":"Error","code":"validation","error":"Field Level must be one of: all mentions none"}`,
+		},
+		{
+			name:      "Set chat notifications not a member",
+			method:    http.MethodPut,
+			path:      "/chats/2/notifications",
+			inputBody: `{"level":"none"}`,
+			mockBehaviour: func(s *mockService.MockNotification) {
+				s.EXPECT().SetChatNotify(dto.ChatNotify{Level: entity.NotifyNone}, int64(2), 1).
+					Return(apperr.NotFound("User with userID 1 does not exist in chatID 2"))
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"status":"Error","code":"not_found","error":"Failed to set chat notifications: User with userID 1 does not exist in chatID 2"}`,
+		},
+		{
+			name:                 "Set chat notifications invalid chat id",
+			method:               http.MethodPut,
+			path:                 "/chats/abc/notifications",
+			inputBody:            `{"level":"all"}`,
+			mockBehaviour:        func(s *mockService.MockNotification) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":"Error","code":"validation","error":"Invalid id in path"}`,
+		},
+	}
+
+	// Итерируемся по нашей тестовой таблице
+	for _, tt := range testTable {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockBehaviour(mockNotification)
+
+			// Готовим и выполняем тестовый запрос
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(tt.method, tt.path, bytes.NewBufferString(tt.inputBody))
+			r.ServeHTTP(w, req.WithContext(context.WithValue(req.Context(), userCtx, 1)))
+
+			// Сравниваем ожидаемый и актуальный результат
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			assert.Equal(t, tt.expectedResponseBody, strings.TrimSpace(w.Body.String()))
+		})
+	}
+}
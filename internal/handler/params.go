@@ -0,0 +1,64 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"service-chat/internal/apperr"
+)
+
+const (
+	idParam        = "id"
+	webhookIDParam = "webhookID"
+	commandIDParam = "commandID"
+)
+
+// GetPathID - достаём из пути запроса идентификатор ресурса, например /messages/{id}/reactions
+func GetPathID(r *http.Request) (int64, error) {
+	return getPathParamID(r, idParam)
+}
+
+// getPathParamID - достаём из пути запроса идентификатор с именем name, например /chats/{id}/webhooks/{webhookID}
+func getPathParamID(r *http.Request, name string) (int64, error) {
+	id, err := strconv.ParseInt(chi.URLParam(r, name), 10, 64)
+	if err != nil || id <= 0 {
+		return 0, apperr.Validation(fmt.Sprintf("Invalid %s in path", name))
+	}
+
+	return id, nil
+}
+
+// queryInt64 - необязательный числовой query параметр, отсутствующий параметр равен 0
+func queryInt64(values url.Values, name string) (int64, error) {
+	raw := values.Get(name)
+	if raw == "" {
+		return 0, nil
+	}
+
+	v, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil {
+		return 0, apperr.Validation(fmt.Sprintf("Invalid query parameter %s", name))
+	}
+
+	return v, nil
+}
+
+// queryTime - необязательный query параметр времени в формате RFC3339
+func queryTime(values url.Values, name string) (*time.Time, error) {
+	raw := values.Get(name)
+	if raw == "" {
+		return nil, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, raw)
+	if err != nil {
+		return nil, apperr.Validation(fmt.Sprintf("Invalid query parameter %s, expected RFC3339 time", name))
+	}
+
+	return &t, nil
+}
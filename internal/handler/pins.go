@@ -0,0 +1,179 @@
+package handler
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+
+	"service-chat/internal/logger"
+)
+
+// PinAdd - закрепить сообщение в чате
+// @Summary PinAdd
+// @Security ApiKeyAuth
+// @Tags Pin
+// @Description Pin message in its chat, only for chat admins
+// @ID Pin message
+// @Produce json
+// @Param id path int true "message id"
+// @Success 200 {object} Response
+// @Failure 400,404,405 {object} Response
+// @Failure 500 {object} Response
+// @Failure default {object} Response
+// @Router /messages/{id}/pin [post]
+func (h *Handler) PinAdd(log *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Логируем наш запрос
+		const op = "handler.PinAdd"
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		// Получаем id пользователя из контекста
+		idCtx, errCtx := GetUserID(r.Context())
+		if errCtx != nil {
+			log.Error("failed to get userID from context")
+			renderError(w, r, "", errCtx)
+			return
+		}
+
+		// Получаем id сообщения из пути запроса
+		messageID, errID := GetPathID(r)
+		if errID != nil {
+			log.Error("invalid message ID")
+			renderError(w, r, "", errID)
+			return
+		}
+
+		// Отправляем запрос на слой сервиса
+		if errPin := h.services.Pin.PinMessage(messageID, idCtx); errPin != nil {
+			log.Error("failed to pin message", logger.Err(errPin))
+			renderError(w, r, "Failed to pin message", errPin)
+			return
+		}
+
+		// Если ошибок нет отправляем успешный ответ
+		log.Info("Message pinned successfully", slog.Int64("messageID", messageID))
+		render.JSON(w, r, OK("Message pinned successfully"))
+		return
+	}
+}
+
+// PinDelete - открепить сообщение в чате
+// @Summary PinDelete
+// @Security ApiKeyAuth
+// @Tags Pin
+// @Description Unpin message in its chat, only for chat admins
+// @ID Unpin message
+// @Produce json
+// @Param id path int true "message id"
+// @Success 200 {object} Response
+// @Failure 400,404,405 {object} Response
+// @Failure 500 {object} Response
+// @Failure default {object} Response
+// @Router /messages/{id}/pin [delete]
+func (h *Handler) PinDelete(log *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Логируем наш запрос
+		const op = "handler.PinDelete"
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		// Получаем id пользователя из контекста
+		idCtx, errCtx := GetUserID(r.Context())
+		if errCtx != nil {
+			log.Error("failed to get userID from context")
+			renderError(w, r, "", errCtx)
+			return
+		}
+
+		// Получаем id сообщения из пути запроса
+		messageID, errID := GetPathID(r)
+		if errID != nil {
+			log.Error("invalid message ID")
+			renderError(w, r, "", errID)
+			return
+		}
+
+		// Отправляем запрос на слой сервиса
+		if errPin := h.services.Pin.UnpinMessage(messageID, idCtx); errPin != nil {
+			log.Error("failed to unpin message", logger.Err(errPin))
+			renderError(w, r, "Failed to unpin message", errPin)
+			return
+		}
+
+		// Если ошибок нет отправляем успешный ответ
+		log.Info("Message unpinned successfully", slog.Int64("messageID", messageID))
+		render.JSON(w, r, OK("Message unpinned successfully"))
+		return
+	}
+}
+
+// PinGet - получить закреплённые сообщения чата
+// @Summary PinGet
+// @Security ApiKeyAuth
+// @Tags Pin
+// @Description Get pinned messages of chat, latest pins first
+// @ID Get pins
+// @Produce json
+// @Param id path int true "chat id"
+// @Success 200 {object} Response{Status, Message, MessagesList}
+// @Failure 400,404,405 {object} Response
+// @Failure 500 {object} Response
+// @Failure default {object} Response
+// @Router /chats/{id}/pins [get]
+func (h *Handler) PinGet(log *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Логируем наш запрос
+		const op = "handler.PinGet"
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		// Получаем id пользователя из контекста
+		idCtx, errCtx := GetUserID(r.Context())
+		if errCtx != nil {
+			log.Error("failed to get userID from context")
+			renderError(w, r, "", errCtx)
+			return
+		}
+
+		// Получаем id чата из пути запроса
+		chatID, errID := GetPathID(r)
+		if errID != nil {
+			log.Error("invalid chat ID")
+			renderError(w, r, "", errID)
+			return
+		}
+
+		// Отправляем запрос на слой сервиса
+		messages, errPin := h.services.Pin.GetPins(chatID, idCtx)
+		if errPin != nil {
+			log.Error("failed to get pinned messages", logger.Err(errPin))
+			renderError(w, r, "Failed to get pinned messages", errPin)
+			return
+		}
+
+		// Если закреплённых сообщений нет
+		if len(messages) == 0 {
+			log.Info("pinned messages not found")
+			render.JSON(w, r, OK("No pinned messages found"))
+			return
+		}
+
+		// Если ошибок нет отправляем успешный ответ
+		log.Info("Pinned messages found successfully", slog.Int("count", len(messages)))
+		render.JSON(w, r, Response{
+			Status:       StatusOK,
+			Message:      "Pinned messages found successfully",
+			MessagesList: messages,
+		})
+		return
+	}
+}
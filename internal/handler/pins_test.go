@@ -0,0 +1,201 @@
+package handler
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"service-chat/internal/apperr"
+	"service-chat/internal/db/entity"
+	"service-chat/internal/service"
+	mockService "service-chat/internal/service/mocks"
+)
+
+func TestHandler_PinAdd(t *testing.T) {
+	// Структура для последующей реализации поведения мока
+	type mockBehaviour func(s *mockService.MockPin)
+
+	//Инициализируем контролер для мока сервиса
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Создаём моки сервиса закреплённых сообщений
+	mockPin := mockService.NewMockPin(ctrl)
+
+	// Создаём экземпляр обработчика
+	handler := NewHandler(&service.Service{Pin: mockPin})
+
+	// Мокируем логгер
+	mockLog := slog.New(slog.NewJSONHandler(io.Discard, nil))
+
+	// Инициализируем сервер
+	r := chi.NewRouter()
+	r.Post("/messages/{id}/pin", handler.PinAdd(mockLog))
+	r.Delete("/messages/{id}/pin", handler.PinDelete(mockLog))
+
+	// Тестовая таблица с данными
+	testTable := []struct {
+		name                 string
+		method               string
+		path                 string
+		mockBehaviour        mockBehaviour
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:   "Pin OK",
+			method: http.MethodPost,
+			path:   "/messages/5/pin",
+			mockBehaviour: func(s *mockService.MockPin) {
+				s.EXPECT().PinMessage(int64(5), 1).Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"status":"OK","message":"Message pinned successfully"}`,
+		},
+		{
+			name:   "Pin by not admin",
+			method: http.MethodPost,
+			path:   "/messages/5/pin",
+			mockBehaviour: func(s *mockService.MockPin) {
+				s.EXPECT().PinMessage(int64(5), 1).Return(apperr.Forbidden("Only chat admins can do this"))
+			},
+			expectedStatusCode:   http.StatusForbidden,
+			expectedResponseBody: `{"status":"Error","code":"forbidden","error":"Failed to pin message: Only chat admins can do this"}`,
+		},
+		{
+			name:                 "Invalid id",
+			method:               http.MethodPost,
+			path:                 "/messages/abc/pin",
+			mockBehaviour:        func(s *mockService.MockPin) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":"Error","code":"validation","error":"Invalid id in path"}`,
+		},
+		{
+			name:   "Unpin OK",
+			method: http.MethodDelete,
+			path:   "/messages/5/pin",
+			mockBehaviour: func(s *mockService.MockPin) {
+				s.EXPECT().UnpinMessage(int64(5), 1).Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"status":"OK","message":"Message unpinned successfully"}`,
+		},
+		{
+			name:   "Unpin not pinned",
+			method: http.MethodDelete,
+			path:   "/messages/5/pin",
+			mockBehaviour: func(s *mockService.MockPin) {
+				s.EXPECT().UnpinMessage(int64(5), 1).Return(apperr.NotFound("Pin not found"))
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"status":"Error","code":"not_found","error":"Failed to unpin message: Pin not found"}`,
+		},
+	}
+
+	// Итерируемся по нашей тестовой таблице
+	for _, tt := range testTable {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockBehaviour(mockPin)
+
+			// Готовим и выполняем тестовый запрос
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			r.ServeHTTP(w, req.WithContext(context.WithValue(req.Context(), userCtx, 1)))
+
+			// Сравниваем ожидаемый и актуальный результат
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			assert.Equal(t, tt.expectedResponseBody, strings.TrimSpace(w.Body.String()))
+		})
+	}
+}
+
+func TestHandler_PinGet(t *testing.T) {
+	// Структура для последующей реализации поведения мока
+	type mockBehaviour func(s *mockService.MockPin)
+
+	//Инициализируем контролер для мока сервиса
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Создаём моки сервиса закреплённых сообщений
+	mockPin := mockService.NewMockPin(ctrl)
+
+	// Создаём экземпляр обработчика
+	handler := NewHandler(&service.Service{Pin: mockPin})
+
+	// Мокируем логгер
+	mockLog := slog.New(slog.NewJSONHandler(io.Discard, nil))
+
+	// Инициализируем сервер
+	r := chi.NewRouter()
+	r.Get("/chats/{id}/pins", handler.PinGet(mockLog))
+
+	// Тестовая таблица с данными
+	testTable := []struct {
+		name                 string
+		path                 string
+		mockBehaviour        mockBehaviour
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name: "OK",
+			path: "/chats/2/pins",
+			mockBehaviour: func(s *mockService.MockPin) {
+				s.EXPECT().GetPins(int64(2), 1).
+					Return([]entity.Message{{Id: 5, Text: "rules", UserID: 1, Kind: "user", Pinned: true}}, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"status":"OK","message":"Pinned messages found successfully","messages_list":[{"id":5,"text":"rules","user_id":1,"created_at":"","is_deleted":false,"kind":"user","pinned":true}]}`,
+		},
+		{
+			name: "No pins",
+			path: "/chats/2/pins",
+			mockBehaviour: func(s *mockService.MockPin) {
+				s.EXPECT().GetPins(int64(2), 1).Return(nil, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"status":"OK","message":"No pinned messages found"}`,
+		},
+		{
+			name: "Not a member",
+			path: "/chats/2/pins",
+			mockBehaviour: func(s *mockService.MockPin) {
+				s.EXPECT().GetPins(int64(2), 1).Return(nil, apperr.NotFound("User with userID 1 does not exist in chatID 2"))
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"status":"Error","code":"not_found","error":"Failed to get pinned messages: User with userID 1 does not exist in chatID 2"}`,
+		},
+		{
+			name:                 "Invalid id",
+			path:                 "/chats/0/pins",
+			mockBehaviour:        func(s *mockService.MockPin) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":"Error","code":"validation","error":"Invalid id in path"}`,
+		},
+	}
+
+	// Итерируемся по нашей тестовой таблице
+	for _, tt := range testTable {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockBehaviour(mockPin)
+
+			// Готовим и выполняем тестовый запрос
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			r.ServeHTTP(w, req.WithContext(context.WithValue(req.Context(), userCtx, 1)))
+
+			// Сравниваем ожидаемый и актуальный результат
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			assert.Equal(t, tt.expectedResponseBody, strings.TrimSpace(w.Body.String()))
+		})
+	}
+}
@@ -0,0 +1,215 @@
+package handler
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+
+	"service-chat/internal/apperr"
+	"service-chat/internal/dto"
+	"service-chat/internal/logger"
+	"service-chat/internal/validate"
+)
+
+// PollAdd - создать опрос в чате от лица пользователя
+// @Summary PollAdd
+// @Security ApiKeyAuth
+// @Tags Poll
+// @Description Create a poll in the chat: the question is posted as a message of kind poll with 2-10 options. Multiple allows choosing several options, anonymous hides who voted, close_at stops voting at the given time. Poll results come with the message in /messages/get
+// @ID Add poll
+// @Accept json
+// @Produce json
+// @Param id path int true "chat id"
+// @Param input body dto.PollAdd true "poll question and options"
+// @Success 200 {object} Response{Status, Message}
+// @Failure 400,404,405 {object} Response
+// @Failure 500 {object} Response
+// @Failure default {object} Response
+// @Router /chats/{id}/polls [post]
+func (h *Handler) PollAdd(log *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Логируем наш запрос
+		const op = "handler.PollAdd"
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		// Получаем id пользователя из контекста
+		idCtx, errCtx := GetUserID(r.Context())
+		if errCtx != nil {
+			log.Error("failed to get userID from context")
+			renderError(w, r, "", errCtx)
+			return
+		}
+
+		// Получаем id чата из пути запроса
+		chatID, errID := GetPathID(r)
+		if errID != nil {
+			log.Error("invalid chat ID")
+			renderError(w, r, "", errID)
+			return
+		}
+
+		// Структура для записи входных данных из JSON от пользователя
+		var req dto.PollAdd
+
+		// Анализируем запрос от пользователя
+		fail := validate.BaseValidate(log, r.Body, &req)
+		if fail != nil && fail.ValidateErr != nil {
+			log.Error("invalid request data")
+			renderError(w, r, "", fail.ValidateErr)
+			return
+		} else if fail != nil && fail.ErrMsg != "" {
+			log.Error("invalid request data")
+			renderError(w, r, "", apperr.Validation(fail.ErrMsg))
+			return
+		}
+
+		// Отправляем валидную структуру на слой сервиса
+		messageID, errAdd := h.services.Poll.CreatePoll(req, chatID, idCtx)
+		if errAdd != nil {
+			log.Error("failed to create poll", logger.Err(errAdd))
+			renderError(w, r, "Failed to create poll", errAdd)
+			return
+		}
+
+		// Если ошибок нет отправляем успешный ответ
+		log.Info("Poll created successfully", slog.Int("messageID", messageID))
+		render.JSON(w, r, OK(fmt.Sprintf("Poll created successfully, id: %d", messageID)))
+		return
+	}
+}
+
+// PollVote - проголосовать в опросе от лица участника чата
+// @Summary PollVote
+// @Security ApiKeyAuth
+// @Tags Poll
+// @Description Vote in the poll of the message, the new vote replaces the previous one. A single-choice poll accepts exactly one option, a closed poll accepts no votes
+// @ID Vote in poll
+// @Accept json
+// @Produce json
+// @Param id path int true "message id"
+// @Param input body dto.PollVote true "chosen options"
+// @Success 200 {object} Response{Status, Message, Poll}
+// @Failure 400,404,405 {object} Response
+// @Failure 500 {object} Response
+// @Failure default {object} Response
+// @Router /messages/{id}/vote [post]
+func (h *Handler) PollVote(log *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Логируем наш запрос
+		const op = "handler.PollVote"
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		// Получаем id пользователя из контекста
+		idCtx, errCtx := GetUserID(r.Context())
+		if errCtx != nil {
+			log.Error("failed to get userID from context")
+			renderError(w, r, "", errCtx)
+			return
+		}
+
+		// Получаем id сообщения из пути запроса
+		messageID, errID := GetPathID(r)
+		if errID != nil {
+			log.Error("invalid message ID")
+			renderError(w, r, "", errID)
+			return
+		}
+
+		// Структура для записи входных данных из JSON от пользователя
+		var req dto.PollVote
+
+		// Анализируем запрос от пользователя
+		fail := validate.BaseValidate(log, r.Body, &req)
+		if fail != nil && fail.ValidateErr != nil {
+			log.Error("invalid request data")
+			renderError(w, r, "", fail.ValidateErr)
+			return
+		} else if fail != nil && fail.ErrMsg != "" {
+			log.Error("invalid request data")
+			renderError(w, r, "", apperr.Validation(fail.ErrMsg))
+			return
+		}
+
+		// Отправляем валидную структуру на слой сервиса
+		poll, errVote := h.services.Poll.Vote(req, messageID, idCtx)
+		if errVote != nil {
+			log.Error("failed to vote", logger.Err(errVote))
+			renderError(w, r, "Failed to vote", errVote)
+			return
+		}
+
+		// Если ошибок нет отправляем успешный ответ
+		log.Info("Vote saved successfully", slog.Int64("messageID", messageID))
+		render.JSON(w, r, Response{
+			Status:  StatusOK,
+			Message: "Vote saved successfully",
+			Poll:    poll,
+		})
+		return
+	}
+}
+
+// PollUnvote - отозвать голос в опросе от лица участника чата
+// @Summary PollUnvote
+// @Security ApiKeyAuth
+// @Tags Poll
+// @Description Retract the vote in the poll of the message, a closed poll keeps its votes
+// @ID Unvote in poll
+// @Produce json
+// @Param id path int true "message id"
+// @Success 200 {object} Response{Status, Message, Poll}
+// @Failure 400,404,405 {object} Response
+// @Failure 500 {object} Response
+// @Failure default {object} Response
+// @Router /messages/{id}/vote [delete]
+func (h *Handler) PollUnvote(log *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Логируем наш запрос
+		const op = "handler.PollUnvote"
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		// Получаем id пользователя из контекста
+		idCtx, errCtx := GetUserID(r.Context())
+		if errCtx != nil {
+			log.Error("failed to get userID from context")
+			renderError(w, r, "", errCtx)
+			return
+		}
+
+		// Получаем id сообщения из пути запроса
+		messageID, errID := GetPathID(r)
+		if errID != nil {
+			log.Error("invalid message ID")
+			renderError(w, r, "", errID)
+			return
+		}
+
+		poll, errVote := h.services.Poll.Unvote(messageID, idCtx)
+		if errVote != nil {
+			log.Error("failed to retract vote", logger.Err(errVote))
+			renderError(w, r, "Failed to retract vote", errVote)
+			return
+		}
+
+		// Если ошибок нет отправляем успешный ответ
+		log.Info("Vote retracted successfully", slog.Int64("messageID", messageID))
+		render.JSON(w, r, Response{
+			Status:  StatusOK,
+			Message: "Vote retracted successfully",
+			Poll:    poll,
+		})
+		return
+	}
+}
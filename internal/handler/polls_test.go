@@ -0,0 +1,161 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"service-chat/internal/apperr"
+	"service-chat/internal/db/entity"
+	"service-chat/internal/dto"
+	"service-chat/internal/service"
+	mockService "service-chat/internal/service/mocks"
+)
+
+func TestHandler_Poll(t *testing.T) {
+	// Структура для последующей реализации поведения мока
+	type mockBehaviour func(s *mockService.MockPoll)
+
+	//Инициализируем контролер для мока сервиса
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Создаём моки сервиса опросов
+	mockPoll := mockService.NewMockPoll(ctrl)
+
+	// Создаём экземпляр обработчика
+	handler := NewHandler(&service.Service{Poll: mockPoll})
+
+	// Мокируем логгер
+	mockLog := slog.New(slog.NewJSONHandler(io.Discard, nil))
+
+	// Инициализируем сервер
+	r := chi.NewRouter()
+	r.Post("/chats/{id}/polls", handler.PollAdd(mockLog))
+	r.Post("/messages/{id}/vote", handler.PollVote(mockLog))
+	r.Delete("/messages/{id}/vote", handler.PollUnvote(mockLog))
+
+	poll := &entity.Poll{ID: 4, Multiple: true, TotalVoters: 1, Options: []entity.PollOption{
+		{ID: 7, Text: "Pizza", Votes: 1, VotedByMe: true, Voters: []int64{1}},
+		{ID: 8, Text: "Sushi"},
+	}}
+
+	// Тестовая таблица с данными
+	testTable := []struct {
+		name                 string
+		method               string
+		path                 string
+		inputBody            string
+		mockBehaviour        mockBehaviour
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:      "Add OK",
+			method:    http.MethodPost,
+			path:      "/chats/2/polls",
+			inputBody: `{"question":"Lunch?","options":["Pizza","Sushi"],"multiple":true}`,
+			mockBehaviour: func(s *mockService.MockPoll) {
+				s.EXPECT().CreatePoll(dto.PollAdd{Question: "Lunch?", Options: []string{"Pizza", "Sushi"}, Multiple: true}, int64(2), 1).
+					Return(12, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"status":"OK","message":"Poll created successfully, id: 12"}`,
+		},
+		{
+			name:                 "Add without options",
+			method:               http.MethodPost,
+			path:                 "/chats/2/polls",
+			inputBody:            `{"question":"Lunch?"}`,
+			mockBehaviour:        func(s *mockService.MockPoll) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":"Error","code":"validation","error":"Field Options is a required field"}`,
+		},
+		{
+			name:      "Add too few options",
+			method:    http.MethodPost,
+			path:      "/chats/2/polls",
+			inputBody: `{"question":"Lunch?","options":["Pizza"]}`,
+			mockBehaviour: func(s *mockService.MockPoll) {
+				s.EXPECT().CreatePoll(dto.PollAdd{Question: "Lunch?", Options: []string{"Pizza"}}, int64(2), 1).
+					Return(0, apperr.Validation("Poll must have from 2 to 10 options"))
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":"Error","code":"validation","error":"Failed to create poll: Poll must have from 2 to 10 options"}`,
+		},
+		{
+			name:      "Vote OK",
+			method:    http.MethodPost,
+			path:      "/messages/12/vote",
+			inputBody: `{"option_ids":[7]}`,
+			mockBehaviour: func(s *mockService.MockPoll) {
+				s.EXPECT().Vote(dto.PollVote{OptionIDs: []int64{7}}, int64(12), 1).Return(poll, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"status":"OK","message":"Vote saved successfully","poll":{"id":4,"multiple":true,"anonymous":false,"closed":false,"total_voters":1,"options":[{"id":7,"text":"Pizza","votes":1,"voted_by_me":true,"voters":[1]},{"id":8,"text":"Sushi","votes":0,"voted_by_me":false}]}}`,
+		},
+		{
+			name:      "Vote closed poll",
+			method:    http.MethodPost,
+			path:      "/messages/12/vote",
+			inputBody: `{"option_ids":[7]}`,
+			mockBehaviour: func(s *mockService.MockPoll) {
+				s.EXPECT().Vote(dto.PollVote{OptionIDs: []int64{7}}, int64(12), 1).Return(nil, apperr.Conflict("Poll is closed"))
+			},
+			expectedStatusCode:   http.StatusConflict,
+			expectedResponseBody: `{"status":"Error","code":"conflict","error":"Failed to vote: Poll is closed"}`,
+		},
+		{
+			name:                 "Vote without options",
+			method:               http.MethodPost,
+			path:                 "/messages/12/vote",
+			inputBody:            `{}`,
+			mockBehaviour:        func(s *mockService.MockPoll) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":"Error","code":"validation","error":"Field OptionIDs is a required field"}`,
+		},
+		{
+			name:   "Unvote OK",
+			method: http.MethodDelete,
+			path:   "/messages/12/vote",
+			mockBehaviour: func(s *mockService.MockPoll) {
+				s.EXPECT().Unvote(int64(12), 1).Return(&entity.Poll{ID: 4, Options: []entity.PollOption{{ID: 7, Text: "Pizza"}}}, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"status":"OK","message":"Vote retracted successfully","poll":{"id":4,"multiple":false,"anonymous":false,"closed":false,"total_voters":0,"options":[{"id":7,"text":"Pizza","votes":0,"voted_by_me":false}]}}`,
+		},
+		{
+			name:                 "Unvote invalid message id",
+			method:               http.MethodDelete,
+			path:                 "/messages/abc/vote",
+			mockBehaviour:        func(s *mockService.MockPoll) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":"Error","code":"validation","error":"Invalid id in path"}`,
+		},
+	}
+
+	// Итерируемся по нашей тестовой таблице
+	for _, tt := range testTable {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockBehaviour(mockPoll)
+
+			// Готовим и выполняем тестовый запрос
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(tt.method, tt.path, bytes.NewBufferString(tt.inputBody))
+			r.ServeHTTP(w, req.WithContext(context.WithValue(req.Context(), userCtx, 1)))
+
+			// Сравниваем ожидаемый и актуальный результат
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			assert.Equal(t, tt.expectedResponseBody, strings.TrimSpace(w.Body.String()))
+		})
+	}
+}
@@ -0,0 +1,212 @@
+package handler
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+
+	"service-chat/internal/apperr"
+	"service-chat/internal/dto"
+	"service-chat/internal/logger"
+	"service-chat/internal/validate"
+)
+
+// PresenceGet - статусы участников чата
+// @Summary PresenceGet
+// @Security ApiKeyAuth
+// @Tags Presence
+// @Description Get online status, last seen time and typing indicator of every member of the chat
+// @ID Get presence
+// @Produce json
+// @Param id path int true "chat id"
+// @Success 200 {object} Response{Status, Message, PresenceList}
+// @Failure 400,404,405 {object} Response
+// @Failure 500 {object} Response
+// @Failure default {object} Response
+// @Router /chats/{id}/presence [get]
+func (h *Handler) PresenceGet(log *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Логируем наш запрос
+		const op = "handler.PresenceGet"
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		// Получаем id пользователя из контекста
+		idCtx, errCtx := GetUserID(r.Context())
+		if errCtx != nil {
+			log.Error("failed to get userID from context")
+			renderError(w, r, "", errCtx)
+			return
+		}
+
+		// Получаем id чата из пути запроса
+		chatID, errID := GetPathID(r)
+		if errID != nil {
+			log.Error("invalid chat ID")
+			renderError(w, r, "", errID)
+			return
+		}
+
+		// Отправляем запрос на слой сервиса
+		members, errGet := h.services.Presence.GetPresence(chatID, idCtx)
+		if errGet != nil {
+			log.Error("failed to get presence", logger.Err(errGet))
+			renderError(w, r, "Failed to get presence", errGet)
+			return
+		}
+
+		// Если ошибок нет отправляем успешный ответ
+		log.Info("Presence found successfully", slog.Int("count", len(members)))
+		render.JSON(w, r, Response{
+			Status:       StatusOK,
+			Message:      "Presence found successfully",
+			PresenceList: members,
+		})
+		return
+	}
+}
+
+// PresenceSet - сменить статус текущего пользователя
+// @Summary PresenceSet
+// @Security ApiKeyAuth
+// @Tags Presence
+// @Description Mark the current user as away or back online. The user must have an open /ws or /events connection
+// @ID Set presence
+// @Accept json
+// @Produce json
+// @Param input body dto.PresenceSet true "status: online or away"
+// @Success 200 {object} Response{Status, Message}
+// @Failure 400,404,405 {object} Response
+// @Failure 500 {object} Response
+// @Failure default {object} Response
+// @Router /users/me/presence [put]
+func (h *Handler) PresenceSet(log *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Логируем наш запрос
+		const op = "handler.PresenceSet"
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		// Получаем id пользователя из контекста
+		idCtx, errCtx := GetUserID(r.Context())
+		if errCtx != nil {
+			log.Error("failed to get userID from context")
+			renderError(w, r, "", errCtx)
+			return
+		}
+
+		// Структура для записи входных данных из JSON от пользователя
+		var req dto.PresenceSet
+
+		// Анализируем запрос от пользователя
+		fail := validate.BaseValidate(log, r.Body, &req)
+		if fail != nil && fail.ValidateErr != nil {
+			log.Error("invalid request data")
+			renderError(w, r, "", fail.ValidateErr)
+			return
+		} else if fail != nil && fail.ErrMsg != "" {
+			log.Error("invalid request data")
+			renderError(w, r, "", apperr.Validation(fail.ErrMsg))
+			return
+		}
+
+		// Отправляем валидную структуру на слой сервиса
+		if errSet := h.services.Presence.SetStatus(req, idCtx); errSet != nil {
+			log.Error("failed to set presence", logger.Err(errSet))
+			renderError(w, r, "Failed to set presence", errSet)
+			return
+		}
+
+		// Если ошибок нет отправляем успешный ответ
+		log.Info("Presence updated successfully", slog.String("status", req.Status))
+		render.JSON(w, r, OK("Presence updated successfully"))
+		return
+	}
+}
+
+// TypingStart - сообщить участникам чата, что пользователь печатает
+// @Summary TypingStart
+// @Security ApiKeyAuth
+// @Tags Presence
+// @Description Notify chat members that the current user is typing. The indicator expires automatically,
+// @Description clients repeat the request while the user keeps typing
+// @ID Start typing
+// @Produce json
+// @Param id path int true "chat id"
+// @Success 200 {object} Response{Status, Message}
+// @Failure 400,404,405 {object} Response
+// @Failure 500 {object} Response
+// @Failure default {object} Response
+// @Router /chats/{id}/typing [post]
+func (h *Handler) TypingStart(log *slog.Logger) http.HandlerFunc {
+	return h.typing(log, "handler.TypingStart", true)
+}
+
+// TypingStop - сообщить участникам чата, что пользователь перестал печатать
+// @Summary TypingStop
+// @Security ApiKeyAuth
+// @Tags Presence
+// @Description Notify chat members that the current user stopped typing
+// @ID Stop typing
+// @Produce json
+// @Param id path int true "chat id"
+// @Success 200 {object} Response{Status, Message}
+// @Failure 400,404,405 {object} Response
+// @Failure 500 {object} Response
+// @Failure default {object} Response
+// @Router /chats/{id}/typing [delete]
+func (h *Handler) TypingStop(log *slog.Logger) http.HandlerFunc {
+	return h.typing(log, "handler.TypingStop", false)
+}
+
+// typing - общий обработчик начала и окончания набора текста
+func (h *Handler) typing(log *slog.Logger, op string, started bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Логируем наш запрос
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		// Получаем id пользователя из контекста
+		idCtx, errCtx := GetUserID(r.Context())
+		if errCtx != nil {
+			log.Error("failed to get userID from context")
+			renderError(w, r, "", errCtx)
+			return
+		}
+
+		// Получаем id чата из пути запроса
+		chatID, errID := GetPathID(r)
+		if errID != nil {
+			log.Error("invalid chat ID")
+			renderError(w, r, "", errID)
+			return
+		}
+
+		// Отправляем запрос на слой сервиса
+		var errTyping error
+		msg := "Typing started"
+		if started {
+			errTyping = h.services.Presence.StartTyping(chatID, idCtx)
+		} else {
+			errTyping, msg = h.services.Presence.StopTyping(chatID, idCtx), "Typing stopped"
+		}
+		if errTyping != nil {
+			log.Error("failed to update typing", logger.Err(errTyping))
+			renderError(w, r, "Failed to update typing", errTyping)
+			return
+		}
+
+		// Если ошибок нет отправляем успешный ответ
+		log.Info(msg)
+		render.JSON(w, r, OK(msg))
+		return
+	}
+}
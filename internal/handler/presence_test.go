@@ -0,0 +1,168 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"service-chat/internal/apperr"
+	"service-chat/internal/db/entity"
+	"service-chat/internal/dto"
+	"service-chat/internal/service"
+	mockService "service-chat/internal/service/mocks"
+)
+
+func TestHandler_Presence(t *testing.T) {
+	// Структура для последующей реализации поведения мока
+	type mockBehaviour func(s *mockService.MockPresence)
+
+	//Инициализируем контролер для мока сервиса
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Создаём моки сервиса статусов
+	mockPresence := mockService.NewMockPresence(ctrl)
+
+	// Создаём экземпляр обработчика
+	handler := NewHandler(&service.Service{Presence: mockPresence})
+
+	// Мокируем логгер
+	mockLog := slog.New(slog.NewJSONHandler(io.Discard, nil))
+
+	// Инициализируем сервер
+	r := chi.NewRouter()
+	r.Get("/chats/{id}/presence", handler.PresenceGet(mockLog))
+	r.Post("/chats/{id}/typing", handler.TypingStart(mockLog))
+	r.Delete("/chats/{id}/typing", handler.TypingStop(mockLog))
+	r.Put("/users/me/presence", handler.PresenceSet(mockLog))
+
+	// Тестовая таблица с данными
+	testTable := []struct {
+		name                 string
+		method               string
+		path                 string
+		inputBody            string
+		mockBehaviour        mockBehaviour
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:   "Get OK",
+			method: http.MethodGet,
+			path:   "/chats/2/presence",
+			mockBehaviour: func(s *mockService.MockPresence) {
+				s.EXPECT().GetPresence(int64(2), 1).Return([]entity.Presence{
+					{UserID: 1, Status: entity.PresenceOnline},
+					{UserID: 3, Status: entity.PresenceOffline, LastSeen: "2024-01-01T00:00:00Z", Typing: false},
+				}, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"status":"OK","message":"Presence found successfully","presence_list":[{"user_id":1,"status":"online","typing":false},{"user_id":3,"status":"offline","last_seen":"2024-01-01T00:00:00Z","typing":false}]}`,
+		},
+		{
+			name:                 "Get invalid id",
+			method:               http.MethodGet,
+			path:                 "/chats/abc/presence",
+			mockBehaviour:        func(s *mockService.MockPresence) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":"Error","code":"validation","error":"Invalid id in path"}`,
+		},
+		{
+			name:   "Get not a member",
+			method: http.MethodGet,
+			path:   "/chats/2/presence",
+			mockBehaviour: func(s *mockService.MockPresence) {
+				s.EXPECT().GetPresence(int64(2), 1).Return(nil, apperr.NotFound("User with userID 1 does not exist in chatID 2"))
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"status":"Error","code":"not_found","error":"Failed to get presence: User with userID 1 does not exist in chatID 2"}`,
+		},
+		{
+			name:      "Set OK",
+			method:    http.MethodPut,
+			path:      "/users/me/presence",
+			inputBody: `{"status":"away"}`,
+			mockBehaviour: func(s *mockService.MockPresence) {
+				s.EXPECT().SetStatus(dto.PresenceSet{Status: "away"}, 1).Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"status":"OK","message":"Presence updated successfully"}`,
+		},
+		{
+			name:                 "Set invalid status",
+			method:               http.MethodPut,
+			path:                 "/users/me/presence",
+			inputBody:            `{"status":"offline"}`,
+			mockBehaviour:        func(s *mockService.MockPresence) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":"Error","code":"validation","error":"Field Status must be one of: online away"}`,
+		},
+		{
+			name:      "Set offline user",
+			method:    http.MethodPut,
+			path:      "/users/me/presence",
+			inputBody: `{"status":"online"}`,
+			mockBehaviour: func(s *mockService.MockPresence) {
+				s.EXPECT().SetStatus(dto.PresenceSet{Status: "online"}, 1).
+					Return(apperr.Conflict("user is offline, connect to /ws or /events first"))
+			},
+			expectedStatusCode:   http.StatusConflict,
+			expectedResponseBody: `{"status":"Error","code":"conflict","error":"Failed to set presence: user is offline, connect to /ws or /events first"}`,
+		},
+		{
+			name:   "Typing start OK",
+			method: http.MethodPost,
+			path:   "/chats/2/typing",
+			mockBehaviour: func(s *mockService.MockPresence) {
+				s.EXPECT().StartTyping(int64(2), 1).Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"status":"OK","message":"Typing started"}`,
+		},
+		{
+			name:   "Typing stop OK",
+			method: http.MethodDelete,
+			path:   "/chats/2/typing",
+			mockBehaviour: func(s *mockService.MockPresence) {
+				s.EXPECT().StopTyping(int64(2), 1).Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"status":"OK","message":"Typing stopped"}`,
+		},
+		{
+			name:   "Typing not a member",
+			method: http.MethodPost,
+			path:   "/chats/2/typing",
+			mockBehaviour: func(s *mockService.MockPresence) {
+				s.EXPECT().StartTyping(int64(2), 1).Return(apperr.NotFound("User with userID 1 does not exist in chatID 2"))
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"status":"Error","code":"not_found","error":"Failed to update typing: User with userID 1 does not exist in chatID 2"}`,
+		},
+	}
+
+	// Итерируемся по нашей тестовой таблице
+	for _, tt := range testTable {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockBehaviour(mockPresence)
+
+			// Готовим и выполняем тестовый запрос
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(tt.method, tt.path, bytes.NewBufferString(tt.inputBody))
+			r.ServeHTTP(w, req.WithContext(context.WithValue(req.Context(), userCtx, 1)))
+
+			// Сравниваем ожидаемый и актуальный результат
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			assert.Equal(t, tt.expectedResponseBody, strings.TrimSpace(w.Body.String()))
+		})
+	}
+}
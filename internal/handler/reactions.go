@@ -0,0 +1,162 @@
+package handler
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+
+	"service-chat/internal/apperr"
+	"service-chat/internal/dto"
+	"service-chat/internal/logger"
+	"service-chat/internal/validate"
+)
+
+// ReactionAdd - добавить реакцию на сообщение от лица пользователя
+// @Summary ReactionAdd
+// @Security ApiKeyAuth
+// @Tags Reaction
+// @Description Add reaction to message
+// @ID Add reaction
+// @Accept json
+// @Produce json
+// @Param id path int true "message id"
+// @Param input body dto.ReactionAdd true "reaction info"
+// @Success 200 {object} Response{Status, Message, ReactionsList}
+// @Failure 400,404,405 {object} Response
+// @Failure 500 {object} Response
+// @Failure default {object} Response
+// @Router /messages/{id}/reactions [post]
+func (h *Handler) ReactionAdd(log *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Логируем наш запрос
+		const op = "handler.ReactionAdd"
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		// Получаем id пользователя из контекста
+		idCtx, errCtx := GetUserID(r.Context())
+		if errCtx != nil {
+			log.Error("failed to get userID from context")
+			renderError(w, r, "", errCtx)
+			return
+		}
+
+		// Получаем id сообщения из пути запроса
+		messageID, errID := GetPathID(r)
+		if errID != nil {
+			log.Error("invalid message ID")
+			renderError(w, r, "", errID)
+			return
+		}
+
+		// Структура для записи входных данных из JSON от пользователя
+		var req dto.ReactionAdd
+
+		// Анализируем запрос от пользователя
+		fail := validate.BaseValidate(log, r.Body, &req)
+		if fail != nil && fail.ValidateErr != nil {
+			log.Error("invalid request data")
+			renderError(w, r, "", fail.ValidateErr)
+			return
+		} else if fail != nil && fail.ErrMsg != "" {
+			log.Error("invalid request data")
+			renderError(w, r, "", apperr.Validation(fail.ErrMsg))
+			return
+		}
+
+		// Отправляем валидную структуру на слой сервиса
+		reactions, errReact := h.services.Reaction.AddReaction(req, messageID, idCtx)
+		if errReact != nil {
+			log.Error("failed to add reaction", logger.Err(errReact))
+			renderError(w, r, "Failed to add reaction", errReact)
+			return
+		}
+
+		// Если ошибок нет отправляем успешный ответ
+		log.Info("Reaction added successfully", slog.Int64("messageID", messageID))
+		render.JSON(w, r, Response{
+			Status:        StatusOK,
+			Message:       "Reaction added successfully",
+			ReactionsList: reactions,
+		})
+		return
+	}
+}
+
+// ReactionDelete - убрать реакцию на сообщение от лица пользователя
+// @Summary ReactionDelete
+// @Security ApiKeyAuth
+// @Tags Reaction
+// @Description Delete reaction from message
+// @ID Delete reaction
+// @Accept json
+// @Produce json
+// @Param id path int true "message id"
+// @Param input body dto.ReactionDelete true "reaction info"
+// @Success 200 {object} Response{Status, Message, ReactionsList}
+// @Failure 400,404,405 {object} Response
+// @Failure 500 {object} Response
+// @Failure default {object} Response
+// @Router /messages/{id}/reactions [delete]
+func (h *Handler) ReactionDelete(log *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Логируем наш запрос
+		const op = "handler.ReactionDelete"
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		// Получаем id пользователя из контекста
+		idCtx, errCtx := GetUserID(r.Context())
+		if errCtx != nil {
+			log.Error("failed to get userID from context")
+			renderError(w, r, "", errCtx)
+			return
+		}
+
+		// Получаем id сообщения из пути запроса
+		messageID, errID := GetPathID(r)
+		if errID != nil {
+			log.Error("invalid message ID")
+			renderError(w, r, "", errID)
+			return
+		}
+
+		// Структура для записи входных данных из JSON от пользователя
+		var req dto.ReactionDelete
+
+		// Анализируем запрос от пользователя
+		fail := validate.BaseValidate(log, r.Body, &req)
+		if fail != nil && fail.ValidateErr != nil {
+			log.Error("invalid request data")
+			renderError(w, r, "", fail.ValidateErr)
+			return
+		} else if fail != nil && fail.ErrMsg != "" {
+			log.Error("invalid request data")
+			renderError(w, r, "", apperr.Validation(fail.ErrMsg))
+			return
+		}
+
+		// Отправляем валидную структуру на слой сервиса
+		reactions, errReact := h.services.Reaction.DeleteReaction(req, messageID, idCtx)
+		if errReact != nil {
+			log.Error("failed to delete reaction", logger.Err(errReact))
+			renderError(w, r, "Failed to delete reaction", errReact)
+			return
+		}
+
+		// Если ошибок нет отправляем успешный ответ
+		log.Info("Reaction deleted successfully", slog.Int64("messageID", messageID))
+		render.JSON(w, r, Response{
+			Status:        StatusOK,
+			Message:       "Reaction deleted successfully",
+			ReactionsList: reactions,
+		})
+		return
+	}
+}
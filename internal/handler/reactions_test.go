@@ -0,0 +1,224 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"service-chat/internal/db/entity"
+	"service-chat/internal/dto"
+	"service-chat/internal/service"
+	mockService "service-chat/internal/service/mocks"
+)
+
+func TestHandler_ReactionAdd(t *testing.T) {
+	// Структура для последующей реализации поведения мока
+	type mockBehaviour func(s *mockService.MockReaction, reaction dto.ReactionAdd)
+
+	//Инициализируем контролер для мока сервиса
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Создаём моки сервиса реакций
+	mockReaction := mockService.NewMockReaction(ctrl)
+
+	// Создаём объект сервиса в который передадим наш мок реакций
+	services := &service.Service{Reaction: mockReaction}
+
+	// Создаём экземпляр обработчика
+	handler := NewHandler(services)
+
+	// Мокируем логгер
+	mockLog := slog.New(slog.NewJSONHandler(io.Discard, nil))
+
+	// Инициализируем сервер
+	r := chi.NewRouter()
+	r.Post("/messages/{id}/reactions", handler.ReactionAdd(mockLog))
+
+	// Тестовая таблица с данными
+	testTable := []struct {
+		name                 string
+		path                 string
+		inputBody            string
+		inputReaction        dto.ReactionAdd
+		mockBehaviour        mockBehaviour
+		unauthorized         bool
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:          "OK",
+			path:          "/messages/1/reactions",
+			inputBody:     `{"emoji": "👍"}`,
+			inputReaction: dto.ReactionAdd{Emoji: "👍"},
+			mockBehaviour: func(s *mockService.MockReaction, reaction dto.ReactionAdd) {
+				s.EXPECT().AddReaction(reaction, int64(1), 1).Return([]entity.Reaction{
+					{Emoji: "👍", Count: 2, ReactedByMe: true},
+				}, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"status":"OK","message":"Reaction added successfully","reactions_list":[{"emoji":"👍","count":2,"reacted_by_me":true}]}`,
+		},
+		{
+			name:                 "Invalid message id",
+			path:                 "/messages/abc/reactions",
+			inputBody:            `{"emoji": "👍"}`,
+			mockBehaviour:        func(s *mockService.MockReaction, reaction dto.ReactionAdd) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":"Error","code":"validation","error":"Invalid id in path"}`,
+		},
+		{
+			name:                 "Required field emoji is missing",
+			path:                 "/messages/1/reactions",
+			inputBody:            `{}`,
+			mockBehaviour:        func(s *mockService.MockReaction, reaction dto.ReactionAdd) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":"Error","code":"validation","error":"Field Emoji is a required field"}`,
+		},
+		{
+			name:                 "Request body is nil",
+			path:                 "/messages/1/reactions",
+			mockBehaviour:        func(s *mockService.MockReaction, reaction dto.ReactionAdd) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":"Error","code":"validation","error":"Empty request"}`,
+		},
+		{
+			name:          "Other error",
+			path:          "/messages/1/reactions",
+			inputBody:     `{"emoji": "👍"}`,
+			inputReaction: dto.ReactionAdd{Emoji: "👍"},
+			mockBehaviour: func(s *mockService.MockReaction, reaction dto.ReactionAdd) {
+				s.EXPECT().AddReaction(reaction, int64(1), 1).Return(nil, errors.New("some error"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: `{"status":"Error","code":"internal","error":"Failed to add reaction"}`,
+		},
+		{
+			name:                 "User id not found",
+			path:                 "/messages/1/reactions",
+			inputBody:            `{"emoji": "👍"}`,
+			mockBehaviour:        func(s *mockService.MockReaction, reaction dto.ReactionAdd) {},
+			unauthorized:         true,
+			expectedStatusCode:   http.StatusUnauthorized,
+			expectedResponseBody: `{"status":"Error","code":"unauthorized","error":"user id not found"}`,
+		},
+	}
+
+	// Итерируемся по нашей тестовой таблице
+	for _, tt := range testTable {
+		t.Run(tt.name, func(t *testing.T) {
+			// Передаём структуру реакции
+			tt.mockBehaviour(mockReaction, tt.inputReaction)
+
+			// Готовим тестовый запрос
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, tt.path, strings.NewReader(tt.inputBody))
+
+			// Выполняем запрос
+			if tt.unauthorized {
+				r.ServeHTTP(w, req)
+			} else {
+				r.ServeHTTP(w, req.WithContext(context.WithValue(req.Context(), userCtx, 1)))
+			}
+
+			// Сравниваем ожидаемый и актуальный результат
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			assert.Equal(t, tt.expectedResponseBody, strings.TrimSpace(w.Body.String()))
+		})
+	}
+}
+
+func TestHandler_ReactionDelete(t *testing.T) {
+	// Структура для последующей реализации поведения мока
+	type mockBehaviour func(s *mockService.MockReaction, reaction dto.ReactionDelete)
+
+	//Инициализируем контролер для мока сервиса
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Создаём моки сервиса реакций
+	mockReaction := mockService.NewMockReaction(ctrl)
+
+	// Создаём объект сервиса в который передадим наш мок реакций
+	services := &service.Service{Reaction: mockReaction}
+
+	// Создаём экземпляр обработчика
+	handler := NewHandler(services)
+
+	// Мокируем логгер
+	mockLog := slog.New(slog.NewJSONHandler(io.Discard, nil))
+
+	// Инициализируем сервер
+	r := chi.NewRouter()
+	r.Delete("/messages/{id}/reactions", handler.ReactionDelete(mockLog))
+
+	// Тестовая таблица с данными
+	testTable := []struct {
+		name                 string
+		path                 string
+		inputBody            string
+		inputReaction        dto.ReactionDelete
+		mockBehaviour        mockBehaviour
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:          "OK last reaction removed",
+			path:          "/messages/1/reactions",
+			inputBody:     `{"emoji": "👍"}`,
+			inputReaction: dto.ReactionDelete{Emoji: "👍"},
+			mockBehaviour: func(s *mockService.MockReaction, reaction dto.ReactionDelete) {
+				s.EXPECT().DeleteReaction(reaction, int64(1), 1).Return(nil, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"status":"OK","message":"Reaction deleted successfully"}`,
+		},
+		{
+			name:                 "Zero message id",
+			path:                 "/messages/0/reactions",
+			inputBody:            `{"emoji": "👍"}`,
+			mockBehaviour:        func(s *mockService.MockReaction, reaction dto.ReactionDelete) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":"Error","code":"validation","error":"Invalid id in path"}`,
+		},
+		{
+			name:          "Other error",
+			path:          "/messages/1/reactions",
+			inputBody:     `{"emoji": "👍"}`,
+			inputReaction: dto.ReactionDelete{Emoji: "👍"},
+			mockBehaviour: func(s *mockService.MockReaction, reaction dto.ReactionDelete) {
+				s.EXPECT().DeleteReaction(reaction, int64(1), 1).Return(nil, errors.New("some error"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: `{"status":"Error","code":"internal","error":"Failed to delete reaction"}`,
+		},
+	}
+
+	// Итерируемся по нашей тестовой таблице
+	for _, tt := range testTable {
+		t.Run(tt.name, func(t *testing.T) {
+			// Передаём структуру реакции
+			tt.mockBehaviour(mockReaction, tt.inputReaction)
+
+			// Готовим тестовый запрос
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodDelete, tt.path, strings.NewReader(tt.inputBody))
+
+			// Выполняем запрос
+			r.ServeHTTP(w, req.WithContext(context.WithValue(req.Context(), userCtx, 1)))
+
+			// Сравниваем ожидаемый и актуальный результат
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			assert.Equal(t, tt.expectedResponseBody, strings.TrimSpace(w.Body.String()))
+		})
+	}
+}
@@ -1,11 +1,15 @@
 package handler
 
 import (
+	"errors"
 	"fmt"
+	"net/http"
 	"strings"
 
+	"github.com/go-chi/render"
 	"github.com/go-playground/validator/v10"
 
+	"service-chat/internal/apperr"
 	"service-chat/internal/db/entity"
 )
 
@@ -14,14 +18,52 @@ const (
 	StatusError = "Error"
 )
 
+// errInternal - текст ответа на внутреннюю ошибку, если у ответа нет своего префикса
+const errInternal = "Internal server error"
+
+// httpStatus - HTTP статус ответа для каждого кода ошибки, ошибки без кода отдаются как 500
+var httpStatus = map[string]int{
+	apperr.CodeValidation:   http.StatusBadRequest,
+	apperr.CodeUnauthorized: http.StatusUnauthorized,
+	apperr.CodeForbidden:    http.StatusForbidden,
+	apperr.CodeNotFound:     http.StatusNotFound,
+	apperr.CodeConflict:     http.StatusConflict,
+	apperr.CodeRateLimited:  http.StatusTooManyRequests,
+	apperr.CodeUnavailable:  http.StatusServiceUnavailable,
+}
+
 type Response struct {
-	Status       string                `json:"status"`
-	Error        string                `json:"error,omitempty"`
-	Message      string                `json:"message,omitempty"`
-	MessagesList []entity.Message      `json:"messages_list,omitempty"`
-	ChatsList    []entity.Chat         `json:"chats_list,omitempty"`
-	DelChatsList []entity.DeletedChats `json:"del_chats_list,omitempty"`
-	DelMsgList   []entity.DelMsg       `json:"del_msg_list,omitempty"`
+	Status           string                    `json:"status"`
+	Code             string                    `json:"code,omitempty"`
+	Error            string                    `json:"error,omitempty"`
+	Message          string                    `json:"message,omitempty"`
+	MessagesList     []entity.Message          `json:"messages_list,omitempty"`
+	ChatsList        []entity.Chat             `json:"chats_list,omitempty"`
+	DelChatsList     []entity.DeletedChats     `json:"del_chats_list,omitempty"`
+	DelMsgList       []entity.DelMsg           `json:"del_msg_list,omitempty"`
+	ReactionsList    []entity.Reaction         `json:"reactions_list,omitempty"`
+	AttachmentsList  []entity.Attachment       `json:"attachments_list,omitempty"`
+	SearchResults    []entity.SearchResult     `json:"search_results,omitempty"`
+	NextCursor       string                    `json:"next_cursor,omitempty"`
+	ForwardedList    []entity.Forwarded        `json:"forwarded_list,omitempty"`
+	ScheduledList    []entity.ScheduledMessage `json:"scheduled_list,omitempty"`
+	Draft            *entity.Draft             `json:"draft,omitempty"`
+	PresenceList     []entity.Presence         `json:"presence_list,omitempty"`
+	Webhook          *entity.Webhook           `json:"webhook,omitempty"`
+	WebhooksList     []entity.Webhook          `json:"webhooks_list,omitempty"`
+	AttemptsList     []entity.WebhookAttempt   `json:"attempts_list,omitempty"`
+	IncomingWebhook  *entity.IncomingWebhook   `json:"incoming_webhook,omitempty"`
+	IncomingList     []entity.IncomingWebhook  `json:"incoming_list,omitempty"`
+	CommandReply     *entity.CommandReply      `json:"command_reply,omitempty"`
+	Command          *entity.ChatCommand       `json:"command,omitempty"`
+	CommandsList     []entity.ChatCommand      `json:"commands_list,omitempty"`
+	Poll             *entity.Poll              `json:"poll,omitempty"`
+	NotifySettings   *entity.NotifySettings    `json:"notify_settings,omitempty"`
+	BlocksList       []entity.Block            `json:"blocks_list,omitempty"`
+	ReportsList      []entity.Report           `json:"reports_list,omitempty"`
+	ModerationAction *entity.ModerationAction  `json:"moderation_action,omitempty"`
+	ActionsList      []entity.ModerationAction `json:"actions_list,omitempty"`
+	FiltersList      []entity.ChatFilter       `json:"filters_list,omitempty"`
 }
 
 func OK(msg string) Response {
@@ -49,6 +91,8 @@ func ValidationError(errs validator.ValidationErrors) Response {
 			errMsgs = append(errMsgs, fmt.Sprintf("Field %s cannot exceed %s characters", err.Field(), err.Param()))
 		case "min":
 			errMsgs = append(errMsgs, fmt.Sprintf("Field %s must contain at least %s characters", err.Field(), err.Param()))
+		case "oneof":
+			errMsgs = append(errMsgs, fmt.Sprintf("Field %s must be one of: %s", err.Field(), err.Param()))
 		case "containsany":
 			errMsgs = append(errMsgs, fmt.Sprintf("Field %s must contain Latin letters and Arabic numerals, as well as the symbols @#$&*()", err.Field()))
 		case "excludesall":
@@ -60,6 +104,44 @@ func ValidationError(errs validator.ValidationErrors) Response {
 
 	return Response{
 		Status: StatusError,
+		Code:   apperr.CodeValidation,
 		Error:  strings.Join(errMsgs, ", "),
 	}
 }
+
+// ErrorResponse - ответ с ошибкой err и его HTTP статус. Текст ошибки с кодом добавляется после prefix,
+// текст остальных ошибок клиенту не показывается, чтобы наружу не попали ошибки бд
+func ErrorResponse(prefix string, err error) (int, Response) {
+	var errsValidate validator.ValidationErrors
+	if errors.As(err, &errsValidate) {
+		return http.StatusBadRequest, ValidationError(errsValidate)
+	}
+
+	appErr, ok := apperr.As(err)
+	if !ok {
+		msg := prefix
+		if msg == "" {
+			msg = errInternal
+		}
+		return http.StatusInternalServerError, Response{Status: StatusError, Code: apperr.CodeInternal, Error: msg}
+	}
+
+	status, ok := httpStatus[appErr.Code]
+	if !ok {
+		status = http.StatusInternalServerError
+	}
+
+	msg := appErr.Message
+	if prefix != "" {
+		msg = fmt.Sprintf("%s: %s", prefix, appErr.Message)
+	}
+
+	return status, Response{Status: StatusError, Code: appErr.Code, Error: msg}
+}
+
+// renderError - отвечаем клиенту ошибкой err с HTTP статусом по её коду
+func renderError(w http.ResponseWriter, r *http.Request, prefix string, err error) {
+	status, resp := ErrorResponse(prefix, err)
+	render.Status(r, status)
+	render.JSON(w, r, resp)
+}
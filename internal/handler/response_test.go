@@ -1,11 +1,15 @@
 package handler
 
 import (
+	"errors"
+	"fmt"
+	"net/http"
 	"testing"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/stretchr/testify/assert"
 
+	"service-chat/internal/apperr"
 	respMock "service-chat/internal/handler/mocks"
 )
 
@@ -24,6 +28,7 @@ func TestValidationError(t *testing.T) {
 			},
 			exResponse: Response{
 				Status: StatusError,
+				Code:   apperr.CodeValidation,
 				Error:  "Field Username is a required field",
 			},
 		},
@@ -35,6 +40,7 @@ func TestValidationError(t *testing.T) {
 			},
 			exResponse: Response{
 				Status: StatusError,
+				Code:   apperr.CodeValidation,
 				Error:  "Field Username is a required field, Field Password is a required field",
 			},
 		},
@@ -45,6 +51,7 @@ func TestValidationError(t *testing.T) {
 			},
 			exResponse: Response{
 				Status: StatusError,
+				Code:   apperr.CodeValidation,
 				Error:  "Field Username cannot exceed 20 characters",
 			},
 		},
@@ -55,6 +62,7 @@ func TestValidationError(t *testing.T) {
 			},
 			exResponse: Response{
 				Status: StatusError,
+				Code:   apperr.CodeValidation,
 				Error:  "Field Password must contain at least 6 characters",
 			},
 		},
@@ -66,6 +74,7 @@ func TestValidationError(t *testing.T) {
 			},
 			exResponse: Response{
 				Status: StatusError,
+				Code:   apperr.CodeValidation,
 				Error:  "Field Password cannot exceed 12 characters, Field Password must contain at least 6 characters",
 			},
 		},
@@ -76,6 +85,7 @@ func TestValidationError(t *testing.T) {
 			},
 			exResponse: Response{
 				Status: StatusError,
+				Code:   apperr.CodeValidation,
 				Error:  "Field chat_name must not contain symbols !@#$&*()?",
 			},
 		},
@@ -86,6 +96,7 @@ func TestValidationError(t *testing.T) {
 			},
 			exResponse: Response{
 				Status: StatusError,
+				Code:   apperr.CodeValidation,
 				Error:  "Field Password must contain Latin letters and Arabic numerals, as well as the symbols @#$&*()",
 			},
 		},
@@ -96,6 +107,7 @@ func TestValidationError(t *testing.T) {
 			},
 			exResponse: Response{
 				Status: StatusError,
+				Code:   apperr.CodeValidation,
 				Error:  "Field Chat is not valid",
 			},
 		},
@@ -111,6 +123,7 @@ func TestValidationError(t *testing.T) {
 			},
 			exResponse: Response{
 				Status: StatusError,
+				Code:   apperr.CodeValidation,
 				Error:  "Field Username is a required field, Field Username cannot exceed 20 characters, Field Password must contain at least 6 characters, Field chat_name must not contain symbols !@#$&*()?, Field Password must contain Latin letters and Arabic numerals, as well as the symbols @#$&*(), Field Chat is not valid",
 			},
 		},
@@ -215,3 +228,65 @@ func TestError(t *testing.T) {
 		})
 	}
 }
+
+func TestErrorResponse(t *testing.T) {
+	// Тестовая таблица с данными
+	testTable := []struct {
+		name       string
+		prefix     string
+		err        error
+		exStatus   int
+		exResponse Response
+	}{
+		{
+			name:       "Validation fields",
+			err:        validator.ValidationErrors{&respMock.MockFieldError{TestTag: "required", TestField: "Text"}},
+			exStatus:   http.StatusBadRequest,
+			exResponse: Response{Status: StatusError, Code: apperr.CodeValidation, Error: "Field Text is a required field"},
+		},
+		{
+			name:       "Not found with prefix",
+			prefix:     "Failed to get chats",
+			err:        fmt.Errorf("error path: db.GetChat, error: %w", apperr.NotFound("Invalid chat_id")),
+			exStatus:   http.StatusNotFound,
+			exResponse: Response{Status: StatusError, Code: apperr.CodeNotFound, Error: "Failed to get chats: Invalid chat_id"},
+		},
+		{
+			name:       "Forbidden without prefix",
+			err:        apperr.Forbidden("Only chat admins can do this"),
+			exStatus:   http.StatusForbidden,
+			exResponse: Response{Status: StatusError, Code: apperr.CodeForbidden, Error: "Only chat admins can do this"},
+		},
+		{
+			name:       "Conflict",
+			prefix:     "Failed to pin message",
+			err:        apperr.Conflict("Message is already pinned"),
+			exStatus:   http.StatusConflict,
+			exResponse: Response{Status: StatusError, Code: apperr.CodeConflict, Error: "Failed to pin message: Message is already pinned"},
+		},
+		{
+			name:       "Internal error is hidden",
+			prefix:     "Failed to get chats",
+			err:        errors.New("pq: relation \"chat\" does not exist"),
+			exStatus:   http.StatusInternalServerError,
+			exResponse: Response{Status: StatusError, Code: apperr.CodeInternal, Error: "Failed to get chats"},
+		},
+		{
+			name:       "Internal error without prefix",
+			err:        errors.New("db error"),
+			exStatus:   http.StatusInternalServerError,
+			exResponse: Response{Status: StatusError, Code: apperr.CodeInternal, Error: "Internal server error"},
+		},
+	}
+
+	// Итерируемся по нашей тестовой таблице
+	for _, tt := range testTable {
+		t.Run(tt.name, func(t *testing.T) {
+			acStatus, acResponse := ErrorResponse(tt.prefix, tt.err)
+
+			// Сравниваем ожидаемый и актуальный результат
+			assert.Equal(t, tt.exStatus, acStatus)
+			assert.Equal(t, tt.exResponse, acResponse)
+		})
+	}
+}
@@ -7,11 +7,14 @@ import (
 	"log/slog"
 
 	_ "service-chat/docs"
+	"service-chat/internal/ratelimit"
 	"service-chat/internal/service"
 )
 
 type Handler struct {
 	services *service.Service
+	// limiter - лимиты частоты запросов, если не задан - запросы не ограничиваются
+	limiter *ratelimit.Limiter
 }
 
 // NewHandler - Слой обработки запросов
@@ -19,6 +22,12 @@ func NewHandler(services *service.Service) *Handler {
 	return &Handler{services: services}
 }
 
+// WithRateLimiter - ограничиваем частоту запросов групп маршрутов лимитами limiter
+func (h *Handler) WithRateLimiter(limiter *ratelimit.Limiter) *Handler {
+	h.limiter = limiter
+	return h
+}
+
 func (h *Handler) NewRouter(log *slog.Logger) *chi.Mux {
 	// Инициализируем роутер
 	r := chi.NewRouter()
@@ -43,30 +52,171 @@ func (h *Handler) NewRouter(log *slog.Logger) *chi.Mux {
 
 	// Структура наших handlers
 
-	// Регистрация и авторизация
-	r.Route("/auth", func(r chi.Router) {
-		r.Post("/sign-up", h.SignUp(log)) // POST /auth/sign-up
-		r.Post("/sign-in", h.SignIn(log)) // POST /auth/sign-in
-	})
+	// API v1 доступно как по прежним адресам, так и с префиксом версии
+	h.routesV1(log)(r)
+	r.Route("/api/v1", h.routesV1(log))
 
-	// Protected Endpoints
-	r.Group(func(r chi.Router) {
-		r.Use(h.AuthMiddleware)
-		// Работа с чатами
-		r.Route("/chats", func(r chi.Router) {
-			r.Post("/add", h.ChatAdd(log))         // POST /chats/add
-			r.Delete("/delete", h.ChatDelete(log)) // DELETE /chats/delete
-			r.Post("/get", h.ChatGet(log))         // POST /chats/get
+	// API v2 - ресурсные адреса, пользователь определяется только по токену
+	r.Route("/api/v2", h.routesV2(log))
+
+	return r
+}
+
+// routesV1 - маршруты API v1
+func (h *Handler) routesV1(log *slog.Logger) func(r chi.Router) {
+	return func(r chi.Router) {
+		// Регистрация и авторизация
+		r.Route("/auth", func(r chi.Router) {
+			r.Use(h.RateLimit(log, ratelimit.GroupAuth, h.ipKey))
+
+			r.Post("/sign-up", h.SignUp(log)) // POST /auth/sign-up
+			r.Post("/sign-in", h.SignIn(log)) // POST /auth/sign-in
 		})
 
-		// Работа с сообщениями
-		r.Route("/messages", func(r chi.Router) {
-			r.Post("/add", h.MessageAdd(log))         // POST /messages/add
-			r.Post("/get", h.MessageGet(log))         // POST /messages/get
-			r.Put("/update", h.MessageUpdate(log))    // PUT /messages/update
-			r.Delete("/delete", h.MessageDelete(log)) // DELETE /messages/delete
+		// Сообщения внешних систем через входящие вебхуки, запрос авторизует токен в адресе
+		r.Route("/hooks", func(r chi.Router) {
+			r.Post("/{token}", h.IncomingPost(log)) // POST /hooks/{token}
 		})
-	})
 
-	return r
+		// Protected Endpoints
+		r.Group(func(r chi.Router) {
+			r.Use(h.AuthMiddleware)
+			r.Use(h.RateLimit(log, ratelimit.GroupAPI, userKey))
+
+			// Работа с чатами
+			r.Route("/chats", func(r chi.Router) {
+				r.Post("/add", h.ChatAdd(log))         // POST /chats/add
+				r.Delete("/delete", h.ChatDelete(log)) // DELETE /chats/delete
+				r.Post("/get", h.ChatGet(log))         // POST /chats/get
+
+				// Закреплённые сообщения чата
+				r.Get("/{id}/pins", h.PinGet(log)) // GET /chats/{id}/pins
+
+				// Черновик сообщения, общий для всех устройств пользователя
+				r.Put("/{id}/draft", h.DraftSave(log))      // PUT /chats/{id}/draft
+				r.Get("/{id}/draft", h.DraftGet(log))       // GET /chats/{id}/draft
+				r.Delete("/{id}/draft", h.DraftDelete(log)) // DELETE /chats/{id}/draft
+
+				// Статусы участников чата и индикатор набора текста
+				r.Get("/{id}/presence", h.PresenceGet(log)) // GET /chats/{id}/presence
+				r.Post("/{id}/typing", h.TypingStart(log))  // POST /chats/{id}/typing
+				r.Delete("/{id}/typing", h.TypingStop(log)) // DELETE /chats/{id}/typing
+
+				// Исходящие вебхуки чата, доступны администраторам чата
+				r.Post("/{id}/webhooks", h.WebhookAdd(log))                          // POST /chats/{id}/webhooks
+				r.Get("/{id}/webhooks", h.WebhookGet(log))                           // GET /chats/{id}/webhooks
+				r.Delete("/{id}/webhooks/{webhookID}", h.WebhookDelete(log))         // DELETE /chats/{id}/webhooks/{webhookID}
+				r.Get("/{id}/webhooks/{webhookID}/attempts", h.WebhookAttempts(log)) // GET /chats/{id}/webhooks/{webhookID}/attempts
+
+				// Входящие вебхуки чата, доступны администраторам чата
+				r.Post("/{id}/incoming-webhooks", h.IncomingAdd(log))                  // POST /chats/{id}/incoming-webhooks
+				r.Get("/{id}/incoming-webhooks", h.IncomingGet(log))                   // GET /chats/{id}/incoming-webhooks
+				r.Delete("/{id}/incoming-webhooks/{webhookID}", h.IncomingDelete(log)) // DELETE /chats/{id}/incoming-webhooks/{webhookID}
+
+				// Команды внешних ботов чата, создают и удаляют их администраторы чата
+				r.Post("/{id}/commands", h.CommandAdd(log))                  // POST /chats/{id}/commands
+				r.Get("/{id}/commands", h.CommandGet(log))                   // GET /chats/{id}/commands
+				r.Delete("/{id}/commands/{commandID}", h.CommandDelete(log)) // DELETE /chats/{id}/commands/{commandID}
+
+				// Опросы в чате
+				r.Post("/{id}/polls", h.PollAdd(log)) // POST /chats/{id}/polls
+
+				// Уведомления участника о сообщениях чата
+				r.Put("/{id}/notifications", h.ChatNotifySet(log)) // PUT /chats/{id}/notifications
+
+				// Фильтры текста сообщений, действия в чате меняют администраторы чата
+				r.Get("/{id}/filters", h.ChatFilterGet(log)) // GET /chats/{id}/filters
+				r.Put("/{id}/filters", h.ChatFilterSet(log)) // PUT /chats/{id}/filters
+			})
+
+			// Работа с сообщениями
+			r.Route("/messages", func(r chi.Router) {
+				// Лимит сообщений действует вместе с лимитом api группы, запрос забирает токен из обеих корзин
+				r.Use(h.RateLimit(log, ratelimit.GroupMessages, userKey))
+
+				r.Post("/add", h.MessageAdd(log))         // POST /messages/add
+				r.Post("/get", h.MessageGet(log))         // POST /messages/get
+				r.Put("/update", h.MessageUpdate(log))    // PUT /messages/update
+				r.Delete("/delete", h.MessageDelete(log)) // DELETE /messages/delete
+				r.Get("/search", h.MessageSearch(log))    // GET /messages/search
+
+				// Отложенные сообщения автора
+				r.Get("/scheduled", h.ScheduledGet(log))            // GET /messages/scheduled
+				r.Put("/scheduled/{id}", h.ScheduledUpdate(log))    // PUT /messages/scheduled/{id}
+				r.Delete("/scheduled/{id}", h.ScheduledCancel(log)) // DELETE /messages/scheduled/{id}
+
+				// Пересылка сообщения в другие чаты
+				r.Post("/{id}/forward", h.MessageForward(log)) // POST /messages/{id}/forward
+
+				// Реакции на сообщения
+				r.Post("/{id}/reactions", h.ReactionAdd(log))      // POST /messages/{id}/reactions
+				r.Delete("/{id}/reactions", h.ReactionDelete(log)) // DELETE /messages/{id}/reactions
+
+				// Вложения сообщений
+				r.Post("/{id}/attachments", h.AttachmentAdd(log)) // POST /messages/{id}/attachments
+
+				// Закрепление сообщений администраторами чата
+				r.Post("/{id}/pin", h.PinAdd(log))      // POST /messages/{id}/pin
+				r.Delete("/{id}/pin", h.PinDelete(log)) // DELETE /messages/{id}/pin
+
+				// Голосование в опросах участниками чата
+				r.Post("/{id}/vote", h.PollVote(log))     // POST /messages/{id}/vote
+				r.Delete("/{id}/vote", h.PollUnvote(log)) // DELETE /messages/{id}/vote
+
+				// Жалобы участников чата на сообщения
+				r.Post("/{id}/report", h.ReportAdd(log)) // POST /messages/{id}/report
+			})
+
+			// Скачивание вложений, доступно только участникам чата
+			r.Route("/attachments", func(r chi.Router) {
+				r.Get("/{id}", h.AttachmentGet(log))                 // GET /attachments/{id}
+				r.Get("/{id}/thumbnail", h.AttachmentThumbnail(log)) // GET /attachments/{id}/thumbnail
+			})
+
+			// События чатов пользователя в реальном времени
+			r.Get("/ws", h.WebSocket(log))  // GET /ws
+			r.Get("/events", h.Events(log)) // GET /events
+
+			// Данные текущего пользователя
+			r.Route("/users", func(r chi.Router) {
+				r.Get("/me/mentions", h.MentionGet(log))  // GET /users/me/mentions
+				r.Put("/me/presence", h.PresenceSet(log)) // PUT /users/me/presence
+
+				// Адреса уведомлений о сообщениях, пока пользователь не в сети
+				r.Get("/me/notifications", h.NotifySettingsGet(log))  // GET /users/me/notifications
+				r.Put("/me/notifications", h.NotifySettingsSave(log)) // PUT /users/me/notifications
+
+				// Чёрный список пользователя
+				r.Post("/me/blocks", h.BlockAdd(log))           // POST /users/me/blocks
+				r.Get("/me/blocks", h.BlockGet(log))            // GET /users/me/blocks
+				r.Delete("/me/blocks/{id}", h.BlockDelete(log)) // DELETE /users/me/blocks/{id}
+			})
+
+			// Разбор жалоб, доступно только модераторам
+			r.Route("/moderation", func(r chi.Router) {
+				r.Get("/reports", h.ReportGet(log))                   // GET /moderation/reports
+				r.Post("/reports/{id}/actions", h.ModerationAdd(log)) // POST /moderation/reports/{id}/actions
+				r.Get("/actions", h.ModerationGet(log))               // GET /moderation/actions
+			})
+		})
+	}
+}
+
+// routesV2 - маршруты API v2
+func (h *Handler) routesV2(log *slog.Logger) func(r chi.Router) {
+	return func(r chi.Router) {
+		r.Use(h.AuthMiddleware)
+		r.Use(h.RateLimit(log, ratelimit.GroupAPI, userKey))
+
+		r.Get("/chats", h.ChatGetV2(log))                  // GET /api/v2/chats
+		r.Get("/chats/{id}/messages", h.MessageGetV2(log)) // GET /api/v2/chats/{id}/messages
+
+		r.Route("/messages", func(r chi.Router) {
+			// Лимит сообщений действует вместе с лимитом api, как и в v1
+			r.Use(h.RateLimit(log, ratelimit.GroupMessages, userKey))
+
+			r.Patch("/{id}", h.MessageUpdateV2(log))  // PATCH /api/v2/messages/{id}
+			r.Delete("/{id}", h.MessageDeleteV2(log)) // DELETE /api/v2/messages/{id}
+		})
+	}
 }
@@ -32,6 +32,23 @@ func TestNewHandler(t *testing.T) {
 					Authorization: mockService.NewMockAuthorization(ctrl),
 					Chat:          mockService.NewMockChat(ctrl),
 					Message:       mockService.NewMockMessage(ctrl),
+					Reaction:      mockService.NewMockReaction(ctrl),
+					Attachment:    mockService.NewMockAttachment(ctrl),
+					Mention:       mockService.NewMockMention(ctrl),
+					Pin:           mockService.NewMockPin(ctrl),
+					Scheduled:     mockService.NewMockScheduled(ctrl),
+					Draft:         mockService.NewMockDraft(ctrl),
+					Preview:       mockService.NewMockPreview(ctrl),
+					Realtime:      mockService.NewMockRealtime(ctrl),
+					Presence:      mockService.NewMockPresence(ctrl),
+					Webhook:       mockService.NewMockWebhook(ctrl),
+					Incoming:      mockService.NewMockIncoming(ctrl),
+					Command:       mockService.NewMockCommand(ctrl),
+					Poll:          mockService.NewMockPoll(ctrl),
+					Notification:  mockService.NewMockNotification(ctrl),
+					Block:         mockService.NewMockBlock(ctrl),
+					Moderation:    mockService.NewMockModeration(ctrl),
+					Filter:        mockService.NewMockFilter(ctrl),
 				}
 			},
 		},
@@ -107,7 +124,7 @@ func TestHandler_NewRouter(t *testing.T) {
 				}
 			},
 			log:      slog.New(slog.NewJSONHandler(io.Discard, nil)),
-			patterns: []string{"/auth/*", "/chats/*", "/messages/*", "/swagger/*"},
+			patterns: []string{"/auth/*", "/hooks/*", "/chats/*", "/messages/*", "/attachments/*", "/users/*", "/moderation/*", "/ws", "/events", "/api/v1/*", "/api/v2/*", "/swagger/*"},
 		},
 	}
 
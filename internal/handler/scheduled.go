@@ -0,0 +1,212 @@
+package handler
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+
+	"service-chat/internal/apperr"
+	"service-chat/internal/dto"
+	"service-chat/internal/logger"
+	"service-chat/internal/validate"
+)
+
+// ScheduledGet - получить отложенные сообщения пользователя
+// @Summary ScheduledGet
+// @Security ApiKeyAuth
+// @Tags Scheduled
+// @Description Get pending and failed scheduled messages of the current user, nearest first
+// @ID Get scheduled messages
+// @Produce json
+// @Param chat_id query int false "filter by chat"
+// @Success 200 {object} Response{Status, Message, ScheduledList}
+// @Failure 400,404,405 {object} Response
+// @Failure 500 {object} Response
+// @Failure default {object} Response
+// @Router /messages/scheduled [get]
+func (h *Handler) ScheduledGet(log *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Логируем наш запрос
+		const op = "handler.ScheduledGet"
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		// Получаем id пользователя из контекста
+		idCtx, errCtx := GetUserID(r.Context())
+		if errCtx != nil {
+			log.Error("failed to get userID from context")
+			renderError(w, r, "", errCtx)
+			return
+		}
+
+		// Заполняем структуру запроса из query параметров
+		var req dto.ScheduledGet
+		var errParse error
+		if req.ChatID, errParse = queryInt64(r.URL.Query(), "chat_id"); errParse != nil {
+			log.Error("invalid query parameters", logger.Err(errParse))
+			renderError(w, r, "", errParse)
+			return
+		}
+
+		// Анализируем запрос от пользователя
+		fail := validate.StructValidate(log, &req)
+		if fail != nil && fail.ValidateErr != nil {
+			log.Error("invalid request data")
+			renderError(w, r, "", fail.ValidateErr)
+			return
+		} else if fail != nil && fail.ErrMsg != "" {
+			log.Error("invalid request data")
+			renderError(w, r, "", apperr.Validation(fail.ErrMsg))
+			return
+		}
+
+		// Отправляем валидную структуру на слой сервиса
+		scheduled, errGet := h.services.Scheduled.GetScheduled(req, idCtx)
+		if errGet != nil {
+			log.Error("failed to get scheduled messages", logger.Err(errGet))
+			renderError(w, r, "Failed to get scheduled messages", errGet)
+			return
+		}
+
+		// Если отложенных сообщений нет
+		if len(scheduled) == 0 {
+			log.Info("scheduled messages not found")
+			render.JSON(w, r, OK("No scheduled messages found"))
+			return
+		}
+
+		// Если ошибок нет отправляем успешный ответ
+		log.Info("Scheduled messages found successfully", slog.Int("count", len(scheduled)))
+		render.JSON(w, r, Response{
+			Status:        StatusOK,
+			Message:       "Scheduled messages found successfully",
+			ScheduledList: scheduled,
+		})
+		return
+	}
+}
+
+// ScheduledUpdate - редактировать отложенное сообщение
+// @Summary ScheduledUpdate
+// @Security ApiKeyAuth
+// @Tags Scheduled
+// @Description Edit text and/or send_at of a pending scheduled message
+// @ID Update scheduled message
+// @Accept json
+// @Produce json
+// @Param id path int true "scheduled message id"
+// @Param input body dto.ScheduledUpdate true "new text and/or send_at"
+// @Success 200 {object} Response
+// @Failure 400,404,405 {object} Response
+// @Failure 500 {object} Response
+// @Failure default {object} Response
+// @Router /messages/scheduled/{id} [put]
+func (h *Handler) ScheduledUpdate(log *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Логируем наш запрос
+		const op = "handler.ScheduledUpdate"
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		// Получаем id пользователя из контекста
+		idCtx, errCtx := GetUserID(r.Context())
+		if errCtx != nil {
+			log.Error("failed to get userID from context")
+			renderError(w, r, "", errCtx)
+			return
+		}
+
+		// Получаем id отложенного сообщения из пути запроса
+		scheduledID, errID := GetPathID(r)
+		if errID != nil {
+			log.Error("invalid scheduled message ID")
+			renderError(w, r, "", errID)
+			return
+		}
+
+		// Структура для записи входных данных из JSON от пользователя
+		var req dto.ScheduledUpdate
+
+		// Анализируем запрос от пользователя
+		fail := validate.BaseValidate(log, r.Body, &req)
+		if fail != nil && fail.ValidateErr != nil {
+			log.Error("invalid request data")
+			renderError(w, r, "", fail.ValidateErr)
+			return
+		} else if fail != nil && fail.ErrMsg != "" {
+			log.Error("invalid request data")
+			renderError(w, r, "", apperr.Validation(fail.ErrMsg))
+			return
+		}
+
+		// Отправляем валидную структуру на слой сервиса
+		if errUpd := h.services.Scheduled.UpdateScheduled(req, scheduledID, idCtx); errUpd != nil {
+			log.Error("failed to update scheduled message", logger.Err(errUpd))
+			renderError(w, r, "Failed to update scheduled message", errUpd)
+			return
+		}
+
+		// Если ошибок нет отправляем успешный ответ
+		log.Info("Scheduled message updated successfully", slog.Int64("scheduledID", scheduledID))
+		render.JSON(w, r, OK("Scheduled message updated successfully"))
+		return
+	}
+}
+
+// ScheduledCancel - отменить отложенное сообщение
+// @Summary ScheduledCancel
+// @Security ApiKeyAuth
+// @Tags Scheduled
+// @Description Cancel a pending scheduled message
+// @ID Cancel scheduled message
+// @Produce json
+// @Param id path int true "scheduled message id"
+// @Success 200 {object} Response
+// @Failure 400,404,405 {object} Response
+// @Failure 500 {object} Response
+// @Failure default {object} Response
+// @Router /messages/scheduled/{id} [delete]
+func (h *Handler) ScheduledCancel(log *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Логируем наш запрос
+		const op = "handler.ScheduledCancel"
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		// Получаем id пользователя из контекста
+		idCtx, errCtx := GetUserID(r.Context())
+		if errCtx != nil {
+			log.Error("failed to get userID from context")
+			renderError(w, r, "", errCtx)
+			return
+		}
+
+		// Получаем id отложенного сообщения из пути запроса
+		scheduledID, errID := GetPathID(r)
+		if errID != nil {
+			log.Error("invalid scheduled message ID")
+			renderError(w, r, "", errID)
+			return
+		}
+
+		// Отправляем запрос на слой сервиса
+		if errCancel := h.services.Scheduled.CancelScheduled(scheduledID, idCtx); errCancel != nil {
+			log.Error("failed to cancel scheduled message", logger.Err(errCancel))
+			renderError(w, r, "Failed to cancel scheduled message", errCancel)
+			return
+		}
+
+		// Если ошибок нет отправляем успешный ответ
+		log.Info("Scheduled message cancelled successfully", slog.Int64("scheduledID", scheduledID))
+		render.JSON(w, r, OK("Scheduled message cancelled successfully"))
+		return
+	}
+}
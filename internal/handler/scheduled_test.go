@@ -0,0 +1,161 @@
+package handler
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"service-chat/internal/apperr"
+	"service-chat/internal/db/entity"
+	"service-chat/internal/dto"
+	"service-chat/internal/service"
+	mockService "service-chat/internal/service/mocks"
+)
+
+func TestHandler_Scheduled(t *testing.T) {
+	// Структура для последующей реализации поведения мока
+	type mockBehaviour func(s *mockService.MockScheduled)
+
+	//Инициализируем контролер для мока сервиса
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Создаём моки сервиса отложенных сообщений
+	mockScheduled := mockService.NewMockScheduled(ctrl)
+
+	// Создаём экземпляр обработчика
+	handler := NewHandler(&service.Service{Scheduled: mockScheduled})
+
+	// Мокируем логгер
+	mockLog := slog.New(slog.NewJSONHandler(io.Discard, nil))
+
+	// Инициализируем сервер
+	r := chi.NewRouter()
+	r.Post("/messages/add", handler.MessageAdd(mockLog))
+	r.Get("/messages/scheduled", handler.ScheduledGet(mockLog))
+	r.Put("/messages/scheduled/{id}", handler.ScheduledUpdate(mockLog))
+	r.Delete("/messages/scheduled/{id}", handler.ScheduledCancel(mockLog))
+
+	sendAt := time.Date(2030, 1, 1, 9, 0, 0, 0, time.UTC)
+
+	// Тестовая таблица с данными
+	testTable := []struct {
+		name                 string
+		method               string
+		path                 string
+		inputBody            string
+		mockBehaviour        mockBehaviour
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:      "Add with send_at schedules message",
+			method:    http.MethodPost,
+			path:      "/messages/add",
+			inputBody: `{"chat_id": 2, "user_id": 1, "text": "standup", "send_at": "2030-01-01T09:00:00Z"}`,
+			mockBehaviour: func(s *mockService.MockScheduled) {
+				s.EXPECT().ScheduleMessage(dto.MessageAdd{ChatID: 2, UserID: 1, Text: "standup", SendAt: &sendAt}).
+					Return(int64(3), nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"status":"OK","message":"Message scheduled successfully, id: 3"}`,
+		},
+		{
+			name:      "Add with send_at in the past",
+			method:    http.MethodPost,
+			path:      "/messages/add",
+			inputBody: `{"chat_id": 2, "user_id": 1, "text": "standup", "send_at": "2030-01-01T09:00:00Z"}`,
+			mockBehaviour: func(s *mockService.MockScheduled) {
+				s.EXPECT().ScheduleMessage(gomock.Any()).Return(int64(0), apperr.Validation("send_at must be in the future"))
+			},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":"Error","code":"validation","error":"Failed to schedule message: send_at must be in the future"}`,
+		},
+		{
+			name:   "List",
+			method: http.MethodGet,
+			path:   "/messages/scheduled?chat_id=2",
+			mockBehaviour: func(s *mockService.MockScheduled) {
+				s.EXPECT().GetScheduled(dto.ScheduledGet{ChatID: 2}, 1).Return([]entity.ScheduledMessage{{
+					Id: 3, ChatID: 2, UserID: 1, Text: "standup", Format: "plain", SendAt: "2030-01-01T09:00:00Z", Status: "pending",
+				}}, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"status":"OK","message":"Scheduled messages found successfully","scheduled_list":[{"id":3,"chat_id":2,"user_id":1,"text":"standup","format":"plain","send_at":"2030-01-01T09:00:00Z","status":"pending","created_at":""}]}`,
+		},
+		{
+			name:   "List empty",
+			method: http.MethodGet,
+			path:   "/messages/scheduled",
+			mockBehaviour: func(s *mockService.MockScheduled) {
+				s.EXPECT().GetScheduled(dto.ScheduledGet{}, 1).Return(nil, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"status":"OK","message":"No scheduled messages found"}`,
+		},
+		{
+			name:                 "List with invalid chat_id",
+			method:               http.MethodGet,
+			path:                 "/messages/scheduled?chat_id=x",
+			mockBehaviour:        func(s *mockService.MockScheduled) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":"Error","code":"validation","error":"Invalid query parameter chat_id"}`,
+		},
+		{
+			name:      "Update",
+			method:    http.MethodPut,
+			path:      "/messages/scheduled/3",
+			inputBody: `{"text": "standup moved"}`,
+			mockBehaviour: func(s *mockService.MockScheduled) {
+				s.EXPECT().UpdateScheduled(dto.ScheduledUpdate{Text: "standup moved"}, int64(3), 1).Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"status":"OK","message":"Scheduled message updated successfully"}`,
+		},
+		{
+			name:   "Cancel",
+			method: http.MethodDelete,
+			path:   "/messages/scheduled/3",
+			mockBehaviour: func(s *mockService.MockScheduled) {
+				s.EXPECT().CancelScheduled(int64(3), 1).Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"status":"OK","message":"Scheduled message cancelled successfully"}`,
+		},
+		{
+			name:   "Cancel already sent",
+			method: http.MethodDelete,
+			path:   "/messages/scheduled/3",
+			mockBehaviour: func(s *mockService.MockScheduled) {
+				s.EXPECT().CancelScheduled(int64(3), 1).Return(apperr.NotFound("Scheduled message not found"))
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"status":"Error","code":"not_found","error":"Failed to cancel scheduled message: Scheduled message not found"}`,
+		},
+	}
+
+	// Итерируемся по нашей тестовой таблице
+	for _, tt := range testTable {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockBehaviour(mockScheduled)
+
+			// Готовим и выполняем тестовый запрос
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.inputBody))
+			r.ServeHTTP(w, req.WithContext(context.WithValue(req.Context(), userCtx, 1)))
+
+			// Сравниваем ожидаемый и актуальный результат
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			assert.Equal(t, tt.expectedResponseBody, strings.TrimSpace(w.Body.String()))
+		})
+	}
+}
@@ -0,0 +1,124 @@
+package handler
+
+import (
+	"log/slog"
+	"net/http"
+	"net/url"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+
+	"service-chat/internal/apperr"
+	"service-chat/internal/dto"
+	"service-chat/internal/logger"
+	"service-chat/internal/validate"
+)
+
+// MessageSearch - полнотекстовый поиск по сообщениям в чатах пользователя
+// @Summary MessageSearch
+// @Security ApiKeyAuth
+// @Tags Message
+// @Description Full-text search in messages of user chats
+// @ID Search messages
+// @Produce json
+// @Param q query string true "search query, websearch syntax: words, \"phrases\", -excluded, or"
+// @Param chat_id query int false "filter by chat"
+// @Param author_id query int false "filter by message author"
+// @Param from query string false "messages created at or after, RFC3339"
+// @Param to query string false "messages created before, RFC3339"
+// @Param limit query int false "page size, 1-100, default 20"
+// @Param cursor query string false "next_cursor from previous page"
+// @Success 200 {object} Response{Status, Message, SearchResults, NextCursor}
+// @Failure 400,404,405 {object} Response
+// @Failure 500 {object} Response
+// @Failure default {object} Response
+// @Router /messages/search [get]
+func (h *Handler) MessageSearch(log *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Логируем наш запрос
+		const op = "handler.MessageSearch"
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		// Получаем id пользователя из контекста
+		idCtx, errCtx := GetUserID(r.Context())
+		if errCtx != nil {
+			log.Error("failed to get userID from context")
+			renderError(w, r, "", errCtx)
+			return
+		}
+
+		// Заполняем структуру запроса из query параметров
+		req, errParse := parseMessageSearch(r.URL.Query())
+		if errParse != nil {
+			log.Error("invalid query parameters", logger.Err(errParse))
+			renderError(w, r, "", errParse)
+			return
+		}
+
+		// Анализируем запрос от пользователя
+		fail := validate.StructValidate(log, &req)
+		if fail != nil && fail.ValidateErr != nil {
+			log.Error("invalid request data")
+			renderError(w, r, "", fail.ValidateErr)
+			return
+		} else if fail != nil && fail.ErrMsg != "" {
+			log.Error("invalid request data")
+			renderError(w, r, "", apperr.Validation(fail.ErrMsg))
+			return
+		}
+
+		// Отправляем валидную структуру на слой сервиса
+		results, next, errSearch := h.services.Message.SearchMessage(req, idCtx)
+		if errSearch != nil {
+			log.Error("failed to search messages", logger.Err(errSearch))
+			renderError(w, r, "Failed to search messages", errSearch)
+			return
+		}
+
+		// Если ничего не нашли
+		if len(results) == 0 {
+			log.Info("messages not found")
+			render.JSON(w, r, OK("No messages found"))
+			return
+		}
+
+		// Если ошибок нет и есть результаты отправляем успешный ответ
+		log.Info("Messages found successfully", slog.Int("count", len(results)))
+		render.JSON(w, r, Response{
+			Status:        StatusOK,
+			Message:       "Messages found successfully",
+			SearchResults: results,
+			NextCursor:    next,
+		})
+		return
+	}
+}
+
+// parseMessageSearch - разбираем query параметры поиска
+func parseMessageSearch(values url.Values) (dto.MessageSearch, error) {
+	var req dto.MessageSearch
+	var err error
+
+	req.Query = values.Get("q")
+	req.Cursor = values.Get("cursor")
+	if req.ChatID, err = queryInt64(values, "chat_id"); err != nil {
+		return req, err
+	}
+	if req.AuthorID, err = queryInt64(values, "author_id"); err != nil {
+		return req, err
+	}
+	if req.Limit, err = queryInt64(values, "limit"); err != nil {
+		return req, err
+	}
+	if req.From, err = queryTime(values, "from"); err != nil {
+		return req, err
+	}
+	if req.To, err = queryTime(values, "to"); err != nil {
+		return req, err
+	}
+
+	return req, nil
+}
@@ -0,0 +1,123 @@
+package handler
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"service-chat/internal/db/entity"
+	"service-chat/internal/dto"
+	"service-chat/internal/service"
+	mockService "service-chat/internal/service/mocks"
+)
+
+func TestHandler_MessageSearch(t *testing.T) {
+	// Структура для последующей реализации поведения мока
+	type mockBehaviour func(s *mockService.MockMessage)
+
+	//Инициализируем контролер для мока сервиса
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Создаём моки сервиса сообщений
+	mockMessage := mockService.NewMockMessage(ctrl)
+
+	// Создаём экземпляр обработчика
+	handler := NewHandler(&service.Service{Message: mockMessage})
+
+	// Мокируем логгер
+	mockLog := slog.New(slog.NewJSONHandler(io.Discard, nil))
+
+	// Инициализируем сервер
+	r := chi.NewRouter()
+	r.Get("/messages/search", handler.MessageSearch(mockLog))
+
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// Тестовая таблица с данными
+	testTable := []struct {
+		name                 string
+		query                string
+		mockBehaviour        mockBehaviour
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:  "OK",
+			query: "?q=deploy&chat_id=2&from=2024-01-01T00:00:00Z&limit=1",
+			mockBehaviour: func(s *mockService.MockMessage) {
+				s.EXPECT().SearchMessage(dto.MessageSearch{Query: "deploy", ChatID: 2, From: &from, Limit: 1}, 1).
+					Return([]entity.SearchResult{{
+						Message: entity.Message{Id: 5, Text: "deploy today", UserID: 1},
+						ChatID:  2,
+						Snippet: "<mark>deploy</mark> today",
+						Rank:    0.5,
+					}}, "next", nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"status":"OK","message":"Messages found successfully","search_results":[{"message":{"id":5,"text":"deploy today","user_id":1,"created_at":"","is_deleted":false},"chat_id":2,"snippet":"\u003cmark\u003edeploy\u003c/mark\u003e today","rank":0.5}],"next_cursor":"next"}`,
+		},
+		{
+			name:  "Nothing found",
+			query: "?q=deploy",
+			mockBehaviour: func(s *mockService.MockMessage) {
+				s.EXPECT().SearchMessage(dto.MessageSearch{Query: "deploy"}, 1).Return(nil, "", nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"status":"OK","message":"No messages found"}`,
+		},
+		{
+			name:                 "Required query is missing",
+			query:                "?chat_id=2",
+			mockBehaviour:        func(s *mockService.MockMessage) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":"Error","code":"validation","error":"Field Query is a required field"}`,
+		},
+		{
+			name:                 "Invalid chat_id",
+			query:                "?q=deploy&chat_id=abc",
+			mockBehaviour:        func(s *mockService.MockMessage) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":"Error","code":"validation","error":"Invalid query parameter chat_id"}`,
+		},
+		{
+			name:                 "Invalid date",
+			query:                "?q=deploy&to=yesterday",
+			mockBehaviour:        func(s *mockService.MockMessage) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":"Error","code":"validation","error":"Invalid query parameter to, expected RFC3339 time"}`,
+		},
+		{
+			name:                 "Limit too large",
+			query:                "?q=deploy&limit=1000",
+			mockBehaviour:        func(s *mockService.MockMessage) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":"Error","code":"validation","error":"Field Limit cannot exceed 100 characters"}`,
+		},
+	}
+
+	// Итерируемся по нашей тестовой таблице
+	for _, tt := range testTable {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockBehaviour(mockMessage)
+
+			// Готовим и выполняем тестовый запрос
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/messages/search"+tt.query, nil)
+			r.ServeHTTP(w, req.WithContext(context.WithValue(req.Context(), userCtx, 1)))
+
+			// Сравниваем ожидаемый и актуальный результат
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			assert.Equal(t, tt.expectedResponseBody, strings.TrimSpace(w.Body.String()))
+		})
+	}
+}
@@ -0,0 +1,311 @@
+package handler
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"slices"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+
+	"service-chat/internal/apperr"
+	"service-chat/internal/db/entity"
+	"service-chat/internal/dto"
+	"service-chat/internal/logger"
+	"service-chat/internal/validate"
+)
+
+// defaultMessagesLimit - размер страницы сообщений в API v2, если limit не задан
+const defaultMessagesLimit = 20
+
+// ChatGetV2 - получить чаты текущего пользователя
+// @Summary ChatGetV2
+// @Security ApiKeyAuth
+// @Tags API v2
+// @Description Get chats of the current user, chats with the latest messages first
+// @ID Get chats v2
+// @Produce json
+// @Param limit query int false "page size, 1-100, all chats if not set"
+// @Param offset query int false "number of chats to skip"
+// @Success 200 {object} Response{Status, Message, ChatsList}
+// @Failure 400,401,403,404,405 {object} Response
+// @Failure 500 {object} Response
+// @Failure default {object} Response
+// @Router /api/v2/chats [get]
+func (h *Handler) ChatGetV2(log *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Логируем наш запрос
+		const op = "handler.ChatGetV2"
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		// Получаем id пользователя из контекста
+		idCtx, errCtx := GetUserID(r.Context())
+		if errCtx != nil {
+			log.Error("failed to get userID from context")
+			renderError(w, r, "", errCtx)
+			return
+		}
+
+		// Заполняем страницу из query параметров
+		page, errParse := parsePage(log, r.URL.Query())
+		if errParse != nil {
+			log.Error("invalid query parameters", logger.Err(errParse))
+			renderError(w, r, "", errParse)
+			return
+		}
+
+		// Пользователь берётся только из токена
+		userID := int64(idCtx)
+		chats, errGet := h.services.Chat.GetChat(dto.ChatGet{UserID: &userID, Limit: page.Limit, Offset: page.Offset})
+		if errGet != nil {
+			log.Error("failed to get chats", logger.Err(errGet))
+			renderError(w, r, "Failed to get chats", errGet)
+			return
+		}
+
+		// Если ошибок нет отправляем успешный ответ, у пользователя без чатов список пустой
+		log.Info("Chats get successfully", slog.Int("count", len(chats)))
+		render.JSON(w, r, Response{
+			Status:    StatusOK,
+			Message:   "Chats get successfully",
+			ChatsList: chats,
+		})
+		return
+	}
+}
+
+// MessageGetV2 - получить сообщения чата
+// @Summary MessageGetV2
+// @Security ApiKeyAuth
+// @Tags API v2
+// @Description Get messages of the chat, available to chat members
+// @ID Get messages v2
+// @Produce json
+// @Param id path int true "chat id"
+// @Param limit query int false "page size, 1-100, default 20"
+// @Param offset query int false "number of messages to skip"
+// @Success 200 {object} Response{Status, Message, MessagesList}
+// @Failure 400,401,403,404,405 {object} Response
+// @Failure 500 {object} Response
+// @Failure default {object} Response
+// @Router /api/v2/chats/{id}/messages [get]
+func (h *Handler) MessageGetV2(log *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Логируем наш запрос
+		const op = "handler.MessageGetV2"
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		// Получаем id пользователя из контекста
+		idCtx, errCtx := GetUserID(r.Context())
+		if errCtx != nil {
+			log.Error("failed to get userID from context")
+			renderError(w, r, "", errCtx)
+			return
+		}
+
+		// Получаем id чата из пути запроса
+		chatID, errID := GetPathID(r)
+		if errID != nil {
+			log.Error("invalid chat ID")
+			renderError(w, r, "", errID)
+			return
+		}
+
+		// Заполняем страницу из query параметров
+		page, errParse := parsePage(log, r.URL.Query())
+		if errParse != nil {
+			log.Error("invalid query parameters", logger.Err(errParse))
+			renderError(w, r, "", errParse)
+			return
+		}
+		if page.Limit == 0 {
+			page.Limit = defaultMessagesLimit
+		}
+
+		// Отправляем запрос на слой сервиса
+		messages, errGet := h.services.Message.GetMessage(dto.MessageGet{ChatID: chatID, Limit: &page.Limit, Offset: &page.Offset}, idCtx)
+		if errGet != nil {
+			log.Error("failed to get messages", logger.Err(errGet))
+			renderError(w, r, "Failed to get messages", errGet)
+			return
+		}
+
+		// Если ошибок нет отправляем успешный ответ, в чате без сообщений список пустой
+		log.Info("Messages get successfully", slog.Int("count", len(messages)))
+		render.JSON(w, r, Response{
+			Status:       StatusOK,
+			Message:      "Messages get successfully",
+			MessagesList: messages,
+		})
+		return
+	}
+}
+
+// MessageUpdateV2 - отредактировать сообщение текущего пользователя
+// @Summary MessageUpdateV2
+// @Security ApiKeyAuth
+// @Tags API v2
+// @Description Edit message of the current user
+// @ID Update message v2
+// @Accept json
+// @Produce json
+// @Param id path int true "message id"
+// @Param input body dto.MessageEdit true "new text"
+// @Success 200 {object} Response
+// @Failure 400,401,403,404,405 {object} Response
+// @Failure 500 {object} Response
+// @Failure default {object} Response
+// @Router /api/v2/messages/{id} [patch]
+func (h *Handler) MessageUpdateV2(log *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Логируем наш запрос
+		const op = "handler.MessageUpdateV2"
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		// Получаем id пользователя из контекста
+		idCtx, errCtx := GetUserID(r.Context())
+		if errCtx != nil {
+			log.Error("failed to get userID from context")
+			renderError(w, r, "", errCtx)
+			return
+		}
+
+		// Получаем id сообщения из пути запроса
+		messageID, errID := GetPathID(r)
+		if errID != nil {
+			log.Error("invalid message ID")
+			renderError(w, r, "", errID)
+			return
+		}
+
+		// Структура для записи входных данных из JSON от пользователя
+		var req dto.MessageEdit
+
+		// Анализируем запрос от пользователя
+		fail := validate.BaseValidate(log, r.Body, &req)
+		if fail != nil && fail.ValidateErr != nil {
+			log.Error("invalid request data")
+			renderError(w, r, "", fail.ValidateErr)
+			return
+		} else if fail != nil && fail.ErrMsg != "" {
+			log.Error("invalid request data")
+			renderError(w, r, "", apperr.Validation(fail.ErrMsg))
+			return
+		}
+
+		// Отправляем запрос на слой сервиса, автор сообщения - пользователь из токена
+		_, errUpd := h.services.Message.UpdateMessage(dto.MessageUpdate{
+			MessageID: messageID,
+			UserID:    int64(idCtx),
+			NewText:   req.Text,
+			Format:    req.Format,
+		})
+		if errUpd != nil {
+			log.Error("failed to update message", logger.Err(errUpd))
+			renderError(w, r, "Failed to update message", errUpd)
+			return
+		}
+
+		// Если ошибок нет отправляем успешный ответ
+		log.Info("Message updated successfully", slog.Int64("messageID", messageID))
+		render.JSON(w, r, OK(fmt.Sprintf("Message update successfully, id: %d", messageID)))
+		return
+	}
+}
+
+// MessageDeleteV2 - удалить сообщение текущего пользователя
+// @Summary MessageDeleteV2
+// @Security ApiKeyAuth
+// @Tags API v2
+// @Description Delete message of the current user
+// @ID Delete message v2
+// @Produce json
+// @Param id path int true "message id"
+// @Success 200 {object} Response{Status, Message, DelMsgList}
+// @Failure 400,401,403,404,405 {object} Response
+// @Failure 500 {object} Response
+// @Failure default {object} Response
+// @Router /api/v2/messages/{id} [delete]
+func (h *Handler) MessageDeleteV2(log *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Логируем наш запрос
+		const op = "handler.MessageDeleteV2"
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		// Получаем id пользователя из контекста
+		idCtx, errCtx := GetUserID(r.Context())
+		if errCtx != nil {
+			log.Error("failed to get userID from context")
+			renderError(w, r, "", errCtx)
+			return
+		}
+
+		// Получаем id сообщения из пути запроса
+		messageID, errID := GetPathID(r)
+		if errID != nil {
+			log.Error("invalid message ID")
+			renderError(w, r, "", errID)
+			return
+		}
+
+		// Отправляем запрос на слой сервиса
+		msgDel, errDel := h.services.Message.DeleteMessage(dto.MessageDelete{MessageIds: &[]int64{messageID}}, idCtx)
+		if errDel != nil {
+			log.Error("failed to delete message", logger.Err(errDel))
+			renderError(w, r, "Failed to delete message", errDel)
+			return
+		}
+
+		// Сообщения с таким id у пользователя нет или оно уже удалено
+		if !slices.ContainsFunc(msgDel, func(msg entity.DelMsg) bool { return msg.Result == entity.ResultMessageDeleted }) {
+			log.Error("message not found", slog.Int64("messageID", messageID))
+			renderError(w, r, "Failed to delete message", apperr.NotFound("Message not found"))
+			return
+		}
+
+		// Если ошибок нет отправляем успешный ответ
+		log.Info("Message delete successfully", "Messages", msgDel)
+		render.JSON(w, r, Response{
+			Status:     StatusOK,
+			Message:    "Result of deleted messages",
+			DelMsgList: msgDel,
+		})
+		return
+	}
+}
+
+// parsePage - разбираем и проверяем query параметры страницы списка
+func parsePage(log *slog.Logger, values url.Values) (dto.Page, error) {
+	var page dto.Page
+	var err error
+
+	if page.Limit, err = queryInt64(values, "limit"); err != nil {
+		return page, err
+	}
+	if page.Offset, err = queryInt64(values, "offset"); err != nil {
+		return page, err
+	}
+
+	fail := validate.StructValidate(log, &page)
+	if fail != nil && fail.ValidateErr != nil {
+		return page, fail.ValidateErr
+	} else if fail != nil && fail.ErrMsg != "" {
+		return page, apperr.Validation(fail.ErrMsg)
+	}
+
+	return page, nil
+}
@@ -0,0 +1,210 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"service-chat/internal/apperr"
+	"service-chat/internal/db/entity"
+	"service-chat/internal/dto"
+	"service-chat/internal/service"
+	mockService "service-chat/internal/service/mocks"
+)
+
+func TestHandler_V2(t *testing.T) {
+	// Структура для последующей реализации поведения моков
+	type mockBehaviour func(c *mockService.MockChat, m *mockService.MockMessage)
+
+	//Инициализируем контролер для мока сервиса
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Создаём моки сервисов чатов и сообщений
+	mockChat := mockService.NewMockChat(ctrl)
+	mockMessage := mockService.NewMockMessage(ctrl)
+
+	// Создаём экземпляр обработчика
+	handler := NewHandler(&service.Service{Chat: mockChat, Message: mockMessage})
+
+	// Мокируем логгер
+	mockLog := slog.New(slog.NewJSONHandler(io.Discard, nil))
+
+	// Инициализируем сервер
+	r := chi.NewRouter()
+	r.Get("/api/v2/chats", handler.ChatGetV2(mockLog))
+	r.Get("/api/v2/chats/{id}/messages", handler.MessageGetV2(mockLog))
+	r.Patch("/api/v2/messages/{id}", handler.MessageUpdateV2(mockLog))
+	r.Delete("/api/v2/messages/{id}", handler.MessageDeleteV2(mockLog))
+
+	userID := int64(1)
+	page := func(v int64) *int64 { return &v }
+
+	// Тестовая таблица с данными
+	testTable := []struct {
+		name                 string
+		method               string
+		path                 string
+		inputBody            string
+		mockBehaviour        mockBehaviour
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:   "Chats OK",
+			method: http.MethodGet,
+			path:   "/api/v2/chats?limit=1&offset=2",
+			mockBehaviour: func(c *mockService.MockChat, m *mockService.MockMessage) {
+				c.EXPECT().GetChat(dto.ChatGet{UserID: &userID, Limit: 1, Offset: 2}).
+					Return([]entity.Chat{{Id: 3, Name: "chat_3", CreatedAt: "2024-09-20T18:26:13Z"}}, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"status":"OK","message":"Chats get successfully","chats_list":[{"id":3,"name":"chat_3","created_at":"2024-09-20T18:26:13Z","is_deleted":false,"has_draft":false}]}`,
+		},
+		{
+			name:   "Chats without paging",
+			method: http.MethodGet,
+			path:   "/api/v2/chats",
+			mockBehaviour: func(c *mockService.MockChat, m *mockService.MockMessage) {
+				c.EXPECT().GetChat(dto.ChatGet{UserID: &userID}).Return(nil, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"status":"OK","message":"Chats get successfully"}`,
+		},
+		{
+			name:                 "Chats invalid limit",
+			method:               http.MethodGet,
+			path:                 "/api/v2/chats?limit=abc",
+			mockBehaviour:        func(c *mockService.MockChat, m *mockService.MockMessage) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":"Error","code":"validation","error":"Invalid query parameter limit"}`,
+		},
+		{
+			name:                 "Chats limit too big",
+			method:               http.MethodGet,
+			path:                 "/api/v2/chats?limit=1000",
+			mockBehaviour:        func(c *mockService.MockChat, m *mockService.MockMessage) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":"Error","code":"validation","error":"Field Limit cannot exceed 100 characters"}`,
+		},
+		{
+			name:   "Messages default page",
+			method: http.MethodGet,
+			path:   "/api/v2/chats/2/messages",
+			mockBehaviour: func(c *mockService.MockChat, m *mockService.MockMessage) {
+				m.EXPECT().GetMessage(dto.MessageGet{ChatID: 2, Limit: page(20), Offset: page(0)}, 1).
+					Return([]entity.Message{{Id: 1, Text: "msg1", UserID: 1, CreatedAt: "2024-09-20T18:26:13Z"}}, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"status":"OK","message":"Messages get successfully","messages_list":[{"id":1,"text":"msg1","user_id":1,"created_at":"2024-09-20T18:26:13Z","is_deleted":false}]}`,
+		},
+		{
+			name:   "Messages not a member",
+			method: http.MethodGet,
+			path:   "/api/v2/chats/2/messages?limit=5&offset=10",
+			mockBehaviour: func(c *mockService.MockChat, m *mockService.MockMessage) {
+				m.EXPECT().GetMessage(dto.MessageGet{ChatID: 2, Limit: page(5), Offset: page(10)}, 1).
+					Return(nil, apperr.NotFound("User with userID 1 does not exist in chatID 2"))
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"status":"Error","code":"not_found","error":"Failed to get messages: User with userID 1 does not exist in chatID 2"}`,
+		},
+		{
+			name:                 "Messages invalid chat id",
+			method:               http.MethodGet,
+			path:                 "/api/v2/chats/abc/messages",
+			mockBehaviour:        func(c *mockService.MockChat, m *mockService.MockMessage) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":"Error","code":"validation","error":"Invalid id in path"}`,
+		},
+		{
+			name:      "Update OK",
+			method:    http.MethodPatch,
+			path:      "/api/v2/messages/7",
+			inputBody: `{"text":"new text","format":"markdown"}`,
+			mockBehaviour: func(c *mockService.MockChat, m *mockService.MockMessage) {
+				m.EXPECT().UpdateMessage(dto.MessageUpdate{MessageID: 7, UserID: 1, NewText: "new text", Format: "markdown"}).Return(7, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"status":"OK","message":"Message update successfully, id: 7"}`,
+		},
+		{
+			name:                 "Update without text",
+			method:               http.MethodPatch,
+			path:                 "/api/v2/messages/7",
+			inputBody:            `{"format":"plain"}`,
+			mockBehaviour:        func(c *mockService.MockChat, m *mockService.MockMessage) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":"Error","code":"validation","error":"Field Text is a required field"}`,
+		},
+		{
+			name:      "Update service error",
+			method:    http.MethodPatch,
+			path:      "/api/v2/messages/7",
+			inputBody: `{"text":"new text"}`,
+			mockBehaviour: func(c *mockService.MockChat, m *mockService.MockMessage) {
+				m.EXPECT().UpdateMessage(dto.MessageUpdate{MessageID: 7, UserID: 1, NewText: "new text"}).Return(0, errors.New("db error"))
+			},
+			expectedStatusCode:   http.StatusInternalServerError,
+			expectedResponseBody: `{"status":"Error","code":"internal","error":"Failed to update message"}`,
+		},
+		{
+			name:   "Delete OK",
+			method: http.MethodDelete,
+			path:   "/api/v2/messages/7",
+			mockBehaviour: func(c *mockService.MockChat, m *mockService.MockMessage) {
+				m.EXPECT().DeleteMessage(dto.MessageDelete{MessageIds: &[]int64{7}}, 1).
+					Return([]entity.DelMsg{{MessageID: 7, Result: "Message successfully deleted"}}, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"status":"OK","message":"Result of deleted messages","del_msg_list":[{"message_id":7,"result":"Message successfully deleted"}]}`,
+		},
+		{
+			name:   "Delete not found",
+			method: http.MethodDelete,
+			path:   "/api/v2/messages/8",
+			mockBehaviour: func(c *mockService.MockChat, m *mockService.MockMessage) {
+				m.EXPECT().DeleteMessage(dto.MessageDelete{MessageIds: &[]int64{8}}, 1).Return(nil, apperr.NotFound("Message not found"))
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"status":"Error","code":"not_found","error":"Failed to delete message: Message not found"}`,
+		},
+		{
+			name:   "Delete already deleted",
+			method: http.MethodDelete,
+			path:   "/api/v2/messages/9",
+			mockBehaviour: func(c *mockService.MockChat, m *mockService.MockMessage) {
+				m.EXPECT().DeleteMessage(dto.MessageDelete{MessageIds: &[]int64{9}}, 1).
+					Return([]entity.DelMsg{{MessageID: 9, Result: "Message does not exist or has already been deleted"}}, nil)
+			},
+			expectedStatusCode:   http.StatusNotFound,
+			expectedResponseBody: `{"status":"Error","code":"not_found","error":"Failed to delete message: Message not found"}`,
+		},
+	}
+
+	// Итерируемся по нашей тестовой таблице
+	for _, tt := range testTable {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockBehaviour(mockChat, mockMessage)
+
+			// Готовим и выполняем тестовый запрос
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(tt.method, tt.path, bytes.NewBufferString(tt.inputBody))
+			r.ServeHTTP(w, req.WithContext(context.WithValue(req.Context(), userCtx, 1)))
+
+			// Сравниваем ожидаемый и актуальный результат
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			assert.Equal(t, tt.expectedResponseBody, strings.TrimSpace(w.Body.String()))
+		})
+	}
+}
@@ -0,0 +1,319 @@
+package handler
+
+import (
+	"log/slog"
+	"net/http"
+	"net/url"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+
+	"service-chat/internal/apperr"
+	"service-chat/internal/dto"
+	"service-chat/internal/logger"
+	"service-chat/internal/validate"
+)
+
+// WebhookAdd - создать вебхук чата
+// @Summary WebhookAdd
+// @Security ApiKeyAuth
+// @Tags Webhook
+// @Description Create an outgoing webhook of the chat, only chat admins can do this. New messages of the chat are sent to the url as signed POST requests. The signing secret is returned only in this response
+// @ID Add webhook
+// @Accept json
+// @Produce json
+// @Param id path int true "chat id"
+// @Param input body dto.WebhookAdd true "webhook url"
+// @Success 200 {object} Response{Status, Message, Webhook}
+// @Failure 400,404,405 {object} Response
+// @Failure 500 {object} Response
+// @Failure default {object} Response
+// @Router /chats/{id}/webhooks [post]
+func (h *Handler) WebhookAdd(log *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Логируем наш запрос
+		const op = "handler.WebhookAdd"
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		// Получаем id пользователя из контекста
+		idCtx, errCtx := GetUserID(r.Context())
+		if errCtx != nil {
+			log.Error("failed to get userID from context")
+			renderError(w, r, "", errCtx)
+			return
+		}
+
+		// Получаем id чата из пути запроса
+		chatID, errID := GetPathID(r)
+		if errID != nil {
+			log.Error("invalid chat ID")
+			renderError(w, r, "", errID)
+			return
+		}
+
+		// Структура для записи входных данных из JSON от пользователя
+		var req dto.WebhookAdd
+
+		// Анализируем запрос от пользователя
+		fail := validate.BaseValidate(log, r.Body, &req)
+		if fail != nil && fail.ValidateErr != nil {
+			log.Error("invalid request data")
+			renderError(w, r, "", fail.ValidateErr)
+			return
+		} else if fail != nil && fail.ErrMsg != "" {
+			log.Error("invalid request data")
+			renderError(w, r, "", apperr.Validation(fail.ErrMsg))
+			return
+		}
+
+		// Отправляем валидную структуру на слой сервиса
+		hook, errAdd := h.services.Webhook.AddWebhook(req, chatID, idCtx)
+		if errAdd != nil {
+			log.Error("failed to add webhook", logger.Err(errAdd))
+			renderError(w, r, "Failed to add webhook", errAdd)
+			return
+		}
+
+		// Если ошибок нет отправляем успешный ответ
+		log.Info("Webhook created successfully", slog.Int64("webhookID", hook.ID))
+		render.JSON(w, r, Response{
+			Status:  StatusOK,
+			Message: "Webhook created successfully",
+			Webhook: hook,
+		})
+		return
+	}
+}
+
+// WebhookGet - вебхуки чата
+// @Summary WebhookGet
+// @Security ApiKeyAuth
+// @Tags Webhook
+// @Description Get outgoing webhooks of the chat without signing secrets, only chat admins can do this
+// @ID Get webhooks
+// @Produce json
+// @Param id path int true "chat id"
+// @Success 200 {object} Response{Status, Message, WebhooksList}
+// @Failure 400,404,405 {object} Response
+// @Failure 500 {object} Response
+// @Failure default {object} Response
+// @Router /chats/{id}/webhooks [get]
+func (h *Handler) WebhookGet(log *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Логируем наш запрос
+		const op = "handler.WebhookGet"
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		// Получаем id пользователя из контекста
+		idCtx, errCtx := GetUserID(r.Context())
+		if errCtx != nil {
+			log.Error("failed to get userID from context")
+			renderError(w, r, "", errCtx)
+			return
+		}
+
+		// Получаем id чата из пути запроса
+		chatID, errID := GetPathID(r)
+		if errID != nil {
+			log.Error("invalid chat ID")
+			renderError(w, r, "", errID)
+			return
+		}
+
+		// Отправляем запрос на слой сервиса
+		hooks, errGet := h.services.Webhook.GetWebhooks(chatID, idCtx)
+		if errGet != nil {
+			log.Error("failed to get webhooks", logger.Err(errGet))
+			renderError(w, r, "Failed to get webhooks", errGet)
+			return
+		}
+
+		// Если вебхуков нет
+		if len(hooks) == 0 {
+			log.Info("webhooks not found")
+			render.JSON(w, r, OK("No webhooks found"))
+			return
+		}
+
+		// Если ошибок нет отправляем успешный ответ
+		log.Info("Webhooks found successfully", slog.Int("count", len(hooks)))
+		render.JSON(w, r, Response{
+			Status:       StatusOK,
+			Message:      "Webhooks found successfully",
+			WebhooksList: hooks,
+		})
+		return
+	}
+}
+
+// WebhookDelete - удалить вебхук чата
+// @Summary WebhookDelete
+// @Security ApiKeyAuth
+// @Tags Webhook
+// @Description Delete an outgoing webhook of the chat together with its undelivered events, only chat admins can do this
+// @ID Delete webhook
+// @Produce json
+// @Param id path int true "chat id"
+// @Param webhookID path int true "webhook id"
+// @Success 200 {object} Response{Status, Message}
+// @Failure 400,404,405 {object} Response
+// @Failure 500 {object} Response
+// @Failure default {object} Response
+// @Router /chats/{id}/webhooks/{webhookID} [delete]
+func (h *Handler) WebhookDelete(log *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Логируем наш запрос
+		const op = "handler.WebhookDelete"
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		// Получаем id пользователя из контекста
+		idCtx, errCtx := GetUserID(r.Context())
+		if errCtx != nil {
+			log.Error("failed to get userID from context")
+			renderError(w, r, "", errCtx)
+			return
+		}
+
+		// Получаем id чата и вебхука из пути запроса
+		chatID, webhookID, errID := webhookPath(r)
+		if errID != nil {
+			log.Error("invalid chat or webhook ID")
+			renderError(w, r, "", errID)
+			return
+		}
+
+		// Отправляем запрос на слой сервиса
+		if errDel := h.services.Webhook.DeleteWebhook(webhookID, chatID, idCtx); errDel != nil {
+			log.Error("failed to delete webhook", logger.Err(errDel))
+			renderError(w, r, "Failed to delete webhook", errDel)
+			return
+		}
+
+		// Если ошибок нет отправляем успешный ответ
+		log.Info("Webhook deleted successfully", slog.Int64("webhookID", webhookID))
+		render.JSON(w, r, OK("Webhook deleted successfully"))
+		return
+	}
+}
+
+// WebhookAttempts - последние попытки отправки событий в вебхук
+// @Summary WebhookAttempts
+// @Security ApiKeyAuth
+// @Tags Webhook
+// @Description Get recent delivery attempts of the webhook, newest first, only chat admins can do this. delivery_status shows whether the event is still retried (pending), delivered or gave up (dead)
+// @ID Get webhook attempts
+// @Produce json
+// @Param id path int true "chat id"
+// @Param webhookID path int true "webhook id"
+// @Param limit query int false "page size, 1-100, default 20"
+// @Success 200 {object} Response{Status, Message, AttemptsList}
+// @Failure 400,404,405 {object} Response
+// @Failure 500 {object} Response
+// @Failure default {object} Response
+// @Router /chats/{id}/webhooks/{webhookID}/attempts [get]
+func (h *Handler) WebhookAttempts(log *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Логируем наш запрос
+		const op = "handler.WebhookAttempts"
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		// Получаем id пользователя из контекста
+		idCtx, errCtx := GetUserID(r.Context())
+		if errCtx != nil {
+			log.Error("failed to get userID from context")
+			renderError(w, r, "", errCtx)
+			return
+		}
+
+		// Получаем id чата и вебхука из пути запроса
+		chatID, webhookID, errID := webhookPath(r)
+		if errID != nil {
+			log.Error("invalid chat or webhook ID")
+			renderError(w, r, "", errID)
+			return
+		}
+
+		// Заполняем структуру запроса из query параметров
+		req, errParse := parseWebhookAttempts(r.URL.Query())
+		if errParse != nil {
+			log.Error("invalid query parameters", logger.Err(errParse))
+			renderError(w, r, "", errParse)
+			return
+		}
+
+		// Анализируем запрос от пользователя
+		fail := validate.StructValidate(log, &req)
+		if fail != nil && fail.ValidateErr != nil {
+			log.Error("invalid request data")
+			renderError(w, r, "", fail.ValidateErr)
+			return
+		} else if fail != nil && fail.ErrMsg != "" {
+			log.Error("invalid request data")
+			renderError(w, r, "", apperr.Validation(fail.ErrMsg))
+			return
+		}
+
+		// Отправляем валидную структуру на слой сервиса
+		attempts, errGet := h.services.Webhook.GetAttempts(req, webhookID, chatID, idCtx)
+		if errGet != nil {
+			log.Error("failed to get webhook attempts", logger.Err(errGet))
+			renderError(w, r, "Failed to get webhook attempts", errGet)
+			return
+		}
+
+		// Если попыток отправки ещё не было
+		if len(attempts) == 0 {
+			log.Info("webhook attempts not found")
+			render.JSON(w, r, OK("No delivery attempts found"))
+			return
+		}
+
+		// Если ошибок нет отправляем успешный ответ
+		log.Info("Webhook attempts found successfully", slog.Int("count", len(attempts)))
+		render.JSON(w, r, Response{
+			Status:       StatusOK,
+			Message:      "Webhook attempts found successfully",
+			AttemptsList: attempts,
+		})
+		return
+	}
+}
+
+// webhookPath - достаём из пути запроса id чата и id вебхука
+func webhookPath(r *http.Request) (int64, int64, error) {
+	chatID, err := GetPathID(r)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	webhookID, err := getPathParamID(r, webhookIDParam)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return chatID, webhookID, nil
+}
+
+// parseWebhookAttempts - разбираем query параметры истории отправки
+func parseWebhookAttempts(values url.Values) (dto.WebhookAttemptsGet, error) {
+	var req dto.WebhookAttemptsGet
+	var err error
+
+	if req.Limit, err = queryInt64(values, "limit"); err != nil {
+		return req, err
+	}
+
+	return req, nil
+}
@@ -0,0 +1,177 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"service-chat/internal/apperr"
+	"service-chat/internal/db/entity"
+	"service-chat/internal/dto"
+	"service-chat/internal/service"
+	mockService "service-chat/internal/service/mocks"
+)
+
+func TestHandler_Webhook(t *testing.T) {
+	// Структура для последующей реализации поведения мока
+	type mockBehaviour func(s *mockService.MockWebhook)
+
+	//Инициализируем контролер для мока сервиса
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Создаём моки сервиса вебхуков
+	mockWebhook := mockService.NewMockWebhook(ctrl)
+
+	// Создаём экземпляр обработчика
+	handler := NewHandler(&service.Service{Webhook: mockWebhook})
+
+	// Мокируем логгер
+	mockLog := slog.New(slog.NewJSONHandler(io.Discard, nil))
+
+	// Инициализируем сервер
+	r := chi.NewRouter()
+	r.Post("/chats/{id}/webhooks", handler.WebhookAdd(mockLog))
+	r.Get("/chats/{id}/webhooks", handler.WebhookGet(mockLog))
+	r.Delete("/chats/{id}/webhooks/{webhookID}", handler.WebhookDelete(mockLog))
+	r.Get("/chats/{id}/webhooks/{webhookID}/attempts", handler.WebhookAttempts(mockLog))
+
+	// Тестовая таблица с данными
+	testTable := []struct {
+		name                 string
+		method               string
+		path                 string
+		inputBody            string
+		mockBehaviour        mockBehaviour
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:      "Add OK",
+			method:    http.MethodPost,
+			path:      "/chats/2/webhooks",
+			inputBody: `{"url":"https://example.com/hook"}`,
+			mockBehaviour: func(s *mockService.MockWebhook) {
+				s.EXPECT().AddWebhook(dto.WebhookAdd{URL: "https://example.com/hook"}, int64(2), 1).
+					Return(&entity.Webhook{ID: 3, ChatID: 2, UserID: 1, URL: "https://example.com/hook", Secret: "whsec_1", CreatedAt: "2024-01-01T00:00:00Z"}, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"status":"OK","message":"Webhook created successfully","webhook":{"id":3,"chat_id":2,"user_id":1,"url":"https://example.com/hook","secret":"whsec_1","created_at":"2024-01-01T00:00:00Z"}}`,
+		},
+		{
+			name:                 "Add without url",
+			method:               http.MethodPost,
+			path:                 "/chats/2/webhooks",
+			inputBody:            `{}`,
+			mockBehaviour:        func(s *mockService.MockWebhook) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":"Error","code":"validation","error":"Field URL is a required field"}`,
+		},
+		{
+			name:      "Add not an admin",
+			method:    http.MethodPost,
+			path:      "/chats/2/webhooks",
+			inputBody: `{"url":"https://example.com/hook"}`,
+			mockBehaviour: func(s *mockService.MockWebhook) {
+				s.EXPECT().AddWebhook(dto.WebhookAdd{URL: "https://example.com/hook"}, int64(2), 1).
+					Return(nil, apperr.Forbidden("Only chat admins can do this"))
+			},
+			expectedStatusCode:   http.StatusForbidden,
+			expectedResponseBody: `{"status":"Error","code":"forbidden","error":"Failed to add webhook: Only chat admins can do this"}`,
+		},
+		{
+			name:   "Get OK",
+			method: http.MethodGet,
+			path:   "/chats/2/webhooks",
+			mockBehaviour: func(s *mockService.MockWebhook) {
+				s.EXPECT().GetWebhooks(int64(2), 1).
+					Return([]entity.Webhook{{ID: 3, ChatID: 2, UserID: 1, URL: "https://example.com/hook", CreatedAt: "2024-01-01T00:00:00Z"}}, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"status":"OK","message":"Webhooks found successfully","webhooks_list":[{"id":3,"chat_id":2,"user_id":1,"url":"https://example.com/hook","created_at":"2024-01-01T00:00:00Z"}]}`,
+		},
+		{
+			name:   "Get empty",
+			method: http.MethodGet,
+			path:   "/chats/2/webhooks",
+			mockBehaviour: func(s *mockService.MockWebhook) {
+				s.EXPECT().GetWebhooks(int64(2), 1).Return(nil, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"status":"OK","message":"No webhooks found"}`,
+		},
+		{
+			name:   "Delete OK",
+			method: http.MethodDelete,
+			path:   "/chats/2/webhooks/3",
+			mockBehaviour: func(s *mockService.MockWebhook) {
+				s.EXPECT().DeleteWebhook(int64(3), int64(2), 1).Return(nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"status":"OK","message":"Webhook deleted successfully"}`,
+		},
+		{
+			name:                 "Delete invalid webhook id",
+			method:               http.MethodDelete,
+			path:                 "/chats/2/webhooks/abc",
+			mockBehaviour:        func(s *mockService.MockWebhook) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":"Error","code":"validation","error":"Invalid webhookID in path"}`,
+		},
+		{
+			name:   "Attempts OK",
+			method: http.MethodGet,
+			path:   "/chats/2/webhooks/3/attempts?limit=5",
+			mockBehaviour: func(s *mockService.MockWebhook) {
+				s.EXPECT().GetAttempts(dto.WebhookAttemptsGet{Limit: 5}, int64(3), int64(2), 1).
+					Return([]entity.WebhookAttempt{{DeliveryID: 7, Event: "message.created", DeliveryStatus: "dead", Attempt: 8,
+						StatusCode: 500, Error: "unexpected status 500", DurationMs: 12, CreatedAt: "2024-01-01T00:00:00Z"}}, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"status":"OK","message":"Webhook attempts found successfully","attempts_list":[{"delivery_id":7,"event":"message.created","delivery_status":"dead","attempt":8,"status_code":500,"error":"unexpected status 500","duration_ms":12,"created_at":"2024-01-01T00:00:00Z"}]}`,
+		},
+		{
+			name:                 "Attempts invalid limit",
+			method:               http.MethodGet,
+			path:                 "/chats/2/webhooks/3/attempts?limit=500",
+			mockBehaviour:        func(s *mockService.MockWebhook) {},
+			expectedStatusCode:   http.StatusBadRequest,
+			expectedResponseBody: `{"status":"Error","code":"validation","error":"Field Limit cannot exceed 100 characters"}`,
+		},
+		{
+			name:   "Attempts empty",
+			method: http.MethodGet,
+			path:   "/chats/2/webhooks/3/attempts",
+			mockBehaviour: func(s *mockService.MockWebhook) {
+				s.EXPECT().GetAttempts(dto.WebhookAttemptsGet{}, int64(3), int64(2), 1).Return(nil, nil)
+			},
+			expectedStatusCode:   http.StatusOK,
+			expectedResponseBody: `{"status":"OK","message":"No delivery attempts found"}`,
+		},
+	}
+
+	// Итерируемся по нашей тестовой таблице
+	for _, tt := range testTable {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockBehaviour(mockWebhook)
+
+			// Готовим и выполняем тестовый запрос
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(tt.method, tt.path, bytes.NewBufferString(tt.inputBody))
+			r.ServeHTTP(w, req.WithContext(context.WithValue(req.Context(), userCtx, 1)))
+
+			// Сравниваем ожидаемый и актуальный результат
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			assert.Equal(t, tt.expectedResponseBody, strings.TrimSpace(w.Body.String()))
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package handler
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"golang.org/x/net/websocket"
+
+	"service-chat/internal/logger"
+)
+
+// WebSocket - подключение для получения событий всех чатов пользователя в реальном времени
+// @Summary WebSocket
+// @Security ApiKeyAuth
+// @Tags Realtime
+// @Description Upgrade to a WebSocket connection delivering message.created, message.updated, message.deleted,
+// @Description chat.created and chat.deleted events for every chat of the user. The server sends {"type":"ping"}
+// @Description periodically; any message from the client keeps the connection alive
+// @ID WebSocket
+// @Success 101
+// @Failure default {object} Response
+// @Router /ws [get]
+func (h *Handler) WebSocket(log *slog.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Логируем наш запрос
+		const op = "handler.WebSocket"
+		log := log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		// Получаем id пользователя из контекста
+		idCtx, errCtx := GetUserID(r.Context())
+		if errCtx != nil {
+			log.Error("failed to get userID from context")
+			renderError(w, r, "", errCtx)
+			return
+		}
+
+		// Подписываемся на чаты пользователя до установки соединения, чтобы ошибку можно было вернуть обычным ответом
+		client, errSub := h.services.Realtime.Subscribe(idCtx)
+		if errSub != nil {
+			log.Error("failed to subscribe", logger.Err(errSub))
+			renderError(w, r, "Failed to subscribe", errSub)
+			return
+		}
+		defer func() {
+			if errUnsub := h.services.Realtime.Unsubscribe(client); errUnsub != nil {
+				log.Error("failed to unsubscribe", logger.Err(errUnsub))
+			}
+		}()
+
+		log.Info("WebSocket connected", slog.Int("user_id", idCtx))
+		websocket.Server{Handler: client.Serve}.ServeHTTP(w, r)
+		log.Info("WebSocket disconnected", slog.Int("user_id", idCtx))
+	}
+}
@@ -0,0 +1,95 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"golang.org/x/net/websocket"
+
+	"service-chat/internal/config"
+	"service-chat/internal/realtime"
+	"service-chat/internal/service"
+	mockService "service-chat/internal/service/mocks"
+)
+
+func TestHandler_WebSocket(t *testing.T) {
+	//Инициализируем контролер для мока сервиса
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Хаб настоящий, мокируем только подписку пользователя на чаты
+	hub := realtime.NewHub(config.Realtime{
+		SendBuffer:   4,
+		PingInterval: time.Minute,
+		PongTimeout:  time.Minute,
+		WriteTimeout: time.Second,
+	})
+	mockRealtime := mockService.NewMockRealtime(ctrl)
+	handler := NewHandler(&service.Service{Realtime: mockRealtime})
+
+	// Мокируем логгер
+	mockLog := slog.New(slog.NewJSONHandler(io.Discard, nil))
+
+	// Инициализируем сервер, пользователь приходит из контекста так же, как после AuthMiddleware
+	r := chi.NewRouter()
+	r.Get("/ws", func(w http.ResponseWriter, req *http.Request) {
+		handler.WebSocket(mockLog)(w, req.WithContext(context.WithValue(req.Context(), userCtx, 1)))
+	})
+	srv := httptest.NewServer(r)
+	defer srv.Close()
+
+	t.Run("Subscribe error", func(t *testing.T) {
+		mockRealtime.EXPECT().Subscribe(1).Return(nil, errors.New("db error"))
+
+		resp, err := http.Get(srv.URL + "/ws")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+		assert.Equal(t, `{"status":"Error","code":"internal","error":"Failed to subscribe"}`, strings.TrimSpace(string(body)))
+	})
+
+	t.Run("Events of user chats", func(t *testing.T) {
+		unsubscribed := make(chan struct{})
+		mockRealtime.EXPECT().Subscribe(1).DoAndReturn(func(userID int) (*realtime.Client, error) {
+			return hub.Register(int64(userID), []int64{2})
+		})
+		mockRealtime.EXPECT().Unsubscribe(gomock.Any()).Do(func(c *realtime.Client) {
+			hub.Unregister(c)
+			close(unsubscribed)
+		})
+
+		wsURL := "ws" + strings.TrimPrefix(srv.URL, "http") + "/ws"
+		ws, err := websocket.Dial(wsURL, "", srv.URL)
+		require.NoError(t, err)
+
+		// Событие чужого чата не приходит, событие чата пользователя приходит
+		hub.Publish(realtime.Event{Type: realtime.EventMessageDeleted, ChatID: 3})
+		hub.Publish(realtime.Event{Type: realtime.EventMessageCreated, ChatID: 2, Data: realtime.MessagePayload{MessageID: 7}})
+
+		require.NoError(t, ws.SetReadDeadline(time.Now().Add(5*time.Second)))
+		var msg string
+		require.NoError(t, websocket.Message.Receive(ws, &msg))
+		assert.Equal(t, `{"type":"message.created","chat_id":2,"data":{"message_id":7}}`, msg)
+
+		// После отключения клиента подписка снимается
+		require.NoError(t, ws.Close())
+		select {
+		case <-unsubscribed:
+		case <-time.After(5 * time.Second):
+			t.Fatal("client was not unsubscribed")
+		}
+	})
+}
@@ -0,0 +1,207 @@
+package markdown
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Типы узлов дерева разбора
+const (
+	NodeDocument  = "document"
+	NodeParagraph = "paragraph"
+	NodeText      = "text"
+	NodeBreak     = "break"
+	NodeStrong    = "strong"
+	NodeEmphasis  = "emphasis"
+	NodeStrike    = "strike"
+	NodeCode      = "code"
+	NodeCodeBlock = "code_block"
+	NodeLink      = "link"
+	NodeQuote     = "quote"
+	NodeList      = "list"
+	NodeListItem  = "list_item"
+)
+
+// maxDepth - максимальная вложенность цитат и inline-разметки, глубже разметка остаётся текстом
+const maxDepth = 16
+
+// Node - узел дерева разбора markdown. Текст хранится как есть, экранирование выполняется при рендеринге
+type Node struct {
+	Type     string  `json:"type"`
+	Text     string  `json:"text,omitempty"`
+	URL      string  `json:"url,omitempty"`
+	Children []*Node `json:"children,omitempty"`
+}
+
+// Parse - разбираем текст сообщения в дерево. Поддерживается подмножество markdown:
+// абзацы, **жирный**, *курсив*, ~~зачёркнутый~~, `код`, блоки ``` кода ```, [ссылки](url), > цитаты и - списки.
+// Ссылки с недопустимой схемой остаются обычным текстом
+func Parse(src string) *Node {
+	src = strings.ReplaceAll(src, "\r\n", "\n")
+	src = strings.ReplaceAll(src, "\r", "\n")
+	return &Node{Type: NodeDocument, Children: parseBlocks(strings.Split(src, "\n"), 0)}
+}
+
+// parseBlocks - разбираем строки на блоки: абзацы, блоки кода, цитаты и списки
+func parseBlocks(lines []string, depth int) []*Node {
+	var blocks []*Node
+
+	for i := 0; i < len(lines); {
+		line := lines[i]
+
+		switch {
+		case strings.TrimSpace(line) == "":
+			i++
+
+		case strings.HasPrefix(line, "```"):
+			// Блок кода идёт до закрывающих ``` или до конца текста
+			var code []string
+			for i++; i < len(lines) && !strings.HasPrefix(lines[i], "```"); i++ {
+				code = append(code, lines[i])
+			}
+			i++
+			blocks = append(blocks, &Node{Type: NodeCodeBlock, Text: strings.Join(code, "\n")})
+
+		case isQuote(line) && depth < maxDepth:
+			var quoted []string
+			for ; i < len(lines) && isQuote(lines[i]); i++ {
+				quoted = append(quoted, strings.TrimPrefix(strings.TrimPrefix(lines[i], ">"), " "))
+			}
+			blocks = append(blocks, &Node{Type: NodeQuote, Children: parseBlocks(quoted, depth+1)})
+
+		case isListItem(line):
+			list := &Node{Type: NodeList}
+			for ; i < len(lines) && isListItem(lines[i]); i++ {
+				list.Children = append(list.Children, &Node{Type: NodeListItem, Children: parseInline(lines[i][2:], 0)})
+			}
+			blocks = append(blocks, list)
+
+		default:
+			// Абзац продолжается до пустой строки или начала другого блока, переносы строк сохраняются
+			para := &Node{Type: NodeParagraph}
+			for start := i; i < len(lines) && strings.TrimSpace(lines[i]) != ""; i++ {
+				if i > start && startsBlock(lines[i], depth) {
+					break
+				}
+				if i > start {
+					para.Children = append(para.Children, &Node{Type: NodeBreak})
+				}
+				para.Children = append(para.Children, parseInline(lines[i], 0)...)
+			}
+			blocks = append(blocks, para)
+		}
+	}
+
+	return blocks
+}
+
+// isQuote - строка цитаты начинается с >
+func isQuote(line string) bool {
+	return strings.HasPrefix(line, ">")
+}
+
+// isListItem - элемент списка начинается с "- " или "* "
+func isListItem(line string) bool {
+	return strings.HasPrefix(line, "- ") || strings.HasPrefix(line, "* ")
+}
+
+// startsBlock - строка начинает новый блок и прерывает абзац
+func startsBlock(line string, depth int) bool {
+	return strings.HasPrefix(line, "```") || (isQuote(line) && depth < maxDepth) || isListItem(line)
+}
+
+// parseInline - разбираем inline-разметку внутри строки
+func parseInline(s string, depth int) []*Node {
+	var nodes []*Node
+	var text strings.Builder
+
+	// flush - сохраняем накопленный обычный текст отдельным узлом
+	flush := func() {
+		if text.Len() > 0 {
+			nodes = append(nodes, &Node{Type: NodeText, Text: text.String()})
+			text.Reset()
+		}
+	}
+
+	for i := 0; i < len(s); {
+		if depth < maxDepth {
+			if node, next := parseSpan(s, i, depth); node != nil {
+				flush()
+				nodes = append(nodes, node)
+				i = next
+				continue
+			}
+		}
+
+		// Экранированный символ разметки выводится как есть
+		if s[i] == '\\' && i+1 < len(s) && strings.IndexByte(markupChars, s[i+1]) >= 0 {
+			text.WriteByte(s[i+1])
+			i += 2
+			continue
+		}
+
+		_, size := utf8.DecodeRuneInString(s[i:])
+		text.WriteString(s[i : i+size])
+		i += size
+	}
+	flush()
+
+	return nodes
+}
+
+// markupChars - символы разметки, которые можно экранировать обратным слэшем
+const markupChars = "\\`*_~[]()>-#"
+
+// parseSpan - пробуем разобрать inline-элемент с позиции i, возвращаем узел и позицию после него
+func parseSpan(s string, i, depth int) (*Node, int) {
+	rest := s[i:]
+
+	switch {
+	case rest[0] == '`':
+		if end := strings.IndexByte(rest[1:], '`'); end > 0 {
+			return &Node{Type: NodeCode, Text: rest[1 : end+1]}, i + end + 2
+		}
+
+	case strings.HasPrefix(rest, "**"), strings.HasPrefix(rest, "~~"):
+		delim := rest[:2]
+		if end := strings.Index(rest[2:], delim); end > 0 {
+			nodeType := NodeStrong
+			if delim == "~~" {
+				nodeType = NodeStrike
+			}
+			return &Node{Type: nodeType, Children: parseInline(rest[2:end+2], depth+1)}, i + end + 4
+		}
+
+	case rest[0] == '*', rest[0] == '_':
+		// Подчёркивание внутри слова (snake_case) не считается разметкой
+		if rest[0] == '_' && i > 0 && isWordByte(s[i-1]) {
+			return nil, i
+		}
+		if end := strings.IndexByte(rest[1:], rest[0]); end > 0 {
+			return &Node{Type: NodeEmphasis, Children: parseInline(rest[1:end+1], depth+1)}, i + end + 2
+		}
+
+	case rest[0] == '[':
+		mid := strings.Index(rest, "](")
+		if mid < 0 {
+			return nil, i
+		}
+		end := strings.IndexByte(rest[mid+2:], ')')
+		if end < 0 {
+			return nil, i
+		}
+		href, ok := safeURL(rest[mid+2 : mid+2+end])
+		if !ok || mid == 1 {
+			return nil, i
+		}
+		return &Node{Type: NodeLink, URL: href, Children: parseInline(rest[1:mid], depth+1)}, i + mid + end + 3
+	}
+
+	return nil, i
+}
+
+// isWordByte - байт относится к слову: буква, цифра или часть многобайтового символа
+func isWordByte(b byte) bool {
+	return b >= utf8.RuneSelf || unicode.IsLetter(rune(b)) || unicode.IsDigit(rune(b))
+}
@@ -0,0 +1,152 @@
+package markdown
+
+import (
+	"html"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToHTML(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{
+			name: "Plain text",
+			in:   "hello world",
+			want: "<p>hello world</p>",
+		},
+		{
+			name: "Inline markup",
+			in:   "**bold** *italic* _also_ ~~gone~~ `x < y`",
+			want: "<p><strong>bold</strong> <em>italic</em> <em>also</em> <del>gone</del> <code>x &lt; y</code></p>",
+		},
+		{
+			name: "Nested markup",
+			in:   "**bold _and italic_**",
+			want: "<p><strong>bold <em>and italic</em></strong></p>",
+		},
+		{
+			name: "Snake case is not emphasis",
+			in:   "call snake_case_name",
+			want: "<p>call snake_case_name</p>",
+		},
+		{
+			name: "Escaped markup",
+			in:   `\*not italic\*`,
+			want: "<p>*not italic*</p>",
+		},
+		{
+			name: "Paragraphs and line breaks",
+			in:   "first\nline\n\nsecond",
+			want: "<p>first<br>line</p><p>second</p>",
+		},
+		{
+			name: "Link",
+			in:   "see [docs](https://example.com/a?b=1&c=2)",
+			want: `<p>see <a href="https://example.com/a?b=1&amp;c=2" rel="nofollow noopener noreferrer" target="_blank">docs</a></p>`,
+		},
+		{
+			name: "Mailto link",
+			in:   "[mail](mailto:a@example.com)",
+			want: `<p><a href="mailto:a@example.com" rel="nofollow noopener noreferrer" target="_blank">mail</a></p>`,
+		},
+		{
+			name: "Javascript link is text",
+			in:   "[click](javascript:alert(1))",
+			want: "<p>[click](javascript:alert(1))</p>",
+		},
+		{
+			name: "Relative link is text",
+			in:   "[click](/admin)",
+			want: "<p>[click](/admin)</p>",
+		},
+		{
+			name: "Raw HTML is escaped",
+			in:   `<script>alert("x")</script><img src=x onerror=alert(1)>`,
+			want: "<p>&lt;script&gt;alert(&#34;x&#34;)&lt;/script&gt;&lt;img src=x onerror=alert(1)&gt;</p>",
+		},
+		{
+			name: "Code block",
+			in:   "```\n<b>**not bold**</b>\n```\nafter",
+			want: "<pre><code>&lt;b&gt;**not bold**&lt;/b&gt;</code></pre><p>after</p>",
+		},
+		{
+			name: "Quote and list",
+			in:   "> quoted **text**\n- one\n- two",
+			want: "<blockquote><p>quoted <strong>text</strong></p></blockquote><ul><li>one</li><li>two</li></ul>",
+		},
+		{
+			name: "Empty text",
+			in:   "",
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ToHTML(tt.in))
+		})
+	}
+}
+
+func TestRender_UnsafeLink(t *testing.T) {
+	// Дерево, собранное в обход Parse, тоже не должно давать опасных ссылок
+	root := &Node{Type: NodeDocument, Children: []*Node{
+		{Type: NodeLink, URL: "javascript:alert(1)", Children: []*Node{{Type: NodeText, Text: "click"}}},
+	}}
+	assert.Equal(t, "click", Render(root))
+}
+
+// tagRe - теги, которые может выдавать рендерер
+var tagRe = regexp.MustCompile(`^(?:<(?:p|br|strong|em|del|code|pre|blockquote|ul|li)>|</(?:p|strong|em|del|code|pre|blockquote|ul|li|a)>|<a href="([^"<>]*)" rel="nofollow noopener noreferrer" target="_blank">)`)
+
+// checkSafeHTML - проверяем, что в HTML есть только разрешённые теги без пользовательских атрибутов,
+// а ссылки ведут только на разрешённые схемы
+func checkSafeHTML(t *testing.T, in, out string) {
+	for i := 0; i < len(out); i++ {
+		switch out[i] {
+		case '<':
+			m := tagRe.FindStringSubmatch(out[i:])
+			if m == nil {
+				t.Fatalf("unexpected tag at %d in %q (input %q)", i, out, in)
+			}
+			if m[1] != "" {
+				href := strings.ToLower(html.UnescapeString(m[1]))
+				if !strings.HasPrefix(href, "http://") && !strings.HasPrefix(href, "https://") && !strings.HasPrefix(href, "mailto:") {
+					t.Fatalf("unsafe href %q in %q (input %q)", m[1], out, in)
+				}
+			}
+			i += len(m[0]) - 1
+		case '>', '"', '\'':
+			t.Fatalf("unescaped %q at %d in %q (input %q)", out[i], i, out, in)
+		}
+	}
+}
+
+func FuzzToHTML(f *testing.F) {
+	seeds := []string{
+		"**bold** *italic* ~~strike~~ `code`",
+		"[link](https://example.com)",
+		"[x](javascript:alert(1))",
+		"[x](JaVaScRiPt:alert(1))",
+		"[x](data:text/html;base64,PHNjcmlwdD4=)",
+		`[x](https://a.com" onmouseover="alert(1))`,
+		"<script>alert(1)</script>",
+		"<img src=x onerror=alert(1)>",
+		"```\n</code></pre><script>\n```",
+		"> > > **[a](http://b)**\n- `<`\n- _'_",
+		"\\<\\>\\&",
+	}
+	for _, seed := range seeds {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, in string) {
+		checkSafeHTML(t, in, ToHTML(in))
+	})
+}
@@ -0,0 +1,98 @@
+package markdown
+
+import (
+	"html"
+	"net/url"
+	"strings"
+	"unicode"
+)
+
+// allowedSchemes - схемы, которые разрешены в ссылках, всё остальное (javascript:, data: и т.д.) отбрасывается
+var allowedSchemes = map[string]bool{
+	"http":   true,
+	"https":  true,
+	"mailto": true,
+}
+
+// ToHTML - разбираем markdown и возвращаем безопасный HTML
+func ToHTML(src string) string {
+	return Render(Parse(src))
+}
+
+// Render - рендерим дерево в HTML. Весь текст и адреса ссылок экранируются,
+// используются только теги из фиксированного списка без пользовательских атрибутов
+func Render(root *Node) string {
+	var b strings.Builder
+	render(&b, root)
+	return b.String()
+}
+
+// render - рекурсивно рендерим узел
+func render(b *strings.Builder, n *Node) {
+	switch n.Type {
+	case NodeText:
+		b.WriteString(html.EscapeString(n.Text))
+	case NodeBreak:
+		b.WriteString("<br>")
+	case NodeCode:
+		b.WriteString("<code>" + html.EscapeString(n.Text) + "</code>")
+	case NodeCodeBlock:
+		b.WriteString("<pre><code>" + html.EscapeString(n.Text) + "</code></pre>")
+	case NodeLink:
+		// Адрес проверяем ещё раз: дерево могло быть собрано не через Parse
+		href, ok := safeURL(n.URL)
+		if !ok {
+			renderChildren(b, n)
+			return
+		}
+		b.WriteString(`<a href="` + html.EscapeString(href) + `" rel="nofollow noopener noreferrer" target="_blank">`)
+		renderChildren(b, n)
+		b.WriteString("</a>")
+	default:
+		tag := tags[n.Type]
+		if tag != "" {
+			b.WriteString("<" + tag + ">")
+		}
+		renderChildren(b, n)
+		if tag != "" {
+			b.WriteString("</" + tag + ">")
+		}
+	}
+}
+
+// tags - HTML теги узлов-контейнеров
+var tags = map[string]string{
+	NodeParagraph: "p",
+	NodeStrong:    "strong",
+	NodeEmphasis:  "em",
+	NodeStrike:    "del",
+	NodeQuote:     "blockquote",
+	NodeList:      "ul",
+	NodeListItem:  "li",
+}
+
+// renderChildren - рендерим дочерние узлы
+func renderChildren(b *strings.Builder, n *Node) {
+	for _, child := range n.Children {
+		render(b, child)
+	}
+}
+
+// safeURL - проверяем адрес ссылки: только абсолютные адреса с разрешённой схемой и без управляющих символов
+func safeURL(raw string) (string, bool) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" || strings.IndexFunc(raw, func(r rune) bool { return unicode.IsControl(r) || unicode.IsSpace(r) }) >= 0 {
+		return "", false
+	}
+
+	u, err := url.Parse(raw)
+	if err != nil {
+		return "", false
+	}
+	scheme := strings.ToLower(u.Scheme)
+	if !allowedSchemes[scheme] || (scheme != "mailto" && u.Host == "") {
+		return "", false
+	}
+
+	return raw, true
+}
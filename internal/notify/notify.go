@@ -0,0 +1,71 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Каналы уведомлений
+const (
+	ChannelEmail = "email"
+	ChannelPush  = "push"
+)
+
+// Item - сообщение, о котором уведомляем пользователя
+type Item struct {
+	ChatID    int64  `json:"chat_id"`
+	Chat      string `json:"chat"`
+	MessageID int64  `json:"message_id"`
+	Author    string `json:"author"`
+	Text      string `json:"text"`
+	// Mention - пользователь упомянут в сообщении
+	Mention   bool   `json:"mention,omitempty"`
+	CreatedAt string `json:"created_at"`
+}
+
+// Digest - уведомление пользователю: одно или несколько сообщений, накопленных с прошлой отправки
+type Digest struct {
+	UserID int64
+	// Address - адрес получателя в канале: почта или push токен устройства
+	Address string
+	Items   []Item
+	// More - сколько сообщений не поместилось в уведомление
+	More int
+}
+
+// Notifier - интерфейс канала уведомлений
+type Notifier interface {
+	// Channel - имя канала, по нему выбирается адрес получателя
+	Channel() string
+	// Notify - отправляем уведомление, ошибка означает, что его нужно отправить повторно
+	Notify(ctx context.Context, d Digest) error
+}
+
+// Title - заголовок уведомления: автор и чат единственного сообщения или количество сообщений
+func (d Digest) Title() string {
+	total := len(d.Items) + d.More
+	if total == 1 {
+		item := d.Items[0]
+		if item.Mention {
+			return fmt.Sprintf("%s mentioned you in %s", item.Author, item.Chat)
+		}
+		return fmt.Sprintf("New message from %s in %s", item.Author, item.Chat)
+	}
+	return fmt.Sprintf("%d new messages", total)
+}
+
+// Body - текст уведомления: по строке на сообщение
+func (d Digest) Body() string {
+	var b strings.Builder
+	for i, item := range d.Items {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		b.WriteString(fmt.Sprintf("[%s] %s: %s", item.Chat, item.Author, item.Text))
+	}
+	if d.More > 0 {
+		b.WriteString(fmt.Sprintf("\n...and %d more", d.More))
+	}
+	return b.String()
+}
@@ -0,0 +1,106 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"service-chat/internal/config"
+	"service-chat/internal/webhook"
+)
+
+// Заголовки запроса к провайдеру push уведомлений
+const (
+	HeaderTimestamp = "X-Push-Timestamp"
+	HeaderSignature = "X-Push-Signature"
+)
+
+const userAgent = "service-chat-push/1.0"
+
+// PushRequest - тело запроса к провайдеру push уведомлений
+type PushRequest struct {
+	UserID int64  `json:"user_id"`
+	Token  string `json:"token"`
+	Title  string `json:"title"`
+	Body   string `json:"body"`
+	Items  []Item `json:"items"`
+	More   int    `json:"more,omitempty"`
+}
+
+// PushNotifier - push уведомления POST запросом с подписанным json к провайдеру, который доставляет их на устройства.
+// Адрес провайдера задаёт администратор сервиса, поэтому он может быть во внутренней сети
+type PushNotifier struct {
+	cfg    config.Push
+	client *http.Client
+	// now - текущее время, подменяется в тестах
+	now func() time.Time
+}
+
+// NewPushNotifier - конструктор push уведомлений
+func NewPushNotifier(cfg config.Push) *PushNotifier {
+	return &PushNotifier{
+		cfg: cfg,
+		client: &http.Client{
+			Timeout: cfg.Timeout,
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		now: time.Now,
+	}
+}
+
+// Channel - push уведомления отправляются на токен устройства из настроек пользователя
+func (n *PushNotifier) Channel() string {
+	return ChannelPush
+}
+
+// Notify - отправляем уведомление провайдеру, ответ с кодом не из 2xx - ошибка
+func (n *PushNotifier) Notify(ctx context.Context, d Digest) error {
+	const op = "notify.PushNotifier.Notify"
+
+	if len(d.Items) == 0 {
+		return nil
+	}
+
+	payload, err := json.Marshal(PushRequest{
+		UserID: d.UserID,
+		Token:  d.Address,
+		Title:  d.Title(),
+		Body:   d.Body(),
+		Items:  d.Items,
+		More:   d.More,
+	})
+	if err != nil {
+		return fmt.Errorf("error path: %s, error: %w", op, err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.cfg.URL, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("error path: %s, error: %w", op, err)
+	}
+	timestamp := n.now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(HeaderSignature, webhook.Sign(n.cfg.Secret, timestamp, payload))
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("error path: %s, error: %w", op, err)
+	}
+	defer resp.Body.Close()
+
+	// Тело ответа дочитываем, чтобы соединение вернулось в пул
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("error path: %s, error: unexpected status %d", op, resp.StatusCode)
+	}
+
+	return nil
+}
@@ -0,0 +1,48 @@
+package notify
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"service-chat/internal/config"
+	"service-chat/internal/webhook"
+)
+
+func TestPushNotifier_Notify(t *testing.T) {
+	var got *http.Request
+	var body []byte
+	status := http.StatusOK
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(status)
+	}))
+	defer srv.Close()
+
+	notifier := NewPushNotifier(config.Push{URL: srv.URL, Secret: "pushsec", Timeout: time.Second})
+	notifier.now = func() time.Time { return time.Unix(1700000000, 0) }
+	assert.Equal(t, ChannelPush, notifier.Channel())
+
+	digest := Digest{
+		UserID:  2,
+		Address: "device-token",
+		Items:   []Item{{ChatID: 1, Chat: "team", MessageID: 7, Author: "alice", Text: "hi @bob", Mention: true, CreatedAt: "2024-01-01T00:00:00Z"}},
+	}
+
+	// Запрос подписан ключом провайдера
+	assert.NoError(t, notifier.Notify(context.Background(), digest))
+	assert.Equal(t, `{"user_id":2,"token":"device-token","title":"alice mentioned you in team","body":"[team] alice: hi @bob",`+
+		`"items":[{"chat_id":1,"chat":"team","message_id":7,"author":"alice","text":"hi @bob","mention":true,"created_at":"2024-01-01T00:00:00Z"}]}`, string(body))
+	assert.Equal(t, "1700000000", got.Header.Get(HeaderTimestamp))
+	assert.Equal(t, webhook.Sign("pushsec", 1700000000, body), got.Header.Get(HeaderSignature))
+
+	// Ответ не из 2xx - уведомление нужно отправить повторно
+	status = http.StatusServiceUnavailable
+	assert.ErrorContains(t, notifier.Notify(context.Background(), digest), "unexpected status 503")
+}
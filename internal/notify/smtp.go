@@ -0,0 +1,116 @@
+package notify
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strings"
+	"time"
+
+	"service-chat/internal/config"
+)
+
+// headerUnsafe - переводы строк в заголовке письма позволили бы добавить свои заголовки
+var headerUnsafe = strings.NewReplacer("\r", " ", "\n", " ")
+
+// SMTPNotifier - уведомления письмом через SMTP сервер. Если сервер поддерживает STARTTLS, соединение шифруется,
+// авторизация выполняется только по зашифрованному соединению или на локальном сервере
+type SMTPNotifier struct {
+	cfg config.Email
+	// tlsConfig - настройки STARTTLS, в тестах подменяется
+	tlsConfig *tls.Config
+}
+
+// NewSMTPNotifier - конструктор уведомлений по почте
+func NewSMTPNotifier(cfg config.Email) *SMTPNotifier {
+	return &SMTPNotifier{cfg: cfg, tlsConfig: &tls.Config{ServerName: cfg.Host}}
+}
+
+// Channel - письма отправляются на почту из настроек пользователя
+func (n *SMTPNotifier) Channel() string {
+	return ChannelEmail
+}
+
+// Notify - отправляем письмо со сводкой сообщений
+func (n *SMTPNotifier) Notify(ctx context.Context, d Digest) error {
+	const op = "notify.SMTPNotifier.Notify"
+
+	if len(d.Items) == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, n.cfg.Timeout)
+	defer cancel()
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(n.cfg.Host, n.cfg.Port))
+	if err != nil {
+		return fmt.Errorf("error path: %s, error: %w", op, err)
+	}
+	defer conn.Close()
+
+	// Таймаут контекста распространяем на весь разговор с сервером
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	client, err := smtp.NewClient(conn, n.cfg.Host)
+	if err != nil {
+		return fmt.Errorf("error path: %s, error: %w", op, err)
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err = client.StartTLS(n.tlsConfig); err != nil {
+			return fmt.Errorf("error path: %s, error: %w", op, err)
+		}
+	}
+	if n.cfg.Username != "" {
+		// PlainAuth сам отказывается передавать пароль по незашифрованному соединению на удалённый сервер
+		if err = client.Auth(smtp.PlainAuth("", n.cfg.Username, n.cfg.Password, n.cfg.Host)); err != nil {
+			return fmt.Errorf("error path: %s, error: %w", op, err)
+		}
+	}
+
+	if err = client.Mail(n.cfg.From); err != nil {
+		return fmt.Errorf("error path: %s, error: %w", op, err)
+	}
+	if err = client.Rcpt(d.Address); err != nil {
+		return fmt.Errorf("error path: %s, error: %w", op, err)
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("error path: %s, error: %w", op, err)
+	}
+	if _, err = w.Write(n.message(d)); err != nil {
+		return fmt.Errorf("error path: %s, error: %w", op, err)
+	}
+	if err = w.Close(); err != nil {
+		return fmt.Errorf("error path: %s, error: %w", op, err)
+	}
+
+	return client.Quit()
+}
+
+// message - письмо в текстовом виде, тема кодируется для не ASCII символов
+func (n *SMTPNotifier) message(d Digest) []byte {
+	var b strings.Builder
+	b.WriteString("From: " + headerUnsafe.Replace(n.cfg.From) + "\r\n")
+	b.WriteString("To: " + headerUnsafe.Replace(d.Address) + "\r\n")
+	b.WriteString("Subject: " + mime.QEncoding.Encode("utf-8", headerUnsafe.Replace(d.Title())) + "\r\n")
+	b.WriteString("Date: " + time.Now().Format(time.RFC1123Z) + "\r\n")
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	b.WriteString("Content-Transfer-Encoding: 8bit\r\n")
+	b.WriteString("\r\n")
+
+	// Тело письма: строки с CRLF, как требует SMTP
+	body := strings.ReplaceAll(strings.ReplaceAll(d.Body(), "\r\n", "\n"), "\n", "\r\n")
+	b.WriteString(body + "\r\n")
+
+	return []byte(b.String())
+}
@@ -0,0 +1,187 @@
+package notify
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"service-chat/internal/config"
+)
+
+// fakeSMTP - локальный SMTP сервер, который принимает письма и запоминает их
+type fakeSMTP struct {
+	ln net.Listener
+	// reject - код ответа на RCPT, если не пустой
+	reject string
+
+	mu   sync.Mutex
+	auth string
+	from string
+	to   []string
+	data string
+}
+
+func newFakeSMTP(t *testing.T) *fakeSMTP {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	s := &fakeSMTP{ln: ln}
+	go s.serve()
+	t.Cleanup(func() { _ = ln.Close() })
+	return s
+}
+
+func (s *fakeSMTP) port() string {
+	return s.ln.Addr().(*net.TCPAddr).AddrPort().String()[len("127.0.0.1:"):]
+}
+
+func (s *fakeSMTP) serve() {
+	for {
+		conn, err := s.ln.Accept()
+		if err != nil {
+			return
+		}
+		go s.handle(conn)
+	}
+}
+
+func (s *fakeSMTP) handle(conn net.Conn) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	reply := func(line string) { _, _ = conn.Write([]byte(line + "\r\n")) }
+
+	reply("220 localhost ESMTP fake")
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		line = strings.TrimRight(line, "\r\n")
+		cmd := strings.ToUpper(line)
+
+		switch {
+		case strings.HasPrefix(cmd, "EHLO"):
+			reply("250-localhost")
+			reply("250-8BITMIME")
+			reply("250 AUTH PLAIN")
+		case strings.HasPrefix(cmd, "AUTH PLAIN"):
+			s.mu.Lock()
+			s.auth = strings.TrimSpace(line[len("AUTH PLAIN"):])
+			s.mu.Unlock()
+			reply("235 Authentication successful")
+		case strings.HasPrefix(cmd, "MAIL FROM:"):
+			s.mu.Lock()
+			s.from = line[len("MAIL FROM:"):]
+			s.mu.Unlock()
+			reply("250 OK")
+		case strings.HasPrefix(cmd, "RCPT TO:"):
+			if s.reject != "" {
+				reply(s.reject)
+				continue
+			}
+			s.mu.Lock()
+			s.to = append(s.to, line[len("RCPT TO:"):])
+			s.mu.Unlock()
+			reply("250 OK")
+		case cmd == "DATA":
+			reply("354 End data with <CR><LF>.<CR><LF>")
+			var data strings.Builder
+			for {
+				dataLine, errData := r.ReadString('\n')
+				if errData != nil {
+					return
+				}
+				if dataLine == ".\r\n" {
+					break
+				}
+				data.WriteString(dataLine)
+			}
+			s.mu.Lock()
+			s.data = data.String()
+			s.mu.Unlock()
+			reply("250 OK queued")
+		case cmd == "QUIT":
+			reply("221 Bye")
+			return
+		default:
+			reply("250 OK")
+		}
+	}
+}
+
+func TestSMTPNotifier_Notify(t *testing.T) {
+	srv := newFakeSMTP(t)
+	notifier := NewSMTPNotifier(config.Email{
+		Host:     "127.0.0.1",
+		Port:     srv.port(),
+		Username: "chat",
+		Password: "secret",
+		From:     "chat@example.com",
+		Timeout:  time.Second,
+	})
+	assert.Equal(t, ChannelEmail, notifier.Channel())
+
+	digest := Digest{
+		UserID:  2,
+		Address: "bob@example.com",
+		Items: []Item{
+			{ChatID: 1, Chat: "team", MessageID: 7, Author: "alice", Text: "Привет, @bob", Mention: true},
+			{ChatID: 1, Chat: "team", MessageID: 8, Author: "alice", Text: "."},
+		},
+		More: 3,
+	}
+	require.NoError(t, notifier.Notify(context.Background(), digest))
+
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+
+	// Авторизация по локальному соединению разрешена, пароль передан
+	auth, err := base64.StdEncoding.DecodeString(srv.auth)
+	require.NoError(t, err)
+	assert.Equal(t, "\x00chat\x00secret", string(auth))
+	assert.True(t, strings.HasPrefix(srv.from, "<chat@example.com>"), srv.from)
+	assert.Equal(t, []string{"<bob@example.com>"}, srv.to)
+
+	// Письмо содержит заголовки и строки сводки, строки с точкой в начале экранируются второй точкой
+	assert.Contains(t, srv.data, "To: bob@example.com\r\n")
+	assert.Contains(t, srv.data, "Subject: 5 new messages\r\n")
+	assert.Contains(t, srv.data, "Content-Type: text/plain; charset=utf-8\r\n")
+	assert.Contains(t, srv.data, "[team] alice: Привет, @bob\r\n[team] alice: .\r\n....and 3 more\r\n")
+}
+
+func TestSMTPNotifier_Errors(t *testing.T) {
+	srv := newFakeSMTP(t)
+	srv.reject = "550 No such user"
+	notifier := NewSMTPNotifier(config.Email{Host: "127.0.0.1", Port: srv.port(), From: "chat@example.com", Timeout: time.Second})
+	digest := Digest{UserID: 2, Address: "nobody@example.com", Items: []Item{{Chat: "team", Author: "alice", Text: "hi"}}}
+
+	// Сервер отказался принять получателя - письмо нужно отправить повторно
+	err := notifier.Notify(context.Background(), digest)
+	assert.ErrorContains(t, err, "550")
+
+	// Сервер недоступен
+	_ = srv.ln.Close()
+	err = notifier.Notify(context.Background(), digest)
+	assert.Error(t, err)
+
+	// Пустая сводка не отправляется
+	assert.NoError(t, notifier.Notify(context.Background(), Digest{Address: "bob@example.com"}))
+}
+
+func TestDigest_Title(t *testing.T) {
+	item := Item{Chat: "team", Author: "alice", Text: "hi"}
+	assert.Equal(t, "New message from alice in team", Digest{Items: []Item{item}}.Title())
+
+	item.Mention = true
+	assert.Equal(t, "alice mentioned you in team", Digest{Items: []Item{item}}.Title())
+	assert.Equal(t, "2 new messages", Digest{Items: []Item{item, item}}.Title())
+	assert.Equal(t, "4 new messages", Digest{Items: []Item{item}, More: 3}.Title())
+}
@@ -0,0 +1,211 @@
+package preview
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/net/html"
+
+	"service-chat/internal/config"
+	"service-chat/internal/db/entity"
+	"service-chat/internal/safehttp"
+)
+
+const (
+	// maxRedirects - сколько редиректов проходим до страницы
+	maxRedirects = 3
+	// maxTitleRunes, maxDescriptionRunes - ограничения длины полей превью
+	maxTitleRunes       = 300
+	maxDescriptionRunes = 1000
+	// maxURLLength - ограничение длины адресов страницы и картинки
+	maxURLLength = 2048
+	userAgent    = "service-chat-preview/1.0"
+)
+
+// HTTPFetcher - загрузка превью по HTTP. Защита от SSRF: разрешены только http и https,
+// а соединения только с публичными адресами, в том числе после редиректов
+type HTTPFetcher struct {
+	client      *http.Client
+	maxBodySize int64
+}
+
+// NewHTTPFetcher - конструктор загрузчика превью
+func NewHTTPFetcher(cfg config.Previews) *HTTPFetcher {
+	return newHTTPFetcher(cfg, false)
+}
+
+// newHTTPFetcher - allowPrivate разрешает адреса внутренней сети, нужен только для тестов на локальном сервере
+func newHTTPFetcher(cfg config.Previews, allowPrivate bool) *HTTPFetcher {
+	return &HTTPFetcher{
+		client: safehttp.NewClient(cfg.Timeout, allowPrivate, func(req *http.Request, via []*http.Request) error {
+			if len(via) >= maxRedirects {
+				return errors.New("too many redirects")
+			}
+			if !allowedScheme(req.URL) {
+				return ErrForbiddenAddress
+			}
+			return nil
+		}),
+		maxBodySize: cfg.MaxBodySize,
+	}
+}
+
+// Fetch - загружаем страницу и достаём из неё данные Open Graph, читаем не больше maxBodySize байт
+func (f *HTTPFetcher) Fetch(ctx context.Context, rawURL string) (*entity.LinkPreview, error) {
+	const op = "preview.HTTPFetcher.Fetch"
+
+	u, err := url.Parse(rawURL)
+	if err != nil || len(rawURL) > maxURLLength || !allowedScheme(u) || u.User != nil {
+		return nil, fmt.Errorf("error path: %s, error: %w", op, ErrForbiddenAddress)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("error path: %s, error: %w", op, err)
+	}
+	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set("Accept", "text/html,application/xhtml+xml")
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error path: %s, error: %w", op, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("error path: %s, error: unexpected status %d", op, resp.StatusCode)
+	}
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if mediaType != "text/html" && mediaType != "application/xhtml+xml" {
+		return nil, fmt.Errorf("error path: %s, error: %w", op, ErrNotHTML)
+	}
+
+	preview := parseHTML(io.LimitReader(resp.Body, f.maxBodySize), resp.Request.URL)
+	preview.URL = rawURL
+	return preview, nil
+}
+
+// parseHTML - достаём метатеги Open Graph из head страницы, если их нет - title и description.
+// Относительный адрес картинки разрешаем относительно адреса страницы после редиректов
+func parseHTML(r io.Reader, base *url.URL) *entity.LinkPreview {
+	var preview entity.LinkPreview
+	var title, description string
+
+	z := html.NewTokenizer(r)
+	for inTitle := false; ; {
+		tt := z.Next()
+		switch tt {
+		case html.ErrorToken:
+			return finishPreview(&preview, base, title, description)
+		case html.TextToken:
+			if inTitle && title == "" {
+				title = strings.TrimSpace(string(z.Text()))
+			}
+		case html.EndTagToken:
+			name, _ := z.TagName()
+			switch string(name) {
+			case "title":
+				inTitle = false
+			case "head":
+				return finishPreview(&preview, base, title, description)
+			}
+		case html.StartTagToken, html.SelfClosingTagToken:
+			name, hasAttr := z.TagName()
+			switch string(name) {
+			case "body":
+				return finishPreview(&preview, base, title, description)
+			case "title":
+				inTitle = tt == html.StartTagToken
+			case "meta":
+				if !hasAttr {
+					continue
+				}
+				key, content := metaAttrs(z)
+				switch key {
+				case "og:title":
+					preview.Title = content
+				case "og:description":
+					preview.Description = content
+				case "og:image":
+					preview.ImageURL = content
+				case "og:site_name":
+					preview.SiteName = content
+				case "description":
+					description = content
+				}
+			}
+		}
+	}
+}
+
+// metaAttrs - ключ (property или name) и содержимое метатега
+func metaAttrs(z *html.Tokenizer) (string, string) {
+	var key, content string
+	for {
+		name, val, more := z.TagAttr()
+		switch string(name) {
+		case "property", "name":
+			if key == "" {
+				key = strings.ToLower(strings.TrimSpace(string(val)))
+			}
+		case "content":
+			content = strings.TrimSpace(string(val))
+		}
+		if !more {
+			return key, content
+		}
+	}
+}
+
+// finishPreview - подставляем запасные значения и обрезаем поля
+func finishPreview(preview *entity.LinkPreview, base *url.URL, title, description string) *entity.LinkPreview {
+	if preview.Title == "" {
+		preview.Title = title
+	}
+	if preview.Description == "" {
+		preview.Description = description
+	}
+	preview.Title = truncate(preview.Title, maxTitleRunes)
+	preview.Description = truncate(preview.Description, maxDescriptionRunes)
+	preview.SiteName = truncate(preview.SiteName, maxTitleRunes)
+	preview.ImageURL = resolveImage(base, preview.ImageURL)
+	return preview
+}
+
+// resolveImage - адрес картинки должен быть http или https, иначе не отдаём его клиенту
+func resolveImage(base *url.URL, raw string) string {
+	if raw == "" || len(raw) > maxURLLength {
+		return ""
+	}
+	ref, err := url.Parse(raw)
+	if err != nil {
+		return ""
+	}
+	if base != nil {
+		ref = base.ResolveReference(ref)
+	}
+	if !allowedScheme(ref) {
+		return ""
+	}
+	return ref.String()
+}
+
+// truncate - обрезаем строку до n символов
+func truncate(s string, n int) string {
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	return string([]rune(s)[:n])
+}
+
+// allowedScheme - загружаем только http и https адреса с хостом
+func allowedScheme(u *url.URL) bool {
+	return (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
@@ -0,0 +1,22 @@
+package preview
+
+import (
+	"context"
+	"errors"
+
+	"service-chat/internal/db/entity"
+	"service-chat/internal/safehttp"
+)
+
+var (
+	// ErrForbiddenAddress - адрес ведёт во внутреннюю сеть или на недопустимую схему
+	ErrForbiddenAddress = safehttp.ErrForbiddenAddress
+	// ErrNotHTML - по адресу не HTML страница
+	ErrNotHTML = errors.New("not an html page")
+)
+
+// PreviewFetcher - интерфейс загрузки превью ссылки
+type PreviewFetcher interface {
+	// Fetch - загружаем страницу по адресу и достаём из неё данные Open Graph
+	Fetch(ctx context.Context, rawURL string) (*entity.LinkPreview, error)
+}
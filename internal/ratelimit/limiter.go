@@ -0,0 +1,137 @@
+package ratelimit
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"service-chat/internal/config"
+)
+
+const (
+	storeMemory   = "memory"
+	storePostgres = "postgres"
+)
+
+// Группы маршрутов с отдельными лимитами
+const (
+	GroupAuth     = "auth"
+	GroupAPI      = "api"
+	GroupMessages = "messages"
+)
+
+// sweepEvery - через сколько запросов хранилище удаляет полные корзины
+const sweepEvery = 1024
+
+// Limit - корзина токенов: Burst запросов подряд, дальше Rate запросов в секунду
+type Limit struct {
+	Rate  float64
+	Burst int
+}
+
+// Result - решение по запросу
+type Result struct {
+	Allowed bool
+	// Remaining - сколько запросов можно сделать сразу после этого
+	Remaining int
+	// RetryAfter - через сколько появится токен для следующего запроса, если запрос не разрешён
+	RetryAfter time.Duration
+}
+
+// Store - хранилище корзин токенов
+type Store interface {
+	// Take - забираем токен из корзины key, если он есть
+	Take(ctx context.Context, key string, limit Limit, now time.Time) (Result, error)
+}
+
+// Limiter - лимиты запросов по группам маршрутов
+type Limiter struct {
+	store Store
+	rules map[string]Limit
+	// TrustProxy - IP клиента берётся из заголовков прокси
+	TrustProxy bool
+	// now - текущее время, подменяется в тестах
+	now func() time.Time
+}
+
+// NewLimiter - лимиты групп маршрутов в хранилище store, группы без лимита не ограничиваются
+func NewLimiter(store Store, rules map[string]Limit) *Limiter {
+	return &Limiter{store: store, rules: rules, now: time.Now}
+}
+
+// NewLimiterFromConfig - создаём лимиты согласно конфигу, если ограничение выключено - возвращаем nil
+func NewLimiterFromConfig(cfg config.RateLimit, database *sql.DB) (*Limiter, error) {
+	if cfg.Disabled {
+		return nil, nil
+	}
+
+	var store Store
+	switch cfg.Store {
+	case storeMemory:
+		store = NewMemoryStore()
+	case storePostgres:
+		store = NewPostgresStore(database)
+	default:
+		return nil, errors.New("unknown rate limit store: " + cfg.Store)
+	}
+
+	rules := make(map[string]Limit)
+	for group, rule := range map[string]config.RateRule{
+		GroupAuth:     cfg.Auth,
+		GroupAPI:      cfg.API,
+		GroupMessages: cfg.Messages,
+	} {
+		if rule.Requests <= 0 {
+			continue
+		}
+		if rule.Period <= 0 {
+			return nil, errors.New("rate limit period must be positive for group " + group)
+		}
+		limit := Limit{Rate: float64(rule.Requests) / rule.Period.Seconds(), Burst: rule.Burst}
+		if limit.Burst <= 0 {
+			limit.Burst = rule.Requests
+		}
+		rules[group] = limit
+	}
+
+	limiter := NewLimiter(store, rules)
+	limiter.TrustProxy = cfg.TrustProxy
+	return limiter, nil
+}
+
+// Allow - забираем токен группы для ключа клиента. Группа без лимита запрос не ограничивает
+func (l *Limiter) Allow(ctx context.Context, group, key string) (Result, error) {
+	limit, ok := l.rules[group]
+	if !ok {
+		return Result{Allowed: true}, nil
+	}
+
+	return l.store.Take(ctx, group+":"+key, limit, l.now())
+}
+
+// take - пополняем корзину за прошедшее время и забираем токен. Возвращаем остаток токенов,
+// время, когда корзина снова станет полной, и решение по запросу
+func take(tokens float64, updatedAt, now time.Time, limit Limit) (float64, time.Time, Result) {
+	elapsed := now.Sub(updatedAt).Seconds()
+	if elapsed < 0 {
+		elapsed = 0
+	}
+	tokens = min(float64(limit.Burst), tokens+elapsed*limit.Rate)
+
+	var res Result
+	if tokens >= 1 {
+		tokens--
+		res = Result{Allowed: true, Remaining: int(tokens)}
+	} else {
+		res = Result{RetryAfter: seconds((1 - tokens) / limit.Rate)}
+	}
+
+	fullAt := now.Add(seconds((float64(limit.Burst) - tokens) / limit.Rate))
+	return tokens, fullAt, res
+}
+
+// seconds - переводим секунды в time.Duration
+func seconds(s float64) time.Duration {
+	return time.Duration(s * float64(time.Second))
+}
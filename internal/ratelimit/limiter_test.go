@@ -0,0 +1,92 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"service-chat/internal/config"
+)
+
+func TestLimiter_Allow(t *testing.T) {
+	// 2 запроса подряд, дальше 1 запрос в секунду
+	limiter := NewLimiter(NewMemoryStore(), map[string]Limit{GroupMessages: {Rate: 1, Burst: 2}})
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	limiter.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	res, err := limiter.Allow(ctx, GroupMessages, "user:1")
+	assert.NoError(t, err)
+	assert.Equal(t, Result{Allowed: true, Remaining: 1}, res)
+
+	res, _ = limiter.Allow(ctx, GroupMessages, "user:1")
+	assert.Equal(t, Result{Allowed: true}, res)
+
+	// Корзина пуста, токен появится через секунду
+	res, _ = limiter.Allow(ctx, GroupMessages, "user:1")
+	assert.Equal(t, Result{RetryAfter: time.Second}, res)
+
+	// У другого пользователя своя корзина
+	res, _ = limiter.Allow(ctx, GroupMessages, "user:2")
+	assert.True(t, res.Allowed)
+
+	// Через 500ms токена ещё нет
+	now = now.Add(500 * time.Millisecond)
+	res, _ = limiter.Allow(ctx, GroupMessages, "user:1")
+	assert.Equal(t, Result{RetryAfter: 500 * time.Millisecond}, res)
+
+	now = now.Add(500 * time.Millisecond)
+	res, _ = limiter.Allow(ctx, GroupMessages, "user:1")
+	assert.Equal(t, Result{Allowed: true}, res)
+
+	// Корзина не переполняется больше burst
+	now = now.Add(time.Hour)
+	res, _ = limiter.Allow(ctx, GroupMessages, "user:1")
+	assert.Equal(t, Result{Allowed: true, Remaining: 1}, res)
+
+	// Группа без лимита не ограничивается
+	res, err = limiter.Allow(ctx, GroupAuth, "ip:127.0.0.1")
+	assert.NoError(t, err)
+	assert.True(t, res.Allowed)
+}
+
+func TestMemoryStore_Sweep(t *testing.T) {
+	store := NewMemoryStore()
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	limit := Limit{Rate: 1, Burst: 1}
+
+	_, _ = store.Take(context.Background(), "a", limit, now)
+	_, _ = store.Take(context.Background(), "b", limit, now.Add(time.Minute))
+
+	// Корзина a полная через секунду после запроса, b - ещё нет
+	store.sweep(now.Add(time.Minute))
+	assert.Len(t, store.buckets, 1)
+	assert.Contains(t, store.buckets, "b")
+}
+
+func TestNewLimiterFromConfig(t *testing.T) {
+	limiter, err := NewLimiterFromConfig(config.RateLimit{
+		Store:      "memory",
+		TrustProxy: true,
+		Auth:       config.RateRule{Requests: 10, Period: time.Minute, Burst: 5},
+		Messages:   config.RateRule{Requests: 30, Period: 10 * time.Second},
+	}, nil)
+	assert.NoError(t, err)
+	assert.True(t, limiter.TrustProxy)
+	assert.Equal(t, map[string]Limit{
+		GroupAuth:     {Rate: 10.0 / 60, Burst: 5},
+		GroupMessages: {Rate: 3, Burst: 30},
+	}, limiter.rules)
+
+	limiter, err = NewLimiterFromConfig(config.RateLimit{Disabled: true}, nil)
+	assert.NoError(t, err)
+	assert.Nil(t, limiter)
+
+	_, err = NewLimiterFromConfig(config.RateLimit{Store: "redis"}, nil)
+	assert.EqualError(t, err, "unknown rate limit store: redis")
+
+	_, err = NewLimiterFromConfig(config.RateLimit{Store: "memory", API: config.RateRule{Requests: 10}}, nil)
+	assert.EqualError(t, err, "rate limit period must be positive for group api")
+}
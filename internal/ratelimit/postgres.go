@@ -0,0 +1,84 @@
+package ratelimit
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	opTake  = "ratelimit.Take"
+	opSweep = "ratelimit.sweep"
+)
+
+// PostgresStore - корзины в таблице rate_limit, лимиты общие для всех экземпляров сервиса
+type PostgresStore struct {
+	db    *sql.DB
+	calls atomic.Int64
+}
+
+func NewPostgresStore(db *sql.DB) *PostgresStore {
+	return &PostgresStore{db: db}
+}
+
+// Take - забираем токен из корзины key. Строка корзины блокируется до конца транзакции,
+// поэтому одновременные запросы разных экземпляров не заберут один токен дважды
+func (s *PostgresStore) Take(ctx context.Context, key string, limit Limit, now time.Time) (Result, error) {
+	now = now.UTC()
+	if s.calls.Add(1)%sweepEvery == 0 {
+		if err := s.sweep(ctx, now); err != nil {
+			return Result{}, err
+		}
+	}
+
+	// Начинаем транзакцию
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return Result{}, fmt.Errorf("error path: %s, error: %w", opTake, err)
+	}
+
+	res, errTake := takeTx(ctx, tx, key, limit, now)
+	if errTake != nil {
+		if errTx := tx.Rollback(); errTx != nil {
+			return Result{}, fmt.Errorf("error path: %s, error: %s", opTake, errTx)
+		}
+		return Result{}, errTake
+	}
+
+	return res, tx.Commit()
+}
+
+// takeTx - забираем токен внутри транзакции, откат транзакции при ошибке остаётся на вызывающей стороне
+func takeTx(ctx context.Context, tx *sql.Tx, key string, limit Limit, now time.Time) (Result, error) {
+	// Новая корзина полная
+	if _, err := tx.ExecContext(ctx, `INSERT INTO "rate_limit" (key, tokens, updated_at, full_at)
+										VALUES ($1, $2, $3, $3)
+										ON CONFLICT (key) DO NOTHING`, key, float64(limit.Burst), now); err != nil {
+		return Result{}, fmt.Errorf("error path: %s, error: %w", opTake, err)
+	}
+
+	var tokens float64
+	var updatedAt time.Time
+	if err := tx.QueryRowContext(ctx, `SELECT tokens, updated_at FROM "rate_limit" WHERE key = $1 FOR UPDATE`, key).
+		Scan(&tokens, &updatedAt); err != nil {
+		return Result{}, fmt.Errorf("error path: %s, error: %w", opTake, err)
+	}
+
+	tokens, fullAt, res := take(tokens, updatedAt, now, limit)
+	if _, err := tx.ExecContext(ctx, `UPDATE "rate_limit" SET tokens = $2, updated_at = $3, full_at = $4 WHERE key = $1`,
+		key, tokens, now, fullAt.UTC()); err != nil {
+		return Result{}, fmt.Errorf("error path: %s, error: %w", opTake, err)
+	}
+
+	return res, nil
+}
+
+// sweep - удаляем полные корзины, новая корзина для того же ключа будет такой же
+func (s *PostgresStore) sweep(ctx context.Context, now time.Time) error {
+	if _, err := s.db.ExecContext(ctx, `DELETE FROM "rate_limit" WHERE full_at <= $1`, now); err != nil {
+		return fmt.Errorf("error path: %s, error: %w", opSweep, err)
+	}
+	return nil
+}
@@ -0,0 +1,135 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	sqlInsert = `INSERT INTO "rate_limit" (key, tokens, updated_at, full_at)
+										VALUES ($1, $2, $3, $3)
+										ON CONFLICT (key) DO NOTHING`
+	sqlSelect = `SELECT tokens, updated_at FROM "rate_limit" WHERE key = $1 FOR UPDATE`
+	sqlUpdate = `UPDATE "rate_limit" SET tokens = $2, updated_at = $3, full_at = $4 WHERE key = $1`
+	sqlSweep  = `DELETE FROM "rate_limit" WHERE full_at <= $1`
+)
+
+func TestPostgresStore_Take(t *testing.T) {
+	// 2 запроса подряд, дальше 1 запрос в секунду
+	limit := Limit{Rate: 1, Burst: 2}
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+
+	// Функция для определения поведения мока базы данных
+	type mockBehavior func(mock sqlmock.Sqlmock)
+
+	tests := []struct {
+		name    string
+		mock    mockBehavior
+		want    Result
+		wantErr string
+	}{
+		{
+			name: "New bucket",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(sqlInsert).WithArgs("user:1", float64(2), now).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery(sqlSelect).WithArgs("user:1").
+					WillReturnRows(sqlmock.NewRows([]string{"tokens", "updated_at"}).AddRow(float64(2), now))
+				mock.ExpectExec(sqlUpdate).WithArgs("user:1", float64(1), now, now.Add(time.Second)).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			want: Result{Allowed: true, Remaining: 1},
+		},
+		{
+			name: "Refill",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(sqlInsert).WithArgs("user:1", float64(2), now).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery(sqlSelect).WithArgs("user:1").
+					WillReturnRows(sqlmock.NewRows([]string{"tokens", "updated_at"}).AddRow(float64(0), now.Add(-1500*time.Millisecond)))
+				mock.ExpectExec(sqlUpdate).WithArgs("user:1", 0.5, now, now.Add(1500*time.Millisecond)).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			want: Result{Allowed: true},
+		},
+		{
+			name: "Bucket exhausted",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(sqlInsert).WithArgs("user:1", float64(2), now).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery(sqlSelect).WithArgs("user:1").
+					WillReturnRows(sqlmock.NewRows([]string{"tokens", "updated_at"}).AddRow(0.25, now))
+				mock.ExpectExec(sqlUpdate).WithArgs("user:1", 0.25, now, now.Add(1750*time.Millisecond)).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			want: Result{RetryAfter: 750 * time.Millisecond},
+		},
+		{
+			name: "Select error",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(sqlInsert).WithArgs("user:1", float64(2), now).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectQuery(sqlSelect).WithArgs("user:1").WillReturnError(errors.New("connection reset"))
+				mock.ExpectRollback()
+			},
+			wantErr: "error path: ratelimit.Take, error: connection reset",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Создаём мок объекта базы данных
+			db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			if err != nil {
+				t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+			}
+			defer func() { _ = db.Close() }()
+
+			tt.mock(mock)
+
+			got, err := NewPostgresStore(db).Take(context.Background(), "user:1", limit, now)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestPostgresStore_Sweep(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("an error '%s' was not expected when opening a stub database connection", err)
+	}
+	defer func() { _ = db.Close() }()
+
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	store := NewPostgresStore(db)
+	store.calls.Store(sweepEvery - 1)
+
+	// Каждый sweepEvery запрос сначала удаляет полные корзины
+	mock.ExpectExec(sqlSweep).WithArgs(now).WillReturnResult(sqlmock.NewResult(0, 3))
+	mock.ExpectBegin()
+	mock.ExpectExec(sqlInsert).WithArgs("ip:10.0.0.1", float64(1), now).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(sqlSelect).WithArgs("ip:10.0.0.1").
+		WillReturnRows(sqlmock.NewRows([]string{"tokens", "updated_at"}).AddRow(float64(1), now))
+	mock.ExpectExec(sqlUpdate).WithArgs("ip:10.0.0.1", float64(0), now, now.Add(time.Second)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	res, err := store.Take(context.Background(), "ip:10.0.0.1", Limit{Rate: 1, Burst: 1}, now)
+	assert.NoError(t, err)
+	assert.Equal(t, Result{Allowed: true}, res)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
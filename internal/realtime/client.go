@@ -0,0 +1,126 @@
+package realtime
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+
+	"golang.org/x/net/websocket"
+
+	"service-chat/internal/config"
+)
+
+// pingPayload - сообщение проверки соединения
+var pingPayload, _ = json.Marshal(Event{Type: EventPing})
+
+// Client - подключение пользователя. События копятся в буфере send, писатель отправляет их в соединение.
+// Поле unregistered защищено мьютексом хаба
+type Client struct {
+	userID int64
+	cfg    config.Realtime
+	send   chan Delivery
+	done   chan struct{}
+	once   sync.Once
+
+	unregistered bool
+}
+
+// newClient - конструктор подключения
+func newClient(userID int64, cfg config.Realtime) *Client {
+	size := cfg.SendBuffer
+	if size <= 0 {
+		size = 1
+	}
+
+	return &Client{
+		userID: userID,
+		cfg:    cfg,
+		send:   make(chan Delivery, size),
+		done:   make(chan struct{}),
+	}
+}
+
+// UserID - пользователь подключения
+func (c *Client) UserID() int64 {
+	return c.userID
+}
+
+// Events - события, ожидающие отправки клиенту
+func (c *Client) Events() <-chan Delivery {
+	return c.send
+}
+
+// Done - закрывается, когда подключение нужно закрыть: клиент не успевает читать события или сервер останавливается
+func (c *Client) Done() <-chan struct{} {
+	return c.done
+}
+
+// deliver - кладём событие в буфер, не дожидаясь клиента. Если буфер переполнен, клиент отключается,
+// чтобы медленное соединение не задерживало доставку остальным
+func (c *Client) deliver(d Delivery) {
+	select {
+	case <-c.done:
+	case c.send <- d:
+	default:
+		c.close()
+	}
+}
+
+// close - сигнал писателю закрыть соединение
+func (c *Client) close() {
+	c.once.Do(func() { close(c.done) })
+}
+
+// Serve - обслуживаем соединение: отправляем события и проверки соединения, читаем ответы клиента.
+// Если клиент ничего не присылает дольше PongTimeout, соединение закрывается. Возвращается после закрытия
+func (c *Client) Serve(ws *websocket.Conn) {
+	readDone := make(chan struct{})
+	go func() {
+		defer close(readDone)
+		c.readLoop(ws)
+	}()
+
+	c.writeLoop(ws)
+	_ = ws.Close()
+	<-readDone
+}
+
+// readLoop - любое сообщение от клиента продлевает соединение, содержимое не используется
+func (c *Client) readLoop(ws *websocket.Conn) {
+	defer c.close()
+
+	for {
+		if err := ws.SetReadDeadline(time.Now().Add(c.cfg.PongTimeout)); err != nil {
+			return
+		}
+		var msg []byte
+		if err := websocket.Message.Receive(ws, &msg); err != nil {
+			return
+		}
+	}
+}
+
+// writeLoop - отправляем события из буфера и периодические проверки соединения
+func (c *Client) writeLoop(ws *websocket.Conn) {
+	ticker := time.NewTicker(c.cfg.PingInterval)
+	defer ticker.Stop()
+
+	for {
+		var payload []byte
+		select {
+		case <-c.done:
+			return
+		case d := <-c.send:
+			payload = d.Payload
+		case <-ticker.C:
+			payload = pingPayload
+		}
+
+		if err := ws.SetWriteDeadline(time.Now().Add(c.cfg.WriteTimeout)); err != nil {
+			return
+		}
+		if err := websocket.Message.Send(ws, string(payload)); err != nil {
+			return
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package realtime
+
+import "time"
+
+// Типы событий, которые получают клиенты
+const (
+	// EventMessageCreated - новое сообщение в чате
+	EventMessageCreated = "message.created"
+	// EventMessageUpdated - сообщение отредактировано
+	EventMessageUpdated = "message.updated"
+	// EventMessageDeleted - сообщение удалено
+	EventMessageDeleted = "message.deleted"
+	// EventChatCreated - пользователи добавлены в новый чат
+	EventChatCreated = "chat.created"
+	// EventChatDeleted - чат удалён, подписка на него прекращается
+	EventChatDeleted = "chat.deleted"
+	// EventPresenceChanged - пользователь появился в сети, отошёл или вышел из сети
+	EventPresenceChanged = "presence.changed"
+	// EventTypingStarted - участник чата печатает сообщение, индикатор пропадает в expires_at, если его не продлили
+	EventTypingStarted = "typing.started"
+	// EventTypingStopped - участник чата перестал печатать
+	EventTypingStopped = "typing.stopped"
+	// EventPollUpdated - изменились голоса в опросе
+	EventPollUpdated = "poll.updated"
+	// EventCommandReply - ответ команды, который получает только вызвавший её пользователь
+	EventCommandReply = "command.reply"
+	// EventPing - проверка соединения, клиент отвечает сообщением {"type":"pong"}
+	EventPing = "ping"
+	// EventResync - клиент пропустил больше событий, чем хранит журнал, и должен заново загрузить чаты
+	EventResync = "resync"
+)
+
+// Event - событие чата, которое доставляется всем подключённым участникам чата
+type Event struct {
+	Type   string `json:"type"`
+	ChatID int64  `json:"chat_id,omitempty"`
+	// ChatIDs - событие пользователя, которое получают участники всех его чатов, по одному разу.
+	// Клиентам не отправляется, чтобы не раскрывать список чатов пользователя
+	ChatIDs []int64 `json:"-"`
+	// UserIDs - пользователи, которые стали участниками чата, их подключения подписываются на чат
+	UserIDs []int64 `json:"user_ids,omitempty"`
+	// ToUserID - событие получает только этот участник чата ChatID, например ответ команды
+	ToUserID int64 `json:"-"`
+	Data     any   `json:"data,omitempty"`
+}
+
+// Publisher - интерфейс публикации событий, вызывается слоем сервиса после успешной записи в бд
+type Publisher interface {
+	Publish(ev Event)
+}
+
+// MessagePayload - данные событий сообщений. У удалённого сообщения заполнен только MessageID,
+// у пересланного - MessageID и UserID, текст клиент получает вместе с историей чата
+type MessagePayload struct {
+	MessageID int64  `json:"message_id"`
+	UserID    int64  `json:"user_id,omitempty"`
+	Text      string `json:"text,omitempty"`
+	Format    string `json:"format,omitempty"`
+	// HTML - отрендеренный markdown, как в ответе на получение сообщений
+	HTML string `json:"html,omitempty"`
+}
+
+// ChatPayload - данные событий чата
+type ChatPayload struct {
+	Name    string `json:"name,omitempty"`
+	AdminID int64  `json:"admin_id,omitempty"`
+}
+
+// PresencePayload - данные события о статусе пользователя
+type PresencePayload struct {
+	UserID int64  `json:"user_id"`
+	Status string `json:"status"`
+	// LastSeen - заполняется, когда пользователь вышел из сети
+	LastSeen *time.Time `json:"last_seen,omitempty"`
+}
+
+// TypingPayload - данные событий набора текста
+type TypingPayload struct {
+	UserID    int64      `json:"user_id"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+// CommandPayload - данные ответа команды
+type CommandPayload struct {
+	Command string `json:"command"`
+	Text    string `json:"text"`
+	Format  string `json:"format,omitempty"`
+	HTML    string `json:"html,omitempty"`
+}
+
+// PollPayload - данные события о голосах в опросе. Отметка о своём голосе и список проголосовавших
+// у каждого пользователя свои, их клиент получает вместе с историей чата
+type PollPayload struct {
+	MessageID   int64               `json:"message_id"`
+	TotalVoters int64               `json:"total_voters"`
+	Options     []PollOptionPayload `json:"options"`
+}
+
+// PollOptionPayload - количество голосов за вариант ответа
+type PollOptionPayload struct {
+	ID    int64 `json:"id"`
+	Votes int64 `json:"votes"`
+}
@@ -0,0 +1,292 @@
+package realtime
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"service-chat/internal/config"
+)
+
+// ErrHubClosed - сервер останавливается и не принимает новые подключения
+var ErrHubClosed = errors.New("realtime hub is closed")
+
+// resyncPayload - событие для клиента, который пропустил больше, чем хранит журнал: ему нужно заново загрузить чаты
+var resyncPayload, _ = json.Marshal(Event{Type: EventResync})
+
+// Delivery - событие с порядковым номером, по номеру клиент продолжает получение после переподключения
+type Delivery struct {
+	ID      uint64
+	Payload []byte
+}
+
+// userState - подписки и журнал событий пользователя. Состояние живёт, пока у пользователя есть подключения,
+// и ещё LogTTL после отключения последнего, чтобы переподключившийся клиент получил пропущенные события
+type userState struct {
+	clients map[*Client]struct{}
+	chats   map[int64]struct{}
+	log     []Delivery
+	// floor - события с номером не больше floor в журнале отсутствуют: они были до появления состояния или вытеснены
+	floor uint64
+	// lastSeen - время отключения последнего подключения
+	lastSeen time.Time
+}
+
+// Hub - подключения пользователей и их подписки на чаты. Событие чата записывается в журнал каждого участника
+// и отправляется в буфер каждого его подключения; если буфер переполнен, клиент не успевает читать и отключается
+type Hub struct {
+	cfg config.Realtime
+	// now - текущее время, подменяется в тестах
+	now func() time.Time
+
+	mu sync.Mutex
+	// chats - участники чата, у которых есть состояние в хабе
+	chats map[int64]map[int64]struct{}
+	users map[int64]*userState
+	// seq - номер последнего события, начинается со времени запуска, чтобы номера не повторялись после перезапуска
+	seq       uint64
+	lastSweep time.Time
+	closed    bool
+	// conns - подключения, которые ещё не сняты с регистрации, ждём их при остановке
+	conns sync.WaitGroup
+}
+
+// NewHub - конструктор хаба
+func NewHub(cfg config.Realtime) *Hub {
+	now := time.Now()
+	return &Hub{
+		cfg:       cfg,
+		now:       time.Now,
+		chats:     make(map[int64]map[int64]struct{}),
+		users:     make(map[int64]*userState),
+		seq:       uint64(now.UnixMicro()),
+		lastSweep: now,
+	}
+}
+
+// Register - регистрируем подключение пользователя и подписываем его на чаты пользователя
+func (h *Hub) Register(userID int64, chatIDs []int64) (*Client, error) {
+	c, _, err := h.Resume(userID, chatIDs, 0)
+	return c, err
+}
+
+// Resume - регистрируем подключение и возвращаем события после lastEventID, которые клиент пропустил.
+// Если журнал их уже не хранит, вместо них возвращается событие resync. Нулевой lastEventID - новое подключение
+func (h *Hub) Resume(userID int64, chatIDs []int64, lastEventID uint64) (*Client, []Delivery, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.closed {
+		return nil, nil, ErrHubClosed
+	}
+	h.sweep()
+
+	st := h.users[userID]
+	if st == nil {
+		st = &userState{
+			clients: make(map[*Client]struct{}),
+			chats:   make(map[int64]struct{}),
+			floor:   h.seq,
+		}
+		h.users[userID] = st
+	}
+
+	// Подписки берём из бд: пока пользователь был отключён, список его чатов мог измениться
+	for chatID := range st.chats {
+		if !slices.Contains(chatIDs, chatID) {
+			h.unsubscribe(userID, st, chatID)
+		}
+	}
+	for _, chatID := range chatIDs {
+		h.subscribe(userID, st, chatID)
+	}
+
+	c := newClient(userID, h.cfg)
+	st.clients[c] = struct{}{}
+	h.conns.Add(1)
+
+	return c, st.since(lastEventID, h.seq), nil
+}
+
+// Unregister - снимаем подключение с регистрации, повторный вызов ничего не делает
+func (h *Hub) Unregister(c *Client) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if c.unregistered {
+		return
+	}
+	c.unregistered = true
+	c.close()
+	h.conns.Done()
+
+	st := h.users[c.userID]
+	if st == nil {
+		return
+	}
+	delete(st.clients, c)
+	if len(st.clients) == 0 {
+		st.lastSeen = h.now()
+		if h.cfg.LogTTL <= 0 {
+			h.removeUser(c.userID, st)
+		}
+	}
+}
+
+// Publish - записываем событие в журналы участников чата и доставляем их подключениям.
+// Новые участники чата подписываются до доставки, подписка на удалённый чат снимается после доставки
+func (h *Hub) Publish(ev Event) {
+	payload, err := json.Marshal(ev)
+	if err != nil {
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if ev.Type == EventChatCreated {
+		for _, userID := range ev.UserIDs {
+			if st := h.users[userID]; st != nil {
+				h.subscribe(userID, st, ev.ChatID)
+			}
+		}
+	}
+
+	h.seq++
+	d := Delivery{ID: h.seq, Payload: payload}
+	for userID := range h.recipients(ev) {
+		st := h.users[userID]
+		st.append(d, h.cfg.LogSize)
+		for c := range st.clients {
+			c.deliver(d)
+		}
+	}
+
+	if ev.Type == EventChatDeleted {
+		for userID := range h.chats[ev.ChatID] {
+			delete(h.users[userID].chats, ev.ChatID)
+		}
+		delete(h.chats, ev.ChatID)
+	}
+}
+
+// Shutdown - закрываем все подключения и ждём, пока они будут сняты с регистрации или истечёт контекст
+func (h *Hub) Shutdown(ctx context.Context) error {
+	h.mu.Lock()
+	h.closed = true
+	for _, st := range h.users {
+		for c := range st.clients {
+			c.close()
+		}
+	}
+	h.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		h.conns.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// recipients - пользователи, которые получают событие: подписчики чата события или всех чатов из ChatIDs,
+// а событие для одного пользователя - только он, если подписан на чат события. Вызывается под мьютексом
+func (h *Hub) recipients(ev Event) map[int64]struct{} {
+	if ev.ToUserID != 0 {
+		if _, ok := h.chats[ev.ChatID][ev.ToUserID]; !ok {
+			return nil
+		}
+		return map[int64]struct{}{ev.ToUserID: {}}
+	}
+	if len(ev.ChatIDs) == 0 {
+		return h.chats[ev.ChatID]
+	}
+
+	users := make(map[int64]struct{})
+	for _, chatID := range append([]int64{ev.ChatID}, ev.ChatIDs...) {
+		for userID := range h.chats[chatID] {
+			users[userID] = struct{}{}
+		}
+	}
+	return users
+}
+
+// subscribe - подписываем пользователя на чат, вызывается под мьютексом
+func (h *Hub) subscribe(userID int64, st *userState, chatID int64) {
+	if h.chats[chatID] == nil {
+		h.chats[chatID] = make(map[int64]struct{})
+	}
+	h.chats[chatID][userID] = struct{}{}
+	st.chats[chatID] = struct{}{}
+}
+
+// unsubscribe - снимаем подписку пользователя на чат, вызывается под мьютексом
+func (h *Hub) unsubscribe(userID int64, st *userState, chatID int64) {
+	delete(st.chats, chatID)
+	delete(h.chats[chatID], userID)
+	if len(h.chats[chatID]) == 0 {
+		delete(h.chats, chatID)
+	}
+}
+
+// removeUser - удаляем состояние пользователя вместе с журналом, вызывается под мьютексом
+func (h *Hub) removeUser(userID int64, st *userState) {
+	for chatID := range st.chats {
+		h.unsubscribe(userID, st, chatID)
+	}
+	delete(h.users, userID)
+}
+
+// sweep - не чаще раза в LogTTL удаляем состояния пользователей, которые отключились дольше LogTTL назад
+func (h *Hub) sweep() {
+	now := h.now()
+	if now.Sub(h.lastSweep) < h.cfg.LogTTL {
+		return
+	}
+	h.lastSweep = now
+
+	for userID, st := range h.users {
+		if len(st.clients) == 0 && now.Sub(st.lastSeen) >= h.cfg.LogTTL {
+			h.removeUser(userID, st)
+		}
+	}
+}
+
+// append - записываем событие в журнал, при переполнении вытесняем самое старое
+func (st *userState) append(d Delivery, size int) {
+	if size <= 0 {
+		st.floor = d.ID
+		return
+	}
+
+	if len(st.log) == size {
+		st.floor = st.log[0].ID
+		copy(st.log, st.log[1:])
+		st.log = st.log[:size-1]
+	}
+	st.log = append(st.log, d)
+}
+
+// since - события журнала после lastEventID. Если часть событий уже недоступна или номер выдан
+// не этим хабом, возвращаем одно событие resync с текущим номером
+func (st *userState) since(lastEventID, seq uint64) []Delivery {
+	if lastEventID == 0 {
+		return nil
+	}
+	if lastEventID < st.floor || lastEventID > seq {
+		return []Delivery{{ID: seq, Payload: resyncPayload}}
+	}
+
+	i := sort.Search(len(st.log), func(i int) bool { return st.log[i].ID > lastEventID })
+	return slices.Clone(st.log[i:])
+}
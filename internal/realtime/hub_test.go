@@ -0,0 +1,300 @@
+package realtime
+
+import (
+	"context"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/websocket"
+
+	"service-chat/internal/config"
+)
+
+// pending - события, которые ждут отправки клиенту
+func pending(c *Client) []string {
+	var out []string
+	for {
+		select {
+		case payload := <-c.send:
+			out = append(out, string(payload.Payload))
+		default:
+			return out
+		}
+	}
+}
+
+// isClosed - клиент отключён хабом
+func isClosed(c *Client) bool {
+	select {
+	case <-c.done:
+		return true
+	default:
+		return false
+	}
+}
+
+func TestHub_Publish(t *testing.T) {
+	hub := NewHub(config.Realtime{SendBuffer: 8})
+
+	alice, err := hub.Register(1, []int64{10, 11})
+	require.NoError(t, err)
+	bob, err := hub.Register(2, []int64{10})
+	require.NoError(t, err)
+	bobPhone, err := hub.Register(2, []int64{10})
+	require.NoError(t, err)
+
+	hub.Publish(Event{Type: EventMessageCreated, ChatID: 10})
+	hub.Publish(Event{Type: EventMessageCreated, ChatID: 11})
+	hub.Publish(Event{Type: EventMessageCreated, ChatID: 12})
+
+	assert.Equal(t, []string{`{"type":"message.created","chat_id":10}`, `{"type":"message.created","chat_id":11}`}, pending(alice))
+	assert.Equal(t, []string{`{"type":"message.created","chat_id":10}`}, pending(bob))
+	assert.Equal(t, []string{`{"type":"message.created","chat_id":10}`}, pending(bobPhone))
+
+	// После отключения события больше не доставляются
+	hub.Unregister(bob)
+	hub.Unregister(bob)
+	hub.Publish(Event{Type: EventMessageDeleted, ChatID: 10})
+	assert.Empty(t, pending(bob))
+	assert.Len(t, pending(bobPhone), 1)
+}
+
+func TestHub_PublishChatIDs(t *testing.T) {
+	hub := NewHub(config.Realtime{SendBuffer: 8})
+
+	alice, err := hub.Register(1, []int64{10, 11})
+	require.NoError(t, err)
+	bob, err := hub.Register(2, []int64{11})
+	require.NoError(t, err)
+	carol, err := hub.Register(3, []int64{12})
+	require.NoError(t, err)
+
+	// Событие для нескольких чатов доставляется каждому получателю один раз, а список чатов клиенту не уходит
+	hub.Publish(Event{Type: EventPresenceChanged, ChatIDs: []int64{10, 11}, Data: PresencePayload{UserID: 1, Status: "online"}})
+
+	want := []string{`{"type":"presence.changed","data":{"user_id":1,"status":"online"}}`}
+	assert.Equal(t, want, pending(alice))
+	assert.Equal(t, want, pending(bob))
+	assert.Empty(t, pending(carol))
+}
+
+func TestHub_PublishToUser(t *testing.T) {
+	hub := NewHub(config.Realtime{SendBuffer: 8})
+
+	alice, err := hub.Register(1, []int64{10})
+	require.NoError(t, err)
+	bob, err := hub.Register(2, []int64{10})
+	require.NoError(t, err)
+	carol, err := hub.Register(3, []int64{11})
+	require.NoError(t, err)
+
+	// Ответ команды получает только вызвавший её участник чата
+	hub.Publish(Event{Type: EventCommandReply, ChatID: 10, ToUserID: 1, Data: CommandPayload{Command: "help", Text: "Commands"}})
+	assert.Equal(t, []string{`{"type":"command.reply","chat_id":10,"data":{"command":"help","text":"Commands"}}`}, pending(alice))
+	assert.Empty(t, pending(bob))
+
+	// Пользователь не подписан на чат события
+	hub.Publish(Event{Type: EventCommandReply, ChatID: 10, ToUserID: 3, Data: CommandPayload{Command: "help", Text: "Commands"}})
+	assert.Empty(t, pending(carol))
+}
+
+func TestHub_Membership(t *testing.T) {
+	hub := NewHub(config.Realtime{SendBuffer: 8})
+
+	alice, err := hub.Register(1, nil)
+	require.NoError(t, err)
+	bob, err := hub.Register(2, nil)
+	require.NoError(t, err)
+
+	// Новый чат: подключения участников подписываются и получают событие создания
+	hub.Publish(Event{Type: EventChatCreated, ChatID: 5, UserIDs: []int64{1}})
+	hub.Publish(Event{Type: EventMessageCreated, ChatID: 5})
+	assert.Equal(t, []string{`{"type":"chat.created","chat_id":5,"user_ids":[1]}`, `{"type":"message.created","chat_id":5}`}, pending(alice))
+	assert.Empty(t, pending(bob))
+
+	// Удалённый чат: событие удаления доставляется, дальше подписки нет
+	hub.Publish(Event{Type: EventChatDeleted, ChatID: 5})
+	hub.Publish(Event{Type: EventMessageCreated, ChatID: 5})
+	assert.Equal(t, []string{`{"type":"chat.deleted","chat_id":5}`}, pending(alice))
+}
+
+func TestHub_Backpressure(t *testing.T) {
+	hub := NewHub(config.Realtime{SendBuffer: 2})
+
+	slow, err := hub.Register(1, []int64{10})
+	require.NoError(t, err)
+	fast, err := hub.Register(2, []int64{10})
+	require.NoError(t, err)
+
+	for i := 0; i < 3; i++ {
+		hub.Publish(Event{Type: EventMessageCreated, ChatID: 10})
+		pending(fast)
+	}
+
+	// Медленный клиент отключён, быстрый продолжает получать события
+	assert.True(t, isClosed(slow))
+	assert.False(t, isClosed(fast))
+}
+
+func TestHub_Shutdown(t *testing.T) {
+	hub := NewHub(config.Realtime{SendBuffer: 2})
+
+	client, err := hub.Register(1, []int64{10})
+	require.NoError(t, err)
+
+	// Подключение не снято с регистрации - ждём до истечения контекста
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, hub.Shutdown(ctx), context.DeadlineExceeded)
+	assert.True(t, isClosed(client))
+
+	// Новые подключения не принимаются
+	_, err = hub.Register(2, nil)
+	assert.ErrorIs(t, err, ErrHubClosed)
+
+	hub.Unregister(client)
+	assert.NoError(t, hub.Shutdown(context.Background()))
+}
+
+func TestClient_Serve(t *testing.T) {
+	hub := NewHub(config.Realtime{
+		SendBuffer:   4,
+		PingInterval: 20 * time.Millisecond,
+		PongTimeout:  time.Minute,
+		WriteTimeout: time.Second,
+	})
+
+	served := make(chan *Client, 1)
+	srv := httptest.NewServer(websocket.Server{Handler: func(ws *websocket.Conn) {
+		client, err := hub.Register(1, []int64{10})
+		if err != nil {
+			return
+		}
+		defer hub.Unregister(client)
+		served <- client
+		client.Serve(ws)
+	}})
+	defer srv.Close()
+
+	ws, err := websocket.Dial("ws"+strings.TrimPrefix(srv.URL, "http"), "", srv.URL)
+	require.NoError(t, err)
+	defer ws.Close()
+	<-served
+
+	require.NoError(t, ws.SetReadDeadline(time.Now().Add(5*time.Second)))
+
+	// Без событий приходит проверка соединения
+	var msg string
+	require.NoError(t, websocket.Message.Receive(ws, &msg))
+	assert.Equal(t, `{"type":"ping"}`, msg)
+	require.NoError(t, websocket.Message.Send(ws, `{"type":"pong"}`))
+
+	// Остановка хаба закрывает соединение и дожидается его снятия с регистрации
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	require.NoError(t, hub.Shutdown(ctx))
+	for {
+		if err = websocket.Message.Receive(ws, &msg); err != nil {
+			break
+		}
+	}
+}
+
+func TestClient_PongTimeout(t *testing.T) {
+	hub := NewHub(config.Realtime{
+		SendBuffer:   4,
+		PingInterval: time.Minute,
+		PongTimeout:  50 * time.Millisecond,
+		WriteTimeout: time.Second,
+	})
+
+	done := make(chan struct{})
+	srv := httptest.NewServer(websocket.Server{Handler: func(ws *websocket.Conn) {
+		defer close(done)
+		client, err := hub.Register(1, nil)
+		if err != nil {
+			return
+		}
+		defer hub.Unregister(client)
+		client.Serve(ws)
+	}})
+	defer srv.Close()
+
+	ws, err := websocket.Dial("ws"+strings.TrimPrefix(srv.URL, "http"), "", srv.URL)
+	require.NoError(t, err)
+	defer ws.Close()
+
+	// Клиент молчит - сервер закрывает соединение
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("connection was not closed after pong timeout")
+	}
+}
+
+func TestHub_Resume(t *testing.T) {
+	now := time.Date(2030, 1, 1, 9, 0, 0, 0, time.UTC)
+	hub := NewHub(config.Realtime{SendBuffer: 8, LogSize: 3, LogTTL: time.Minute})
+	hub.now = func() time.Time { return now }
+
+	client, err := hub.Register(1, []int64{10})
+	require.NoError(t, err)
+	hub.Publish(Event{Type: EventMessageCreated, ChatID: 10})
+	first := <-client.Events()
+	hub.Unregister(client)
+
+	// Пока клиент отключён, события пишутся в журнал
+	hub.Publish(Event{Type: EventMessageUpdated, ChatID: 10})
+	hub.Publish(Event{Type: EventMessageDeleted, ChatID: 10})
+
+	client, missed, err := hub.Resume(1, []int64{10}, first.ID)
+	require.NoError(t, err)
+	require.Len(t, missed, 2)
+	assert.Equal(t, first.ID+1, missed[0].ID)
+	assert.Equal(t, `{"type":"message.updated","chat_id":10}`, string(missed[0].Payload))
+	assert.Equal(t, `{"type":"message.deleted","chat_id":10}`, string(missed[1].Payload))
+	hub.Unregister(client)
+
+	// Журнал вмещает 3 события, вытеснено ещё не полученное клиентом - он должен загрузить чаты заново
+	hub.Publish(Event{Type: EventMessageCreated, ChatID: 10})
+	hub.Publish(Event{Type: EventMessageCreated, ChatID: 10})
+	client, missed, err = hub.Resume(1, []int64{10}, first.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []Delivery{{ID: first.ID + 4, Payload: []byte(`{"type":"resync"}`)}}, missed)
+	hub.Unregister(client)
+
+	// Номер, который хаб не выдавал, тоже приводит к resync
+	client, missed, err = hub.Resume(1, []int64{10}, first.ID+100)
+	require.NoError(t, err)
+	assert.Equal(t, `{"type":"resync"}`, string(missed[0].Payload))
+	hub.Unregister(client)
+
+	// Через LogTTL после отключения журнал удаляется вместе с событием, которое клиент не получил
+	hub.Publish(Event{Type: EventMessageCreated, ChatID: 10})
+	now = now.Add(2 * time.Minute)
+	client, missed, err = hub.Resume(1, []int64{10}, first.ID+4)
+	require.NoError(t, err)
+	assert.Equal(t, `{"type":"resync"}`, string(missed[0].Payload))
+	hub.Unregister(client)
+}
+
+func TestHub_ResumeChatsFromDB(t *testing.T) {
+	hub := NewHub(config.Realtime{SendBuffer: 8, LogSize: 8, LogTTL: time.Minute})
+
+	client, err := hub.Register(1, []int64{10, 11})
+	require.NoError(t, err)
+	hub.Unregister(client)
+
+	// Пользователь вышел из чата 11, пока был отключён
+	client, _, err = hub.Resume(1, []int64{10}, 0)
+	require.NoError(t, err)
+	defer hub.Unregister(client)
+
+	hub.Publish(Event{Type: EventMessageCreated, ChatID: 11})
+	hub.Publish(Event{Type: EventMessageCreated, ChatID: 10})
+	assert.Equal(t, []string{`{"type":"message.created","chat_id":10}`}, pending(client))
+}
@@ -0,0 +1,72 @@
+package realtime
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// ssePing - комментарий text/event-stream, не даёт прокси закрыть молчащее соединение
+var ssePing = []byte(": ping\n\n")
+
+// ServeSSE - отправляем события в формате text/event-stream: сначала пропущенные, затем новые.
+// Номер события передаётся в поле id, браузер вернёт его в заголовке Last-Event-ID при переподключении.
+// Возвращается, когда клиент отключился или подключение закрыто хабом
+func (c *Client) ServeSSE(w http.ResponseWriter, r *http.Request, missed []Delivery) {
+	rc := http.NewResponseController(w)
+
+	// Таймауты http.Server оборвали бы долгий поток, поэтому срок ставим на каждую запись отдельно
+	_ = rc.SetReadDeadline(time.Time{})
+
+	header := w.Header()
+	header.Set("Content-Type", "text/event-stream")
+	header.Set("Cache-Control", "no-cache")
+	header.Set("Connection", "keep-alive")
+	// Отключаем буферизацию ответа в nginx
+	header.Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	write := func(data []byte) bool {
+		_ = rc.SetWriteDeadline(time.Now().Add(c.cfg.WriteTimeout))
+		if _, err := w.Write(data); err != nil {
+			return false
+		}
+		return rc.Flush() == nil
+	}
+
+	// Пустой комментарий сразу отправляет заголовки, даже если пропущенных событий нет
+	if !write(ssePing) {
+		return
+	}
+	for _, d := range missed {
+		if !write(sseEvent(d)) {
+			return
+		}
+	}
+
+	ticker := time.NewTicker(c.cfg.PingInterval)
+	defer ticker.Stop()
+
+	for {
+		var data []byte
+		select {
+		case <-r.Context().Done():
+			return
+		case <-c.done:
+			return
+		case d := <-c.send:
+			data = sseEvent(d)
+		case <-ticker.C:
+			data = ssePing
+		}
+
+		if !write(data) {
+			return
+		}
+	}
+}
+
+// sseEvent - событие в формате text/event-stream, json не содержит переводов строк и помещается в одно поле data
+func sseEvent(d Delivery) []byte {
+	return []byte(fmt.Sprintf("id: %d\ndata: %s\n\n", d.ID, d.Payload))
+}
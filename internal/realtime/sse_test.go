@@ -0,0 +1,73 @@
+package realtime
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"service-chat/internal/config"
+)
+
+func TestClient_ServeSSE(t *testing.T) {
+	hub := NewHub(config.Realtime{
+		SendBuffer:   4,
+		PingInterval: time.Minute,
+		WriteTimeout: time.Second,
+		LogSize:      4,
+		LogTTL:       time.Minute,
+	})
+
+	registered := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		client, missed, err := hub.Resume(1, []int64{10}, 0)
+		if err != nil {
+			return
+		}
+		defer hub.Unregister(client)
+		missed = append(missed, Delivery{ID: 5, Payload: []byte(`{"type":"message.deleted","chat_id":10}`)})
+		close(registered)
+		client.ServeSSE(w, r, missed)
+	}))
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	<-registered
+	hub.Publish(Event{Type: EventMessageCreated, ChatID: 10})
+
+	// Сначала комментарий, затем пропущенное и новое событие
+	reader := bufio.NewReader(resp.Body)
+	var lines []string
+	for len(lines) < 8 {
+		line, errRead := reader.ReadString('\n')
+		require.NoError(t, errRead)
+		lines = append(lines, line)
+	}
+	assert.Equal(t, []string{
+		": ping\n", "\n",
+		"id: 5\n", `data: {"type":"message.deleted","chat_id":10}` + "\n", "\n",
+		"id: " + strconv.FormatUint(lastID(hub), 10) + "\n", `data: {"type":"message.created","chat_id":10}` + "\n", "\n",
+	}, lines)
+
+	// Остановка хаба завершает поток
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	require.NoError(t, hub.Shutdown(ctx))
+}
+
+// lastID - номер последнего опубликованного события
+func lastID(h *Hub) uint64 {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.seq
+}
@@ -0,0 +1,74 @@
+package safehttp
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+)
+
+// ErrForbiddenAddress - адрес ведёт во внутреннюю сеть или на недопустимую схему
+var ErrForbiddenAddress = errors.New("forbidden address")
+
+// NewClient - HTTP клиент для адресов, которые задают пользователи: превью ссылок, вебхуки, боты.
+// Защита от SSRF: адрес проверяется при каждом соединении уже после разрешения DNS, поэтому
+// ни редирект, ни подмена DNS записи не приведут во внутреннюю сеть. checkRedirect - правило редиректов клиента,
+// allowPrivate разрешает адреса внутренней сети и нужен только для тестов на локальном сервере
+func NewClient(timeout time.Duration, allowPrivate bool, checkRedirect func(req *http.Request, via []*http.Request) error) *http.Client {
+	dialer := Dialer(timeout, allowPrivate)
+
+	transport := &http.Transport{
+		// Прокси из окружения не используем, иначе проверка адреса применится к прокси, а не к адресу запроса
+		Proxy:                 nil,
+		DialContext:           dialer.DialContext,
+		TLSHandshakeTimeout:   timeout,
+		ResponseHeaderTimeout: timeout,
+		MaxIdleConns:          10,
+		IdleConnTimeout:       30 * time.Second,
+	}
+
+	return &http.Client{
+		Transport:     transport,
+		Timeout:       timeout,
+		CheckRedirect: checkRedirect,
+	}
+}
+
+// NoRedirect - правило редиректов, при котором клиент возвращает сам ответ с редиректом
+func NoRedirect(*http.Request, []*http.Request) error {
+	return http.ErrUseLastResponse
+}
+
+// Dialer - соединения только с публичными адресами. Адрес проверяется уже после разрешения DNS,
+// allowPrivate разрешает адреса внутренней сети и нужен только для тестов на локальном сервере
+func Dialer(timeout time.Duration, allowPrivate bool) *net.Dialer {
+	return &net.Dialer{
+		Timeout: timeout,
+		Control: func(_, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || (!allowPrivate && !isPublicIP(ip)) {
+				return ErrForbiddenAddress
+			}
+			return nil
+		},
+	}
+}
+
+// cgnat - общее адресное пространство провайдеров (RFC 6598), тоже не публичная сеть
+var cgnat = &net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
+
+// isPublicIP - адрес не относится к локальной, частной, служебной или multicast сети
+func isPublicIP(ip net.IP) bool {
+	if ip4 := ip.To4(); ip4 != nil {
+		ip = ip4
+		if ip[0] == 0 || cgnat.Contains(ip) {
+			return false
+		}
+	}
+	return !(ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() || ip.IsLinkLocalUnicast() ||
+		ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() || ip.IsMulticast())
+}
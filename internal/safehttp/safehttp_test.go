@@ -0,0 +1,34 @@
+package safehttp
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsPublicIP(t *testing.T) {
+	tests := []struct {
+		ip   string
+		want bool
+	}{
+		{ip: "93.184.216.34", want: true},
+		{ip: "2606:2800:220:1:248:1893:25c8:1946", want: true},
+		{ip: "127.0.0.1"},
+		{ip: "10.1.2.3"},
+		{ip: "172.16.0.1"},
+		{ip: "192.168.1.1"},
+		{ip: "169.254.169.254"},
+		{ip: "100.64.0.1"},
+		{ip: "0.0.0.0"},
+		{ip: "::1"},
+		{ip: "fd00::1"},
+		{ip: "fe80::1"},
+		{ip: "::ffff:127.0.0.1"},
+		{ip: "224.0.0.1"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, isPublicIP(net.ParseIP(tt.ip)), tt.ip)
+	}
+}
@@ -0,0 +1,192 @@
+package service
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"unicode"
+
+	"service-chat/internal/apperr"
+	"service-chat/internal/config"
+	"service-chat/internal/db"
+	"service-chat/internal/db/entity"
+	"service-chat/internal/dto"
+	"service-chat/internal/storage"
+)
+
+const (
+	attachmentURL    = "/attachments/%d"
+	thumbnailURL     = "/attachments/%d/thumbnail"
+	maxFileNameRunes = 255
+)
+
+type AttachmentService struct {
+	repo  db.Attachment
+	store storage.BlobStore
+	cfg   config.Attachments
+}
+
+func NewAttachmentService(repo db.Attachment, store storage.BlobStore, cfg config.Attachments) *AttachmentService {
+	return &AttachmentService{repo: repo, store: store, cfg: cfg}
+}
+
+// AddAttachment - сохраняем файл в хранилище и прикрепляем его к сообщению автора
+func (as *AttachmentService) AddAttachment(in dto.AttachmentAdd, messageID int64, userID int) (*entity.Attachment, error) {
+	// Если запрос пустой
+	if messageID == 0 || userID == 0 {
+		return nil, apperr.Validation("empty message_id or user_id")
+	} else if in.File == nil {
+		return nil, apperr.Validation("empty file")
+	}
+
+	// Проверяем заявленный размер, а затем и фактический, не читая больше лимита
+	errSize := apperr.Validation(fmt.Sprintf("file size exceeds limit of %d bytes", as.cfg.MaxSize))
+	if in.Size > as.cfg.MaxSize {
+		return nil, errSize
+	}
+	data, err := io.ReadAll(io.LimitReader(in.File, as.cfg.MaxSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+	if int64(len(data)) > as.cfg.MaxSize {
+		return nil, errSize
+	} else if len(data) == 0 {
+		return nil, apperr.Validation("empty file")
+	}
+
+	// MIME тип определяем по содержимому файла, а не по заявленному клиентом
+	contentType, _, err := mime.ParseMediaType(http.DetectContentType(data))
+	if err != nil || !as.allowedType(contentType) {
+		return nil, apperr.Validation(fmt.Sprintf("file type %s is not allowed", contentType))
+	}
+
+	// Сохраняем файл в хранилище
+	key, err := storageKey(messageID)
+	if err != nil {
+		return nil, err
+	}
+	if err = as.store.Put(key, bytes.NewReader(data), int64(len(data)), contentType); err != nil {
+		return nil, err
+	}
+
+	// Для изображений дополнительно сохраняем миниатюру, если изображение не удалось
+	// декодировать, то вложение сохраняется без миниатюры
+	var thumbKey string
+	if strings.HasPrefix(contentType, "image/") {
+		if thumb, errThumb := storage.Thumbnail(data, as.cfg.ThumbnailSize); errThumb == nil {
+			thumbKey = key + "_thumbnail"
+			if err = as.store.Put(thumbKey, bytes.NewReader(thumb), int64(len(thumb)), storage.ThumbnailType); err != nil {
+				_ = as.store.Delete(key)
+				return nil, err
+			}
+		}
+	}
+
+	dataDB := entity.AttachmentAdd{
+		MessageID:    messageID,
+		UserID:       userID,
+		FileName:     cleanFileName(in.FileName),
+		ContentType:  contentType,
+		Size:         int64(len(data)),
+		StorageKey:   key,
+		ThumbnailKey: thumbKey,
+	}
+	attachment, err := as.repo.AddAttachment(dataDB)
+	if err != nil {
+		// Не оставляем в хранилище файлы, которые не удалось привязать к сообщению
+		_ = as.store.Delete(key)
+		if thumbKey != "" {
+			_ = as.store.Delete(thumbKey)
+		}
+		return nil, err
+	}
+
+	setAttachmentURLs(attachment)
+	return attachment, nil
+}
+
+// GetAttachment - получаем вложение или его миниатюру, если пользователь состоит в чате с сообщением
+func (as *AttachmentService) GetAttachment(attachmentID int64, userID int, thumbnail bool) (*entity.Attachment, io.ReadCloser, error) {
+	// Если запрос пустой
+	if attachmentID == 0 || userID == 0 {
+		return nil, nil, apperr.Validation("empty attachment_id or user_id")
+	}
+
+	attachment, err := as.repo.GetAttachment(entity.AttachmentGet{AttachmentID: attachmentID, UserID: userID})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	key := attachment.StorageKey
+	if thumbnail {
+		if attachment.ThumbnailKey == "" {
+			return nil, nil, apperr.NotFound("attachment has no thumbnail")
+		}
+		key = attachment.ThumbnailKey
+		attachment.ContentType = storage.ThumbnailType
+	}
+
+	body, err := as.store.Get(key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	setAttachmentURLs(attachment)
+	return attachment, body, nil
+}
+
+// MaxUploadSize - максимальный размер одного загружаемого файла
+func (as *AttachmentService) MaxUploadSize() int64 {
+	return as.cfg.MaxSize
+}
+
+// allowedType - проверяем MIME тип по списку из конфига
+func (as *AttachmentService) allowedType(contentType string) bool {
+	for _, allowed := range as.cfg.AllowedTypes {
+		if strings.EqualFold(allowed, contentType) {
+			return true
+		}
+	}
+	return false
+}
+
+// storageKey - случайный ключ файла в хранилище, имя от клиента в ключе не используется
+func storageKey(messageID int64) (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate storage key: %w", err)
+	}
+	return fmt.Sprintf("messages/%d/%s", messageID, hex.EncodeToString(buf)), nil
+}
+
+// cleanFileName - оставляем только имя файла без пути и управляющих символов
+func cleanFileName(name string) string {
+	name = filepath.Base(strings.ReplaceAll(name, "\\", "/"))
+	name = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, name)
+	if name == "." || name == "/" || name == "" {
+		name = "file"
+	}
+	if runes := []rune(name); len(runes) > maxFileNameRunes {
+		name = string(runes[:maxFileNameRunes])
+	}
+	return name
+}
+
+// setAttachmentURLs - проставляем ссылки на скачивание вложения
+func setAttachmentURLs(attachment *entity.Attachment) {
+	attachment.URL = fmt.Sprintf(attachmentURL, attachment.Id)
+	if attachment.ThumbnailKey != "" {
+		attachment.ThumbnailURL = fmt.Sprintf(thumbnailURL, attachment.Id)
+	}
+}
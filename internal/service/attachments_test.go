@@ -0,0 +1,189 @@
+package service
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/png"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"service-chat/internal/apperr"
+	"service-chat/internal/config"
+	"service-chat/internal/db/entity"
+	mockRepo "service-chat/internal/db/mocks"
+	"service-chat/internal/dto"
+	"service-chat/internal/storage"
+)
+
+func TestAttachmentService_AddAttachment(t *testing.T) {
+	// Структура для последующей реализации поведения мока
+	type mockBehaviour func(s *mockRepo.MockAttachment)
+
+	//Инициализируем контролер для мока сервиса
+	ctrl := gomock.NewController(t)
+	// Завершаем работу контролера после выполнения каждого теста
+	defer ctrl.Finish()
+
+	// Создаём моки базы данных вложений и локальное хранилище во временной директории
+	mockAttachment := mockRepo.NewMockAttachment(ctrl)
+	store, err := storage.NewLocalStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Создаём экземпляр сервиса вложений
+	serviceAttachment := NewAttachmentService(mockAttachment, store, config.Attachments{
+		MaxSize:       1024,
+		AllowedTypes:  []string{"text/plain", "image/png"},
+		ThumbnailSize: 8,
+	})
+
+	// Готовим маленькое png изображение
+	var img bytes.Buffer
+	if err = png.Encode(&img, image.NewRGBA(image.Rect(0, 0, 16, 16))); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		in        dto.AttachmentAdd
+		messageID int64
+		userID    int
+		mock      mockBehaviour
+		want      *entity.Attachment
+		wantErr   error
+	}{
+		{
+			name:      "Success text file",
+			in:        dto.AttachmentAdd{FileName: "../../notes.txt", Size: 5, File: strings.NewReader("hello")},
+			messageID: 1,
+			userID:    1,
+			mock: func(s *mockRepo.MockAttachment) {
+				s.EXPECT().AddAttachment(gomock.Any()).DoAndReturn(func(in entity.AttachmentAdd) (*entity.Attachment, error) {
+					assert.Equal(t, "notes.txt", in.FileName)
+					assert.Equal(t, "text/plain", in.ContentType)
+					assert.Equal(t, int64(5), in.Size)
+					assert.Empty(t, in.ThumbnailKey)
+					return &entity.Attachment{Id: 7, MessageID: 1, FileName: in.FileName, ContentType: in.ContentType, Size: in.Size}, nil
+				})
+			},
+			want: &entity.Attachment{Id: 7, MessageID: 1, FileName: "notes.txt", ContentType: "text/plain", Size: 5, URL: "/attachments/7"},
+		},
+		{
+			name:      "Success image with thumbnail",
+			in:        dto.AttachmentAdd{FileName: "img.png", Size: int64(img.Len()), File: bytes.NewReader(img.Bytes())},
+			messageID: 1,
+			userID:    1,
+			mock: func(s *mockRepo.MockAttachment) {
+				s.EXPECT().AddAttachment(gomock.Any()).DoAndReturn(func(in entity.AttachmentAdd) (*entity.Attachment, error) {
+					assert.Equal(t, "image/png", in.ContentType)
+					assert.Equal(t, in.StorageKey+"_thumbnail", in.ThumbnailKey)
+					return &entity.Attachment{Id: 8, ThumbnailKey: "thumbnail"}, nil
+				})
+			},
+			want: &entity.Attachment{Id: 8, ThumbnailKey: "thumbnail", URL: "/attachments/8", ThumbnailURL: "/attachments/8/thumbnail"},
+		},
+		{
+			name:      "Empty message_id",
+			in:        dto.AttachmentAdd{FileName: "notes.txt", File: strings.NewReader("hello")},
+			messageID: 0,
+			userID:    1,
+			mock:      func(s *mockRepo.MockAttachment) {},
+			wantErr:   apperr.Validation("empty message_id or user_id"),
+		},
+		{
+			name:      "Empty file",
+			in:        dto.AttachmentAdd{FileName: "notes.txt", File: strings.NewReader("")},
+			messageID: 1,
+			userID:    1,
+			mock:      func(s *mockRepo.MockAttachment) {},
+			wantErr:   apperr.Validation("empty file"),
+		},
+		{
+			name:      "File too large",
+			in:        dto.AttachmentAdd{FileName: "big.txt", File: strings.NewReader(strings.Repeat("a", 2048))},
+			messageID: 1,
+			userID:    1,
+			mock:      func(s *mockRepo.MockAttachment) {},
+			wantErr:   apperr.Validation("file size exceeds limit of 1024 bytes"),
+		},
+		{
+			name:      "Type not allowed",
+			in:        dto.AttachmentAdd{FileName: "doc.pdf", File: strings.NewReader("%PDF-1.4 test")},
+			messageID: 1,
+			userID:    1,
+			mock:      func(s *mockRepo.MockAttachment) {},
+			wantErr:   apperr.Validation("file type application/pdf is not allowed"),
+		},
+		{
+			name:      "Other error",
+			in:        dto.AttachmentAdd{FileName: "notes.txt", File: strings.NewReader("hello")},
+			messageID: 1,
+			userID:    1,
+			mock: func(s *mockRepo.MockAttachment) {
+				s.EXPECT().AddAttachment(gomock.Any()).Return(nil, errors.New("other error"))
+			},
+			wantErr: errors.New("other error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock(mockAttachment)
+
+			// Проверяем ожидаемый и актуальный результат
+			acAttachment, acErr := serviceAttachment.AddAttachment(tt.in, tt.messageID, tt.userID)
+			assert.Equal(t, tt.want, acAttachment)
+			assert.Equal(t, tt.wantErr, acErr)
+		})
+	}
+}
+
+func TestAttachmentService_GetAttachment(t *testing.T) {
+	//Инициализируем контролер для мока сервиса
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Создаём моки базы данных вложений и локальное хранилище с одним файлом
+	mockAttachment := mockRepo.NewMockAttachment(ctrl)
+	store, err := storage.NewLocalStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = store.Put("messages/1/abc", strings.NewReader("hello"), 5, "text/plain"); err != nil {
+		t.Fatal(err)
+	}
+
+	serviceAttachment := NewAttachmentService(mockAttachment, store, config.Attachments{})
+	stored := entity.Attachment{Id: 1, ContentType: "text/plain", StorageKey: "messages/1/abc"}
+
+	// Успешное скачивание
+	mockAttachment.EXPECT().GetAttachment(entity.AttachmentGet{AttachmentID: 1, UserID: 2}).Return(&stored, nil)
+	attachment, body, err := serviceAttachment.GetAttachment(1, 2, false)
+	assert.Nil(t, err)
+	assert.Equal(t, "/attachments/1", attachment.URL)
+	data, _ := io.ReadAll(body)
+	body.Close()
+	assert.Equal(t, "hello", string(data))
+
+	// Миниатюры у текстового файла нет
+	noThumb := stored
+	mockAttachment.EXPECT().GetAttachment(entity.AttachmentGet{AttachmentID: 1, UserID: 2}).Return(&noThumb, nil)
+	_, _, err = serviceAttachment.GetAttachment(1, 2, true)
+	assert.Equal(t, apperr.NotFound("attachment has no thumbnail"), err)
+
+	// Пользователь не состоит в чате
+	mockAttachment.EXPECT().GetAttachment(entity.AttachmentGet{AttachmentID: 1, UserID: 3}).
+		Return(nil, errors.New("Attachment not found"))
+	_, _, err = serviceAttachment.GetAttachment(1, 3, false)
+	assert.Equal(t, errors.New("Attachment not found"), err)
+
+	// Пустой запрос
+	_, _, err = serviceAttachment.GetAttachment(0, 3, false)
+	assert.Equal(t, apperr.Validation("empty attachment_id or user_id"), err)
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/golang-jwt/jwt/v5"
 
+	"service-chat/internal/apperr"
 	"service-chat/internal/db"
 	"service-chat/internal/db/entity"
 	"service-chat/internal/dto"
@@ -25,18 +26,20 @@ type tokenClaims struct {
 
 type AuthService struct {
 	repo db.Authorization
+	// now - текущее время, подменяется в тестах
+	now func() time.Time
 }
 
 // NewAuthService - конструктор для работы со слоем сервиса
 func NewAuthService(repo db.Authorization) *AuthService {
-	return &AuthService{repo: repo}
+	return &AuthService{repo: repo, now: time.Now}
 }
 
 // CreateUser - реализуем интерфейс функции CreateUser передав данные со слоя бизнес логики на слой базы данных
 func (s *AuthService) CreateUser(user dto.SignUpRequest) (int, error) {
 	// Если пустой запрос
 	if user.Username == "" || user.Password == "" {
-		return 0, fmt.Errorf("username or password is empty")
+		return 0, apperr.Validation("username or password is empty")
 	}
 
 	// Шифруем пароль, чтобы хранить в базе не в открытом виде
@@ -58,7 +61,7 @@ func (s *AuthService) CreateUser(user dto.SignUpRequest) (int, error) {
 func (s *AuthService) GenerateToken(user dto.SignInRequest) (string, error) {
 	// Если пустой запрос
 	if user.Username == "" || user.Password == "" {
-		return "", fmt.Errorf("username or password is empty")
+		return "", apperr.Validation("username or password is empty")
 	}
 
 	// Получаем пользователя из базы данных
@@ -78,7 +81,7 @@ func (s *AuthService) GenerateToken(user dto.SignInRequest) (string, error) {
 		return "", fmt.Errorf("failed to decrypt password: %w", errDecode)
 	}
 	if passwordDecode != user.Password {
-		return "", fmt.Errorf("incorrect password")
+		return "", apperr.Unauthorized("incorrect password")
 	}
 
 	// Если пользователь существует, генерируем token
@@ -104,7 +107,7 @@ func (s *AuthService) ParseToken(token string) (int, error) {
 	// Получаем token
 	jwtToken, err := jwt.ParseWithClaims(token, &tokenClaims{}, func(token *jwt.Token) (interface{}, error) {
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method")
+			return nil, apperr.Unauthorized("unexpected signing method")
 		}
 
 		return []byte(signingKey), nil
@@ -118,8 +121,20 @@ func (s *AuthService) ParseToken(token string) (int, error) {
 	// Получаем параметры из декодированного token
 	claims, ok := jwtToken.Claims.(*tokenClaims)
 	if !ok {
-		return 0, fmt.Errorf("invalid token claims")
+		return 0, apperr.Unauthorized("invalid token claims")
 	}
 
 	return claims.UserID, nil
 }
+
+// CheckSuspension - проверяем, что пользователь не отстранён модератором
+func (s *AuthService) CheckSuspension(userID int) error {
+	until, err := s.repo.GetSuspension(userID)
+	if err != nil {
+		return err
+	}
+	if until != nil && until.After(s.now()) {
+		return apperr.Forbidden(fmt.Sprintf("User is suspended until %s", until.UTC().Format(time.RFC3339)))
+	}
+	return nil
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
 
+	"service-chat/internal/apperr"
 	"service-chat/internal/db"
 	"service-chat/internal/db/entity"
 	mockRepo "service-chat/internal/db/mocks"
@@ -98,14 +99,14 @@ func TestAuthService_CreateUser(t *testing.T) {
 			},
 			mock:    func(s *mockRepo.MockAuthorization, dataDB entity.User) {},
 			wantID:  0,
-			wantErr: errors.New("username or password is empty"),
+			wantErr: apperr.Validation("username or password is empty"),
 		},
 		{
 			name:    "Nil request",
 			user:    dto.SignUpRequest{},
 			mock:    func(s *mockRepo.MockAuthorization, dataDB entity.User) {},
 			wantID:  0,
-			wantErr: errors.New("username or password is empty"),
+			wantErr: apperr.Validation("username or password is empty"),
 		},
 	}
 
@@ -193,12 +194,12 @@ func TestAuthService_GenerateToken(t *testing.T) {
 				Password: "",
 			},
 			mock:    func(s *mockRepo.MockAuthorization, dataDB entity.User) {},
-			wantErr: errors.New("username or password is empty"),
+			wantErr: apperr.Validation("username or password is empty"),
 		},
 		{
 			name:    "Nil request",
 			mock:    func(s *mockRepo.MockAuthorization, dataDB entity.User) {},
-			wantErr: errors.New("username or password is empty"),
+			wantErr: apperr.Validation("username or password is empty"),
 		},
 		{
 			name: "Error decrypt password",
@@ -235,7 +236,7 @@ func TestAuthService_GenerateToken(t *testing.T) {
 					Password: "Fail",
 				}, nil)
 			},
-			wantErr: errors.New("incorrect password"),
+			wantErr: apperr.Unauthorized("incorrect password"),
 		},
 	}
 
@@ -0,0 +1,58 @@
+package service
+
+import (
+	"service-chat/internal/apperr"
+	"service-chat/internal/db"
+	"service-chat/internal/db/entity"
+	"service-chat/internal/dto"
+)
+
+type BlockService struct {
+	repo db.Block
+}
+
+func NewBlockService(repo db.Block) *BlockService {
+	return &BlockService{repo: repo}
+}
+
+// AddBlock - вносим пользователя в чёрный список: он не сможет создать с нами личный чат и упомянуть нас
+func (s *BlockService) AddBlock(in dto.BlockAdd, userID int) error {
+	// Если запрос пустой
+	if in.UserID == 0 || userID == 0 {
+		return apperr.Validation("empty user_id")
+	} else if in.UserID < 0 {
+		return apperr.Validation("invalid user_id")
+	} else if in.UserID == int64(userID) {
+		return apperr.Validation("cannot block yourself")
+	}
+
+	dataDB := entity.BlockAdd{
+		UserID:    userID,
+		BlockedID: in.UserID,
+	}
+	return s.repo.AddBlock(dataDB)
+}
+
+// GetBlocks - получаем чёрный список пользователя
+func (s *BlockService) GetBlocks(userID int) ([]entity.Block, error) {
+	// Если запрос пустой
+	if userID == 0 {
+		return nil, apperr.Validation("empty user_id")
+	}
+
+	return s.repo.GetBlocks(userID)
+}
+
+// DeleteBlock - убираем пользователя из чёрного списка
+func (s *BlockService) DeleteBlock(blockedID int64, userID int) error {
+	// Если запрос пустой
+	if blockedID == 0 || userID == 0 {
+		return apperr.Validation("empty user_id")
+	}
+
+	dataDB := entity.BlockDel{
+		UserID:    userID,
+		BlockedID: blockedID,
+	}
+	return s.repo.DeleteBlock(dataDB)
+}
@@ -0,0 +1,72 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"service-chat/internal/apperr"
+	"service-chat/internal/config"
+	"service-chat/internal/db/entity"
+	mockRepo "service-chat/internal/db/mocks"
+	"service-chat/internal/dto"
+	mockService "service-chat/internal/service/mocks"
+)
+
+func TestBlockService_AddBlock(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockBlock := mockRepo.NewMockBlock(ctrl)
+	serviceBlock := NewBlockService(mockBlock)
+
+	mockBlock.EXPECT().AddBlock(entity.BlockAdd{UserID: 1, BlockedID: 2}).Return(nil)
+	assert.NoError(t, serviceBlock.AddBlock(dto.BlockAdd{UserID: 2}, 1))
+
+	mockBlock.EXPECT().AddBlock(entity.BlockAdd{UserID: 1, BlockedID: 9}).
+		Return(errors.New("User with userID 9 does not exist"))
+	assert.Equal(t, errors.New("User with userID 9 does not exist"), serviceBlock.AddBlock(dto.BlockAdd{UserID: 9}, 1))
+
+	assert.Equal(t, apperr.Validation("cannot block yourself"), serviceBlock.AddBlock(dto.BlockAdd{UserID: 1}, 1))
+	assert.Equal(t, apperr.Validation("invalid user_id"), serviceBlock.AddBlock(dto.BlockAdd{UserID: -2}, 1))
+	assert.Equal(t, apperr.Validation("empty user_id"), serviceBlock.AddBlock(dto.BlockAdd{UserID: 2}, 0))
+}
+
+func TestBlockService_GetDeleteBlock(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockBlock := mockRepo.NewMockBlock(ctrl)
+	serviceBlock := NewBlockService(mockBlock)
+
+	mockBlock.EXPECT().GetBlocks(1).Return([]entity.Block{{UserID: 2, Username: "eve"}}, nil)
+	blocks, err := serviceBlock.GetBlocks(1)
+	assert.NoError(t, err)
+	assert.Equal(t, []entity.Block{{UserID: 2, Username: "eve"}}, blocks)
+
+	mockBlock.EXPECT().DeleteBlock(entity.BlockDel{UserID: 1, BlockedID: 2}).Return(errors.New("User with userID 2 is not blocked"))
+	assert.Equal(t, errors.New("User with userID 2 is not blocked"), serviceBlock.DeleteBlock(2, 1))
+
+	_, err = serviceBlock.GetBlocks(0)
+	assert.Equal(t, apperr.Validation("empty user_id"), err)
+	assert.Equal(t, apperr.Validation("empty user_id"), serviceBlock.DeleteBlock(0, 1))
+}
+
+func TestMessageService_GetMessage_HideBlocked(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockMessage := mockRepo.NewMockMessage(ctrl)
+	limit, offset := int64(10), int64(0)
+
+	// Скрытие сообщений из чёрного списка передаётся в бд из конфига
+	for _, hide := range []bool{true, false} {
+		serviceMessage := NewMessageService(mockMessage, config.Messages{HideBlocked: hide}, mockService.NewMockPreview(ctrl), &fakePublisher{})
+		mockMessage.EXPECT().GetMessage(entity.MessageGet{ChatID: 1, Limit: limit, Offset: offset, UserID: 1, HideBlocked: hide}).
+			Return(nil, nil)
+		_, err := serviceMessage.GetMessage(dto.MessageGet{ChatID: 1, Limit: &limit, Offset: &offset}, 1)
+		assert.NoError(t, err)
+	}
+}
@@ -1,44 +1,72 @@
 package service
 
 import (
-	"errors"
+	"slices"
 
+	"service-chat/internal/apperr"
 	"service-chat/internal/db"
 	"service-chat/internal/db/entity"
 	"service-chat/internal/dto"
+	"service-chat/internal/realtime"
 )
 
 type ChatService struct {
-	repo db.Chat
+	repo   db.Chat
+	events realtime.Publisher
 }
 
-func NewChatService(repo db.Chat) *ChatService {
-	return &ChatService{repo: repo}
+func NewChatService(repo db.Chat, events realtime.Publisher) *ChatService {
+	return &ChatService{repo: repo, events: events}
 }
 
-// CreateChat - создаём чат между пользователями
-func (s *ChatService) CreateChat(in dto.ChatAdd) (int, error) {
+// CreateChat - создаём чат между пользователями, создатель чата становится его администратором
+func (s *ChatService) CreateChat(in dto.ChatAdd, userID int) (int, error) {
 	// Если запрос пустой
 	if in.ChatName == "" || len(in.Users) == 0 {
-		return 0, errors.New("chat_name or users is empty")
+		return 0, apperr.Validation("chat_name or users is empty")
+	}
+	if userID == 0 {
+		return 0, apperr.Validation("user_id is empty")
+	}
+
+	// Создатель всегда состоит в своём чате
+	users := in.Users
+	if !slices.Contains(users, int64(userID)) {
+		users = append(slices.Clip(users), int64(userID))
 	}
 
 	dataDB := entity.ChatAdd{
 		ChatName: in.ChatName,
-		Users:    in.Users,
+		Users:    users,
+		AdminID:  int64(userID),
+	}
+	chatID, err := s.repo.CreateChat(dataDB)
+	if err != nil {
+		return 0, err
 	}
-	return s.repo.CreateChat(dataDB)
+
+	// Подключения участников подписываются на новый чат
+	s.events.Publish(realtime.Event{
+		Type:    realtime.EventChatCreated,
+		ChatID:  int64(chatID),
+		UserIDs: users,
+		Data:    realtime.ChatPayload{Name: in.ChatName, AdminID: int64(userID)},
+	})
+
+	return chatID, nil
 }
 
 // GetChat - получаем список чатов пользователя
 func (s *ChatService) GetChat(in dto.ChatGet) ([]entity.Chat, error) {
 	// Если запрос пустой
 	if in.UserID == nil || *in.UserID == 0 {
-		return nil, errors.New("user_id is empty")
+		return nil, apperr.Validation("user_id is empty")
 	}
 
 	dataDB := entity.ChatGet{
 		UserID: *in.UserID,
+		Limit:  in.Limit,
+		Offset: in.Offset,
 	}
 	return s.repo.GetChat(dataDB)
 }
@@ -47,15 +75,26 @@ func (s *ChatService) GetChat(in dto.ChatGet) ([]entity.Chat, error) {
 func (s *ChatService) DeleteChat(in dto.ChatDelete, userID int) ([]entity.DeletedChats, error) {
 	// Если запрос пустой
 	if in.ChatIds == nil || len(*in.ChatIds) == 0 {
-		return nil, errors.New("chat_ids is empty")
+		return nil, apperr.Validation("chat_ids is empty")
 	}
 	if userID == 0 {
-		return nil, errors.New("user_id is empty")
+		return nil, apperr.Validation("user_id is empty")
 	}
 
 	dataDB := entity.ChatDelete{
 		ChatIds: *in.ChatIds,
 		UserID:  userID,
 	}
-	return s.repo.DeleteChat(dataDB)
+	deleted, err := s.repo.DeleteChat(dataDB)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, chat := range deleted {
+		if chat.Result == resultChatDeleted {
+			s.events.Publish(realtime.Event{Type: realtime.EventChatDeleted, ChatID: chat.ChatID})
+		}
+	}
+
+	return deleted, nil
 }
@@ -7,6 +7,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
 
+	"service-chat/internal/apperr"
 	"service-chat/internal/db"
 	"service-chat/internal/db/entity"
 	mockRepo "service-chat/internal/db/mocks"
@@ -29,11 +30,12 @@ func TestChatService_CreateChat(t *testing.T) {
 	repository := &db.DB{Chat: mockChat}
 
 	// Создаём экземпляр сервиса чат
-	serviceChat := NewChatService(repository)
+	serviceChat := NewChatService(repository, &fakePublisher{})
 
 	tests := []struct {
 		name    string
 		inChat  dto.ChatAdd
+		userID  int
 		dataDB  entity.ChatAdd
 		mock    mockBehaviour
 		wantID  int
@@ -45,9 +47,11 @@ func TestChatService_CreateChat(t *testing.T) {
 				ChatName: "chat_1",
 				Users:    []int64{1, 2},
 			},
+			userID: 1,
 			dataDB: entity.ChatAdd{
 				ChatName: "chat_1",
 				Users:    []int64{1, 2},
+				AdminID:  1,
 			},
 			mock: func(s *mockRepo.MockChat, dataDB entity.ChatAdd) {
 				s.EXPECT().CreateChat(dataDB).Return(1, nil)
@@ -61,9 +65,11 @@ func TestChatService_CreateChat(t *testing.T) {
 				ChatName: "chat_1",
 				Users:    []int64{1, 2, 3, 4},
 			},
+			userID: 1,
 			dataDB: entity.ChatAdd{
 				ChatName: "chat_1",
 				Users:    []int64{1, 2, 3, 4},
+				AdminID:  1,
 			},
 			mock: func(s *mockRepo.MockChat, dataDB entity.ChatAdd) {
 				s.EXPECT().CreateChat(dataDB).Return(1, nil)
@@ -71,19 +77,48 @@ func TestChatService_CreateChat(t *testing.T) {
 			wantID:  1,
 			wantErr: nil,
 		},
+		{
+			name: "Creator is not in users",
+			inChat: dto.ChatAdd{
+				ChatName: "chat_1",
+				Users:    []int64{2, 3},
+			},
+			userID: 1,
+			dataDB: entity.ChatAdd{
+				ChatName: "chat_1",
+				Users:    []int64{2, 3, 1},
+				AdminID:  1,
+			},
+			mock: func(s *mockRepo.MockChat, dataDB entity.ChatAdd) {
+				s.EXPECT().CreateChat(dataDB).Return(1, nil)
+			},
+			wantID:  1,
+			wantErr: nil,
+		},
+		{
+			name: "Empty user_id",
+			inChat: dto.ChatAdd{
+				ChatName: "chat_1",
+				Users:    []int64{1, 2},
+			},
+			mock:    func(s *mockRepo.MockChat, dataDB entity.ChatAdd) {},
+			wantID:  0,
+			wantErr: apperr.Validation("user_id is empty"),
+		},
 		{
 			name: "Empty request",
 			inChat: dto.ChatAdd{
 				ChatName: "",
 				Users:    []int64{},
 			},
+			userID: 1,
 			dataDB: entity.ChatAdd{
 				ChatName: "",
 				Users:    []int64{},
 			},
 			mock:    func(s *mockRepo.MockChat, dataDB entity.ChatAdd) {},
 			wantID:  0,
-			wantErr: errors.New("chat_name or users is empty"),
+			wantErr: apperr.Validation("chat_name or users is empty"),
 		},
 		{
 			name: "Empty chat_name",
@@ -91,19 +126,20 @@ func TestChatService_CreateChat(t *testing.T) {
 				ChatName: "",
 				Users:    []int64{1, 2},
 			},
+			userID: 1,
 			dataDB: entity.ChatAdd{
 				ChatName: "",
 				Users:    []int64{1, 2},
 			},
 			mock:    func(s *mockRepo.MockChat, dataDB entity.ChatAdd) {},
 			wantID:  0,
-			wantErr: errors.New("chat_name or users is empty"),
+			wantErr: apperr.Validation("chat_name or users is empty"),
 		},
 		{
 			name:    "Nil request",
 			mock:    func(s *mockRepo.MockChat, dataDB entity.ChatAdd) {},
 			wantID:  0,
-			wantErr: errors.New("chat_name or users is empty"),
+			wantErr: apperr.Validation("chat_name or users is empty"),
 		},
 		{
 			name: "Other error",
@@ -111,9 +147,11 @@ func TestChatService_CreateChat(t *testing.T) {
 				ChatName: "chat_1",
 				Users:    []int64{1, 2},
 			},
+			userID: 1,
 			dataDB: entity.ChatAdd{
 				ChatName: "chat_1",
 				Users:    []int64{1, 2},
+				AdminID:  1,
 			},
 			mock: func(s *mockRepo.MockChat, dataDB entity.ChatAdd) {
 				s.EXPECT().CreateChat(dataDB).Return(0, errors.New("some error"))
@@ -129,7 +167,7 @@ func TestChatService_CreateChat(t *testing.T) {
 			tt.mock(mockChat, tt.dataDB)
 
 			// Проверяем ожидаемый и актуальный результат
-			acID, acErr := serviceChat.CreateChat(tt.inChat)
+			acID, acErr := serviceChat.CreateChat(tt.inChat, tt.userID)
 			assert.Equal(t, tt.wantID, acID)
 			assert.Equal(t, tt.wantErr, acErr)
 		})
@@ -154,7 +192,7 @@ func TestChatService_GetChat(t *testing.T) {
 	repository := &db.DB{Chat: mockChat}
 
 	// Создаём экземпляр сервиса чат
-	serviceChat := NewChatService(repository)
+	serviceChat := NewChatService(repository, &fakePublisher{})
 
 	tests := []struct {
 		name    string
@@ -164,6 +202,23 @@ func TestChatService_GetChat(t *testing.T) {
 		want    []entity.Chat
 		wantErr error
 	}{
+		{
+			name: "Success page",
+			inChat: dto.ChatGet{
+				UserID: &userID,
+				Limit:  10,
+				Offset: 20,
+			},
+			dataDB: entity.ChatGet{
+				UserID: userID,
+				Limit:  10,
+				Offset: 20,
+			},
+			mock: func(s *mockRepo.MockChat, dataDB entity.ChatGet) {
+				s.EXPECT().GetChat(dataDB).Return([]entity.Chat{{Id: 5, Name: "chat_5"}}, nil)
+			},
+			want: []entity.Chat{{Id: 5, Name: "chat_5"}},
+		},
 		{
 			name: "Success one chat",
 			inChat: dto.ChatGet{
@@ -251,13 +306,13 @@ func TestChatService_GetChat(t *testing.T) {
 			},
 			mock:    func(s *mockRepo.MockChat, dataDB entity.ChatGet) {},
 			want:    nil,
-			wantErr: errors.New("user_id is empty"),
+			wantErr: apperr.Validation("user_id is empty"),
 		},
 		{
 			name:    "Nil user",
 			mock:    func(s *mockRepo.MockChat, dataDB entity.ChatGet) {},
 			want:    nil,
-			wantErr: errors.New("user_id is empty"),
+			wantErr: apperr.Validation("user_id is empty"),
 		},
 		{
 			name: "Other error",
@@ -304,7 +359,7 @@ func TestChatService_DeleteChat(t *testing.T) {
 	repository := &db.DB{Chat: mockChat}
 
 	// Создаём экземпляр сервиса чат
-	serviceChat := NewChatService(repository)
+	serviceChat := NewChatService(repository, &fakePublisher{})
 
 	tests := []struct {
 		name    string
@@ -388,7 +443,7 @@ func TestChatService_DeleteChat(t *testing.T) {
 			mock: func(s *mockRepo.MockChat, dataDB entity.ChatDelete) {
 			},
 			want:    nil,
-			wantErr: errors.New("chat_ids is empty"),
+			wantErr: apperr.Validation("chat_ids is empty"),
 		},
 		{
 			name: "Empty userID",
@@ -400,14 +455,14 @@ func TestChatService_DeleteChat(t *testing.T) {
 			},
 			mock:    func(s *mockRepo.MockChat, dataDB entity.ChatDelete) {},
 			want:    nil,
-			wantErr: errors.New("user_id is empty"),
+			wantErr: apperr.Validation("user_id is empty"),
 		},
 		{
 			name: "Nil chat_ids",
 			mock: func(s *mockRepo.MockChat, dataDB entity.ChatDelete) {
 			},
 			want:    nil,
-			wantErr: errors.New("chat_ids is empty"),
+			wantErr: apperr.Validation("chat_ids is empty"),
 		},
 		{
 			name: "Other error",
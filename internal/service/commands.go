@@ -0,0 +1,345 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"service-chat/internal/apperr"
+	"service-chat/internal/bot"
+	"service-chat/internal/config"
+	"service-chat/internal/db"
+	"service-chat/internal/db/entity"
+	"service-chat/internal/dto"
+	"service-chat/internal/markdown"
+	"service-chat/internal/realtime"
+	"service-chat/internal/webhook"
+)
+
+// commandSecretPrefix - префикс ключа подписи вызовов команды внешнего бота
+const commandSecretPrefix = "cmdsec_"
+
+var (
+	// commandRe - сообщение-команда: /name, затем пробел и аргументы или конец текста
+	commandRe = regexp.MustCompile(`^/([A-Za-z][A-Za-z0-9_-]{0,31})(?:\s+([\s\S]*))?$`)
+	// commandNameRe - допустимое имя команды
+	commandNameRe = regexp.MustCompile(`^[a-z][a-z0-9_-]{0,31}$`)
+)
+
+// CommandCall - вызов команды участником чата
+type CommandCall struct {
+	// Name - имя команды без косой черты в нижнем регистре
+	Name string
+	// Args - текст после имени команды
+	Args   string
+	ChatID int64
+	UserID int64
+	// Format - формат исходного сообщения
+	Format string
+}
+
+// CommandHandler - интерфейс обработчика команды, который работает внутри сервиса.
+// Reply.Text видит только вызвавший команду, сообщения в чат обработчик отправляет сам
+type CommandHandler interface {
+	HandleCommand(ctx context.Context, call CommandCall) (*entity.CommandReply, error)
+}
+
+// CommandFunc - функция как обработчик команды
+type CommandFunc func(ctx context.Context, call CommandCall) (*entity.CommandReply, error)
+
+// HandleCommand - вызываем функцию
+func (f CommandFunc) HandleCommand(ctx context.Context, call CommandCall) (*entity.CommandReply, error) {
+	return f(ctx, call)
+}
+
+// registeredCommand - встроенная команда с описанием для /help
+type registeredCommand struct {
+	description string
+	handler     CommandHandler
+}
+
+// CommandRegistry - встроенные команды, доступные во всех чатах. Команды внешних ботов создаются в чатах
+// и хранятся в бд, встроенная команда с тем же именем важнее
+type CommandRegistry struct {
+	mu       sync.RWMutex
+	commands map[string]registeredCommand
+}
+
+// NewCommandRegistry - конструктор пустого списка встроенных команд
+func NewCommandRegistry() *CommandRegistry {
+	return &CommandRegistry{commands: make(map[string]registeredCommand)}
+}
+
+// Register - регистрируем встроенную команду. Как и http.HandleFunc, паникует при недопустимом имени
+// или повторной регистрации: это ошибка в коде сервиса, а не во входных данных
+func (r *CommandRegistry) Register(name, description string, handler CommandHandler) {
+	if !commandNameRe.MatchString(name) {
+		panic(fmt.Sprintf("service: invalid command name %q", name))
+	}
+	if handler == nil {
+		panic(fmt.Sprintf("service: nil handler for command /%s", name))
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.commands[name]; ok {
+		panic(fmt.Sprintf("service: command /%s registered twice", name))
+	}
+	r.commands[name] = registeredCommand{description: description, handler: handler}
+}
+
+// Lookup - находим обработчик встроенной команды
+func (r *CommandRegistry) Lookup(name string) (CommandHandler, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	command, ok := r.commands[name]
+	return command.handler, ok
+}
+
+// Commands - встроенные команды с описаниями, отсортированные по имени
+func (r *CommandRegistry) Commands() []entity.ChatCommand {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	commands := make([]entity.ChatCommand, 0, len(r.commands))
+	for name, command := range r.commands {
+		commands = append(commands, entity.ChatCommand{Command: name, Description: command.description})
+	}
+	sort.Slice(commands, func(i, j int) bool { return commands[i].Command < commands[j].Command })
+	return commands
+}
+
+// CommandService - команды чатов: встроенные обработчики и внешние боты, которых вызываем по http
+type CommandService struct {
+	repo     db.Command
+	registry *CommandRegistry
+	caller   bot.Caller
+	messages Message
+	events   realtime.Publisher
+	cfg      config.Commands
+	// now - текущее время, подменяется в тестах
+	now func() time.Time
+}
+
+// NewCommandService - конструктор сервиса команд, встроенная команда /help регистрируется сразу
+func NewCommandService(repo db.Command, caller bot.Caller, messages Message, events realtime.Publisher,
+	cfg config.Commands) *CommandService {
+	s := &CommandService{
+		repo:     repo,
+		registry: NewCommandRegistry(),
+		caller:   caller,
+		messages: messages,
+		events:   events,
+		cfg:      cfg,
+		now:      time.Now,
+	}
+	s.registry.Register("help", "Show commands available in this chat", CommandFunc(s.help))
+	return s
+}
+
+// AddCommand - создаём команду чата, которую обрабатывает внешний бот, доступно администраторам чата.
+// Ключ подписи вызовов возвращается только в ответе на создание
+func (s *CommandService) AddCommand(in dto.CommandAdd, chatID int64, userID int) (*entity.ChatCommand, error) {
+	// Если запрос пустой
+	if chatID == 0 || userID == 0 {
+		return nil, apperr.Validation("empty chat_id or user_id")
+	}
+
+	name := strings.TrimPrefix(in.Command, "/")
+	if !commandNameRe.MatchString(name) {
+		return nil, apperr.Validation("invalid command name, use lowercase latin letters, digits, _ and -")
+	}
+	if _, ok := s.registry.Lookup(name); ok {
+		return nil, apperr.Conflict(fmt.Sprintf("command /%s is built in", name))
+	}
+	if err := webhook.ValidateURL(in.URL); err != nil {
+		return nil, apperr.Validation("command url must be an absolute http or https url without credentials")
+	}
+
+	secret, err := randomHex(32)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate command secret: %w", err)
+	}
+
+	// Имя служебного пользователя содержит @, поэтому не совпадёт с именем обычного пользователя
+	suffix, err := randomHex(4)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate bot name: %w", err)
+	}
+
+	dataDB := entity.CommandAdd{
+		ChatID:      chatID,
+		UserID:      userID,
+		Command:     name,
+		Description: in.Description,
+		URL:         in.URL,
+		Secret:      commandSecretPrefix + secret,
+		BotName:     name + "@bot-" + suffix,
+		MaxPerChat:  s.cfg.MaxPerChat,
+	}
+	command, err := s.repo.AddCommand(dataDB)
+	if err != nil {
+		return nil, err
+	}
+
+	command.Secret = dataDB.Secret
+	return command, nil
+}
+
+// GetCommands - получаем команды внешних ботов чата, доступно участникам чата
+func (s *CommandService) GetCommands(chatID int64, userID int) ([]entity.ChatCommand, error) {
+	// Если запрос пустой
+	if chatID == 0 || userID == 0 {
+		return nil, apperr.Validation("empty chat_id or user_id")
+	}
+
+	dataDB := entity.CommandGet{
+		ChatID: chatID,
+		UserID: userID,
+	}
+	return s.repo.GetCommands(dataDB)
+}
+
+// DeleteCommand - удаляем команду чата, сообщения бота остаются в чате
+func (s *CommandService) DeleteCommand(commandID, chatID int64, userID int) error {
+	// Если запрос пустой
+	if commandID == 0 || chatID == 0 || userID == 0 {
+		return apperr.Validation("empty command_id, chat_id or user_id")
+	}
+
+	dataDB := entity.CommandDel{
+		ID:     commandID,
+		ChatID: chatID,
+		UserID: userID,
+	}
+	return s.repo.DeleteCommand(dataDB)
+}
+
+// ExecuteCommand - выполняем команду из сообщения участника чата. Само сообщение в чат не отправляется,
+// ответ команды получает только её автор: в ответе на запрос и событием в его подключения
+func (s *CommandService) ExecuteCommand(in dto.MessageAdd) (*entity.CommandReply, error) {
+	name, args, ok := parseCommand(in.Text)
+	if !ok {
+		return nil, apperr.Validation("not a command")
+	}
+	call := CommandCall{Name: name, Args: args, ChatID: in.ChatID, UserID: in.UserID, Format: in.Format}
+
+	ctx, cancel := context.WithTimeout(context.Background(), s.cfg.Timeout)
+	defer cancel()
+
+	var reply *entity.CommandReply
+	var err error
+	if handler, builtin := s.registry.Lookup(name); builtin {
+		reply, err = handler.HandleCommand(ctx, call)
+	} else {
+		reply, err = s.callBot(ctx, call)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if reply == nil {
+		reply = &entity.CommandReply{}
+	}
+	reply.Command = name
+	reply.HTML = ""
+	if reply.Format == entity.FormatMarkdown {
+		reply.HTML = markdown.ToHTML(reply.Text)
+	}
+
+	if reply.Text != "" {
+		s.events.Publish(realtime.Event{
+			Type:     realtime.EventCommandReply,
+			ChatID:   in.ChatID,
+			ToUserID: in.UserID,
+			Data:     realtime.CommandPayload{Command: name, Text: reply.Text, Format: reply.Format, HTML: reply.HTML},
+		})
+	}
+
+	return reply, nil
+}
+
+// callBot - вызываем внешнего бота команды чата. Ответ in_channel бот отправляет в чат от своего имени
+func (s *CommandService) callBot(ctx context.Context, call CommandCall) (*entity.CommandReply, error) {
+	dataDB := entity.CommandFind{
+		ChatID:  call.ChatID,
+		UserID:  int(call.UserID),
+		Command: call.Name,
+	}
+	command, err := s.repo.FindCommand(dataDB)
+	if err != nil {
+		return nil, err
+	}
+	if command == nil {
+		return &entity.CommandReply{Text: fmt.Sprintf("Unknown command /%s, type /help to see available commands", call.Name)}, nil
+	}
+
+	reply, err := s.caller.Call(ctx, bot.Request{
+		URL:    command.URL,
+		Secret: command.Secret,
+		Call: bot.Call{
+			Command:   call.Name,
+			Text:      call.Args,
+			ChatID:    call.ChatID,
+			UserID:    call.UserID,
+			Timestamp: s.now().Unix(),
+		},
+	})
+	if err != nil {
+		return nil, bot.ErrNoResponse
+	}
+	if reply == nil || strings.TrimSpace(reply.Text) == "" {
+		return nil, nil
+	}
+
+	if reply.ResponseType == bot.ResponseInChannel {
+		messageID, _, errAdd := s.messages.AddMessage(dto.MessageAdd{
+			ChatID:       call.ChatID,
+			UserID:       command.BotID,
+			Text:         reply.Text,
+			Format:       reply.Format,
+			SkipCommands: true,
+		})
+		if errAdd != nil {
+			return nil, errAdd
+		}
+		return &entity.CommandReply{MessageID: int64(messageID)}, nil
+	}
+
+	return &entity.CommandReply{Text: reply.Text, Format: reply.Format}, nil
+}
+
+// help - список встроенных команд и команд внешних ботов чата
+func (s *CommandService) help(_ context.Context, call CommandCall) (*entity.CommandReply, error) {
+	custom, err := s.GetCommands(call.ChatID, int(call.UserID))
+	if err != nil {
+		return nil, err
+	}
+
+	var b strings.Builder
+	b.WriteString("**Commands**\n")
+	for _, command := range append(s.registry.Commands(), custom...) {
+		b.WriteString("\n- `/" + command.Command + "`")
+		if command.Description != "" {
+			b.WriteString(" - " + command.Description)
+		}
+	}
+	b.WriteString("\n\nStart a message with `//` to send text beginning with `/`")
+
+	return &entity.CommandReply{Text: b.String(), Format: entity.FormatMarkdown}, nil
+}
+
+// parseCommand - имя команды в нижнем регистре и аргументы, если сообщение является командой
+func parseCommand(text string) (string, string, bool) {
+	match := commandRe.FindStringSubmatch(text)
+	if match == nil {
+		return "", "", false
+	}
+	return strings.ToLower(match[1]), strings.TrimSpace(match[2]), true
+}
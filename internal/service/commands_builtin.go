@@ -0,0 +1,130 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"service-chat/internal/db/entity"
+	"service-chat/internal/dto"
+)
+
+const (
+	remindUsage = "Usage: `/remind <when> <text>`, when is a duration like `30m`, `2h`, `1d` or an RFC3339 time"
+	pollUsage   = "Usage: `/poll \"Question\" \"Option 1\" \"Option 2\"`, from 2 to 10 options"
+)
+
+// registerBuiltinCommands - встроенные команды, которые работают через сервисы сообщений
+func registerBuiltinCommands(registry *CommandRegistry, scheduled Scheduled, polls Poll) {
+	registry.Register("remind", "Post a reminder to this chat later: /remind 30m stand-up",
+		&remindCommand{scheduled: scheduled, now: time.Now})
+	registry.Register("poll", `Start a poll: /poll "Question" "Option 1" "Option 2"`,
+		&pollCommand{polls: polls})
+}
+
+// remindCommand - /remind <когда> <текст>: отложенное сообщение в чат от имени автора команды
+type remindCommand struct {
+	scheduled Scheduled
+	// now - текущее время, подменяется в тестах
+	now func() time.Time
+}
+
+// HandleCommand - планируем напоминание, при неверных аргументах автор получает подсказку
+func (c *remindCommand) HandleCommand(_ context.Context, call CommandCall) (*entity.CommandReply, error) {
+	when, text, _ := strings.Cut(call.Args, " ")
+	if when == "in" {
+		when, text, _ = strings.Cut(strings.TrimSpace(text), " ")
+	}
+	text = strings.TrimSpace(text)
+
+	sendAt, ok := parseRemindTime(when, c.now())
+	if !ok || text == "" {
+		return &entity.CommandReply{Text: remindUsage, Format: entity.FormatMarkdown}, nil
+	}
+
+	id, err := c.scheduled.ScheduleMessage(dto.MessageAdd{
+		ChatID: call.ChatID,
+		UserID: call.UserID,
+		Text:   "⏰ Reminder: " + text,
+		Format: call.Format,
+		SendAt: &sendAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.CommandReply{
+		Text: fmt.Sprintf("Reminder scheduled for %s, id: %d", sendAt.UTC().Format(time.RFC3339), id),
+	}, nil
+}
+
+// parseRemindTime - время напоминания: длительность от текущего момента (30m, 2h, 1d) или время в RFC3339
+func parseRemindTime(when string, now time.Time) (time.Time, bool) {
+	if days, ok := strings.CutSuffix(when, "d"); ok {
+		if n, err := strconv.Atoi(days); err == nil && n > 0 {
+			return now.AddDate(0, 0, n), true
+		}
+	}
+	if d, err := time.ParseDuration(when); err == nil && d > 0 {
+		return now.Add(d), true
+	}
+	if t, err := time.Parse(time.RFC3339, when); err == nil {
+		return t, true
+	}
+	return time.Time{}, false
+}
+
+// pollCommand - /poll "вопрос" "вариант" "вариант": опрос с одним ответом от имени автора команды
+type pollCommand struct {
+	polls Poll
+}
+
+// HandleCommand - создаём опрос в чате, при неверных аргументах автор получает подсказку
+func (c *pollCommand) HandleCommand(_ context.Context, call CommandCall) (*entity.CommandReply, error) {
+	args := splitArgs(call.Args)
+	if len(args) < minPollOptions+1 || len(args) > maxPollOptions+1 || slices.ContainsFunc(args, func(arg string) bool {
+		return strings.TrimSpace(arg) == ""
+	}) {
+		return &entity.CommandReply{Text: pollUsage, Format: entity.FormatMarkdown}, nil
+	}
+
+	messageID, err := c.polls.CreatePoll(dto.PollAdd{Question: args[0], Options: args[1:]}, call.ChatID, int(call.UserID))
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.CommandReply{MessageID: int64(messageID)}, nil
+}
+
+// splitArgs - разбиваем аргументы команды по пробелам, текст в двойных кавычках остаётся одним аргументом
+func splitArgs(s string) []string {
+	var args []string
+	var current strings.Builder
+	inQuotes, hasArg := false, false
+
+	for _, r := range s {
+		switch {
+		case r == '"':
+			inQuotes = !inQuotes
+			hasArg = true
+		case unicode.IsSpace(r) && !inQuotes:
+			if hasArg {
+				args = append(args, current.String())
+				current.Reset()
+				hasArg = false
+			}
+		default:
+			current.WriteRune(r)
+			hasArg = true
+		}
+	}
+	if hasArg {
+		args = append(args, current.String())
+	}
+
+	return args
+}
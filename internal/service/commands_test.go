@@ -0,0 +1,314 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"service-chat/internal/bot"
+	"service-chat/internal/config"
+	"service-chat/internal/db/entity"
+	mockRepo "service-chat/internal/db/mocks"
+	"service-chat/internal/dto"
+	"service-chat/internal/realtime"
+	mockService "service-chat/internal/service/mocks"
+)
+
+// fakeCaller - внешний бот с заданным ответом
+type fakeCaller struct {
+	reply    *bot.Reply
+	err      error
+	requests []bot.Request
+}
+
+func (f *fakeCaller) Call(_ context.Context, req bot.Request) (*bot.Reply, error) {
+	f.requests = append(f.requests, req)
+	return f.reply, f.err
+}
+
+func TestCommandRegistry(t *testing.T) {
+	registry := NewCommandRegistry()
+	echo := CommandFunc(func(_ context.Context, call CommandCall) (*entity.CommandReply, error) {
+		return &entity.CommandReply{Text: call.Args}, nil
+	})
+	registry.Register("echo", "Repeat the text", echo)
+	registry.Register("ask", "", echo)
+
+	handler, ok := registry.Lookup("echo")
+	assert.True(t, ok)
+	reply, err := handler.HandleCommand(context.Background(), CommandCall{Args: "hi"})
+	assert.NoError(t, err)
+	assert.Equal(t, "hi", reply.Text)
+
+	_, ok = registry.Lookup("missing")
+	assert.False(t, ok)
+
+	assert.Equal(t, []entity.ChatCommand{{Command: "ask"}, {Command: "echo", Description: "Repeat the text"}}, registry.Commands())
+
+	// Ошибки регистрации - ошибки в коде сервиса
+	assert.Panics(t, func() { registry.Register("echo", "", echo) })
+	assert.Panics(t, func() { registry.Register("Echo!", "", echo) })
+	assert.Panics(t, func() { registry.Register("nil", "", nil) })
+}
+
+func TestCommandService_AddCommand(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCommand := mockRepo.NewMockCommand(ctrl)
+	serviceCommand := NewCommandService(mockCommand, &fakeCaller{}, mockService.NewMockMessage(ctrl), &fakePublisher{},
+		config.Commands{MaxPerChat: 20, Timeout: time.Second})
+
+	// Ключ подписи и служебный пользователь создаются сервисом
+	mockCommand.EXPECT().AddCommand(gomock.Any()).DoAndReturn(func(in entity.CommandAdd) (*entity.ChatCommand, error) {
+		assert.Equal(t, int64(2), in.ChatID)
+		assert.Equal(t, 1, in.UserID)
+		assert.Equal(t, "deploy", in.Command)
+		assert.Equal(t, "https://bot.example.com/deploy", in.URL)
+		assert.Equal(t, 20, in.MaxPerChat)
+		assert.True(t, strings.HasPrefix(in.Secret, "cmdsec_"))
+		assert.True(t, strings.HasPrefix(in.BotName, "deploy@bot-"))
+		return &entity.ChatCommand{ID: 3, ChatID: in.ChatID, Command: in.Command, URL: in.URL}, nil
+	})
+	command, err := serviceCommand.AddCommand(dto.CommandAdd{Command: "/deploy", URL: "https://bot.example.com/deploy"}, 2, 1)
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(command.Secret, "cmdsec_"))
+
+	// Неверные запросы не доходят до бд
+	_, err = serviceCommand.AddCommand(dto.CommandAdd{Command: "help", URL: "https://bot.example.com/help"}, 2, 1)
+	assert.EqualError(t, err, "command /help is built in")
+	_, err = serviceCommand.AddCommand(dto.CommandAdd{Command: "Deploy now", URL: "https://bot.example.com/deploy"}, 2, 1)
+	assert.EqualError(t, err, "invalid command name, use lowercase latin letters, digits, _ and -")
+	_, err = serviceCommand.AddCommand(dto.CommandAdd{Command: "deploy", URL: "ftp://bot.example.com/"}, 2, 1)
+	assert.EqualError(t, err, "command url must be an absolute http or https url without credentials")
+}
+
+func TestCommandService_ExecuteCommand(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCommand := mockRepo.NewMockCommand(ctrl)
+	mockMessage := mockService.NewMockMessage(ctrl)
+	caller := &fakeCaller{}
+	events := &fakePublisher{}
+	serviceCommand := NewCommandService(mockCommand, caller, mockMessage, events, config.Commands{Timeout: time.Second})
+	serviceCommand.now = func() time.Time { return time.Unix(1700000000, 0) }
+
+	deploy := &entity.ChatCommand{ID: 3, ChatID: 2, BotID: 9, Command: "deploy", URL: "https://bot.example.com/deploy", Secret: "cmdsec_1"}
+
+	t.Run("Help", func(t *testing.T) {
+		mockCommand.EXPECT().GetCommands(entity.CommandGet{ChatID: 2, UserID: 1}).
+			Return([]entity.ChatCommand{{Command: "deploy", Description: "Deploy a branch"}}, nil)
+
+		reply, err := serviceCommand.ExecuteCommand(dto.MessageAdd{ChatID: 2, UserID: 1, Text: "/HELP"})
+		assert.NoError(t, err)
+		assert.Equal(t, "help", reply.Command)
+		assert.Equal(t, entity.FormatMarkdown, reply.Format)
+		assert.Contains(t, reply.Text, "- `/help` - Show commands available in this chat")
+		assert.Contains(t, reply.Text, "- `/deploy` - Deploy a branch")
+		assert.NotEmpty(t, reply.HTML)
+
+		// Ответ получает только автор команды
+		last := events.events[len(events.events)-1]
+		assert.Equal(t, realtime.EventCommandReply, last.Type)
+		assert.Equal(t, int64(2), last.ChatID)
+		assert.Equal(t, int64(1), last.ToUserID)
+	})
+
+	t.Run("Unknown command", func(t *testing.T) {
+		mockCommand.EXPECT().FindCommand(entity.CommandFind{ChatID: 2, UserID: 1, Command: "nope"}).Return(nil, nil)
+
+		reply, err := serviceCommand.ExecuteCommand(dto.MessageAdd{ChatID: 2, UserID: 1, Text: "/nope"})
+		assert.NoError(t, err)
+		assert.Equal(t, &entity.CommandReply{Command: "nope", Text: "Unknown command /nope, type /help to see available commands"}, reply)
+	})
+
+	t.Run("Not a member", func(t *testing.T) {
+		mockCommand.EXPECT().FindCommand(entity.CommandFind{ChatID: 2, UserID: 5, Command: "deploy"}).
+			Return(nil, errors.New("User with userID 5 does not exist in chatID 2"))
+
+		_, err := serviceCommand.ExecuteCommand(dto.MessageAdd{ChatID: 2, UserID: 5, Text: "/deploy main"})
+		assert.EqualError(t, err, "User with userID 5 does not exist in chatID 2")
+	})
+
+	t.Run("Bot ephemeral reply", func(t *testing.T) {
+		caller.reply, caller.err = &bot.Reply{Text: "Deploying `main`", Format: "markdown"}, nil
+		mockCommand.EXPECT().FindCommand(entity.CommandFind{ChatID: 2, UserID: 1, Command: "deploy"}).Return(deploy, nil)
+
+		reply, err := serviceCommand.ExecuteCommand(dto.MessageAdd{ChatID: 2, UserID: 1, Text: "/deploy  main --force"})
+		assert.NoError(t, err)
+		assert.Equal(t, &entity.CommandReply{Command: "deploy", Text: "Deploying `main`", Format: "markdown",
+			HTML: "<p>Deploying <code>main</code></p>"}, reply)
+
+		assert.Equal(t, bot.Request{
+			URL:    "https://bot.example.com/deploy",
+			Secret: "cmdsec_1",
+			Call:   bot.Call{Command: "deploy", Text: "main --force", ChatID: 2, UserID: 1, Timestamp: 1700000000},
+		}, caller.requests[len(caller.requests)-1])
+	})
+
+	t.Run("Bot reply in channel", func(t *testing.T) {
+		caller.reply, caller.err = &bot.Reply{Text: "/deploy started", ResponseType: bot.ResponseInChannel}, nil
+		mockCommand.EXPECT().FindCommand(gomock.Any()).Return(deploy, nil)
+		count := len(events.events)
+
+		// Ответ бота пишет его служебный пользователь, текст с / не выполняется как команда
+		mockMessage.EXPECT().AddMessage(dto.MessageAdd{ChatID: 2, UserID: 9, Text: "/deploy started", SkipCommands: true}).
+			Return(11, nil, nil)
+
+		reply, err := serviceCommand.ExecuteCommand(dto.MessageAdd{ChatID: 2, UserID: 1, Text: "/deploy main"})
+		assert.NoError(t, err)
+		assert.Equal(t, &entity.CommandReply{Command: "deploy", MessageID: 11}, reply)
+		assert.Len(t, events.events, count)
+	})
+
+	t.Run("Bot failed", func(t *testing.T) {
+		caller.reply, caller.err = nil, errors.New("dial tcp 10.0.0.1:443: forbidden address")
+		mockCommand.EXPECT().FindCommand(gomock.Any()).Return(deploy, nil)
+
+		_, err := serviceCommand.ExecuteCommand(dto.MessageAdd{ChatID: 2, UserID: 1, Text: "/deploy main"})
+		assert.ErrorIs(t, err, bot.ErrNoResponse)
+	})
+}
+
+func TestMessageService_AddMessageCommand(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockMessage := mockRepo.NewMockMessage(ctrl)
+	mockPreview := mockService.NewMockPreview(ctrl)
+	mockCommand := mockService.NewMockCommand(ctrl)
+	serviceMessage := NewMessageService(mockMessage, config.Messages{}, mockPreview, &fakePublisher{})
+	serviceMessage.commands = mockCommand
+
+	// Команда не попадает в чат
+	in := dto.MessageAdd{ChatID: 2, UserID: 1, Text: "/poll \"Lunch?\" Pizza Sushi"}
+	mockCommand.EXPECT().ExecuteCommand(in).Return(&entity.CommandReply{Command: "poll", MessageID: 12}, nil)
+	id, reply, err := serviceMessage.AddMessage(in)
+	assert.NoError(t, err)
+	assert.Equal(t, 12, id)
+	assert.Equal(t, "poll", reply.Command)
+
+	// Текст с // отправляется без первой косой черты, путь к файлу командой не считается
+	for text, want := range map[string]string{"//help is here": "/help is here", "/usr/bin is here": "/usr/bin is here"} {
+		mockMessage.EXPECT().AddMessage(entity.MessageAdd{ChatID: 2, UserID: 1, Text: want}).Return(7, nil)
+		mockPreview.EXPECT().Enqueue(int64(7), want)
+		id, reply, err = serviceMessage.AddMessage(dto.MessageAdd{ChatID: 2, UserID: 1, Text: text})
+		assert.NoError(t, err)
+		assert.Equal(t, 7, id)
+		assert.Nil(t, reply)
+	}
+
+	// Сообщения ботов отправляются как есть
+	mockMessage.EXPECT().AddMessage(entity.MessageAdd{ChatID: 2, UserID: 9, Text: "/help"}).Return(8, nil)
+	mockPreview.EXPECT().Enqueue(int64(8), "/help")
+	_, reply, err = serviceMessage.AddMessage(dto.MessageAdd{ChatID: 2, UserID: 9, Text: "/help", SkipCommands: true})
+	assert.NoError(t, err)
+	assert.Nil(t, reply)
+}
+
+func TestRemindCommand(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockScheduled := mockService.NewMockScheduled(ctrl)
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	remind := &remindCommand{scheduled: mockScheduled, now: func() time.Time { return now }}
+
+	sendAt := now.Add(30 * time.Minute)
+	mockScheduled.EXPECT().ScheduleMessage(dto.MessageAdd{ChatID: 2, UserID: 1, Text: "⏰ Reminder: stand-up", SendAt: &sendAt}).
+		Return(int64(5), nil)
+	reply, err := remind.HandleCommand(context.Background(), CommandCall{Name: "remind", Args: "in 30m stand-up", ChatID: 2, UserID: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, "Reminder scheduled for 2024-01-01T00:30:00Z, id: 5", reply.Text)
+
+	// Неверные аргументы - подсказка вместо ошибки
+	for _, args := range []string{"", "30m", "tomorrow stand-up", "-5m stand-up"} {
+		reply, err = remind.HandleCommand(context.Background(), CommandCall{Name: "remind", Args: args, ChatID: 2, UserID: 1})
+		assert.NoError(t, err)
+		assert.Equal(t, remindUsage, reply.Text, args)
+	}
+}
+
+func TestParseRemindTime(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		when   string
+		want   time.Time
+		wantOK bool
+	}{
+		{when: "90s", want: now.Add(90 * time.Second), wantOK: true},
+		{when: "2h30m", want: now.Add(150 * time.Minute), wantOK: true},
+		{when: "3d", want: time.Date(2024, 1, 4, 0, 0, 0, 0, time.UTC), wantOK: true},
+		{when: "2024-02-01T09:00:00+03:00", want: time.Date(2024, 2, 1, 6, 0, 0, 0, time.UTC), wantOK: true},
+		{when: "0d"},
+		{when: "0s"},
+		{when: "soon"},
+	}
+
+	for _, tt := range tests {
+		got, ok := parseRemindTime(tt.when, now)
+		assert.Equal(t, tt.wantOK, ok, tt.when)
+		if tt.wantOK {
+			assert.True(t, tt.want.Equal(got), "%s: %s", tt.when, got)
+		}
+	}
+}
+
+func TestPollCommand(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPoll := mockService.NewMockPoll(ctrl)
+	poll := &pollCommand{polls: mockPoll}
+
+	mockPoll.EXPECT().CreatePoll(dto.PollAdd{
+		Question: "Where to lunch?",
+		Options:  []string{"Pizza place", "Sushi"},
+	}, int64(2), 1).Return(12, nil)
+	reply, err := poll.HandleCommand(context.Background(), CommandCall{Name: "poll", Args: `"Where to lunch?" "Pizza place" Sushi`, ChatID: 2, UserID: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, &entity.CommandReply{MessageID: 12}, reply)
+
+	for _, args := range []string{"", `"Only question" "One option"`, `"Q" "" "B"`} {
+		reply, err = poll.HandleCommand(context.Background(), CommandCall{Name: "poll", Args: args, ChatID: 2, UserID: 1})
+		assert.NoError(t, err)
+		assert.Equal(t, pollUsage, reply.Text, args)
+	}
+}
+
+func TestParseCommand(t *testing.T) {
+	tests := []struct {
+		text     string
+		wantName string
+		wantArgs string
+		wantOK   bool
+	}{
+		{text: "/help", wantName: "help", wantOK: true},
+		{text: "/Remind  30m\nstand-up ", wantName: "remind", wantArgs: "30m\nstand-up", wantOK: true},
+		{text: "/deploy-app main", wantName: "deploy-app", wantArgs: "main", wantOK: true},
+		{text: "//help"},
+		{text: "/usr/bin"},
+		{text: "/ help"},
+		{text: "help"},
+		{text: "/1st"},
+	}
+
+	for _, tt := range tests {
+		name, args, ok := parseCommand(tt.text)
+		assert.Equal(t, tt.wantOK, ok, tt.text)
+		assert.Equal(t, tt.wantName, name, tt.text)
+		assert.Equal(t, tt.wantArgs, args, tt.text)
+	}
+}
+
+func TestSplitArgs(t *testing.T) {
+	assert.Equal(t, []string{"Where to lunch?", "Pizza", "Sushi bar", ""}, splitArgs(`"Where to lunch?"  Pizza "Sushi bar" ""`))
+	assert.Nil(t, splitArgs("   "))
+}
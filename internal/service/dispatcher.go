@@ -0,0 +1,61 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"service-chat/internal/config"
+	"service-chat/internal/logger"
+)
+
+// Dispatcher - фоновая отправка отложенных сообщений. Состояние хранится в бд,
+// поэтому после перезапуска сервиса сообщения, время которых прошло, отправляются при первой проверке
+type Dispatcher struct {
+	scheduled Scheduled
+	interval  time.Duration
+	log       *slog.Logger
+}
+
+// defaultDispatchInterval - интервал проверки, если в конфиге он не задан
+const defaultDispatchInterval = 5 * time.Second
+
+func NewDispatcher(scheduled Scheduled, cfg config.Scheduler, log *slog.Logger) *Dispatcher {
+	interval := cfg.Interval
+	if interval <= 0 {
+		interval = defaultDispatchInterval
+	}
+
+	return &Dispatcher{
+		scheduled: scheduled,
+		interval:  interval,
+		log:       log.With(slog.String("op", "service.Dispatcher")),
+	}
+}
+
+// Run - периодически отправляем отложенные сообщения, пока не будет отменён контекст
+func (d *Dispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+
+	for {
+		d.dispatch()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// dispatch - одна проверка отложенных сообщений
+func (d *Dispatcher) dispatch() {
+	sent, err := d.scheduled.DispatchDue()
+	if err != nil {
+		d.log.Error("failed to dispatch scheduled messages", logger.Err(err))
+	}
+	if sent > 0 {
+		d.log.Info("scheduled messages sent", slog.Int("count", sent))
+	}
+}
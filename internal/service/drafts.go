@@ -0,0 +1,73 @@
+package service
+
+import (
+	"service-chat/internal/apperr"
+	"service-chat/internal/db"
+	"service-chat/internal/db/entity"
+	"service-chat/internal/dto"
+)
+
+type DraftService struct {
+	repo db.Draft
+}
+
+func NewDraftService(repo db.Draft) *DraftService {
+	return &DraftService{repo: repo}
+}
+
+// SaveDraft - сохраняем черновик пользователя в чате. Возвращаем актуальный черновик и признак,
+// применена ли запись: если на другом устройстве уже сохранена более новая версия, она и возвращается
+func (s *DraftService) SaveDraft(in dto.DraftSave, chatID int64, userID int) (*entity.Draft, bool, error) {
+	// Если запрос пустой
+	if chatID == 0 || userID == 0 {
+		return nil, false, apperr.Validation("empty chat_id or user_id")
+	} else if in.Text == "" {
+		return nil, false, apperr.Validation("empty text")
+	} else if in.Version <= 0 {
+		return nil, false, apperr.Validation("invalid version")
+	}
+
+	dataDB := entity.DraftSave{
+		ChatID:  chatID,
+		UserID:  userID,
+		Text:    in.Text,
+		Version: in.Version,
+	}
+	return s.repo.SaveDraft(dataDB)
+}
+
+// GetDraft - получаем черновик пользователя в чате, если черновика нет - возвращаем nil
+func (s *DraftService) GetDraft(chatID int64, userID int) (*entity.Draft, error) {
+	// Если запрос пустой
+	if chatID == 0 || userID == 0 {
+		return nil, apperr.Validation("empty chat_id or user_id")
+	}
+
+	draft, err := s.repo.GetDraft(entity.DraftGet{ChatID: chatID, UserID: userID})
+	if err != nil {
+		return nil, err
+	}
+
+	// Удалённый черновик хранится с пустым текстом, чтобы удаление тоже участвовало в сравнении версий
+	if draft == nil || draft.Text == "" {
+		return nil, nil
+	}
+	return draft, nil
+}
+
+// DeleteDraft - удаляем черновик пользователя в чате с той же семантикой версий, что и при сохранении
+func (s *DraftService) DeleteDraft(in dto.DraftDelete, chatID int64, userID int) (*entity.Draft, bool, error) {
+	// Если запрос пустой
+	if chatID == 0 || userID == 0 {
+		return nil, false, apperr.Validation("empty chat_id or user_id")
+	} else if in.Version <= 0 {
+		return nil, false, apperr.Validation("invalid version")
+	}
+
+	dataDB := entity.DraftSave{
+		ChatID:  chatID,
+		UserID:  userID,
+		Version: in.Version,
+	}
+	return s.repo.SaveDraft(dataDB)
+}
@@ -0,0 +1,264 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"service-chat/internal/apperr"
+	"service-chat/internal/db/entity"
+	mockRepo "service-chat/internal/db/mocks"
+	"service-chat/internal/dto"
+)
+
+func TestDraftService_SaveDraft(t *testing.T) {
+	// Структура для последующей реализации поведения мока
+	type mockBehaviour func(s *mockRepo.MockDraft, dataDB entity.DraftSave)
+
+	//Инициализируем контролер для мока сервиса
+	ctrl := gomock.NewController(t)
+	// Завершаем работу контролера после выполнения каждого теста
+	defer ctrl.Finish()
+
+	// Создаём моки базы данных черновиков
+	mockDraft := mockRepo.NewMockDraft(ctrl)
+
+	// Создаём экземпляр сервиса черновиков
+	serviceDraft := NewDraftService(mockDraft)
+
+	tests := []struct {
+		name        string
+		in          dto.DraftSave
+		chatID      int64
+		userID      int
+		dataDB      entity.DraftSave
+		mock        mockBehaviour
+		want        *entity.Draft
+		wantApplied bool
+		wantErr     error
+	}{
+		{
+			name:   "Success",
+			in:     dto.DraftSave{Text: "hello", Version: 2},
+			chatID: 1,
+			userID: 1,
+			dataDB: entity.DraftSave{ChatID: 1, UserID: 1, Text: "hello", Version: 2},
+			mock: func(s *mockRepo.MockDraft, dataDB entity.DraftSave) {
+				s.EXPECT().SaveDraft(dataDB).Return(&entity.Draft{ChatID: 1, Text: "hello", Version: 2}, true, nil)
+			},
+			want:        &entity.Draft{ChatID: 1, Text: "hello", Version: 2},
+			wantApplied: true,
+		},
+		{
+			name:   "Outdated version",
+			in:     dto.DraftSave{Text: "old", Version: 1},
+			chatID: 1,
+			userID: 1,
+			dataDB: entity.DraftSave{ChatID: 1, UserID: 1, Text: "old", Version: 1},
+			mock: func(s *mockRepo.MockDraft, dataDB entity.DraftSave) {
+				s.EXPECT().SaveDraft(dataDB).Return(&entity.Draft{ChatID: 1, Text: "new", Version: 3}, false, nil)
+			},
+			want: &entity.Draft{ChatID: 1, Text: "new", Version: 3},
+		},
+		{
+			name:    "Empty chat_id",
+			in:      dto.DraftSave{Text: "hello", Version: 1},
+			userID:  1,
+			mock:    func(s *mockRepo.MockDraft, dataDB entity.DraftSave) {},
+			wantErr: apperr.Validation("empty chat_id or user_id"),
+		},
+		{
+			name:    "Empty text",
+			in:      dto.DraftSave{Version: 1},
+			chatID:  1,
+			userID:  1,
+			mock:    func(s *mockRepo.MockDraft, dataDB entity.DraftSave) {},
+			wantErr: apperr.Validation("empty text"),
+		},
+		{
+			name:    "Invalid version",
+			in:      dto.DraftSave{Text: "hello"},
+			chatID:  1,
+			userID:  1,
+			mock:    func(s *mockRepo.MockDraft, dataDB entity.DraftSave) {},
+			wantErr: apperr.Validation("invalid version"),
+		},
+		{
+			name:   "Other error",
+			in:     dto.DraftSave{Text: "hello", Version: 2},
+			chatID: 1,
+			userID: 1,
+			dataDB: entity.DraftSave{ChatID: 1, UserID: 1, Text: "hello", Version: 2},
+			mock: func(s *mockRepo.MockDraft, dataDB entity.DraftSave) {
+				s.EXPECT().SaveDraft(dataDB).Return(nil, false, errors.New("other error"))
+			},
+			wantErr: errors.New("other error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Передаём структуру черновика
+			tt.mock(mockDraft, tt.dataDB)
+
+			// Проверяем ожидаемый и актуальный результат
+			acDraft, acApplied, acErr := serviceDraft.SaveDraft(tt.in, tt.chatID, tt.userID)
+			assert.Equal(t, tt.want, acDraft)
+			assert.Equal(t, tt.wantApplied, acApplied)
+			assert.Equal(t, tt.wantErr, acErr)
+		})
+	}
+}
+
+func TestDraftService_GetDraft(t *testing.T) {
+	// Структура для последующей реализации поведения мока
+	type mockBehaviour func(s *mockRepo.MockDraft, dataDB entity.DraftGet)
+
+	//Инициализируем контролер для мока сервиса
+	ctrl := gomock.NewController(t)
+	// Завершаем работу контролера после выполнения каждого теста
+	defer ctrl.Finish()
+
+	// Создаём моки базы данных черновиков
+	mockDraft := mockRepo.NewMockDraft(ctrl)
+
+	// Создаём экземпляр сервиса черновиков
+	serviceDraft := NewDraftService(mockDraft)
+
+	tests := []struct {
+		name    string
+		chatID  int64
+		userID  int
+		dataDB  entity.DraftGet
+		mock    mockBehaviour
+		want    *entity.Draft
+		wantErr error
+	}{
+		{
+			name:   "Success",
+			chatID: 1,
+			userID: 1,
+			dataDB: entity.DraftGet{ChatID: 1, UserID: 1},
+			mock: func(s *mockRepo.MockDraft, dataDB entity.DraftGet) {
+				s.EXPECT().GetDraft(dataDB).Return(&entity.Draft{ChatID: 1, Text: "hello", Version: 2}, nil)
+			},
+			want: &entity.Draft{ChatID: 1, Text: "hello", Version: 2},
+		},
+		{
+			name:   "Deleted draft",
+			chatID: 1,
+			userID: 1,
+			dataDB: entity.DraftGet{ChatID: 1, UserID: 1},
+			mock: func(s *mockRepo.MockDraft, dataDB entity.DraftGet) {
+				s.EXPECT().GetDraft(dataDB).Return(&entity.Draft{ChatID: 1, Version: 3}, nil)
+			},
+		},
+		{
+			name:   "No draft",
+			chatID: 1,
+			userID: 1,
+			dataDB: entity.DraftGet{ChatID: 1, UserID: 1},
+			mock: func(s *mockRepo.MockDraft, dataDB entity.DraftGet) {
+				s.EXPECT().GetDraft(dataDB).Return(nil, nil)
+			},
+		},
+		{
+			name:    "Empty user_id",
+			chatID:  1,
+			mock:    func(s *mockRepo.MockDraft, dataDB entity.DraftGet) {},
+			wantErr: apperr.Validation("empty chat_id or user_id"),
+		},
+		{
+			name:   "Other error",
+			chatID: 1,
+			userID: 1,
+			dataDB: entity.DraftGet{ChatID: 1, UserID: 1},
+			mock: func(s *mockRepo.MockDraft, dataDB entity.DraftGet) {
+				s.EXPECT().GetDraft(dataDB).Return(nil, errors.New("other error"))
+			},
+			wantErr: errors.New("other error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Передаём структуру черновика
+			tt.mock(mockDraft, tt.dataDB)
+
+			// Проверяем ожидаемый и актуальный результат
+			acDraft, acErr := serviceDraft.GetDraft(tt.chatID, tt.userID)
+			assert.Equal(t, tt.want, acDraft)
+			assert.Equal(t, tt.wantErr, acErr)
+		})
+	}
+}
+
+func TestDraftService_DeleteDraft(t *testing.T) {
+	// Структура для последующей реализации поведения мока
+	type mockBehaviour func(s *mockRepo.MockDraft, dataDB entity.DraftSave)
+
+	//Инициализируем контролер для мока сервиса
+	ctrl := gomock.NewController(t)
+	// Завершаем работу контролера после выполнения каждого теста
+	defer ctrl.Finish()
+
+	// Создаём моки базы данных черновиков
+	mockDraft := mockRepo.NewMockDraft(ctrl)
+
+	// Создаём экземпляр сервиса черновиков
+	serviceDraft := NewDraftService(mockDraft)
+
+	tests := []struct {
+		name        string
+		in          dto.DraftDelete
+		chatID      int64
+		userID      int
+		dataDB      entity.DraftSave
+		mock        mockBehaviour
+		want        *entity.Draft
+		wantApplied bool
+		wantErr     error
+	}{
+		{
+			name:   "Success",
+			in:     dto.DraftDelete{Version: 4},
+			chatID: 1,
+			userID: 1,
+			dataDB: entity.DraftSave{ChatID: 1, UserID: 1, Version: 4},
+			mock: func(s *mockRepo.MockDraft, dataDB entity.DraftSave) {
+				s.EXPECT().SaveDraft(dataDB).Return(&entity.Draft{ChatID: 1, Version: 4}, true, nil)
+			},
+			want:        &entity.Draft{ChatID: 1, Version: 4},
+			wantApplied: true,
+		},
+		{
+			name:    "Invalid version",
+			chatID:  1,
+			userID:  1,
+			mock:    func(s *mockRepo.MockDraft, dataDB entity.DraftSave) {},
+			wantErr: apperr.Validation("invalid version"),
+		},
+		{
+			name:    "Empty chat_id",
+			in:      dto.DraftDelete{Version: 4},
+			userID:  1,
+			mock:    func(s *mockRepo.MockDraft, dataDB entity.DraftSave) {},
+			wantErr: apperr.Validation("empty chat_id or user_id"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Передаём структуру черновика
+			tt.mock(mockDraft, tt.dataDB)
+
+			// Проверяем ожидаемый и актуальный результат
+			acDraft, acApplied, acErr := serviceDraft.DeleteDraft(tt.in, tt.chatID, tt.userID)
+			assert.Equal(t, tt.want, acDraft)
+			assert.Equal(t, tt.wantApplied, acApplied)
+			assert.Equal(t, tt.wantErr, acErr)
+		})
+	}
+}
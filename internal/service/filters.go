@@ -0,0 +1,95 @@
+package service
+
+import (
+	"service-chat/internal/apperr"
+	"service-chat/internal/db"
+	"service-chat/internal/db/entity"
+	"service-chat/internal/dto"
+	"service-chat/internal/filter"
+)
+
+// actionDefault - действие фильтра в запросе, которое возвращает значение из конфига
+const actionDefault = "default"
+
+// FilterService - фильтры текста сообщений с действиями из конфига и настройками чатов
+type FilterService struct {
+	repo  db.Filter
+	chain *filter.Chain
+}
+
+func NewFilterService(repo db.Filter, chain *filter.Chain) *FilterService {
+	return &FilterService{repo: repo, chain: chain}
+}
+
+// CheckText - применяем фильтры к тексту сообщения с учётом настроек чата
+func (s *FilterService) CheckText(chatID int64, text string) (filter.Result, error) {
+	var overrides map[string]string
+	if chatID != 0 {
+		var err error
+		if overrides, err = s.repo.ChatFilterActions(chatID); err != nil {
+			return filter.Result{}, err
+		}
+	}
+
+	return s.chain.Apply(text, overrides)
+}
+
+// GetChatFilters - действия всех фильтров в чате, доступно участникам чата
+func (s *FilterService) GetChatFilters(chatID int64, userID int) ([]entity.ChatFilter, error) {
+	// Если запрос пустой
+	if chatID == 0 || userID == 0 {
+		return nil, apperr.Validation("empty chat_id or user_id")
+	}
+
+	overrides, err := s.repo.GetChatFilters(entity.ChatFiltersGet{ChatID: chatID, UserID: userID})
+	if err != nil {
+		return nil, err
+	}
+
+	rules := s.chain.Rules()
+	filters := make([]entity.ChatFilter, 0, len(rules))
+	for _, rule := range rules {
+		chatFilter := entity.ChatFilter{Filter: rule.Filter.Name(), Action: rule.Action}
+		if action, ok := overrides[chatFilter.Filter]; ok {
+			chatFilter.Action = action
+			chatFilter.Overridden = true
+		}
+		filters = append(filters, chatFilter)
+	}
+
+	return filters, nil
+}
+
+// SetChatFilter - меняем действие фильтра в чате, доступно администраторам чата
+func (s *FilterService) SetChatFilter(in dto.ChatFilterSet, chatID int64, userID int) error {
+	// Если запрос пустой
+	if chatID == 0 || userID == 0 {
+		return apperr.Validation("empty chat_id or user_id")
+	}
+
+	var known bool
+	for _, rule := range s.chain.Rules() {
+		if rule.Filter.Name() == in.Filter {
+			known = true
+			break
+		}
+	}
+	if !known {
+		return apperr.Validation("unknown filter")
+	}
+
+	action := in.Action
+	if action == actionDefault {
+		action = ""
+	} else if !filter.ValidAction(action) {
+		return apperr.Validation("invalid action")
+	}
+
+	dataDB := entity.ChatFilterSet{
+		ChatID: chatID,
+		UserID: userID,
+		Filter: in.Filter,
+		Action: action,
+	}
+	return s.repo.SetChatFilter(dataDB)
+}
@@ -0,0 +1,308 @@
+package service
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"service-chat/internal/apperr"
+	"service-chat/internal/config"
+	"service-chat/internal/db"
+	"service-chat/internal/db/entity"
+	"service-chat/internal/dto"
+)
+
+const (
+	// incomingTokenPrefix - по префиксу токен входящего вебхука легко узнать, например при поиске утёкших ключей
+	incomingTokenPrefix = "ihk_"
+	// incomingURL - адрес, на который внешняя система отправляет сообщения
+	incomingURL = "/hooks/%s"
+	// bucketSweepInterval - как часто удаляем лимиты вебхуков, которые давно не писали
+	bucketSweepInterval = time.Minute
+)
+
+var (
+	// ErrRateLimited - вебхук отправляет сообщения чаще, чем разрешено
+	ErrRateLimited = apperr.New(apperr.CodeRateLimited, "Rate limit exceeded, retry later")
+	// slackLinkRe - ссылки и упоминания Slack mrkdwn: <url>, <url|text>, <!here>, <@U123>, <#C123|general>
+	slackLinkRe = regexp.MustCompile(`<([^<>|\s]+)(?:\|([^<>]*))?>`)
+	// markdownLabel - экранируем квадратные скобки в тексте ссылки markdown
+	markdownLabel = strings.NewReplacer(`[`, `\[`, `]`, `\]`)
+	// slackUnescape - Slack экранирует в тексте только эти три символа
+	slackUnescape = strings.NewReplacer("&lt;", "<", "&gt;", ">", "&amp;", "&")
+)
+
+// tokenBucket - лимит сообщений одного вебхука: tokens пополняются равномерно до Burst, сообщение тратит один
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// IncomingService - входящие вебхуки: внешние системы без учётной записи пишут в чат от имени служебного пользователя
+type IncomingService struct {
+	repo     db.Incoming
+	messages Message
+	cfg      config.Incoming
+	maxText  int
+
+	mu        sync.Mutex
+	buckets   map[string]*tokenBucket
+	lastSweep time.Time
+	// now - текущее время, подменяется в тестах
+	now func() time.Time
+}
+
+func NewIncomingService(repo db.Incoming, messages Message, cfg config.Incoming, msgCfg config.Messages) *IncomingService {
+	return &IncomingService{
+		repo:     repo,
+		messages: messages,
+		cfg:      cfg,
+		maxText:  msgCfg.MaxTextLength,
+		buckets:  make(map[string]*tokenBucket),
+		now:      time.Now,
+	}
+}
+
+// AddIncoming - создаём входящий вебхук чата, доступно администраторам чата. Токен возвращается только в ответе на создание
+func (s *IncomingService) AddIncoming(in dto.IncomingAdd, chatID int64, userID int) (*entity.IncomingWebhook, error) {
+	// Если запрос пустой
+	if chatID == 0 || userID == 0 {
+		return nil, apperr.Validation("empty chat_id or user_id")
+	}
+
+	token, err := randomHex(32)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate webhook token: %w", err)
+	}
+	token = incomingTokenPrefix + token
+
+	// Имя служебного пользователя содержит @, поэтому не совпадёт с именем обычного пользователя
+	suffix, err := randomHex(4)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate bot name: %w", err)
+	}
+
+	dataDB := entity.IncomingAdd{
+		ChatID:     chatID,
+		UserID:     userID,
+		Name:       in.Name,
+		BotName:    in.Name + "@bot-" + suffix,
+		TokenHash:  hashToken(token),
+		MaxPerChat: s.cfg.MaxPerChat,
+	}
+	hook, err := s.repo.AddIncoming(dataDB)
+	if err != nil {
+		return nil, err
+	}
+
+	hook.Token = token
+	hook.URL = fmt.Sprintf(incomingURL, token)
+	return hook, nil
+}
+
+// GetIncoming - получаем входящие вебхуки чата, доступно администраторам чата
+func (s *IncomingService) GetIncoming(chatID int64, userID int) ([]entity.IncomingWebhook, error) {
+	// Если запрос пустой
+	if chatID == 0 || userID == 0 {
+		return nil, apperr.Validation("empty chat_id or user_id")
+	}
+
+	dataDB := entity.IncomingGet{
+		ChatID: chatID,
+		UserID: userID,
+	}
+	return s.repo.GetIncoming(dataDB)
+}
+
+// DeleteIncoming - удаляем входящий вебхук чата, сообщения вебхука остаются в чате
+func (s *IncomingService) DeleteIncoming(webhookID, chatID int64, userID int) error {
+	// Если запрос пустой
+	if webhookID == 0 || chatID == 0 || userID == 0 {
+		return apperr.Validation("empty webhook_id, chat_id or user_id")
+	}
+
+	dataDB := entity.IncomingDel{
+		ID:     webhookID,
+		ChatID: chatID,
+		UserID: userID,
+	}
+	return s.repo.DeleteIncoming(dataDB)
+}
+
+// PostIncoming - отправляем сообщение внешней системы в чат вебхука и возвращаем id сообщения.
+// Сообщение проходит через MessageService.AddMessage, как сообщение обычного участника чата
+func (s *IncomingService) PostIncoming(token string, in dto.IncomingMessage) (int, error) {
+	// Если токена нет
+	if token == "" {
+		return 0, apperr.Validation("empty token")
+	}
+
+	// Лимит проверяем до обращения в бд, чтобы поток запросов не нагружал базу
+	tokenHash := hashToken(token)
+	if !s.allow(tokenHash) {
+		return 0, ErrRateLimited
+	}
+
+	hook, err := s.repo.GetIncomingByToken(tokenHash)
+	if err != nil {
+		return 0, err
+	}
+
+	text, format := incomingText(in)
+	if text == "" {
+		return 0, apperr.Validation("empty text")
+	}
+	if runes := []rune(text); s.maxText > 0 && len(runes) > s.maxText {
+		text = string(runes[:s.maxText-1]) + "…"
+	}
+
+	// Внешние системы не вызывают команды чата, текст с / отправляется как есть
+	messageID, _, err := s.messages.AddMessage(dto.MessageAdd{
+		ChatID:       hook.ChatID,
+		UserID:       hook.BotID,
+		Text:         text,
+		Format:       format,
+		SkipCommands: true,
+	})
+	return messageID, err
+}
+
+// allow - тратим одно сообщение из лимита вебхука, если лимит исчерпан - возвращаем false
+func (s *IncomingService) allow(key string) bool {
+	if s.cfg.RatePerMinute <= 0 {
+		return true
+	}
+	burst := float64(max(s.cfg.Burst, 1))
+	perSecond := float64(s.cfg.RatePerMinute) / 60
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.sweep(now, burst, perSecond)
+
+	bucket, ok := s.buckets[key]
+	if !ok {
+		bucket = &tokenBucket{tokens: burst, last: now}
+		s.buckets[key] = bucket
+	}
+	bucket.tokens = min(burst, bucket.tokens+now.Sub(bucket.last).Seconds()*perSecond)
+	bucket.last = now
+
+	if bucket.tokens < 1 {
+		return false
+	}
+	bucket.tokens--
+	return true
+}
+
+// sweep - удаляем лимиты, которые уже полностью восстановились: для них новый лимит ничем не отличается.
+// Вызывается под s.mu не чаще bucketSweepInterval
+func (s *IncomingService) sweep(now time.Time, burst, perSecond float64) {
+	if now.Sub(s.lastSweep) < bucketSweepInterval {
+		return
+	}
+	s.lastSweep = now
+
+	for key, bucket := range s.buckets {
+		if bucket.tokens+now.Sub(bucket.last).Seconds()*perSecond >= burst {
+			delete(s.buckets, key)
+		}
+	}
+}
+
+// incomingText - текст сообщения из тела запроса. Запрос в формате Slack собираем в markdown:
+// текст, блоки, затем вложения с заголовком, текстом и полями. Если у вложения ничего нет - берём fallback
+func incomingText(in dto.IncomingMessage) (string, string) {
+	if len(in.Blocks) == 0 && len(in.Attachments) == 0 {
+		return strings.TrimSpace(in.Text), in.Format
+	}
+
+	var parts []string
+	add := func(text string) {
+		if text = strings.TrimSpace(slackToMarkdown(text)); text != "" {
+			parts = append(parts, text)
+		}
+	}
+
+	add(in.Text)
+	for _, block := range in.Blocks {
+		if block.Text != nil {
+			add(block.Text.Text)
+		}
+		for _, element := range block.Elements {
+			add(element.Text)
+		}
+	}
+
+	for _, attachment := range in.Attachments {
+		before := len(parts)
+		add(attachment.Pretext)
+		if title := strings.TrimSpace(attachment.Title); title != "" && attachment.TitleLink != "" {
+			parts = append(parts, fmt.Sprintf("**[%s](%s)**", markdownLabel.Replace(title), attachment.TitleLink))
+		} else if title != "" {
+			add("**" + title + "**")
+		}
+		add(attachment.Text)
+		for _, field := range attachment.Fields {
+			if field.Title == "" {
+				add(field.Value)
+				continue
+			}
+			add("**" + field.Title + "**: " + field.Value)
+		}
+		if len(parts) == before {
+			add(attachment.Fallback)
+		}
+	}
+
+	return strings.Join(parts, "\n\n"), entity.FormatMarkdown
+}
+
+// slackToMarkdown - переводим ссылки и упоминания Slack mrkdwn в markdown и снимаем экранирование
+func slackToMarkdown(text string) string {
+	text = slackLinkRe.ReplaceAllStringFunc(text, func(match string) string {
+		parts := slackLinkRe.FindStringSubmatch(match)
+		target, label := parts[1], parts[2]
+
+		switch {
+		case strings.HasPrefix(target, "!"):
+			// Специальные упоминания: <!here>, <!channel>, <!subteam^ID|@team>
+			if label != "" {
+				return label
+			}
+			return "@" + strings.TrimPrefix(target, "!")
+		case strings.HasPrefix(target, "@"), strings.HasPrefix(target, "#"):
+			if label != "" {
+				return target[:1] + strings.TrimPrefix(label, target[:1])
+			}
+			return target
+		case label != "":
+			return fmt.Sprintf("[%s](%s)", markdownLabel.Replace(slackUnescape.Replace(label)), target)
+		default:
+			return target
+		}
+	})
+
+	return slackUnescape.Replace(text)
+}
+
+// hashToken - в бд хранится только sha256 от токена, утечка базы не даёт писать в чаты
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// randomHex - случайная строка из n байт в hex
+func randomHex(n int) (string, error) {
+	buf := make([]byte, n)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
@@ -0,0 +1,177 @@
+package service
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"service-chat/internal/config"
+	"service-chat/internal/db/entity"
+	mockRepo "service-chat/internal/db/mocks"
+	"service-chat/internal/dto"
+	mockService "service-chat/internal/service/mocks"
+)
+
+func TestIncomingService_AddIncoming(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockIncoming := mockRepo.NewMockIncoming(ctrl)
+	serviceIncoming := NewIncomingService(mockIncoming, mockService.NewMockMessage(ctrl), config.Incoming{MaxPerChat: 10}, config.Messages{})
+
+	// Токен создаётся сервисом, в бд попадает только его хеш
+	var hash string
+	mockIncoming.EXPECT().AddIncoming(gomock.Any()).DoAndReturn(func(in entity.IncomingAdd) (*entity.IncomingWebhook, error) {
+		assert.Equal(t, int64(2), in.ChatID)
+		assert.Equal(t, 1, in.UserID)
+		assert.Equal(t, "alertmanager", in.Name)
+		assert.True(t, strings.HasPrefix(in.BotName, "alertmanager@bot-"))
+		assert.Equal(t, 10, in.MaxPerChat)
+		assert.Len(t, in.TokenHash, 64)
+		hash = in.TokenHash
+		return &entity.IncomingWebhook{ID: 3, ChatID: in.ChatID, Name: in.Name}, nil
+	})
+	hook, err := serviceIncoming.AddIncoming(dto.IncomingAdd{Name: "alertmanager"}, 2, 1)
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(hook.Token, "ihk_"))
+	assert.Equal(t, hashToken(hook.Token), hash)
+	assert.Equal(t, "/hooks/"+hook.Token, hook.URL)
+
+	// Пустой запрос не доходит до бд
+	_, err = serviceIncoming.AddIncoming(dto.IncomingAdd{Name: "alertmanager"}, 0, 1)
+	assert.EqualError(t, err, "empty chat_id or user_id")
+}
+
+func TestIncomingService_PostIncoming(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockIncoming := mockRepo.NewMockIncoming(ctrl)
+	mockMessage := mockService.NewMockMessage(ctrl)
+	serviceIncoming := NewIncomingService(mockIncoming, mockMessage, config.Incoming{}, config.Messages{MaxTextLength: 10})
+
+	hook := &entity.IncomingWebhook{ID: 3, ChatID: 2, BotID: 9}
+
+	// Сообщение пишет служебный пользователь вебхука
+	mockIncoming.EXPECT().GetIncomingByToken(hashToken("ihk_1")).Return(hook, nil)
+	mockMessage.EXPECT().AddMessage(dto.MessageAdd{ChatID: 2, UserID: 9, Text: "Deploy ok", Format: "markdown", SkipCommands: true}).Return(11, nil, nil)
+	id, err := serviceIncoming.PostIncoming("ihk_1", dto.IncomingMessage{Text: " Deploy ok ", Format: "markdown"})
+	assert.NoError(t, err)
+	assert.Equal(t, 11, id)
+
+	// Длинный текст обрезается до лимита сообщения
+	mockIncoming.EXPECT().GetIncomingByToken(hashToken("ihk_1")).Return(hook, nil)
+	mockMessage.EXPECT().AddMessage(dto.MessageAdd{ChatID: 2, UserID: 9, Text: "ааааааааа…", SkipCommands: true}).Return(12, nil, nil)
+	_, err = serviceIncoming.PostIncoming("ihk_1", dto.IncomingMessage{Text: strings.Repeat("а", 20)})
+	assert.NoError(t, err)
+
+	// Неизвестный токен
+	mockIncoming.EXPECT().GetIncomingByToken(hashToken("ihk_2")).Return(nil, errors.New("Invalid webhook token"))
+	_, err = serviceIncoming.PostIncoming("ihk_2", dto.IncomingMessage{Text: "hi"})
+	assert.EqualError(t, err, "Invalid webhook token")
+
+	// Пустой текст
+	mockIncoming.EXPECT().GetIncomingByToken(hashToken("ihk_1")).Return(hook, nil)
+	_, err = serviceIncoming.PostIncoming("ihk_1", dto.IncomingMessage{Text: "  "})
+	assert.EqualError(t, err, "empty text")
+
+	_, err = serviceIncoming.PostIncoming("", dto.IncomingMessage{Text: "hi"})
+	assert.EqualError(t, err, "empty token")
+}
+
+func TestIncomingService_RateLimit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockIncoming := mockRepo.NewMockIncoming(ctrl)
+	mockMessage := mockService.NewMockMessage(ctrl)
+	serviceIncoming := NewIncomingService(mockIncoming, mockMessage, config.Incoming{RatePerMinute: 60, Burst: 2}, config.Messages{})
+
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	serviceIncoming.now = func() time.Time { return now }
+
+	hook := &entity.IncomingWebhook{ID: 3, ChatID: 2, BotID: 9}
+	mockIncoming.EXPECT().GetIncomingByToken(hashToken("ihk_1")).Return(hook, nil).Times(3)
+	mockMessage.EXPECT().AddMessage(gomock.Any()).Return(11, nil, nil).Times(3)
+
+	// Два сообщения подряд укладываются в burst, третье отклоняется без обращения в бд
+	for i := 0; i < 2; i++ {
+		_, err := serviceIncoming.PostIncoming("ihk_1", dto.IncomingMessage{Text: "hi"})
+		assert.NoError(t, err)
+	}
+	_, err := serviceIncoming.PostIncoming("ihk_1", dto.IncomingMessage{Text: "hi"})
+	assert.ErrorIs(t, err, ErrRateLimited)
+
+	// Лимит другого вебхука не тратится
+	assert.True(t, serviceIncoming.allow(hashToken("ihk_2")))
+
+	// Через секунду восстанавливается одно сообщение
+	now = now.Add(time.Second)
+	_, err = serviceIncoming.PostIncoming("ihk_1", dto.IncomingMessage{Text: "hi"})
+	assert.NoError(t, err)
+	_, err = serviceIncoming.PostIncoming("ihk_1", dto.IncomingMessage{Text: "hi"})
+	assert.ErrorIs(t, err, ErrRateLimited)
+
+	// Восстановившиеся лимиты удаляются
+	now = now.Add(2 * time.Minute)
+	assert.True(t, serviceIncoming.allow(hashToken("ihk_3")))
+	assert.Len(t, serviceIncoming.buckets, 1)
+}
+
+func TestIncomingText(t *testing.T) {
+	tests := []struct {
+		name       string
+		in         dto.IncomingMessage
+		wantText   string
+		wantFormat string
+	}{
+		{
+			name:     "Simple payload",
+			in:       dto.IncomingMessage{Text: "Build <b>42</b>"},
+			wantText: "Build <b>42</b>",
+		},
+		{
+			name: "Alertmanager attachment",
+			in: dto.IncomingMessage{Attachments: []dto.IncomingAttachment{{
+				Fallback:  "[FIRING:1] HighLoad",
+				Title:     "[FIRING:1] HighLoad",
+				TitleLink: "https://alerts.example.com/#/alerts",
+				Text:      "cpu &gt; 90% on <https://grafana.example.com/d/1|node-1>",
+				Fields:    []dto.IncomingField{{Title: "severity", Value: "critical"}},
+			}}},
+			wantText: "**[\\[FIRING:1\\] HighLoad](https://alerts.example.com/#/alerts)**\n\n" +
+				"cpu > 90% on [node-1](https://grafana.example.com/d/1)\n\n**severity**: critical",
+			wantFormat: entity.FormatMarkdown,
+		},
+		{
+			name: "Blocks and mentions",
+			in: dto.IncomingMessage{
+				Text: "<!here> deploy",
+				Blocks: []dto.IncomingBlock{
+					{Type: "section", Text: &dto.IncomingBlockText{Type: "mrkdwn", Text: "by <@U123|alice> in <#C1|ops>"}},
+					{Type: "context", Elements: []dto.IncomingBlockText{{Type: "mrkdwn", Text: "<https://example.com>"}}},
+				},
+			},
+			wantText:   "@here deploy\n\nby @alice in #ops\n\nhttps://example.com",
+			wantFormat: entity.FormatMarkdown,
+		},
+		{
+			name:       "Fallback only",
+			in:         dto.IncomingMessage{Attachments: []dto.IncomingAttachment{{Fallback: "Job failed"}}},
+			wantText:   "Job failed",
+			wantFormat: entity.FormatMarkdown,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			text, format := incomingText(tt.in)
+			assert.Equal(t, tt.wantText, text)
+			assert.Equal(t, tt.wantFormat, format)
+		})
+	}
+}
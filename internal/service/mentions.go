@@ -0,0 +1,78 @@
+package service
+
+import (
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"service-chat/internal/apperr"
+	"service-chat/internal/db"
+	"service-chat/internal/db/entity"
+	"service-chat/internal/dto"
+)
+
+const (
+	defaultMentionsLimit = 20
+	// maxUsernameLen - ограничение длины имени пользователя при регистрации
+	maxUsernameLen = 20
+)
+
+// mentionRe - "@username" в начале текста или после символа, который не может быть частью имени,
+// чтобы не принимать за упоминание адреса почты вида name@example.com
+var mentionRe = regexp.MustCompile(`(?:^|[^\p{L}\p{N}_.@])@([\p{L}\p{N}_][\p{L}\p{N}_.\-]*)`)
+
+type MentionService struct {
+	repo db.Mention
+}
+
+func NewMentionService(repo db.Mention) *MentionService {
+	return &MentionService{repo: repo}
+}
+
+// GetMentions - лента сообщений, в которых упомянут пользователь
+func (s *MentionService) GetMentions(in dto.MentionGet, userID int) ([]entity.Message, error) {
+	// Если запрос пустой
+	if userID == 0 {
+		return nil, apperr.Validation("empty user_id")
+	}
+
+	dataDB := entity.MentionGet{
+		UserID: userID,
+		Limit:  in.Limit,
+		Offset: in.Offset,
+	}
+	if dataDB.Limit == 0 {
+		dataDB.Limit = defaultMentionsLimit
+	}
+	messages, err := s.repo.GetMentions(dataDB)
+	if err != nil {
+		return nil, err
+	}
+
+	renderMessages(messages)
+	return messages, nil
+}
+
+// parseMentions - находим в тексте упоминания "@username", позиции считаются в символах.
+// Сопоставление с участниками чата выполняется на слое базы данных
+func parseMentions(text string) []entity.MentionAdd {
+	var mentions []entity.MentionAdd
+
+	for _, match := range mentionRe.FindAllStringSubmatchIndex(text, -1) {
+		// Точки и дефисы в конце считаем знаками препинания, а не частью имени
+		username := strings.TrimRight(text[match[2]:match[3]], ".-")
+		if username == "" || utf8.RuneCountInString(username) > maxUsernameLen {
+			continue
+		}
+
+		// Позиция символа "@" перед именем
+		at := match[2] - 1
+		mentions = append(mentions, entity.MentionAdd{
+			Username: username,
+			Offset:   utf8.RuneCountInString(text[:at]),
+			Length:   utf8.RuneCountInString(username) + 1,
+		})
+	}
+
+	return mentions
+}
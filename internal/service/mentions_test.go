@@ -0,0 +1,141 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"service-chat/internal/apperr"
+	"service-chat/internal/db/entity"
+	mockRepo "service-chat/internal/db/mocks"
+	"service-chat/internal/dto"
+)
+
+func TestParseMentions(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []entity.MentionAdd
+	}{
+		{
+			name: "No mentions",
+			text: "hello world",
+			want: nil,
+		},
+		{
+			name: "Mention at start",
+			text: "@alex hi",
+			want: []entity.MentionAdd{{Username: "alex", Offset: 0, Length: 5}},
+		},
+		{
+			name: "Several mentions",
+			text: "@alex, @bob_2 look",
+			want: []entity.MentionAdd{
+				{Username: "alex", Offset: 0, Length: 5},
+				{Username: "bob_2", Offset: 7, Length: 6},
+			},
+		},
+		{
+			name: "Trailing dot is punctuation",
+			text: "thanks @alex.",
+			want: []entity.MentionAdd{{Username: "alex", Offset: 7, Length: 5}},
+		},
+		{
+			name: "Email is not a mention",
+			text: "write to alex@example.com",
+			want: nil,
+		},
+		{
+			name: "Offsets in runes",
+			text: "привет @дима",
+			want: []entity.MentionAdd{{Username: "дима", Offset: 7, Length: 5}},
+		},
+		{
+			name: "Too long username",
+			text: "@abcdefghijklmnopqrstuvwxyz",
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, parseMentions(tt.text))
+		})
+	}
+}
+
+func TestMentionService_GetMentions(t *testing.T) {
+	// Структура для последующей реализации поведения мока
+	type mockBehaviour func(s *mockRepo.MockMention, dataDB entity.MentionGet)
+
+	//Инициализируем контролер для мока сервиса
+	ctrl := gomock.NewController(t)
+	// Завершаем работу контролера после выполнения каждого теста
+	defer ctrl.Finish()
+
+	// Создаём моки базы данных упоминаний
+	mockMention := mockRepo.NewMockMention(ctrl)
+
+	// Создаём экземпляр сервиса упоминаний
+	serviceMention := NewMentionService(mockMention)
+
+	tests := []struct {
+		name    string
+		in      dto.MentionGet
+		userID  int
+		dataDB  entity.MentionGet
+		mock    mockBehaviour
+		want    []entity.Message
+		wantErr error
+	}{
+		{
+			name:   "Success with default limit",
+			in:     dto.MentionGet{},
+			userID: 1,
+			dataDB: entity.MentionGet{UserID: 1, Limit: 20},
+			mock: func(s *mockRepo.MockMention, dataDB entity.MentionGet) {
+				s.EXPECT().GetMentions(dataDB).Return([]entity.Message{{Id: 1, Text: "@alex"}}, nil)
+			},
+			want: []entity.Message{{Id: 1, Text: "@alex"}},
+		},
+		{
+			name:   "Success with paging",
+			in:     dto.MentionGet{Limit: 5, Offset: 10},
+			userID: 1,
+			dataDB: entity.MentionGet{UserID: 1, Limit: 5, Offset: 10},
+			mock: func(s *mockRepo.MockMention, dataDB entity.MentionGet) {
+				s.EXPECT().GetMentions(dataDB).Return(nil, nil)
+			},
+			want: nil,
+		},
+		{
+			name:    "Empty user_id",
+			in:      dto.MentionGet{},
+			userID:  0,
+			mock:    func(s *mockRepo.MockMention, dataDB entity.MentionGet) {},
+			wantErr: apperr.Validation("empty user_id"),
+		},
+		{
+			name:   "Other error",
+			in:     dto.MentionGet{},
+			userID: 1,
+			dataDB: entity.MentionGet{UserID: 1, Limit: 20},
+			mock: func(s *mockRepo.MockMention, dataDB entity.MentionGet) {
+				s.EXPECT().GetMentions(dataDB).Return(nil, errors.New("other error"))
+			},
+			wantErr: errors.New("other error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock(mockMention, tt.dataDB)
+
+			got, err := serviceMention.GetMentions(tt.in, tt.userID)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}
@@ -1,61 +1,172 @@
 package service
 
 import (
-	"errors"
+	"encoding/base64"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"service-chat/internal/apperr"
+	"service-chat/internal/config"
 	"service-chat/internal/db"
 	"service-chat/internal/db/entity"
 	"service-chat/internal/dto"
+	"service-chat/internal/markdown"
+	"service-chat/internal/realtime"
+)
+
+const (
+	defaultSearchLimit = 20
 )
 
 type MessageService struct {
-	repo db.Message
+	repo     db.Message
+	cfg      config.Messages
+	previews Preview
+	events   realtime.Publisher
+	// commands - выполняет сообщения с /, если не задан - такие сообщения отправляются как обычные
+	commands Command
+	// notifications - уведомляет участников чата не в сети, если не задан - уведомления не создаются
+	notifications Notification
+	// filters - проверяет текст сообщений, если не задан - текст сохраняется как есть
+	filters Filter
 }
 
-func NewMessageService(repo db.Message) *MessageService {
-	return &MessageService{repo: repo}
+func NewMessageService(repo db.Message, cfg config.Messages, previews Preview, events realtime.Publisher) *MessageService {
+	return &MessageService{repo: repo, cfg: cfg, previews: previews, events: events}
 }
 
-// AddMessage - отправка сообщения в чат от лица пользователя
-func (ms *MessageService) AddMessage(in dto.MessageAdd) (int, error) {
+// AddMessage - отправка сообщения в чат от лица пользователя. Сообщение-команда выполняется и в чат не попадает,
+// текст, который начинается с //, отправляется без первой косой черты
+func (ms *MessageService) AddMessage(in dto.MessageAdd) (int, *entity.CommandReply, error) {
 	// Если запрос пустой
 	if in.ChatID == 0 || in.UserID == 0 {
-		return 0, errors.New("empty chat_id or user_id")
+		return 0, nil, apperr.Validation("empty chat_id or user_id")
 	} else if in.Text == "" {
-		return 0, errors.New("empty text")
+		return 0, nil, apperr.Validation("empty text")
+	}
+
+	if ms.commands != nil && !in.SkipCommands {
+		if _, _, ok := parseCommand(in.Text); ok {
+			reply, err := ms.commands.ExecuteCommand(in)
+			if err != nil {
+				return 0, nil, err
+			}
+			return int(reply.MessageID), reply, nil
+		}
+		if strings.HasPrefix(in.Text, "//") {
+			in.Text = in.Text[1:]
+		}
+	}
+
+	if err := checkText(in.Text, in.Format, ms.cfg); err != nil {
+		return 0, nil, err
+	}
+
+	// Фильтры могут отклонить сообщение, замаскировать часть текста или отправить сообщение модераторам
+	var flagged []string
+	if ms.filters != nil {
+		result, err := ms.filters.CheckText(in.ChatID, in.Text)
+		if err != nil {
+			return 0, nil, err
+		}
+		in.Text, flagged = result.Text, result.Flagged
 	}
 
 	dataDB := entity.MessageAdd{
-		ChatID: in.ChatID,
-		UserID: in.UserID,
-		Text:   in.Text,
+		ChatID:   in.ChatID,
+		UserID:   in.UserID,
+		Text:     in.Text,
+		Format:   in.Format,
+		Mentions: parseMentions(in.Text),
+		// Жалоба модераторам создаётся вместе с сообщением, без неё сообщение не сохранится
+		Flagged: flagged,
 	}
-	return ms.repo.AddMessage(dataDB)
+	messageID, err := ms.repo.AddMessage(dataDB)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	// Превью ссылок загружаются в фоне и не задерживают отправку сообщения
+	ms.previews.Enqueue(int64(messageID), in.Text)
+	// Уведомления тоже создаются в фоне
+	if ms.notifications != nil {
+		ms.notifications.Enqueue(in.ChatID, int64(messageID), in.UserID)
+	}
+
+	ms.events.Publish(realtime.Event{
+		Type:   realtime.EventMessageCreated,
+		ChatID: in.ChatID,
+		Data:   messagePayload(int64(messageID), in.UserID, in.Text, in.Format),
+	})
+
+	return messageID, nil, nil
 }
 
 // UpdateMessage - редактирование сообщения от лица пользователя
 func (ms *MessageService) UpdateMessage(in dto.MessageUpdate) (int, error) {
 	// Если запрос пустой
 	if in.MessageID == 0 || in.UserID == 0 {
-		return 0, errors.New("empty chat_id or user_id")
+		return 0, apperr.Validation("empty chat_id or user_id")
 	} else if in.NewText == "" {
-		return 0, errors.New("empty new_text")
+		return 0, apperr.Validation("empty new_text")
+	}
+
+	if err := checkText(in.NewText, in.Format, ms.cfg); err != nil {
+		return 0, err
+	}
+
+	// Новый текст проходит те же фильтры, что и при отправке, с настройками чата сообщения.
+	// Если чат не найден, действуют настройки из конфига, а редактирование отклонит слой бд
+	var flagged []string
+	if ms.filters != nil {
+		chats, errChats := ms.repo.MessageChats([]int64{in.MessageID})
+		if errChats != nil {
+			return 0, errChats
+		}
+		result, err := ms.filters.CheckText(chats[in.MessageID], in.NewText)
+		if err != nil {
+			return 0, err
+		}
+		in.NewText, flagged = result.Text, result.Flagged
 	}
 
 	dataDB := entity.MessageUpdate{
 		MessageID: in.MessageID,
 		UserID:    in.UserID,
 		NewText:   in.NewText,
+		Format:    in.Format,
+		Mentions:  parseMentions(in.NewText),
+		Flagged:   flagged,
 	}
-	return ms.repo.UpdateMessage(dataDB)
+	messageID, err := ms.repo.UpdateMessage(dataDB)
+	if err != nil {
+		return 0, err
+	}
+
+	// Событие получают участники чата сообщения, если чат найти не удалось - событие не отправляем
+	if chats, errChats := ms.repo.MessageChats([]int64{in.MessageID}); errChats == nil {
+		if chatID, ok := chats[in.MessageID]; ok {
+			ms.events.Publish(realtime.Event{
+				Type:   realtime.EventMessageUpdated,
+				ChatID: chatID,
+				Data:   messagePayload(in.MessageID, in.UserID, in.NewText, in.Format),
+			})
+		}
+	}
+
+	return messageID, nil
 }
 
 // GetMessage - получение списка сообщений из конкретного чата
 func (ms *MessageService) GetMessage(in dto.MessageGet, userID int) ([]entity.Message, error) {
 	// Если пустой запрос
 	if in.ChatID == 0 {
-		return nil, errors.New("empty chat_id")
+		return nil, apperr.Validation("empty chat_id")
 	} else if userID == 0 {
-		return nil, errors.New("empty user_id")
+		return nil, apperr.Validation("empty user_id")
 	}
 
 	dataDB := entity.MessageGet{
@@ -63,23 +174,204 @@ func (ms *MessageService) GetMessage(in dto.MessageGet, userID int) ([]entity.Me
 		Limit:  *in.Limit,
 		Offset: *in.Offset,
 		UserID: userID,
+		// Сообщения из чёрного списка пользователя скрываем, если это включено в конфиге
+		HideBlocked: ms.cfg.HideBlocked,
+	}
+	messages, err := ms.repo.GetMessage(dataDB)
+	if err != nil {
+		return nil, err
 	}
-	return ms.repo.GetMessage(dataDB)
+
+	// Проставляем ссылки на скачивание вложений
+	for i := range messages {
+		for j := range messages[i].Attachments {
+			setAttachmentURLs(&messages[i].Attachments[j])
+		}
+	}
+	renderMessages(messages)
+
+	return messages, nil
 }
 
 // DeleteMessage - удаление сообщений
 func (ms *MessageService) DeleteMessage(in dto.MessageDelete, userID int) ([]entity.DelMsg, error) {
 	// Если запрос пустой
 	if in.MessageIds == nil || len(*in.MessageIds) == 0 {
-		return nil, errors.New("message_ids is empty")
+		return nil, apperr.Validation("message_ids is empty")
 	}
 	if userID == 0 {
-		return nil, errors.New("user_id is empty")
+		return nil, apperr.Validation("user_id is empty")
 	}
 
 	dataDB := entity.MessageDel{
 		MsgIds: *in.MessageIds,
 		UserID: userID,
 	}
-	return ms.repo.DeleteMessage(dataDB)
+	deleted, err := ms.repo.DeleteMessage(dataDB)
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []int64
+	for _, msg := range deleted {
+		if msg.Result == entity.ResultMessageDeleted {
+			ids = append(ids, msg.MessageID)
+		}
+	}
+	if len(ids) > 0 {
+		if chats, errChats := ms.repo.MessageChats(ids); errChats == nil {
+			for _, id := range ids {
+				if chatID, ok := chats[id]; ok {
+					ms.events.Publish(realtime.Event{
+						Type:   realtime.EventMessageDeleted,
+						ChatID: chatID,
+						Data:   realtime.MessagePayload{MessageID: id},
+					})
+				}
+			}
+		}
+	}
+
+	return deleted, nil
+}
+
+// ForwardMessage - пересылка сообщения в другие чаты от лица пользователя
+func (ms *MessageService) ForwardMessage(in dto.MessageForward, messageID int64, userID int) ([]entity.Forwarded, error) {
+	// Если запрос пустой
+	if messageID == 0 || userID == 0 {
+		return nil, apperr.Validation("empty message_id or user_id")
+	} else if len(in.ChatIDs) == 0 {
+		return nil, apperr.Validation("empty chat_ids")
+	}
+
+	// Убираем повторы, чтобы не пересылать сообщение в один чат дважды
+	chatIDs := make([]int64, 0, len(in.ChatIDs))
+	for _, chatID := range in.ChatIDs {
+		if chatID <= 0 {
+			return nil, apperr.Validation("invalid chat_id")
+		}
+		if !slices.Contains(chatIDs, chatID) {
+			chatIDs = append(chatIDs, chatID)
+		}
+	}
+
+	dataDB := entity.MessageForward{
+		MessageID: messageID,
+		UserID:    userID,
+		ChatIDs:   chatIDs,
+	}
+	forwarded, err := ms.repo.ForwardMessage(dataDB)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, fwd := range forwarded {
+		ms.events.Publish(realtime.Event{
+			Type:   realtime.EventMessageCreated,
+			ChatID: fwd.ChatID,
+			Data:   realtime.MessagePayload{MessageID: fwd.MessageID, UserID: int64(userID)},
+		})
+	}
+
+	return forwarded, nil
+}
+
+// SearchMessage - полнотекстовый поиск по сообщениям в чатах пользователя, возвращаем страницу
+// результатов и курсор следующей страницы (пустой, если результатов больше нет)
+func (ms *MessageService) SearchMessage(in dto.MessageSearch, userID int) ([]entity.SearchResult, string, error) {
+	// Если пустой запрос
+	if strings.TrimSpace(in.Query) == "" {
+		return nil, "", apperr.Validation("empty query")
+	} else if userID == 0 {
+		return nil, "", apperr.Validation("empty user_id")
+	}
+	if in.From != nil && in.To != nil && !in.From.Before(*in.To) {
+		return nil, "", apperr.Validation("from must be before to")
+	}
+
+	dataDB := entity.MessageSearch{
+		UserID:   userID,
+		Query:    in.Query,
+		ChatID:   in.ChatID,
+		AuthorID: in.AuthorID,
+		From:     in.From,
+		To:       in.To,
+		Limit:    in.Limit,
+	}
+	if dataDB.Limit == 0 {
+		dataDB.Limit = defaultSearchLimit
+	}
+
+	// Продолжаем с позиции, на которой закончилась предыдущая страница
+	if in.Cursor != "" {
+		rank, id, err := decodeSearchCursor(in.Cursor)
+		if err != nil {
+			return nil, "", err
+		}
+		dataDB.AfterRank, dataDB.AfterID = &rank, id
+	}
+
+	results, err := ms.repo.SearchMessage(dataDB)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// Если страница заполнена целиком, возможно есть следующая
+	var next string
+	if int64(len(results)) == dataDB.Limit {
+		last := results[len(results)-1]
+		next = encodeSearchCursor(last.Rank, last.Message.Id)
+	}
+
+	return results, next, nil
+}
+
+// checkText - проверяем формат текста сообщения и его длину в символах, нулевой лимит не ограничивает длину
+func checkText(text, format string, cfg config.Messages) error {
+	if format != "" && format != entity.FormatPlain && format != entity.FormatMarkdown {
+		return apperr.Validation("invalid format")
+	}
+	if cfg.MaxTextLength > 0 && utf8.RuneCountInString(text) > cfg.MaxTextLength {
+		return apperr.Validation(fmt.Sprintf("text is too long, max %d characters", cfg.MaxTextLength))
+	}
+	return nil
+}
+
+// renderMessages - рендерим безопасный HTML для сообщений в формате markdown, исходный текст не меняется
+func renderMessages(messages []entity.Message) {
+	for i := range messages {
+		if messages[i].Format == entity.FormatMarkdown && !messages[i].IsDeleted {
+			messages[i].HTML = markdown.ToHTML(messages[i].Text)
+		}
+	}
+}
+
+// encodeSearchCursor - курсор хранит rank и id последнего результата страницы
+func encodeSearchCursor(rank float32, id int64) string {
+	raw := strconv.FormatFloat(float64(rank), 'g', -1, 32) + ":" + strconv.FormatInt(id, 10)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// decodeSearchCursor - разбираем курсор, полученный от клиента
+func decodeSearchCursor(cursor string) (float32, int64, error) {
+	errCursor := apperr.Validation("invalid cursor")
+
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, 0, errCursor
+	}
+	rankStr, idStr, ok := strings.Cut(string(raw), ":")
+	if !ok {
+		return 0, 0, errCursor
+	}
+	rank, err := strconv.ParseFloat(rankStr, 32)
+	if err != nil {
+		return 0, 0, errCursor
+	}
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil || id <= 0 {
+		return 0, 0, errCursor
+	}
+
+	return float32(rank), id, nil
 }
@@ -7,15 +7,18 @@ import (
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
 
+	"service-chat/internal/apperr"
+	"service-chat/internal/config"
 	"service-chat/internal/db"
 	"service-chat/internal/db/entity"
 	mockRepo "service-chat/internal/db/mocks"
 	"service-chat/internal/dto"
+	mockService "service-chat/internal/service/mocks"
 )
 
 func TestMessageService_AddMessage(t *testing.T) {
 	// Структура для последующей реализации поведения мока
-	type mockBehaviour func(s *mockRepo.MockMessage, dataDB entity.MessageAdd)
+	type mockBehaviour func(s *mockRepo.MockMessage, p *mockService.MockPreview, dataDB entity.MessageAdd)
 
 	//Инициализируем контролер для мока сервиса
 	ctrl := gomock.NewController(t)
@@ -25,11 +28,14 @@ func TestMessageService_AddMessage(t *testing.T) {
 	// Создаём моки базы данных сообщений
 	mockMessage := mockRepo.NewMockMessage(ctrl)
 
+	// Создаём мок фоновой загрузки превью ссылок
+	mockPreview := mockService.NewMockPreview(ctrl)
+
 	// Создаём объект базы данных в который передадим наш мок сообщений
 	repository := &db.DB{Message: mockMessage}
 
 	// Создаём экземпляр сервиса сообщений
-	serviceChat := NewMessageService(repository)
+	serviceChat := NewMessageService(repository, config.Messages{MaxTextLength: 10}, mockPreview, &fakePublisher{})
 
 	tests := []struct {
 		name      string
@@ -51,12 +57,77 @@ func TestMessageService_AddMessage(t *testing.T) {
 				UserID: 1,
 				Text:   "test",
 			},
-			mock: func(s *mockRepo.MockMessage, dataDB entity.MessageAdd) {
+			mock: func(s *mockRepo.MockMessage, p *mockService.MockPreview, dataDB entity.MessageAdd) {
+				s.EXPECT().AddMessage(dataDB).Return(1, nil)
+				p.EXPECT().Enqueue(int64(1), dataDB.Text)
+			},
+			want:    1,
+			wantErr: nil,
+		},
+		{
+			name: "Success with mention",
+			inMessage: dto.MessageAdd{
+				ChatID: 1,
+				UserID: 1,
+				Text:   "hi @alex",
+			},
+			dataDB: entity.MessageAdd{
+				ChatID:   1,
+				UserID:   1,
+				Text:     "hi @alex",
+				Mentions: []entity.MentionAdd{{Username: "alex", Offset: 3, Length: 5}},
+			},
+			mock: func(s *mockRepo.MockMessage, p *mockService.MockPreview, dataDB entity.MessageAdd) {
+				s.EXPECT().AddMessage(dataDB).Return(1, nil)
+				p.EXPECT().Enqueue(int64(1), dataDB.Text)
+			},
+			want:    1,
+			wantErr: nil,
+		},
+		{
+			name: "Success with markdown",
+			inMessage: dto.MessageAdd{
+				ChatID: 1,
+				UserID: 1,
+				Text:   "**test**",
+				Format: entity.FormatMarkdown,
+			},
+			dataDB: entity.MessageAdd{
+				ChatID: 1,
+				UserID: 1,
+				Text:   "**test**",
+				Format: entity.FormatMarkdown,
+			},
+			mock: func(s *mockRepo.MockMessage, p *mockService.MockPreview, dataDB entity.MessageAdd) {
 				s.EXPECT().AddMessage(dataDB).Return(1, nil)
+				p.EXPECT().Enqueue(int64(1), dataDB.Text)
 			},
 			want:    1,
 			wantErr: nil,
 		},
+		{
+			name: "Text too long",
+			inMessage: dto.MessageAdd{
+				ChatID: 1,
+				UserID: 1,
+				Text:   "тест тест тест",
+			},
+			mock:    func(s *mockRepo.MockMessage, p *mockService.MockPreview, dataDB entity.MessageAdd) {},
+			want:    0,
+			wantErr: apperr.Validation("text is too long, max 10 characters"),
+		},
+		{
+			name: "Invalid format",
+			inMessage: dto.MessageAdd{
+				ChatID: 1,
+				UserID: 1,
+				Text:   "test",
+				Format: "html",
+			},
+			mock:    func(s *mockRepo.MockMessage, p *mockService.MockPreview, dataDB entity.MessageAdd) {},
+			want:    0,
+			wantErr: apperr.Validation("invalid format"),
+		},
 		{
 			name: "Empty chat_id",
 			inMessage: dto.MessageAdd{
@@ -64,9 +135,9 @@ func TestMessageService_AddMessage(t *testing.T) {
 				UserID: 1,
 				Text:   "test",
 			},
-			mock:    func(s *mockRepo.MockMessage, dataDB entity.MessageAdd) {},
+			mock:    func(s *mockRepo.MockMessage, p *mockService.MockPreview, dataDB entity.MessageAdd) {},
 			want:    0,
-			wantErr: errors.New("empty chat_id or user_id"),
+			wantErr: apperr.Validation("empty chat_id or user_id"),
 		},
 		{
 			name: "Empty user_id",
@@ -75,9 +146,9 @@ func TestMessageService_AddMessage(t *testing.T) {
 				UserID: 0,
 				Text:   "test",
 			},
-			mock:    func(s *mockRepo.MockMessage, dataDB entity.MessageAdd) {},
+			mock:    func(s *mockRepo.MockMessage, p *mockService.MockPreview, dataDB entity.MessageAdd) {},
 			want:    0,
-			wantErr: errors.New("empty chat_id or user_id"),
+			wantErr: apperr.Validation("empty chat_id or user_id"),
 		},
 		{
 			name: "Empty text",
@@ -86,9 +157,9 @@ func TestMessageService_AddMessage(t *testing.T) {
 				UserID: 1,
 				Text:   "",
 			},
-			mock:    func(s *mockRepo.MockMessage, dataDB entity.MessageAdd) {},
+			mock:    func(s *mockRepo.MockMessage, p *mockService.MockPreview, dataDB entity.MessageAdd) {},
 			want:    0,
-			wantErr: errors.New("empty text"),
+			wantErr: apperr.Validation("empty text"),
 		},
 		{
 			name: "Other error",
@@ -102,7 +173,7 @@ func TestMessageService_AddMessage(t *testing.T) {
 				UserID: 1,
 				Text:   "test",
 			},
-			mock: func(s *mockRepo.MockMessage, dataDB entity.MessageAdd) {
+			mock: func(s *mockRepo.MockMessage, p *mockService.MockPreview, dataDB entity.MessageAdd) {
 				s.EXPECT().AddMessage(dataDB).Return(0, errors.New("other error"))
 			},
 			want:    0,
@@ -113,10 +184,10 @@ func TestMessageService_AddMessage(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			// Передаём структуру сообщения
-			tt.mock(mockMessage, tt.dataDB)
+			tt.mock(mockMessage, mockPreview, tt.dataDB)
 
 			// Проверяем ожидаемый и актуальный результат
-			acMsg, acErr := serviceChat.AddMessage(tt.inMessage)
+			acMsg, _, acErr := serviceChat.AddMessage(tt.inMessage)
 			assert.Equal(t, tt.want, acMsg)
 			assert.Equal(t, tt.wantErr, acErr)
 		})
@@ -139,7 +210,7 @@ func TestMessageService_UpdateMessage(t *testing.T) {
 	repository := &db.DB{Message: mockMessage}
 
 	// Создаём экземпляр сервиса сообщений
-	serviceChat := NewMessageService(repository)
+	serviceChat := NewMessageService(repository, config.Messages{}, mockService.NewMockPreview(ctrl), &fakePublisher{})
 
 	tests := []struct {
 		name      string
@@ -163,6 +234,7 @@ func TestMessageService_UpdateMessage(t *testing.T) {
 			},
 			mock: func(s *mockRepo.MockMessage, dataDB entity.MessageUpdate) {
 				s.EXPECT().UpdateMessage(dataDB).Return(1, nil)
+				s.EXPECT().MessageChats([]int64{1}).Return(map[int64]int64{1: 1}, nil)
 			},
 			want:    1,
 			wantErr: nil,
@@ -176,7 +248,7 @@ func TestMessageService_UpdateMessage(t *testing.T) {
 			},
 			mock:    func(s *mockRepo.MockMessage, dataDB entity.MessageUpdate) {},
 			want:    0,
-			wantErr: errors.New("empty chat_id or user_id"),
+			wantErr: apperr.Validation("empty chat_id or user_id"),
 		},
 		{
 			name: "Empty user_id",
@@ -187,7 +259,7 @@ func TestMessageService_UpdateMessage(t *testing.T) {
 			},
 			mock:    func(s *mockRepo.MockMessage, dataDB entity.MessageUpdate) {},
 			want:    0,
-			wantErr: errors.New("empty chat_id or user_id"),
+			wantErr: apperr.Validation("empty chat_id or user_id"),
 		},
 		{
 			name: "Empty new_text",
@@ -198,7 +270,7 @@ func TestMessageService_UpdateMessage(t *testing.T) {
 			},
 			mock:    func(s *mockRepo.MockMessage, dataDB entity.MessageUpdate) {},
 			want:    0,
-			wantErr: errors.New("empty new_text"),
+			wantErr: apperr.Validation("empty new_text"),
 		},
 		{
 			name: "Other error",
@@ -251,7 +323,7 @@ func TestMessageService_GetMessage(t *testing.T) {
 	repository := &db.DB{Message: mockMessage}
 
 	// Создаём экземпляр сервиса сообщений
-	serviceChat := NewMessageService(repository)
+	serviceChat := NewMessageService(repository, config.Messages{}, mockService.NewMockPreview(ctrl), &fakePublisher{})
 
 	tests := []struct {
 		name      string
@@ -296,6 +368,31 @@ func TestMessageService_GetMessage(t *testing.T) {
 			},
 			wantErr: nil,
 		},
+		{
+			name: "Success markdown message",
+			inMessage: dto.MessageGet{
+				ChatID: 1,
+				Limit:  &limit,
+				Offset: &offset,
+			},
+			dataDB: entity.MessageGet{
+				ChatID: 1,
+				Limit:  limit,
+				Offset: offset,
+				UserID: 1,
+			},
+			mock: func(s *mockRepo.MockMessage, dataDB entity.MessageGet) {
+				s.EXPECT().GetMessage(dataDB).Return([]entity.Message{
+					{Id: 1, Text: "**hi** <b>", UserID: 1, Format: entity.FormatMarkdown},
+					{Id: 2, Text: "**hi**", UserID: 1, Format: entity.FormatPlain},
+				}, nil)
+			},
+			want: []entity.Message{
+				{Id: 1, Text: "**hi** <b>", UserID: 1, Format: entity.FormatMarkdown, HTML: "<p><strong>hi</strong> &lt;b&gt;</p>"},
+				{Id: 2, Text: "**hi**", UserID: 1, Format: entity.FormatPlain},
+			},
+			wantErr: nil,
+		},
 		{
 			name: "Success many message",
 			inMessage: dto.MessageGet{
@@ -368,7 +465,7 @@ func TestMessageService_GetMessage(t *testing.T) {
 			},
 			mock:    func(s *mockRepo.MockMessage, dataDB entity.MessageGet) {},
 			want:    nil,
-			wantErr: errors.New("empty chat_id"),
+			wantErr: apperr.Validation("empty chat_id"),
 		},
 		{
 			name: "Empty user_id",
@@ -382,7 +479,7 @@ func TestMessageService_GetMessage(t *testing.T) {
 			},
 			mock:    func(s *mockRepo.MockMessage, dataDB entity.MessageGet) {},
 			want:    nil,
-			wantErr: errors.New("empty user_id"),
+			wantErr: apperr.Validation("empty user_id"),
 		},
 		{
 			name: "Other errors",
@@ -434,7 +531,7 @@ func TestMessageService_DeleteMessage(t *testing.T) {
 	repository := &db.DB{Message: mockMessage}
 
 	// Создаём экземпляр сервиса сообщений
-	serviceChat := NewMessageService(repository)
+	serviceChat := NewMessageService(repository, config.Messages{}, mockService.NewMockPreview(ctrl), &fakePublisher{})
 
 	tests := []struct {
 		name      string
@@ -460,6 +557,7 @@ func TestMessageService_DeleteMessage(t *testing.T) {
 						Result:    "Message successfully deleted",
 					},
 				}, nil)
+				s.EXPECT().MessageChats([]int64{1}).Return(map[int64]int64{1: 1}, nil)
 			},
 			want: []entity.DelMsg{
 				{
@@ -493,6 +591,7 @@ func TestMessageService_DeleteMessage(t *testing.T) {
 						Result:    "Message does not exist or has already been deleted",
 					},
 				}, nil)
+				s.EXPECT().MessageChats([]int64{1, 2}).Return(map[int64]int64{1: 1, 2: 1}, nil)
 			},
 			want: []entity.DelMsg{
 				{
@@ -517,7 +616,7 @@ func TestMessageService_DeleteMessage(t *testing.T) {
 			},
 			mock:    func(s *mockRepo.MockMessage, dataDB entity.MessageDel) {},
 			want:    nil,
-			wantErr: errors.New("message_ids is empty"),
+			wantErr: apperr.Validation("message_ids is empty"),
 		},
 		{
 			name: "Empty user_id",
@@ -529,7 +628,7 @@ func TestMessageService_DeleteMessage(t *testing.T) {
 			},
 			mock:    func(s *mockRepo.MockMessage, dataDB entity.MessageDel) {},
 			want:    nil,
-			wantErr: errors.New("user_id is empty"),
+			wantErr: apperr.Validation("user_id is empty"),
 		},
 		{
 			name: "Other errors",
@@ -560,3 +659,166 @@ func TestMessageService_DeleteMessage(t *testing.T) {
 		})
 	}
 }
+
+func TestMessageService_SearchMessage(t *testing.T) {
+	//Инициализируем контролер для мока сервиса
+	ctrl := gomock.NewController(t)
+	// Завершаем работу контролера после выполнения каждого теста
+	defer ctrl.Finish()
+
+	// Создаём моки базы данных сообщений
+	mockMessage := mockRepo.NewMockMessage(ctrl)
+
+	// Создаём экземпляр сервиса сообщений
+	serviceChat := NewMessageService(mockMessage, config.Messages{}, mockService.NewMockPreview(ctrl), &fakePublisher{})
+
+	rank := float32(0.0607927)
+	cursor := encodeSearchCursor(rank, 5)
+
+	tests := []struct {
+		name     string
+		in       dto.MessageSearch
+		userID   int
+		mock     func(s *mockRepo.MockMessage)
+		want     []entity.SearchResult
+		wantNext string
+		wantErr  error
+	}{
+		{
+			name:   "Full page returns next cursor",
+			in:     dto.MessageSearch{Query: "deploy", Limit: 1},
+			userID: 1,
+			mock: func(s *mockRepo.MockMessage) {
+				s.EXPECT().SearchMessage(entity.MessageSearch{UserID: 1, Query: "deploy", Limit: 1}).
+					Return([]entity.SearchResult{{Message: entity.Message{Id: 5}, Rank: rank}}, nil)
+			},
+			want:     []entity.SearchResult{{Message: entity.Message{Id: 5}, Rank: rank}},
+			wantNext: cursor,
+		},
+		{
+			name:   "Cursor continues from last result with default limit",
+			in:     dto.MessageSearch{Query: "deploy", ChatID: 2, Cursor: cursor},
+			userID: 1,
+			mock: func(s *mockRepo.MockMessage) {
+				s.EXPECT().SearchMessage(entity.MessageSearch{
+					UserID: 1, Query: "deploy", ChatID: 2, Limit: defaultSearchLimit, AfterRank: &rank, AfterID: 5,
+				}).Return(nil, nil)
+			},
+		},
+		{
+			name:    "Empty query",
+			in:      dto.MessageSearch{Query: "  "},
+			userID:  1,
+			mock:    func(s *mockRepo.MockMessage) {},
+			wantErr: apperr.Validation("empty query"),
+		},
+		{
+			name:    "Invalid cursor",
+			in:      dto.MessageSearch{Query: "deploy", Cursor: "%%%"},
+			userID:  1,
+			mock:    func(s *mockRepo.MockMessage) {},
+			wantErr: apperr.Validation("invalid cursor"),
+		},
+		{
+			name:   "Other error",
+			in:     dto.MessageSearch{Query: "deploy"},
+			userID: 1,
+			mock: func(s *mockRepo.MockMessage) {
+				s.EXPECT().SearchMessage(gomock.Any()).Return(nil, errors.New("other error"))
+			},
+			wantErr: errors.New("other error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock(mockMessage)
+
+			// Проверяем ожидаемый и актуальный результат
+			acResults, acNext, acErr := serviceChat.SearchMessage(tt.in, tt.userID)
+			assert.Equal(t, tt.want, acResults)
+			assert.Equal(t, tt.wantNext, acNext)
+			assert.Equal(t, tt.wantErr, acErr)
+		})
+	}
+}
+
+func TestMessageService_ForwardMessage(t *testing.T) {
+	//Инициализируем контролер для мока сервиса
+	ctrl := gomock.NewController(t)
+	// Завершаем работу контролера после выполнения каждого теста
+	defer ctrl.Finish()
+
+	// Создаём моки базы данных сообщений
+	mockMessage := mockRepo.NewMockMessage(ctrl)
+
+	// Создаём экземпляр сервиса сообщений
+	serviceMessage := NewMessageService(mockMessage, config.Messages{}, mockService.NewMockPreview(ctrl), &fakePublisher{})
+
+	tests := []struct {
+		name      string
+		in        dto.MessageForward
+		messageID int64
+		userID    int
+		mock      func(s *mockRepo.MockMessage)
+		want      []entity.Forwarded
+		wantErr   error
+	}{
+		{
+			name:      "Success with duplicate targets",
+			in:        dto.MessageForward{ChatIDs: []int64{2, 3, 2}},
+			messageID: 5,
+			userID:    1,
+			mock: func(s *mockRepo.MockMessage) {
+				s.EXPECT().ForwardMessage(entity.MessageForward{MessageID: 5, UserID: 1, ChatIDs: []int64{2, 3}}).
+					Return([]entity.Forwarded{{ChatID: 2, MessageID: 10}, {ChatID: 3, MessageID: 11}}, nil)
+			},
+			want: []entity.Forwarded{{ChatID: 2, MessageID: 10}, {ChatID: 3, MessageID: 11}},
+		},
+		{
+			name:      "Empty message_id",
+			in:        dto.MessageForward{ChatIDs: []int64{2}},
+			messageID: 0,
+			userID:    1,
+			mock:      func(s *mockRepo.MockMessage) {},
+			wantErr:   apperr.Validation("empty message_id or user_id"),
+		},
+		{
+			name:      "Empty chat_ids",
+			in:        dto.MessageForward{},
+			messageID: 5,
+			userID:    1,
+			mock:      func(s *mockRepo.MockMessage) {},
+			wantErr:   apperr.Validation("empty chat_ids"),
+		},
+		{
+			name:      "Invalid chat_id",
+			in:        dto.MessageForward{ChatIDs: []int64{2, -1}},
+			messageID: 5,
+			userID:    1,
+			mock:      func(s *mockRepo.MockMessage) {},
+			wantErr:   apperr.Validation("invalid chat_id"),
+		},
+		{
+			name:      "Not a member of target",
+			in:        dto.MessageForward{ChatIDs: []int64{7}},
+			messageID: 5,
+			userID:    1,
+			mock: func(s *mockRepo.MockMessage) {
+				s.EXPECT().ForwardMessage(entity.MessageForward{MessageID: 5, UserID: 1, ChatIDs: []int64{7}}).
+					Return(nil, errors.New("User with userID 1 does not exist in chatID 7"))
+			},
+			wantErr: errors.New("User with userID 1 does not exist in chatID 7"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock(mockMessage)
+
+			got, err := serviceMessage.ForwardMessage(tt.in, tt.messageID, tt.userID)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}
@@ -10,9 +10,14 @@
 package mock_service
 
 import (
+	context "context"
+	io "io"
+	slog "log/slog"
 	reflect "reflect"
 	entity "service-chat/internal/db/entity"
 	dto "service-chat/internal/dto"
+	filter "service-chat/internal/filter"
+	realtime "service-chat/internal/realtime"
 
 	gomock "go.uber.org/mock/gomock"
 )
@@ -40,6 +45,20 @@ func (m *MockAuthorization) EXPECT() *MockAuthorizationMockRecorder {
 	return m.recorder
 }
 
+// CheckSuspension mocks base method.
+func (m *MockAuthorization) CheckSuspension(userID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckSuspension", userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CheckSuspension indicates an expected call of CheckSuspension.
+func (mr *MockAuthorizationMockRecorder) CheckSuspension(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckSuspension", reflect.TypeOf((*MockAuthorization)(nil).CheckSuspension), userID)
+}
+
 // CreateUser mocks base method.
 func (m *MockAuthorization) CreateUser(user dto.SignUpRequest) (int, error) {
 	m.ctrl.T.Helper()
@@ -109,18 +128,18 @@ func (m *MockChat) EXPECT() *MockChatMockRecorder {
 }
 
 // CreateChat mocks base method.
-func (m *MockChat) CreateChat(in dto.ChatAdd) (int, error) {
+func (m *MockChat) CreateChat(in dto.ChatAdd, userID int) (int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateChat", in)
+	ret := m.ctrl.Call(m, "CreateChat", in, userID)
 	ret0, _ := ret[0].(int)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateChat indicates an expected call of CreateChat.
-func (mr *MockChatMockRecorder) CreateChat(in any) *gomock.Call {
+func (mr *MockChatMockRecorder) CreateChat(in, userID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateChat", reflect.TypeOf((*MockChat)(nil).CreateChat), in)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateChat", reflect.TypeOf((*MockChat)(nil).CreateChat), in, userID)
 }
 
 // DeleteChat mocks base method.
@@ -177,12 +196,13 @@ func (m *MockMessage) EXPECT() *MockMessageMockRecorder {
 }
 
 // AddMessage mocks base method.
-func (m *MockMessage) AddMessage(in dto.MessageAdd) (int, error) {
+func (m *MockMessage) AddMessage(in dto.MessageAdd) (int, *entity.CommandReply, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "AddMessage", in)
 	ret0, _ := ret[0].(int)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret1, _ := ret[1].(*entity.CommandReply)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
 // AddMessage indicates an expected call of AddMessage.
@@ -206,6 +226,21 @@ func (mr *MockMessageMockRecorder) DeleteMessage(in, userID any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMessage", reflect.TypeOf((*MockMessage)(nil).DeleteMessage), in, userID)
 }
 
+// ForwardMessage mocks base method.
+func (m *MockMessage) ForwardMessage(in dto.MessageForward, messageID int64, userID int) ([]entity.Forwarded, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ForwardMessage", in, messageID, userID)
+	ret0, _ := ret[0].([]entity.Forwarded)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ForwardMessage indicates an expected call of ForwardMessage.
+func (mr *MockMessageMockRecorder) ForwardMessage(in, messageID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForwardMessage", reflect.TypeOf((*MockMessage)(nil).ForwardMessage), in, messageID, userID)
+}
+
 // GetMessage mocks base method.
 func (m *MockMessage) GetMessage(in dto.MessageGet, userID int) ([]entity.Message, error) {
 	m.ctrl.T.Helper()
@@ -221,6 +256,22 @@ func (mr *MockMessageMockRecorder) GetMessage(in, userID any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMessage", reflect.TypeOf((*MockMessage)(nil).GetMessage), in, userID)
 }
 
+// SearchMessage mocks base method.
+func (m *MockMessage) SearchMessage(in dto.MessageSearch, userID int) ([]entity.SearchResult, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchMessage", in, userID)
+	ret0, _ := ret[0].([]entity.SearchResult)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// SearchMessage indicates an expected call of SearchMessage.
+func (mr *MockMessageMockRecorder) SearchMessage(in, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchMessage", reflect.TypeOf((*MockMessage)(nil).SearchMessage), in, userID)
+}
+
 // UpdateMessage mocks base method.
 func (m *MockMessage) UpdateMessage(in dto.MessageUpdate) (int, error) {
 	m.ctrl.T.Helper()
@@ -235,3 +286,1261 @@ func (mr *MockMessageMockRecorder) UpdateMessage(in any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMessage", reflect.TypeOf((*MockMessage)(nil).UpdateMessage), in)
 }
+
+// MockReaction is a mock of Reaction interface.
+type MockReaction struct {
+	ctrl     *gomock.Controller
+	recorder *MockReactionMockRecorder
+}
+
+// MockReactionMockRecorder is the mock recorder for MockReaction.
+type MockReactionMockRecorder struct {
+	mock *MockReaction
+}
+
+// NewMockReaction creates a new mock instance.
+func NewMockReaction(ctrl *gomock.Controller) *MockReaction {
+	mock := &MockReaction{ctrl: ctrl}
+	mock.recorder = &MockReactionMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReaction) EXPECT() *MockReactionMockRecorder {
+	return m.recorder
+}
+
+// AddReaction mocks base method.
+func (m *MockReaction) AddReaction(in dto.ReactionAdd, messageID int64, userID int) ([]entity.Reaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddReaction", in, messageID, userID)
+	ret0, _ := ret[0].([]entity.Reaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddReaction indicates an expected call of AddReaction.
+func (mr *MockReactionMockRecorder) AddReaction(in, messageID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddReaction", reflect.TypeOf((*MockReaction)(nil).AddReaction), in, messageID, userID)
+}
+
+// DeleteReaction mocks base method.
+func (m *MockReaction) DeleteReaction(in dto.ReactionDelete, messageID int64, userID int) ([]entity.Reaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteReaction", in, messageID, userID)
+	ret0, _ := ret[0].([]entity.Reaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteReaction indicates an expected call of DeleteReaction.
+func (mr *MockReactionMockRecorder) DeleteReaction(in, messageID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteReaction", reflect.TypeOf((*MockReaction)(nil).DeleteReaction), in, messageID, userID)
+}
+
+// MockAttachment is a mock of Attachment interface.
+type MockAttachment struct {
+	ctrl     *gomock.Controller
+	recorder *MockAttachmentMockRecorder
+}
+
+// MockAttachmentMockRecorder is the mock recorder for MockAttachment.
+type MockAttachmentMockRecorder struct {
+	mock *MockAttachment
+}
+
+// NewMockAttachment creates a new mock instance.
+func NewMockAttachment(ctrl *gomock.Controller) *MockAttachment {
+	mock := &MockAttachment{ctrl: ctrl}
+	mock.recorder = &MockAttachmentMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAttachment) EXPECT() *MockAttachmentMockRecorder {
+	return m.recorder
+}
+
+// AddAttachment mocks base method.
+func (m *MockAttachment) AddAttachment(in dto.AttachmentAdd, messageID int64, userID int) (*entity.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddAttachment", in, messageID, userID)
+	ret0, _ := ret[0].(*entity.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddAttachment indicates an expected call of AddAttachment.
+func (mr *MockAttachmentMockRecorder) AddAttachment(in, messageID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddAttachment", reflect.TypeOf((*MockAttachment)(nil).AddAttachment), in, messageID, userID)
+}
+
+// GetAttachment mocks base method.
+func (m *MockAttachment) GetAttachment(attachmentID int64, userID int, thumbnail bool) (*entity.Attachment, io.ReadCloser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAttachment", attachmentID, userID, thumbnail)
+	ret0, _ := ret[0].(*entity.Attachment)
+	ret1, _ := ret[1].(io.ReadCloser)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetAttachment indicates an expected call of GetAttachment.
+func (mr *MockAttachmentMockRecorder) GetAttachment(attachmentID, userID, thumbnail any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAttachment", reflect.TypeOf((*MockAttachment)(nil).GetAttachment), attachmentID, userID, thumbnail)
+}
+
+// MaxUploadSize mocks base method.
+func (m *MockAttachment) MaxUploadSize() int64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MaxUploadSize")
+	ret0, _ := ret[0].(int64)
+	return ret0
+}
+
+// MaxUploadSize indicates an expected call of MaxUploadSize.
+func (mr *MockAttachmentMockRecorder) MaxUploadSize() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MaxUploadSize", reflect.TypeOf((*MockAttachment)(nil).MaxUploadSize))
+}
+
+// MockMention is a mock of Mention interface.
+type MockMention struct {
+	ctrl     *gomock.Controller
+	recorder *MockMentionMockRecorder
+}
+
+// MockMentionMockRecorder is the mock recorder for MockMention.
+type MockMentionMockRecorder struct {
+	mock *MockMention
+}
+
+// NewMockMention creates a new mock instance.
+func NewMockMention(ctrl *gomock.Controller) *MockMention {
+	mock := &MockMention{ctrl: ctrl}
+	mock.recorder = &MockMentionMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMention) EXPECT() *MockMentionMockRecorder {
+	return m.recorder
+}
+
+// GetMentions mocks base method.
+func (m *MockMention) GetMentions(in dto.MentionGet, userID int) ([]entity.Message, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMentions", in, userID)
+	ret0, _ := ret[0].([]entity.Message)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMentions indicates an expected call of GetMentions.
+func (mr *MockMentionMockRecorder) GetMentions(in, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMentions", reflect.TypeOf((*MockMention)(nil).GetMentions), in, userID)
+}
+
+// MockPin is a mock of Pin interface.
+type MockPin struct {
+	ctrl     *gomock.Controller
+	recorder *MockPinMockRecorder
+}
+
+// MockPinMockRecorder is the mock recorder for MockPin.
+type MockPinMockRecorder struct {
+	mock *MockPin
+}
+
+// NewMockPin creates a new mock instance.
+func NewMockPin(ctrl *gomock.Controller) *MockPin {
+	mock := &MockPin{ctrl: ctrl}
+	mock.recorder = &MockPinMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPin) EXPECT() *MockPinMockRecorder {
+	return m.recorder
+}
+
+// GetPins mocks base method.
+func (m *MockPin) GetPins(chatID int64, userID int) ([]entity.Message, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPins", chatID, userID)
+	ret0, _ := ret[0].([]entity.Message)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPins indicates an expected call of GetPins.
+func (mr *MockPinMockRecorder) GetPins(chatID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPins", reflect.TypeOf((*MockPin)(nil).GetPins), chatID, userID)
+}
+
+// PinMessage mocks base method.
+func (m *MockPin) PinMessage(messageID int64, userID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PinMessage", messageID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PinMessage indicates an expected call of PinMessage.
+func (mr *MockPinMockRecorder) PinMessage(messageID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PinMessage", reflect.TypeOf((*MockPin)(nil).PinMessage), messageID, userID)
+}
+
+// UnpinMessage mocks base method.
+func (m *MockPin) UnpinMessage(messageID int64, userID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnpinMessage", messageID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnpinMessage indicates an expected call of UnpinMessage.
+func (mr *MockPinMockRecorder) UnpinMessage(messageID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnpinMessage", reflect.TypeOf((*MockPin)(nil).UnpinMessage), messageID, userID)
+}
+
+// MockScheduled is a mock of Scheduled interface.
+type MockScheduled struct {
+	ctrl     *gomock.Controller
+	recorder *MockScheduledMockRecorder
+}
+
+// MockScheduledMockRecorder is the mock recorder for MockScheduled.
+type MockScheduledMockRecorder struct {
+	mock *MockScheduled
+}
+
+// NewMockScheduled creates a new mock instance.
+func NewMockScheduled(ctrl *gomock.Controller) *MockScheduled {
+	mock := &MockScheduled{ctrl: ctrl}
+	mock.recorder = &MockScheduledMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockScheduled) EXPECT() *MockScheduledMockRecorder {
+	return m.recorder
+}
+
+// CancelScheduled mocks base method.
+func (m *MockScheduled) CancelScheduled(id int64, userID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelScheduled", id, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CancelScheduled indicates an expected call of CancelScheduled.
+func (mr *MockScheduledMockRecorder) CancelScheduled(id, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelScheduled", reflect.TypeOf((*MockScheduled)(nil).CancelScheduled), id, userID)
+}
+
+// DispatchDue mocks base method.
+func (m *MockScheduled) DispatchDue() (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DispatchDue")
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DispatchDue indicates an expected call of DispatchDue.
+func (mr *MockScheduledMockRecorder) DispatchDue() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DispatchDue", reflect.TypeOf((*MockScheduled)(nil).DispatchDue))
+}
+
+// GetScheduled mocks base method.
+func (m *MockScheduled) GetScheduled(in dto.ScheduledGet, userID int) ([]entity.ScheduledMessage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetScheduled", in, userID)
+	ret0, _ := ret[0].([]entity.ScheduledMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetScheduled indicates an expected call of GetScheduled.
+func (mr *MockScheduledMockRecorder) GetScheduled(in, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetScheduled", reflect.TypeOf((*MockScheduled)(nil).GetScheduled), in, userID)
+}
+
+// ScheduleMessage mocks base method.
+func (m *MockScheduled) ScheduleMessage(in dto.MessageAdd) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ScheduleMessage", in)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ScheduleMessage indicates an expected call of ScheduleMessage.
+func (mr *MockScheduledMockRecorder) ScheduleMessage(in any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ScheduleMessage", reflect.TypeOf((*MockScheduled)(nil).ScheduleMessage), in)
+}
+
+// UpdateScheduled mocks base method.
+func (m *MockScheduled) UpdateScheduled(in dto.ScheduledUpdate, id int64, userID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateScheduled", in, id, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateScheduled indicates an expected call of UpdateScheduled.
+func (mr *MockScheduledMockRecorder) UpdateScheduled(in, id, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateScheduled", reflect.TypeOf((*MockScheduled)(nil).UpdateScheduled), in, id, userID)
+}
+
+// MockDraft is a mock of Draft interface.
+type MockDraft struct {
+	ctrl     *gomock.Controller
+	recorder *MockDraftMockRecorder
+}
+
+// MockDraftMockRecorder is the mock recorder for MockDraft.
+type MockDraftMockRecorder struct {
+	mock *MockDraft
+}
+
+// NewMockDraft creates a new mock instance.
+func NewMockDraft(ctrl *gomock.Controller) *MockDraft {
+	mock := &MockDraft{ctrl: ctrl}
+	mock.recorder = &MockDraftMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDraft) EXPECT() *MockDraftMockRecorder {
+	return m.recorder
+}
+
+// DeleteDraft mocks base method.
+func (m *MockDraft) DeleteDraft(in dto.DraftDelete, chatID int64, userID int) (*entity.Draft, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteDraft", in, chatID, userID)
+	ret0, _ := ret[0].(*entity.Draft)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// DeleteDraft indicates an expected call of DeleteDraft.
+func (mr *MockDraftMockRecorder) DeleteDraft(in, chatID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDraft", reflect.TypeOf((*MockDraft)(nil).DeleteDraft), in, chatID, userID)
+}
+
+// GetDraft mocks base method.
+func (m *MockDraft) GetDraft(chatID int64, userID int) (*entity.Draft, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDraft", chatID, userID)
+	ret0, _ := ret[0].(*entity.Draft)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDraft indicates an expected call of GetDraft.
+func (mr *MockDraftMockRecorder) GetDraft(chatID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDraft", reflect.TypeOf((*MockDraft)(nil).GetDraft), chatID, userID)
+}
+
+// SaveDraft mocks base method.
+func (m *MockDraft) SaveDraft(in dto.DraftSave, chatID int64, userID int) (*entity.Draft, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveDraft", in, chatID, userID)
+	ret0, _ := ret[0].(*entity.Draft)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// SaveDraft indicates an expected call of SaveDraft.
+func (mr *MockDraftMockRecorder) SaveDraft(in, chatID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveDraft", reflect.TypeOf((*MockDraft)(nil).SaveDraft), in, chatID, userID)
+}
+
+// MockPreview is a mock of Preview interface.
+type MockPreview struct {
+	ctrl     *gomock.Controller
+	recorder *MockPreviewMockRecorder
+}
+
+// MockPreviewMockRecorder is the mock recorder for MockPreview.
+type MockPreviewMockRecorder struct {
+	mock *MockPreview
+}
+
+// NewMockPreview creates a new mock instance.
+func NewMockPreview(ctrl *gomock.Controller) *MockPreview {
+	mock := &MockPreview{ctrl: ctrl}
+	mock.recorder = &MockPreviewMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPreview) EXPECT() *MockPreviewMockRecorder {
+	return m.recorder
+}
+
+// Enqueue mocks base method.
+func (m *MockPreview) Enqueue(messageID int64, text string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Enqueue", messageID, text)
+}
+
+// Enqueue indicates an expected call of Enqueue.
+func (mr *MockPreviewMockRecorder) Enqueue(messageID, text any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Enqueue", reflect.TypeOf((*MockPreview)(nil).Enqueue), messageID, text)
+}
+
+// Run mocks base method.
+func (m *MockPreview) Run(ctx context.Context, log *slog.Logger) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Run", ctx, log)
+}
+
+// Run indicates an expected call of Run.
+func (mr *MockPreviewMockRecorder) Run(ctx, log any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockPreview)(nil).Run), ctx, log)
+}
+
+// MockRealtime is a mock of Realtime interface.
+type MockRealtime struct {
+	ctrl     *gomock.Controller
+	recorder *MockRealtimeMockRecorder
+}
+
+// MockRealtimeMockRecorder is the mock recorder for MockRealtime.
+type MockRealtimeMockRecorder struct {
+	mock *MockRealtime
+}
+
+// NewMockRealtime creates a new mock instance.
+func NewMockRealtime(ctrl *gomock.Controller) *MockRealtime {
+	mock := &MockRealtime{ctrl: ctrl}
+	mock.recorder = &MockRealtimeMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRealtime) EXPECT() *MockRealtimeMockRecorder {
+	return m.recorder
+}
+
+// Resume mocks base method.
+func (m *MockRealtime) Resume(userID int, lastEventID uint64) (*realtime.Client, []realtime.Delivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Resume", userID, lastEventID)
+	ret0, _ := ret[0].(*realtime.Client)
+	ret1, _ := ret[1].([]realtime.Delivery)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Resume indicates an expected call of Resume.
+func (mr *MockRealtimeMockRecorder) Resume(userID, lastEventID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Resume", reflect.TypeOf((*MockRealtime)(nil).Resume), userID, lastEventID)
+}
+
+// Subscribe mocks base method.
+func (m *MockRealtime) Subscribe(userID int) (*realtime.Client, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Subscribe", userID)
+	ret0, _ := ret[0].(*realtime.Client)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Subscribe indicates an expected call of Subscribe.
+func (mr *MockRealtimeMockRecorder) Subscribe(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subscribe", reflect.TypeOf((*MockRealtime)(nil).Subscribe), userID)
+}
+
+// Unsubscribe mocks base method.
+func (m *MockRealtime) Unsubscribe(c *realtime.Client) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unsubscribe", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Unsubscribe indicates an expected call of Unsubscribe.
+func (mr *MockRealtimeMockRecorder) Unsubscribe(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unsubscribe", reflect.TypeOf((*MockRealtime)(nil).Unsubscribe), c)
+}
+
+// MockPresence is a mock of Presence interface.
+type MockPresence struct {
+	ctrl     *gomock.Controller
+	recorder *MockPresenceMockRecorder
+}
+
+// MockPresenceMockRecorder is the mock recorder for MockPresence.
+type MockPresenceMockRecorder struct {
+	mock *MockPresence
+}
+
+// NewMockPresence creates a new mock instance.
+func NewMockPresence(ctrl *gomock.Controller) *MockPresence {
+	mock := &MockPresence{ctrl: ctrl}
+	mock.recorder = &MockPresenceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPresence) EXPECT() *MockPresenceMockRecorder {
+	return m.recorder
+}
+
+// Connected mocks base method.
+func (m *MockPresence) Connected(userID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Connected", userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Connected indicates an expected call of Connected.
+func (mr *MockPresenceMockRecorder) Connected(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Connected", reflect.TypeOf((*MockPresence)(nil).Connected), userID)
+}
+
+// Disconnected mocks base method.
+func (m *MockPresence) Disconnected(userID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Disconnected", userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Disconnected indicates an expected call of Disconnected.
+func (mr *MockPresenceMockRecorder) Disconnected(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Disconnected", reflect.TypeOf((*MockPresence)(nil).Disconnected), userID)
+}
+
+// GetPresence mocks base method.
+func (m *MockPresence) GetPresence(chatID int64, userID int) ([]entity.Presence, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPresence", chatID, userID)
+	ret0, _ := ret[0].([]entity.Presence)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPresence indicates an expected call of GetPresence.
+func (mr *MockPresenceMockRecorder) GetPresence(chatID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPresence", reflect.TypeOf((*MockPresence)(nil).GetPresence), chatID, userID)
+}
+
+// Publish mocks base method.
+func (m *MockPresence) Publish(ev realtime.Event) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Publish", ev)
+}
+
+// Publish indicates an expected call of Publish.
+func (mr *MockPresenceMockRecorder) Publish(ev any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockPresence)(nil).Publish), ev)
+}
+
+// SetStatus mocks base method.
+func (m *MockPresence) SetStatus(in dto.PresenceSet, userID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetStatus", in, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetStatus indicates an expected call of SetStatus.
+func (mr *MockPresenceMockRecorder) SetStatus(in, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetStatus", reflect.TypeOf((*MockPresence)(nil).SetStatus), in, userID)
+}
+
+// StartTyping mocks base method.
+func (m *MockPresence) StartTyping(chatID int64, userID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartTyping", chatID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StartTyping indicates an expected call of StartTyping.
+func (mr *MockPresenceMockRecorder) StartTyping(chatID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartTyping", reflect.TypeOf((*MockPresence)(nil).StartTyping), chatID, userID)
+}
+
+// StopTyping mocks base method.
+func (m *MockPresence) StopTyping(chatID int64, userID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StopTyping", chatID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StopTyping indicates an expected call of StopTyping.
+func (mr *MockPresenceMockRecorder) StopTyping(chatID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StopTyping", reflect.TypeOf((*MockPresence)(nil).StopTyping), chatID, userID)
+}
+
+// MockWebhook is a mock of Webhook interface.
+type MockWebhook struct {
+	ctrl     *gomock.Controller
+	recorder *MockWebhookMockRecorder
+}
+
+// MockWebhookMockRecorder is the mock recorder for MockWebhook.
+type MockWebhookMockRecorder struct {
+	mock *MockWebhook
+}
+
+// NewMockWebhook creates a new mock instance.
+func NewMockWebhook(ctrl *gomock.Controller) *MockWebhook {
+	mock := &MockWebhook{ctrl: ctrl}
+	mock.recorder = &MockWebhookMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWebhook) EXPECT() *MockWebhookMockRecorder {
+	return m.recorder
+}
+
+// AddWebhook mocks base method.
+func (m *MockWebhook) AddWebhook(in dto.WebhookAdd, chatID int64, userID int) (*entity.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddWebhook", in, chatID, userID)
+	ret0, _ := ret[0].(*entity.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddWebhook indicates an expected call of AddWebhook.
+func (mr *MockWebhookMockRecorder) AddWebhook(in, chatID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddWebhook", reflect.TypeOf((*MockWebhook)(nil).AddWebhook), in, chatID, userID)
+}
+
+// DeleteWebhook mocks base method.
+func (m *MockWebhook) DeleteWebhook(webhookID, chatID int64, userID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWebhook", webhookID, chatID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteWebhook indicates an expected call of DeleteWebhook.
+func (mr *MockWebhookMockRecorder) DeleteWebhook(webhookID, chatID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWebhook", reflect.TypeOf((*MockWebhook)(nil).DeleteWebhook), webhookID, chatID, userID)
+}
+
+// GetAttempts mocks base method.
+func (m *MockWebhook) GetAttempts(in dto.WebhookAttemptsGet, webhookID, chatID int64, userID int) ([]entity.WebhookAttempt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAttempts", in, webhookID, chatID, userID)
+	ret0, _ := ret[0].([]entity.WebhookAttempt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAttempts indicates an expected call of GetAttempts.
+func (mr *MockWebhookMockRecorder) GetAttempts(in, webhookID, chatID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAttempts", reflect.TypeOf((*MockWebhook)(nil).GetAttempts), in, webhookID, chatID, userID)
+}
+
+// GetWebhooks mocks base method.
+func (m *MockWebhook) GetWebhooks(chatID int64, userID int) ([]entity.Webhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhooks", chatID, userID)
+	ret0, _ := ret[0].([]entity.Webhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWebhooks indicates an expected call of GetWebhooks.
+func (mr *MockWebhookMockRecorder) GetWebhooks(chatID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhooks", reflect.TypeOf((*MockWebhook)(nil).GetWebhooks), chatID, userID)
+}
+
+// Run mocks base method.
+func (m *MockWebhook) Run(ctx context.Context, log *slog.Logger) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Run", ctx, log)
+}
+
+// Run indicates an expected call of Run.
+func (mr *MockWebhookMockRecorder) Run(ctx, log any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockWebhook)(nil).Run), ctx, log)
+}
+
+// MockIncoming is a mock of Incoming interface.
+type MockIncoming struct {
+	ctrl     *gomock.Controller
+	recorder *MockIncomingMockRecorder
+}
+
+// MockIncomingMockRecorder is the mock recorder for MockIncoming.
+type MockIncomingMockRecorder struct {
+	mock *MockIncoming
+}
+
+// NewMockIncoming creates a new mock instance.
+func NewMockIncoming(ctrl *gomock.Controller) *MockIncoming {
+	mock := &MockIncoming{ctrl: ctrl}
+	mock.recorder = &MockIncomingMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIncoming) EXPECT() *MockIncomingMockRecorder {
+	return m.recorder
+}
+
+// AddIncoming mocks base method.
+func (m *MockIncoming) AddIncoming(in dto.IncomingAdd, chatID int64, userID int) (*entity.IncomingWebhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddIncoming", in, chatID, userID)
+	ret0, _ := ret[0].(*entity.IncomingWebhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddIncoming indicates an expected call of AddIncoming.
+func (mr *MockIncomingMockRecorder) AddIncoming(in, chatID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddIncoming", reflect.TypeOf((*MockIncoming)(nil).AddIncoming), in, chatID, userID)
+}
+
+// DeleteIncoming mocks base method.
+func (m *MockIncoming) DeleteIncoming(webhookID, chatID int64, userID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteIncoming", webhookID, chatID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteIncoming indicates an expected call of DeleteIncoming.
+func (mr *MockIncomingMockRecorder) DeleteIncoming(webhookID, chatID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteIncoming", reflect.TypeOf((*MockIncoming)(nil).DeleteIncoming), webhookID, chatID, userID)
+}
+
+// GetIncoming mocks base method.
+func (m *MockIncoming) GetIncoming(chatID int64, userID int) ([]entity.IncomingWebhook, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIncoming", chatID, userID)
+	ret0, _ := ret[0].([]entity.IncomingWebhook)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIncoming indicates an expected call of GetIncoming.
+func (mr *MockIncomingMockRecorder) GetIncoming(chatID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIncoming", reflect.TypeOf((*MockIncoming)(nil).GetIncoming), chatID, userID)
+}
+
+// PostIncoming mocks base method.
+func (m *MockIncoming) PostIncoming(token string, in dto.IncomingMessage) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PostIncoming", token, in)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PostIncoming indicates an expected call of PostIncoming.
+func (mr *MockIncomingMockRecorder) PostIncoming(token, in any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PostIncoming", reflect.TypeOf((*MockIncoming)(nil).PostIncoming), token, in)
+}
+
+// MockCommand is a mock of Command interface.
+type MockCommand struct {
+	ctrl     *gomock.Controller
+	recorder *MockCommandMockRecorder
+}
+
+// MockCommandMockRecorder is the mock recorder for MockCommand.
+type MockCommandMockRecorder struct {
+	mock *MockCommand
+}
+
+// NewMockCommand creates a new mock instance.
+func NewMockCommand(ctrl *gomock.Controller) *MockCommand {
+	mock := &MockCommand{ctrl: ctrl}
+	mock.recorder = &MockCommandMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCommand) EXPECT() *MockCommandMockRecorder {
+	return m.recorder
+}
+
+// AddCommand mocks base method.
+func (m *MockCommand) AddCommand(in dto.CommandAdd, chatID int64, userID int) (*entity.ChatCommand, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddCommand", in, chatID, userID)
+	ret0, _ := ret[0].(*entity.ChatCommand)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddCommand indicates an expected call of AddCommand.
+func (mr *MockCommandMockRecorder) AddCommand(in, chatID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddCommand", reflect.TypeOf((*MockCommand)(nil).AddCommand), in, chatID, userID)
+}
+
+// DeleteCommand mocks base method.
+func (m *MockCommand) DeleteCommand(commandID, chatID int64, userID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCommand", commandID, chatID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCommand indicates an expected call of DeleteCommand.
+func (mr *MockCommandMockRecorder) DeleteCommand(commandID, chatID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCommand", reflect.TypeOf((*MockCommand)(nil).DeleteCommand), commandID, chatID, userID)
+}
+
+// ExecuteCommand mocks base method.
+func (m *MockCommand) ExecuteCommand(in dto.MessageAdd) (*entity.CommandReply, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExecuteCommand", in)
+	ret0, _ := ret[0].(*entity.CommandReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExecuteCommand indicates an expected call of ExecuteCommand.
+func (mr *MockCommandMockRecorder) ExecuteCommand(in any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExecuteCommand", reflect.TypeOf((*MockCommand)(nil).ExecuteCommand), in)
+}
+
+// GetCommands mocks base method.
+func (m *MockCommand) GetCommands(chatID int64, userID int) ([]entity.ChatCommand, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCommands", chatID, userID)
+	ret0, _ := ret[0].([]entity.ChatCommand)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCommands indicates an expected call of GetCommands.
+func (mr *MockCommandMockRecorder) GetCommands(chatID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCommands", reflect.TypeOf((*MockCommand)(nil).GetCommands), chatID, userID)
+}
+
+// MockPoll is a mock of Poll interface.
+type MockPoll struct {
+	ctrl     *gomock.Controller
+	recorder *MockPollMockRecorder
+}
+
+// MockPollMockRecorder is the mock recorder for MockPoll.
+type MockPollMockRecorder struct {
+	mock *MockPoll
+}
+
+// NewMockPoll creates a new mock instance.
+func NewMockPoll(ctrl *gomock.Controller) *MockPoll {
+	mock := &MockPoll{ctrl: ctrl}
+	mock.recorder = &MockPollMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPoll) EXPECT() *MockPollMockRecorder {
+	return m.recorder
+}
+
+// CreatePoll mocks base method.
+func (m *MockPoll) CreatePoll(in dto.PollAdd, chatID int64, userID int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePoll", in, chatID, userID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePoll indicates an expected call of CreatePoll.
+func (mr *MockPollMockRecorder) CreatePoll(in, chatID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePoll", reflect.TypeOf((*MockPoll)(nil).CreatePoll), in, chatID, userID)
+}
+
+// Unvote mocks base method.
+func (m *MockPoll) Unvote(messageID int64, userID int) (*entity.Poll, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unvote", messageID, userID)
+	ret0, _ := ret[0].(*entity.Poll)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Unvote indicates an expected call of Unvote.
+func (mr *MockPollMockRecorder) Unvote(messageID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unvote", reflect.TypeOf((*MockPoll)(nil).Unvote), messageID, userID)
+}
+
+// Vote mocks base method.
+func (m *MockPoll) Vote(in dto.PollVote, messageID int64, userID int) (*entity.Poll, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Vote", in, messageID, userID)
+	ret0, _ := ret[0].(*entity.Poll)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Vote indicates an expected call of Vote.
+func (mr *MockPollMockRecorder) Vote(in, messageID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Vote", reflect.TypeOf((*MockPoll)(nil).Vote), in, messageID, userID)
+}
+
+// MockNotification is a mock of Notification interface.
+type MockNotification struct {
+	ctrl     *gomock.Controller
+	recorder *MockNotificationMockRecorder
+}
+
+// MockNotificationMockRecorder is the mock recorder for MockNotification.
+type MockNotificationMockRecorder struct {
+	mock *MockNotification
+}
+
+// NewMockNotification creates a new mock instance.
+func NewMockNotification(ctrl *gomock.Controller) *MockNotification {
+	mock := &MockNotification{ctrl: ctrl}
+	mock.recorder = &MockNotificationMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNotification) EXPECT() *MockNotificationMockRecorder {
+	return m.recorder
+}
+
+// Enqueue mocks base method.
+func (m *MockNotification) Enqueue(chatID, messageID, authorID int64) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Enqueue", chatID, messageID, authorID)
+}
+
+// Enqueue indicates an expected call of Enqueue.
+func (mr *MockNotificationMockRecorder) Enqueue(chatID, messageID, authorID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Enqueue", reflect.TypeOf((*MockNotification)(nil).Enqueue), chatID, messageID, authorID)
+}
+
+// GetSettings mocks base method.
+func (m *MockNotification) GetSettings(userID int) (*entity.NotifySettings, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSettings", userID)
+	ret0, _ := ret[0].(*entity.NotifySettings)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSettings indicates an expected call of GetSettings.
+func (mr *MockNotificationMockRecorder) GetSettings(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSettings", reflect.TypeOf((*MockNotification)(nil).GetSettings), userID)
+}
+
+// Run mocks base method.
+func (m *MockNotification) Run(ctx context.Context, log *slog.Logger) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Run", ctx, log)
+}
+
+// Run indicates an expected call of Run.
+func (mr *MockNotificationMockRecorder) Run(ctx, log any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Run", reflect.TypeOf((*MockNotification)(nil).Run), ctx, log)
+}
+
+// SaveSettings mocks base method.
+func (m *MockNotification) SaveSettings(in dto.NotifySettings, userID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveSettings", in, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveSettings indicates an expected call of SaveSettings.
+func (mr *MockNotificationMockRecorder) SaveSettings(in, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveSettings", reflect.TypeOf((*MockNotification)(nil).SaveSettings), in, userID)
+}
+
+// SetChatNotify mocks base method.
+func (m *MockNotification) SetChatNotify(in dto.ChatNotify, chatID int64, userID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetChatNotify", in, chatID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetChatNotify indicates an expected call of SetChatNotify.
+func (mr *MockNotificationMockRecorder) SetChatNotify(in, chatID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetChatNotify", reflect.TypeOf((*MockNotification)(nil).SetChatNotify), in, chatID, userID)
+}
+
+// MockBlock is a mock of Block interface.
+type MockBlock struct {
+	ctrl     *gomock.Controller
+	recorder *MockBlockMockRecorder
+}
+
+// MockBlockMockRecorder is the mock recorder for MockBlock.
+type MockBlockMockRecorder struct {
+	mock *MockBlock
+}
+
+// NewMockBlock creates a new mock instance.
+func NewMockBlock(ctrl *gomock.Controller) *MockBlock {
+	mock := &MockBlock{ctrl: ctrl}
+	mock.recorder = &MockBlockMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBlock) EXPECT() *MockBlockMockRecorder {
+	return m.recorder
+}
+
+// AddBlock mocks base method.
+func (m *MockBlock) AddBlock(in dto.BlockAdd, userID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddBlock", in, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddBlock indicates an expected call of AddBlock.
+func (mr *MockBlockMockRecorder) AddBlock(in, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddBlock", reflect.TypeOf((*MockBlock)(nil).AddBlock), in, userID)
+}
+
+// DeleteBlock mocks base method.
+func (m *MockBlock) DeleteBlock(blockedID int64, userID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBlock", blockedID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteBlock indicates an expected call of DeleteBlock.
+func (mr *MockBlockMockRecorder) DeleteBlock(blockedID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBlock", reflect.TypeOf((*MockBlock)(nil).DeleteBlock), blockedID, userID)
+}
+
+// GetBlocks mocks base method.
+func (m *MockBlock) GetBlocks(userID int) ([]entity.Block, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBlocks", userID)
+	ret0, _ := ret[0].([]entity.Block)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBlocks indicates an expected call of GetBlocks.
+func (mr *MockBlockMockRecorder) GetBlocks(userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlocks", reflect.TypeOf((*MockBlock)(nil).GetBlocks), userID)
+}
+
+// MockModeration is a mock of Moderation interface.
+type MockModeration struct {
+	ctrl     *gomock.Controller
+	recorder *MockModerationMockRecorder
+}
+
+// MockModerationMockRecorder is the mock recorder for MockModeration.
+type MockModerationMockRecorder struct {
+	mock *MockModeration
+}
+
+// NewMockModeration creates a new mock instance.
+func NewMockModeration(ctrl *gomock.Controller) *MockModeration {
+	mock := &MockModeration{ctrl: ctrl}
+	mock.recorder = &MockModerationMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockModeration) EXPECT() *MockModerationMockRecorder {
+	return m.recorder
+}
+
+// GetActions mocks base method.
+func (m *MockModeration) GetActions(in dto.ActionsGet, userID int) ([]entity.ModerationAction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActions", in, userID)
+	ret0, _ := ret[0].([]entity.ModerationAction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActions indicates an expected call of GetActions.
+func (mr *MockModerationMockRecorder) GetActions(in, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActions", reflect.TypeOf((*MockModeration)(nil).GetActions), in, userID)
+}
+
+// GetReports mocks base method.
+func (m *MockModeration) GetReports(in dto.ReportsGet, userID int) ([]entity.Report, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReports", in, userID)
+	ret0, _ := ret[0].([]entity.Report)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReports indicates an expected call of GetReports.
+func (mr *MockModerationMockRecorder) GetReports(in, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReports", reflect.TypeOf((*MockModeration)(nil).GetReports), in, userID)
+}
+
+// Moderate mocks base method.
+func (m *MockModeration) Moderate(in dto.ModerationAdd, reportID int64, userID int) (*entity.ModerationAction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Moderate", in, reportID, userID)
+	ret0, _ := ret[0].(*entity.ModerationAction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Moderate indicates an expected call of Moderate.
+func (mr *MockModerationMockRecorder) Moderate(in, reportID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Moderate", reflect.TypeOf((*MockModeration)(nil).Moderate), in, reportID, userID)
+}
+
+// ReportMessage mocks base method.
+func (m *MockModeration) ReportMessage(in dto.ReportAdd, messageID int64, userID int) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReportMessage", in, messageID, userID)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReportMessage indicates an expected call of ReportMessage.
+func (mr *MockModerationMockRecorder) ReportMessage(in, messageID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReportMessage", reflect.TypeOf((*MockModeration)(nil).ReportMessage), in, messageID, userID)
+}
+
+// MockFilter is a mock of Filter interface.
+type MockFilter struct {
+	ctrl     *gomock.Controller
+	recorder *MockFilterMockRecorder
+}
+
+// MockFilterMockRecorder is the mock recorder for MockFilter.
+type MockFilterMockRecorder struct {
+	mock *MockFilter
+}
+
+// NewMockFilter creates a new mock instance.
+func NewMockFilter(ctrl *gomock.Controller) *MockFilter {
+	mock := &MockFilter{ctrl: ctrl}
+	mock.recorder = &MockFilterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFilter) EXPECT() *MockFilterMockRecorder {
+	return m.recorder
+}
+
+// CheckText mocks base method.
+func (m *MockFilter) CheckText(chatID int64, text string) (filter.Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckText", chatID, text)
+	ret0, _ := ret[0].(filter.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CheckText indicates an expected call of CheckText.
+func (mr *MockFilterMockRecorder) CheckText(chatID, text any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckText", reflect.TypeOf((*MockFilter)(nil).CheckText), chatID, text)
+}
+
+// GetChatFilters mocks base method.
+func (m *MockFilter) GetChatFilters(chatID int64, userID int) ([]entity.ChatFilter, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChatFilters", chatID, userID)
+	ret0, _ := ret[0].([]entity.ChatFilter)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChatFilters indicates an expected call of GetChatFilters.
+func (mr *MockFilterMockRecorder) GetChatFilters(chatID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChatFilters", reflect.TypeOf((*MockFilter)(nil).GetChatFilters), chatID, userID)
+}
+
+// SetChatFilter mocks base method.
+func (m *MockFilter) SetChatFilter(in dto.ChatFilterSet, chatID int64, userID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetChatFilter", in, chatID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetChatFilter indicates an expected call of SetChatFilter.
+func (mr *MockFilterMockRecorder) SetChatFilter(in, chatID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetChatFilter", reflect.TypeOf((*MockFilter)(nil).SetChatFilter), in, chatID, userID)
+}
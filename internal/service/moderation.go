@@ -0,0 +1,152 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"service-chat/internal/apperr"
+	"service-chat/internal/config"
+	"service-chat/internal/db"
+	"service-chat/internal/db/entity"
+	"service-chat/internal/dto"
+	"service-chat/internal/realtime"
+)
+
+const (
+	defaultModerationLimit = 20
+)
+
+// reportReasons - причины, по которым можно пожаловаться на сообщение
+var reportReasons = []string{
+	entity.ReportSpam, entity.ReportHarassment, entity.ReportHate,
+	entity.ReportViolence, entity.ReportSexual, entity.ReportOther,
+}
+
+// ModerationService - жалобы участников чатов на сообщения и их разбор модераторами
+type ModerationService struct {
+	repo   db.Moderation
+	events realtime.Publisher
+	cfg    config.Moderation
+	// now - текущее время, подменяется в тестах
+	now func() time.Time
+}
+
+func NewModerationService(repo db.Moderation, events realtime.Publisher, cfg config.Moderation) *ModerationService {
+	return &ModerationService{repo: repo, events: events, cfg: cfg, now: time.Now}
+}
+
+// ReportMessage - жалоба участника чата на сообщение, возвращаем id жалобы
+func (s *ModerationService) ReportMessage(in dto.ReportAdd, messageID int64, userID int) (int64, error) {
+	// Если запрос пустой
+	if messageID == 0 || userID == 0 {
+		return 0, apperr.Validation("empty message_id or user_id")
+	}
+
+	var valid bool
+	for _, reason := range reportReasons {
+		if in.Reason == reason {
+			valid = true
+			break
+		}
+	}
+	if !valid {
+		return 0, apperr.Validation("invalid reason")
+	}
+
+	dataDB := entity.ReportAdd{
+		MessageID: messageID,
+		UserID:    userID,
+		Reason:    in.Reason,
+		Comment:   in.Comment,
+	}
+	return s.repo.AddReport(dataDB)
+}
+
+// GetReports - очередь жалоб, доступно модераторам
+func (s *ModerationService) GetReports(in dto.ReportsGet, userID int) ([]entity.Report, error) {
+	// Если запрос пустой
+	if userID == 0 {
+		return nil, apperr.Validation("empty user_id")
+	}
+
+	dataDB := entity.ReportsGet{
+		UserID: userID,
+		Status: in.Status,
+		Limit:  in.Limit,
+		Offset: in.Offset,
+	}
+	if dataDB.Status == "" {
+		dataDB.Status = entity.ReportOpen
+	}
+	if dataDB.Limit == 0 {
+		dataDB.Limit = defaultModerationLimit
+	}
+	return s.repo.GetReports(dataDB)
+}
+
+// Moderate - действие модератора по жалобе, доступно модераторам. Скрытое сообщение пропадает у участников чата
+func (s *ModerationService) Moderate(in dto.ModerationAdd, reportID int64, userID int) (*entity.ModerationAction, error) {
+	// Если запрос пустой
+	if reportID == 0 || userID == 0 {
+		return nil, apperr.Validation("empty report_id or user_id")
+	}
+
+	dataDB := entity.ModerationAdd{
+		ReportID: reportID,
+		UserID:   userID,
+		Action:   in.Action,
+		Note:     in.Note,
+	}
+	switch in.Action {
+	case entity.ActionSuspend:
+		duration, err := time.ParseDuration(in.Duration)
+		if err != nil || duration <= 0 {
+			return nil, apperr.Validation("suspend requires a positive duration, e.g. 24h")
+		}
+		if s.cfg.MaxSuspension > 0 && duration > s.cfg.MaxSuspension {
+			return nil, apperr.Validation(fmt.Sprintf("duration cannot exceed %s", s.cfg.MaxSuspension))
+		}
+		until := s.now().Add(duration)
+		dataDB.SuspendedUntil = &until
+	case entity.ActionHide, entity.ActionWarn, entity.ActionDismiss:
+		if in.Duration != "" {
+			return nil, apperr.Validation("duration is allowed only for suspend")
+		}
+	default:
+		return nil, apperr.Validation("invalid action")
+	}
+
+	action, err := s.repo.ModerateReport(dataDB)
+	if err != nil {
+		return nil, err
+	}
+
+	if action.Action == entity.ActionHide && action.ChatID != 0 {
+		s.events.Publish(realtime.Event{
+			Type:   realtime.EventMessageDeleted,
+			ChatID: action.ChatID,
+			Data:   realtime.MessagePayload{MessageID: action.MessageID},
+		})
+	}
+
+	return action, nil
+}
+
+// GetActions - журнал действий модераторов, доступно модераторам
+func (s *ModerationService) GetActions(in dto.ActionsGet, userID int) ([]entity.ModerationAction, error) {
+	// Если запрос пустой
+	if userID == 0 {
+		return nil, apperr.Validation("empty user_id")
+	}
+
+	dataDB := entity.ActionsGet{
+		UserID:   userID,
+		TargetID: in.UserID,
+		Limit:    in.Limit,
+		Offset:   in.Offset,
+	}
+	if dataDB.Limit == 0 {
+		dataDB.Limit = defaultModerationLimit
+	}
+	return s.repo.GetActions(dataDB)
+}
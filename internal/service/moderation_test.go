@@ -0,0 +1,153 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"service-chat/internal/apperr"
+	"service-chat/internal/config"
+	"service-chat/internal/db/entity"
+	mockRepo "service-chat/internal/db/mocks"
+	"service-chat/internal/dto"
+	"service-chat/internal/realtime"
+)
+
+func TestModerationService_ReportMessage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockModeration := mockRepo.NewMockModeration(ctrl)
+	serviceModeration := NewModerationService(mockModeration, &fakePublisher{}, config.Moderation{})
+
+	mockModeration.EXPECT().AddReport(entity.ReportAdd{MessageID: 5, UserID: 1, Reason: entity.ReportSpam, Comment: "ads"}).Return(int64(3), nil)
+	id, err := serviceModeration.ReportMessage(dto.ReportAdd{Reason: "spam", Comment: "ads"}, 5, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), id)
+
+	mockModeration.EXPECT().AddReport(entity.ReportAdd{MessageID: 5, UserID: 1, Reason: entity.ReportOther}).
+		Return(int64(0), errors.New("Message already reported"))
+	_, err = serviceModeration.ReportMessage(dto.ReportAdd{Reason: "other"}, 5, 1)
+	assert.Equal(t, errors.New("Message already reported"), err)
+
+	_, err = serviceModeration.ReportMessage(dto.ReportAdd{Reason: "boring"}, 5, 1)
+	assert.Equal(t, apperr.Validation("invalid reason"), err)
+	_, err = serviceModeration.ReportMessage(dto.ReportAdd{Reason: "spam"}, 0, 1)
+	assert.Equal(t, apperr.Validation("empty message_id or user_id"), err)
+}
+
+func TestModerationService_GetReports(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockModeration := mockRepo.NewMockModeration(ctrl)
+	serviceModeration := NewModerationService(mockModeration, &fakePublisher{}, config.Moderation{})
+
+	// По умолчанию отдаём открытые жалобы первой страницей
+	mockModeration.EXPECT().GetReports(entity.ReportsGet{UserID: 1, Status: entity.ReportOpen, Limit: 20}).
+		Return([]entity.Report{{ID: 3, MessageID: 5, Reports: 2}}, nil)
+	reports, err := serviceModeration.GetReports(dto.ReportsGet{}, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, []entity.Report{{ID: 3, MessageID: 5, Reports: 2}}, reports)
+
+	mockModeration.EXPECT().GetReports(entity.ReportsGet{UserID: 2, Status: entity.ReportDismissed, Limit: 5, Offset: 10}).
+		Return(nil, errors.New("User with userID 2 is not a moderator"))
+	_, err = serviceModeration.GetReports(dto.ReportsGet{Status: "dismissed", Limit: 5, Offset: 10}, 2)
+	assert.Equal(t, errors.New("User with userID 2 is not a moderator"), err)
+
+	_, err = serviceModeration.GetReports(dto.ReportsGet{}, 0)
+	assert.Equal(t, apperr.Validation("empty user_id"), err)
+}
+
+func TestModerationService_Moderate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockModeration := mockRepo.NewMockModeration(ctrl)
+	events := &fakePublisher{}
+	serviceModeration := NewModerationService(mockModeration, events, config.Moderation{MaxSuspension: 720 * time.Hour})
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	serviceModeration.now = func() time.Time { return now }
+
+	t.Run("Suspend", func(t *testing.T) {
+		until := now.Add(24 * time.Hour)
+		action := &entity.ModerationAction{ID: 1, Action: entity.ActionSuspend, ReportID: 3, MessageID: 5, UserID: 7,
+			SuspendedUntil: "2026-10-20 12:00:00"}
+		mockModeration.EXPECT().ModerateReport(entity.ModerationAdd{ReportID: 3, UserID: 1, Action: entity.ActionSuspend,
+			Note: "flood", SuspendedUntil: &until}).Return(action, nil)
+
+		got, err := serviceModeration.Moderate(dto.ModerationAdd{Action: "suspend", Duration: "24h", Note: "flood"}, 3, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, action, got)
+		assert.Empty(t, events.events)
+	})
+
+	t.Run("Hide publishes deletion", func(t *testing.T) {
+		action := &entity.ModerationAction{ID: 2, Action: entity.ActionHide, ReportID: 3, MessageID: 5, ChatID: 4, UserID: 7}
+		mockModeration.EXPECT().ModerateReport(entity.ModerationAdd{ReportID: 3, UserID: 1, Action: entity.ActionHide}).
+			Return(action, nil)
+
+		_, err := serviceModeration.Moderate(dto.ModerationAdd{Action: "hide"}, 3, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, []realtime.Event{{Type: realtime.EventMessageDeleted, ChatID: 4,
+			Data: realtime.MessagePayload{MessageID: 5}}}, events.events)
+	})
+
+	t.Run("Invalid duration", func(t *testing.T) {
+		_, err := serviceModeration.Moderate(dto.ModerationAdd{Action: "suspend"}, 3, 1)
+		assert.Equal(t, apperr.Validation("suspend requires a positive duration, e.g. 24h"), err)
+		_, err = serviceModeration.Moderate(dto.ModerationAdd{Action: "suspend", Duration: "-1h"}, 3, 1)
+		assert.Equal(t, apperr.Validation("suspend requires a positive duration, e.g. 24h"), err)
+		_, err = serviceModeration.Moderate(dto.ModerationAdd{Action: "suspend", Duration: "721h"}, 3, 1)
+		assert.Equal(t, apperr.Validation("duration cannot exceed 720h0m0s"), err)
+		_, err = serviceModeration.Moderate(dto.ModerationAdd{Action: "warn", Duration: "1h"}, 3, 1)
+		assert.Equal(t, apperr.Validation("duration is allowed only for suspend"), err)
+	})
+
+	t.Run("Invalid request", func(t *testing.T) {
+		_, err := serviceModeration.Moderate(dto.ModerationAdd{Action: "ban"}, 3, 1)
+		assert.Equal(t, apperr.Validation("invalid action"), err)
+		_, err = serviceModeration.Moderate(dto.ModerationAdd{Action: "warn"}, 0, 1)
+		assert.Equal(t, apperr.Validation("empty report_id or user_id"), err)
+	})
+}
+
+func TestModerationService_GetActions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockModeration := mockRepo.NewMockModeration(ctrl)
+	serviceModeration := NewModerationService(mockModeration, &fakePublisher{}, config.Moderation{})
+
+	mockModeration.EXPECT().GetActions(entity.ActionsGet{UserID: 1, TargetID: 7, Limit: 20}).
+		Return([]entity.ModerationAction{{ID: 1, Action: entity.ActionWarn, UserID: 7}}, nil)
+	actions, err := serviceModeration.GetActions(dto.ActionsGet{UserID: 7}, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, []entity.ModerationAction{{ID: 1, Action: entity.ActionWarn, UserID: 7}}, actions)
+
+	_, err = serviceModeration.GetActions(dto.ActionsGet{}, 0)
+	assert.Equal(t, apperr.Validation("empty user_id"), err)
+}
+
+func TestAuthService_CheckSuspension(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAuth := mockRepo.NewMockAuthorization(ctrl)
+	serviceAuth := NewAuthService(mockAuth)
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	serviceAuth.now = func() time.Time { return now }
+
+	future, past := now.Add(time.Hour), now.Add(-time.Hour)
+	mockAuth.EXPECT().GetSuspension(1).Return(&future, nil)
+	assert.EqualError(t, serviceAuth.CheckSuspension(1), "User is suspended until 2026-10-19T13:00:00Z")
+
+	mockAuth.EXPECT().GetSuspension(1).Return(&past, nil)
+	assert.NoError(t, serviceAuth.CheckSuspension(1))
+
+	mockAuth.EXPECT().GetSuspension(2).Return(nil, nil)
+	assert.NoError(t, serviceAuth.CheckSuspension(2))
+}
@@ -0,0 +1,393 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/mail"
+	"slices"
+	"sync"
+	"time"
+
+	"service-chat/internal/apperr"
+	"service-chat/internal/config"
+	"service-chat/internal/db"
+	"service-chat/internal/db/entity"
+	"service-chat/internal/dto"
+	"service-chat/internal/logger"
+	"service-chat/internal/notify"
+)
+
+const (
+	// defaultNotifyInterval, defaultNotifyTimeout - значения, если в конфиге они не заданы
+	defaultNotifyInterval = 10 * time.Second
+	defaultNotifyTimeout  = 10 * time.Second
+)
+
+// notificationJob - новое сообщение, для которого нужно выбрать получателей уведомлений
+type notificationJob struct {
+	chatID    int64
+	messageID int64
+	authorID  int64
+}
+
+// NotificationService - уведомления о сообщениях для пользователей не в сети. Получатели выбираются в фоне
+// после отправки сообщения с учётом заглушения чата и упоминаний, уведомления пишутся в очередь в бд,
+// копятся в сводки и отправляются по каналам: почта, push. Упоминания отправляются без ожидания сводки
+type NotificationService struct {
+	repo db.Notification
+	// notifiers - каналы уведомлений по имени, канал без настроек не используется
+	notifiers map[string]notify.Notifier
+	// online - пользователь в сети, ему уведомления не нужны
+	online func(userID int64) bool
+	cfg    config.Notifications
+	queue  chan notificationJob
+	// now - текущее время, подменяется в тестах
+	now func() time.Time
+}
+
+func NewNotificationService(repo db.Notification, notifiers []notify.Notifier, online func(userID int64) bool,
+	cfg config.Notifications) *NotificationService {
+	if cfg.Interval <= 0 {
+		cfg.Interval = defaultNotifyInterval
+	}
+	queueSize := cfg.QueueSize
+	if queueSize <= 0 {
+		queueSize = 1
+	}
+
+	byChannel := make(map[string]notify.Notifier, len(notifiers))
+	for _, notifier := range notifiers {
+		byChannel[notifier.Channel()] = notifier
+	}
+
+	return &NotificationService{
+		repo:      repo,
+		notifiers: byChannel,
+		online:    online,
+		cfg:       cfg,
+		queue:     make(chan notificationJob, queueSize),
+		now:       time.Now,
+	}
+}
+
+// GetSettings - адреса, на которые пользователь получает уведомления
+func (s *NotificationService) GetSettings(userID int) (*entity.NotifySettings, error) {
+	// Если запрос пустой
+	if userID == 0 {
+		return nil, apperr.Validation("empty user_id")
+	}
+
+	return s.repo.GetNotifySettings(userID)
+}
+
+// SaveSettings - сохраняем адреса уведомлений пользователя, пустой адрес отключает канал
+func (s *NotificationService) SaveSettings(in dto.NotifySettings, userID int) error {
+	// Если запрос пустой
+	if userID == 0 {
+		return apperr.Validation("empty user_id")
+	}
+
+	// Принимаем только сам адрес, без имени получателя
+	if in.Email != "" {
+		if addr, err := mail.ParseAddress(in.Email); err != nil || addr.Address != in.Email {
+			return apperr.Validation("invalid email")
+		}
+	}
+
+	dataDB := entity.NotifySettingsSave{
+		UserID:    userID,
+		Email:     in.Email,
+		PushToken: in.PushToken,
+	}
+	return s.repo.SaveNotifySettings(dataDB)
+}
+
+// SetChatNotify - меняем уровень уведомлений чата для участника и заглушаем чат до MutedUntil
+func (s *NotificationService) SetChatNotify(in dto.ChatNotify, chatID int64, userID int) error {
+	// Если запрос пустой
+	if chatID == 0 || userID == 0 {
+		return apperr.Validation("empty chat_id or user_id")
+	}
+
+	if in.Level != entity.NotifyAll && in.Level != entity.NotifyMentions && in.Level != entity.NotifyNone {
+		return apperr.Validation("invalid level")
+	}
+	if in.MutedUntil != nil && !in.MutedUntil.After(s.now()) {
+		return apperr.Validation("muted_until must be in the future")
+	}
+
+	dataDB := entity.ChatNotifySet{
+		ChatID:     chatID,
+		UserID:     userID,
+		Level:      in.Level,
+		MutedUntil: in.MutedUntil,
+	}
+	return s.repo.SetChatNotify(dataDB)
+}
+
+// Enqueue - ставим новое сообщение в очередь на выбор получателей уведомлений.
+// Не блокирует отправку сообщения: если очередь переполнена, уведомления о сообщении не создаются
+func (s *NotificationService) Enqueue(chatID, messageID, authorID int64) {
+	if s.cfg.Disabled || len(s.notifiers) == 0 || messageID == 0 {
+		return
+	}
+
+	select {
+	case s.queue <- notificationJob{chatID: chatID, messageID: messageID, authorID: authorID}:
+	default:
+	}
+}
+
+// Run - выбираем получателей новых сообщений и периодически отправляем накопленные уведомления,
+// пока не будет отменён контекст
+func (s *NotificationService) Run(ctx context.Context, log *slog.Logger) {
+	if s.cfg.Disabled || len(s.notifiers) == 0 {
+		return
+	}
+	log = log.With(slog.String("op", "service.NotificationService"))
+
+	ticker := time.NewTicker(s.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case job := <-s.queue:
+			if err := s.process(job); err != nil {
+				log.Error("failed to create notifications", slog.Int64("messageID", job.messageID), logger.Err(err))
+			}
+		case <-ticker.C:
+			sent, err := s.DeliverDue(ctx)
+			if err != nil {
+				log.Error("failed to deliver notifications", logger.Err(err))
+			}
+			if sent > 0 {
+				log.Info("notifications delivered", slog.Int("count", sent))
+			}
+		}
+	}
+}
+
+// process - создаём уведомления о сообщении по всем каналам, для которых у получателя задан адрес.
+// Пользователи в сети видят сообщение сами, поэтому их не уведомляем
+func (s *NotificationService) process(job notificationJob) error {
+	now := s.now()
+	recipients, err := s.repo.GetRecipients(entity.RecipientsGet{
+		ChatID:    job.chatID,
+		MessageID: job.messageID,
+		AuthorID:  job.authorID,
+		Now:       now,
+	})
+	if err != nil {
+		return err
+	}
+
+	var notifications []entity.NotificationAdd
+	for _, recipient := range recipients {
+		if s.online != nil && s.online(recipient.UserID) {
+			continue
+		}
+		for _, channel := range s.channels() {
+			if recipientAddress(recipient, channel) == "" {
+				continue
+			}
+			// Упоминание отправляем сразу, остальные сообщения ждут сводки
+			sendAfter := now
+			if !recipient.Mention {
+				sendAfter = now.Add(s.digestInterval(channel))
+			}
+			notifications = append(notifications, entity.NotificationAdd{
+				UserID:    recipient.UserID,
+				Channel:   channel,
+				ChatID:    job.chatID,
+				MessageID: job.messageID,
+				Mention:   recipient.Mention,
+				SendAfter: sendAfter,
+			})
+		}
+	}
+
+	return s.repo.AddNotifications(notifications)
+}
+
+// DeliverDue - отправляем сводки пользователям, у которых наступило время хотя бы одного уведомления,
+// и возвращаем количество отправленных сводок. В сводку попадают все ожидающие уведомления пользователя
+// в канале, сводки отправляются параллельно. После неудачи следующая попытка откладывается
+// с экспоненциальной паузой, после MaxAttempts неудач уведомления переводятся в статус failed
+func (s *NotificationService) DeliverDue(ctx context.Context) (int, error) {
+	var sent int
+	var errs []error
+	for _, channel := range s.channels() {
+		n, err := s.deliverChannel(ctx, s.notifiers[channel])
+		sent += n
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return sent, errors.Join(errs...)
+}
+
+// deliverChannel - отправляем сводки одного канала
+func (s *NotificationService) deliverChannel(ctx context.Context, notifier notify.Notifier) (int, error) {
+	timeout := s.timeout(notifier.Channel())
+	now := s.now()
+	jobs, err := s.repo.ClaimNotifications(entity.NotificationClaim{
+		Channel: notifier.Channel(),
+		Now:     now,
+		// Сводки отправляются параллельно, поэтому двух таймаутов отправки хватает на всю выборку
+		LeaseUntil: now.Add(2 * timeout),
+		Limit:      s.cfg.BatchSize,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	// Уведомления из бд отсортированы по пользователю
+	var groups [][]entity.NotificationJob
+	for i, job := range jobs {
+		if i == 0 || jobs[i-1].UserID != job.UserID {
+			groups = append(groups, nil)
+		}
+		groups[len(groups)-1] = append(groups[len(groups)-1], job)
+	}
+
+	results := make([][]entity.NotificationResult, len(groups))
+	var wg sync.WaitGroup
+	for i, group := range groups {
+		wg.Add(1)
+		go func(i int, group []entity.NotificationJob) {
+			defer wg.Done()
+			results[i] = s.send(ctx, notifier, timeout, group)
+		}(i, group)
+	}
+	wg.Wait()
+
+	var sent int
+	var errs []error
+	for _, groupResults := range results {
+		for _, result := range groupResults {
+			// Попытку, прерванную остановкой сервиса, не записываем: сводка будет отправлена повторно после LeaseUntil
+			if result.Status == entity.NotificationPending && ctx.Err() != nil {
+				continue
+			}
+			if errSave := s.repo.CompleteNotifications(result); errSave != nil {
+				errs = append(errs, errSave)
+				continue
+			}
+			if result.Status == entity.NotificationSent {
+				sent++
+			}
+		}
+	}
+
+	return sent, errors.Join(errs...)
+}
+
+// send - одна попытка отправки сводки пользователю и результаты для его уведомлений.
+// Уведомления об удалённых сообщениях и уведомления без адреса не отправляются
+func (s *NotificationService) send(ctx context.Context, notifier notify.Notifier, timeout time.Duration,
+	group []entity.NotificationJob) []entity.NotificationResult {
+	skipped := entity.NotificationResult{Status: entity.NotificationSkipped, SendAfter: s.now()}
+	digest := notify.Digest{UserID: group[0].UserID, Address: group[0].Address}
+	var ids []int64
+	var attempts int
+	for _, job := range group {
+		if job.IsDeleted || job.Address == "" {
+			skipped.IDs = append(skipped.IDs, job.ID)
+			continue
+		}
+		ids = append(ids, job.ID)
+		attempts = max(attempts, job.Attempts)
+		if s.cfg.MaxItems > 0 && len(digest.Items) >= s.cfg.MaxItems {
+			digest.More++
+			continue
+		}
+		digest.Items = append(digest.Items, notify.Item{
+			ChatID:    job.ChatID,
+			Chat:      job.ChatName,
+			MessageID: job.MessageID,
+			Author:    job.Author,
+			Text:      job.Text,
+			Mention:   job.Mention,
+			CreatedAt: job.CreatedAt,
+		})
+	}
+
+	var results []entity.NotificationResult
+	if len(skipped.IDs) > 0 {
+		results = append(results, skipped)
+	}
+	if len(ids) == 0 {
+		return results
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	err := notifier.Notify(ctx, digest)
+	finish := s.now()
+	result := entity.NotificationResult{IDs: ids, Status: entity.NotificationSent, SendAfter: finish}
+	if err != nil {
+		result.Error = err.Error()
+		if runes := []rune(result.Error); len(runes) > maxAttemptErrorRunes {
+			result.Error = string(runes[:maxAttemptErrorRunes])
+		}
+		if attempts >= s.cfg.MaxAttempts {
+			result.Status = entity.NotificationFailed
+		} else {
+			result.Status = entity.NotificationPending
+			result.SendAfter = finish.Add(backoff(attempts, s.cfg.MinBackoff, s.cfg.MaxBackoff))
+		}
+	}
+
+	return append(results, result)
+}
+
+// channels - имена настроенных каналов в постоянном порядке
+func (s *NotificationService) channels() []string {
+	channels := make([]string, 0, len(s.notifiers))
+	for channel := range s.notifiers {
+		channels = append(channels, channel)
+	}
+	slices.Sort(channels)
+	return channels
+}
+
+// digestInterval - сколько копятся сообщения канала перед отправкой сводки
+func (s *NotificationService) digestInterval(channel string) time.Duration {
+	switch channel {
+	case notify.ChannelEmail:
+		return s.cfg.Email.DigestInterval
+	case notify.ChannelPush:
+		return s.cfg.Push.DigestInterval
+	}
+	return 0
+}
+
+// timeout - ограничение времени одной отправки в канал
+func (s *NotificationService) timeout(channel string) time.Duration {
+	var timeout time.Duration
+	switch channel {
+	case notify.ChannelEmail:
+		timeout = s.cfg.Email.Timeout
+	case notify.ChannelPush:
+		timeout = s.cfg.Push.Timeout
+	}
+	if timeout <= 0 {
+		timeout = defaultNotifyTimeout
+	}
+	return timeout
+}
+
+// recipientAddress - адрес получателя в канале
+func recipientAddress(recipient entity.NotifyRecipient, channel string) string {
+	switch channel {
+	case notify.ChannelEmail:
+		return recipient.Email
+	case notify.ChannelPush:
+		return recipient.PushToken
+	}
+	return ""
+}
@@ -0,0 +1,204 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"service-chat/internal/apperr"
+	"service-chat/internal/config"
+	"service-chat/internal/db/entity"
+	mockRepo "service-chat/internal/db/mocks"
+	"service-chat/internal/dto"
+	"service-chat/internal/notify"
+)
+
+// fakeNotifier - канал уведомлений, который запоминает сводки и отвечает ошибкой для адресов из fail
+type fakeNotifier struct {
+	channel string
+	fail    map[string]bool
+	mu      sync.Mutex
+	digests []notify.Digest
+}
+
+func (f *fakeNotifier) Channel() string {
+	return f.channel
+}
+
+func (f *fakeNotifier) Notify(_ context.Context, d notify.Digest) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.digests = append(f.digests, d)
+
+	if f.fail[d.Address] {
+		return errors.New("connection refused")
+	}
+	return nil
+}
+
+func TestNotificationService_Process(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Date(2030, 1, 1, 9, 0, 0, 0, time.UTC)
+	mockNotification := mockRepo.NewMockNotification(ctrl)
+	online := map[int64]bool{4: true}
+	serviceNotification := NewNotificationService(mockNotification,
+		[]notify.Notifier{&fakeNotifier{channel: notify.ChannelEmail}, &fakeNotifier{channel: notify.ChannelPush}},
+		func(userID int64) bool { return online[userID] },
+		config.Notifications{
+			Email: config.Email{DigestInterval: 15 * time.Minute},
+			Push:  config.Push{DigestInterval: time.Minute},
+		})
+	serviceNotification.now = func() time.Time { return now }
+
+	mockNotification.EXPECT().GetRecipients(entity.RecipientsGet{ChatID: 2, MessageID: 10, AuthorID: 1, Now: now}).
+		Return([]entity.NotifyRecipient{
+			{UserID: 2, Email: "two@example.com", PushToken: "device-2"},
+			{UserID: 3, PushToken: "device-3", Mention: true},
+			// Пользователь в сети видит сообщение сам
+			{UserID: 4, Email: "four@example.com"},
+		}, nil)
+	mockNotification.EXPECT().AddNotifications([]entity.NotificationAdd{
+		{UserID: 2, Channel: notify.ChannelEmail, ChatID: 2, MessageID: 10, SendAfter: now.Add(15 * time.Minute)},
+		{UserID: 2, Channel: notify.ChannelPush, ChatID: 2, MessageID: 10, SendAfter: now.Add(time.Minute)},
+		// Упоминание отправляется без ожидания сводки
+		{UserID: 3, Channel: notify.ChannelPush, ChatID: 2, MessageID: 10, Mention: true, SendAfter: now},
+	}).Return(nil)
+
+	assert.NoError(t, serviceNotification.process(notificationJob{chatID: 2, messageID: 10, authorID: 1}))
+
+	// Ошибка выбора получателей возвращается без создания уведомлений
+	mockNotification.EXPECT().GetRecipients(gomock.Any()).Return(nil, errors.New("connection refused"))
+	assert.Equal(t, errors.New("connection refused"),
+		serviceNotification.process(notificationJob{chatID: 2, messageID: 11, authorID: 1}))
+}
+
+func TestNotificationService_Enqueue(t *testing.T) {
+	serviceNotification := NewNotificationService(nil, []notify.Notifier{&fakeNotifier{channel: notify.ChannelEmail}},
+		nil, config.Notifications{QueueSize: 1})
+
+	// При переполненной очереди сообщение не блокирует отправку и пропускается
+	serviceNotification.Enqueue(2, 10, 1)
+	serviceNotification.Enqueue(2, 11, 1)
+	assert.Len(t, serviceNotification.queue, 1)
+	assert.Equal(t, notificationJob{chatID: 2, messageID: 10, authorID: 1}, <-serviceNotification.queue)
+
+	// Без каналов уведомления не создаются
+	withoutChannels := NewNotificationService(nil, nil, nil, config.Notifications{QueueSize: 1})
+	withoutChannels.Enqueue(2, 10, 1)
+	assert.Len(t, withoutChannels.queue, 0)
+}
+
+func TestNotificationService_DeliverDue(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Date(2030, 1, 1, 9, 0, 0, 0, time.UTC)
+	mockNotification := mockRepo.NewMockNotification(ctrl)
+	notifier := &fakeNotifier{channel: notify.ChannelEmail, fail: map[string]bool{"down@example.com": true, "dead@example.com": true}}
+	serviceNotification := NewNotificationService(mockNotification, []notify.Notifier{notifier}, nil, config.Notifications{
+		BatchSize:   10,
+		MaxItems:    2,
+		MaxAttempts: 3,
+		MinBackoff:  time.Minute,
+		MaxBackoff:  time.Hour,
+		Email:       config.Email{Timeout: 5 * time.Second},
+	})
+	serviceNotification.now = func() time.Time { return now }
+
+	mockNotification.EXPECT().ClaimNotifications(entity.NotificationClaim{
+		Channel: notify.ChannelEmail, Now: now, LeaseUntil: now.Add(10 * time.Second), Limit: 10,
+	}).Return([]entity.NotificationJob{
+		{ID: 1, UserID: 2, Address: "two@example.com", ChatID: 5, ChatName: "team", MessageID: 10, Author: "bob", Text: "hi", Attempts: 1},
+		{ID: 2, UserID: 2, Address: "two@example.com", ChatID: 5, ChatName: "team", MessageID: 11, Author: "bob", Text: "gone", IsDeleted: true, Attempts: 1},
+		{ID: 3, UserID: 2, Address: "two@example.com", ChatID: 5, ChatName: "team", MessageID: 12, Author: "ann", Text: "@two look", Mention: true, Attempts: 1},
+		{ID: 4, UserID: 2, Address: "two@example.com", ChatID: 6, ChatName: "dev", MessageID: 13, Author: "ann", Text: "deploy", Attempts: 1},
+		{ID: 5, UserID: 3, Address: "down@example.com", ChatID: 5, ChatName: "team", MessageID: 10, Author: "bob", Text: "hi", Attempts: 2},
+		{ID: 6, UserID: 4, Address: "dead@example.com", ChatID: 5, ChatName: "team", MessageID: 10, Author: "bob", Text: "hi", Attempts: 3},
+		// Пользователь очистил адрес после создания уведомления
+		{ID: 7, UserID: 5, ChatID: 5, ChatName: "team", MessageID: 10, Author: "bob", Text: "hi", Attempts: 1},
+	}, nil)
+
+	var mu sync.Mutex
+	saved := make(map[string][]int64)
+	var pending entity.NotificationResult
+	mockNotification.EXPECT().CompleteNotifications(gomock.Any()).DoAndReturn(func(in entity.NotificationResult) error {
+		mu.Lock()
+		defer mu.Unlock()
+		saved[in.Status] = append(saved[in.Status], in.IDs...)
+		if in.Status == entity.NotificationPending {
+			pending = in
+		}
+		return nil
+	}).Times(5)
+
+	sent, err := serviceNotification.DeliverDue(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, sent)
+
+	assert.ElementsMatch(t, []int64{1, 3, 4}, saved[entity.NotificationSent])
+	assert.ElementsMatch(t, []int64{2, 7}, saved[entity.NotificationSkipped])
+	assert.Equal(t, []int64{6}, saved[entity.NotificationFailed])
+	// Вторая неудача: пауза удваивается
+	assert.Equal(t, entity.NotificationResult{IDs: []int64{5}, Status: entity.NotificationPending,
+		Error: "connection refused", SendAfter: now.Add(2 * time.Minute)}, pending)
+
+	// Сводка первого пользователя: удалённое сообщение пропущено, не поместившиеся в MaxItems посчитаны
+	assert.Len(t, notifier.digests, 3)
+	for _, digest := range notifier.digests {
+		if digest.UserID != 2 {
+			continue
+		}
+		assert.Equal(t, "two@example.com", digest.Address)
+		assert.Equal(t, []notify.Item{
+			{ChatID: 5, Chat: "team", MessageID: 10, Author: "bob", Text: "hi"},
+			{ChatID: 5, Chat: "team", MessageID: 12, Author: "ann", Text: "@two look", Mention: true},
+		}, digest.Items)
+		assert.Equal(t, 1, digest.More)
+		assert.Equal(t, "3 new messages", digest.Title())
+	}
+
+	// Ошибка выборки возвращается без отправки
+	mockNotification.EXPECT().ClaimNotifications(gomock.Any()).Return(nil, errors.New("connection refused"))
+	_, err = serviceNotification.DeliverDue(context.Background())
+	assert.EqualError(t, err, "connection refused")
+}
+
+func TestNotificationService_Settings(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Date(2030, 1, 1, 9, 0, 0, 0, time.UTC)
+	mockNotification := mockRepo.NewMockNotification(ctrl)
+	serviceNotification := NewNotificationService(mockNotification, nil, nil, config.Notifications{})
+	serviceNotification.now = func() time.Time { return now }
+
+	mockNotification.EXPECT().SaveNotifySettings(entity.NotifySettingsSave{UserID: 1, Email: "bob@example.com", PushToken: "device-1"}).
+		Return(nil)
+	assert.NoError(t, serviceNotification.SaveSettings(dto.NotifySettings{Email: "bob@example.com", PushToken: "device-1"}, 1))
+
+	// Пустой адрес отключает канал
+	mockNotification.EXPECT().SaveNotifySettings(entity.NotifySettingsSave{UserID: 1}).Return(nil)
+	assert.NoError(t, serviceNotification.SaveSettings(dto.NotifySettings{}, 1))
+
+	for _, email := range []string{"bob", "Bob <bob@example.com>", "bob@example.com\r\nBcc: eve@example.com"} {
+		assert.Equal(t, apperr.Validation("invalid email"), serviceNotification.SaveSettings(dto.NotifySettings{Email: email}, 1), email)
+	}
+
+	mutedUntil := now.Add(time.Hour)
+	mockNotification.EXPECT().SetChatNotify(entity.ChatNotifySet{ChatID: 2, UserID: 1, Level: entity.NotifyMentions, MutedUntil: &mutedUntil}).
+		Return(nil)
+	assert.NoError(t, serviceNotification.SetChatNotify(dto.ChatNotify{Level: entity.NotifyMentions, MutedUntil: &mutedUntil}, 2, 1))
+
+	past := now.Add(-time.Minute)
+	assert.Equal(t, apperr.Validation("muted_until must be in the future"),
+		serviceNotification.SetChatNotify(dto.ChatNotify{Level: entity.NotifyAll, MutedUntil: &past}, 2, 1))
+	assert.Equal(t, apperr.Validation("invalid level"), serviceNotification.SetChatNotify(dto.ChatNotify{Level: "loud"}, 2, 1))
+	assert.Equal(t, apperr.Validation("empty chat_id or user_id"), serviceNotification.SetChatNotify(dto.ChatNotify{Level: entity.NotifyAll}, 0, 1))
+}
@@ -0,0 +1,68 @@
+package service
+
+import (
+	"service-chat/internal/apperr"
+	"service-chat/internal/db"
+	"service-chat/internal/db/entity"
+)
+
+// maxPinnedMessages - максимальное количество закреплённых сообщений в одном чате
+const maxPinnedMessages = 50
+
+type PinService struct {
+	repo db.Pin
+}
+
+func NewPinService(repo db.Pin) *PinService {
+	return &PinService{repo: repo}
+}
+
+// PinMessage - закрепляем сообщение в чате, доступно администраторам чата
+func (s *PinService) PinMessage(messageID int64, userID int) error {
+	// Если запрос пустой
+	if messageID == 0 || userID == 0 {
+		return apperr.Validation("empty message_id or user_id")
+	}
+
+	dataDB := entity.PinAdd{
+		MessageID: messageID,
+		UserID:    userID,
+		MaxPins:   maxPinnedMessages,
+	}
+	return s.repo.PinMessage(dataDB)
+}
+
+// UnpinMessage - открепляем сообщение в чате, доступно администраторам чата
+func (s *PinService) UnpinMessage(messageID int64, userID int) error {
+	// Если запрос пустой
+	if messageID == 0 || userID == 0 {
+		return apperr.Validation("empty message_id or user_id")
+	}
+
+	dataDB := entity.PinDel{
+		MessageID: messageID,
+		UserID:    userID,
+	}
+	return s.repo.UnpinMessage(dataDB)
+}
+
+// GetPins - получаем закреплённые сообщения чата
+func (s *PinService) GetPins(chatID int64, userID int) ([]entity.Message, error) {
+	// Если запрос пустой
+	if chatID == 0 || userID == 0 {
+		return nil, apperr.Validation("empty chat_id or user_id")
+	}
+
+	dataDB := entity.PinGet{
+		ChatID: chatID,
+		UserID: userID,
+		Limit:  maxPinnedMessages,
+	}
+	messages, err := s.repo.GetPins(dataDB)
+	if err != nil {
+		return nil, err
+	}
+
+	renderMessages(messages)
+	return messages, nil
+}
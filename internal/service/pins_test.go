@@ -0,0 +1,206 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"service-chat/internal/apperr"
+	"service-chat/internal/db/entity"
+	mockRepo "service-chat/internal/db/mocks"
+)
+
+func TestPinService_PinMessage(t *testing.T) {
+	// Структура для последующей реализации поведения мока
+	type mockBehaviour func(s *mockRepo.MockPin, dataDB entity.PinAdd)
+
+	//Инициализируем контролер для мока сервиса
+	ctrl := gomock.NewController(t)
+	// Завершаем работу контролера после выполнения каждого теста
+	defer ctrl.Finish()
+
+	// Создаём моки базы данных закреплённых сообщений
+	mockPin := mockRepo.NewMockPin(ctrl)
+
+	// Создаём экземпляр сервиса закреплённых сообщений
+	servicePin := NewPinService(mockPin)
+
+	tests := []struct {
+		name      string
+		messageID int64
+		userID    int
+		dataDB    entity.PinAdd
+		mock      mockBehaviour
+		wantErr   error
+	}{
+		{
+			name:      "Success",
+			messageID: 1,
+			userID:    1,
+			dataDB:    entity.PinAdd{MessageID: 1, UserID: 1, MaxPins: maxPinnedMessages},
+			mock: func(s *mockRepo.MockPin, dataDB entity.PinAdd) {
+				s.EXPECT().PinMessage(dataDB).Return(nil)
+			},
+		},
+		{
+			name:      "Empty message_id",
+			messageID: 0,
+			userID:    1,
+			mock:      func(s *mockRepo.MockPin, dataDB entity.PinAdd) {},
+			wantErr:   apperr.Validation("empty message_id or user_id"),
+		},
+		{
+			name:      "Empty user_id",
+			messageID: 1,
+			userID:    0,
+			mock:      func(s *mockRepo.MockPin, dataDB entity.PinAdd) {},
+			wantErr:   apperr.Validation("empty message_id or user_id"),
+		},
+		{
+			name:      "Not admin",
+			messageID: 1,
+			userID:    2,
+			dataDB:    entity.PinAdd{MessageID: 1, UserID: 2, MaxPins: maxPinnedMessages},
+			mock: func(s *mockRepo.MockPin, dataDB entity.PinAdd) {
+				s.EXPECT().PinMessage(dataDB).Return(errors.New("Only chat admins can do this"))
+			},
+			wantErr: errors.New("Only chat admins can do this"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock(mockPin, tt.dataDB)
+
+			err := servicePin.PinMessage(tt.messageID, tt.userID)
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}
+
+func TestPinService_UnpinMessage(t *testing.T) {
+	// Структура для последующей реализации поведения мока
+	type mockBehaviour func(s *mockRepo.MockPin, dataDB entity.PinDel)
+
+	//Инициализируем контролер для мока сервиса
+	ctrl := gomock.NewController(t)
+	// Завершаем работу контролера после выполнения каждого теста
+	defer ctrl.Finish()
+
+	// Создаём моки базы данных закреплённых сообщений
+	mockPin := mockRepo.NewMockPin(ctrl)
+
+	// Создаём экземпляр сервиса закреплённых сообщений
+	servicePin := NewPinService(mockPin)
+
+	tests := []struct {
+		name      string
+		messageID int64
+		userID    int
+		dataDB    entity.PinDel
+		mock      mockBehaviour
+		wantErr   error
+	}{
+		{
+			name:      "Success",
+			messageID: 1,
+			userID:    1,
+			dataDB:    entity.PinDel{MessageID: 1, UserID: 1},
+			mock: func(s *mockRepo.MockPin, dataDB entity.PinDel) {
+				s.EXPECT().UnpinMessage(dataDB).Return(nil)
+			},
+		},
+		{
+			name:      "Empty message_id",
+			messageID: 0,
+			userID:    1,
+			mock:      func(s *mockRepo.MockPin, dataDB entity.PinDel) {},
+			wantErr:   apperr.Validation("empty message_id or user_id"),
+		},
+		{
+			name:      "Pin not found",
+			messageID: 1,
+			userID:    1,
+			dataDB:    entity.PinDel{MessageID: 1, UserID: 1},
+			mock: func(s *mockRepo.MockPin, dataDB entity.PinDel) {
+				s.EXPECT().UnpinMessage(dataDB).Return(errors.New("Pin not found"))
+			},
+			wantErr: errors.New("Pin not found"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock(mockPin, tt.dataDB)
+
+			err := servicePin.UnpinMessage(tt.messageID, tt.userID)
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}
+
+func TestPinService_GetPins(t *testing.T) {
+	// Структура для последующей реализации поведения мока
+	type mockBehaviour func(s *mockRepo.MockPin, dataDB entity.PinGet)
+
+	//Инициализируем контролер для мока сервиса
+	ctrl := gomock.NewController(t)
+	// Завершаем работу контролера после выполнения каждого теста
+	defer ctrl.Finish()
+
+	// Создаём моки базы данных закреплённых сообщений
+	mockPin := mockRepo.NewMockPin(ctrl)
+
+	// Создаём экземпляр сервиса закреплённых сообщений
+	servicePin := NewPinService(mockPin)
+
+	tests := []struct {
+		name    string
+		chatID  int64
+		userID  int
+		dataDB  entity.PinGet
+		mock    mockBehaviour
+		want    []entity.Message
+		wantErr error
+	}{
+		{
+			name:   "Success",
+			chatID: 1,
+			userID: 1,
+			dataDB: entity.PinGet{ChatID: 1, UserID: 1, Limit: maxPinnedMessages},
+			mock: func(s *mockRepo.MockPin, dataDB entity.PinGet) {
+				s.EXPECT().GetPins(dataDB).Return([]entity.Message{{Id: 1, Text: "rules", Pinned: true}}, nil)
+			},
+			want: []entity.Message{{Id: 1, Text: "rules", Pinned: true}},
+		},
+		{
+			name:    "Empty chat_id",
+			chatID:  0,
+			userID:  1,
+			mock:    func(s *mockRepo.MockPin, dataDB entity.PinGet) {},
+			wantErr: apperr.Validation("empty chat_id or user_id"),
+		},
+		{
+			name:   "Other error",
+			chatID: 1,
+			userID: 1,
+			dataDB: entity.PinGet{ChatID: 1, UserID: 1, Limit: maxPinnedMessages},
+			mock: func(s *mockRepo.MockPin, dataDB entity.PinGet) {
+				s.EXPECT().GetPins(dataDB).Return(nil, errors.New("other error"))
+			},
+			wantErr: errors.New("other error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock(mockPin, tt.dataDB)
+
+			got, err := servicePin.GetPins(tt.chatID, tt.userID)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}
@@ -0,0 +1,177 @@
+package service
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"service-chat/internal/apperr"
+	"service-chat/internal/db"
+	"service-chat/internal/db/entity"
+	"service-chat/internal/dto"
+	"service-chat/internal/realtime"
+)
+
+const (
+	// minPollOptions, maxPollOptions - сколько вариантов ответа можно задать в опросе
+	minPollOptions = 2
+	maxPollOptions = 10
+	// maxPollOptionLength - максимальная длина варианта ответа в символах
+	maxPollOptionLength = 100
+)
+
+type PollService struct {
+	repo db.Poll
+	// messages - по нему находим чат опроса, чтобы отправить событие о голосах
+	messages db.Message
+	events   realtime.Publisher
+	// now - текущее время, подменяется в тестах
+	now func() time.Time
+}
+
+func NewPollService(repo db.Poll, messages db.Message, events realtime.Publisher) *PollService {
+	return &PollService{repo: repo, messages: messages, events: events, now: time.Now}
+}
+
+// CreatePoll - создаём опрос в чате от лица пользователя и возвращаем id сообщения с опросом
+func (ps *PollService) CreatePoll(in dto.PollAdd, chatID int64, userID int) (int, error) {
+	// Если запрос пустой
+	if chatID == 0 || userID == 0 {
+		return 0, apperr.Validation("empty chat_id or user_id")
+	}
+
+	question := strings.TrimSpace(in.Question)
+	if question == "" {
+		return 0, apperr.Validation("empty question")
+	}
+
+	options, err := pollOptions(in.Options)
+	if err != nil {
+		return 0, err
+	}
+
+	if in.CloseAt != nil && !in.CloseAt.After(ps.now()) {
+		return 0, apperr.Validation("close_at must be in the future")
+	}
+
+	dataDB := entity.PollAdd{
+		ChatID:    chatID,
+		UserID:    int64(userID),
+		Question:  question,
+		Options:   options,
+		Multiple:  in.Multiple,
+		Anonymous: in.Anonymous,
+		CloseAt:   in.CloseAt,
+	}
+	messageID, err := ps.repo.AddPoll(dataDB)
+	if err != nil {
+		return 0, err
+	}
+
+	ps.events.Publish(realtime.Event{
+		Type:   realtime.EventMessageCreated,
+		ChatID: chatID,
+		Data:   messagePayload(int64(messageID), int64(userID), question, entity.FormatPlain),
+	})
+
+	return messageID, nil
+}
+
+// Vote - голос пользователя в опросе, новый голос заменяет прежний. Возвращаем опрос с текущими голосами
+func (ps *PollService) Vote(in dto.PollVote, messageID int64, userID int) (*entity.Poll, error) {
+	// Если запрос пустой
+	if messageID == 0 || userID == 0 {
+		return nil, apperr.Validation("empty message_id or user_id")
+	} else if len(in.OptionIDs) == 0 {
+		return nil, apperr.Validation("empty option_ids")
+	}
+
+	// Повторы одного варианта засчитываем один раз
+	optionIDs := slices.Clone(in.OptionIDs)
+	slices.Sort(optionIDs)
+	optionIDs = slices.Compact(optionIDs)
+	if optionIDs[0] <= 0 {
+		return nil, apperr.Validation("Invalid option_id")
+	}
+
+	return ps.vote(messageID, userID, optionIDs)
+}
+
+// Unvote - отзываем голос пользователя в опросе. Возвращаем опрос с текущими голосами
+func (ps *PollService) Unvote(messageID int64, userID int) (*entity.Poll, error) {
+	// Если запрос пустой
+	if messageID == 0 || userID == 0 {
+		return nil, apperr.Validation("empty message_id or user_id")
+	}
+
+	return ps.vote(messageID, userID, nil)
+}
+
+// vote - сохраняем голос и сообщаем участникам чата новые итоги опроса
+func (ps *PollService) vote(messageID int64, userID int, optionIDs []int64) (*entity.Poll, error) {
+	dataDB := entity.PollVote{
+		MessageID: messageID,
+		UserID:    userID,
+		OptionIDs: optionIDs,
+		Now:       ps.now(),
+	}
+	poll, err := ps.repo.Vote(dataDB)
+	if err != nil {
+		return nil, err
+	}
+
+	// Событие получают участники чата опроса, если чат найти не удалось - событие не отправляем
+	if chats, errChats := ps.messages.MessageChats([]int64{messageID}); errChats == nil {
+		if chatID, ok := chats[messageID]; ok && poll != nil {
+			ps.events.Publish(realtime.Event{
+				Type:   realtime.EventPollUpdated,
+				ChatID: chatID,
+				Data:   pollPayload(messageID, poll),
+			})
+		}
+	}
+
+	return poll, nil
+}
+
+// pollOptions - проверяем варианты ответа: от 2 до 10 непустых вариантов без повторов
+func pollOptions(in []string) ([]string, error) {
+	if len(in) < minPollOptions || len(in) > maxPollOptions {
+		return nil, apperr.Validation(fmt.Sprintf("Poll must have from %d to %d options", minPollOptions, maxPollOptions))
+	}
+
+	options := make([]string, 0, len(in))
+	seen := make(map[string]bool, len(in))
+	for _, option := range in {
+		option = strings.TrimSpace(option)
+		if option == "" {
+			return nil, apperr.Validation("Poll option cannot be empty")
+		} else if utf8.RuneCountInString(option) > maxPollOptionLength {
+			return nil, apperr.Validation(fmt.Sprintf("Poll option cannot exceed %d characters", maxPollOptionLength))
+		}
+
+		key := strings.ToLower(option)
+		if seen[key] {
+			return nil, apperr.Validation("Poll options must be unique")
+		}
+		seen[key] = true
+		options = append(options, option)
+	}
+
+	return options, nil
+}
+
+// pollPayload - итоги опроса для события, без отметок о голосе конкретного пользователя
+func pollPayload(messageID int64, poll *entity.Poll) realtime.PollPayload {
+	payload := realtime.PollPayload{
+		MessageID:   messageID,
+		TotalVoters: poll.TotalVoters,
+		Options:     make([]realtime.PollOptionPayload, 0, len(poll.Options)),
+	}
+	for _, option := range poll.Options {
+		payload.Options = append(payload.Options, realtime.PollOptionPayload{ID: option.ID, Votes: option.Votes})
+	}
+	return payload
+}
@@ -0,0 +1,216 @@
+package service
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"service-chat/internal/apperr"
+	"service-chat/internal/db/entity"
+	mockRepo "service-chat/internal/db/mocks"
+	"service-chat/internal/dto"
+	"service-chat/internal/realtime"
+)
+
+func TestPollService_CreatePoll(t *testing.T) {
+	// Структура для последующей реализации поведения мока
+	type mockBehaviour func(s *mockRepo.MockPoll, dataDB entity.PollAdd)
+
+	//Инициализируем контролер для мока сервиса
+	ctrl := gomock.NewController(t)
+	// Завершаем работу контролера после выполнения каждого теста
+	defer ctrl.Finish()
+
+	// Создаём моки базы данных опросов
+	mockPoll := mockRepo.NewMockPoll(ctrl)
+	events := &fakePublisher{}
+
+	// Создаём экземпляр сервиса опросов с фиксированным временем
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	servicePoll := NewPollService(mockPoll, mockRepo.NewMockMessage(ctrl), events)
+	servicePoll.now = func() time.Time { return now }
+
+	closeAt := now.Add(time.Hour)
+	past := now.Add(-time.Minute)
+
+	tests := []struct {
+		name    string
+		in      dto.PollAdd
+		chatID  int64
+		userID  int
+		dataDB  entity.PollAdd
+		mock    mockBehaviour
+		wantID  int
+		wantErr error
+	}{
+		{
+			name:   "Success",
+			in:     dto.PollAdd{Question: " Lunch? ", Options: []string{" Pizza", "Sushi "}, Anonymous: true, CloseAt: &closeAt},
+			chatID: 2,
+			userID: 1,
+			dataDB: entity.PollAdd{ChatID: 2, UserID: 1, Question: "Lunch?", Options: []string{"Pizza", "Sushi"}, Anonymous: true, CloseAt: &closeAt},
+			mock: func(s *mockRepo.MockPoll, dataDB entity.PollAdd) {
+				s.EXPECT().AddPoll(dataDB).Return(12, nil)
+			},
+			wantID: 12,
+		},
+		{
+			name:    "Empty chat_id",
+			in:      dto.PollAdd{Question: "Lunch?", Options: []string{"Pizza", "Sushi"}},
+			userID:  1,
+			mock:    func(s *mockRepo.MockPoll, dataDB entity.PollAdd) {},
+			wantErr: apperr.Validation("empty chat_id or user_id"),
+		},
+		{
+			name:    "Too few options",
+			in:      dto.PollAdd{Question: "Lunch?", Options: []string{"Pizza"}},
+			chatID:  2,
+			userID:  1,
+			mock:    func(s *mockRepo.MockPoll, dataDB entity.PollAdd) {},
+			wantErr: apperr.Validation("Poll must have from 2 to 10 options"),
+		},
+		{
+			name:    "Too many options",
+			in:      dto.PollAdd{Question: "Lunch?", Options: strings.Split("a b c d e f g h i j k", " ")},
+			chatID:  2,
+			userID:  1,
+			mock:    func(s *mockRepo.MockPoll, dataDB entity.PollAdd) {},
+			wantErr: apperr.Validation("Poll must have from 2 to 10 options"),
+		},
+		{
+			name:    "Empty option",
+			in:      dto.PollAdd{Question: "Lunch?", Options: []string{"Pizza", " "}},
+			chatID:  2,
+			userID:  1,
+			mock:    func(s *mockRepo.MockPoll, dataDB entity.PollAdd) {},
+			wantErr: apperr.Validation("Poll option cannot be empty"),
+		},
+		{
+			name:    "Long option",
+			in:      dto.PollAdd{Question: "Lunch?", Options: []string{"Pizza", strings.Repeat("я", maxPollOptionLength+1)}},
+			chatID:  2,
+			userID:  1,
+			mock:    func(s *mockRepo.MockPoll, dataDB entity.PollAdd) {},
+			wantErr: apperr.Validation("Poll option cannot exceed 100 characters"),
+		},
+		{
+			name:    "Duplicate options",
+			in:      dto.PollAdd{Question: "Lunch?", Options: []string{"Pizza", "pizza"}},
+			chatID:  2,
+			userID:  1,
+			mock:    func(s *mockRepo.MockPoll, dataDB entity.PollAdd) {},
+			wantErr: apperr.Validation("Poll options must be unique"),
+		},
+		{
+			name:    "Close in the past",
+			in:      dto.PollAdd{Question: "Lunch?", Options: []string{"Pizza", "Sushi"}, CloseAt: &past},
+			chatID:  2,
+			userID:  1,
+			mock:    func(s *mockRepo.MockPoll, dataDB entity.PollAdd) {},
+			wantErr: apperr.Validation("close_at must be in the future"),
+		},
+		{
+			name:   "Not a member",
+			in:     dto.PollAdd{Question: "Lunch?", Options: []string{"Pizza", "Sushi"}},
+			chatID: 2,
+			userID: 3,
+			dataDB: entity.PollAdd{ChatID: 2, UserID: 3, Question: "Lunch?", Options: []string{"Pizza", "Sushi"}},
+			mock: func(s *mockRepo.MockPoll, dataDB entity.PollAdd) {
+				s.EXPECT().AddPoll(dataDB).Return(0, errors.New("Invalid chat_id"))
+			},
+			wantErr: errors.New("Invalid chat_id"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mock(mockPoll, tt.dataDB)
+
+			id, err := servicePoll.CreatePoll(tt.in, tt.chatID, tt.userID)
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.wantID, id)
+		})
+	}
+
+	// Участники чата получают опрос как новое сообщение
+	assert.Equal(t, []realtime.Event{{
+		Type:   realtime.EventMessageCreated,
+		ChatID: 2,
+		Data:   realtime.MessagePayload{MessageID: 12, UserID: 1, Text: "Lunch?", Format: entity.FormatPlain},
+	}}, events.events)
+}
+
+func TestPollService_Vote(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPoll := mockRepo.NewMockPoll(ctrl)
+	mockMessage := mockRepo.NewMockMessage(ctrl)
+	events := &fakePublisher{}
+
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	servicePoll := NewPollService(mockPoll, mockMessage, events)
+	servicePoll.now = func() time.Time { return now }
+
+	poll := &entity.Poll{ID: 4, Multiple: true, TotalVoters: 1, Options: []entity.PollOption{
+		{ID: 7, Text: "Pizza", Votes: 1, VotedByMe: true},
+		{ID: 8, Text: "Sushi", Votes: 1, VotedByMe: true},
+	}}
+
+	t.Run("Duplicates are counted once", func(t *testing.T) {
+		mockPoll.EXPECT().Vote(entity.PollVote{MessageID: 12, UserID: 1, OptionIDs: []int64{7, 8}, Now: now}).Return(poll, nil)
+		mockMessage.EXPECT().MessageChats([]int64{12}).Return(map[int64]int64{12: 2}, nil)
+
+		got, err := servicePoll.Vote(dto.PollVote{OptionIDs: []int64{8, 7, 8}}, 12, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, poll, got)
+
+		// Событие о голосах не раскрывает, кто голосовал
+		assert.Equal(t, realtime.Event{
+			Type:   realtime.EventPollUpdated,
+			ChatID: 2,
+			Data: realtime.PollPayload{MessageID: 12, TotalVoters: 1, Options: []realtime.PollOptionPayload{
+				{ID: 7, Votes: 1}, {ID: 8, Votes: 1},
+			}},
+		}, events.events[len(events.events)-1])
+	})
+
+	t.Run("Invalid option", func(t *testing.T) {
+		_, err := servicePoll.Vote(dto.PollVote{OptionIDs: []int64{0, 7}}, 12, 1)
+		assert.Equal(t, apperr.Validation("Invalid option_id"), err)
+	})
+
+	t.Run("Empty options", func(t *testing.T) {
+		_, err := servicePoll.Vote(dto.PollVote{}, 12, 1)
+		assert.Equal(t, apperr.Validation("empty option_ids"), err)
+	})
+
+	t.Run("Closed poll", func(t *testing.T) {
+		count := len(events.events)
+		mockPoll.EXPECT().Vote(entity.PollVote{MessageID: 12, UserID: 1, OptionIDs: []int64{7}, Now: now}).
+			Return(nil, errors.New("Poll is closed"))
+
+		_, err := servicePoll.Vote(dto.PollVote{OptionIDs: []int64{7}}, 12, 1)
+		assert.Equal(t, errors.New("Poll is closed"), err)
+		assert.Len(t, events.events, count)
+	})
+
+	t.Run("Unvote", func(t *testing.T) {
+		empty := &entity.Poll{ID: 4, Options: []entity.PollOption{{ID: 7, Text: "Pizza"}}}
+		mockPoll.EXPECT().Vote(entity.PollVote{MessageID: 12, UserID: 1, Now: now}).Return(empty, nil)
+		mockMessage.EXPECT().MessageChats([]int64{12}).Return(map[int64]int64{12: 2}, nil)
+
+		got, err := servicePoll.Unvote(12, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, empty, got)
+	})
+
+	t.Run("Unvote empty message_id", func(t *testing.T) {
+		_, err := servicePoll.Unvote(0, 1)
+		assert.Equal(t, apperr.Validation("empty message_id or user_id"), err)
+	})
+}
@@ -0,0 +1,291 @@
+package service
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+
+	"service-chat/internal/apperr"
+	"service-chat/internal/config"
+	"service-chat/internal/db"
+	"service-chat/internal/db/entity"
+	"service-chat/internal/dto"
+	"service-chat/internal/realtime"
+)
+
+// PresenceService - статусы пользователей в сети и индикатор набора текста. Состояние хранится в памяти:
+// подключения считаются в этом экземпляре, а статусы и набор текста пользователей других экземпляров
+// приходят событиями шины. В бд сохраняется только время выхода из сети
+type PresenceService struct {
+	repo   db.Presence
+	events realtime.Publisher
+	cfg    config.Presence
+	// now - текущее время, подменяется в тестах
+	now func() time.Time
+
+	mu sync.Mutex
+	// conns - количество подключений пользователя к этому экземпляру
+	conns map[int64]int
+	// statuses - последние известные статусы пользователей со всех экземпляров
+	statuses map[int64]string
+	// typing - кто печатает в чате и до какого времени
+	typing map[int64]map[int64]time.Time
+}
+
+func NewPresenceService(repo db.Presence, events realtime.Publisher, cfg config.Presence) *PresenceService {
+	return &PresenceService{
+		repo:     repo,
+		events:   events,
+		cfg:      cfg,
+		now:      time.Now,
+		conns:    make(map[int64]int),
+		statuses: make(map[int64]string),
+		typing:   make(map[int64]map[int64]time.Time),
+	}
+}
+
+// Connected - у пользователя появилось подключение, первое подключение переводит его в сеть
+func (s *PresenceService) Connected(userID int) error {
+	uid := int64(userID)
+
+	s.mu.Lock()
+	s.conns[uid]++
+	first := s.conns[uid] == 1
+	if first {
+		s.statuses[uid] = entity.PresenceOnline
+	}
+	s.mu.Unlock()
+
+	if !first {
+		return nil
+	}
+	return s.publishStatus(uid, entity.PresenceOnline, nil)
+}
+
+// Disconnected - подключение пользователя закрыто, после закрытия последнего сохраняем время выхода из сети
+func (s *PresenceService) Disconnected(userID int) error {
+	uid := int64(userID)
+
+	s.mu.Lock()
+	s.conns[uid]--
+	last := s.conns[uid] <= 0
+	if last {
+		delete(s.conns, uid)
+		s.statuses[uid] = entity.PresenceOffline
+		s.stopUserTyping(uid)
+	}
+	s.mu.Unlock()
+
+	if !last {
+		return nil
+	}
+
+	seenAt := s.now().UTC()
+	if err := s.repo.SaveLastSeen(entity.PresenceSeen{UserID: uid, SeenAt: seenAt}); err != nil {
+		return err
+	}
+	return s.publishStatus(uid, entity.PresenceOffline, &seenAt)
+}
+
+// SetStatus - пользователь в сети отмечает себя отошедшим или вернувшимся
+func (s *PresenceService) SetStatus(in dto.PresenceSet, userID int) error {
+	if userID == 0 {
+		return apperr.Validation("user_id is empty")
+	}
+	if in.Status != entity.PresenceOnline && in.Status != entity.PresenceAway {
+		return apperr.Validation("invalid status")
+	}
+	uid := int64(userID)
+
+	s.mu.Lock()
+	current := s.statuses[uid]
+	if current != "" && current != entity.PresenceOffline {
+		s.statuses[uid] = in.Status
+	}
+	s.mu.Unlock()
+
+	// Статус хранится только пока у пользователя есть подключение
+	if current == "" || current == entity.PresenceOffline {
+		return apperr.Conflict("user is offline, connect to /ws or /events first")
+	}
+	if current == in.Status {
+		return nil
+	}
+	return s.publishStatus(uid, in.Status, nil)
+}
+
+// IsOnline - пользователь в сети и не отошёл, такому пользователю уведомления о сообщениях не нужны
+func (s *PresenceService) IsOnline(userID int64) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.statuses[userID] == entity.PresenceOnline
+}
+
+// GetPresence - статусы участников чата и кто из них печатает, доступно участникам чата
+func (s *PresenceService) GetPresence(chatID int64, userID int) ([]entity.Presence, error) {
+	// Если запрос пустой
+	if chatID == 0 || userID == 0 {
+		return nil, apperr.Validation("empty chat_id or user_id")
+	}
+
+	members, err := s.repo.GetChatMembers(entity.PresenceGet{ChatID: chatID, UserID: userID})
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.pruneTyping(chatID, now)
+	for i := range members {
+		members[i].Status = s.statuses[members[i].UserID]
+		if members[i].Status == "" {
+			members[i].Status = entity.PresenceOffline
+		}
+		_, members[i].Typing = s.typing[chatID][members[i].UserID]
+	}
+
+	return members, nil
+}
+
+// StartTyping - участник чата начал печатать, индикатор пропадёт через TypingTTL, если клиент его не продлит
+func (s *PresenceService) StartTyping(chatID int64, userID int) error {
+	// Если запрос пустой
+	if chatID == 0 || userID == 0 {
+		return apperr.Validation("empty chat_id or user_id")
+	}
+
+	if err := s.repo.CheckMember(entity.PresenceGet{ChatID: chatID, UserID: userID}); err != nil {
+		return err
+	}
+
+	expiresAt := s.now().Add(s.cfg.TypingTTL).UTC()
+	s.events.Publish(realtime.Event{
+		Type:   realtime.EventTypingStarted,
+		ChatID: chatID,
+		Data:   realtime.TypingPayload{UserID: int64(userID), ExpiresAt: &expiresAt},
+	})
+
+	return nil
+}
+
+// StopTyping - участник чата перестал печатать
+func (s *PresenceService) StopTyping(chatID int64, userID int) error {
+	// Если запрос пустой
+	if chatID == 0 || userID == 0 {
+		return apperr.Validation("empty chat_id or user_id")
+	}
+
+	if err := s.repo.CheckMember(entity.PresenceGet{ChatID: chatID, UserID: userID}); err != nil {
+		return err
+	}
+
+	s.events.Publish(realtime.Event{
+		Type:   realtime.EventTypingStopped,
+		ChatID: chatID,
+		Data:   realtime.TypingPayload{UserID: int64(userID)},
+	})
+
+	return nil
+}
+
+// Publish - получаем события шины со всех экземпляров и обновляем статусы и набор текста
+func (s *PresenceService) Publish(ev realtime.Event) {
+	switch ev.Type {
+	case realtime.EventPresenceChanged:
+		var payload realtime.PresencePayload
+		if !decodePayload(ev.Data, &payload) {
+			return
+		}
+
+		s.mu.Lock()
+		s.statuses[payload.UserID] = payload.Status
+		// Пользователь отключился от другого экземпляра, но подключён к этому - он всё ещё в сети
+		stillOnline := payload.Status == entity.PresenceOffline && s.conns[payload.UserID] > 0
+		if stillOnline {
+			s.statuses[payload.UserID] = entity.PresenceOnline
+		} else if payload.Status == entity.PresenceOffline {
+			s.stopUserTyping(payload.UserID)
+		}
+		s.mu.Unlock()
+
+		if stillOnline {
+			_ = s.publishStatus(payload.UserID, entity.PresenceOnline, nil)
+		}
+
+	case realtime.EventTypingStarted:
+		var payload realtime.TypingPayload
+		if !decodePayload(ev.Data, &payload) || payload.ExpiresAt == nil {
+			return
+		}
+
+		s.mu.Lock()
+		if s.typing[ev.ChatID] == nil {
+			s.typing[ev.ChatID] = make(map[int64]time.Time)
+		}
+		s.typing[ev.ChatID][payload.UserID] = *payload.ExpiresAt
+		s.mu.Unlock()
+
+	case realtime.EventTypingStopped, realtime.EventMessageCreated:
+		// Отправленное сообщение тоже завершает набор текста
+		var payload struct {
+			UserID int64 `json:"user_id"`
+		}
+		if !decodePayload(ev.Data, &payload) {
+			return
+		}
+
+		s.mu.Lock()
+		delete(s.typing[ev.ChatID], payload.UserID)
+		s.mu.Unlock()
+	}
+}
+
+// publishStatus - событие о статусе пользователя получают участники всех его чатов
+func (s *PresenceService) publishStatus(userID int64, status string, lastSeen *time.Time) error {
+	chatIDs, err := s.repo.GetUserChats(userID)
+	if err != nil {
+		return err
+	}
+	if len(chatIDs) == 0 {
+		return nil
+	}
+
+	s.events.Publish(realtime.Event{
+		Type:    realtime.EventPresenceChanged,
+		ChatIDs: chatIDs,
+		Data:    realtime.PresencePayload{UserID: userID, Status: status, LastSeen: lastSeen},
+	})
+
+	return nil
+}
+
+// pruneTyping - убираем истёкшие индикаторы набора текста в чате, вызывается под мьютексом
+func (s *PresenceService) pruneTyping(chatID int64, now time.Time) {
+	for userID, expiresAt := range s.typing[chatID] {
+		if !now.Before(expiresAt) {
+			delete(s.typing[chatID], userID)
+		}
+	}
+	if len(s.typing[chatID]) == 0 {
+		delete(s.typing, chatID)
+	}
+}
+
+// stopUserTyping - пользователь вышел из сети и больше не печатает ни в одном чате, вызывается под мьютексом
+func (s *PresenceService) stopUserTyping(userID int64) {
+	for chatID := range s.typing {
+		delete(s.typing[chatID], userID)
+	}
+}
+
+// decodePayload - данные события приходят структурой от этого экземпляра или json от других экземпляров через шину
+func decodePayload(data any, v any) bool {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return false
+	}
+	return json.Unmarshal(raw, v) == nil
+}
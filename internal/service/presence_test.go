@@ -0,0 +1,143 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"service-chat/internal/apperr"
+	"service-chat/internal/config"
+	"service-chat/internal/db/entity"
+	mockRepo "service-chat/internal/db/mocks"
+	"service-chat/internal/dto"
+	"service-chat/internal/realtime"
+)
+
+// loopback - шина внутри экземпляра: запоминает события и возвращает их в сервис статусов
+type loopback struct {
+	fakePublisher
+	presence *PresenceService
+}
+
+func (l *loopback) Publish(ev realtime.Event) {
+	l.fakePublisher.Publish(ev)
+	l.presence.Publish(ev)
+}
+
+func TestPresenceService_Status(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Date(2030, 1, 1, 9, 0, 0, 0, time.UTC)
+	mockPresence := mockRepo.NewMockPresence(ctrl)
+	bus := &loopback{}
+	servicePresence := NewPresenceService(mockPresence, bus, config.Presence{TypingTTL: 5 * time.Second})
+	servicePresence.now = func() time.Time { return now }
+	bus.presence = servicePresence
+
+	mockPresence.EXPECT().GetUserChats(int64(1)).Return([]int64{2, 3}, nil).AnyTimes()
+	mockPresence.EXPECT().GetChatMembers(entity.PresenceGet{ChatID: 2, UserID: 2}).
+		Return([]entity.Presence{{UserID: 1}, {UserID: 2}}, nil).AnyTimes()
+
+	// Без подключения статус сменить нельзя
+	assert.Equal(t, apperr.Conflict("user is offline, connect to /ws or /events first"),
+		servicePresence.SetStatus(dto.PresenceSet{Status: "away"}, 1))
+
+	// Первое подключение переводит в сеть, второе событий не создаёт
+	assert.NoError(t, servicePresence.Connected(1))
+	assert.NoError(t, servicePresence.Connected(1))
+	assert.NoError(t, servicePresence.SetStatus(dto.PresenceSet{Status: "away"}, 1))
+
+	members, err := servicePresence.GetPresence(2, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []entity.Presence{{UserID: 1, Status: "away"}, {UserID: 2, Status: "offline"}}, members)
+
+	// После закрытия последнего подключения сохраняем время выхода из сети
+	assert.NoError(t, servicePresence.Disconnected(1))
+	mockPresence.EXPECT().SaveLastSeen(entity.PresenceSeen{UserID: 1, SeenAt: now}).Return(nil)
+	assert.NoError(t, servicePresence.Disconnected(1))
+
+	assert.Equal(t, []realtime.Event{
+		{Type: realtime.EventPresenceChanged, ChatIDs: []int64{2, 3}, Data: realtime.PresencePayload{UserID: 1, Status: "online"}},
+		{Type: realtime.EventPresenceChanged, ChatIDs: []int64{2, 3}, Data: realtime.PresencePayload{UserID: 1, Status: "away"}},
+		{Type: realtime.EventPresenceChanged, ChatIDs: []int64{2, 3}, Data: realtime.PresencePayload{UserID: 1, Status: "offline", LastSeen: &now}},
+	}, bus.events)
+
+	members, err = servicePresence.GetPresence(2, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, "offline", members[0].Status)
+}
+
+func TestPresenceService_OtherInstance(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPresence := mockRepo.NewMockPresence(ctrl)
+	events := &fakePublisher{}
+	servicePresence := NewPresenceService(mockPresence, events, config.Presence{})
+
+	mockPresence.EXPECT().GetUserChats(int64(1)).Return([]int64{2}, nil).AnyTimes()
+	mockPresence.EXPECT().GetChatMembers(gomock.Any()).Return([]entity.Presence{{UserID: 1}}, nil).AnyTimes()
+
+	// Статус с другого экземпляра приходит через шину в виде json
+	servicePresence.Publish(realtime.Event{Type: realtime.EventPresenceChanged, Data: json.RawMessage(`{"user_id":1,"status":"online"}`)})
+	members, _ := servicePresence.GetPresence(2, 2)
+	assert.Equal(t, "online", members[0].Status)
+
+	// Пользователь отключился от другого экземпляра, но подключён к этому - он остаётся в сети
+	assert.NoError(t, servicePresence.Connected(1))
+	servicePresence.Publish(realtime.Event{Type: realtime.EventPresenceChanged, Data: json.RawMessage(`{"user_id":1,"status":"offline"}`)})
+	members, _ = servicePresence.GetPresence(2, 2)
+	assert.Equal(t, "online", members[0].Status)
+	assert.Len(t, events.events, 2)
+	assert.Equal(t, realtime.PresencePayload{UserID: 1, Status: "online"}, events.events[1].Data)
+}
+
+func TestPresenceService_Typing(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Date(2030, 1, 1, 9, 0, 0, 0, time.UTC)
+	mockPresence := mockRepo.NewMockPresence(ctrl)
+	bus := &loopback{}
+	servicePresence := NewPresenceService(mockPresence, bus, config.Presence{TypingTTL: 5 * time.Second})
+	servicePresence.now = func() time.Time { return now }
+	bus.presence = servicePresence
+
+	mockPresence.EXPECT().GetChatMembers(entity.PresenceGet{ChatID: 2, UserID: 2}).
+		Return([]entity.Presence{{UserID: 1}}, nil).AnyTimes()
+
+	// Печатать можно только в своём чате
+	mockPresence.EXPECT().CheckMember(entity.PresenceGet{ChatID: 3, UserID: 1}).Return(errors.New("not a member"))
+	assert.Equal(t, errors.New("not a member"), servicePresence.StartTyping(3, 1))
+	assert.Equal(t, apperr.Validation("empty chat_id or user_id"), servicePresence.StartTyping(0, 1))
+
+	mockPresence.EXPECT().CheckMember(entity.PresenceGet{ChatID: 2, UserID: 1}).Return(nil).Times(2)
+	assert.NoError(t, servicePresence.StartTyping(2, 1))
+	expiresAt := now.Add(5 * time.Second)
+	assert.Equal(t, realtime.Event{
+		Type:   realtime.EventTypingStarted,
+		ChatID: 2,
+		Data:   realtime.TypingPayload{UserID: 1, ExpiresAt: &expiresAt},
+	}, bus.events[0])
+
+	members, _ := servicePresence.GetPresence(2, 2)
+	assert.True(t, members[0].Typing)
+
+	// Индикатор истекает сам
+	now = now.Add(5 * time.Second)
+	members, _ = servicePresence.GetPresence(2, 2)
+	assert.False(t, members[0].Typing)
+
+	// Отправленное сообщение завершает набор текста
+	assert.NoError(t, servicePresence.StartTyping(2, 1))
+	members, _ = servicePresence.GetPresence(2, 2)
+	assert.True(t, members[0].Typing)
+	servicePresence.Publish(realtime.Event{Type: realtime.EventMessageCreated, ChatID: 2, Data: realtime.MessagePayload{MessageID: 7, UserID: 1}})
+	members, _ = servicePresence.GetPresence(2, 2)
+	assert.False(t, members[0].Typing)
+}
@@ -0,0 +1,149 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"service-chat/internal/config"
+	"service-chat/internal/db"
+	"service-chat/internal/db/entity"
+	"service-chat/internal/logger"
+	"service-chat/internal/preview"
+)
+
+// urlRe - http и https адреса в тексте сообщения
+var urlRe = regexp.MustCompile(`https?://[^\s<>"'()\[\]{}]+`)
+
+// previewJob - задание на загрузку превью ссылок сообщения
+type previewJob struct {
+	messageID int64
+	urls      []string
+}
+
+// PreviewService - фоновая загрузка превью ссылок из сообщений. Сообщение сохраняется сразу,
+// превью появляются в GetMessage после загрузки. Превью адреса кэшируется в бд на CacheTTL
+type PreviewService struct {
+	repo    db.Preview
+	fetcher preview.PreviewFetcher
+	cfg     config.Previews
+	queue   chan previewJob
+	// now - текущее время, подменяется в тестах
+	now func() time.Time
+}
+
+func NewPreviewService(repo db.Preview, fetcher preview.PreviewFetcher, cfg config.Previews) *PreviewService {
+	queueSize := cfg.QueueSize
+	if queueSize <= 0 {
+		queueSize = 1
+	}
+
+	return &PreviewService{
+		repo:    repo,
+		fetcher: fetcher,
+		cfg:     cfg,
+		queue:   make(chan previewJob, queueSize),
+		now:     time.Now,
+	}
+}
+
+// Enqueue - ставим ссылки из текста сообщения в очередь на загрузку превью.
+// Не блокирует отправку сообщения: если очередь переполнена, превью для сообщения не загружаются
+func (s *PreviewService) Enqueue(messageID int64, text string) {
+	if s.cfg.Disabled || messageID == 0 {
+		return
+	}
+
+	urls := extractURLs(text, s.cfg.MaxURLs)
+	if len(urls) == 0 {
+		return
+	}
+
+	select {
+	case s.queue <- previewJob{messageID: messageID, urls: urls}:
+	default:
+	}
+}
+
+// Run - загружаем превью из очереди в нескольких горутинах, пока не будет отменён контекст
+func (s *PreviewService) Run(ctx context.Context, log *slog.Logger) {
+	log = log.With(slog.String("op", "service.PreviewService"))
+
+	workers := s.cfg.Workers
+	if workers <= 0 {
+		workers = 1
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case job := <-s.queue:
+					if err := s.process(ctx, job); err != nil {
+						log.Error("failed to load link previews", slog.Int64("messageID", job.messageID), logger.Err(err))
+					}
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// process - сохраняем ссылки сообщения и загружаем превью адресов, которых нет в кэше или кэш устарел.
+// Недоступный адрес тоже кэшируем, чтобы не запрашивать его заново для каждого сообщения
+func (s *PreviewService) process(ctx context.Context, job previewJob) error {
+	if err := s.repo.AddMessageLinks(entity.MessageLinks{MessageID: job.messageID, URLs: job.urls}); err != nil {
+		return err
+	}
+
+	for _, url := range job.urls {
+		cached, err := s.repo.GetPreview(url)
+		if err != nil {
+			return err
+		}
+		if cached != nil && s.now().Sub(cached.FetchedAt) < s.cfg.CacheTTL {
+			continue
+		}
+
+		loaded, errFetch := s.fetcher.Fetch(ctx, url)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if errFetch != nil {
+			loaded = &entity.LinkPreview{URL: url, Status: entity.PreviewFailed}
+		} else {
+			loaded.URL, loaded.Status = url, entity.PreviewOK
+		}
+
+		if err = s.repo.SavePreview(*loaded); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// extractURLs - находим в тексте до max различных http(s) адресов, знаки препинания в конце адреса отбрасываем
+func extractURLs(text string, max int) []string {
+	var urls []string
+	for _, url := range urlRe.FindAllString(text, -1) {
+		if max > 0 && len(urls) == max {
+			break
+		}
+		url = strings.TrimRight(url, ".,;:!?*_~`")
+		if strings.HasSuffix(url, "//") || slices.Contains(urls, url) {
+			continue
+		}
+		urls = append(urls, url)
+	}
+	return urls
+}
@@ -0,0 +1,188 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"service-chat/internal/config"
+	"service-chat/internal/db/entity"
+	mockRepo "service-chat/internal/db/mocks"
+)
+
+// fakeFetcher - загрузчик превью без сети, запоминает запрошенные адреса
+type fakeFetcher struct {
+	mu       sync.Mutex
+	previews map[string]*entity.LinkPreview
+	fetched  []string
+}
+
+func (f *fakeFetcher) Fetch(_ context.Context, rawURL string) (*entity.LinkPreview, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.fetched = append(f.fetched, rawURL)
+	preview, ok := f.previews[rawURL]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	copied := *preview
+	return &copied, nil
+}
+
+func TestExtractURLs(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		max  int
+		want []string
+	}{
+		{
+			name: "Several urls",
+			text: "see https://example.com/a?b=1, and http://example.org.",
+			max:  3,
+			want: []string{"https://example.com/a?b=1", "http://example.org"},
+		},
+		{
+			name: "Markdown link",
+			text: "[docs](https://example.com/docs) **https://example.com/x**",
+			max:  3,
+			want: []string{"https://example.com/docs", "https://example.com/x"},
+		},
+		{
+			name: "Duplicates and limit",
+			text: "https://a.com https://a.com https://b.com https://c.com",
+			max:  2,
+			want: []string{"https://a.com", "https://b.com"},
+		},
+		{
+			name: "No urls",
+			text: "ftp://example.com and example.com and https://",
+			max:  3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, extractURLs(tt.text, tt.max))
+		})
+	}
+}
+
+func TestPreviewService_process(t *testing.T) {
+	//Инициализируем контролер для мока сервиса
+	ctrl := gomock.NewController(t)
+	// Завершаем работу контролера после выполнения каждого теста
+	defer ctrl.Finish()
+
+	// Создаём моки базы данных превью
+	mockPreview := mockRepo.NewMockPreview(ctrl)
+
+	now := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	fetcher := &fakeFetcher{previews: map[string]*entity.LinkPreview{
+		"https://stale.com": {Title: "Stale"},
+		"https://new.com":   {Title: "New"},
+	}}
+
+	// Создаём экземпляр сервиса превью
+	servicePreview := NewPreviewService(mockPreview, fetcher, config.Previews{CacheTTL: time.Hour})
+	servicePreview.now = func() time.Time { return now }
+
+	urls := []string{"https://fresh.com", "https://stale.com", "https://new.com", "https://down.com"}
+	gomock.InOrder(
+		mockPreview.EXPECT().AddMessageLinks(entity.MessageLinks{MessageID: 7, URLs: urls}).Return(nil),
+		// Свежий кэш - страницу не загружаем
+		mockPreview.EXPECT().GetPreview("https://fresh.com").
+			Return(&entity.LinkPreview{URL: "https://fresh.com", Status: entity.PreviewOK, FetchedAt: now.Add(-time.Minute)}, nil),
+		// Устаревший кэш - загружаем заново
+		mockPreview.EXPECT().GetPreview("https://stale.com").
+			Return(&entity.LinkPreview{URL: "https://stale.com", Status: entity.PreviewOK, FetchedAt: now.Add(-2 * time.Hour)}, nil),
+		mockPreview.EXPECT().SavePreview(entity.LinkPreview{URL: "https://stale.com", Title: "Stale", Status: entity.PreviewOK}).Return(nil),
+		// Адреса нет в кэше
+		mockPreview.EXPECT().GetPreview("https://new.com").Return(nil, nil),
+		mockPreview.EXPECT().SavePreview(entity.LinkPreview{URL: "https://new.com", Title: "New", Status: entity.PreviewOK}).Return(nil),
+		// Недоступный адрес кэшируется как failed
+		mockPreview.EXPECT().GetPreview("https://down.com").Return(nil, nil),
+		mockPreview.EXPECT().SavePreview(entity.LinkPreview{URL: "https://down.com", Status: entity.PreviewFailed}).Return(nil),
+	)
+
+	err := servicePreview.process(context.Background(), previewJob{messageID: 7, urls: urls})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"https://stale.com", "https://new.com", "https://down.com"}, fetcher.fetched)
+
+	// Ошибка бд прерывает обработку
+	mockPreview.EXPECT().AddMessageLinks(gomock.Any()).Return(errors.New("other error"))
+	err = servicePreview.process(context.Background(), previewJob{messageID: 8, urls: urls})
+	assert.Equal(t, errors.New("other error"), err)
+}
+
+func TestPreviewService_Enqueue(t *testing.T) {
+	//Инициализируем контролер для мока сервиса
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPreview := mockRepo.NewMockPreview(ctrl)
+
+	// Без ссылок и при выключенных превью в очередь ничего не попадает
+	disabled := NewPreviewService(mockPreview, &fakeFetcher{}, config.Previews{Disabled: true, QueueSize: 1})
+	disabled.Enqueue(1, "https://example.com")
+	assert.Len(t, disabled.queue, 0)
+
+	servicePreview := NewPreviewService(mockPreview, &fakeFetcher{}, config.Previews{QueueSize: 1, MaxURLs: 3})
+	servicePreview.Enqueue(1, "no links")
+	assert.Len(t, servicePreview.queue, 0)
+
+	// Переполненная очередь не блокирует отправку сообщения
+	servicePreview.Enqueue(1, "https://example.com")
+	servicePreview.Enqueue(2, "https://example.org")
+	assert.Len(t, servicePreview.queue, 1)
+	assert.Equal(t, previewJob{messageID: 1, urls: []string{"https://example.com"}}, <-servicePreview.queue)
+}
+
+func TestPreviewService_Run(t *testing.T) {
+	//Инициализируем контролер для мока сервиса
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPreview := mockRepo.NewMockPreview(ctrl)
+	fetcher := &fakeFetcher{previews: map[string]*entity.LinkPreview{"https://example.com": {Title: "Example"}}}
+	servicePreview := NewPreviewService(mockPreview, fetcher, config.Previews{Workers: 2, QueueSize: 10, MaxURLs: 3})
+
+	saved := make(chan struct{})
+	mockPreview.EXPECT().AddMessageLinks(entity.MessageLinks{MessageID: 1, URLs: []string{"https://example.com"}}).Return(nil)
+	mockPreview.EXPECT().GetPreview("https://example.com").Return(nil, nil)
+	mockPreview.EXPECT().SavePreview(entity.LinkPreview{URL: "https://example.com", Title: "Example", Status: entity.PreviewOK}).
+		DoAndReturn(func(entity.LinkPreview) error {
+			close(saved)
+			return nil
+		})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		servicePreview.Run(ctx, slog.New(slog.NewJSONHandler(io.Discard, nil)))
+		close(done)
+	}()
+
+	servicePreview.Enqueue(1, "look https://example.com")
+	select {
+	case <-saved:
+	case <-time.After(time.Second):
+		t.Fatal("preview was not saved")
+	}
+
+	// После отмены контекста все горутины завершаются
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Run did not stop")
+	}
+}
@@ -0,0 +1,50 @@
+package service
+
+import (
+	"service-chat/internal/apperr"
+	"service-chat/internal/db"
+	"service-chat/internal/db/entity"
+	"service-chat/internal/dto"
+)
+
+type ReactionService struct {
+	repo db.Reaction
+}
+
+func NewReactionService(repo db.Reaction) *ReactionService {
+	return &ReactionService{repo: repo}
+}
+
+// AddReaction - добавляем реакцию на сообщение от лица пользователя
+func (rs *ReactionService) AddReaction(in dto.ReactionAdd, messageID int64, userID int) ([]entity.Reaction, error) {
+	// Если запрос пустой
+	if messageID == 0 || userID == 0 {
+		return nil, apperr.Validation("empty message_id or user_id")
+	} else if in.Emoji == "" {
+		return nil, apperr.Validation("empty emoji")
+	}
+
+	dataDB := entity.ReactionAdd{
+		MessageID: messageID,
+		UserID:    userID,
+		Emoji:     in.Emoji,
+	}
+	return rs.repo.AddReaction(dataDB)
+}
+
+// DeleteReaction - убираем реакцию на сообщение от лица пользователя
+func (rs *ReactionService) DeleteReaction(in dto.ReactionDelete, messageID int64, userID int) ([]entity.Reaction, error) {
+	// Если запрос пустой
+	if messageID == 0 || userID == 0 {
+		return nil, apperr.Validation("empty message_id or user_id")
+	} else if in.Emoji == "" {
+		return nil, apperr.Validation("empty emoji")
+	}
+
+	dataDB := entity.ReactionDel{
+		MessageID: messageID,
+		UserID:    userID,
+		Emoji:     in.Emoji,
+	}
+	return rs.repo.DeleteReaction(dataDB)
+}
@@ -0,0 +1,177 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"service-chat/internal/apperr"
+	"service-chat/internal/db/entity"
+	mockRepo "service-chat/internal/db/mocks"
+	"service-chat/internal/dto"
+)
+
+func TestReactionService_AddReaction(t *testing.T) {
+	// Структура для последующей реализации поведения мока
+	type mockBehaviour func(s *mockRepo.MockReaction, dataDB entity.ReactionAdd)
+
+	//Инициализируем контролер для мока сервиса
+	ctrl := gomock.NewController(t)
+	// Завершаем работу контролера после выполнения каждого теста
+	defer ctrl.Finish()
+
+	// Создаём моки базы данных реакций
+	mockReaction := mockRepo.NewMockReaction(ctrl)
+
+	// Создаём экземпляр сервиса реакций
+	serviceReaction := NewReactionService(mockReaction)
+
+	tests := []struct {
+		name      string
+		in        dto.ReactionAdd
+		messageID int64
+		userID    int
+		dataDB    entity.ReactionAdd
+		mock      mockBehaviour
+		want      []entity.Reaction
+		wantErr   error
+	}{
+		{
+			name:      "Success",
+			in:        dto.ReactionAdd{Emoji: "👍"},
+			messageID: 1,
+			userID:    1,
+			dataDB:    entity.ReactionAdd{MessageID: 1, UserID: 1, Emoji: "👍"},
+			mock: func(s *mockRepo.MockReaction, dataDB entity.ReactionAdd) {
+				s.EXPECT().AddReaction(dataDB).Return([]entity.Reaction{{Emoji: "👍", Count: 1, ReactedByMe: true}}, nil)
+			},
+			want: []entity.Reaction{{Emoji: "👍", Count: 1, ReactedByMe: true}},
+		},
+		{
+			name:      "Empty message_id",
+			in:        dto.ReactionAdd{Emoji: "👍"},
+			messageID: 0,
+			userID:    1,
+			mock:      func(s *mockRepo.MockReaction, dataDB entity.ReactionAdd) {},
+			wantErr:   apperr.Validation("empty message_id or user_id"),
+		},
+		{
+			name:      "Empty user_id",
+			in:        dto.ReactionAdd{Emoji: "👍"},
+			messageID: 1,
+			userID:    0,
+			mock:      func(s *mockRepo.MockReaction, dataDB entity.ReactionAdd) {},
+			wantErr:   apperr.Validation("empty message_id or user_id"),
+		},
+		{
+			name:      "Empty emoji",
+			in:        dto.ReactionAdd{},
+			messageID: 1,
+			userID:    1,
+			mock:      func(s *mockRepo.MockReaction, dataDB entity.ReactionAdd) {},
+			wantErr:   apperr.Validation("empty emoji"),
+		},
+		{
+			name:      "Other error",
+			in:        dto.ReactionAdd{Emoji: "👍"},
+			messageID: 1,
+			userID:    1,
+			dataDB:    entity.ReactionAdd{MessageID: 1, UserID: 1, Emoji: "👍"},
+			mock: func(s *mockRepo.MockReaction, dataDB entity.ReactionAdd) {
+				s.EXPECT().AddReaction(dataDB).Return(nil, errors.New("other error"))
+			},
+			wantErr: errors.New("other error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Передаём структуру реакции
+			tt.mock(mockReaction, tt.dataDB)
+
+			// Проверяем ожидаемый и актуальный результат
+			acReactions, acErr := serviceReaction.AddReaction(tt.in, tt.messageID, tt.userID)
+			assert.Equal(t, tt.want, acReactions)
+			assert.Equal(t, tt.wantErr, acErr)
+		})
+	}
+}
+
+func TestReactionService_DeleteReaction(t *testing.T) {
+	// Структура для последующей реализации поведения мока
+	type mockBehaviour func(s *mockRepo.MockReaction, dataDB entity.ReactionDel)
+
+	//Инициализируем контролер для мока сервиса
+	ctrl := gomock.NewController(t)
+	// Завершаем работу контролера после выполнения каждого теста
+	defer ctrl.Finish()
+
+	// Создаём моки базы данных реакций
+	mockReaction := mockRepo.NewMockReaction(ctrl)
+
+	// Создаём экземпляр сервиса реакций
+	serviceReaction := NewReactionService(mockReaction)
+
+	tests := []struct {
+		name      string
+		in        dto.ReactionDelete
+		messageID int64
+		userID    int
+		dataDB    entity.ReactionDel
+		mock      mockBehaviour
+		want      []entity.Reaction
+		wantErr   error
+	}{
+		{
+			name:      "Success",
+			in:        dto.ReactionDelete{Emoji: "👍"},
+			messageID: 1,
+			userID:    1,
+			dataDB:    entity.ReactionDel{MessageID: 1, UserID: 1, Emoji: "👍"},
+			mock: func(s *mockRepo.MockReaction, dataDB entity.ReactionDel) {
+				s.EXPECT().DeleteReaction(dataDB).Return(nil, nil)
+			},
+		},
+		{
+			name:      "Empty message_id",
+			in:        dto.ReactionDelete{Emoji: "👍"},
+			messageID: 0,
+			userID:    1,
+			mock:      func(s *mockRepo.MockReaction, dataDB entity.ReactionDel) {},
+			wantErr:   apperr.Validation("empty message_id or user_id"),
+		},
+		{
+			name:      "Empty emoji",
+			in:        dto.ReactionDelete{},
+			messageID: 1,
+			userID:    1,
+			mock:      func(s *mockRepo.MockReaction, dataDB entity.ReactionDel) {},
+			wantErr:   apperr.Validation("empty emoji"),
+		},
+		{
+			name:      "Other error",
+			in:        dto.ReactionDelete{Emoji: "👍"},
+			messageID: 1,
+			userID:    1,
+			dataDB:    entity.ReactionDel{MessageID: 1, UserID: 1, Emoji: "👍"},
+			mock: func(s *mockRepo.MockReaction, dataDB entity.ReactionDel) {
+				s.EXPECT().DeleteReaction(dataDB).Return(nil, errors.New("other error"))
+			},
+			wantErr: errors.New("other error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Передаём структуру реакции
+			tt.mock(mockReaction, tt.dataDB)
+
+			// Проверяем ожидаемый и актуальный результат
+			acReactions, acErr := serviceReaction.DeleteReaction(tt.in, tt.messageID, tt.userID)
+			assert.Equal(t, tt.want, acReactions)
+			assert.Equal(t, tt.wantErr, acErr)
+		})
+	}
+}
@@ -0,0 +1,80 @@
+package service
+
+import (
+	"service-chat/internal/apperr"
+	"service-chat/internal/db"
+	"service-chat/internal/db/entity"
+	"service-chat/internal/markdown"
+	"service-chat/internal/realtime"
+)
+
+// resultChatDeleted - результат функции delete_chat в бд для удалённого чата
+const resultChatDeleted = "Chat successfully deleted"
+
+type RealtimeService struct {
+	repo     db.Chat
+	hub      *realtime.Hub
+	presence Presence
+}
+
+func NewRealtimeService(repo db.Chat, hub *realtime.Hub, presence Presence) *RealtimeService {
+	return &RealtimeService{repo: repo, hub: hub, presence: presence}
+}
+
+// Subscribe - регистрируем подключение пользователя и подписываем его на все его чаты
+func (s *RealtimeService) Subscribe(userID int) (*realtime.Client, error) {
+	client, _, err := s.Resume(userID, 0)
+	return client, err
+}
+
+// Resume - регистрируем подключение пользователя и возвращаем пропущенные после lastEventID события его чатов
+func (s *RealtimeService) Resume(userID int, lastEventID uint64) (*realtime.Client, []realtime.Delivery, error) {
+	if userID == 0 {
+		return nil, nil, apperr.Validation("user_id is empty")
+	}
+
+	chats, err := s.repo.GetChat(entity.ChatGet{UserID: int64(userID)})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	chatIDs := make([]int64, 0, len(chats))
+	for _, chat := range chats {
+		if !chat.IsDeleted {
+			chatIDs = append(chatIDs, chat.Id)
+		}
+	}
+
+	client, missed, err := s.hub.Resume(int64(userID), chatIDs, lastEventID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Подключённый пользователь в сети
+	if errPresence := s.presence.Connected(userID); errPresence != nil {
+		_ = s.Unsubscribe(client)
+		return nil, nil, errPresence
+	}
+
+	return client, missed, nil
+}
+
+// Unsubscribe - снимаем подключение с регистрации, после последнего подключения пользователь выходит из сети
+func (s *RealtimeService) Unsubscribe(c *realtime.Client) error {
+	s.hub.Unregister(c)
+	return s.presence.Disconnected(int(c.UserID()))
+}
+
+// messagePayload - данные события о новом или отредактированном сообщении
+func messagePayload(messageID, userID int64, text, format string) realtime.MessagePayload {
+	payload := realtime.MessagePayload{
+		MessageID: messageID,
+		UserID:    userID,
+		Text:      text,
+		Format:    format,
+	}
+	if format == entity.FormatMarkdown {
+		payload.HTML = markdown.ToHTML(text)
+	}
+	return payload
+}
@@ -0,0 +1,160 @@
+package service
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"service-chat/internal/apperr"
+	"service-chat/internal/config"
+	"service-chat/internal/db/entity"
+	mockRepo "service-chat/internal/db/mocks"
+	"service-chat/internal/dto"
+	"service-chat/internal/realtime"
+	mockService "service-chat/internal/service/mocks"
+)
+
+// fakePublisher - запоминает опубликованные события
+type fakePublisher struct {
+	mu     sync.Mutex
+	events []realtime.Event
+}
+
+func (f *fakePublisher) Publish(ev realtime.Event) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.events = append(f.events, ev)
+}
+
+func TestRealtimeService_Subscribe(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockChat := mockRepo.NewMockChat(ctrl)
+	mockPresence := mockService.NewMockPresence(ctrl)
+	hub := realtime.NewHub(config.Realtime{SendBuffer: 4})
+	serviceRealtime := NewRealtimeService(mockChat, hub, mockPresence)
+
+	t.Run("Empty user_id", func(t *testing.T) {
+		_, err := serviceRealtime.Subscribe(0)
+		assert.Equal(t, apperr.Validation("user_id is empty"), err)
+	})
+
+	t.Run("DB error", func(t *testing.T) {
+		mockChat.EXPECT().GetChat(entity.ChatGet{UserID: 1}).Return(nil, errors.New("db error"))
+		_, err := serviceRealtime.Subscribe(1)
+		assert.Equal(t, errors.New("db error"), err)
+	})
+
+	t.Run("Presence error", func(t *testing.T) {
+		mockChat.EXPECT().GetChat(entity.ChatGet{UserID: 1}).Return(nil, nil)
+		mockPresence.EXPECT().Connected(1).Return(errors.New("db error"))
+		mockPresence.EXPECT().Disconnected(1).Return(nil)
+		_, err := serviceRealtime.Subscribe(1)
+		assert.Equal(t, errors.New("db error"), err)
+	})
+
+	t.Run("Subscribed to not deleted chats", func(t *testing.T) {
+		mockChat.EXPECT().GetChat(entity.ChatGet{UserID: 1}).
+			Return([]entity.Chat{{Id: 2}, {Id: 3, IsDeleted: true}}, nil)
+		mockPresence.EXPECT().Connected(1).Return(nil)
+		client, err := serviceRealtime.Subscribe(1)
+		assert.NoError(t, err)
+		defer func() {
+			mockPresence.EXPECT().Disconnected(1).Return(nil)
+			assert.NoError(t, serviceRealtime.Unsubscribe(client))
+		}()
+
+		// Событие удалённого чата не доставляется, событие чата 2 доставляется
+		hub.Publish(realtime.Event{Type: realtime.EventMessageDeleted, ChatID: 3})
+		hub.Publish(realtime.Event{Type: realtime.EventMessageDeleted, ChatID: 2})
+		assert.Equal(t, []string{`{"type":"message.deleted","chat_id":2}`}, pending(client))
+	})
+}
+
+func TestMessageService_Events(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockMessage := mockRepo.NewMockMessage(ctrl)
+	mockPreview := mockService.NewMockPreview(ctrl)
+	events := &fakePublisher{}
+	serviceMessage := NewMessageService(mockMessage, config.Messages{}, mockPreview, events)
+
+	// Новое сообщение
+	mockMessage.EXPECT().AddMessage(gomock.Any()).Return(7, nil)
+	mockPreview.EXPECT().Enqueue(int64(7), "**hi**")
+	_, _, err := serviceMessage.AddMessage(dto.MessageAdd{ChatID: 2, UserID: 1, Text: "**hi**", Format: "markdown"})
+	assert.NoError(t, err)
+
+	// Редактирование
+	mockMessage.EXPECT().UpdateMessage(gomock.Any()).Return(7, nil)
+	mockMessage.EXPECT().MessageChats([]int64{7}).Return(map[int64]int64{7: 2}, nil)
+	_, err = serviceMessage.UpdateMessage(dto.MessageUpdate{MessageID: 7, UserID: 1, NewText: "edited"})
+	assert.NoError(t, err)
+
+	// Удаление, событие только для действительно удалённых сообщений
+	ids := []int64{7, 8}
+	mockMessage.EXPECT().DeleteMessage(entity.MessageDel{MsgIds: ids, UserID: 1}).Return([]entity.DelMsg{
+		{MessageID: 7, Result: "Message successfully deleted"},
+		{MessageID: 8, Result: "Message does not exist or has already been deleted"},
+	}, nil)
+	mockMessage.EXPECT().MessageChats([]int64{7}).Return(map[int64]int64{7: 2}, nil)
+	_, err = serviceMessage.DeleteMessage(dto.MessageDelete{MessageIds: &ids}, 1)
+	assert.NoError(t, err)
+
+	// Ошибка бд - событий нет
+	mockMessage.EXPECT().UpdateMessage(gomock.Any()).Return(0, errors.New("db error"))
+	_, err = serviceMessage.UpdateMessage(dto.MessageUpdate{MessageID: 7, UserID: 1, NewText: "edited"})
+	assert.Error(t, err)
+
+	assert.Equal(t, []realtime.Event{
+		{Type: realtime.EventMessageCreated, ChatID: 2, Data: realtime.MessagePayload{
+			MessageID: 7, UserID: 1, Text: "**hi**", Format: "markdown", HTML: "<p><strong>hi</strong></p>",
+		}},
+		{Type: realtime.EventMessageUpdated, ChatID: 2, Data: realtime.MessagePayload{MessageID: 7, UserID: 1, Text: "edited"}},
+		{Type: realtime.EventMessageDeleted, ChatID: 2, Data: realtime.MessagePayload{MessageID: 7}},
+	}, events.events)
+}
+
+func TestChatService_Events(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockChat := mockRepo.NewMockChat(ctrl)
+	events := &fakePublisher{}
+	serviceChat := NewChatService(mockChat, events)
+
+	mockChat.EXPECT().CreateChat(gomock.Any()).Return(5, nil)
+	_, err := serviceChat.CreateChat(dto.ChatAdd{ChatName: "team", Users: []int64{2}}, 1)
+	assert.NoError(t, err)
+
+	ids := []int64{5, 6}
+	mockChat.EXPECT().DeleteChat(entity.ChatDelete{ChatIds: ids, UserID: 1}).Return([]entity.DeletedChats{
+		{ChatID: 5, Result: "Chat successfully deleted"},
+		{ChatID: 6, Result: "Chat does not exist or has already been deleted"},
+	}, nil)
+	_, err = serviceChat.DeleteChat(dto.ChatDelete{ChatIds: &ids}, 1)
+	assert.NoError(t, err)
+
+	assert.Equal(t, []realtime.Event{
+		{Type: realtime.EventChatCreated, ChatID: 5, UserIDs: []int64{2, 1}, Data: realtime.ChatPayload{Name: "team", AdminID: 1}},
+		{Type: realtime.EventChatDeleted, ChatID: 5},
+	}, events.events)
+}
+
+// pending - события, которые ждут отправки клиенту
+func pending(c *realtime.Client) []string {
+	var out []string
+	for {
+		select {
+		case payload := <-c.Events():
+			out = append(out, string(payload.Payload))
+		default:
+			return out
+		}
+	}
+}
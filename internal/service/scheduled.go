@@ -0,0 +1,220 @@
+package service
+
+import (
+	"errors"
+	"time"
+
+	"service-chat/internal/apperr"
+	"service-chat/internal/config"
+	"service-chat/internal/db"
+	"service-chat/internal/db/entity"
+	"service-chat/internal/dto"
+)
+
+type ScheduledService struct {
+	repo db.Scheduled
+	// sender - отправляет наступившие сообщения в чат тем же путём, что и обычную отправку
+	sender Message
+	cfg    config.Scheduler
+	// messages - ограничения текста, такие же как у обычных сообщений
+	messages config.Messages
+	// filters - проверяет текст до сохранения, если не задан - текст сохраняется как есть
+	filters Filter
+	// now - текущее время, подменяется в тестах
+	now func() time.Time
+}
+
+func NewScheduledService(repo db.Scheduled, sender Message, cfg config.Scheduler, messages config.Messages) *ScheduledService {
+	return &ScheduledService{repo: repo, sender: sender, cfg: cfg, messages: messages, now: time.Now}
+}
+
+// ScheduleMessage - планируем отправку сообщения в чат от лица пользователя на время send_at.
+// Фильтры чата отклоняют или маскируют текст сразу, а на модерацию сообщение попадёт при отправке
+func (s *ScheduledService) ScheduleMessage(in dto.MessageAdd) (int64, error) {
+	// Если запрос пустой
+	if in.ChatID == 0 || in.UserID == 0 {
+		return 0, apperr.Validation("empty chat_id or user_id")
+	} else if in.Text == "" {
+		return 0, apperr.Validation("empty text")
+	} else if in.SendAt == nil {
+		return 0, apperr.Validation("empty send_at")
+	}
+
+	if err := checkText(in.Text, in.Format, s.messages); err != nil {
+		return 0, err
+	}
+	if err := s.checkSendAt(*in.SendAt); err != nil {
+		return 0, err
+	}
+
+	text, err := s.filterText(in.ChatID, in.Text)
+	if err != nil {
+		return 0, err
+	}
+	in.Text = text
+
+	dataDB := entity.ScheduledAdd{
+		ChatID: in.ChatID,
+		UserID: in.UserID,
+		Text:   in.Text,
+		Format: in.Format,
+		SendAt: *in.SendAt,
+	}
+	return s.repo.AddScheduled(dataDB)
+}
+
+// GetScheduled - получаем отложенные сообщения автора
+func (s *ScheduledService) GetScheduled(in dto.ScheduledGet, userID int) ([]entity.ScheduledMessage, error) {
+	// Если запрос пустой
+	if userID == 0 {
+		return nil, apperr.Validation("empty user_id")
+	}
+
+	dataDB := entity.ScheduledGet{
+		UserID: userID,
+		ChatID: in.ChatID,
+	}
+	return s.repo.GetScheduled(dataDB)
+}
+
+// UpdateScheduled - редактируем текст и/или время отправки отложенного сообщения
+func (s *ScheduledService) UpdateScheduled(in dto.ScheduledUpdate, id int64, userID int) error {
+	// Если запрос пустой
+	if id == 0 || userID == 0 {
+		return apperr.Validation("empty id or user_id")
+	} else if in.Text == "" && in.SendAt == nil {
+		return apperr.Validation("nothing to update")
+	}
+
+	if err := checkText(in.Text, "", s.messages); err != nil {
+		return err
+	}
+	if in.SendAt != nil {
+		if err := s.checkSendAt(*in.SendAt); err != nil {
+			return err
+		}
+	}
+
+	// Новый текст проходит фильтры чата, в который отложено сообщение.
+	// Если сообщение не найдено, действуют настройки из конфига, а редактирование отклонит слой бд
+	if in.Text != "" && s.filters != nil {
+		scheduled, err := s.repo.GetScheduled(entity.ScheduledGet{UserID: userID})
+		if err != nil {
+			return err
+		}
+		var chatID int64
+		for _, msg := range scheduled {
+			if msg.Id == id {
+				chatID = msg.ChatID
+				break
+			}
+		}
+		if in.Text, err = s.filterText(chatID, in.Text); err != nil {
+			return err
+		}
+	}
+
+	dataDB := entity.ScheduledUpdate{
+		ID:     id,
+		UserID: userID,
+		Text:   in.Text,
+		SendAt: in.SendAt,
+	}
+	return s.repo.UpdateScheduled(dataDB)
+}
+
+// CancelScheduled - отменяем отложенное сообщение
+func (s *ScheduledService) CancelScheduled(id int64, userID int) error {
+	// Если запрос пустой
+	if id == 0 || userID == 0 {
+		return apperr.Validation("empty id or user_id")
+	}
+
+	dataDB := entity.ScheduledDel{
+		ID:     id,
+		UserID: userID,
+	}
+	return s.repo.CancelScheduled(dataDB)
+}
+
+// DispatchDue - отправляем в чаты отложенные сообщения, время которых наступило, и возвращаем количество отправленных.
+// Ошибка одного сообщения не мешает отправке остальных, ошибки возвращаются вместе
+func (s *ScheduledService) DispatchDue() (int, error) {
+	now := s.now()
+	// Сообщение, забранное раньше этого времени и всё ещё не отправленное, бросил упавший экземпляр сервиса
+	leaseBefore := now.Add(-s.cfg.ClaimTimeout)
+	due, err := s.repo.GetDueScheduled(entity.ScheduledDue{Now: now, LeaseBefore: leaseBefore, Limit: s.cfg.BatchSize})
+	if err != nil {
+		return 0, err
+	}
+
+	var sent int
+	var errs []error
+	for _, item := range due {
+		// Сообщение уже отправлено, отменено или его забрал другой экземпляр сервиса
+		msg, errClaim := s.repo.ClaimScheduled(entity.ScheduledClaim{ID: item.Id, Now: now, LeaseBefore: leaseBefore})
+		if errClaim != nil {
+			errs = append(errs, errClaim)
+			continue
+		}
+		if msg == nil {
+			continue
+		}
+
+		// Отправляем так же, как сообщение без send_at: команда выполняется, текст проходит фильтры,
+		// а после сохранения ставятся превью ссылок, уведомления и событие
+		messageID, _, errSend := s.sender.AddMessage(dto.MessageAdd{
+			ChatID: msg.ChatID,
+			UserID: msg.UserID,
+			Text:   msg.Text,
+			Format: msg.Format,
+		})
+		finish := entity.ScheduledFinish{ID: msg.Id, MessageID: int64(messageID)}
+		if errSend != nil {
+			errs = append(errs, errSend)
+			finish.MessageID = 0
+			// Временную ошибку повторим при следующей проверке, остальные повторять бесполезно
+			switch appErr, ok := apperr.As(errSend); {
+			case !ok || appErr.Code == apperr.CodeUnavailable:
+				finish.Retry = true
+			default:
+				finish.Error = appErr.Error()
+			}
+		}
+
+		if errFinish := s.repo.FinishScheduled(finish); errFinish != nil {
+			errs = append(errs, errFinish)
+			continue
+		}
+		if errSend == nil {
+			sent++
+		}
+	}
+
+	return sent, errors.Join(errs...)
+}
+
+// filterText - применяем фильтры чата к тексту: отклонённый текст возвращает ошибку, маскированный сохраняется
+func (s *ScheduledService) filterText(chatID int64, text string) (string, error) {
+	if s.filters == nil {
+		return text, nil
+	}
+
+	result, err := s.filters.CheckText(chatID, text)
+	if err != nil {
+		return "", err
+	}
+	return result.Text, nil
+}
+
+// checkSendAt - время отправки должно быть в будущем, но не дальше допустимого
+func (s *ScheduledService) checkSendAt(sendAt time.Time) error {
+	now := s.now()
+	if !sendAt.After(now) {
+		return apperr.Validation("send_at must be in the future")
+	}
+	if s.cfg.MaxDelay > 0 && sendAt.After(now.Add(s.cfg.MaxDelay)) {
+		return apperr.Validation("send_at is too far in the future")
+	}
+	return nil
+}
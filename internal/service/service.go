@@ -1,9 +1,21 @@
 package service
 
 import (
+	"context"
+	"io"
+	"log/slog"
+
+	"service-chat/internal/bot"
+	"service-chat/internal/config"
 	"service-chat/internal/db"
 	"service-chat/internal/db/entity"
 	"service-chat/internal/dto"
+	"service-chat/internal/filter"
+	"service-chat/internal/notify"
+	"service-chat/internal/preview"
+	"service-chat/internal/realtime"
+	"service-chat/internal/storage"
+	"service-chat/internal/webhook"
 )
 
 // Генерируем моки для интерфейсов слоя сервиса
@@ -19,12 +31,14 @@ type Authorization interface {
 	GenerateToken(user dto.SignInRequest) (string, error)
 	// ParseToken - анализируем jwt token
 	ParseToken(token string) (int, error)
+	// CheckSuspension - проверяем, что пользователь не отстранён модератором
+	CheckSuspension(userID int) error
 }
 
 // Chat - интерфейс для чатов
 type Chat interface {
-	// CreateChat - создаём чат между пользователями
-	CreateChat(in dto.ChatAdd) (int, error)
+	// CreateChat - создаём чат между пользователями, создатель чата становится его администратором
+	CreateChat(in dto.ChatAdd, userID int) (int, error)
 	// GetChat - получение чатов пользователя
 	GetChat(in dto.ChatGet) ([]entity.Chat, error)
 	// DeleteChat - удаление чатов
@@ -33,14 +47,207 @@ type Chat interface {
 
 // Message - интерфейс для сообщений
 type Message interface {
-	// AddMessage - отправить сообщение в чат от лица пользователя
-	AddMessage(in dto.MessageAdd) (int, error)
+	// AddMessage - отправить сообщение в чат от лица пользователя. Сообщение, которое начинается с /,
+	// выполняется как команда: в чат оно не попадает, а ответ команды возвращается вторым значением
+	AddMessage(in dto.MessageAdd) (int, *entity.CommandReply, error)
 	// UpdateMessage - обновить сообщение пользователя
 	UpdateMessage(in dto.MessageUpdate) (int, error)
 	// GetMessage - получить список всех сообщений в конкретном чате
 	GetMessage(in dto.MessageGet, userID int) ([]entity.Message, error)
 	// DeleteMessage - удаление сообщений от лица пользователя
 	DeleteMessage(in dto.MessageDelete, userID int) ([]entity.DelMsg, error)
+	// SearchMessage - полнотекстовый поиск по сообщениям в чатах пользователя
+	SearchMessage(in dto.MessageSearch, userID int) ([]entity.SearchResult, string, error)
+	// ForwardMessage - пересылка сообщения в другие чаты от лица пользователя
+	ForwardMessage(in dto.MessageForward, messageID int64, userID int) ([]entity.Forwarded, error)
+}
+
+// Reaction - интерфейс для реакций на сообщения
+type Reaction interface {
+	// AddReaction - добавить реакцию на сообщение от лица пользователя
+	AddReaction(in dto.ReactionAdd, messageID int64, userID int) ([]entity.Reaction, error)
+	// DeleteReaction - убрать реакцию на сообщение от лица пользователя
+	DeleteReaction(in dto.ReactionDelete, messageID int64, userID int) ([]entity.Reaction, error)
+}
+
+// Attachment - интерфейс для вложений сообщений
+type Attachment interface {
+	// AddAttachment - прикрепить файл к сообщению от лица автора сообщения
+	AddAttachment(in dto.AttachmentAdd, messageID int64, userID int) (*entity.Attachment, error)
+	// GetAttachment - скачать вложение или его миниатюру, если пользователь состоит в чате
+	GetAttachment(attachmentID int64, userID int, thumbnail bool) (*entity.Attachment, io.ReadCloser, error)
+	// MaxUploadSize - максимальный размер одного файла в байтах
+	MaxUploadSize() int64
+}
+
+// Mention - интерфейс для упоминаний пользователей
+type Mention interface {
+	// GetMentions - лента сообщений, в которых упомянут пользователь
+	GetMentions(in dto.MentionGet, userID int) ([]entity.Message, error)
+}
+
+// Pin - интерфейс для закреплённых сообщений
+type Pin interface {
+	// PinMessage - закрепляем сообщение в чате, доступно администраторам чата
+	PinMessage(messageID int64, userID int) error
+	// UnpinMessage - открепляем сообщение в чате, доступно администраторам чата
+	UnpinMessage(messageID int64, userID int) error
+	// GetPins - получаем закреплённые сообщения чата
+	GetPins(chatID int64, userID int) ([]entity.Message, error)
+}
+
+// Scheduled - интерфейс для отложенных сообщений
+type Scheduled interface {
+	// ScheduleMessage - планируем отправку сообщения в чат на время send_at
+	ScheduleMessage(in dto.MessageAdd) (int64, error)
+	// GetScheduled - получаем отложенные сообщения автора
+	GetScheduled(in dto.ScheduledGet, userID int) ([]entity.ScheduledMessage, error)
+	// UpdateScheduled - редактируем отложенное сообщение
+	UpdateScheduled(in dto.ScheduledUpdate, id int64, userID int) error
+	// CancelScheduled - отменяем отложенное сообщение
+	CancelScheduled(id int64, userID int) error
+	// DispatchDue - отправляем отложенные сообщения, время которых наступило
+	DispatchDue() (int, error)
+}
+
+// Draft - интерфейс для черновиков сообщений
+type Draft interface {
+	// SaveDraft - сохраняем черновик, побеждает запись с большей версией
+	SaveDraft(in dto.DraftSave, chatID int64, userID int) (*entity.Draft, bool, error)
+	// GetDraft - получаем черновик пользователя в чате
+	GetDraft(chatID int64, userID int) (*entity.Draft, error)
+	// DeleteDraft - удаляем черновик, побеждает запись с большей версией
+	DeleteDraft(in dto.DraftDelete, chatID int64, userID int) (*entity.Draft, bool, error)
+}
+
+// Preview - интерфейс для превью ссылок в сообщениях
+type Preview interface {
+	// Enqueue - ставим ссылки из текста сообщения в очередь на загрузку превью
+	Enqueue(messageID int64, text string)
+	// Run - загружаем превью в фоне, пока не будет отменён контекст
+	Run(ctx context.Context, log *slog.Logger)
+}
+
+// Realtime - интерфейс для доставки событий чатов подключённым клиентам
+type Realtime interface {
+	// Subscribe - регистрируем подключение пользователя и подписываем его на все его чаты
+	Subscribe(userID int) (*realtime.Client, error)
+	// Resume - регистрируем подключение и возвращаем события после lastEventID, которые пользователь пропустил
+	Resume(userID int, lastEventID uint64) (*realtime.Client, []realtime.Delivery, error)
+	// Unsubscribe - снимаем подключение с регистрации
+	Unsubscribe(c *realtime.Client) error
+}
+
+// Presence - интерфейс для статусов пользователей в сети и индикатора набора текста
+type Presence interface {
+	// Connected - у пользователя появилось подключение к /ws или /events
+	Connected(userID int) error
+	// Disconnected - подключение пользователя закрыто
+	Disconnected(userID int) error
+	// SetStatus - пользователь отмечает себя отошедшим или вернувшимся
+	SetStatus(in dto.PresenceSet, userID int) error
+	// GetPresence - статусы участников чата и кто из них печатает
+	GetPresence(chatID int64, userID int) ([]entity.Presence, error)
+	// StartTyping - участник чата начал печатать
+	StartTyping(chatID int64, userID int) error
+	// StopTyping - участник чата перестал печатать
+	StopTyping(chatID int64, userID int) error
+	// Publish - получаем события шины, чтобы знать статусы пользователей других экземпляров сервиса
+	Publish(ev realtime.Event)
+}
+
+// Webhook - интерфейс для исходящих вебхуков чатов
+type Webhook interface {
+	// AddWebhook - создаём вебхук чата, ключ подписи возвращается только здесь
+	AddWebhook(in dto.WebhookAdd, chatID int64, userID int) (*entity.Webhook, error)
+	// GetWebhooks - получаем вебхуки чата
+	GetWebhooks(chatID int64, userID int) ([]entity.Webhook, error)
+	// DeleteWebhook - удаляем вебхук чата
+	DeleteWebhook(webhookID, chatID int64, userID int) error
+	// GetAttempts - последние попытки отправки событий в вебхук
+	GetAttempts(in dto.WebhookAttemptsGet, webhookID, chatID int64, userID int) ([]entity.WebhookAttempt, error)
+	// Run - отправляем события из очереди в фоне, пока не будет отменён контекст
+	Run(ctx context.Context, log *slog.Logger)
+}
+
+// Incoming - интерфейс для входящих вебхуков чатов
+type Incoming interface {
+	// AddIncoming - создаём входящий вебхук чата, токен возвращается только здесь
+	AddIncoming(in dto.IncomingAdd, chatID int64, userID int) (*entity.IncomingWebhook, error)
+	// GetIncoming - получаем входящие вебхуки чата
+	GetIncoming(chatID int64, userID int) ([]entity.IncomingWebhook, error)
+	// DeleteIncoming - удаляем входящий вебхук чата
+	DeleteIncoming(webhookID, chatID int64, userID int) error
+	// PostIncoming - отправляем в чат сообщение внешней системы по токену вебхука
+	PostIncoming(token string, in dto.IncomingMessage) (int, error)
+}
+
+// Command - интерфейс для команд чатов
+type Command interface {
+	// AddCommand - создаём команду чата, которую обрабатывает внешний бот, ключ подписи возвращается только здесь
+	AddCommand(in dto.CommandAdd, chatID int64, userID int) (*entity.ChatCommand, error)
+	// GetCommands - получаем команды внешних ботов чата
+	GetCommands(chatID int64, userID int) ([]entity.ChatCommand, error)
+	// DeleteCommand - удаляем команду чата
+	DeleteCommand(commandID, chatID int64, userID int) error
+	// ExecuteCommand - выполняем команду из сообщения участника чата
+	ExecuteCommand(in dto.MessageAdd) (*entity.CommandReply, error)
+}
+
+// Poll - интерфейс для опросов в чатах
+type Poll interface {
+	// CreatePoll - создать опрос в чате от лица пользователя
+	CreatePoll(in dto.PollAdd, chatID int64, userID int) (int, error)
+	// Vote - проголосовать в опросе, новый голос заменяет прежний
+	Vote(in dto.PollVote, messageID int64, userID int) (*entity.Poll, error)
+	// Unvote - отозвать голос в опросе
+	Unvote(messageID int64, userID int) (*entity.Poll, error)
+}
+
+// Notification - интерфейс для уведомлений о сообщениях пользователей не в сети
+type Notification interface {
+	// GetSettings - адреса, на которые пользователь получает уведомления
+	GetSettings(userID int) (*entity.NotifySettings, error)
+	// SaveSettings - сохраняем адреса уведомлений пользователя
+	SaveSettings(in dto.NotifySettings, userID int) error
+	// SetChatNotify - уровень уведомлений чата и заглушение чата для участника
+	SetChatNotify(in dto.ChatNotify, chatID int64, userID int) error
+	// Enqueue - ставим новое сообщение в очередь на выбор получателей уведомлений
+	Enqueue(chatID, messageID, authorID int64)
+	// Run - создаём и отправляем уведомления в фоне, пока не будет отменён контекст
+	Run(ctx context.Context, log *slog.Logger)
+}
+
+// Block - интерфейс для чёрного списка пользователя
+type Block interface {
+	// AddBlock - вносим пользователя в чёрный список
+	AddBlock(in dto.BlockAdd, userID int) error
+	// GetBlocks - получаем чёрный список пользователя
+	GetBlocks(userID int) ([]entity.Block, error)
+	// DeleteBlock - убираем пользователя из чёрного списка
+	DeleteBlock(blockedID int64, userID int) error
+}
+
+// Moderation - интерфейс для жалоб на сообщения и их разбора модераторами
+type Moderation interface {
+	// ReportMessage - пожаловаться на сообщение
+	ReportMessage(in dto.ReportAdd, messageID int64, userID int) (int64, error)
+	// GetReports - очередь жалоб для модератора
+	GetReports(in dto.ReportsGet, userID int) ([]entity.Report, error)
+	// Moderate - действие модератора по жалобе
+	Moderate(in dto.ModerationAdd, reportID int64, userID int) (*entity.ModerationAction, error)
+	// GetActions - журнал действий модераторов
+	GetActions(in dto.ActionsGet, userID int) ([]entity.ModerationAction, error)
+}
+
+// Filter - интерфейс для фильтров текста сообщений
+type Filter interface {
+	// CheckText - применяем фильтры чата к тексту сообщения
+	CheckText(chatID int64, text string) (filter.Result, error)
+	// GetChatFilters - действия фильтров в чате
+	GetChatFilters(chatID int64, userID int) ([]entity.ChatFilter, error)
+	// SetChatFilter - изменить действие фильтра в чате
+	SetChatFilter(in dto.ChatFilterSet, chatID int64, userID int) error
 }
 
 // Service - собирает все наши интерфейсы в одном месте
@@ -48,13 +255,81 @@ type Service struct {
 	Authorization
 	Chat
 	Message
+	Reaction
+	Attachment
+	Mention
+	Pin
+	Scheduled
+	Draft
+	Preview
+	Realtime
+	Presence
+	Webhook
+	Incoming
+	Command
+	Poll
+	Notification
+	Block
+	Moderation
+	Filter
+	// Commands - встроенные команды, здесь можно зарегистрировать свои обработчики команд
+	Commands *CommandRegistry
 }
 
-// NewService - конструктор сервиса
-func NewService(db *db.DB) *Service {
+// NewService - конструктор сервиса, события после записи в бд публикуются в events,
+// подключения клиентов регистрируются в hub, события вебхуков отправляются через sender, команды внешних ботов - через caller,
+// текст сообщений проверяется цепочкой фильтров filters
+func NewService(db *db.DB, store storage.BlobStore, fetcher preview.PreviewFetcher, sender webhook.Sender,
+	caller bot.Caller, filters *filter.Chain, events realtime.Publisher, hub *realtime.Hub, cfg *config.Config) *Service {
+	previews := NewPreviewService(db.Preview, fetcher, cfg.Previews)
+	presence := NewPresenceService(db.Presence, events, cfg.Presence)
+	messages := NewMessageService(db.Message, cfg.Messages, previews, events)
+	scheduled := NewScheduledService(db.Scheduled, messages, cfg.Scheduler, cfg.Messages)
+	polls := NewPollService(db.Poll, db.Message, events)
+	notifications := NewNotificationService(db.Notification, notifiers(cfg.Notifications), presence.IsOnline, cfg.Notifications)
+	messages.notifications = notifications
+	contentFilter := NewFilterService(db.Filter, filters)
+	messages.filters = contentFilter
+	scheduled.filters = contentFilter
+
+	// Команды отправляют сообщения через сервис сообщений, а он передаёт им сообщения с /
+	commands := NewCommandService(db.Command, caller, messages, events, cfg.Commands)
+	registerBuiltinCommands(commands.registry, scheduled, polls)
+	messages.commands = commands
+
 	return &Service{
 		Authorization: NewAuthService(db.Authorization),
-		Chat:          NewChatService(db.Chat),
-		Message:       NewMessageService(db.Message),
+		Chat:          NewChatService(db.Chat, events),
+		Message:       messages,
+		Reaction:      NewReactionService(db.Reaction),
+		Attachment:    NewAttachmentService(db.Attachment, store, cfg.Attachments),
+		Mention:       NewMentionService(db.Mention),
+		Pin:           NewPinService(db.Pin),
+		Scheduled:     scheduled,
+		Draft:         NewDraftService(db.Draft),
+		Preview:       previews,
+		Realtime:      NewRealtimeService(db.Chat, hub, presence),
+		Presence:      presence,
+		Webhook:       NewWebhookService(db.Webhook, sender, cfg.Webhooks),
+		Incoming:      NewIncomingService(db.Incoming, messages, cfg.Incoming, cfg.Messages),
+		Command:       commands,
+		Poll:          polls,
+		Notification:  notifications,
+		Block:         NewBlockService(db.Block),
+		Moderation:    NewModerationService(db.Moderation, events, cfg.Moderation),
+		Filter:        contentFilter,
+		Commands:      commands.registry,
+	}
+}
+
+// notifiers - каналы уведомлений, для которых задан адрес сервера
+func notifiers(cfg config.Notifications) []notify.Notifier {
+	var notifiers []notify.Notifier
+	if cfg.Email.Host != "" {
+		notifiers = append(notifiers, notify.NewSMTPNotifier(cfg.Email))
+	}
+	if cfg.Push.URL != "" {
+		notifiers = append(notifiers, notify.NewPushNotifier(cfg.Push))
 	}
+	return notifiers
 }
@@ -0,0 +1,270 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"service-chat/internal/apperr"
+	"service-chat/internal/config"
+	"service-chat/internal/db"
+	"service-chat/internal/db/entity"
+	"service-chat/internal/dto"
+	"service-chat/internal/logger"
+	"service-chat/internal/webhook"
+)
+
+const (
+	defaultAttemptsLimit = 20
+	// secretPrefix - по префиксу ключ подписи вебхука легко узнать, например при поиске утёкших ключей
+	secretPrefix = "whsec_"
+	// maxAttemptErrorRunes - ограничение длины текста ошибки в истории отправки
+	maxAttemptErrorRunes = 255
+	// defaultWebhookInterval, defaultWebhookTimeout - значения, если в конфиге они не заданы
+	defaultWebhookInterval = 2 * time.Second
+	defaultWebhookTimeout  = 10 * time.Second
+)
+
+// WebhookService - исходящие вебхуки чатов. События пишутся в очередь в бд в одной транзакции
+// с сообщением, а фоновая отправка доставляет их с повторами, поэтому событие не теряется
+// ни при недоступности адреса, ни при перезапуске сервиса
+type WebhookService struct {
+	repo   db.Webhook
+	sender webhook.Sender
+	cfg    config.Webhooks
+	// now - текущее время, подменяется в тестах
+	now func() time.Time
+}
+
+func NewWebhookService(repo db.Webhook, sender webhook.Sender, cfg config.Webhooks) *WebhookService {
+	if cfg.Interval <= 0 {
+		cfg.Interval = defaultWebhookInterval
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = defaultWebhookTimeout
+	}
+
+	return &WebhookService{
+		repo:   repo,
+		sender: sender,
+		cfg:    cfg,
+		now:    time.Now,
+	}
+}
+
+// AddWebhook - создаём вебхук чата, доступно администраторам чата. Ключ подписи возвращается только в ответе на создание
+func (s *WebhookService) AddWebhook(in dto.WebhookAdd, chatID int64, userID int) (*entity.Webhook, error) {
+	// Если запрос пустой
+	if chatID == 0 || userID == 0 {
+		return nil, apperr.Validation("empty chat_id or user_id")
+	}
+
+	if err := webhook.ValidateURL(in.URL); err != nil {
+		return nil, err
+	}
+
+	secret, err := webhookSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	dataDB := entity.WebhookAdd{
+		ChatID:     chatID,
+		UserID:     userID,
+		URL:        in.URL,
+		Secret:     secret,
+		MaxPerChat: s.cfg.MaxPerChat,
+	}
+	return s.repo.AddWebhook(dataDB)
+}
+
+// GetWebhooks - получаем вебхуки чата, доступно администраторам чата
+func (s *WebhookService) GetWebhooks(chatID int64, userID int) ([]entity.Webhook, error) {
+	// Если запрос пустой
+	if chatID == 0 || userID == 0 {
+		return nil, apperr.Validation("empty chat_id or user_id")
+	}
+
+	dataDB := entity.WebhookGet{
+		ChatID: chatID,
+		UserID: userID,
+	}
+	return s.repo.GetWebhooks(dataDB)
+}
+
+// DeleteWebhook - удаляем вебхук чата, неотправленные события вебхука удаляются вместе с ним
+func (s *WebhookService) DeleteWebhook(webhookID, chatID int64, userID int) error {
+	// Если запрос пустой
+	if webhookID == 0 || chatID == 0 || userID == 0 {
+		return apperr.Validation("empty webhook_id, chat_id or user_id")
+	}
+
+	dataDB := entity.WebhookDel{
+		ID:     webhookID,
+		ChatID: chatID,
+		UserID: userID,
+	}
+	return s.repo.DeleteWebhook(dataDB)
+}
+
+// GetAttempts - последние попытки отправки событий в вебхук, новые идут первыми
+func (s *WebhookService) GetAttempts(in dto.WebhookAttemptsGet, webhookID, chatID int64, userID int) ([]entity.WebhookAttempt, error) {
+	// Если запрос пустой
+	if webhookID == 0 || chatID == 0 || userID == 0 {
+		return nil, apperr.Validation("empty webhook_id, chat_id or user_id")
+	}
+
+	dataDB := entity.WebhookAttemptsGet{
+		WebhookID: webhookID,
+		ChatID:    chatID,
+		UserID:    userID,
+		Limit:     in.Limit,
+	}
+	if dataDB.Limit == 0 {
+		dataDB.Limit = defaultAttemptsLimit
+	}
+	return s.repo.GetWebhookAttempts(dataDB)
+}
+
+// Run - периодически отправляем события из очереди, пока не будет отменён контекст
+func (s *WebhookService) Run(ctx context.Context, log *slog.Logger) {
+	if s.cfg.Disabled {
+		return
+	}
+	log = log.With(slog.String("op", "service.WebhookService"))
+
+	ticker := time.NewTicker(s.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		delivered, err := s.DeliverDue(ctx)
+		if err != nil {
+			log.Error("failed to deliver webhooks", logger.Err(err))
+		}
+		if delivered > 0 {
+			log.Info("webhook events delivered", slog.Int("count", delivered))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// DeliverDue - отправляем события, время отправки которых наступило, и возвращаем количество доставленных.
+// События одной выборки отправляются параллельно, чтобы медленный адрес не задерживал остальные вебхуки.
+// После неудачи следующая попытка откладывается с экспоненциальной паузой, после MaxAttempts неудач
+// событие переводится в статус dead и больше не отправляется
+func (s *WebhookService) DeliverDue(ctx context.Context) (int, error) {
+	now := s.now()
+	jobs, err := s.repo.ClaimDeliveries(entity.WebhookClaim{
+		Now: now,
+		// Выборка отправляется параллельно, поэтому двух таймаутов запроса хватает на всю выборку
+		LeaseUntil: now.Add(2 * s.cfg.Timeout),
+		Limit:      s.cfg.BatchSize,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	results := make([]entity.WebhookResult, len(jobs))
+	var wg sync.WaitGroup
+	for i, job := range jobs {
+		wg.Add(1)
+		go func(i int, job entity.WebhookJob) {
+			defer wg.Done()
+			results[i] = s.send(ctx, job)
+		}(i, job)
+	}
+	wg.Wait()
+
+	var delivered int
+	var errs []error
+	for _, result := range results {
+		// Попытку, прерванную остановкой сервиса, не записываем: событие будет отправлено повторно после LeaseUntil
+		if result.Status != entity.DeliveryDelivered && ctx.Err() != nil {
+			continue
+		}
+		if errSave := s.repo.SaveAttempt(result); errSave != nil {
+			errs = append(errs, errSave)
+			continue
+		}
+		if result.Status == entity.DeliveryDelivered {
+			delivered++
+		}
+	}
+
+	return delivered, errors.Join(errs...)
+}
+
+// send - одна попытка отправки события и её результат
+func (s *WebhookService) send(ctx context.Context, job entity.WebhookJob) entity.WebhookResult {
+	ctx, cancel := context.WithTimeout(ctx, s.cfg.Timeout)
+	defer cancel()
+
+	start := s.now()
+	code, err := s.sender.Send(ctx, webhook.Request{
+		URL:        job.URL,
+		Secret:     job.Secret,
+		Event:      job.Event,
+		DeliveryID: job.DeliveryID,
+		Payload:    []byte(job.Payload),
+		Timestamp:  start,
+	})
+	finish := s.now()
+
+	result := entity.WebhookResult{
+		DeliveryID:    job.DeliveryID,
+		Attempt:       job.Attempt,
+		StatusCode:    code,
+		Duration:      finish.Sub(start),
+		Status:        entity.DeliveryDelivered,
+		NextAttemptAt: finish,
+	}
+	if err == nil {
+		return result
+	}
+
+	result.Error = err.Error()
+	if runes := []rune(result.Error); len(runes) > maxAttemptErrorRunes {
+		result.Error = string(runes[:maxAttemptErrorRunes])
+	}
+	if job.Attempt >= s.cfg.MaxAttempts {
+		result.Status = entity.DeliveryDead
+		return result
+	}
+	result.Status = entity.DeliveryPending
+	result.NextAttemptAt = finish.Add(s.backoff(job.Attempt))
+	return result
+}
+
+// backoff - пауза после неудачной попытки: MinBackoff, затем удваивается, но не больше MaxBackoff
+func (s *WebhookService) backoff(attempt int) time.Duration {
+	return backoff(attempt, s.cfg.MinBackoff, s.cfg.MaxBackoff)
+}
+
+// backoff - пауза после неудачной попытки номер attempt: minDelay, затем удваивается, но не больше maxDelay
+func backoff(attempt int, minDelay, maxDelay time.Duration) time.Duration {
+	delay := minDelay
+	for i := 1; i < attempt && delay < maxDelay; i++ {
+		delay *= 2
+	}
+	if maxDelay > 0 && delay > maxDelay {
+		delay = maxDelay
+	}
+	return delay
+}
+
+// webhookSecret - случайный ключ подписи вебхука
+func webhookSecret() (string, error) {
+	secret, err := randomHex(32)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate webhook secret: %w", err)
+	}
+	return secretPrefix + secret, nil
+}
@@ -0,0 +1,148 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"service-chat/internal/apperr"
+	"service-chat/internal/config"
+	"service-chat/internal/db/entity"
+	mockRepo "service-chat/internal/db/mocks"
+	"service-chat/internal/dto"
+	"service-chat/internal/webhook"
+)
+
+// fakeSender - отправитель вебхуков с заданными ответами по адресу
+type fakeSender struct {
+	mu       sync.Mutex
+	codes    map[string]int
+	requests []webhook.Request
+}
+
+func (f *fakeSender) Send(_ context.Context, req webhook.Request) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.requests = append(f.requests, req)
+
+	code := f.codes[req.URL]
+	if code < 200 || code > 299 {
+		return code, errors.New("unexpected status")
+	}
+	return code, nil
+}
+
+func TestWebhookService_AddWebhook(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWebhook := mockRepo.NewMockWebhook(ctrl)
+	serviceWebhook := NewWebhookService(mockWebhook, &fakeSender{}, config.Webhooks{MaxPerChat: 10})
+
+	// Ключ подписи создаётся сервисом
+	mockWebhook.EXPECT().AddWebhook(gomock.Any()).DoAndReturn(func(in entity.WebhookAdd) (*entity.Webhook, error) {
+		assert.Equal(t, int64(2), in.ChatID)
+		assert.Equal(t, 1, in.UserID)
+		assert.Equal(t, "https://example.com/hook", in.URL)
+		assert.Equal(t, 10, in.MaxPerChat)
+		assert.True(t, strings.HasPrefix(in.Secret, "whsec_"))
+		assert.Len(t, in.Secret, len("whsec_")+64)
+		return &entity.Webhook{ID: 3, ChatID: in.ChatID, URL: in.URL, Secret: in.Secret}, nil
+	})
+	hook, err := serviceWebhook.AddWebhook(dto.WebhookAdd{URL: "https://example.com/hook"}, 2, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), hook.ID)
+
+	_, err = serviceWebhook.AddWebhook(dto.WebhookAdd{URL: "ftp://example.com/hook"}, 2, 1)
+	assert.Equal(t, webhook.ErrInvalidURL, err)
+
+	_, err = serviceWebhook.AddWebhook(dto.WebhookAdd{URL: "https://example.com/hook"}, 0, 1)
+	assert.Equal(t, apperr.Validation("empty chat_id or user_id"), err)
+}
+
+func TestWebhookService_GetAttempts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWebhook := mockRepo.NewMockWebhook(ctrl)
+	serviceWebhook := NewWebhookService(mockWebhook, &fakeSender{}, config.Webhooks{})
+
+	// Без limit отдаём страницу по умолчанию
+	mockWebhook.EXPECT().GetWebhookAttempts(entity.WebhookAttemptsGet{WebhookID: 3, ChatID: 2, UserID: 1, Limit: 20}).
+		Return([]entity.WebhookAttempt{{DeliveryID: 5, Attempt: 1}}, nil)
+	attempts, err := serviceWebhook.GetAttempts(dto.WebhookAttemptsGet{}, 3, 2, 1)
+	assert.NoError(t, err)
+	assert.Len(t, attempts, 1)
+
+	_, err = serviceWebhook.GetAttempts(dto.WebhookAttemptsGet{}, 0, 2, 1)
+	assert.Equal(t, apperr.Validation("empty webhook_id, chat_id or user_id"), err)
+}
+
+func TestWebhookService_DeliverDue(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	now := time.Date(2030, 1, 1, 9, 0, 0, 0, time.UTC)
+	mockWebhook := mockRepo.NewMockWebhook(ctrl)
+	sender := &fakeSender{codes: map[string]int{"https://ok.example.com": 200, "https://down.example.com": 503}}
+	serviceWebhook := NewWebhookService(mockWebhook, sender, config.Webhooks{
+		BatchSize:   10,
+		Timeout:     5 * time.Second,
+		MaxAttempts: 3,
+		MinBackoff:  10 * time.Second,
+		MaxBackoff:  time.Minute,
+	})
+	serviceWebhook.now = func() time.Time { return now }
+
+	mockWebhook.EXPECT().ClaimDeliveries(entity.WebhookClaim{Now: now, LeaseUntil: now.Add(10 * time.Second), Limit: 10}).
+		Return([]entity.WebhookJob{
+			{DeliveryID: 1, URL: "https://ok.example.com", Secret: "s1", Event: "message.created", Payload: `{"a":1}`, Attempt: 1},
+			{DeliveryID: 2, URL: "https://down.example.com", Secret: "s2", Event: "message.created", Payload: `{"a":2}`, Attempt: 2},
+			{DeliveryID: 3, URL: "https://down.example.com", Secret: "s2", Event: "message.created", Payload: `{"a":3}`, Attempt: 3},
+		}, nil)
+
+	var saved []entity.WebhookResult
+	mockWebhook.EXPECT().SaveAttempt(gomock.Any()).DoAndReturn(func(in entity.WebhookResult) error {
+		saved = append(saved, in)
+		return nil
+	}).Times(3)
+
+	delivered, err := serviceWebhook.DeliverDue(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, delivered)
+	assert.Len(t, sender.requests, 3)
+
+	assert.Equal(t, []entity.WebhookResult{
+		{DeliveryID: 1, Attempt: 1, StatusCode: 200, Status: entity.DeliveryDelivered, NextAttemptAt: now},
+		// Вторая неудача: пауза удваивается
+		{DeliveryID: 2, Attempt: 2, StatusCode: 503, Error: "unexpected status", Status: entity.DeliveryPending,
+			NextAttemptAt: now.Add(20 * time.Second)},
+		// Попытки исчерпаны
+		{DeliveryID: 3, Attempt: 3, StatusCode: 503, Error: "unexpected status", Status: entity.DeliveryDead, NextAttemptAt: now},
+	}, saved)
+
+	// Ошибка выборки возвращается без отправки
+	mockWebhook.EXPECT().ClaimDeliveries(gomock.Any()).Return(nil, errors.New("connection refused"))
+	_, err = serviceWebhook.DeliverDue(context.Background())
+	assert.Equal(t, errors.New("connection refused"), err)
+}
+
+func TestWebhookService_Backoff(t *testing.T) {
+	serviceWebhook := NewWebhookService(nil, nil, config.Webhooks{MinBackoff: 10 * time.Second, MaxBackoff: time.Minute})
+
+	for attempt, want := range map[int]time.Duration{
+		1: 10 * time.Second,
+		2: 20 * time.Second,
+		3: 40 * time.Second,
+		4: time.Minute,
+		9: time.Minute,
+	} {
+		assert.Equal(t, want, serviceWebhook.backoff(attempt), "attempt %d", attempt)
+	}
+}
@@ -0,0 +1,95 @@
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// LocalStore - хранилище файлов в локальной файловой системе
+type LocalStore struct {
+	dir string
+}
+
+// NewLocalStore - конструктор локального хранилища, директория создаётся при необходимости
+func NewLocalStore(dir string) (*LocalStore, error) {
+	const op = "storage.NewLocalStore"
+
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("error path: %s, error: %w", op, err)
+	}
+
+	return &LocalStore{dir: dir}, nil
+}
+
+// Put - записываем файл сначала во временный файл, затем переименовываем, чтобы не оставлять обрезанных файлов
+func (s *LocalStore) Put(key string, r io.Reader, _ int64, _ string) error {
+	const op = "storage.LocalStore.Put"
+
+	if !validKey(key) {
+		return fmt.Errorf("error path: %s, error: invalid key %q", op, key)
+	}
+
+	path := filepath.Join(s.dir, filepath.FromSlash(key))
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return fmt.Errorf("error path: %s, error: %w", op, err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return fmt.Errorf("error path: %s, error: %w", op, err)
+	}
+
+	if _, err = io.Copy(tmp, r); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("error path: %s, error: %w", op, err)
+	}
+	if err = tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("error path: %s, error: %w", op, err)
+	}
+
+	if err = os.Rename(tmp.Name(), path); err != nil {
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("error path: %s, error: %w", op, err)
+	}
+
+	return nil
+}
+
+// Get - открываем файл на чтение
+func (s *LocalStore) Get(key string) (io.ReadCloser, error) {
+	const op = "storage.LocalStore.Get"
+
+	if !validKey(key) {
+		return nil, fmt.Errorf("error path: %s, error: invalid key %q", op, key)
+	}
+
+	f, err := os.Open(filepath.Join(s.dir, filepath.FromSlash(key)))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNotFound
+	} else if err != nil {
+		return nil, fmt.Errorf("error path: %s, error: %w", op, err)
+	}
+
+	return f, nil
+}
+
+// Delete - удаляем файл
+func (s *LocalStore) Delete(key string) error {
+	const op = "storage.LocalStore.Delete"
+
+	if !validKey(key) {
+		return fmt.Errorf("error path: %s, error: invalid key %q", op, key)
+	}
+
+	err := os.Remove(filepath.Join(s.dir, filepath.FromSlash(key)))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("error path: %s, error: %w", op, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,50 @@
+package storage
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocalStore(t *testing.T) {
+	// Создаём хранилище во временной директории
+	store, err := NewLocalStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Сохраняем и читаем объект
+	assert.Nil(t, store.Put("messages/1/abc", strings.NewReader("hello"), 5, "text/plain"))
+
+	body, err := store.Get("messages/1/abc")
+	assert.Nil(t, err)
+	data, _ := io.ReadAll(body)
+	body.Close()
+	assert.Equal(t, "hello", string(data))
+
+	// Удаляем объект, повторное удаление ошибкой не считается
+	assert.Nil(t, store.Delete("messages/1/abc"))
+	assert.Nil(t, store.Delete("messages/1/abc"))
+
+	_, err = store.Get("messages/1/abc")
+	assert.Equal(t, ErrNotFound, err)
+}
+
+func TestLocalStore_InvalidKey(t *testing.T) {
+	store, err := NewLocalStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Ключи не должны позволять выйти за пределы директории хранилища
+	for _, key := range []string{"", "/etc/passwd", "../secret", "a/../../b", "a//b", `a\b`} {
+		t.Run(key, func(t *testing.T) {
+			assert.NotNil(t, store.Put(key, strings.NewReader("x"), 1, "text/plain"))
+			_, errGet := store.Get(key)
+			assert.NotNil(t, errGet)
+			assert.NotNil(t, store.Delete(key))
+		})
+	}
+}
@@ -0,0 +1,260 @@
+package storage
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"service-chat/internal/config"
+)
+
+const (
+	s3Algorithm   = "AWS4-HMAC-SHA256"
+	s3Service     = "s3"
+	s3DateFormat  = "20060102T150405Z"
+	s3ShortFormat = "20060102"
+)
+
+// S3Store - хранилище файлов в S3-совместимом сервисе (AWS S3, MinIO и т.п.),
+// запросы подписываются по схеме AWS Signature Version 4, адресация объектов path-style
+type S3Store struct {
+	endpoint  string
+	region    string
+	bucket    string
+	accessKey string
+	secretKey string
+	client    *http.Client
+	now       func() time.Time
+}
+
+// NewS3Store - конструктор S3-совместимого хранилища
+func NewS3Store(cfg config.S3) *S3Store {
+	return &S3Store{
+		endpoint:  strings.TrimRight(cfg.Endpoint, "/"),
+		region:    cfg.Region,
+		bucket:    cfg.Bucket,
+		accessKey: cfg.AccessKey,
+		secretKey: cfg.SecretKey,
+		client:    &http.Client{Timeout: cfg.Timeout},
+		now:       time.Now,
+	}
+}
+
+// Put - загружаем объект, тело запроса целиком хешируется для подписи
+func (s *S3Store) Put(key string, r io.Reader, _ int64, contentType string) error {
+	const op = "storage.S3Store.Put"
+
+	if !validKey(key) {
+		return fmt.Errorf("error path: %s, error: invalid key %q", op, key)
+	}
+
+	body, err := io.ReadAll(r)
+	if err != nil {
+		return fmt.Errorf("error path: %s, error: %w", op, err)
+	}
+
+	resp, err := s.do(http.MethodPut, key, body, contentType)
+	if err != nil {
+		return fmt.Errorf("error path: %s, error: %w", op, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("error path: %s, error: unexpected status %d", op, resp.StatusCode)
+	}
+
+	return nil
+}
+
+// Get - скачиваем объект
+func (s *S3Store) Get(key string) (io.ReadCloser, error) {
+	const op = "storage.S3Store.Get"
+
+	if !validKey(key) {
+		return nil, fmt.Errorf("error path: %s, error: invalid key %q", op, key)
+	}
+
+	resp, err := s.do(http.MethodGet, key, nil, "")
+	if err != nil {
+		return nil, fmt.Errorf("error path: %s, error: %w", op, err)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return resp.Body, nil
+	case http.StatusNotFound:
+		resp.Body.Close()
+		return nil, ErrNotFound
+	default:
+		resp.Body.Close()
+		return nil, fmt.Errorf("error path: %s, error: unexpected status %d", op, resp.StatusCode)
+	}
+}
+
+// Delete - удаляем объект
+func (s *S3Store) Delete(key string) error {
+	const op = "storage.S3Store.Delete"
+
+	if !validKey(key) {
+		return fmt.Errorf("error path: %s, error: invalid key %q", op, key)
+	}
+
+	resp, err := s.do(http.MethodDelete, key, nil, "")
+	if err != nil {
+		return fmt.Errorf("error path: %s, error: %w", op, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNotFound {
+		return fmt.Errorf("error path: %s, error: unexpected status %d", op, resp.StatusCode)
+	}
+
+	return nil
+}
+
+// do - собираем, подписываем и отправляем запрос к объекту бакета
+func (s *S3Store) do(method, key string, body []byte, contentType string) (*http.Response, error) {
+	req, err := http.NewRequest(method, s.endpoint+"/"+s.bucket+"/"+key, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	// Сохраняем путь без изменений, чтобы подпись совпала с тем, что увидит сервер
+	req.URL.RawPath = "/" + s3EscapePath(s.bucket+"/"+key)
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	signS3Request(req, body, s.accessKey, s.secretKey, s.region, s.now().UTC())
+
+	return s.client.Do(req)
+}
+
+// signS3Request - подписываем запрос по схеме AWS Signature Version 4
+func signS3Request(req *http.Request, body []byte, accessKey, secretKey, region string, now time.Time) {
+	payloadHash := sha256Hex(body)
+	amzDate := now.Format(s3DateFormat)
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	signedHeaders, canonicalHeaders := s3CanonicalHeaders(req)
+	scope := strings.Join([]string{now.Format(s3ShortFormat), region, s3Service, "aws4_request"}, "/")
+	signature := s3Signature(req, payloadHash, signedHeaders, canonicalHeaders, secretKey, region, now)
+
+	req.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s3Algorithm, accessKey, scope, signedHeaders, signature))
+}
+
+// s3Signature - вычисляем подпись запроса
+func s3Signature(req *http.Request, payloadHash, signedHeaders, canonicalHeaders, secretKey, region string, now time.Time) string {
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		s3CanonicalQuery(req.URL.Query()),
+		canonicalHeaders,
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := strings.Join([]string{now.Format(s3ShortFormat), region, s3Service, "aws4_request"}, "/")
+	stringToSign := strings.Join([]string{
+		s3Algorithm,
+		now.Format(s3DateFormat),
+		scope,
+		sha256Hex([]byte(canonicalRequest)),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+secretKey), now.Format(s3ShortFormat))
+	key = hmacSHA256(key, region)
+	key = hmacSHA256(key, s3Service)
+	key = hmacSHA256(key, "aws4_request")
+
+	return hex.EncodeToString(hmacSHA256(key, stringToSign))
+}
+
+// s3CanonicalHeaders - подписываем host, content-type и все x-amz-* заголовки
+func s3CanonicalHeaders(req *http.Request) (string, string) {
+	headers := map[string]string{"host": req.URL.Host}
+	if req.Host != "" {
+		headers["host"] = req.Host
+	}
+	for name, values := range req.Header {
+		lower := strings.ToLower(name)
+		if lower == "content-type" || strings.HasPrefix(lower, "x-amz-") {
+			headers[lower] = strings.TrimSpace(strings.Join(values, ","))
+		}
+	}
+
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var canonical strings.Builder
+	for _, name := range names {
+		canonical.WriteString(name + ":" + headers[name] + "\n")
+	}
+
+	return strings.Join(names, ";"), canonical.String()
+}
+
+// s3CanonicalQuery - параметры запроса в отсортированном и экранированном виде
+func s3CanonicalQuery(values url.Values) string {
+	keys := make([]string, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var parts []string
+	for _, k := range keys {
+		vs := values[k]
+		sort.Strings(vs)
+		for _, v := range vs {
+			parts = append(parts, s3Escape(k, true)+"="+s3Escape(v, true))
+		}
+	}
+
+	return strings.Join(parts, "&")
+}
+
+// s3EscapePath - экранируем путь объекта, сохраняя разделители "/"
+func s3EscapePath(path string) string {
+	return s3Escape(path, false)
+}
+
+// s3Escape - экранирование по правилам SigV4: не экранируются только A-Z a-z 0-9 - _ . ~
+func s3Escape(s string, escapeSlash bool) string {
+	var b strings.Builder
+	for _, c := range []byte(s) {
+		switch {
+		case 'A' <= c && c <= 'Z', 'a' <= c && c <= 'z', '0' <= c && c <= '9',
+			c == '-', c == '_', c == '.', c == '~':
+			b.WriteByte(c)
+		case c == '/' && !escapeSlash:
+			b.WriteByte(c)
+		default:
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,118 @@
+package storage
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"service-chat/internal/config"
+)
+
+// fakeS3 - локальный заменитель S3, который проверяет подпись запросов и хранит объекты в памяти
+type fakeS3 struct {
+	mu        sync.Mutex
+	objects   map[string][]byte
+	secretKey string
+	region    string
+}
+
+var authRe = regexp.MustCompile(`^AWS4-HMAC-SHA256 Credential=([^/]+)/(\d{8})/([^/]+)/s3/aws4_request, SignedHeaders=([^,]+), Signature=([0-9a-f]{64})$`)
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+
+	// Пересчитываем подпись по полученному запросу
+	match := authRe.FindStringSubmatch(r.Header.Get("Authorization"))
+	if match == nil || match[3] != f.region || sha256Hex(body) != r.Header.Get("X-Amz-Content-Sha256") {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+	now, err := time.Parse(s3DateFormat, r.Header.Get("X-Amz-Date"))
+	if err != nil {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+	r.URL.Host = r.Host
+	signedHeaders, canonicalHeaders := s3CanonicalHeaders(r)
+	if signedHeaders != match[4] ||
+		s3Signature(r, sha256Hex(body), signedHeaders, canonicalHeaders, f.secretKey, f.region, now) != match[5] {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	switch r.Method {
+	case http.MethodPut:
+		f.objects[r.URL.Path] = body
+		w.WriteHeader(http.StatusOK)
+	case http.MethodGet:
+		obj, ok := f.objects[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write(obj)
+	case http.MethodDelete:
+		delete(f.objects, r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func TestS3Store(t *testing.T) {
+	fake := &fakeS3{objects: map[string][]byte{}, secretKey: "secret", region: "us-east-1"}
+	srv := httptest.NewServer(fake)
+	defer srv.Close()
+
+	store := NewS3Store(config.S3{
+		Endpoint:  srv.URL,
+		Region:    "us-east-1",
+		Bucket:    "chat",
+		AccessKey: "access",
+		SecretKey: "secret",
+		Timeout:   time.Second,
+	})
+
+	// Сохраняем и читаем объект с символами, которые требуют экранирования
+	key := "messages/1/file name+1"
+	assert.Nil(t, store.Put(key, strings.NewReader("hello"), 5, "text/plain"))
+	assert.Contains(t, fake.objects, "/chat/messages/1/file name+1")
+
+	body, err := store.Get(key)
+	assert.Nil(t, err)
+	data, _ := io.ReadAll(body)
+	body.Close()
+	assert.Equal(t, "hello", string(data))
+
+	// Удаляем объект
+	assert.Nil(t, store.Delete(key))
+	_, err = store.Get(key)
+	assert.Equal(t, ErrNotFound, err)
+}
+
+func TestS3Store_BadSignature(t *testing.T) {
+	fake := &fakeS3{objects: map[string][]byte{}, secretKey: "secret", region: "us-east-1"}
+	srv := httptest.NewServer(fake)
+	defer srv.Close()
+
+	// Неверный секретный ключ - сервер отвечает 403
+	store := NewS3Store(config.S3{
+		Endpoint:  srv.URL,
+		Region:    "us-east-1",
+		Bucket:    "chat",
+		AccessKey: "access",
+		SecretKey: "wrong",
+		Timeout:   time.Second,
+	})
+
+	err := store.Put("messages/1/abc", strings.NewReader("hello"), 5, "text/plain")
+	assert.EqualError(t, err, "error path: storage.S3Store.Put, error: unexpected status 403")
+}
@@ -0,0 +1,52 @@
+package storage
+
+import (
+	"errors"
+	"io"
+	"strings"
+
+	"service-chat/internal/config"
+)
+
+const (
+	storeLocal = "local"
+	storeS3    = "s3"
+)
+
+// ErrNotFound - объект с таким ключом отсутствует в хранилище
+var ErrNotFound = errors.New("blob not found")
+
+// BlobStore - интерфейс хранилища файлов (вложений сообщений)
+type BlobStore interface {
+	// Put - сохранить объект под ключом key
+	Put(key string, r io.Reader, size int64, contentType string) error
+	// Get - получить объект по ключу, вызывающий обязан закрыть reader
+	Get(key string) (io.ReadCloser, error)
+	// Delete - удалить объект по ключу, отсутствие объекта ошибкой не считается
+	Delete(key string) error
+}
+
+// NewBlobStore - создаём хранилище файлов согласно конфигу
+func NewBlobStore(cfg config.Attachments) (BlobStore, error) {
+	switch cfg.Store {
+	case storeLocal:
+		return NewLocalStore(cfg.LocalDir)
+	case storeS3:
+		return NewS3Store(cfg.S3), nil
+	default:
+		return nil, errors.New("unknown blob store: " + cfg.Store)
+	}
+}
+
+// validKey - ключ не должен позволять выйти за пределы хранилища
+func validKey(key string) bool {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, "\\") {
+		return false
+	}
+	for _, part := range strings.Split(key, "/") {
+		if part == "" || part == "." || part == ".." {
+			return false
+		}
+	}
+	return true
+}
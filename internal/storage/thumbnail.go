@@ -0,0 +1,56 @@
+package storage
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	"image/png"
+)
+
+// ThumbnailType - MIME тип миниатюр, миниатюры всегда сохраняются в PNG
+const ThumbnailType = "image/png"
+
+// Thumbnail - уменьшаем изображение так, чтобы большая сторона не превышала maxSide,
+// маленькие изображения не увеличиваются
+func Thumbnail(data []byte, maxSide int) ([]byte, error) {
+	const op = "storage.Thumbnail"
+
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("error path: %s, error: %w", op, err)
+	}
+
+	bounds := src.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width == 0 || height == 0 {
+		return nil, fmt.Errorf("error path: %s, error: empty image", op)
+	}
+
+	// Сохраняем пропорции исходного изображения
+	dstW, dstH := width, height
+	if width > maxSide || height > maxSide {
+		if width >= height {
+			dstW, dstH = maxSide, max(1, height*maxSide/width)
+		} else {
+			dstW, dstH = max(1, width*maxSide/height), maxSide
+		}
+	}
+
+	// Масштабируем методом ближайшего соседа
+	dst := image.NewRGBA(image.Rect(0, 0, dstW, dstH))
+	for y := 0; y < dstH; y++ {
+		srcY := bounds.Min.Y + y*height/dstH
+		for x := 0; x < dstW; x++ {
+			dst.Set(x, y, src.At(bounds.Min.X+x*width/dstW, srcY))
+		}
+	}
+
+	var buf bytes.Buffer
+	if err = png.Encode(&buf, dst); err != nil {
+		return nil, fmt.Errorf("error path: %s, error: %w", op, err)
+	}
+
+	return buf.Bytes(), nil
+}
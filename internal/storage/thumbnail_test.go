@@ -0,0 +1,52 @@
+package storage
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestThumbnail(t *testing.T) {
+	tests := []struct {
+		name          string
+		width, height int
+		maxSide       int
+		wantW, wantH  int
+	}{
+		{name: "Landscape", width: 400, height: 200, maxSide: 100, wantW: 100, wantH: 50},
+		{name: "Portrait", width: 200, height: 400, maxSide: 100, wantW: 50, wantH: 100},
+		{name: "Small image is not enlarged", width: 20, height: 10, maxSide: 100, wantW: 20, wantH: 10},
+		{name: "Very thin image", width: 1000, height: 1, maxSide: 100, wantW: 100, wantH: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Готовим исходное изображение
+			src := image.NewRGBA(image.Rect(0, 0, tt.width, tt.height))
+			for x := 0; x < tt.width; x++ {
+				src.Set(x, 0, color.RGBA{R: 255, A: 255})
+			}
+			var buf bytes.Buffer
+			if err := png.Encode(&buf, src); err != nil {
+				t.Fatal(err)
+			}
+
+			// Проверяем размеры миниатюры
+			thumb, err := Thumbnail(buf.Bytes(), tt.maxSide)
+			assert.Nil(t, err)
+			cfg, err := png.DecodeConfig(bytes.NewReader(thumb))
+			assert.Nil(t, err)
+			assert.Equal(t, tt.wantW, cfg.Width)
+			assert.Equal(t, tt.wantH, cfg.Height)
+		})
+	}
+}
+
+func TestThumbnail_NotImage(t *testing.T) {
+	_, err := Thumbnail([]byte("not an image"), 100)
+	assert.NotNil(t, err)
+}
@@ -42,7 +42,12 @@ func BaseValidate(log *slog.Logger, body io.ReadCloser, req interface{}) *Result
 	}
 
 	// Проверяем поля запроса на соответствие заданным правилам в dto
-	if err = validator.New().Struct(req); err != nil {
+	return StructValidate(log, req)
+}
+
+// StructValidate - проверяем поля уже заполненной структуры запроса, например из query параметров
+func StructValidate(log *slog.Logger, req interface{}) *Result {
+	if err := validator.New().Struct(req); err != nil {
 		var validateErr validator.ValidationErrors
 		match := errors.As(err, &validateErr)
 		if !match {
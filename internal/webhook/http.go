@@ -0,0 +1,69 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"service-chat/internal/config"
+	"service-chat/internal/safehttp"
+)
+
+const (
+	userAgent = "service-chat-webhook/1.0"
+	// maxResponseBody - сколько байт ответа дочитываем, чтобы соединение можно было использовать повторно
+	maxResponseBody = 64 << 10
+)
+
+// HTTPSender - отправка событий POST запросом. Соединения только с публичными адресами,
+// редиректы не выполняются, иначе вебхук мог бы перенаправить запрос во внутреннюю сеть
+type HTTPSender struct {
+	client *http.Client
+}
+
+// NewHTTPSender - конструктор отправителя событий в вебхуки
+func NewHTTPSender(cfg config.Webhooks) *HTTPSender {
+	return newHTTPSender(cfg, false)
+}
+
+// newHTTPSender - allowPrivate разрешает адреса внутренней сети, нужен только для тестов на локальном сервере
+func newHTTPSender(cfg config.Webhooks, allowPrivate bool) *HTTPSender {
+	return &HTTPSender{client: safehttp.NewClient(cfg.Timeout, allowPrivate, safehttp.NoRedirect)}
+}
+
+// Send - отправляем подписанное событие в вебхук
+func (s *HTTPSender) Send(ctx context.Context, in Request) (int, error) {
+	const op = "webhook.HTTPSender.Send"
+
+	if err := ValidateURL(in.URL); err != nil {
+		return 0, fmt.Errorf("error path: %s, error: %w", op, err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, in.URL, bytes.NewReader(in.Payload))
+	if err != nil {
+		return 0, fmt.Errorf("error path: %s, error: %w", op, err)
+	}
+	timestamp := in.Timestamp.Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set(HeaderEvent, in.Event)
+	req.Header.Set(HeaderDelivery, strconv.FormatInt(in.DeliveryID, 10))
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(HeaderSignature, Sign(in.Secret, timestamp, in.Payload))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("error path: %s, error: %w", op, err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxResponseBody))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("error path: %s, error: unexpected status %d", op, resp.StatusCode)
+	}
+
+	return resp.StatusCode, nil
+}
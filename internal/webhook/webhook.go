@@ -0,0 +1,62 @@
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// Заголовки запроса в вебхук
+const (
+	HeaderEvent     = "X-Webhook-Event"
+	HeaderDelivery  = "X-Webhook-Delivery"
+	HeaderTimestamp = "X-Webhook-Timestamp"
+	HeaderSignature = "X-Webhook-Signature"
+)
+
+// maxURLLength - ограничение длины адреса вебхука
+const maxURLLength = 2048
+
+// ErrInvalidURL - адрес вебхука не http(s) адрес или содержит данные авторизации
+var ErrInvalidURL = errors.New("webhook url must be an absolute http or https url without credentials")
+
+// Request - событие для отправки в вебхук
+type Request struct {
+	URL        string
+	Secret     string
+	Event      string
+	DeliveryID int64
+	Payload    []byte
+	Timestamp  time.Time
+}
+
+// Sender - интерфейс отправки события в вебхук
+type Sender interface {
+	// Send - отправляем событие и возвращаем код ответа, 0 - ответ не получен.
+	// Ответ с кодом не из 2xx тоже ошибка
+	Send(ctx context.Context, req Request) (int, error)
+}
+
+// Sign - подпись запроса: HMAC-SHA256 ключом вебхука от строки "timestamp.body".
+// Получатель проверяет подпись и отбрасывает запросы со старым timestamp, чтобы их нельзя было повторить
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// ValidateURL - проверяем адрес вебхука при создании. Адреса внутренней сети отсекаются при соединении
+func ValidateURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil || len(raw) > maxURLLength || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" || u.User != nil {
+		return ErrInvalidURL
+	}
+	return nil
+}
@@ -2,6 +2,7 @@ package server
 
 import (
 	"context"
+	"errors"
 	"net/http"
 
 	"github.com/go-chi/chi/v5"
@@ -12,6 +13,8 @@ import (
 // Server - структура сервера из пакета http
 type Server struct {
 	httpServer *http.Server
+	// onShutDown - остановка долгоживущих соединений (websocket, SSE), которые сами не завершатся
+	onShutDown []func(ctx context.Context) error
 }
 
 // Run - запуск сервера
@@ -27,7 +30,19 @@ func (s *Server) Run(cfg *config.Config, router *chi.Mux) error {
 	return s.httpServer.ListenAndServe()
 }
 
-// ShutDown - остановка сервера
+// RegisterOnShutDown - добавляем функцию, которая вызывается при остановке сервера
+// до ожидания текущих запросов
+func (s *Server) RegisterOnShutDown(f func(ctx context.Context) error) {
+	s.onShutDown = append(s.onShutDown, f)
+}
+
+// ShutDown - остановка сервера. http.Server.Shutdown ждёт, пока все соединения станут неактивными,
+// а поток SSE не станет таким никогда, поэтому сначала закрываем долгоживущие соединения
 func (s *Server) ShutDown(ctx context.Context) error {
-	return s.httpServer.Shutdown(ctx)
+	var errs []error
+	for _, f := range s.onShutDown {
+		errs = append(errs, f(ctx))
+	}
+	errs = append(errs, s.httpServer.Shutdown(ctx))
+	return errors.Join(errs...)
 }
@@ -1,9 +1,13 @@
 package server
 
 import (
+	"bufio"
 	"context"
+	"errors"
+	"net"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -36,3 +40,64 @@ func TestServer_ShutDown(t *testing.T) {
 		})
 	}
 }
+
+func TestServer_ShutDownHooks(t *testing.T) {
+	errHook := errors.New("hook failed")
+
+	srv := &Server{
+		httpServer: &http.Server{
+			Addr: ":8080",
+		},
+	}
+
+	var called []string
+	srv.RegisterOnShutDown(func(ctx context.Context) error {
+		called = append(called, "first")
+		return nil
+	})
+	srv.RegisterOnShutDown(func(ctx context.Context) error {
+		called = append(called, "second")
+		return errHook
+	})
+
+	err := srv.ShutDown(context.Background())
+	assert.ErrorIs(t, err, errHook)
+	assert.Equal(t, []string{"first", "second"}, called)
+}
+
+func TestServer_ShutDownStream(t *testing.T) {
+	// Обработчик держит поток открытым, пока его не закроет функция остановки, как поток SSE
+	stop := make(chan struct{})
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		_, _ = w.Write([]byte(": connected\n\n"))
+		w.(http.Flusher).Flush()
+		<-stop
+	})
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if !assert.NoError(t, err) {
+		return
+	}
+	srv := &Server{httpServer: &http.Server{Handler: handler}}
+	go func() { _ = srv.httpServer.Serve(ln) }()
+
+	srv.RegisterOnShutDown(func(ctx context.Context) error {
+		close(stop)
+		return nil
+	})
+
+	// Открываем поток и дожидаемся первых данных
+	resp, err := http.Get("http://" + ln.Addr().String())
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer resp.Body.Close()
+	_, err = bufio.NewReader(resp.Body).ReadString('\n')
+	assert.NoError(t, err)
+
+	// Остановка не должна ждать, пока истечёт контекст
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	assert.NoError(t, srv.ShutDown(ctx))
+}